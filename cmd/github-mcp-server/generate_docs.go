@@ -64,7 +64,7 @@ func generateReadmeDocs(readmePath string) error {
 	t, _ := translations.TranslationHelper()
 
 	// Create toolset group with mock clients
-	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t)
+	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, github.DefaultDraftRules(), t)
 
 	// Generate toolsets documentation
 	toolsetsDoc := generateToolsetsDoc(tsg)
@@ -146,6 +146,22 @@ func generateToolsetsDoc(tsg *toolsets.ToolsetGroup) string {
 		lines = append(lines, fmt.Sprintf("| `%s` | %s |", name, toolset.Description))
 	}
 
+	// Aliases enable several toolsets at once, list them after the toolsets they stand for
+	var aliasNames []string
+	for name := range tsg.Aliases {
+		aliasNames = append(aliasNames, name)
+	}
+	sort.Strings(aliasNames)
+
+	for _, name := range aliasNames {
+		alias := tsg.Aliases[name]
+		members := make([]string, 0, len(alias.Toolsets))
+		for _, toolset := range alias.Toolsets {
+			members = append(members, fmt.Sprintf("`%s`", toolset))
+		}
+		lines = append(lines, fmt.Sprintf("| `%s` | %s, enables %s |", name, alias.Description, strings.Join(members, ", ")))
+	}
+
 	return strings.Join(lines, "\n")
 }
 
@@ -302,7 +318,7 @@ func generateRemoteToolsetsDoc() string {
 	t, _ := translations.TranslationHelper()
 
 	// Create toolset group with mock clients
-	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t)
+	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, github.DefaultDraftRules(), t)
 
 	// Generate table header
 	buf.WriteString("| Name           | Description                                      | API URL                                               | 1-Click Install (VS Code)                                                                                                                                                                                                 | Read-only Link                                                                                                 | 1-Click Read-only Install (VS Code)                                                                                                                                                                                                 |\n")
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/github/github-mcp-server/pkg/graphql"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// graphQLSchemaURL is the endpoint whose schema is bundled. GitHub Enterprise Server hosts whose schema
+// differs can be introspected at runtime with --graphql-schema-refresh.
+const graphQLSchemaURL = "https://api.github.com/graphql"
+
+var generateGraphQLSchemaCmd = &cobra.Command{
+	Use:   "generate-graphql-schema",
+	Short: "Generate the bundled GraphQL schema",
+	Long:  `Introspect the GitHub GraphQL API with GITHUB_PERSONAL_ACCESS_TOKEN and write the schema that graphql_query validates against to pkg/graphql.`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return generateGraphQLSchema(filepath.Join("pkg", "graphql", graphql.BundledSchemaFile))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(generateGraphQLSchemaCmd)
+}
+
+// tokenTransport authenticates the introspection with a token.
+type tokenTransport struct {
+	token string
+}
+
+func (t tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func generateGraphQLSchema(path string) error {
+	token := viper.GetString("personal_access_token")
+	if token == "" {
+		return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set, it is needed to introspect the GitHub GraphQL API")
+	}
+	client := graphql.NewClient(&http.Client{Transport: tokenTransport{token: token}}, graphQLSchemaURL)
+	data, err := client.Introspect(context.Background())
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := graphql.WriteBundledSchema(&buf, data); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil { //nolint:gosec // the schema is checked in
+		return fmt.Errorf("failed to write GraphQL schema: %w", err)
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "Wrote the GraphQL schema of %s to %s\n", graphQLSchemaURL, path)
+	return nil
+}
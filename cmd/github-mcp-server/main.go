@@ -1,15 +1,20 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/github/github-mcp-server/pkg/auth"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
+	"github.com/github/github-mcp-server/pkg/retry"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -20,6 +25,10 @@ var version = "version"
 var commit = "commit"
 var date = "date"
 
+// oauthClientID is the client ID of the OAuth app `auth login` uses when none is configured, it may be
+// set by the build process using ldflags.
+var oauthClientID = ""
+
 var (
 	rootCmd = &cobra.Command{
 		Use:     "server",
@@ -33,32 +42,262 @@ var (
 		Short: "Start stdio server",
 		Long:  `Start a server that communicates via standard input/output streams using JSON-RPC messages.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			token := viper.GetString("personal_access_token")
-			if token == "" {
-				return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
+			stdioServerConfig, err := serverConfig(!viper.GetBool("token_passthrough"))
+			if err != nil {
+				return err
 			}
+			return ghmcp.RunStdioServer(stdioServerConfig)
+		},
+	}
 
-			var enabledToolsets []string
-			if err := viper.UnmarshalKey("toolsets", &enabledToolsets); err != nil {
-				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
+	sseCmd = &cobra.Command{
+		Use:    "sse",
+		Short:  "Start SSE server",
+		Long:   `Start an HTTP server that remote MCP clients connect to using the MCP SSE transport.`,
+		PreRun: bindListenFlags,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			stdioServerConfig, err := serverConfig(!viper.GetBool("token_passthrough"))
+			if err != nil {
+				return err
 			}
+			return ghmcp.RunSSEServer(ghmcp.SSEServerConfig{
+				StdioServerConfig: stdioServerConfig,
+				ListenAddr:        viper.GetString("listen_addr"),
+				BaseURL:           viper.GetString("base_url"),
+				TLSCertFile:       viper.GetString("tls_cert"),
+				TLSKeyFile:        viper.GetString("tls_key"),
+				ShutdownTimeout:   viper.GetDuration("shutdown_timeout"),
+			})
+		},
+	}
 
-			stdioServerConfig := ghmcp.StdioServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
+	httpCmd = &cobra.Command{
+		Use:    "http",
+		Short:  "Start streamable HTTP server",
+		Long:   `Start an HTTP server that remote MCP clients connect to using the MCP streamable HTTP transport.`,
+		PreRun: bindListenFlags,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			requireAuthorization := viper.GetBool("require_authorization")
+			// Without a token of their own, requests act with the configured one
+			stdioServerConfig, err := serverConfig(!requireAuthorization && !viper.GetBool("token_passthrough"))
+			if err != nil {
+				return err
 			}
-			return ghmcp.RunStdioServer(stdioServerConfig)
+			return ghmcp.RunHTTPServer(ghmcp.HTTPServerConfig{
+				StdioServerConfig:    stdioServerConfig,
+				ListenAddr:           viper.GetString("listen_addr"),
+				TLSCertFile:          viper.GetString("tls_cert"),
+				TLSKeyFile:           viper.GetString("tls_key"),
+				RequireAuthorization: requireAuthorization,
+				SessionIdleTimeout:   viper.GetDuration("session_idle_timeout"),
+				ShutdownTimeout:      viper.GetDuration("shutdown_timeout"),
+				StreamLists:          viper.GetBool("stream_lists"),
+			})
+		},
+	}
+
+	authCmd = &cobra.Command{
+		Use:   "auth",
+		Short: "Manage authentication",
+		Long:  `Log in to GitHub to store a token in the OS keychain, which the server uses when GITHUB_PERSONAL_ACCESS_TOKEN is not set.`,
+	}
+
+	authLoginCmd = &cobra.Command{
+		Use:   "login",
+		Short: "Log in with the OAuth device flow",
+		Long:  `Authorize the server in the browser with a one-time code and store the resulting token in the OS keychain.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return authLogin()
+		},
+	}
+
+	authLogoutCmd = &cobra.Command{
+		Use:   "logout",
+		Short: "Remove the stored token",
+		Long:  `Remove the token stored by auth login from the OS keychain. The token itself stays valid until it is revoked on GitHub.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			webURL, err := auth.WebURL(viper.GetString("host"))
+			if err != nil {
+				return err
+			}
+			deleted, err := auth.DeleteToken(webURL.Hostname())
+			if err != nil {
+				return err
+			}
+			if !deleted {
+				_, _ = fmt.Fprintf(os.Stderr, "No token is stored for %s\n", webURL.Host)
+				return nil
+			}
+			_, _ = fmt.Fprintf(os.Stderr, "Removed the token for %s from the keychain\n", webURL.Host)
+			return nil
 		},
 	}
 )
 
+// authLogin acquires a token with the OAuth device flow and stores it in the OS keychain.
+func authLogin() error {
+	webURL, err := auth.WebURL(viper.GetString("host"))
+	if err != nil {
+		return err
+	}
+	clientID := viper.GetString("oauth_client_id")
+	if clientID == "" {
+		return errors.New("no OAuth app client ID is configured, set --client-id or GITHUB_OAUTH_CLIENT_ID to the client ID of an OAuth app with the device flow enabled")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	flow := &auth.DeviceFlow{
+		BaseURL:  webURL,
+		ClientID: clientID,
+		Scopes:   viper.GetStringSlice("oauth_scopes"),
+	}
+	code, err := flow.RequestCode(ctx)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "First copy your one-time code: %s\nThen open %s in your browser to authorize the GitHub MCP Server.\nWaiting for authorization...\n", code.UserCode, code.VerificationURI)
+
+	token, err := flow.Wait(ctx, code)
+	if err != nil {
+		return err
+	}
+	if err := auth.StoreToken(webURL.Hostname(), token); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "Logged in to %s, the token is stored in the keychain\n", webURL.Host)
+	return nil
+}
+
+// addListenFlags adds the flags of the commands that serve MCP over the network.
+func addListenFlags(cmd *cobra.Command, transport string) {
+	cmd.Flags().String("listen-addr", "localhost:8080", fmt.Sprintf("Address the %s server listens on", transport))
+	cmd.Flags().String("tls-cert", "", "Path to the TLS certificate, serves HTTPS together with --tls-key")
+	cmd.Flags().String("tls-key", "", "Path to the TLS private key, serves HTTPS together with --tls-cert")
+	cmd.Flags().Duration("shutdown-timeout", 10*time.Second, "How long open connections get to finish when the server shuts down")
+}
+
+// bindListenFlags binds the flags added by addListenFlags. Several commands have them, so they are bound
+// once it is known which command runs.
+func bindListenFlags(cmd *cobra.Command, _ []string) {
+	_ = viper.BindPFlag("listen_addr", cmd.Flags().Lookup("listen-addr"))
+	_ = viper.BindPFlag("tls_cert", cmd.Flags().Lookup("tls-cert"))
+	_ = viper.BindPFlag("tls_key", cmd.Flags().Lookup("tls-key"))
+	_ = viper.BindPFlag("shutdown_timeout", cmd.Flags().Lookup("shutdown-timeout"))
+}
+
+// serverConfig reads the server options shared by all transports from the flags, environment and config
+// file. requireToken is unset when requests bring their own token, a configured one is then optional.
+func serverConfig(requireToken bool) (ghmcp.StdioServerConfig, error) {
+	// Flags and environment variables take precedence over the file
+	if path := viper.GetString("config"); path != "" {
+		viper.SetConfigFile(path)
+		if err := viper.ReadInConfig(); err != nil {
+			return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	token := viper.GetString("personal_access_token")
+	// A GitHub App installation authenticates with the tokens it mints instead
+	appInstallationID := viper.GetInt64("app_installation_id")
+	if token == "" && requireToken && appInstallationID == 0 {
+		// Fall back to the token stored by `auth login`
+		webURL, err := auth.WebURL(viper.GetString("host"))
+		if err != nil {
+			return ghmcp.StdioServerConfig{}, err
+		}
+		token, err = auth.LoadToken(webURL.Hostname())
+		if err != nil {
+			return ghmcp.StdioServerConfig{}, fmt.Errorf("GITHUB_PERSONAL_ACCESS_TOKEN not set, and the keychain with the token of `auth login` could not be read: %w", err)
+		}
+		if token == "" {
+			return ghmcp.StdioServerConfig{}, errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set, set it or log in with `github-mcp-server auth login`")
+		}
+	}
+
+	var enabledToolsets []string
+	if err := viper.UnmarshalKey("toolsets", &enabledToolsets); err != nil {
+		return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal toolsets: %w", err)
+	}
+	var enabledTools, excludedTools []string
+	if err := viper.UnmarshalKey("tools", &enabledTools); err != nil {
+		return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal tools: %w", err)
+	}
+	if err := viper.UnmarshalKey("exclude_tools", &excludedTools); err != nil {
+		return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal excluded tools: %w", err)
+	}
+
+	// Unmarshalled like the toolsets, so that GITHUB_API_ALLOWLIST is split on commas rather than spaces
+	var apiAllowlist []string
+	if err := viper.UnmarshalKey("api_allowlist", &apiAllowlist); err != nil {
+		return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal API allowlist: %w", err)
+	}
+	var allowedRepos, blockedRepos []string
+	if err := viper.UnmarshalKey("allowed_repos", &allowedRepos); err != nil {
+		return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal allowed repositories: %w", err)
+	}
+	if err := viper.UnmarshalKey("blocked_repos", &blockedRepos); err != nil {
+		return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to unmarshal blocked repositories: %w", err)
+	}
+
+	return ghmcp.StdioServerConfig{
+		Version:               version,
+		Host:                  viper.GetString("host"),
+		Token:                 token,
+		EnabledToolsets:       enabledToolsets,
+		EnabledTools:          enabledTools,
+		ExcludedTools:         excludedTools,
+		DynamicToolsets:       viper.GetBool("dynamic_toolsets"),
+		ReadOnly:              viper.GetBool("read-only"),
+		DryRun:                viper.GetBool("dry_run"),
+		ConfirmDestructive:    viper.GetBool("confirm_destructive"),
+		ExportTranslations:    viper.GetBool("export-translations"),
+		EnableCommandLogging:  viper.GetBool("enable-command-logging"),
+		LogFilePath:           viper.GetString("log-file"),
+		LogLevel:              viper.GetString("log_level"),
+		LogFormat:             viper.GetString("log_format"),
+		LogRotate:             viper.GetBool("log_rotate"),
+		LogMaxSizeMB:          viper.GetInt("log_max_size"),
+		LogMaxBackups:         viper.GetInt("log_max_backups"),
+		LogMaxAge:             viper.GetDuration("log_max_age"),
+		MaxIdleConns:          viper.GetInt("max_idle_conns"),
+		MaxIdleConnsPerHost:   viper.GetInt("max_idle_conns_per_host"),
+		IdleConnTimeout:       viper.GetDuration("idle_conn_timeout"),
+		DisableKeepAlives:     viper.GetBool("disable_keep_alives"),
+		RateLimitMaxWait:      viper.GetDuration("rate_limit_max_wait"),
+		MaxRetries:            viper.GetInt("max_retries"),
+		MaxResponseTokens:     viper.GetInt("max_response_tokens"),
+		CacheMaxSizeMB:        viper.GetInt("cache_max_size"),
+		CacheDir:              viper.GetString("cache_dir"),
+		IncludeProvenance:     viper.GetBool("include_provenance"),
+		RelativeTimes:         viper.GetBool("relative_times"),
+		ParseReferences:       viper.GetBool("parse_references"),
+		DraftRulesPath:        viper.GetString("draft_rules"),
+		PolicyPath:            viper.GetString("policy"),
+		AllowedRepos:          allowedRepos,
+		BlockedRepos:          blockedRepos,
+		AuditLogPath:          viper.GetString("audit_log"),
+		MetricsAddr:           viper.GetString("metrics_addr"),
+		OTLPEndpoint:          viper.GetString("otlp_endpoint"),
+		AppID:                 viper.GetInt64("app_id"),
+		AppPrivateKeyPath:     viper.GetString("app_private_key_path"),
+		AppInstallationID:     appInstallationID,
+		DisableTelemetry:      viper.GetBool("disable_telemetry"),
+		NoExternalEgress:      viper.GetBool("no_external_egress"),
+		ImpersonateUser:       viper.GetString("impersonate_user"),
+		ImpersonateScopes:     viper.GetStringSlice("impersonate_scopes"),
+		TokenPassthrough:      viper.GetBool("token_passthrough"),
+		GraphQLQuery:          viper.GetBool("graphql_query"),
+		GraphQLMaxCost:        viper.GetInt("graphql_max_cost"),
+		GraphQLAllowMutations: viper.GetBool("graphql_allow_mutations"),
+		GraphQLSchemaRefresh:  viper.GetBool("graphql_schema_refresh"),
+		APIRequest:            viper.GetBool("api_request"),
+		APIAllowlist:          apiAllowlist,
+		ResourcePollInterval:  viper.GetDuration("resource_poll_interval"),
+	}, nil
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 	rootCmd.SetGlobalNormalizationFunc(wordSepNormalizeFunc)
@@ -66,22 +305,135 @@ func init() {
 	rootCmd.SetVersionTemplate("{{.Short}}\n{{.Version}}\n")
 
 	rootCmd.PersistentFlags().StringSlice("toolsets", github.DefaultTools, "An optional comma separated list of groups of tools to allow, defaults to enabling all")
+	rootCmd.PersistentFlags().StringSlice("tools", nil, "An optional comma separated list of tools to allow, narrowing the enabled toolsets down to them")
+	rootCmd.PersistentFlags().StringSlice("exclude-tools", nil, "An optional comma separated list of tools to withhold, even from the enabled toolsets")
+	rootCmd.PersistentFlags().String("config", "", "Path to a YAML, JSON or TOML file with settings, named like their GITHUB_ environment variables without the prefix, such as toolsets or exclude_tools")
 	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "Enable dynamic toolsets")
 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
+	rootCmd.PersistentFlags().Bool("dry-run", false, "Make write tools describe the first GitHub API request that would change something instead of sending it")
+	rootCmd.PersistentFlags().Bool("confirm-destructive", false, "Require destructive tools to be called again with a one-time confirmation token before they run")
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
+	rootCmd.PersistentFlags().String("log-level", "info", "Level of the records that are logged: debug, info, warn or error")
+	rootCmd.PersistentFlags().String("log-format", "text", "Format of the log: text for key=value pairs, or json for a JSON object per line")
+	rootCmd.PersistentFlags().Bool("log-rotate", false, "Rotate the log file once it grows beyond --log-max-size")
+	rootCmd.PersistentFlags().Int("log-max-size", 100, "Size in megabytes above which the log file is rotated")
+	rootCmd.PersistentFlags().Int("log-max-backups", 5, "Number of rotated log files to keep, 0 keeps all of them")
+	rootCmd.PersistentFlags().Duration("log-max-age", 0, "How long rotated log files are kept, 0 keeps them regardless of age")
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
+	rootCmd.PersistentFlags().String("audit-log", "", "Path to a file that every tool call is appended to as a line of JSON with secrets redacted, or syslog to send them to the local syslog daemon")
+	rootCmd.PersistentFlags().String("metrics-addr", "", "Address to serve Prometheus metrics of tool calls, GitHub API requests, rate limits, the cache and sessions at, such as localhost:9090")
+	rootCmd.PersistentFlags().String("otlp-endpoint", "", "OTLP/HTTP collector to export OpenTelemetry traces of tool calls and GitHub API requests to, such as http://localhost:4318. Defaults to OTEL_EXPORTER_OTLP_ENDPOINT")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
+	rootCmd.PersistentFlags().Int("max-idle-conns", 100, "Maximum number of idle (keep-alive) connections kept open across all hosts")
+	rootCmd.PersistentFlags().Int("max-idle-conns-per-host", 10, "Maximum number of idle (keep-alive) connections kept open per host")
+	rootCmd.PersistentFlags().Duration("idle-conn-timeout", 90*time.Second, "How long an idle connection is kept open before being closed")
+	rootCmd.PersistentFlags().Bool("disable-keep-alives", false, "Disable HTTP keep-alives and use a new connection for every request")
+	rootCmd.PersistentFlags().Duration("rate-limit-max-wait", ratelimit.DefaultMaxWait, "How long a request waits at most for the GitHub API rate limit to reset before failing, 0 to fail right away")
+	rootCmd.PersistentFlags().Int("max-retries", retry.DefaultMaxRetries, "How many times a read that failed for a transient reason, such as a 502 or a reset connection, is retried, 0 to never retry")
+	rootCmd.PersistentFlags().Int("max-response-tokens", 0, "Size of tool results in tokens beyond which they are truncated, with a cursor to fetch the rest, such as 25000 for clients with small context windows, 0 to leave results whole")
+	rootCmd.PersistentFlags().Int("cache-max-size", 0, "Size in megabytes of the cache of GitHub API responses that requests are made conditional on with their ETags, such as 64, 0 to disable it")
+	rootCmd.PersistentFlags().String("cache-dir", "", "Directory to keep the cache of GitHub API responses in across restarts, instead of in memory")
+	rootCmd.PersistentFlags().Bool("include-provenance", false, "Attach the GitHub request IDs and API endpoints behind each tool result under _meta.provenance")
+	rootCmd.PersistentFlags().Bool("relative-times", false, "Add a *_relative companion such as \"3 days ago\" next to timestamps in tool results")
+	rootCmd.PersistentFlags().Bool("parse-references", true, "Accept GitHub URLs and owner/repo#123 references in place of the owner, repo and number inputs of tools")
+	rootCmd.PersistentFlags().String("draft-rules", "", "Path to a YAML or JSON file with the rules validate_issue_draft and validate_pull_request_draft apply")
+	rootCmd.PersistentFlags().String("policy", "", "Path to a YAML or JSON file with rules that allow or deny calls of write tools by tool, repository, branch and arguments")
+	rootCmd.PersistentFlags().StringSlice("allowed-repos", nil, "An optional comma separated list of owner/repo patterns, such as octo-org/*, that confine every tool, resource and prompt to the repositories they match")
+	rootCmd.PersistentFlags().StringSlice("blocked-repos", nil, "An optional comma separated list of owner/repo patterns of repositories that no tool, resource or prompt may touch")
+	rootCmd.PersistentFlags().Int64("app-id", 0, "GitHub App ID, enables the app toolset together with --app-private-key-path")
+	rootCmd.PersistentFlags().String("app-private-key-path", "", "Path to the PEM encoded private key of the GitHub App")
+	rootCmd.PersistentFlags().Int64("app-installation-id", 0, "Authenticate as this installation of the GitHub App instead of with GITHUB_PERSONAL_ACCESS_TOKEN")
+	rootCmd.PersistentFlags().Bool("disable-telemetry", false, "Disable any telemetry export (none is sent by default)")
+	rootCmd.PersistentFlags().Bool("no-external-egress", false, "Only allow outbound requests to the configured GitHub host")
+	rootCmd.PersistentFlags().String("impersonate-user", "", "On GitHub Enterprise Server, act as this user with an impersonation token created from the site administrator token")
+	rootCmd.PersistentFlags().Bool("token-passthrough", false, "Act with the GitHub token each tool call brings in _meta.github_token, or in the Authorization header of the sse and http transports")
+	rootCmd.PersistentFlags().Bool("graphql-query", false, "Enable the graphql toolset, which runs arbitrary GraphQL queries validated against the GitHub GraphQL schema")
+	rootCmd.PersistentFlags().Int("graphql-max-cost", github.DefaultGraphQLMaxCost, "Maximum estimated number of nodes a graphql_query operation may request")
+	rootCmd.PersistentFlags().Bool("graphql-allow-mutations", false, "Let graphql_query run mutations as well as queries, unless --read-only is set")
+	rootCmd.PersistentFlags().Bool("graphql-schema-refresh", false, "Validate graphql_query operations against the schema introspected from the host instead of the bundled one, such as for GitHub Enterprise Server")
+	rootCmd.PersistentFlags().Bool("api-request", false, "Enable the api toolset, which sends REST requests to the GitHub API that match --api-allowlist")
+	rootCmd.PersistentFlags().StringSlice("api-allowlist", github.DefaultAPIAllowlist, "Requests api_request may send, as a method (or *) and a path pattern where * matches one segment and a final ** any number, such as \"GET /repos/*/*/issues/**\"")
+	rootCmd.PersistentFlags().Duration("resource-poll-interval", github.DefaultResourcePollInterval, "How often the issues and pull requests clients subscribed to are checked for changes")
+	rootCmd.PersistentFlags().StringSlice("impersonate-scopes", nil, "OAuth scopes of the impersonation token (default repo, read:org, workflow, notifications and gist)")
 
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
+	_ = viper.BindPFlag("tools", rootCmd.PersistentFlags().Lookup("tools"))
+	_ = viper.BindPFlag("exclude_tools", rootCmd.PersistentFlags().Lookup("exclude-tools"))
+	_ = viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
 	_ = viper.BindPFlag("dynamic_toolsets", rootCmd.PersistentFlags().Lookup("dynamic-toolsets"))
 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
+	_ = viper.BindPFlag("dry_run", rootCmd.PersistentFlags().Lookup("dry-run"))
+	_ = viper.BindPFlag("confirm_destructive", rootCmd.PersistentFlags().Lookup("confirm-destructive"))
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
+	_ = viper.BindPFlag("log_level", rootCmd.PersistentFlags().Lookup("log-level"))
+	_ = viper.BindPFlag("log_format", rootCmd.PersistentFlags().Lookup("log-format"))
+	_ = viper.BindPFlag("log_rotate", rootCmd.PersistentFlags().Lookup("log-rotate"))
+	_ = viper.BindPFlag("log_max_size", rootCmd.PersistentFlags().Lookup("log-max-size"))
+	_ = viper.BindPFlag("log_max_backups", rootCmd.PersistentFlags().Lookup("log-max-backups"))
+	_ = viper.BindPFlag("log_max_age", rootCmd.PersistentFlags().Lookup("log-max-age"))
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
+	_ = viper.BindPFlag("audit_log", rootCmd.PersistentFlags().Lookup("audit-log"))
+	_ = viper.BindPFlag("metrics_addr", rootCmd.PersistentFlags().Lookup("metrics-addr"))
+	_ = viper.BindPFlag("otlp_endpoint", rootCmd.PersistentFlags().Lookup("otlp-endpoint"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
+	_ = viper.BindPFlag("max_idle_conns", rootCmd.PersistentFlags().Lookup("max-idle-conns"))
+	_ = viper.BindPFlag("max_idle_conns_per_host", rootCmd.PersistentFlags().Lookup("max-idle-conns-per-host"))
+	_ = viper.BindPFlag("idle_conn_timeout", rootCmd.PersistentFlags().Lookup("idle-conn-timeout"))
+	_ = viper.BindPFlag("disable_keep_alives", rootCmd.PersistentFlags().Lookup("disable-keep-alives"))
+	_ = viper.BindPFlag("rate_limit_max_wait", rootCmd.PersistentFlags().Lookup("rate-limit-max-wait"))
+	_ = viper.BindPFlag("max_retries", rootCmd.PersistentFlags().Lookup("max-retries"))
+	_ = viper.BindPFlag("max_response_tokens", rootCmd.PersistentFlags().Lookup("max-response-tokens"))
+	_ = viper.BindPFlag("cache_max_size", rootCmd.PersistentFlags().Lookup("cache-max-size"))
+	_ = viper.BindPFlag("cache_dir", rootCmd.PersistentFlags().Lookup("cache-dir"))
+	_ = viper.BindPFlag("include_provenance", rootCmd.PersistentFlags().Lookup("include-provenance"))
+	_ = viper.BindPFlag("relative_times", rootCmd.PersistentFlags().Lookup("relative-times"))
+	_ = viper.BindPFlag("parse_references", rootCmd.PersistentFlags().Lookup("parse-references"))
+	_ = viper.BindPFlag("draft_rules", rootCmd.PersistentFlags().Lookup("draft-rules"))
+	_ = viper.BindPFlag("policy", rootCmd.PersistentFlags().Lookup("policy"))
+	_ = viper.BindPFlag("allowed_repos", rootCmd.PersistentFlags().Lookup("allowed-repos"))
+	_ = viper.BindPFlag("blocked_repos", rootCmd.PersistentFlags().Lookup("blocked-repos"))
+	_ = viper.BindPFlag("app_id", rootCmd.PersistentFlags().Lookup("app-id"))
+	_ = viper.BindPFlag("app_private_key_path", rootCmd.PersistentFlags().Lookup("app-private-key-path"))
+	_ = viper.BindPFlag("app_installation_id", rootCmd.PersistentFlags().Lookup("app-installation-id"))
+	_ = viper.BindPFlag("disable_telemetry", rootCmd.PersistentFlags().Lookup("disable-telemetry"))
+	_ = viper.BindPFlag("no_external_egress", rootCmd.PersistentFlags().Lookup("no-external-egress"))
+	_ = viper.BindPFlag("impersonate_user", rootCmd.PersistentFlags().Lookup("impersonate-user"))
+	_ = viper.BindPFlag("impersonate_scopes", rootCmd.PersistentFlags().Lookup("impersonate-scopes"))
+	_ = viper.BindPFlag("token_passthrough", rootCmd.PersistentFlags().Lookup("token-passthrough"))
+	_ = viper.BindPFlag("graphql_query", rootCmd.PersistentFlags().Lookup("graphql-query"))
+	_ = viper.BindPFlag("graphql_max_cost", rootCmd.PersistentFlags().Lookup("graphql-max-cost"))
+	_ = viper.BindPFlag("graphql_allow_mutations", rootCmd.PersistentFlags().Lookup("graphql-allow-mutations"))
+	_ = viper.BindPFlag("graphql_schema_refresh", rootCmd.PersistentFlags().Lookup("graphql-schema-refresh"))
+	_ = viper.BindPFlag("api_request", rootCmd.PersistentFlags().Lookup("api-request"))
+	_ = viper.BindPFlag("api_allowlist", rootCmd.PersistentFlags().Lookup("api-allowlist"))
+	_ = viper.BindPFlag("resource_poll_interval", rootCmd.PersistentFlags().Lookup("resource-poll-interval"))
+
+	addListenFlags(sseCmd, "SSE")
+	sseCmd.Flags().String("base-url", "", "URL clients reach the server at, when it differs from the listen address such as behind a reverse proxy")
+	_ = viper.BindPFlag("base_url", sseCmd.Flags().Lookup("base-url"))
+
+	addListenFlags(httpCmd, "HTTP")
+	httpCmd.Flags().Bool("require-authorization", false, "Require every request to bring its own GitHub token in the Authorization header, instead of using GITHUB_PERSONAL_ACCESS_TOKEN")
+	httpCmd.Flags().Duration("session-idle-timeout", 30*time.Minute, "How long a session is kept without requests, 0 keeps sessions until the client ends them")
+	_ = viper.BindPFlag("require_authorization", httpCmd.Flags().Lookup("require-authorization"))
+	httpCmd.Flags().Bool("stream-lists", false, "Send the pages of list tools to clients that follow the progress of their calls as soon as they are fetched, up to 10 pages per call")
+	_ = viper.BindPFlag("session_idle_timeout", httpCmd.Flags().Lookup("session-idle-timeout"))
+	_ = viper.BindPFlag("stream_lists", httpCmd.Flags().Lookup("stream-lists"))
 
 	rootCmd.AddCommand(stdioCmd)
+	rootCmd.AddCommand(sseCmd)
+	rootCmd.AddCommand(httpCmd)
+
+	authLoginCmd.Flags().String("client-id", oauthClientID, "Client ID of the OAuth app to authorize, which must have the device flow enabled")
+	authLoginCmd.Flags().StringSlice("scopes", auth.DefaultScopes, "OAuth scopes to request")
+	_ = viper.BindPFlag("oauth_client_id", authLoginCmd.Flags().Lookup("client-id"))
+	_ = viper.BindPFlag("oauth_scopes", authLoginCmd.Flags().Lookup("scopes"))
+
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
+	rootCmd.AddCommand(authCmd)
 }
 
 func initConfig() {
@@ -99,194 +451,8 @@ func wordSepNormalizeFunc(_ *pflag.FlagSet, name string) pflag.NormalizedName {
 }
 
 func main() {
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
-
-	
-    // 🔍 환경 변수 디버깅 로그 추가
-    fmt.Println("DEBUG: GITHUB_PERSONAL_ACCESS_TOKEN =", os.Getenv("GITHUB_PERSONAL_ACCESS_TOKEN"))
-
-
-
-	
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		fmt.Fprintln(w, "GitHub MCP Server is running")
-	})
-
-	http.HandleFunc("/run-stdio", func(w http.ResponseWriter, r *http.Request) {
-		err := stdioCmd.RunE(stdioCmd, []string{})
-		if err != nil {
-			http.Error(w, fmt.Sprintf("Failed to run stdio server: %v", err), http.StatusInternalServerError)
-			return
-		}
-		fmt.Fprintln(w, "Stdio server started")
-	})
-
-	http.HandleFunc("/tools", toolsHandler)
-
-	fmt.Printf("Listening on port %s...\n", port)
-	if err := http.ListenAndServe(":"+port, nil); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to start HTTP server: %v\n", err)
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
 	}
-	
-	
-
 }
-
-
-type Tool struct {
-    Name        string      `json:"name"`
-    Description string      `json:"description"`
-    InputSpec   interface{} `json:"input_spec"`
-    OutputSpec  interface{} `json:"output_spec"`
-}
-
-func toolsHandler(w http.ResponseWriter, r *http.Request) {
-    tools := []Tool{
-        {
-            Name:        "Airbnb Search",
-            Description: "Search Airbnb listings",
-            InputSpec: map[string]string{
-                "location":  "string",
-                "check_in":  "date",
-                "check_out": "date",
-            },
-            OutputSpec: map[string]string{
-                "listings": "array",
-            },
-        },
-        {
-            Name:        "Airbnb Listing Details",
-            Description: "Get details for a specific listing",
-            InputSpec: map[string]string{
-                "listing_id": "string",
-            },
-            OutputSpec: map[string]string{
-                "details": "object",
-            },
-        },
-    }
-
-    w.Header().Set("Content-Type", "application/json")
-    json.NewEncoder(w).Encode(map[string]interface{}{"tools": tools})
-}
-
-
-
-
-// package main
-
-// import (
-// 	"errors"
-// 	"fmt"
-// 	"os"
-// 	"strings"
-
-// 	"github.com/github/github-mcp-server/internal/ghmcp"
-// 	"github.com/github/github-mcp-server/pkg/github"
-// 	"github.com/spf13/cobra"
-// 	"github.com/spf13/pflag"
-// 	"github.com/spf13/viper"
-// )
-
-// // These variables are set by the build process using ldflags.
-// var version = "version"
-// var commit = "commit"
-// var date = "date"
-
-// var (
-// 	rootCmd = &cobra.Command{
-// 		Use:     "server",
-// 		Short:   "GitHub MCP Server",
-// 		Long:    `A GitHub MCP server that handles various tools and resources.`,
-// 		Version: fmt.Sprintf("Version: %s\nCommit: %s\nBuild Date: %s", version, commit, date),
-// 	}
-
-// 	stdioCmd = &cobra.Command{
-// 		Use:   "stdio",
-// 		Short: "Start stdio server",
-// 		Long:  `Start a server that communicates via standard input/output streams using JSON-RPC messages.`,
-// 		RunE: func(_ *cobra.Command, _ []string) error {
-// 			token := viper.GetString("personal_access_token")
-// 			if token == "" {
-// 				return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
-// 			}
-
-// 			// If you're wondering why we're not using viper.GetStringSlice("toolsets"),
-// 			// it's because viper doesn't handle comma-separated values correctly for env
-// 			// vars when using GetStringSlice.
-// 			// https://github.com/spf13/viper/issues/380
-// 			var enabledToolsets []string
-// 			if err := viper.UnmarshalKey("toolsets", &enabledToolsets); err != nil {
-// 				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
-// 			}
-
-// 			stdioServerConfig := ghmcp.StdioServerConfig{
-// 				Version:              version,
-// 				Host:                 viper.GetString("host"),
-// 				Token:                token,
-// 				EnabledToolsets:      enabledToolsets,
-// 				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-// 				ReadOnly:             viper.GetBool("read-only"),
-// 				ExportTranslations:   viper.GetBool("export-translations"),
-// 				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-// 				LogFilePath:          viper.GetString("log-file"),
-// 			}
-// 			return ghmcp.RunStdioServer(stdioServerConfig)
-// 		},
-// 	}
-// )
-
-// func init() {
-// 	cobra.OnInitialize(initConfig)
-// 	rootCmd.SetGlobalNormalizationFunc(wordSepNormalizeFunc)
-
-// 	rootCmd.SetVersionTemplate("{{.Short}}\n{{.Version}}\n")
-
-// 	// Add global flags that will be shared by all commands
-// 	rootCmd.PersistentFlags().StringSlice("toolsets", github.DefaultTools, "An optional comma separated list of groups of tools to allow, defaults to enabling all")
-// 	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "Enable dynamic toolsets")
-// 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
-// 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
-// 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
-// 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
-// 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
-
-// 	// Bind flag to viper
-// 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
-// 	_ = viper.BindPFlag("dynamic_toolsets", rootCmd.PersistentFlags().Lookup("dynamic-toolsets"))
-// 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
-// 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
-// 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
-// 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
-// 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
-
-// 	// Add subcommands
-// 	rootCmd.AddCommand(stdioCmd)
-// }
-
-// func initConfig() {
-// 	// Initialize Viper configuration
-// 	viper.SetEnvPrefix("github")
-// 	viper.AutomaticEnv()
-
-// }
-
-// func main() {
-// 	if err := rootCmd.Execute(); err != nil {
-// 		fmt.Fprintf(os.Stderr, "%v\n", err)
-// 		os.Exit(1)
-// 	}
-// }
-
-// func wordSepNormalizeFunc(_ *pflag.FlagSet, name string) pflag.NormalizedName {
-// 	from := []string{"_"}
-// 	to := "-"
-// 	for _, sep := range from {
-// 		name = strings.ReplaceAll(name, sep, to)
-// 	}
-// 	return pflag.NormalizedName(name)
-// }
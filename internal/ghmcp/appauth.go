@@ -0,0 +1,156 @@
+package ghmcp
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	gogithub "github.com/google/go-github/v73/github"
+)
+
+// GitHub rejects app JWTs that are valid for more than 10 minutes.
+const appJWTLifetime = 9 * time.Minute
+
+// loadAppPrivateKey reads a PEM encoded RSA private key as downloaded from the GitHub App settings page.
+func loadAppPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("GitHub App private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("GitHub App private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// appJWTTransport authenticates requests as the GitHub App itself, using a short lived RS256 JWT
+// that is re-signed shortly before it expires.
+type appJWTTransport struct {
+	transport http.RoundTripper
+	appID     int64
+	key       *rsa.PrivateKey
+	now       func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func (t *appJWTTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.jwt()
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.transport.RoundTrip(req)
+}
+
+func (t *appJWTTransport) jwt() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if t.token != "" && now.Add(time.Minute).Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	// Backdate the issue time to allow for clock drift between us and GitHub.
+	expiresAt := now.Add(appJWTLifetime)
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": expiresAt.Unix(),
+		"iss": strconv.FormatInt(t.appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, t.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+
+	t.token = unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+	t.expiresAt = expiresAt
+	return t.token, nil
+}
+
+// installationTokenRefreshMargin is how long before their expiry installation tokens are replaced, so that
+// requests in flight don't run into an expired token.
+const installationTokenRefreshMargin = 5 * time.Minute
+
+// installationTokens mints the tokens that authenticate as an installation of a GitHub App. The tokens
+// expire after an hour, a new one is minted shortly before.
+type installationTokens struct {
+	// appClient authenticates as the app itself, see appJWTTransport
+	appClient      *gogithub.Client
+	installationID int64
+	now            func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func (t *installationTokens) get(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && t.now().Add(installationTokenRefreshMargin).Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	token, resp, err := t.appClient.Apps.CreateInstallationToken(ctx, t.installationID, nil)
+	if err != nil {
+		if resp != nil {
+			switch resp.StatusCode {
+			case http.StatusUnauthorized:
+				return "", fmt.Errorf("failed to create installation token: the GitHub App ID or private key is wrong: %w", err)
+			case http.StatusNotFound:
+				return "", fmt.Errorf("failed to create installation token: installation %d does not exist or does not belong to the GitHub App: %w", t.installationID, err)
+			}
+		}
+		return "", fmt.Errorf("failed to create installation token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	t.token = token.GetToken()
+	t.expiresAt = token.GetExpiresAt().Time
+	return t.token, nil
+}
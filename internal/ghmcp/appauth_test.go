@@ -0,0 +1,290 @@
+package ghmcp
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v73/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a clock that only moves when told to.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// fakeApp is a GitHub API that mints installation tokens for requests that authenticate as the app.
+type fakeApp struct {
+	t      *testing.T
+	key    *rsa.PrivateKey
+	clock  *fakeClock
+	status int
+	minted atomic.Int32
+	jwts   sync.Map
+}
+
+func (a *fakeApp) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/app/installations/42/access_tokens" {
+		http.NotFound(w, r)
+		return
+	}
+	jwt, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	require.True(a.t, ok, "the app authenticates with a bearer JWT")
+	a.verify(jwt)
+	a.jwts.Store(jwt, true)
+
+	if a.status != 0 {
+		w.WriteHeader(a.status)
+		_, _ = w.Write([]byte(`{"message": "failed"}`))
+		return
+	}
+	// Slow enough for concurrent callers to be waiting on the same token
+	time.Sleep(10 * time.Millisecond)
+	n := a.minted.Add(1)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"token":      fmt.Sprintf("ghs_installation%d", n),
+		"expires_at": a.clock.Now().Add(time.Hour).Format(time.RFC3339),
+	})
+}
+
+// verify checks the signature and claims of an app JWT.
+func (a *fakeApp) verify(jwt string) {
+	parts := strings.Split(jwt, ".")
+	require.Len(a.t, parts, 3)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(a.t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(a.t, rsa.VerifyPKCS1v15(&a.key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(a.t, err)
+	var claims struct {
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+		Issuer    string `json:"iss"`
+	}
+	require.NoError(a.t, json.Unmarshal(payload, &claims))
+	assert.Equal(a.t, "7", claims.Issuer)
+	assert.Less(a.t, claims.IssuedAt, a.clock.Now().Unix(), "backdated for clock drift")
+	assert.Greater(a.t, claims.ExpiresAt, a.clock.Now().Unix(), "not expired")
+	assert.LessOrEqual(a.t, claims.ExpiresAt-a.clock.Now().Unix(), int64(10*60), "GitHub rejects JWTs valid for more than 10 minutes")
+}
+
+func newInstallationTokens(t *testing.T, status int) (*installationTokens, *fakeApp) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	app := &fakeApp{t: t, key: key, clock: clock, status: status}
+	srv := httptest.NewServer(app)
+	t.Cleanup(srv.Close)
+
+	appClient := gogithub.NewClient(&http.Client{
+		Transport: &appJWTTransport{
+			transport: http.DefaultTransport,
+			appID:     7,
+			key:       key,
+			now:       clock.Now,
+		},
+	})
+	appClient.BaseURL, err = url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	return &installationTokens{appClient: appClient, installationID: 42, now: clock.Now}, app
+}
+
+func TestInstallationTokensAreCached(t *testing.T) {
+	tokens, app := newInstallationTokens(t, 0)
+
+	token, err := tokens.get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation1", token)
+
+	app.clock.Advance(30 * time.Minute)
+	token, err = tokens.get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation1", token, "the token is reused while it is far from its expiry")
+	assert.Equal(t, int32(1), app.minted.Load())
+}
+
+func TestInstallationTokensAreRefreshedBeforeTheyExpire(t *testing.T) {
+	tokens, app := newInstallationTokens(t, 0)
+
+	token, err := tokens.get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation1", token)
+
+	// Within the refresh margin of the expiry a new token is minted, with a JWT signed anew
+	app.clock.Advance(time.Hour - installationTokenRefreshMargin + time.Second)
+	token, err = tokens.get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation2", token)
+
+	// As well as once the token has expired
+	app.clock.Advance(2 * time.Hour)
+	token, err = tokens.get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation3", token)
+
+	assert.Equal(t, int32(3), app.minted.Load())
+	jwts := 0
+	app.jwts.Range(func(_, _ any) bool {
+		jwts++
+		return true
+	})
+	assert.Equal(t, 3, jwts, "every JWT had expired by the time the next token was minted")
+}
+
+func TestInstallationTokensAreRefreshedOnceConcurrently(t *testing.T) {
+	tokens, app := newInstallationTokens(t, 0)
+
+	var wg sync.WaitGroup
+	got := make([]string, 10)
+	for i := range got {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := tokens.get(context.Background())
+			assert.NoError(t, err)
+			got[i] = token
+		}()
+	}
+	wg.Wait()
+
+	for _, token := range got {
+		assert.Equal(t, "ghs_installation1", token)
+	}
+	assert.Equal(t, int32(1), app.minted.Load(), "concurrent callers share the token being minted")
+
+	// The same goes for a refresh
+	app.clock.Advance(time.Hour)
+	for i := range got {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := tokens.get(context.Background())
+			assert.NoError(t, err)
+			got[i] = token
+		}()
+	}
+	wg.Wait()
+
+	for _, token := range got {
+		assert.Equal(t, "ghs_installation2", token)
+	}
+	assert.Equal(t, int32(2), app.minted.Load())
+}
+
+func TestInstallationTokensErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		expectedErrMsg string
+	}{
+		{
+			name:           "wrong app",
+			status:         http.StatusUnauthorized,
+			expectedErrMsg: "the GitHub App ID or private key is wrong",
+		},
+		{
+			name:           "unknown installation",
+			status:         http.StatusNotFound,
+			expectedErrMsg: "installation 42 does not exist or does not belong to the GitHub App",
+		},
+		{
+			name:           "other failure",
+			status:         http.StatusInternalServerError,
+			expectedErrMsg: "failed to create installation token",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tokens, app := newInstallationTokens(t, tc.status)
+			_, err := tokens.get(context.Background())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			assert.Zero(t, app.minted.Load())
+			assert.Empty(t, tokens.token, "failures are not cached")
+		})
+	}
+}
+
+func TestAppJWTsAreCached(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	transport := &appJWTTransport{appID: 7, key: key, now: clock.Now}
+
+	first, err := transport.jwt()
+	require.NoError(t, err)
+	clock.Advance(5 * time.Minute)
+	second, err := transport.jwt()
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "the JWT is reused while it is far from its expiry")
+
+	clock.Advance(3*time.Minute + time.Second)
+	third, err := transport.jwt()
+	require.NoError(t, err)
+	assert.NotEqual(t, first, third, "the JWT is signed anew shortly before it expires")
+}
+
+func TestLoadAppPrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+		return path
+	}
+
+	for name, path := range map[string]string{
+		"PKCS #1": write("pkcs1.pem", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		"PKCS #8": write("pkcs8.pem", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})),
+	} {
+		loaded, err := loadAppPrivateKey(path)
+		require.NoError(t, err, name)
+		assert.True(t, key.Equal(loaded), name)
+	}
+
+	_, err = loadAppPrivateKey(write("key.txt", []byte("not a key")))
+	assert.ErrorContains(t, err, "not PEM encoded")
+	_, err = loadAppPrivateKey(filepath.Join(dir, "missing.pem"))
+	assert.ErrorContains(t, err, "failed to read GitHub App private key")
+}
@@ -0,0 +1,81 @@
+package ghmcp
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/github"
+)
+
+// maxQueuedMessages is how many messages the stdio transport reads ahead while a tool call runs, so that
+// the cancellation of the call isn't stuck behind them.
+const maxQueuedMessages = 100
+
+// interceptCancellations prepares the messages read from in for cancellation and passes them on through
+// the returned reader. The stdio transport of the MCP server library handles one message at a time, so
+// messages are read ahead into a queue: a cancellation is handled as soon as it arrives, while the call
+// it cancels is still running.
+func interceptCancellations(cancellations *github.RequestCancellations, in io.Reader) io.Reader {
+	pr, pw := io.Pipe()
+	queue := make(chan []byte, maxQueuedMessages)
+	var readErr error
+	go func() {
+		defer close(queue)
+		reader := bufio.NewReader(in)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+				if message, ok := cancellations.PrepareMessage(stdioSessionID, trimmed); ok {
+					queue <- append(message, '\n')
+				}
+			}
+			if err != nil {
+				readErr = err
+				return
+			}
+		}
+	}()
+	go func() {
+		for line := range queue {
+			if _, err := pw.Write(line); err != nil {
+				return
+			}
+		}
+		_ = pw.CloseWithError(readErr)
+	}()
+	return pr
+}
+
+// serveCancellations prepares the messages that the sessions of an HTTP transport post for cancellation
+// before handing them to next. sessionOf returns the session a request belongs to, which the transports
+// carry differently. Cancellations are answered right away.
+func serveCancellations(next http.Handler, cancellations *github.RequestCancellations, sessionOf func(*http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := sessionOf(r)
+		if r.Method != http.MethodPost || r.Body == nil || sessionID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		// A cheap check spares parsing the messages that cancellation doesn't look at
+		if bytes.Contains(body, []byte(`"tools/call"`)) || bytes.Contains(body, []byte(`"notifications/cancelled"`)) {
+			message, ok := cancellations.PrepareMessage(sessionID, body)
+			if !ok {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			body = message
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		next.ServeHTTP(w, r)
+	})
+}
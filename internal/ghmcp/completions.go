@@ -0,0 +1,107 @@
+package ghmcp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// capabilityWriter declares the completions capability in the initialize response that the MCP server
+// writes to w. The stdio transport of the MCP server library writes every message in one call.
+type capabilityWriter struct {
+	w io.Writer
+}
+
+func (w capabilityWriter) Write(p []byte) (int, error) {
+	if !bytes.Contains(p, []byte(`"protocolVersion"`)) {
+		return w.w.Write(p)
+	}
+	message := github.AddCompletionsCapability(bytes.TrimSpace(p))
+	if _, err := w.w.Write(append(message, '\n')); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// bufferedResponseWriter holds back the body of a response until it is complete.
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedResponseWriter) Write(p []byte) (int, error) {
+	return w.body.Write(p)
+}
+
+// serveCompletionsCapability declares the completions capability in the responses of next to the
+// initialize requests of the streamable HTTP transport. Other requests are handed to next as they are.
+func serveCompletionsCapability(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Initialize requests are the ones that start a session
+		if r.Method != http.MethodPost || r.Header.Get(headerSessionID) != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		buffered := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(buffered, r)
+
+		body := buffered.body.Bytes()
+		if buffered.status == http.StatusOK && bytes.HasPrefix([]byte(w.Header().Get("Content-Type")), []byte("application/json")) {
+			body = github.AddCompletionsCapability(bytes.TrimSpace(body))
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		}
+		w.WriteHeader(buffered.status)
+		_, _ = w.Write(body)
+	})
+}
+
+// sseEventPrefix starts the events of the SSE transport that carry a message.
+const sseEventPrefix = "event: message\ndata: "
+
+// sseCapabilityWriter declares the completions capability in the initialize response that the SSE
+// transport writes to the stream of a session. The transport writes every event in one call, and flushes
+// it right after.
+type sseCapabilityWriter struct {
+	http.ResponseWriter
+}
+
+func (w sseCapabilityWriter) Write(p []byte) (int, error) {
+	if !bytes.HasPrefix(p, []byte(sseEventPrefix)) || !bytes.Contains(p, []byte(`"protocolVersion"`)) {
+		return w.ResponseWriter.Write(p)
+	}
+	message := github.AddCompletionsCapability(bytes.TrimSpace(p[len(sseEventPrefix):]))
+	if _, err := fmt.Fprintf(w.ResponseWriter, "%s%s\n\n", sseEventPrefix, message); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w sseCapabilityWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// serveSSECompletionsCapability declares the completions capability in the responses of next to the
+// initialize requests of the SSE transport, which are sent on the streams that clients open with a GET
+// request to the path of sseServer. Other requests are handed to next as they are.
+func serveSSECompletionsCapability(next http.Handler, sseServer *server.SSEServer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != sseServer.CompleteSsePath() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(sseCapabilityWriter{w}, r)
+	})
+}
@@ -0,0 +1,347 @@
+package ghmcp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/errors"
+	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// httpEndpointPath is where the streamable HTTP transport is served
+	httpEndpointPath = "/mcp"
+
+	// headerSessionID carries the session of the streamable HTTP transport
+	headerSessionID = "Mcp-Session-Id"
+)
+
+// HTTPServerConfig configures the server that MCP clients connect to with the streamable HTTP transport.
+type HTTPServerConfig struct {
+	// StdioServerConfig holds the options shared with the stdio server. Its Token is not needed, and not
+	// used, if RequireAuthorization is set.
+	StdioServerConfig
+
+	// ListenAddr is the address to listen on, such as localhost:8080
+	ListenAddr string
+
+	// TLSCertFile and TLSKeyFile serve HTTPS when both are set
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// RequireAuthorization makes every request authenticate with its own GitHub token in the
+	// Authorization header, which the tools then act with, so that one server can serve many users
+	RequireAuthorization bool
+
+	// SessionIdleTimeout is how long a session is kept without requests, zero keeps sessions until the
+	// client ends them
+	SessionIdleTimeout time.Duration
+
+	// ShutdownTimeout is how long open connections get to finish when the server shuts down
+	ShutdownTimeout time.Duration
+
+	// StreamLists sends the pages of list tools to clients that give a progress token as soon as they are
+	// fetched, up to streamlist.MaxPages pages per call
+	StreamLists bool
+}
+
+// httpSession is a session of the streamable HTTP transport.
+type httpSession struct {
+	// tokenHash is the hash of the token the session was started with, only that token can use it
+	tokenHash [sha256.Size]byte
+	lastSeen  time.Time
+}
+
+// httpSessions keeps track of the sessions of the streamable HTTP transport. It is the SessionIdManager of
+// the transport, which generates, validates and terminates session IDs, and in addition binds each session
+// to the token it was started with so that another user can't take it over.
+type httpSessions struct {
+	idleTimeout time.Duration
+	now         func() time.Time
+	// ended is called with the sessions that were terminated or expired, if set
+	ended func(sessionID string)
+
+	mu       sync.Mutex
+	sessions map[string]*httpSession
+}
+
+var _ server.SessionIdManager = (*httpSessions)(nil)
+
+func newHTTPSessions(idleTimeout time.Duration) *httpSessions {
+	return &httpSessions{
+		idleTimeout: idleTimeout,
+		now:         time.Now,
+		sessions:    make(map[string]*httpSession),
+	}
+}
+
+// expired reports whether a session has been idle for too long, the caller must hold mu.
+func (s *httpSessions) expired(session *httpSession, now time.Time) bool {
+	return s.idleTimeout > 0 && now.Sub(session.lastSeen) > s.idleTimeout
+}
+
+func (s *httpSessions) Generate() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	id := "mcp-session-" + hex.EncodeToString(b)
+
+	s.mu.Lock()
+	now := s.now()
+	// New sessions are a good time to forget the ones clients abandoned
+	var expired []string
+	for sessionID, session := range s.sessions {
+		if s.expired(session, now) {
+			delete(s.sessions, sessionID)
+			expired = append(expired, sessionID)
+		}
+	}
+	s.sessions[id] = &httpSession{lastSeen: now}
+	s.mu.Unlock()
+
+	s.end(expired...)
+	return id
+}
+
+// end tells of sessions that ended, the caller must not hold mu.
+func (s *httpSessions) end(sessionIDs ...string) {
+	if s.ended == nil {
+		return
+	}
+	for _, sessionID := range sessionIDs {
+		s.ended(sessionID)
+	}
+}
+
+// Validate reports unknown and expired sessions as terminated, which the transport answers with a 404 that
+// tells clients to start a new session.
+func (s *httpSessions) Validate(sessionID string) (isTerminated bool, err error) {
+	if sessionID == "" {
+		return false, fmt.Errorf("missing %s header", headerSessionID)
+	}
+
+	s.mu.Lock()
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		s.mu.Unlock()
+		return true, nil
+	}
+	now := s.now()
+	if s.expired(session, now) {
+		delete(s.sessions, sessionID)
+		s.mu.Unlock()
+		s.end(sessionID)
+		return true, nil
+	}
+	session.lastSeen = now
+	s.mu.Unlock()
+	return false, nil
+}
+
+func (s *httpSessions) Terminate(sessionID string) (isNotAllowed bool, err error) {
+	s.mu.Lock()
+	_, ok := s.sessions[sessionID]
+	delete(s.sessions, sessionID)
+	s.mu.Unlock()
+	if ok {
+		s.end(sessionID)
+	}
+	return false, nil
+}
+
+// bind ties a new session to the token it was started with.
+func (s *httpSessions) bind(sessionID, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if session, ok := s.sessions[sessionID]; ok {
+		session.tokenHash = sha256.Sum256([]byte(token))
+	}
+}
+
+// owns reports whether token may use a session. Unknown sessions are left to Validate.
+func (s *httpSessions) owns(sessionID, token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		return true
+	}
+	hash := sha256.Sum256([]byte(token))
+	return subtle.ConstantTimeCompare(session.tokenHash[:], hash[:]) == 1
+}
+
+// httpSessionID returns the session of a request of the streamable HTTP transport.
+func httpSessionID(r *http.Request) string {
+	return r.Header.Get(headerSessionID)
+}
+
+// authenticate requires requests to carry a token in the Authorization header if required is set, and
+// keeps sessions to the token that started them.
+func authenticate(next http.Handler, sessions *httpSessions, required bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := bearerToken(r)
+		if required && token == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="github-mcp-server"`)
+			http.Error(w, "a GitHub token is required in the Authorization header", http.StatusUnauthorized)
+			return
+		}
+
+		// Answer like for an unknown session, so that sessions of other users can't be probed
+		if sessionID := r.Header.Get(headerSessionID); sessionID != "" && !sessions.owns(sessionID, token) {
+			http.Error(w, "Session not found", http.StatusNotFound)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+
+		// The transport creates a session when answering an initialize request
+		if sessionID := w.Header().Get(headerSessionID); sessionID != "" && r.Header.Get(headerSessionID) == "" {
+			sessions.bind(sessionID, token)
+		}
+	})
+}
+
+// RunHTTPServer is not concurrent safe.
+func RunHTTPServer(cfg HTTPServerConfig) error {
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return fmt.Errorf("both a TLS certificate and key are required to serve HTTPS")
+	}
+	if cfg.RequireAuthorization && cfg.ImpersonateUser != "" {
+		return fmt.Errorf("impersonation can't be combined with per-request authorization")
+	}
+
+	// Create app context
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	t, dumpTranslations := translations.TranslationHelper()
+
+	auditLog, closeAuditLog, err := cfg.newAuditLog()
+	if err != nil {
+		return err
+	}
+	defer closeAuditLog()
+
+	logger, closeLog, err := cfg.newLogger()
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
+	tracer, closeTracer, err := cfg.newTracer(logger)
+	if err != nil {
+		return err
+	}
+	defer closeTracer()
+
+	serverMetrics := cfg.newMetrics()
+	mcpServerConfig := cfg.mcpServerConfig(t, auditLog, serverMetrics, tracer, logger)
+	mcpServerConfig.StreamLists = cfg.StreamLists
+	ghServer, err := newMCPServer(mcpServerConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+	go ghServer.subscriptions.Run(ctx)
+
+	cfg.warnImpersonation(logger)
+
+	if err := cfg.serveMetrics(ctx, serverMetrics, logger); err != nil {
+		return err
+	}
+
+	if cfg.ExportTranslations {
+		// Once server is initialized, all translations are loaded
+		dumpTranslations()
+	}
+
+	sessions := newHTTPSessions(cfg.SessionIdleTimeout)
+	streams := newServerStreams(ghServer.MCPServer)
+	// The sessions of the requests are never registered with the MCP server, only the ones with a stream
+	sessions.ended = func(sessionID string) {
+		streams.end(sessionID)
+		ghServer.agents.remove(sessionID)
+	}
+	httpServer := &http.Server{
+		Addr:              cfg.ListenAddr,
+		ReadHeaderTimeout: 10 * time.Second,
+		ErrorLog:          mcplog.NewStdLogger(logger, "httpserver"),
+	}
+	contextFunc := func(ctx context.Context, r *http.Request) context.Context {
+		// enable GitHub errors in the context
+		ctx = errors.ContextWithGitHubErrors(ctx)
+		if token := bearerToken(r); token != "" && (cfg.RequireAuthorization || cfg.TokenPassthrough) {
+			ctx = contextWithToken(ctx, token)
+		}
+		return ctx
+	}
+	transport := server.NewStreamableHTTPServer(ghServer.MCPServer,
+		server.WithStreamableHTTPServer(httpServer),
+		server.WithSessionIdManager(sessions),
+		server.WithHTTPContextFunc(contextFunc),
+	)
+
+	handler := serveRequests(serveCompletionsCapability(serveStreams(transport, sessions, streams)), sessions, contextFunc, ghServer.messageHandlers()...)
+	handler = serveCancellations(handler, ghServer.cancellations, httpSessionID)
+	if cfg.EnableCommandLogging {
+		handler = logMessages(handler, logger, "http")
+	}
+	mux := http.NewServeMux()
+	mux.Handle(httpEndpointPath, authenticate(handler, sessions, cfg.RequireAuthorization))
+	httpServer.Handler = mux
+
+	// Listen before reporting that the server runs, so that a taken address fails right away
+	listener, err := net.Listen("tcp", cfg.ListenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", cfg.ListenAddr, err)
+	}
+
+	errC := make(chan error, 1)
+	go func() {
+		if cfg.TLSCertFile != "" {
+			errC <- httpServer.ServeTLS(listener, cfg.TLSCertFile, cfg.TLSKeyFile)
+		} else {
+			errC <- httpServer.Serve(listener)
+		}
+	}()
+
+	scheme := "http"
+	if cfg.TLSCertFile != "" {
+		scheme = "https"
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "GitHub MCP Server running on streamable HTTP at %s://%s%s\n", scheme, listener.Addr(), httpEndpointPath)
+	if cfg.RequireAuthorization {
+		logger.Info("requests authenticate with the token in their Authorization header")
+	}
+
+	// Wait for shutdown signal
+	select {
+	case <-ctx.Done():
+		logger.Info("shutting down server")
+	case err := <-errC:
+		if err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("error running server: %w", err)
+		}
+		return nil
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	// The streams stay open until the client disconnects, which would hold up the shutdown
+	streams.endAll()
+	if err := transport.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	return nil
+}
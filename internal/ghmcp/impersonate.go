@@ -0,0 +1,46 @@
+package ghmcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/auth"
+	gogithub "github.com/google/go-github/v73/github"
+)
+
+// impersonationToken exchanges a site administrator token for an impersonation OAuth token of user on
+// GitHub Enterprise Server. Everything done with the returned token is attributed to user. The server
+// returns the existing token when one with the same scopes was created before, so restarts don't pile
+// up tokens.
+func impersonationToken(ctx context.Context, transport http.RoundTripper, host apiHost, adminToken, user string, scopes []string) (string, error) {
+	if !host.enterpriseServer {
+		return "", errors.New("impersonation is only supported against GitHub Enterprise Server hosts, set --gh-host to your GitHub Enterprise Server")
+	}
+	if len(scopes) == 0 {
+		scopes = auth.DefaultScopes
+	}
+
+	client := gogithub.NewClient(&http.Client{Transport: transport}).WithAuthToken(adminToken)
+	client.BaseURL = host.baseRESTURL
+
+	authorization, resp, err := client.Admin.CreateUserImpersonation(ctx, user, &gogithub.ImpersonateUserOptions{Scopes: scopes})
+	if err != nil {
+		if resp != nil {
+			switch resp.StatusCode {
+			case http.StatusUnauthorized, http.StatusForbidden:
+				return "", fmt.Errorf("not permitted to impersonate %s: impersonation requires the token of a site administrator: %w", user, err)
+			case http.StatusNotFound:
+				return "", fmt.Errorf("cannot impersonate %s: the user does not exist, or the token is not a site administrator token: %w", user, err)
+			}
+		}
+		return "", fmt.Errorf("failed to impersonate %s: %w", user, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if authorization.GetToken() == "" {
+		return "", fmt.Errorf("failed to impersonate %s: no token was returned", user)
+	}
+	return authorization.GetToken(), nil
+}
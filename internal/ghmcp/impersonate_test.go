@@ -0,0 +1,185 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newImpersonationServer returns a GitHub Enterprise Server host whose impersonation endpoint answers with
+// status and body, and records the scopes it was asked for.
+func newImpersonationServer(t *testing.T, status int, body string, scopes *[]string) apiHost {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v3/admin/users/octocat/authorizations" {
+			http.NotFound(w, r)
+			return
+		}
+		assert.Equal(t, "Bearer admin-token", r.Header.Get("Authorization"), "the site administrator token creates the impersonation token")
+		var options struct {
+			Scopes []string `json:"scopes"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+		*scopes = options.Scopes
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	restURL, err := url.Parse(srv.URL + "/api/v3/")
+	require.NoError(t, err)
+	return apiHost{baseRESTURL: restURL, enterpriseServer: true}
+}
+
+func TestImpersonationToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		dotcom         bool
+		scopes         []string
+		status         int
+		body           string
+		expectedToken  string
+		expectedScopes []string
+		expectedErrMsg string
+	}{
+		{
+			name:           "default scopes",
+			status:         http.StatusCreated,
+			body:           `{"token": "impersonation-token"}`,
+			expectedToken:  "impersonation-token",
+			expectedScopes: auth.DefaultScopes,
+		},
+		{
+			name:           "given scopes",
+			scopes:         []string{"repo"},
+			status:         http.StatusOK,
+			body:           `{"token": "existing-token"}`,
+			expectedToken:  "existing-token",
+			expectedScopes: []string{"repo"},
+		},
+		{
+			name:           "not an enterprise server host",
+			dotcom:         true,
+			expectedErrMsg: "impersonation is only supported against GitHub Enterprise Server hosts",
+		},
+		{
+			name:           "not a site administrator",
+			status:         http.StatusForbidden,
+			body:           `{"message": "Must have admin rights"}`,
+			expectedErrMsg: "not permitted to impersonate octocat: impersonation requires the token of a site administrator",
+		},
+		{
+			name:           "bad credentials",
+			status:         http.StatusUnauthorized,
+			body:           `{"message": "Bad credentials"}`,
+			expectedErrMsg: "not permitted to impersonate octocat",
+		},
+		{
+			name:           "unknown user",
+			status:         http.StatusNotFound,
+			body:           `{"message": "Not Found"}`,
+			expectedErrMsg: "cannot impersonate octocat: the user does not exist, or the token is not a site administrator token",
+		},
+		{
+			name:           "server error",
+			status:         http.StatusInternalServerError,
+			body:           `{"message": "Server Error"}`,
+			expectedErrMsg: "failed to impersonate octocat",
+		},
+		{
+			name:           "no token returned",
+			status:         http.StatusCreated,
+			body:           `{}`,
+			expectedErrMsg: "failed to impersonate octocat: no token was returned",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var scopes []string
+			host := newImpersonationServer(t, tc.status, tc.body, &scopes)
+			if tc.dotcom {
+				var err error
+				host, err = newDotcomHost()
+				require.NoError(t, err)
+			}
+
+			token, err := impersonationToken(context.Background(), http.DefaultTransport, host, "admin-token", "octocat", tc.scopes)
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				assert.Empty(t, token)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedToken, token)
+			assert.Equal(t, tc.expectedScopes, scopes)
+		})
+	}
+}
+
+func TestBearerAuthTransportPrecedence(t *testing.T) {
+	var authorization string
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{
+			name:     "the impersonation token without a token in the context",
+			ctx:      context.Background(),
+			expected: "Bearer impersonation-token",
+		},
+		{
+			name:     "the token of the request, passed through in the context",
+			ctx:      contextWithToken(context.Background(), "request-token"),
+			expected: "Bearer request-token",
+		},
+		{
+			name:     "an empty token in the context is no token",
+			ctx:      contextWithToken(context.Background(), ""),
+			expected: "Bearer impersonation-token",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &http.Client{Transport: &bearerAuthTransport{transport: http.DefaultTransport, token: "impersonation-token"}}
+			req, err := http.NewRequestWithContext(tc.ctx, http.MethodGet, srv.URL, nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer stale-token")
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, tc.expected, authorization)
+		})
+	}
+}
+
+func TestImpersonationIsRejectedWithOtherAuthentication(t *testing.T) {
+	err := RunHTTPServer(HTTPServerConfig{
+		StdioServerConfig:    StdioServerConfig{ImpersonateUser: "octocat"},
+		RequireAuthorization: true,
+	})
+	assert.ErrorContains(t, err, "impersonation can't be combined with per-request authorization")
+
+	_, err = NewMCPServer(MCPServerConfig{
+		Host:              "https://ghes.example.com",
+		Token:             "admin-token",
+		ImpersonateUser:   "octocat",
+		AppInstallationID: 42,
+	})
+	assert.ErrorContains(t, err, "impersonation can't be combined with GitHub App installation authentication")
+}
@@ -0,0 +1,71 @@
+package ghmcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// tokenMetaField is the field of the `_meta` of a tool call that carries the GitHub token in token
+// passthrough mode.
+const tokenMetaField = "github_token"
+
+type tokenContextKey struct{}
+
+// contextWithToken returns a context whose requests to GitHub authenticate with token instead of the
+// configured one.
+func contextWithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, token)
+}
+
+// tokenFromContext returns the token set by contextWithToken.
+func tokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(tokenContextKey{}).(string)
+	return token, ok && token != ""
+}
+
+// callerIdentity identifies the caller of a tool call by the token it acts with, so that callers sharing a
+// session, such as the users of a stdio server in token passthrough mode, are kept apart. The token is
+// hashed so that it isn't kept around. Calls that act with the server's own token all have the same caller.
+func callerIdentity(ctx context.Context) string {
+	token, ok := tokenFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// bearerToken returns the token of an `Authorization: Bearer <token>` or `Authorization: token <token>`
+// header.
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || (!strings.EqualFold(scheme, "bearer") && !strings.EqualFold(scheme, "token")) {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// tokenPassthroughMiddleware makes tool calls act with the token in their `_meta`, which takes precedence
+// over the token of the HTTP request. Calls that bring no token at all fail, unless the server has a
+// token of its own to fall back to.
+func tokenPassthroughMiddleware(hasFallback bool) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if meta := request.Params.Meta; meta != nil {
+				if token, _ := meta.AdditionalFields[tokenMetaField].(string); token != "" {
+					return next(contextWithToken(ctx, token), request)
+				}
+			}
+			if _, ok := tokenFromContext(ctx); !ok && !hasFallback {
+				return mcp.NewToolResultError("this server acts with the token of each caller: pass a GitHub token in the " + tokenMetaField + " field of the _meta of the tool call, or in the Authorization header"), nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
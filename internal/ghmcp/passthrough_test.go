@@ -0,0 +1,48 @@
+package ghmcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallerIdentity(t *testing.T) {
+	assert.Empty(t, callerIdentity(context.Background()), "calls with the server's own token share a caller")
+
+	alice := callerIdentity(contextWithToken(context.Background(), "alice-token"))
+	bob := callerIdentity(contextWithToken(context.Background(), "bob-token"))
+	assert.NotEmpty(t, alice)
+	assert.NotEqual(t, alice, bob)
+	assert.Equal(t, alice, callerIdentity(contextWithToken(context.Background(), "alice-token")))
+	assert.NotContains(t, alice, "alice-token", "tokens are not kept around")
+}
+
+func TestTokenPassthroughMiddleware(t *testing.T) {
+	var callers []string
+	handler := tokenPassthroughMiddleware(false)(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		callers = append(callers, callerIdentity(ctx))
+		return mcp.NewToolResultText("done"), nil
+	})
+	call := func(ctx context.Context, token string) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{}
+		if token != "" {
+			request.Params.Meta = &mcp.Meta{AdditionalFields: map[string]any{tokenMetaField: token}}
+		}
+		result, err := handler(ctx, request)
+		require.NoError(t, err)
+		return result
+	}
+
+	header := contextWithToken(context.Background(), "header-token")
+	assert.False(t, call(header, "meta-token").IsError)
+	assert.False(t, call(header, "").IsError)
+	assert.Equal(t, []string{
+		callerIdentity(contextWithToken(context.Background(), "meta-token")),
+		callerIdentity(header),
+	}, callers, "the token of the _meta takes precedence over the one of the header")
+
+	assert.True(t, call(context.Background(), "").IsError, "calls without a token fail without a token to fall back to")
+}
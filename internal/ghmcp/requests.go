@@ -0,0 +1,149 @@
+package ghmcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// stdioSessionID is the ID the MCP server library gives the only session of the stdio transport.
+const stdioSessionID = "stdio"
+
+// messageHandler answers the requests of a method that the MCP server library doesn't dispatch, such as
+// github.ResourceSubscriptions and github.ArgumentCompletions. It reports false for any other message.
+type messageHandler interface {
+	HandleMessage(ctx context.Context, sessionID string, message json.RawMessage) (mcp.JSONRPCMessage, bool)
+}
+
+// lockedWriter serializes writes, so that the messages of the MCP server and the answers of the message
+// handlers never interleave.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+// interceptRequests answers the requests read from in that one of the handlers takes, and passes every
+// other message on through the returned reader. The MCP server must write to the returned writer.
+func interceptRequests(ctx context.Context, in io.Reader, out io.Writer, handlers ...messageHandler) (io.Reader, io.Writer) {
+	writer := &lockedWriter{w: out}
+	pr, pw := io.Pipe()
+	go func() {
+		reader := bufio.NewReader(in)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				if response, ok := handleMessage(ctx, handlers, stdioSessionID, bytes.TrimSpace(line)); ok {
+					if b, err := json.Marshal(response); err == nil {
+						_, _ = writer.Write(append(b, '\n'))
+					}
+				} else if _, err := pw.Write(line); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return pr, writer
+}
+
+// handleMessage hands the message to the first handler that answers it.
+func handleMessage(ctx context.Context, handlers []messageHandler, sessionID string, message json.RawMessage) (mcp.JSONRPCMessage, bool) {
+	for _, handler := range handlers {
+		if response, ok := handler.HandleMessage(ctx, sessionID, message); ok {
+			return response, true
+		}
+	}
+	return nil, false
+}
+
+// serveRequests answers the requests of the sessions of the streamable HTTP transport that one of the
+// handlers takes, and hands every other request to next. contextFunc prepares the context of a request
+// like the transport does.
+func serveRequests(next http.Handler, sessions *httpSessions, contextFunc func(context.Context, *http.Request) context.Context, handlers ...messageHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.Header.Get(headerSessionID)
+		if r.Method != http.MethodPost || r.Body == nil || sessionID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// A cheap check spares parsing the requests that no handler takes, and requests of unknown sessions
+		// are left to the transport to turn down
+		if !bytes.Contains(body, []byte(`"resources/`)) && !bytes.Contains(body, []byte(`"completion/`)) && !bytes.Contains(body, []byte(`"prompts/`)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if terminated, err := sessions.Validate(sessionID); err != nil || terminated {
+			next.ServeHTTP(w, r)
+			return
+		}
+		response, ok := handleMessage(contextFunc(r.Context(), r), handlers, sessionID, body)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(headerSessionID, sessionID)
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}
+
+// serveSSERequests answers the requests that the sessions of the SSE transport post and one of the
+// handlers takes, on the stream of their session like the transport answers the others. Every other
+// request is handed to next. contextFunc prepares the context of a request like the transport does.
+func serveSSERequests(next http.Handler, sseServer *server.SSEServer, contextFunc func(context.Context, *http.Request) context.Context, handlers ...messageHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := sseSessionID(r)
+		if r.Method != http.MethodPost || r.Body == nil || sessionID == "" || r.URL.Path != sseServer.CompleteMessagePath() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// A cheap check spares parsing the requests that no handler takes
+		if !bytes.Contains(body, []byte(`"resources/`)) && !bytes.Contains(body, []byte(`"completion/`)) && !bytes.Contains(body, []byte(`"prompts/`)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		response, ok := handleMessage(contextFunc(r.Context(), r), handlers, sessionID, body)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// Subscriptions of sessions that turn out to be unknown end once their notifications fail
+		if err := sseServer.SendEventToSession(sessionID, response); err != nil {
+			http.Error(w, "Invalid session ID", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
@@ -4,24 +4,45 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
-
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/audit"
+	"github.com/github/github-mcp-server/pkg/budget"
+	"github.com/github/github-mcp-server/pkg/cache"
+	"github.com/github/github-mcp-server/pkg/confirm"
+	"github.com/github/github-mcp-server/pkg/cursor"
+	"github.com/github/github-mcp-server/pkg/dryrun"
+	"github.com/github/github-mcp-server/pkg/egress"
 	"github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/graphql"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/github/github-mcp-server/pkg/metrics"
+	"github.com/github/github-mcp-server/pkg/policy"
+	"github.com/github/github-mcp-server/pkg/provenance"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/reference"
+	"github.com/github/github-mcp-server/pkg/relativetime"
+	"github.com/github/github-mcp-server/pkg/retry"
+	"github.com/github/github-mcp-server/pkg/scope"
+	"github.com/github/github-mcp-server/pkg/streamlist"
+	"github.com/github/github-mcp-server/pkg/structured"
+	"github.com/github/github-mcp-server/pkg/tracing"
 	"github.com/github/github-mcp-server/pkg/translations"
 	gogithub "github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/shurcooL/githubv4"
-	"github.com/sirupsen/logrus"
 )
 
 type MCPServerConfig struct {
@@ -38,6 +59,12 @@ type MCPServerConfig struct {
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
 
+	// EnabledTools narrows the tools of the enabled toolsets down to these, unless empty, and
+	// ExcludedTools withholds tools
+	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
+	EnabledTools  []string
+	ExcludedTools []string
+
 	// Whether to enable dynamic toolsets
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#dynamic-tool-discovery
 	DynamicToolsets bool
@@ -45,19 +72,271 @@ type MCPServerConfig struct {
 	// ReadOnly indicates if we should only offer read-only tools
 	ReadOnly bool
 
+	// DryRun makes write tools describe the first request that would change something instead of sending
+	// it, as if every call set dry_run
+	DryRun bool
+
+	// ConfirmDestructive makes destructive tools return a confirmation token instead of running, and
+	// only run when called again with it
+	ConfirmDestructive bool
+
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
+
+	// MaxIdleConns is the maximum number of idle (keep-alive) connections across all hosts, zero uses the Go default
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is the maximum number of idle (keep-alive) connections per host, zero uses the Go default
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection remains open, zero uses the Go default
+	IdleConnTimeout time.Duration
+
+	// DisableKeepAlives forces a new connection for every request
+	DisableKeepAlives bool
+
+	// RateLimitMaxWait is how long a request waits at most for the GitHub API rate limit to reset, or for
+	// a secondary rate limit to pass, before failing. Zero fails right away instead of waiting
+	RateLimitMaxWait time.Duration
+
+	// MaxRetries is how many times a request that failed for a transient reason, such as a 502 or a reset
+	// connection, is retried. Only reads and GraphQL queries are retried. Zero never retries
+	MaxRetries int
+
+	// MaxResponseTokens is the size of tool results in tokens, beyond which they are truncated and the
+	// rest can be fetched with the cursor they return. Zero leaves results whole
+	MaxResponseTokens int
+
+	// CacheMaxSizeMB is the size of the cache of the responses that GET requests are made conditional on,
+	// zero disables it. The cache is kept in memory, or in CacheDir if set, which keeps it across restarts
+	CacheMaxSizeMB int
+	CacheDir       string
+
+	// IncludeProvenance attaches the GitHub request IDs and endpoints behind each tool result under `_meta.provenance`
+	IncludeProvenance bool
+
+	// RelativeTimes adds a `*_relative` companion such as "3 days ago" next to timestamps in tool results
+	RelativeTimes bool
+
+	// ParseReferences spreads URLs and `owner/repo#123` references in tool inputs into the discrete
+	// owner, repo and number inputs the tool expects
+	ParseReferences bool
+
+	// DraftRulesPath is a YAML or JSON file with the rules validate_issue_draft and
+	// validate_pull_request_draft apply, the defaults are used if empty
+	DraftRulesPath string
+
+	// PolicyPath is a YAML or JSON file with the policy that calls of write tools are checked against,
+	// all of them are allowed if empty
+	PolicyPath string
+
+	// AllowedRepos and BlockedRepos are owner/repo patterns, such as octo-org/*, that confine the server
+	// to the repositories that match one of AllowedRepos, unless empty, and none of BlockedRepos
+	AllowedRepos []string
+	BlockedRepos []string
+
+	// AuditLog records every tool call, none are recorded if nil
+	AuditLog *audit.Logger
+
+	// Metrics collects the tool calls, GitHub API requests and sessions of the server, none are
+	// collected if nil
+	Metrics *metrics.Metrics
+
+	// Tracer traces the tool calls and the GitHub API requests they make, none are traced if nil
+	Tracer *tracing.Tracer
+
+	// Logger logs what goes wrong in the background, such as failures to write the audit log. It falls
+	// back to slog.Default() if nil
+	Logger *slog.Logger
+
+	// AppID and AppPrivateKeyPath identify a GitHub App. When both are set, the app toolset is registered
+	AppID             int64
+	AppPrivateKeyPath string
+
+	// AppInstallationID makes the server authenticate as this installation of the GitHub App instead of
+	// with the Token, using installation tokens that are minted and refreshed before they expire
+	AppInstallationID int64
+
+	// DisableTelemetry turns off any telemetry export, including traces to an OTLP collector
+	DisableTelemetry bool
+
+	// NoExternalEgress confines all outbound requests to the configured GitHub host
+	NoExternalEgress bool
+
+	// ImpersonateUser makes the server act as this user on GitHub Enterprise Server, by exchanging the
+	// site administrator Token for an impersonation token with ImpersonateScopes
+	ImpersonateUser   string
+	ImpersonateScopes []string
+
+	// TokenPassthrough makes tool calls act with the GitHub token in their `_meta`, or in the Authorization
+	// header of the HTTP transports, so that one server can act on behalf of many users. The Token, if
+	// any, is used for calls that bring none
+	TokenPassthrough bool
+
+	// GraphQLQuery registers the graphql toolset, whose graphql_query tool runs arbitrary GraphQL queries
+	// once they validate against the schema of the API and stay within GraphQLMaxCost estimated nodes
+	GraphQLQuery   bool
+	GraphQLMaxCost int
+
+	// GraphQLAllowMutations lets graphql_query run mutations, unless the server is read-only
+	GraphQLAllowMutations bool
+
+	// GraphQLSchemaRefresh makes graphql_query validate against the schema introspected from the host
+	// instead of the bundled one
+	GraphQLSchemaRefresh bool
+
+	// APIRequest registers the api toolset, whose api_request tool sends arbitrary REST requests that
+	// match one of the APIAllowlist entries, such as "GET /repos/*/*/issues/**"
+	APIRequest   bool
+	APIAllowlist []string
+
+	// ResourcePollInterval is how often the issues and pull requests clients subscribed to are checked for
+	// changes, github.DefaultResourcePollInterval if zero
+	ResourcePollInterval time.Duration
+
+	// StreamLists streams the pages of list tools to clients that give a progress token, which only the
+	// streamable HTTP transport delivers as they are sent
+	StreamLists bool
 }
 
 func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
+	ghServer, err := newMCPServer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return ghServer.MCPServer, nil
+}
+
+// mcpServer is the MCP server together with the parts of the protocol that the MCP server library
+// leaves to the transports.
+type mcpServer struct {
+	*server.MCPServer
+	// subscriptions are the resource subscriptions, which the transports serve and poll
+	subscriptions *github.ResourceSubscriptions
+	// cancellations are the running tool calls, which the transports cancel
+	cancellations *github.RequestCancellations
+	// completions are the argument completions, which the transports serve
+	completions *github.ArgumentCompletions
+	// scope turns down the requests of resources, prompts and completions out of the repositories the
+	// server is confined to, before the transports serve them. It is nil without one
+	scope *scope.Scope
+	// agents are the user agents of the sessions, which the transports forget once their sessions end
+	agents *userAgents
+}
+
+// messageHandlers are the handlers of the requests that the transports serve, in the order they are
+// tried.
+func (s *mcpServer) messageHandlers() []messageHandler {
+	handlers := []messageHandler{s.subscriptions, s.completions}
+	if s.scope != nil {
+		handlers = append([]messageHandler{s.scope}, handlers...)
+	}
+	return handlers
+}
+
+// newMCPServer creates the MCP server together with its resource subscriptions, cancellations and
+// completions.
+func newMCPServer(cfg MCPServerConfig) (*mcpServer, error) {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
 	apiHost, err := parseAPIHost(cfg.Host)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse API host: %w", err)
 	}
 
+	// Both clients share a single transport so that they also share the connection pool
+	var transport http.RoundTripper = newHTTPTransport(cfg)
+	if cfg.NoExternalEgress {
+		transport = egress.NewGuard(transport, apiHost.hosts()...)
+	}
+	// Inside of the retries, so that every attempt is a span of its own
+	if cfg.Tracer != nil {
+		transport = tracing.NewTransport(transport, cfg.Tracer)
+	}
+	// Inside of the cache, so that the revalidations of cached responses are retried too
+	transport = retry.NewTransport(transport, cfg.MaxRetries)
+	// Inside of the provenance, so that the responses served from the cache are recorded as cached
+	if cfg.CacheMaxSizeMB > 0 {
+		responses, err := cache.New(int64(cfg.CacheMaxSizeMB)<<20, cfg.CacheDir)
+		if err != nil {
+			return nil, err
+		}
+		transport = cache.NewTransport(transport, responses)
+	}
+	// Outside of the cache, so that the responses it serves count as hits
+	if cfg.Metrics != nil {
+		transport = metrics.NewTransport(transport, cfg.Metrics, cfg.CacheMaxSizeMB > 0)
+	}
+	if cfg.IncludeProvenance {
+		transport = provenance.NewTransport(transport)
+	}
+	transport = cursor.NewTransport(transport)
+	// Outside of the provenance, so that the retries of requests that hit a rate limit are recorded too
+	transport = ratelimit.NewTransport(transport, cfg.RateLimitMaxWait)
+
+	if cfg.ImpersonateUser != "" && cfg.AppInstallationID != 0 {
+		return nil, fmt.Errorf("impersonation can't be combined with GitHub App installation authentication")
+	}
+	token := cfg.Token
+	if cfg.ImpersonateUser != "" {
+		token, err = impersonationToken(context.Background(), transport, apiHost, cfg.Token, cfg.ImpersonateUser, cfg.ImpersonateScopes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// A GitHub App authenticates as the app itself with a JWT signed by its private key
+	var appClient *gogithub.Client
+	if cfg.AppID != 0 && cfg.AppPrivateKeyPath != "" {
+		key, err := loadAppPrivateKey(cfg.AppPrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		appClient = gogithub.NewClient(&http.Client{
+			Transport: &appJWTTransport{
+				transport: transport,
+				appID:     cfg.AppID,
+				key:       key,
+				now:       time.Now,
+			},
+		})
+		appClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
+		appClient.BaseURL = apiHost.baseRESTURL
+		appClient.UploadURL = apiHost.uploadURL
+	}
+
+	// With an installation ID, requests authenticate as that installation of the app instead of with the Token
+	var installation *installationTokens
+	if cfg.AppInstallationID != 0 {
+		if appClient == nil {
+			return nil, fmt.Errorf("authenticating as a GitHub App installation requires the app ID and private key")
+		}
+		installation = &installationTokens{appClient: appClient, installationID: cfg.AppInstallationID, now: time.Now}
+		// Mint the first token right away, so that a wrong configuration fails at startup
+		if _, err := installation.get(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+
 	// Construct our REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
-	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
+	// Requests of the HTTP transport may bring their own token, which bearerAuthTransport picks up from the context.
+	// Only the requests of tools are held back in dry runs, not the ones that mint installation tokens
+	// Requests carry the user agent of the client of their session, which sessions tell when they initialize
+	agents := newUserAgents(fmt.Sprintf("github-mcp-server/%s", cfg.Version))
+	restClient := gogithub.NewClient(&http.Client{
+		Transport: &userAgentTransport{
+			transport: &bearerAuthTransport{
+				transport:    dryrun.NewTransport(transport),
+				token:        token,
+				installation: installation,
+			},
+			agents: agents,
+		},
+	})
+	restClient.UserAgent = agents.base
 	restClient.BaseURL = apiHost.baseRESTURL
 	restClient.UploadURL = apiHost.uploadURL
 
@@ -65,32 +344,39 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	// We're using NewEnterpriseClient here unconditionally as opposed to NewClient because we already
 	// did the necessary API host parsing so that github.com will return the correct URL anyway.
 	gqlHTTPClient := &http.Client{
-		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
-			token:     cfg.Token,
+		Transport: &userAgentTransport{
+			transport: &bearerAuthTransport{
+				transport:    dryrun.NewTransport(transport),
+				token:        token,
+				installation: installation,
+			},
+			agents: agents,
 		},
-	} // We're going to wrap the Transport later in beforeInit
+	}
 	gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
 
-	// When a client send an initialize request, update the user agent to include the client info.
-	beforeInit := func(_ context.Context, _ any, message *mcp.InitializeRequest) {
-		userAgent := fmt.Sprintf(
-			"github-mcp-server/%s (%s/%s)",
-			cfg.Version,
-			message.Params.ClientInfo.Name,
-			message.Params.ClientInfo.Version,
-		)
-
-		restClient.UserAgent = userAgent
-
-		gqlHTTPClient.Transport = &userAgentTransport{
-			transport: gqlHTTPClient.Transport,
-			agent:     userAgent,
+	// When a client send an initialize request, record the user agent of its session to include the client info.
+	beforeInit := func(ctx context.Context, _ any, message *mcp.InitializeRequest) {
+		session := server.ClientSessionFromContext(ctx)
+		if session == nil {
+			return
 		}
+		agents.set(session.SessionID(), message.Params.ClientInfo)
 	}
 
+	// Created with the server below, sessions only end once it exists
+	var subscriptions *github.ResourceSubscriptions
+	var completions *github.ArgumentCompletions
 	hooks := &server.Hooks{
 		OnBeforeInitialize: []server.OnBeforeInitializeFunc{beforeInit},
+		OnAfterListTools:   []server.OnAfterListToolsFunc{structured.AddDefaultOutputSchemas},
+		OnUnregisterSession: []server.OnUnregisterSessionHookFunc{
+			func(_ context.Context, session server.ClientSession) {
+				subscriptions.RemoveSession(session.SessionID())
+				completions.RemoveSession(session.SessionID())
+				agents.remove(session.SessionID())
+			},
+		},
 		OnBeforeAny: []server.BeforeAnyHookFunc{
 			func(ctx context.Context, _ any, _ mcp.MCPMethod, _ any) {
 				// Ensure the context is cleared of any previous errors
@@ -100,7 +386,106 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		},
 	}
 
-	ghServer := github.NewServer(cfg.Version, server.WithHooks(hooks))
+	// Requests to destinations other than the GitHub API, such as log downloads, share the transport
+	// (and so the egress guard) but never the credentials.
+	downloadClient := &http.Client{Transport: transport}
+
+	cancellations := github.NewRequestCancellations()
+	serverOpts := []server.ServerOption{
+		server.WithHooks(hooks),
+		// First, so that results are structured once the middleware below is done changing their text
+		server.WithToolHandlerMiddleware(structured.ToolHandlerMiddleware),
+		server.WithToolHandlerMiddleware(cancellations.Middleware),
+		server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+			return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return next(egress.ContextWithHTTPClient(ctx, downloadClient), request)
+			}
+		}),
+	}
+	if cfg.Tracer != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(cfg.Tracer.ToolHandlerMiddleware))
+	}
+	if cfg.Metrics != nil {
+		// Outside of the middleware below, so that the time the checks and rewrites of results take is
+		// measured too
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(cfg.Metrics.ToolHandlerMiddleware))
+		hooks.AddOnRegisterSession(cfg.Metrics.OnRegisterSession)
+		hooks.AddOnUnregisterSession(cfg.Metrics.OnUnregisterSession)
+	}
+	// Before the budget and the confirmations, so that they tell apart the callers by their tokens
+	if cfg.TokenPassthrough {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(tokenPassthroughMiddleware(cfg.Token != "" || cfg.AppInstallationID != 0)))
+	}
+	// The definitions are only known once the toolsets are created below, and never change afterwards
+	var toolDefinitions map[string]mcp.Tool
+	lookupTool := func(name string) (mcp.Tool, bool) {
+		tool, ok := toolDefinitions[name]
+		return tool, ok
+	}
+	// Outside of the cursors, which lead it from page to page
+	if cfg.StreamLists {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(streamlist.ToolHandlerMiddleware(lookupTool)))
+	}
+	// Outside of the budget, so that truncated listings still say where the next page starts
+	serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(cursor.ToolHandlerMiddleware(lookupTool)))
+	// Right after the structure and the cursors, so that the text it measures is the text that clients get
+	if cfg.MaxResponseTokens > 0 {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(budget.ToolHandlerMiddleware(budget.New(cfg.MaxResponseTokens), callerIdentity)))
+	}
+	if cfg.IncludeProvenance {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(provenance.ToolHandlerMiddleware))
+	}
+	if cfg.RelativeTimes {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(relativetime.ToolHandlerMiddleware))
+	}
+	// The input schemas are only known once the toolsets are created below, and never change afterwards
+	var toolProperties map[string]map[string]any
+	if cfg.ParseReferences {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(reference.ToolHandlerMiddleware(func(tool string) map[string]any {
+			return toolProperties[tool]
+		})))
+	}
+	// After parsing references, so that the audit log, the scope and the policy see the owner and repo
+	// that references name. Before the scope and the policy, so that the calls they deny are recorded
+	if cfg.AuditLog != nil {
+		auditLogger := mcplog.Subsystem(logger, "audit")
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(audit.ToolHandlerMiddleware(cfg.AuditLog, func(err error) {
+			auditLogger.Error("failed to write audit log", "error", err)
+		})))
+	}
+	var repoScope *scope.Scope
+	if len(cfg.AllowedRepos) > 0 || len(cfg.BlockedRepos) > 0 {
+		repoScope, err = scope.New(cfg.AllowedRepos, cfg.BlockedRepos)
+		if err != nil {
+			return nil, err
+		}
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(scope.ToolHandlerMiddleware(repoScope)))
+		hooks.AddOnRequestInitialization(repoScope.OnRequestInitialization)
+	}
+	var writeTools map[string]bool
+	if cfg.PolicyPath != "" {
+		p, err := policy.Load(cfg.PolicyPath)
+		if err != nil {
+			return nil, err
+		}
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(policy.ToolHandlerMiddleware(p, func(tool string) bool {
+			return writeTools[tool]
+		})))
+	}
+	// After the scope and the policy, so that the calls they deny are denied in dry runs as well
+	serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(dryrun.ToolHandlerMiddleware(cfg.DryRun, func(name string) (mcp.Tool, bool) {
+		tool, ok := toolDefinitions[name]
+		return tool, ok
+	})))
+	if cfg.ConfirmDestructive {
+		// After the dry runs, so that they run without confirmation
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(confirm.ToolHandlerMiddleware(confirm.NewConfirmer(0), func(name string) (mcp.Tool, bool) {
+			tool, ok := toolDefinitions[name]
+			return tool, ok
+		}, callerIdentity)))
+	}
+
+	ghServer := github.NewServer(cfg.Version, serverOpts...)
 
 	enabledToolsets := cfg.EnabledToolsets
 	if cfg.DynamicToolsets {
@@ -129,8 +514,74 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		return raw.NewClient(client, apiHost.rawURL), nil // closing over client
 	}
 
+	draftRules := github.DefaultDraftRules()
+	if cfg.DraftRulesPath != "" {
+		draftRules, err = github.LoadDraftRules(cfg.DraftRulesPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create default toolsets
-	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator)
+	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, draftRules, cfg.Translator)
+
+	if appClient != nil {
+		getAppClient := func(_ context.Context) (*gogithub.Client, error) {
+			return appClient, nil // closing over client
+		}
+		tsg.AddToolset(github.AppToolset(getAppClient, cfg.Translator))
+	}
+	if cfg.GraphQLQuery {
+		// The raw client shares the authenticated HTTP client of the typed one, and so its user agent
+		rawGQLClient := graphql.NewClient(gqlHTTPClient, apiHost.graphqlURL.String())
+		rawGQLClient.SetSchemaRefresh(cfg.GraphQLSchemaRefresh)
+		getRawGQLClient := func(_ context.Context) (*graphql.Client, error) {
+			return rawGQLClient, nil // closing over client
+		}
+		maxCost := cfg.GraphQLMaxCost
+		if maxCost <= 0 {
+			maxCost = github.DefaultGraphQLMaxCost
+		}
+		tsg.AddToolset(github.GraphQLToolset(getRawGQLClient, cfg.ReadOnly, cfg.GraphQLAllowMutations, maxCost, cfg.Translator))
+	}
+	if cfg.APIRequest {
+		allowlist := cfg.APIAllowlist
+		if len(allowlist) == 0 {
+			allowlist = github.DefaultAPIAllowlist
+		}
+		rules, err := github.ParseAPIAllowlist(allowlist)
+		if err != nil {
+			return nil, err
+		}
+		tsg.AddToolset(github.APIToolset(getClient, cfg.ReadOnly, rules, cfg.Translator))
+	}
+	if installation != nil {
+		tsg.Toolsets["checks"].AddWriteTools(github.CheckRunWriteTools(getClient, cfg.Translator)...)
+	}
+	if err := tsg.FilterTools(cfg.EnabledTools, cfg.ExcludedTools); err != nil {
+		return nil, fmt.Errorf("failed to filter tools: %w", err)
+	}
+	toolProperties = make(map[string]map[string]any)
+	writeTools = make(map[string]bool)
+	toolDefinitions = make(map[string]mcp.Tool)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAllTools() {
+			writeTools[tool.Tool.Name] = tool.Tool.Annotations.ReadOnlyHint == nil || !*tool.Tool.Annotations.ReadOnlyHint
+			if writeTools[tool.Tool.Name] {
+				// The properties are shared with the tool in the toolset, which is the one that is registered
+				dryrun.AddArgument(&tool.Tool)
+			}
+			if cfg.ConfirmDestructive && confirm.MayBeDestructive(tool.Tool) {
+				confirm.AddArgument(&tool.Tool)
+			}
+			if cfg.MaxResponseTokens > 0 {
+				budget.AddArgument(&tool.Tool)
+			}
+			toolProperties[tool.Tool.Name] = tool.Tool.InputSchema.Properties
+			toolDefinitions[tool.Tool.Name] = tool.Tool
+		}
+	}
+
 	err = tsg.EnableToolsets(enabledToolsets)
 
 	if err != nil {
@@ -143,9 +594,22 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	if cfg.DynamicToolsets {
 		dynamic := github.InitDynamicToolset(ghServer, tsg, cfg.Translator)
 		dynamic.RegisterTools(ghServer)
+	} else {
+		// The catalog is always on so that operators can discover what they could enable in configuration
+		ghServer.AddTool(github.ListAvailableToolsets(tsg, false, cfg.Translator))
 	}
 
-	return ghServer, nil
+	subscriptions = github.NewResourceSubscriptions(getClient, github.ServerNotifier(ghServer), cfg.ResourcePollInterval)
+	completions = github.NewArgumentCompletions(getClient)
+
+	return &mcpServer{
+		MCPServer:     ghServer,
+		subscriptions: subscriptions,
+		cancellations: cancellations,
+		completions:   completions,
+		scope:         repoScope,
+		agents:        agents,
+	}, nil
 }
 
 type StdioServerConfig struct {
@@ -162,6 +626,12 @@ type StdioServerConfig struct {
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
 
+	// EnabledTools narrows the tools of the enabled toolsets down to these, unless empty, and
+	// ExcludedTools withholds tools
+	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
+	EnabledTools  []string
+	ExcludedTools []string
+
 	// Whether to enable dynamic toolsets
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#dynamic-tool-discovery
 	DynamicToolsets bool
@@ -169,6 +639,14 @@ type StdioServerConfig struct {
 	// ReadOnly indicates if we should only register read-only tools
 	ReadOnly bool
 
+	// DryRun makes write tools describe the first request that would change something instead of sending
+	// it, as if every call set dry_run
+	DryRun bool
+
+	// ConfirmDestructive makes destructive tools return a confirmation token instead of running, and
+	// only run when called again with it
+	ConfirmDestructive bool
+
 	// ExportTranslations indicates if we should export translations
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
 	ExportTranslations bool
@@ -178,6 +656,128 @@ type StdioServerConfig struct {
 
 	// Path to the log file if not stderr
 	LogFilePath string
+
+	// LogLevel is the level of the records that are logged, one of debug, info, warn or error
+	LogLevel string
+
+	// LogFormat is how records are written, text or json
+	LogFormat string
+
+	// LogRotate rotates the log file once it grows beyond LogMaxSizeMB megabytes, keeping at most
+	// LogMaxBackups rotated files that are no older than LogMaxAge. Zero keeps all backups, of any age
+	LogRotate     bool
+	LogMaxSizeMB  int
+	LogMaxBackups int
+	LogMaxAge     time.Duration
+
+	// MaxIdleConns is the maximum number of idle (keep-alive) connections across all hosts
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is the maximum number of idle (keep-alive) connections per host
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection remains open
+	IdleConnTimeout time.Duration
+
+	// DisableKeepAlives forces a new connection for every request
+	DisableKeepAlives bool
+
+	// RateLimitMaxWait is how long a request waits at most for the GitHub API rate limit to reset, or for
+	// a secondary rate limit to pass, before failing. Zero fails right away instead of waiting
+	RateLimitMaxWait time.Duration
+
+	// MaxRetries is how many times a request that failed for a transient reason, such as a 502 or a reset
+	// connection, is retried. Only reads and GraphQL queries are retried. Zero never retries
+	MaxRetries int
+
+	// MaxResponseTokens is the size of tool results in tokens, beyond which they are truncated and the
+	// rest can be fetched with the cursor they return. Zero leaves results whole
+	MaxResponseTokens int
+
+	// CacheMaxSizeMB is the size of the cache of the responses that GET requests are made conditional on,
+	// zero disables it. The cache is kept in memory, or in CacheDir if set, which keeps it across restarts
+	CacheMaxSizeMB int
+	CacheDir       string
+
+	// IncludeProvenance attaches the GitHub request IDs and endpoints behind each tool result under `_meta.provenance`
+	IncludeProvenance bool
+
+	// RelativeTimes adds a `*_relative` companion such as "3 days ago" next to timestamps in tool results
+	RelativeTimes bool
+
+	// ParseReferences spreads URLs and `owner/repo#123` references in tool inputs into the discrete
+	// owner, repo and number inputs the tool expects
+	ParseReferences bool
+
+	// DraftRulesPath is a YAML or JSON file with the rules validate_issue_draft and
+	// validate_pull_request_draft apply, the defaults are used if empty
+	DraftRulesPath string
+
+	// PolicyPath is a YAML or JSON file with the policy that calls of write tools are checked against,
+	// all of them are allowed if empty
+	PolicyPath string
+
+	// AllowedRepos and BlockedRepos are owner/repo patterns, such as octo-org/*, that confine the server
+	// to the repositories that match one of AllowedRepos, unless empty, and none of BlockedRepos
+	AllowedRepos []string
+	BlockedRepos []string
+
+	// AuditLogPath is a file that every tool call is appended to as a line of JSON, or syslog to send
+	// them to the local syslog daemon. None are recorded if empty
+	AuditLogPath string
+
+	// MetricsAddr is the address to serve Prometheus metrics at, such as localhost:9090. None are served
+	// if empty
+	MetricsAddr string
+
+	// OTLPEndpoint is the OTLP collector to export traces to, such as http://localhost:4318. It falls
+	// back to OTEL_EXPORTER_OTLP_ENDPOINT, and none are exported if both are empty
+	OTLPEndpoint string
+
+	// AppID and AppPrivateKeyPath identify a GitHub App. When both are set, the app toolset is registered
+	AppID             int64
+	AppPrivateKeyPath string
+
+	// AppInstallationID makes the server authenticate as this installation of the GitHub App instead of
+	// with the Token, using installation tokens that are minted and refreshed before they expire
+	AppInstallationID int64
+
+	// DisableTelemetry turns off any telemetry export, including traces to an OTLP collector
+	DisableTelemetry bool
+
+	// NoExternalEgress confines all outbound requests to the configured GitHub host
+	NoExternalEgress bool
+
+	// ImpersonateUser makes the server act as this user on GitHub Enterprise Server, by exchanging the
+	// site administrator Token for an impersonation token with ImpersonateScopes
+	ImpersonateUser   string
+	ImpersonateScopes []string
+
+	// TokenPassthrough makes tool calls act with the GitHub token in their `_meta`, or in the Authorization
+	// header of the HTTP transports, so that one server can act on behalf of many users. The Token, if
+	// any, is used for calls that bring none
+	TokenPassthrough bool
+
+	// GraphQLQuery registers the graphql toolset, whose graphql_query tool runs arbitrary GraphQL queries
+	// once they validate against the schema of the API and stay within GraphQLMaxCost estimated nodes
+	GraphQLQuery   bool
+	GraphQLMaxCost int
+
+	// GraphQLAllowMutations lets graphql_query run mutations, unless the server is read-only
+	GraphQLAllowMutations bool
+
+	// GraphQLSchemaRefresh makes graphql_query validate against the schema introspected from the host
+	// instead of the bundled one
+	GraphQLSchemaRefresh bool
+
+	// APIRequest registers the api toolset, whose api_request tool sends arbitrary REST requests that
+	// match one of the APIAllowlist entries, such as "GET /repos/*/*/issues/**"
+	APIRequest   bool
+	APIAllowlist []string
+
+	// ResourcePollInterval is how often the issues and pull requests clients subscribed to are checked for
+	// changes
+	ResourcePollInterval time.Duration
 }
 
 // RunStdioServer is not concurrent safe.
@@ -188,33 +788,39 @@ func RunStdioServer(cfg StdioServerConfig) error {
 
 	t, dumpTranslations := translations.TranslationHelper()
 
-	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:         cfg.Version,
-		Host:            cfg.Host,
-		Token:           cfg.Token,
-		EnabledToolsets: cfg.EnabledToolsets,
-		DynamicToolsets: cfg.DynamicToolsets,
-		ReadOnly:        cfg.ReadOnly,
-		Translator:      t,
-	})
+	auditLog, closeAuditLog, err := cfg.newAuditLog()
+	if err != nil {
+		return err
+	}
+	defer closeAuditLog()
+
+	logger, closeLog, err := cfg.newLogger()
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
+	tracer, closeTracer, err := cfg.newTracer(logger)
+	if err != nil {
+		return err
+	}
+	defer closeTracer()
+
+	serverMetrics := cfg.newMetrics()
+	ghServer, err := newMCPServer(cfg.mcpServerConfig(t, auditLog, serverMetrics, tracer, logger))
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
 	}
+	go ghServer.subscriptions.Run(ctx)
 
-	stdioServer := server.NewStdioServer(ghServer)
+	stdioServer := server.NewStdioServer(ghServer.MCPServer)
+	stdioServer.SetErrorLogger(mcplog.NewStdLogger(logger, "stdioserver"))
 
-	logrusLogger := logrus.New()
-	if cfg.LogFilePath != "" {
-		file, err := os.OpenFile(cfg.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
-		if err != nil {
-			return fmt.Errorf("failed to open log file: %w", err)
-		}
+	cfg.warnImpersonation(logger)
 
-		logrusLogger.SetLevel(logrus.DebugLevel)
-		logrusLogger.SetOutput(file)
+	if err := cfg.serveMetrics(ctx, serverMetrics, logger); err != nil {
+		return err
 	}
-	stdLogger := log.New(logrusLogger.Writer(), "stdioserver", 0)
-	stdioServer.SetErrorLogger(stdLogger)
 
 	if cfg.ExportTranslations {
 		// Once server is initialized, all translations are loaded
@@ -227,9 +833,11 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		in, out := io.Reader(os.Stdin), io.Writer(os.Stdout)
 
 		if cfg.EnableCommandLogging {
-			loggedIO := mcplog.NewIOLogger(in, out, logrusLogger)
+			loggedIO := mcplog.NewIOLogger(in, out, mcplog.Subsystem(logger, "stdio"))
 			in, out = loggedIO, loggedIO
 		}
+		in = interceptCancellations(ghServer.cancellations, in)
+		in, out = interceptRequests(ctx, in, capabilityWriter{out}, ghServer.messageHandlers()...)
 		// enable GitHub errors in the context
 		ctx := errors.ContextWithGitHubErrors(ctx)
 		errC <- stdioServer.Listen(ctx, in, out)
@@ -241,7 +849,7 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	// Wait for shutdown signal
 	select {
 	case <-ctx.Done():
-		logrusLogger.Infof("shutting down server...")
+		logger.Info("shutting down server")
 	case err := <-errC:
 		if err != nil {
 			return fmt.Errorf("error running server: %w", err)
@@ -251,11 +859,202 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	return nil
 }
 
+// mcpServerConfig returns the configuration of the MCP server behind the transport.
+func (cfg StdioServerConfig) mcpServerConfig(t translations.TranslationHelperFunc, auditLog *audit.Logger, serverMetrics *metrics.Metrics, tracer *tracing.Tracer, logger *slog.Logger) MCPServerConfig {
+	return MCPServerConfig{
+		Version:               cfg.Version,
+		Host:                  cfg.Host,
+		Token:                 cfg.Token,
+		EnabledToolsets:       cfg.EnabledToolsets,
+		EnabledTools:          cfg.EnabledTools,
+		ExcludedTools:         cfg.ExcludedTools,
+		DynamicToolsets:       cfg.DynamicToolsets,
+		ReadOnly:              cfg.ReadOnly,
+		DryRun:                cfg.DryRun,
+		ConfirmDestructive:    cfg.ConfirmDestructive,
+		Translator:            t,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		DisableKeepAlives:     cfg.DisableKeepAlives,
+		RateLimitMaxWait:      cfg.RateLimitMaxWait,
+		MaxRetries:            cfg.MaxRetries,
+		MaxResponseTokens:     cfg.MaxResponseTokens,
+		CacheMaxSizeMB:        cfg.CacheMaxSizeMB,
+		CacheDir:              cfg.CacheDir,
+		IncludeProvenance:     cfg.IncludeProvenance,
+		RelativeTimes:         cfg.RelativeTimes,
+		ParseReferences:       cfg.ParseReferences,
+		DraftRulesPath:        cfg.DraftRulesPath,
+		PolicyPath:            cfg.PolicyPath,
+		AllowedRepos:          cfg.AllowedRepos,
+		BlockedRepos:          cfg.BlockedRepos,
+		AuditLog:              auditLog,
+		Metrics:               serverMetrics,
+		Tracer:                tracer,
+		Logger:                logger,
+		AppID:                 cfg.AppID,
+		AppPrivateKeyPath:     cfg.AppPrivateKeyPath,
+		AppInstallationID:     cfg.AppInstallationID,
+		DisableTelemetry:      cfg.DisableTelemetry,
+		NoExternalEgress:      cfg.NoExternalEgress,
+		ImpersonateUser:       cfg.ImpersonateUser,
+		ImpersonateScopes:     cfg.ImpersonateScopes,
+		TokenPassthrough:      cfg.TokenPassthrough,
+		GraphQLQuery:          cfg.GraphQLQuery,
+		GraphQLMaxCost:        cfg.GraphQLMaxCost,
+		GraphQLAllowMutations: cfg.GraphQLAllowMutations,
+		GraphQLSchemaRefresh:  cfg.GraphQLSchemaRefresh,
+		APIRequest:            cfg.APIRequest,
+		APIAllowlist:          cfg.APIAllowlist,
+		ResourcePollInterval:  cfg.ResourcePollInterval,
+	}
+}
+
+// newLogger returns the logger of the server, writing to the log file if one is configured. The returned
+// function closes the log file.
+func (cfg StdioServerConfig) newLogger() (*slog.Logger, func(), error) {
+	level := cfg.LogLevel
+	if level == "" {
+		level = "info"
+	}
+	var out io.WriteCloser = os.Stderr
+	closeLog := func() {}
+	if cfg.LogFilePath != "" {
+		var err error
+		if cfg.LogRotate {
+			out, err = mcplog.NewRotatingFile(cfg.LogFilePath, mcplog.RotateOptions{
+				MaxSize:    int64(cfg.LogMaxSizeMB) << 20,
+				MaxBackups: cfg.LogMaxBackups,
+				MaxAge:     cfg.LogMaxAge,
+			})
+		} else {
+			out, err = os.OpenFile(cfg.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		closeLog = func() { _ = out.Close() }
+	}
+	logger, err := mcplog.New(out, level, cfg.LogFormat)
+	if err != nil {
+		closeLog()
+		return nil, nil, err
+	}
+	return logger, closeLog, nil
+}
+
+// newAuditLog opens the audit log if one is configured. The returned function closes it.
+func (cfg StdioServerConfig) newAuditLog() (*audit.Logger, func(), error) {
+	if cfg.AuditLogPath == "" {
+		return nil, func() {}, nil
+	}
+	auditLog, closer, err := audit.Open(cfg.AuditLogPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return auditLog, func() { _ = closer.Close() }, nil
+}
+
+// newTracer returns the tracer of the server if traces are exported, or nil. The returned function exports
+// the last spans.
+func (cfg StdioServerConfig) newTracer(logger *slog.Logger) (*tracing.Tracer, func(), error) {
+	endpoint := cfg.OTLPEndpoint
+	if endpoint == "" {
+		endpoint = os.Getenv(tracing.EndpointEnv)
+	}
+	if endpoint == "" {
+		return nil, func() {}, nil
+	}
+	if cfg.DisableTelemetry {
+		logger.Warn("not exporting traces, telemetry is disabled", "endpoint", endpoint)
+		return nil, func() {}, nil
+	}
+	if cfg.NoExternalEgress {
+		return nil, nil, fmt.Errorf("exporting traces to an OTLP collector can't be combined with --no-external-egress")
+	}
+	tracingLogger := mcplog.Subsystem(logger, "tracing")
+	tracer, err := tracing.New(endpoint, cfg.Version, func(err error) {
+		tracingLogger.Warn("failed to export traces", "error", err)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tracer, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := tracer.Shutdown(ctx); err != nil {
+			tracingLogger.Warn("failed to export traces", "error", err)
+		}
+	}, nil
+}
+
+// newMetrics returns the metrics of the server if they are served, or nil.
+func (cfg StdioServerConfig) newMetrics() *metrics.Metrics {
+	if cfg.MetricsAddr == "" {
+		return nil
+	}
+	return metrics.New()
+}
+
+// serveMetrics serves the metrics at the metrics address until the context is done, if they are served.
+// It listens right away, so that a taken address fails before the server runs.
+func (cfg StdioServerConfig) serveMetrics(ctx context.Context, serverMetrics *metrics.Metrics, logger *slog.Logger) error {
+	if serverMetrics == nil {
+		return nil
+	}
+	listener, err := net.Listen("tcp", cfg.MetricsAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", cfg.MetricsAddr, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(metrics.Path, serverMetrics.Handler())
+	metricsServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		ErrorLog:          mcplog.NewStdLogger(logger, "metricsserver"),
+	}
+	go func() {
+		if err := metricsServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logger.Error("failed to serve metrics", mcplog.SubsystemKey, "metrics", "error", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = metricsServer.Close()
+	}()
+	logger.Info("serving metrics", mcplog.SubsystemKey, "metrics", "url", "http://"+listener.Addr().String()+metrics.Path)
+	return nil
+}
+
+// warnImpersonation tells whoever runs the server that it acts as another user.
+func (cfg StdioServerConfig) warnImpersonation(logger *slog.Logger) {
+	if cfg.ImpersonateUser != "" {
+		// Everything the agent does is attributed to someone else, make sure whoever runs the server knows
+		_, _ = fmt.Fprintf(os.Stderr, "WARNING: impersonating GitHub Enterprise Server user %s, all actions are attributed to them\n", cfg.ImpersonateUser)
+		logger.Warn("impersonating a GitHub Enterprise Server user, all actions are attributed to them", "user", cfg.ImpersonateUser)
+	}
+}
+
 type apiHost struct {
 	baseRESTURL *url.URL
 	graphqlURL  *url.URL
 	uploadURL   *url.URL
 	rawURL      *url.URL
+
+	// enterpriseServer is set for GitHub Enterprise Server hosts
+	enterpriseServer bool
+}
+
+// hosts returns the hostnames of all GitHub endpoints, which is the complete set of
+// destinations allowed when external egress is disabled.
+func (h apiHost) hosts() []string {
+	return []string{
+		h.baseRESTURL.Hostname(),
+		h.graphqlURL.Hostname(),
+		h.uploadURL.Hostname(),
+		h.rawURL.Hostname(),
+	}
 }
 
 func newDotcomHost() (apiHost, error) {
@@ -352,10 +1151,11 @@ func newGHESHost(hostname string) (apiHost, error) {
 	}
 
 	return apiHost{
-		baseRESTURL: restURL,
-		graphqlURL:  gqlURL,
-		uploadURL:   uploadURL,
-		rawURL:      rawURL,
+		baseRESTURL:      restURL,
+		graphqlURL:       gqlURL,
+		uploadURL:        uploadURL,
+		rawURL:           rawURL,
+		enterpriseServer: true,
 	}, nil
 }
 
@@ -385,24 +1185,96 @@ func parseAPIHost(s string) (apiHost, error) {
 	return newGHESHost(s)
 }
 
+// newHTTPTransport clones the default transport and applies the connection pool tuning from the config.
+// Zero values leave the corresponding Go defaults in place.
+func newHTTPTransport(cfg MCPServerConfig) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.MaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.MaxIdleConns
+	}
+	if cfg.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	if cfg.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+	transport.DisableKeepAlives = cfg.DisableKeepAlives
+	return transport
+}
+
+// userAgents are the user agents of the sessions of the server, which name the client of the session
+// next to the server.
+type userAgents struct {
+	base string
+
+	mu     sync.RWMutex
+	agents map[string]string
+}
+
+func newUserAgents(base string) *userAgents {
+	return &userAgents{base: base, agents: make(map[string]string)}
+}
+
+// set records the client of a session.
+func (u *userAgents) set(sessionID string, client mcp.Implementation) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.agents[sessionID] = fmt.Sprintf("%s (%s/%s)", u.base, client.Name, client.Version)
+}
+
+// remove forgets the client of a session that ended.
+func (u *userAgents) remove(sessionID string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.agents, sessionID)
+}
+
+// get returns the user agent of the session of a request context, or the base user agent for requests
+// outside of a session or of sessions that didn't initialize.
+func (u *userAgents) get(ctx context.Context) string {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return u.base
+	}
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	if agent, ok := u.agents[session.SessionID()]; ok {
+		return agent
+	}
+	return u.base
+}
+
+// userAgentTransport sets the user agent of the session each request is made for.
 type userAgentTransport struct {
 	transport http.RoundTripper
-	agent     string
+	agents    *userAgents
 }
 
 func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	req = req.Clone(req.Context())
-	req.Header.Set("User-Agent", t.agent)
+	req.Header.Set("User-Agent", t.agents.get(req.Context()))
 	return t.transport.RoundTrip(req)
 }
 
 type bearerAuthTransport struct {
 	transport http.RoundTripper
 	token     string
+	// installation replaces token with installation tokens of a GitHub App if set
+	installation *installationTokens
 }
 
+// RoundTrip authenticates with the token of the request context if it has one, see contextWithToken.
 func (t *bearerAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := t.token
+	if contextToken, ok := tokenFromContext(req.Context()); ok {
+		token = contextToken
+	} else if t.installation != nil {
+		var err error
+		if token, err = t.installation.get(req.Context()); err != nil {
+			return nil, err
+		}
+	}
 	req = req.Clone(req.Context())
-	req.Header.Set("Authorization", "Bearer "+t.token)
+	req.Header.Set("Authorization", "Bearer "+token)
 	return t.transport.RoundTrip(req)
 }
@@ -0,0 +1,71 @@
+package ghmcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// idSession is a client session that is only known by its ID.
+type idSession string
+
+func (s idSession) Initialize()                                         {}
+func (s idSession) Initialized() bool                                   { return true }
+func (s idSession) SessionID() string                                   { return string(s) }
+func (s idSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+
+func TestUserAgentsOfSessions(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		received[r.URL.Path] = r.Header.Get("User-Agent")
+	}))
+	t.Cleanup(srv.Close)
+
+	agents := newUserAgents("github-mcp-server/1.0")
+	client := &http.Client{Transport: &userAgentTransport{transport: http.DefaultTransport, agents: agents}}
+	mcpServer := server.NewMCPServer("test", "1")
+	agents.set("a", mcp.Implementation{Name: "vscode", Version: "1.99"})
+	agents.set("b", mcp.Implementation{Name: "claude-desktop", Version: "0.9"})
+
+	get := func(ctx context.Context, path string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("User-Agent", "go-github")
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	// Sessions make their requests at once, each with the user agent of its own client
+	var wg sync.WaitGroup
+	for path, session := range map[string]string{"/a": "a", "/b": "b", "/unknown": "unknown"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			get(mcpServer.WithContext(context.Background(), idSession(session)), path)
+		}()
+	}
+	wg.Wait()
+	get(context.Background(), "/none")
+
+	assert.Equal(t, map[string]string{
+		"/a":       "github-mcp-server/1.0 (vscode/1.99)",
+		"/b":       "github-mcp-server/1.0 (claude-desktop/0.9)",
+		"/unknown": "github-mcp-server/1.0",
+		"/none":    "github-mcp-server/1.0",
+	}, received)
+
+	agents.remove("a")
+	get(mcpServer.WithContext(context.Background(), idSession("a")), "/a")
+	assert.Equal(t, "github-mcp-server/1.0", received["/a"], "sessions that ended are forgotten")
+}
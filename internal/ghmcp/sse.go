@@ -0,0 +1,178 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/errors"
+	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// SSEServerConfig configures the server that MCP clients connect to over HTTP with the SSE transport.
+type SSEServerConfig struct {
+	// StdioServerConfig holds the options shared with the stdio server
+	StdioServerConfig
+
+	// ListenAddr is the address to listen on, such as localhost:8080
+	ListenAddr string
+
+	// BaseURL is the URL clients reach the server at, if it differs from the listen address, for example
+	// behind a reverse proxy. It is used to tell clients where to post their messages.
+	BaseURL string
+
+	// TLSCertFile and TLSKeyFile serve HTTPS when both are set
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// ShutdownTimeout is how long open connections get to finish when the server shuts down
+	ShutdownTimeout time.Duration
+}
+
+// RunSSEServer is not concurrent safe.
+func RunSSEServer(cfg SSEServerConfig) error {
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return fmt.Errorf("both a TLS certificate and key are required to serve HTTPS")
+	}
+
+	// Create app context
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	t, dumpTranslations := translations.TranslationHelper()
+
+	auditLog, closeAuditLog, err := cfg.newAuditLog()
+	if err != nil {
+		return err
+	}
+	defer closeAuditLog()
+
+	logger, closeLog, err := cfg.newLogger()
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
+	tracer, closeTracer, err := cfg.newTracer(logger)
+	if err != nil {
+		return err
+	}
+	defer closeTracer()
+
+	serverMetrics := cfg.newMetrics()
+	ghServer, err := newMCPServer(cfg.mcpServerConfig(t, auditLog, serverMetrics, tracer, logger))
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+	go ghServer.subscriptions.Run(ctx)
+
+	cfg.warnImpersonation(logger)
+
+	if err := cfg.serveMetrics(ctx, serverMetrics, logger); err != nil {
+		return err
+	}
+
+	if cfg.ExportTranslations {
+		// Once server is initialized, all translations are loaded
+		dumpTranslations()
+	}
+
+	httpServer := &http.Server{
+		Addr:              cfg.ListenAddr,
+		ReadHeaderTimeout: 10 * time.Second,
+		ErrorLog:          mcplog.NewStdLogger(logger, "sseserver"),
+	}
+	contextFunc := func(ctx context.Context, r *http.Request) context.Context {
+		// enable GitHub errors in the context
+		ctx = errors.ContextWithGitHubErrors(ctx)
+		if token := bearerToken(r); token != "" && cfg.TokenPassthrough {
+			ctx = contextWithToken(ctx, token)
+		}
+		return ctx
+	}
+	sseServer := server.NewSSEServer(ghServer.MCPServer,
+		server.WithHTTPServer(httpServer),
+		server.WithBaseURL(cfg.BaseURL),
+		server.WithSSEContextFunc(contextFunc),
+	)
+
+	handler := serveSSERequests(serveSSECompletionsCapability(sseServer, sseServer), sseServer, contextFunc, ghServer.messageHandlers()...)
+	handler = serveCancellations(handler, ghServer.cancellations, sseSessionID)
+	if cfg.EnableCommandLogging {
+		handler = logMessages(handler, logger, "sse")
+	}
+	httpServer.Handler = handler
+
+	// Listen before reporting that the server runs, so that a taken address fails right away
+	listener, err := net.Listen("tcp", cfg.ListenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", cfg.ListenAddr, err)
+	}
+
+	errC := make(chan error, 1)
+	go func() {
+		if cfg.TLSCertFile != "" {
+			errC <- httpServer.ServeTLS(listener, cfg.TLSCertFile, cfg.TLSKeyFile)
+		} else {
+			errC <- httpServer.Serve(listener)
+		}
+	}()
+
+	scheme := "http"
+	if cfg.TLSCertFile != "" {
+		scheme = "https"
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "GitHub MCP Server running on SSE at %s://%s%s\n", scheme, listener.Addr(), sseServer.CompleteSsePath())
+
+	// Wait for shutdown signal
+	select {
+	case <-ctx.Done():
+		logger.Info("shutting down server")
+	case err := <-errC:
+		if err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("error running server: %w", err)
+		}
+		return nil
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := sseServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	return nil
+}
+
+// sseSessionID returns the session of a message posted to the SSE transport.
+func sseSessionID(r *http.Request) string {
+	return r.URL.Query().Get("sessionId")
+}
+
+// logMessages logs the JSON-RPC messages clients post, the counterpart of the IOLogger of the stdio server.
+// Responses are sent on the SSE stream and not logged.
+func logMessages(next http.Handler, logger *slog.Logger, transport string) http.Handler {
+	logger = mcplog.Subsystem(logger, transport)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.Body != nil {
+			body, err := io.ReadAll(r.Body)
+			_ = r.Body.Close()
+			if err != nil {
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+				return
+			}
+			logger.Info("received", "bytes", len(body), "data", string(body))
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
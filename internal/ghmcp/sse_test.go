@@ -0,0 +1,146 @@
+package ghmcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// methodHandler answers the requests of a single method with an empty result.
+type methodHandler string
+
+func (h methodHandler) HandleMessage(_ context.Context, _ string, message json.RawMessage) (mcp.JSONRPCMessage, bool) {
+	var request struct {
+		ID     mcp.RequestId `json:"id"`
+		Method string        `json:"method"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil || request.Method != string(h) {
+		return nil, false
+	}
+	return mcp.JSONRPCResponse{JSONRPC: mcp.JSONRPC_VERSION, ID: request.ID, Result: mcp.EmptyResult{}}, true
+}
+
+// openSSE opens a session of the SSE transport, and returns the URL to post its messages to and the data of
+// the messages it receives.
+func openSSE(ctx context.Context, t *testing.T, url string) (string, <-chan string) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/sse", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	endpoint := make(chan string, 1)
+	messages := make(chan string, 10)
+	go func() {
+		defer func() { _ = resp.Body.Close() }()
+		scanner := bufio.NewScanner(resp.Body)
+		var event string
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: ") && event == "endpoint":
+				endpoint <- strings.TrimPrefix(line, "data: ")
+			case strings.HasPrefix(line, "data: "):
+				messages <- strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+
+	select {
+	case e := <-endpoint:
+		if strings.HasPrefix(e, "/") {
+			e = url + e
+		}
+		return e, messages
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for the endpoint of the session")
+		return "", nil
+	}
+}
+
+func post(t *testing.T, endpoint, message string) {
+	t.Helper()
+	resp, err := http.Post(endpoint, "application/json", strings.NewReader(message))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+}
+
+// receiveMessage returns the next message of a session.
+func receiveMessage(t *testing.T, messages <-chan string) map[string]any {
+	t.Helper()
+	select {
+	case data := <-messages:
+		var message map[string]any
+		require.NoError(t, json.Unmarshal([]byte(data), &message))
+		return message
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for a message")
+		return nil
+	}
+}
+
+func TestSSEServer(t *testing.T) {
+	cancellations := github.NewRequestCancellations()
+	mcpServer := server.NewMCPServer("test", "1",
+		server.WithResourceCapabilities(true, true),
+		server.WithToolHandlerMiddleware(cancellations.Middleware),
+	)
+	started := make(chan struct{})
+	mcpServer.AddTool(mcp.NewTool("wait"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	sseServer := server.NewSSEServer(mcpServer)
+	contextFunc := func(ctx context.Context, _ *http.Request) context.Context { return ctx }
+	handler := serveSSERequests(serveSSECompletionsCapability(sseServer, sseServer), sseServer, contextFunc, methodHandler("resources/subscribe"))
+	handler = serveCancellations(handler, cancellations, sseSessionID)
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	endpoint, messages := openSSE(ctx, t, srv.URL)
+
+	// The initialize response declares the completions capability
+	post(t, endpoint, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","clientInfo":{"name":"test","version":"1"}}}`)
+	initialized := receiveMessage(t, messages)
+	assert.EqualValues(t, 1, initialized["id"])
+	capabilities := initialized["result"].(map[string]any)["capabilities"].(map[string]any)
+	assert.Contains(t, capabilities, "completions")
+	assert.Contains(t, capabilities, "resources")
+
+	// Requests that a handler takes are answered on the stream
+	post(t, endpoint, `{"jsonrpc":"2.0","id":2,"method":"resources/subscribe","params":{"uri":"repo://owner/repo/issues/1"}}`)
+	subscribed := receiveMessage(t, messages)
+	assert.EqualValues(t, 2, subscribed["id"])
+	assert.Equal(t, map[string]any{}, subscribed["result"])
+
+	// Cancelled tool calls end right away
+	post(t, endpoint, `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"wait"}}`)
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for the tool call")
+	}
+	post(t, endpoint, `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":3}}`)
+	cancelled := receiveMessage(t, messages)
+	assert.EqualValues(t, 3, cancelled["id"])
+	assert.Contains(t, cancelled, "error")
+}
@@ -0,0 +1,258 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// headerLastEventID carries the ID of the last event a client received, when it reconnects to a stream
+	headerLastEventID = "Last-Event-ID"
+
+	// maxStreamEvents is how many of the latest messages of a stream are kept for a client that reconnects
+	maxStreamEvents = 100
+)
+
+// streamEvent is a message of a stream and its event ID.
+type streamEvent struct {
+	id   uint64
+	data []byte
+}
+
+// serverStream is the stream of the messages that the server sends to the client of a session outside of
+// the responses to its requests, such as resource updates, which the client listens to with a GET request.
+// It is a session of the MCP server from the first GET request of the session until the session ends, so
+// that the messages sent while the client is disconnected are kept for it, and it numbers the messages so
+// that a client that reconnects with the Last-Event-ID header receives the ones it missed.
+type serverStream struct {
+	sessionID     string
+	notifications chan mcp.JSONRPCNotification
+	done          chan struct{}
+
+	mu sync.Mutex
+	// events are the latest messages, at most maxStreamEvents of them
+	events []streamEvent
+	lastID uint64
+	// changed is closed and replaced when there are new events, the stream ends or another connection takes
+	// over the stream
+	changed    chan struct{}
+	connection uint64
+	ended      bool
+}
+
+var _ server.ClientSession = (*serverStream)(nil)
+
+func newServerStream(sessionID string) *serverStream {
+	s := &serverStream{
+		sessionID:     sessionID,
+		notifications: make(chan mcp.JSONRPCNotification, maxStreamEvents),
+		done:          make(chan struct{}),
+		changed:       make(chan struct{}),
+	}
+	go s.record()
+	return s
+}
+
+func (s *serverStream) SessionID() string { return s.sessionID }
+
+func (s *serverStream) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+
+// The session was initialized by the POST request that started it, before any GET request.
+func (s *serverStream) Initialize() {}
+
+func (s *serverStream) Initialized() bool { return true }
+
+// record numbers the notifications of the session and keeps the latest of them.
+func (s *serverStream) record() {
+	for {
+		select {
+		case notification := <-s.notifications:
+			data, err := json.Marshal(notification)
+			if err != nil {
+				continue
+			}
+			s.mu.Lock()
+			s.lastID++
+			s.events = append(s.events, streamEvent{id: s.lastID, data: data})
+			if len(s.events) > maxStreamEvents {
+				s.events = append([]streamEvent(nil), s.events[len(s.events)-maxStreamEvents:]...)
+			}
+			s.notifyLocked()
+			s.mu.Unlock()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// notifyLocked wakes up the connection of the stream, the caller must hold mu.
+func (s *serverStream) notifyLocked() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// connect makes a new connection the one the stream is sent to, starting after the event lastEventID. A
+// lastEventID of zero starts with the messages sent from now on. It returns the ID of the connection.
+func (s *serverStream) connect(lastEventID uint64) (uint64, uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connection++
+	// Only one connection gets the messages, the one it replaces ends
+	s.notifyLocked()
+	if lastEventID == 0 || lastEventID > s.lastID {
+		lastEventID = s.lastID
+	}
+	return s.connection, lastEventID
+}
+
+// next returns the events after the event lastEventID, and a channel that is closed once there is more to
+// send. It reports false once the stream ended or another connection took it over.
+func (s *serverStream) next(connection, lastEventID uint64) ([]streamEvent, <-chan struct{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended || s.connection != connection {
+		return nil, nil, false
+	}
+	var events []streamEvent
+	for _, event := range s.events {
+		if event.id > lastEventID {
+			events = append(events, event)
+		}
+	}
+	return events, s.changed, true
+}
+
+// end ends the stream, and the connection to it.
+func (s *serverStream) end() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.ended = true
+	close(s.done)
+	s.notifyLocked()
+}
+
+// serve sends the stream to a connection until the client disconnects, the stream ends or another
+// connection takes it over.
+func (s *serverStream) serve(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	// Event IDs that don't parse are treated like none
+	lastEventID, _ := strconv.ParseUint(strings.TrimSpace(r.Header.Get(headerLastEventID)), 10, 64)
+	connection, lastEventID := s.connect(lastEventID)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		events, changed, ok := s.next(connection, lastEventID)
+		if !ok {
+			return
+		}
+		for _, event := range events {
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: message\ndata: %s\n\n", event.id, event.data); err != nil {
+				return
+			}
+			lastEventID = event.id
+		}
+		if len(events) > 0 {
+			flusher.Flush()
+		}
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// serverStreams are the streams of the sessions of the streamable HTTP transport.
+type serverStreams struct {
+	mcpServer *server.MCPServer
+
+	mu      sync.Mutex
+	streams map[string]*serverStream
+}
+
+func newServerStreams(mcpServer *server.MCPServer) *serverStreams {
+	return &serverStreams{mcpServer: mcpServer, streams: make(map[string]*serverStream)}
+}
+
+// stream returns the stream of a session, starting it on first use.
+func (s *serverStreams) stream(ctx context.Context, sessionID string) (*serverStream, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stream, ok := s.streams[sessionID]; ok {
+		return stream, nil
+	}
+	stream := newServerStream(sessionID)
+	if err := s.mcpServer.RegisterSession(ctx, stream); err != nil {
+		stream.end()
+		return nil, err
+	}
+	s.streams[sessionID] = stream
+	return stream, nil
+}
+
+// end ends the stream of a session that ended, if it has one.
+func (s *serverStreams) end(sessionID string) {
+	s.mu.Lock()
+	stream, ok := s.streams[sessionID]
+	delete(s.streams, sessionID)
+	s.mu.Unlock()
+	if ok {
+		s.mcpServer.UnregisterSession(context.Background(), sessionID)
+		stream.end()
+	}
+}
+
+// endAll ends every stream, so that their connections don't hold up the shutdown of the server.
+func (s *serverStreams) endAll() {
+	s.mu.Lock()
+	sessionIDs := make([]string, 0, len(s.streams))
+	for sessionID := range s.streams {
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	s.mu.Unlock()
+	for _, sessionID := range sessionIDs {
+		s.end(sessionID)
+	}
+}
+
+// serveStreams answers the GET requests of sessions with their resumable stream, and hands every other
+// request to next.
+func serveStreams(next http.Handler, sessions *httpSessions, streams *serverStreams) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.Header.Get(headerSessionID)
+		if r.Method != http.MethodGet || sessionID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if terminated, err := sessions.Validate(sessionID); err != nil || terminated {
+			http.Error(w, "Session not found", http.StatusNotFound)
+			return
+		}
+		stream, err := streams.stream(r.Context(), sessionID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Session registration failed: %v", err), http.StatusBadRequest)
+			return
+		}
+		stream.serve(w, r)
+	})
+}
@@ -0,0 +1,230 @@
+package ghmcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sseEvent is an event read from a stream.
+type sseEvent struct {
+	id     string
+	number float64
+}
+
+// streamServer serves the streams of the sessions of an MCP server.
+type streamServer struct {
+	mcpServer *server.MCPServer
+	sessions  *httpSessions
+	streams   *serverStreams
+	url       string
+}
+
+// newStreamServer starts a stream server, and a session of it.
+func newStreamServer(t *testing.T) (*streamServer, string) {
+	t.Helper()
+	s := &streamServer{mcpServer: server.NewMCPServer("test", "1"), sessions: newHTTPSessions(0)}
+	s.streams = newServerStreams(s.mcpServer)
+	s.sessions.ended = s.streams.end
+	srv := httptest.NewServer(serveStreams(http.NotFoundHandler(), s.sessions, s.streams))
+	t.Cleanup(srv.Close)
+	t.Cleanup(s.streams.endAll)
+	s.url = srv.URL
+	return s, s.sessions.Generate()
+}
+
+// listen opens the stream of a session, after the given event ID if it isn't empty, and returns the events
+// it receives. The stream is closed when ctx is done.
+func listen(ctx context.Context, t *testing.T, url, sessionID, lastEventID string) <-chan sseEvent {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header.Set(headerSessionID, sessionID)
+	if lastEventID != "" {
+		req.Header.Set(headerLastEventID, lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan sseEvent, maxStreamEvents)
+	go func() {
+		defer func() { _ = resp.Body.Close() }()
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		var event sseEvent
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				event.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				var notification struct {
+					Params struct {
+						Number float64 `json:"number"`
+					} `json:"params"`
+				}
+				if json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &notification) == nil {
+					event.number = notification.Params.Number
+				}
+			case line == "":
+				events <- event
+				event = sseEvent{}
+			}
+		}
+	}()
+	return events
+}
+
+// receive returns the next n events of a stream.
+func receive(t *testing.T, events <-chan sseEvent, n int) []sseEvent {
+	t.Helper()
+	var received []sseEvent
+	for len(received) < n {
+		select {
+		case event, ok := <-events:
+			require.True(t, ok, "the stream ended after %d events", len(received))
+			received = append(received, event)
+		case <-time.After(5 * time.Second):
+			require.Failf(t, "timed out", "received %d of %d events", len(received), n)
+		}
+	}
+	return received
+}
+
+// numbers returns the numbers of the notifications of events.
+func numbers(events []sseEvent) []float64 {
+	n := make([]float64, len(events))
+	for i, event := range events {
+		n[i] = event.number
+	}
+	return n
+}
+
+func notify(t *testing.T, mcpServer *server.MCPServer, sessionID string, number int) {
+	t.Helper()
+	require.NoError(t, mcpServer.SendNotificationToSpecificClient(sessionID, "notifications/resources/updated", map[string]any{"number": number}))
+}
+
+func TestStreamsResumeAfterTheLastEventID(t *testing.T) {
+	s, sessionID := newStreamServer(t)
+
+	ctx, disconnect := context.WithCancel(context.Background())
+	events := listen(ctx, t, s.url, sessionID, "")
+	notify(t, s.mcpServer, sessionID, 1)
+	notify(t, s.mcpServer, sessionID, 2)
+	received := receive(t, events, 2)
+	assert.Equal(t, []float64{1, 2}, numbers(received))
+	assert.Equal(t, "1", received[0].id)
+	assert.Equal(t, "2", received[1].id)
+
+	// The messages sent while the client is disconnected are kept for it
+	disconnect()
+	notify(t, s.mcpServer, sessionID, 3)
+	notify(t, s.mcpServer, sessionID, 4)
+
+	events = listen(context.Background(), t, s.url, sessionID, received[1].id)
+	received = receive(t, events, 2)
+	assert.Equal(t, []float64{3, 4}, numbers(received), "the client receives the messages it missed")
+	assert.Equal(t, "3", received[0].id)
+
+	notify(t, s.mcpServer, sessionID, 5)
+	assert.Equal(t, []float64{5}, numbers(receive(t, events, 1)), "and then the new ones")
+}
+
+func TestStreamsReplayFromTheirOwnMessages(t *testing.T) {
+	s, sessionID := newStreamServer(t)
+
+	// Before the first connection there is no stream to keep messages for
+	assert.ErrorIs(t, s.mcpServer.SendNotificationToSpecificClient(sessionID, "notifications/resources/updated", nil), server.ErrSessionNotFound)
+
+	ctx, disconnect := context.WithCancel(context.Background())
+	listen(ctx, t, s.url, sessionID, "")
+	disconnect()
+	// The notifications are handed over to the stream asynchronously, through a channel that holds at most
+	// maxStreamEvents of them
+	for i := 1; i <= maxStreamEvents; i++ {
+		notify(t, s.mcpServer, sessionID, i)
+	}
+	recorded(t, s, sessionID, maxStreamEvents)
+	for i := maxStreamEvents + 1; i <= maxStreamEvents+5; i++ {
+		notify(t, s.mcpServer, sessionID, i)
+	}
+	recorded(t, s, sessionID, maxStreamEvents+5)
+
+	received := receive(t, listen(context.Background(), t, s.url, sessionID, "1"), maxStreamEvents)
+	assert.Equal(t, float64(6), received[0].number, "only the latest messages are kept")
+	assert.Equal(t, float64(maxStreamEvents+5), received[maxStreamEvents-1].number)
+
+	// A connection without a Last-Event-ID, or an ID the stream never sent, starts with the next message
+	for _, lastEventID := range []string{"", "not-a-number", strconv.Itoa(maxStreamEvents + 100)} {
+		ctx, disconnect := context.WithCancel(context.Background())
+		events := listen(ctx, t, s.url, sessionID, lastEventID)
+		notify(t, s.mcpServer, sessionID, 0)
+		assert.Equal(t, []float64{0}, numbers(receive(t, events, 1)), lastEventID)
+		disconnect()
+	}
+}
+
+// recorded waits until the stream of a session recorded the message of the given ID.
+func recorded(t *testing.T, s *streamServer, sessionID string, lastID uint64) {
+	t.Helper()
+	s.streams.mu.Lock()
+	stream := s.streams.streams[sessionID]
+	s.streams.mu.Unlock()
+	require.NotNil(t, stream)
+	require.Eventually(t, func() bool {
+		stream.mu.Lock()
+		defer stream.mu.Unlock()
+		return stream.lastID >= lastID
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestStreamsEndWithTheirSession(t *testing.T) {
+	s, sessionID := newStreamServer(t)
+
+	events := listen(context.Background(), t, s.url, sessionID, "")
+	_, err := s.sessions.Terminate(sessionID)
+	require.NoError(t, err)
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "the stream ends")
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the stream did not end")
+	}
+	assert.ErrorIs(t, s.mcpServer.SendNotificationToSpecificClient(sessionID, "notifications/resources/updated", nil), server.ErrSessionNotFound)
+
+	req, err := http.NewRequest(http.MethodGet, s.url, nil)
+	require.NoError(t, err)
+	req.Header.Set(headerSessionID, sessionID)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestStreamsAreTakenOverByNewConnections(t *testing.T) {
+	s, sessionID := newStreamServer(t)
+
+	first := listen(context.Background(), t, s.url, sessionID, "")
+	second := listen(context.Background(), t, s.url, sessionID, "")
+	select {
+	case _, ok := <-first:
+		assert.False(t, ok, "the connection that was taken over ends")
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the first connection did not end")
+	}
+	notify(t, s.mcpServer, sessionID, 1)
+	assert.Equal(t, []float64{1}, numbers(receive(t, second, 1)), "messages are only sent to one connection")
+}
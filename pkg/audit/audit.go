@@ -0,0 +1,249 @@
+// Package audit records every tool call in an audit log, one JSON object per call, for reviewing what
+// agents did with the server. Unlike command logging, it records calls rather than messages, and leaves
+// out the secrets and the bulk of their arguments.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Syslog is the target of Open that sends the audit log to the local syslog daemon.
+const Syslog = "syslog"
+
+// The statuses of an Entry.
+const (
+	// StatusSuccess is a call that returned a result
+	StatusSuccess = "success"
+	// StatusError is a call that returned an error result, including calls denied by the server
+	StatusError = "error"
+	// StatusFailure is a call that failed with a protocol error
+	StatusFailure = "failure"
+)
+
+// Redacted replaces the values of secret arguments.
+const Redacted = mcplog.Redacted
+
+// maxValueLength is the length above which string arguments, such as the contents of files, are cut.
+const maxValueLength = 1024
+
+// Entry is the record of a tool call.
+type Entry struct {
+	Time time.Time `json:"time"`
+	// Session is the ID of the MCP session the call was made in
+	Session string `json:"session,omitempty"`
+	// Client is the name and version the MCP client gave when it started the session
+	Client string `json:"client,omitempty"`
+	Tool   string `json:"tool"`
+	// Arguments are the arguments of the call, with secrets redacted and long strings cut
+	Arguments map[string]any `json:"arguments,omitempty"`
+	Status    string         `json:"status"`
+	// Error is the text of an error result, or the error of a failure
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+	// Resources are the GitHub resources the call names or returns, such as octo-org/app#12 or the
+	// URL of a created issue
+	Resources []string `json:"resources,omitempty"`
+}
+
+// Logger writes entries as JSON lines. It is safe for concurrent use.
+type Logger struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+// NewLogger creates a logger that writes to w.
+func NewLogger(w io.Writer) *Logger {
+	return &Logger{w: w, now: time.Now}
+}
+
+// Open creates a logger that appends to the file at target, or sends to the local syslog daemon if the
+// target is Syslog. The returned io.Closer closes the file or the connection to syslog.
+func Open(target string) (*Logger, io.Closer, error) {
+	if target == Syslog {
+		w, err := openSyslog()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to syslog: %w", err)
+		}
+		return NewLogger(w), w, nil
+	}
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return NewLogger(file), file, nil
+}
+
+// Log writes an entry. Each entry is written with a single write, so that syslog gets one message per
+// entry.
+func (l *Logger) Log(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(append(data, '\n'))
+	return err
+}
+
+// ToolHandlerMiddleware records every call of a tool after it returns. Calls are recorded even if
+// writing the entry fails, which is reported to onError if set, rather than failing the call.
+func ToolHandlerMiddleware(l *Logger, onError func(error)) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := l.now()
+			result, err := next(ctx, request)
+
+			entry := Entry{
+				Time:       start.UTC(),
+				Tool:       request.Params.Name,
+				Arguments:  Redact(request.GetArguments()),
+				Status:     StatusSuccess,
+				DurationMS: l.now().Sub(start).Milliseconds(),
+				Resources:  Resources(request.GetArguments(), result),
+			}
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				entry.Session = session.SessionID()
+				if withInfo, ok := session.(server.SessionWithClientInfo); ok {
+					info := withInfo.GetClientInfo()
+					entry.Client = strings.TrimSpace(info.Name + " " + info.Version)
+				}
+			}
+			switch {
+			case err != nil:
+				entry.Status = StatusFailure
+				entry.Error = err.Error()
+			case result != nil && result.IsError:
+				entry.Status = StatusError
+				entry.Error = cut(resultText(result))
+			}
+
+			if logErr := l.Log(entry); logErr != nil && onError != nil {
+				onError(logErr)
+			}
+			return result, err
+		}
+	}
+}
+
+// Redact returns a copy of arguments with the values of secret arguments replaced, GitHub tokens and
+// private keys in any value replaced, and long strings cut.
+func Redact(arguments map[string]any) map[string]any {
+	if arguments == nil {
+		return nil
+	}
+	redacted := make(map[string]any, len(arguments))
+	for name, value := range arguments {
+		if mcplog.SecretName(name) {
+			redacted[name] = Redacted
+		} else {
+			redacted[name] = redactValue(value)
+		}
+	}
+	return redacted
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		return cut(mcplog.RedactSecrets(v))
+	case map[string]any:
+		return Redact(v)
+	case []any:
+		values := make([]any, len(v))
+		for i, element := range v {
+			values[i] = redactValue(element)
+		}
+		return values
+	default:
+		return value
+	}
+}
+
+// cut shortens strings longer than maxValueLength, noting their length.
+func cut(s string) string {
+	if len(s) <= maxValueLength {
+		return s
+	}
+	end := maxValueLength
+	// Don't cut a UTF-8 encoded character in half
+	for end > 0 && s[end]&0xC0 == 0x80 {
+		end--
+	}
+	return fmt.Sprintf("%s... (%d bytes)", s[:end], len(s))
+}
+
+// numberArguments are the arguments that number the issue, pull request or discussion of a repository
+// that a call touches.
+var numberArguments = []string{"issue_number", "issueNumber", "pull_number", "pullNumber", "discussion_number", "discussionNumber", "number"}
+
+// branchArguments are the arguments that name the branch or ref of a repository that a call touches.
+var branchArguments = []string{"branch", "ref"}
+
+// Resources returns the GitHub resources a call touches: the repository or owner its arguments name,
+// with the number or branch of the item in it, and the html_url of the object the result returns.
+func Resources(arguments map[string]any, result *mcp.CallToolResult) []string {
+	str := func(name string) string {
+		s, _ := arguments[name].(string)
+		return s
+	}
+	var resources []string
+	owner, repo := str("owner"), str("repo")
+	if owner == "" {
+		owner = str("org")
+	}
+	switch {
+	case owner != "" && repo != "":
+		name := owner + "/" + repo
+		resource := name
+		for _, argument := range numberArguments {
+			if number, ok := arguments[argument].(float64); ok && number > 0 {
+				resource = fmt.Sprintf("%s#%d", name, int(number))
+				break
+			}
+		}
+		if resource == name {
+			for _, argument := range branchArguments {
+				if branch := str(argument); branch != "" {
+					resource = name + "@" + branch
+					break
+				}
+			}
+		}
+		resources = append(resources, resource)
+	case owner != "":
+		resources = append(resources, owner)
+	}
+
+	if result != nil && !result.IsError {
+		var object struct {
+			HTMLURL string `json:"html_url"`
+		}
+		if json.Unmarshal([]byte(resultText(result)), &object) == nil && object.HTMLURL != "" {
+			resources = append(resources, object.HTMLURL)
+		}
+	}
+	return resources
+}
+
+// resultText returns the text of the first text content of a result.
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
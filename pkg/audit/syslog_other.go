@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package audit
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+)
+
+func openSyslog() (io.WriteCloser, error) {
+	return nil, fmt.Errorf("syslog isn't available on %s, write the audit log to a file instead", runtime.GOOS)
+}
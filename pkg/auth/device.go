@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultScopes are the OAuth scopes requested when none are given, enough for the default toolsets.
+var DefaultScopes = []string{"repo", "read:org", "workflow", "notifications", "gist"}
+
+// slowDownIncrease is how much the polling interval grows when GitHub asks to slow down.
+const slowDownIncrease = 5 * time.Second
+
+// ErrAccessDenied is returned when the user cancels the authorization.
+var ErrAccessDenied = errors.New("the authorization was denied")
+
+// ErrExpired is returned when the user code expires before the user enters it.
+var ErrExpired = errors.New("the one-time code expired, run the login again")
+
+// WebURL returns the URL of the GitHub web interface of host, as given to --gh-host. OAuth runs on the web
+// host, not on the API host.
+func WebURL(host string) (*url.URL, error) {
+	if host == "" {
+		return url.Parse("https://github.com")
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse host as URL: %s", host)
+	}
+	if u.Scheme == "" {
+		return nil, fmt.Errorf("host must have a scheme (http or https): %s", host)
+	}
+	if strings.HasSuffix(u.Hostname(), "github.com") {
+		return url.Parse("https://github.com")
+	}
+	return &url.URL{Scheme: u.Scheme, Host: u.Host}, nil
+}
+
+// DeviceCode is what the user needs to authorize the device.
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+// DeviceFlow acquires an OAuth token with the device flow, in which the user authorizes the token in a
+// browser by entering a one-time code. See
+// https://docs.github.com/en/apps/oauth-apps/building-oauth-apps/authorizing-oauth-apps#device-flow
+type DeviceFlow struct {
+	// BaseURL is the GitHub web URL, see WebURL
+	BaseURL *url.URL
+	// ClientID is the client ID of the OAuth app that the token is issued to
+	ClientID string
+	// Scopes are the requested OAuth scopes, DefaultScopes if empty
+	Scopes []string
+	// HTTPClient sends the requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+
+	// sleep waits between polls, it is replaced in tests
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// oauthResponse is the body of the device code and access token endpoints, which report errors with a
+// 200 status.
+type oauthResponse struct {
+	DeviceCode
+	AccessToken      string `json:"access_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (f *DeviceFlow) post(ctx context.Context, path string, form url.Values) (*oauthResponse, error) {
+	endpoint := f.BaseURL.ResolveReference(&url.URL{Path: path})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	client := f.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s from %s", resp.Status, endpoint)
+	}
+	var body oauthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode response of %s: %w", endpoint, err)
+	}
+	return &body, nil
+}
+
+// oauthError describes an error reported by the OAuth endpoints.
+func oauthError(body *oauthResponse) error {
+	if body.ErrorDescription != "" {
+		return fmt.Errorf("%s: %s", body.Error, body.ErrorDescription)
+	}
+	return errors.New(body.Error)
+}
+
+// RequestCode starts the device flow. The user then enters the UserCode at the VerificationURI.
+func (f *DeviceFlow) RequestCode(ctx context.Context) (*DeviceCode, error) {
+	if f.ClientID == "" {
+		return nil, errors.New("an OAuth app client ID is required")
+	}
+	scopes := f.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+
+	body, err := f.post(ctx, "/login/device/code", url.Values{
+		"client_id": {f.ClientID},
+		"scope":     {strings.Join(scopes, " ")},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to request device code: %w", err)
+	}
+	if body.Error != "" {
+		if body.Error == "device_flow_disabled" {
+			return nil, errors.New("the device flow is not enabled for the OAuth app")
+		}
+		return nil, fmt.Errorf("failed to request device code: %w", oauthError(body))
+	}
+	return &body.DeviceCode, nil
+}
+
+// Wait polls until the user authorized the device and returns the token, at the interval GitHub asks for.
+func (f *DeviceFlow) Wait(ctx context.Context, code *DeviceCode) (string, error) {
+	sleep := f.sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+	interval := time.Duration(code.Interval) * time.Second
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	for {
+		if err := sleep(ctx, interval); err != nil {
+			return "", err
+		}
+
+		body, err := f.post(ctx, "/login/oauth/access_token", url.Values{
+			"client_id":   {f.ClientID},
+			"device_code": {code.DeviceCode},
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to poll for the access token: %w", err)
+		}
+
+		switch body.Error {
+		case "":
+			if body.AccessToken == "" {
+				return "", errors.New("no access token was returned")
+			}
+			return body.AccessToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			if body.Interval > 0 {
+				interval = time.Duration(body.Interval) * time.Second
+			} else {
+				interval += slowDownIncrease
+			}
+		case "expired_token":
+			return "", ErrExpired
+		case "access_denied":
+			return "", ErrAccessDenied
+		default:
+			return "", fmt.Errorf("failed to get the access token: %w", oauthError(body))
+		}
+
+		if code.ExpiresIn > 0 && time.Now().After(deadline) {
+			return "", ErrExpired
+		}
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebURL(t *testing.T) {
+	tests := []struct {
+		host     string
+		expected string
+	}{
+		{host: "", expected: "https://github.com"},
+		{host: "https://github.com", expected: "https://github.com"},
+		{host: "https://api.github.com/", expected: "https://github.com"},
+		{host: "https://octocorp.ghe.com", expected: "https://octocorp.ghe.com"},
+		{host: "https://github.example.com/api/v3", expected: "https://github.example.com"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.host, func(t *testing.T) {
+			u, err := WebURL(tc.host)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, u.String())
+		})
+	}
+
+	_, err := WebURL("github.example.com")
+	require.Error(t, err)
+}
+
+// deviceFlowServer answers the access token endpoint with the given responses in turn.
+func deviceFlowServer(t *testing.T, responses ...map[string]any) *httptest.Server {
+	polls := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		assert.Equal(t, "client", r.PostForm.Get("client_id"))
+
+		switch r.URL.Path {
+		case "/login/device/code":
+			assert.Equal(t, "repo read:org", r.PostForm.Get("scope"))
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"device_code":      "device",
+				"user_code":        "ABCD-1234",
+				"verification_uri": "https://github.com/login/device",
+				"expires_in":       900,
+				"interval":         5,
+			})
+		case "/login/oauth/access_token":
+			assert.Equal(t, "device", r.PostForm.Get("device_code"))
+			assert.Equal(t, "urn:ietf:params:oauth:grant-type:device_code", r.PostForm.Get("grant_type"))
+			require.Less(t, polls, len(responses))
+			_ = json.NewEncoder(w).Encode(responses[polls])
+			polls++
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestDeviceFlow(t *testing.T) {
+	tests := []struct {
+		name              string
+		responses         []map[string]any
+		expectedToken     string
+		expectedErr       error
+		expectedErrMsg    string
+		expectedIntervals []time.Duration
+	}{
+		{
+			name: "authorized after pending and slow down",
+			responses: []map[string]any{
+				{"error": "authorization_pending"},
+				{"error": "slow_down", "interval": 10},
+				{"error": "slow_down"},
+				{"access_token": "gho_token", "token_type": "bearer", "scope": "repo,read:org"},
+			},
+			expectedToken:     "gho_token",
+			expectedIntervals: []time.Duration{5 * time.Second, 5 * time.Second, 10 * time.Second, 15 * time.Second},
+		},
+		{
+			name:              "denied",
+			responses:         []map[string]any{{"error": "access_denied"}},
+			expectedErr:       ErrAccessDenied,
+			expectedIntervals: []time.Duration{5 * time.Second},
+		},
+		{
+			name:              "expired",
+			responses:         []map[string]any{{"error": "authorization_pending"}, {"error": "expired_token"}},
+			expectedErr:       ErrExpired,
+			expectedIntervals: []time.Duration{5 * time.Second, 5 * time.Second},
+		},
+		{
+			name:              "other error",
+			responses:         []map[string]any{{"error": "incorrect_client_credentials", "error_description": "The client_id is not valid."}},
+			expectedErrMsg:    "incorrect_client_credentials: The client_id is not valid.",
+			expectedIntervals: []time.Duration{5 * time.Second},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := deviceFlowServer(t, tc.responses...)
+			defer ts.Close()
+			baseURL, err := url.Parse(ts.URL)
+			require.NoError(t, err)
+
+			var intervals []time.Duration
+			flow := &DeviceFlow{
+				BaseURL:  baseURL,
+				ClientID: "client",
+				Scopes:   []string{"repo", "read:org"},
+				sleep: func(_ context.Context, d time.Duration) error {
+					intervals = append(intervals, d)
+					return nil
+				},
+			}
+
+			code, err := flow.RequestCode(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "ABCD-1234", code.UserCode)
+			assert.Equal(t, "https://github.com/login/device", code.VerificationURI)
+
+			token, err := flow.Wait(context.Background(), code)
+			switch {
+			case tc.expectedErr != nil:
+				require.ErrorIs(t, err, tc.expectedErr)
+			case tc.expectedErrMsg != "":
+				require.ErrorContains(t, err, tc.expectedErrMsg)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedToken, token)
+			}
+			assert.Equal(t, tc.expectedIntervals, intervals)
+		})
+	}
+}
+
+func TestDeviceFlowDisabled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": "device_flow_disabled"})
+	}))
+	defer ts.Close()
+	baseURL, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	_, err = (&DeviceFlow{BaseURL: baseURL, ClientID: "client"}).RequestCode(context.Background())
+	require.ErrorContains(t, err, "the device flow is not enabled")
+
+	_, err = (&DeviceFlow{BaseURL: baseURL}).RequestCode(context.Background())
+	require.ErrorContains(t, err, "client ID is required")
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keychainService is the name the tokens are stored under in the OS keychain.
+const keychainService = "github-mcp-server"
+
+// StoreToken saves the token for a GitHub host in the OS keychain, replacing any token stored before.
+// host is the host name of the web URL, see WebURL.
+func StoreToken(host, token string) error {
+	if err := keyring.Set(keychainService, host, token); err != nil {
+		return fmt.Errorf("failed to store token in the keychain: %w", err)
+	}
+	return nil
+}
+
+// LoadToken returns the token stored for a GitHub host, or an empty string if there is none.
+func LoadToken(host string) (string, error) {
+	token, err := keyring.Get(keychainService, host)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read token from the keychain: %w", err)
+	}
+	return token, nil
+}
+
+// DeleteToken removes the token stored for a GitHub host. It reports whether there was one.
+func DeleteToken(host string) (bool, error) {
+	err := keyring.Delete(keychainService, host)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to delete token from the keychain: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+func TestKeychain(t *testing.T) {
+	keyring.MockInit()
+
+	token, err := LoadToken("github.com")
+	require.NoError(t, err)
+	assert.Empty(t, token)
+
+	require.NoError(t, StoreToken("github.com", "gho_first"))
+	require.NoError(t, StoreToken("github.com", "gho_second"))
+	require.NoError(t, StoreToken("github.example.com", "gho_enterprise"))
+
+	token, err = LoadToken("github.com")
+	require.NoError(t, err)
+	assert.Equal(t, "gho_second", token)
+
+	deleted, err := DeleteToken("github.com")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	deleted, err = DeleteToken("github.com")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	token, err = LoadToken("github.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "gho_enterprise", token)
+}
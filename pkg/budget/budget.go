@@ -0,0 +1,415 @@
+// Package budget keeps tool results within a size that clients with small context windows can take. A
+// result larger than the budget is cut down: lists keep as many of their items as fit, anything else is
+// cut after as many bytes. The result then says how much was omitted, and carries a cursor that the tool
+// can be called with to get the next part.
+package budget
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/github/github-mcp-server/pkg/structured"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Argument is the argument that carries the cursor of the next part of a truncated result.
+const Argument = "resultCursor"
+
+// ArgumentDescription describes the Argument that AddArgument gives tools.
+const ArgumentDescription = "The cursor that a truncated result of this tool returned, to get the next part of that result. The other arguments are then ignored"
+
+// MetaKey is the key under the result `_meta` that describes what was omitted.
+const MetaKey = "truncation"
+
+// DefaultTTL is how long the rest of a truncated result is kept.
+const DefaultTTL = 10 * time.Minute
+
+// bytesPerToken estimates how many bytes of JSON or text make up a token.
+const bytesPerToken = 4
+
+// noteSize is the room left in a truncated result for the note about what was omitted.
+const noteSize = 256
+
+// maxKept is the number of truncated results whose rest is kept, beyond which the oldest are dropped.
+const maxKept = 256
+
+// Tokens estimates the number of tokens of a text.
+func Tokens(text string) int {
+	return (len(text) + bytesPerToken - 1) / bytesPerToken
+}
+
+// AddArgument gives a tool the Argument.
+func AddArgument(tool *mcp.Tool) {
+	if tool.InputSchema.Properties == nil {
+		tool.InputSchema.Properties = map[string]any{}
+	}
+	tool.InputSchema.Properties[Argument] = map[string]any{
+		"type":        "string",
+		"description": ArgumentDescription,
+	}
+}
+
+// Truncation describes the part of a result that was omitted.
+type Truncation struct {
+	Omitted int `json:"omitted"`
+	// Unit is "items" for lists and "bytes" for anything else
+	Unit   string `json:"unit"`
+	Cursor string `json:"resultCursor"`
+}
+
+// listing is a JSON list, on its own or in a field of an object, such as the issues of a search.
+type listing struct {
+	// object holds the other fields of the object, or is nil for a list on its own
+	object map[string]json.RawMessage
+	field  string
+	items  []json.RawMessage
+}
+
+// parseListing parses a JSON list, or an object whose largest field is a list.
+func parseListing(text string) (*listing, bool) {
+	trimmed := strings.TrimSpace(text)
+	var items []json.RawMessage
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &items); err != nil {
+			return nil, false
+		}
+		return &listing{items: items}, true
+	}
+	var object map[string]json.RawMessage
+	if !strings.HasPrefix(trimmed, "{") || json.Unmarshal([]byte(trimmed), &object) != nil {
+		return nil, false
+	}
+	field, size := "", 0
+	for name, value := range object {
+		if len(value) > size && value[0] == '[' {
+			field, size = name, len(value)
+		}
+	}
+	if field == "" || json.Unmarshal(object[field], &items) != nil {
+		return nil, false
+	}
+	return &listing{object: object, field: field, items: items}, true
+}
+
+// marshal returns the JSON of the listing with the given items. HTML isn't escaped, so that the items
+// read as the tool returned them.
+func (l *listing) marshal(items []json.RawMessage) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.Write(item)
+	}
+	b.WriteByte(']')
+	list := b.String()
+	if l.object == nil {
+		return list
+	}
+	object := maps.Clone(l.object)
+	object[l.field] = json.RawMessage(list)
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(object); err != nil {
+		return list
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// page returns the JSON of the items that fit within limit bytes and the listing of the rest, or nil once
+// no items are left. It reports false when not even one item fits.
+func (l *listing) page(limit int) (string, *listing, bool) {
+	envelope := len(l.marshal(nil))
+	size, n := envelope, 0
+	for n < len(l.items) && size+len(l.items[n])+1 <= limit {
+		size += len(l.items[n]) + 1
+		n++
+	}
+	if envelope > limit || (n == 0 && len(l.items) > 0) {
+		return "", nil, false
+	}
+	if n == len(l.items) {
+		return l.marshal(l.items), nil, true
+	}
+	return l.marshal(l.items[:n]), &listing{object: l.object, field: l.field, items: l.items[n:]}, true
+}
+
+// cut splits a text after at most limit bytes, at the end of a line if one ends in the second half, and
+// never within a character.
+func cut(text string, limit int) (string, string) {
+	if len(text) <= limit {
+		return text, ""
+	}
+	end := limit
+	for end > 0 && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	if line := strings.LastIndexByte(text[:end], '\n'); line >= end/2 {
+		end = line + 1
+	}
+	return text[:end], text[end:]
+}
+
+// rest is what was omitted from a result, either a listing or a text.
+type rest struct {
+	session string
+	// caller tells apart the callers that share a session, such as the users of a server that acts with
+	// the token of each tool call
+	caller  string
+	tool    string
+	expires time.Time
+	listing *listing
+	text    string
+}
+
+// omitted returns how much of a result is left in the rest, and in what unit.
+func (r *rest) omitted() (int, string) {
+	if r.listing != nil {
+		return len(r.listing.items), "items"
+	}
+	return len(r.text), "bytes"
+}
+
+// page returns the next part of the rest within limit bytes, and the rest after it, or nil once nothing
+// is left. Listings whose items are too large for the limit continue as text.
+func (r *rest) page(limit int) (string, *rest) {
+	if r.listing != nil {
+		if text, next, ok := r.listing.page(limit); ok {
+			if next == nil {
+				return text, nil
+			}
+			return text, &rest{session: r.session, caller: r.caller, tool: r.tool, listing: next}
+		}
+		text, remaining := cut(r.listing.marshal(r.listing.items), limit)
+		return text, &rest{session: r.session, caller: r.caller, tool: r.tool, text: remaining}
+	}
+	text, remaining := cut(r.text, limit)
+	if remaining == "" {
+		return text, nil
+	}
+	return text, &rest{session: r.session, caller: r.caller, tool: r.tool, text: remaining}
+}
+
+// Budget truncates tool results beyond a number of tokens and keeps their rest. It is safe for
+// concurrent use.
+type Budget struct {
+	mu        sync.Mutex
+	maxTokens int
+	ttl       time.Duration
+	now       func() time.Time
+	rests     map[string]*rest
+}
+
+// New creates a Budget of maxTokens, which keeps the rest of results for DefaultTTL.
+func New(maxTokens int) *Budget {
+	return &Budget{maxTokens: max(maxTokens, 1), ttl: DefaultTTL, now: time.Now, rests: make(map[string]*rest)}
+}
+
+// limit is the size in bytes that the parts of a result are cut to, leaving room for the note.
+func (b *Budget) limit() int {
+	maxBytes := b.maxTokens * bytesPerToken
+	return max(maxBytes-noteSize, maxBytes/2, 1)
+}
+
+// keep stores a rest and returns its cursor. Cursors can be used until they expire, so that a client that
+// lost a part can ask for it again.
+func (b *Budget) keep(r *rest) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate result cursor: %w", err)
+	}
+	cursor := hex.EncodeToString(buf)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	for c, kept := range b.rests {
+		if !now.Before(kept.expires) {
+			delete(b.rests, c)
+		}
+	}
+	for len(b.rests) >= maxKept {
+		oldest := ""
+		for c, kept := range b.rests {
+			if oldest == "" || kept.expires.Before(b.rests[oldest].expires) {
+				oldest = c
+			}
+		}
+		delete(b.rests, oldest)
+	}
+	r.expires = now.Add(b.ttl)
+	b.rests[cursor] = r
+	return cursor, nil
+}
+
+// lookup returns the rest of a cursor, if it was returned to the same caller of the same session by the
+// same tool and hasn't expired.
+func (b *Budget) lookup(cursor string, caller rest) (*rest, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	r, ok := b.rests[cursor]
+	if !ok || !b.now().Before(r.expires) || r.session != caller.session || r.caller != caller.caller || r.tool != caller.tool {
+		return nil, false
+	}
+	return r, true
+}
+
+// textOf returns the text of a part of a result, for the parts that are text.
+func textOf(content mcp.Content) (string, bool) {
+	switch content := content.(type) {
+	case mcp.TextContent:
+		return content.Text, true
+	case mcp.EmbeddedResource:
+		if resource, ok := content.Resource.(mcp.TextResourceContents); ok {
+			return resource.Text, true
+		}
+	}
+	return "", false
+}
+
+// withText returns a part of a result with another text.
+func withText(content mcp.Content, text string) mcp.Content {
+	switch content := content.(type) {
+	case mcp.TextContent:
+		content.Text = text
+		return content
+	case mcp.EmbeddedResource:
+		resource := content.Resource.(mcp.TextResourceContents)
+		resource.Text = text
+		content.Resource = resource
+		return content
+	}
+	return content
+}
+
+// truncate cuts a result down to the budget. A result that is a single JSON list keeps the items that
+// fit. Otherwise its parts are kept as long as they fit, and the first part that doesn't is cut. Parts
+// that aren't text, such as images, are left as they are.
+func (b *Budget) truncate(result *mcp.CallToolResult, caller rest) (*mcp.CallToolResult, error) {
+	size := 0
+	for _, content := range result.Content {
+		text, _ := textOf(content)
+		size += len(text)
+	}
+	if size <= b.maxTokens*bytesPerToken {
+		return result, nil
+	}
+
+	limit := b.limit()
+	if len(result.Content) == 1 {
+		if text, ok := result.Content[0].(mcp.TextContent); ok {
+			if l, ok := parseListing(text.Text); ok {
+				caller.listing = l
+				page, next := caller.page(limit)
+				return b.respond(result, []mcp.Content{withText(text, page)}, next)
+			}
+		}
+	}
+
+	var kept []mcp.Content
+	var omitted []string
+	left := limit
+	for _, content := range result.Content {
+		text, ok := textOf(content)
+		switch {
+		case !ok:
+			kept = append(kept, content)
+		case len(omitted) == 0 && len(text) <= left:
+			kept = append(kept, content)
+			left -= len(text)
+		case len(omitted) == 0:
+			page, remaining := cut(text, left)
+			if page != "" {
+				kept = append(kept, withText(content, page))
+			}
+			omitted = append(omitted, remaining)
+			left = 0
+		default:
+			omitted = append(omitted, text)
+		}
+	}
+	caller.text = strings.Join(omitted, "\n")
+	return b.respond(result, kept, &caller)
+}
+
+// respond replaces the content of a result, and notes what was omitted along with the cursor of the rest.
+// It is also used for the next parts of a result, which start out empty.
+func (b *Budget) respond(result *mcp.CallToolResult, content []mcp.Content, next *rest) (*mcp.CallToolResult, error) {
+	// Structure the part itself rather than leave it to package structured, which would take the note in
+	result.Content = content
+	result.StructuredContent = nil
+	if len(content) == 1 {
+		if text, ok := content[0].(mcp.TextContent); ok {
+			result.StructuredContent = structured.Content(text.Text)
+		}
+	}
+	if next == nil {
+		return result, nil
+	}
+
+	cursor, err := b.keep(next)
+	if err != nil {
+		return nil, err
+	}
+	omitted, unit := next.omitted()
+	result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("%d %s omitted to keep the result within %d tokens. Call %s with %s set to %q to get the next part", omitted, unit, b.maxTokens, next.tool, Argument, cursor)))
+	if result.Meta == nil {
+		result.Meta = &mcp.Meta{}
+	}
+	if result.Meta.AdditionalFields == nil {
+		result.Meta.AdditionalFields = map[string]any{}
+	}
+	result.Meta.AdditionalFields[MetaKey] = Truncation{Omitted: omitted, Unit: unit, Cursor: cursor}
+	return result, nil
+}
+
+func sessionID(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
+
+// ToolHandlerMiddleware truncates the results of tools beyond the budget, and answers the calls that
+// carry the Argument with the next part of the result it points to, without calling the tool again. It
+// must run after the middleware that changes the text of results, so that it measures what clients get,
+// and before the one that structures them. Cursors only work for the session and the caller they were
+// returned to. caller identifies the caller of a tool call, and may be nil when sessions have a single
+// caller.
+func ToolHandlerMiddleware(b *Budget, caller func(ctx context.Context) string) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			tool := request.Params.Name
+			call := rest{session: sessionID(ctx), tool: tool}
+			if caller != nil {
+				call.caller = caller(ctx)
+			}
+			if cursor, _ := request.GetArguments()[Argument].(string); cursor != "" {
+				r, ok := b.lookup(cursor, call)
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("the %s is unknown, expired or was returned by another tool. Call %s again without it", Argument, tool)), nil
+				}
+				page, next := r.page(b.limit())
+				return b.respond(&mcp.CallToolResult{}, []mcp.Content{mcp.NewTextContent(page)}, next)
+			}
+
+			result, err := next(ctx, request)
+			if err != nil || result == nil || result.IsError {
+				return result, err
+			}
+			return b.truncate(result, call)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package budget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type callerKey struct{}
+
+// callerOf returns the caller that a test put in the context.
+func callerOf(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+// newHandler returns a handler that answers with the given result, behind the middleware of a budget.
+func newHandler(b *Budget, result func() *mcp.CallToolResult) (func(t *testing.T, ctx context.Context, arguments map[string]any) *mcp.CallToolResult, *int) {
+	calls := 0
+	handler := ToolHandlerMiddleware(b, callerOf)(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		return result(), nil
+	})
+	return func(t *testing.T, ctx context.Context, arguments map[string]any) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "list_issues"
+		request.Params.Arguments = arguments
+		result, err := handler(ctx, request)
+		require.NoError(t, err)
+		return result
+	}, &calls
+}
+
+func issues(n int) []map[string]any {
+	items := make([]map[string]any, n)
+	for i := range items {
+		items[i] = map[string]any{"number": i + 1, "title": strings.Repeat("x", 80)}
+	}
+	return items
+}
+
+func textOfPart(t *testing.T, result *mcp.CallToolResult, i int) string {
+	require.Greater(t, len(result.Content), i)
+	text, ok := result.Content[i].(mcp.TextContent)
+	require.True(t, ok)
+	return text.Text
+}
+
+func truncation(t *testing.T, result *mcp.CallToolResult) Truncation {
+	require.NotNil(t, result.Meta)
+	truncation, ok := result.Meta.AdditionalFields[MetaKey].(Truncation)
+	require.True(t, ok)
+	return truncation
+}
+
+func TestSmallResultsAreLeftAlone(t *testing.T) {
+	call, _ := newHandler(New(100), func() *mcp.CallToolResult { return mcp.NewToolResultText("done") })
+	result := call(t, context.Background(), nil)
+	assert.Equal(t, []mcp.Content{mcp.NewTextContent("done")}, result.Content)
+	assert.Nil(t, result.Meta)
+}
+
+func TestListsAreTruncatedByItems(t *testing.T) {
+	data, err := json.Marshal(issues(100))
+	require.NoError(t, err)
+	call, calls := newHandler(New(1000), func() *mcp.CallToolResult { return mcp.NewToolResultText(string(data)) })
+
+	var numbers []int
+	result := call(t, context.Background(), map[string]any{"owner": "octo-org"})
+	for pages := 1; ; pages++ {
+		require.LessOrEqual(t, Tokens(textOfPart(t, result, 0)), 1000)
+		var page []struct {
+			Number int `json:"number"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(textOfPart(t, result, 0)), &page), "every part is JSON")
+		require.NotEmpty(t, page)
+		for _, item := range page {
+			numbers = append(numbers, item.Number)
+		}
+		items, ok := result.StructuredContent.(map[string]any)["items"].([]any)
+		require.True(t, ok)
+		assert.Len(t, items, len(page))
+		if len(result.Content) == 1 {
+			assert.Greater(t, pages, 1)
+			break
+		}
+
+		truncation := truncation(t, result)
+		assert.Equal(t, "items", truncation.Unit)
+		assert.Equal(t, 100-len(numbers), truncation.Omitted)
+		assert.Contains(t, textOfPart(t, result, 1), fmt.Sprintf("%d items omitted to keep the result within 1000 tokens. Call list_issues with resultCursor set to %q", truncation.Omitted, truncation.Cursor))
+		result = call(t, context.Background(), map[string]any{Argument: truncation.Cursor})
+	}
+	assert.Len(t, numbers, 100)
+	for i, number := range numbers {
+		assert.Equal(t, i+1, number, "items come in order, each once")
+	}
+	assert.Equal(t, 1, *calls, "the next parts don't call the tool again")
+}
+
+func TestFieldsThatAreListsAreTruncatedByItems(t *testing.T) {
+	data, err := json.Marshal(map[string]any{"total_count": 100, "items": issues(100), "incomplete_results": false})
+	require.NoError(t, err)
+	call, _ := newHandler(New(1000), func() *mcp.CallToolResult { return mcp.NewToolResultText(string(data)) })
+
+	result := call(t, context.Background(), nil)
+	var page struct {
+		TotalCount int              `json:"total_count"`
+		Items      []map[string]any `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textOfPart(t, result, 0)), &page))
+	assert.Equal(t, 100, page.TotalCount, "the other fields are kept")
+	assert.NotEmpty(t, page.Items)
+	assert.Equal(t, 100-len(page.Items), truncation(t, result).Omitted)
+
+	result = call(t, context.Background(), map[string]any{Argument: truncation(t, result).Cursor})
+	require.NoError(t, json.Unmarshal([]byte(textOfPart(t, result, 0)), &page))
+	assert.Equal(t, 100, page.TotalCount)
+}
+
+func TestTextIsTruncatedByBytes(t *testing.T) {
+	lines := make([]string, 200)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d é", i)
+	}
+	text := strings.Join(lines, "\n")
+	call, _ := newHandler(New(300), func() *mcp.CallToolResult {
+		return &mcp.CallToolResult{Content: []mcp.Content{
+			mcp.NewTextContent("successfully downloaded text file"),
+			mcp.NewEmbeddedResource(mcp.TextResourceContents{URI: "repo://octo-org/app/contents/README.md", Text: text}),
+		}}
+	})
+
+	result := call(t, context.Background(), nil)
+	require.Len(t, result.Content, 3)
+	assert.Equal(t, "successfully downloaded text file", textOfPart(t, result, 0))
+	resource, ok := result.Content[1].(mcp.EmbeddedResource)
+	require.True(t, ok)
+	got := resource.Resource.(mcp.TextResourceContents).Text
+	assert.True(t, strings.HasSuffix(got, "\n"), "the text is cut at the end of a line")
+	assert.Nil(t, result.StructuredContent, "results of several parts are left to package structured")
+
+	for {
+		truncation := truncation(t, result)
+		assert.Equal(t, "bytes", truncation.Unit)
+		assert.Equal(t, len(text)-len(got), truncation.Omitted)
+		result = call(t, context.Background(), map[string]any{Argument: truncation.Cursor})
+		got += textOfPart(t, result, 0)
+		if len(result.Content) == 1 {
+			break
+		}
+	}
+	assert.Equal(t, text, got)
+}
+
+func TestItemsLargerThanTheBudgetAreTruncatedByBytes(t *testing.T) {
+	data, err := json.Marshal([]string{strings.Repeat("a", 2000)})
+	require.NoError(t, err)
+	call, _ := newHandler(New(100), func() *mcp.CallToolResult { return mcp.NewToolResultText(string(data)) })
+
+	result := call(t, context.Background(), nil)
+	assert.Equal(t, "bytes", truncation(t, result).Unit)
+	got := textOfPart(t, result, 0)
+	for len(result.Content) > 1 {
+		result = call(t, context.Background(), map[string]any{Argument: truncation(t, result).Cursor})
+		got += textOfPart(t, result, 0)
+	}
+	assert.Equal(t, string(data), got)
+}
+
+type fakeSession struct{ id string }
+
+func (s fakeSession) Initialize()                                         {}
+func (s fakeSession) Initialized() bool                                   { return true }
+func (s fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s fakeSession) SessionID() string                                   { return s.id }
+
+func TestCursors(t *testing.T) {
+	b := New(100)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	b.now = func() time.Time { return now }
+	call, _ := newHandler(b, func() *mcp.CallToolResult { return mcp.NewToolResultText(strings.Repeat("a", 1000)) })
+	ctx := server.NewMCPServer("test", "1").WithContext(context.Background(), fakeSession{id: "a"})
+
+	cursor := truncation(t, call(t, ctx, nil)).Cursor
+	result := call(t, ctx, map[string]any{Argument: cursor})
+	assert.False(t, result.IsError)
+	again := call(t, ctx, map[string]any{Argument: cursor})
+	assert.Equal(t, result.Content[0], again.Content[0], "cursors can be used again")
+
+	other := server.NewMCPServer("test", "1").WithContext(context.Background(), fakeSession{id: "b"})
+	result = call(t, other, map[string]any{Argument: cursor})
+	assert.True(t, result.IsError, "cursors belong to a session")
+	assert.Contains(t, textOfPart(t, result, 0), "the resultCursor is unknown, expired or was returned by another tool")
+
+	// Callers multiplexed over one session, each with a token of their own, don't share cursors either
+	alice := context.WithValue(ctx, callerKey{}, "alice")
+	aliceCursor := truncation(t, call(t, alice, nil)).Cursor
+	assert.False(t, call(t, alice, map[string]any{Argument: aliceCursor}).IsError)
+	result = call(t, context.WithValue(ctx, callerKey{}, "bob"), map[string]any{Argument: aliceCursor})
+	assert.True(t, result.IsError, "cursors belong to a caller")
+	assert.True(t, call(t, ctx, map[string]any{Argument: aliceCursor}).IsError)
+
+	now = now.Add(DefaultTTL)
+	assert.True(t, call(t, ctx, map[string]any{Argument: cursor}).IsError, "cursors expire")
+}
+
+func TestErrorsAreLeftAlone(t *testing.T) {
+	call, _ := newHandler(New(10), func() *mcp.CallToolResult { return mcp.NewToolResultError(strings.Repeat("a", 1000)) })
+	result := call(t, context.Background(), nil)
+	assert.Len(t, textOfPart(t, result, 0), 1000)
+}
+
+func TestAddArgument(t *testing.T) {
+	tool := mcp.NewTool("list_issues")
+	AddArgument(&tool)
+	assert.Contains(t, tool.InputSchema.Properties, Argument)
+}
@@ -0,0 +1,327 @@
+// Package cache makes conditional requests to the GitHub API. The Transport keeps the responses of GET
+// requests that carry an ETag or a Last-Modified date, and sends them along with If-None-Match and
+// If-Modified-Since the next time the same URL is requested. When GitHub answers 304 Not Modified, which
+// doesn't count against the rate limit, the kept body is served instead. Responses are always
+// revalidated, so they are never stale.
+package cache
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FromCacheHeader is set to "1" on the responses that the Transport serves from the cache.
+const FromCacheHeader = "X-From-Cache"
+
+// maxEntryShare is the share of the size of the cache above which responses are not kept, so that a
+// single large file can't push everything else out.
+const maxEntryShare = 8
+
+// Entry is a response kept in the cache.
+type Entry struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+func (e *Entry) size() int64 {
+	size := int64(len(e.Body))
+	for name, values := range e.Header {
+		size += int64(len(name))
+		for _, value := range values {
+			size += int64(len(value))
+		}
+	}
+	return size
+}
+
+// element is an entry in the recently used list. Its Entry is nil when the body is kept on disk.
+type element struct {
+	key   string
+	size  int64
+	entry *Entry
+}
+
+// Cache keeps entries up to a size in bytes, dropping the least recently used ones first. Entries are
+// kept in memory, or on disk in a directory when one is given, which keeps them across restarts. It is
+// safe for concurrent use.
+type Cache struct {
+	mu       sync.Mutex
+	maxSize  int64
+	size     int64
+	dir      string
+	recent   *list.List
+	elements map[string]*list.Element
+}
+
+// New creates a cache of maxSize bytes. With a dir, entries are kept in files in it, and the entries that
+// are already there are picked up.
+func New(maxSize int64, dir string) (*Cache, error) {
+	c := &Cache{maxSize: maxSize, dir: dir, recent: list.New(), elements: make(map[string]*list.Element)}
+	if dir == "" {
+		return c, nil
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+	// Pick up the files from the least to the most recently written
+	type file struct {
+		key  string
+		size int64
+		time int64
+	}
+	var existing []file
+	for _, f := range files {
+		// Leave alone anything that isn't an entry, such as temporary files
+		if _, err := hex.DecodeString(f.Name()); err != nil || f.IsDir() || len(f.Name()) != sha256.Size*2 {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			continue
+		}
+		existing = append(existing, file{key: f.Name(), size: info.Size(), time: info.ModTime().UnixNano()})
+	}
+	sort.Slice(existing, func(i, j int) bool { return existing[i].time < existing[j].time })
+	for _, f := range existing {
+		c.add(f.key, f.size, nil)
+	}
+	return c, nil
+}
+
+// Get returns the entry kept under a key.
+func (c *Cache) Get(key string) (*Entry, bool) {
+	name := fileName(key)
+	c.mu.Lock()
+	e, ok := c.elements[name]
+	if !ok {
+		c.mu.Unlock()
+		return nil, false
+	}
+	c.recent.MoveToFront(e)
+	entry := e.Value.(*element).entry
+	c.mu.Unlock()
+	if entry != nil {
+		return entry, true
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, name))
+	if err != nil {
+		c.remove(name)
+		return nil, false
+	}
+	entry = &Entry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		c.remove(name)
+		return nil, false
+	}
+	return entry, true
+}
+
+// Set keeps an entry under a key, unless it is larger than a share of the cache.
+func (c *Cache) Set(key string, entry *Entry) {
+	size := entry.size()
+	if size > c.maxSize/maxEntryShare {
+		return
+	}
+	name := fileName(key)
+	if c.dir == "" {
+		c.mu.Lock()
+		c.add(name, size, entry)
+		c.mu.Unlock()
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	// Write to a temporary file first, so that readers never see half of a file
+	tmp, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(c.dir, name))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	c.mu.Lock()
+	c.add(name, int64(len(data)), nil)
+	c.mu.Unlock()
+}
+
+// add puts an element at the front of the list and drops the least recently used ones beyond the size of
+// the cache. The caller must hold the lock.
+func (c *Cache) add(name string, size int64, entry *Entry) {
+	if e, ok := c.elements[name]; ok {
+		c.size -= e.Value.(*element).size
+		c.recent.Remove(e)
+	}
+	c.elements[name] = c.recent.PushFront(&element{key: name, size: size, entry: entry})
+	c.size += size
+	for c.size > c.maxSize && c.recent.Len() > 0 {
+		oldest := c.recent.Back()
+		c.drop(oldest.Value.(*element).key)
+	}
+}
+
+func (c *Cache) remove(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.drop(name)
+}
+
+// drop removes an element and its file. The caller must hold the lock.
+func (c *Cache) drop(name string) {
+	e, ok := c.elements[name]
+	if !ok {
+		return
+	}
+	c.size -= e.Value.(*element).size
+	c.recent.Remove(e)
+	delete(c.elements, name)
+	if c.dir != "" {
+		_ = os.Remove(filepath.Join(c.dir, name))
+	}
+}
+
+// fileName hashes a key into a name that is safe for files.
+func fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Key returns the key of the response to a request. Responses depend on the credentials and the media
+// type of a request as well as its URL, as the Vary header of GitHub says.
+func Key(req *http.Request) string {
+	return strings.Join([]string{req.URL.String(), req.Header.Get("Authorization"), req.Header.Get("Accept")}, "\n")
+}
+
+// Transport is an http.RoundTripper that makes GET requests conditional on the responses in a Cache.
+type Transport struct {
+	Transport http.RoundTripper
+	Cache     *Cache
+}
+
+// NewTransport wraps the given transport, falling back to http.DefaultTransport when it is nil.
+func NewTransport(transport http.RoundTripper, cache *Cache) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Transport{Transport: transport, Cache: cache}
+}
+
+// notUpdatedHeaders are the headers of a kept response that a 304 Not Modified doesn't replace, as they
+// describe the body that the 304 leaves out.
+var notUpdatedHeaders = []string{"Content-Length", "Content-Type", "Content-Encoding"}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests that are already conditional expect to see the 304 themselves
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" || req.Header.Get("Range") != "" {
+		return t.Transport.RoundTrip(req)
+	}
+
+	key := Key(req)
+	entry, cached := t.Cache.Get(key)
+	if cached {
+		req = req.Clone(req.Context())
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.Header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := t.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached && resp.StatusCode == http.StatusNotModified {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		header := entry.Header.Clone()
+		for name, values := range resp.Header {
+			header[name] = values
+		}
+		for _, name := range notUpdatedHeaders {
+			header.Del(name)
+			if values, ok := entry.Header[name]; ok {
+				header[name] = values
+			}
+		}
+		updated := &Entry{StatusCode: entry.StatusCode, Header: header, Body: entry.Body}
+		t.Cache.Set(key, updated)
+
+		served := header.Clone()
+		served.Set(FromCacheHeader, "1")
+		return &http.Response{
+			Status:        strconv.Itoa(entry.StatusCode) + " " + http.StatusText(entry.StatusCode),
+			StatusCode:    entry.StatusCode,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        served,
+			Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+			ContentLength: int64(len(entry.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	if !cacheable(resp) || resp.ContentLength > t.Cache.maxSize/maxEntryShare {
+		return resp, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, t.Cache.maxSize/maxEntryShare+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if int64(len(body)) > t.Cache.maxSize/maxEntryShare {
+		// Too large to keep, hand on what was read along with the rest
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	t.Cache.Set(key, &Entry{StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: body})
+	return resp, nil
+}
+
+// cacheable reports whether a response can be revalidated and may be kept.
+func cacheable(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	if resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" {
+		return false
+	}
+	return !strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-store")
+}
@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer serves a body with an ETag, answering 304 when the request has it.
+func newServer(t *testing.T, body *string, requests *[]*http.Request) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r)
+		etag := `"` + fileName(*body)[:8] + `"`
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(*body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func get(t *testing.T, client *http.Client, url, token string) (*http.Response, string) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestTransport(t *testing.T) {
+	body := `{"number":1,"title":"Bug"}`
+	var requests []*http.Request
+	srv := newServer(t, &body, &requests)
+	c, err := New(1<<20, "")
+	require.NoError(t, err)
+	client := &http.Client{Transport: NewTransport(nil, c)}
+
+	resp, got := get(t, client, srv.URL+"/repos/octo-org/app/issues/1", "a")
+	assert.Equal(t, body, got)
+	assert.Empty(t, resp.Header.Get(FromCacheHeader))
+
+	resp, got = get(t, client, srv.URL+"/repos/octo-org/app/issues/1", "a")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, body, got)
+	assert.Equal(t, "1", resp.Header.Get(FromCacheHeader))
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "4999", resp.Header.Get("X-RateLimit-Remaining"), "the headers of the 304 are passed on")
+	require.Len(t, requests, 2)
+	assert.NotEmpty(t, requests[1].Header.Get("If-None-Match"))
+
+	_, _ = get(t, client, srv.URL+"/repos/octo-org/app/issues/1", "b")
+	require.Len(t, requests, 3)
+	assert.Empty(t, requests[2].Header.Get("If-None-Match"), "responses aren't shared between tokens")
+
+	body = `{"number":1,"title":"Bug in the parser"}`
+	resp, got = get(t, client, srv.URL+"/repos/octo-org/app/issues/1", "a")
+	assert.Equal(t, body, got, "changed responses are served fresh")
+	assert.Empty(t, resp.Header.Get(FromCacheHeader))
+}
+
+func TestTransportLeavesOtherRequestsAlone(t *testing.T) {
+	body := `{}`
+	var requests []*http.Request
+	srv := newServer(t, &body, &requests)
+	c, err := New(1<<20, "")
+	require.NoError(t, err)
+	client := &http.Client{Transport: NewTransport(nil, c)}
+
+	_, _ = get(t, client, srv.URL+"/repos/octo-org/app", "a")
+	req, err := http.NewRequest(http.MethodPatch, srv.URL+"/repos/octo-org/app", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer a")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Empty(t, requests[1].Header.Get("If-None-Match"))
+
+	req, err = http.NewRequest(http.MethodGet, srv.URL+"/repos/octo-org/app", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer a")
+	req.Header.Set("If-None-Match", `"other"`)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"other"`, requests[2].Header.Get("If-None-Match"), "conditional requests are passed on as they are")
+}
+
+func TestTransportSkipsLargeResponses(t *testing.T) {
+	body := strings.Repeat("a", 200)
+	var requests []*http.Request
+	srv := newServer(t, &body, &requests)
+	c, err := New(800, "")
+	require.NoError(t, err)
+	client := &http.Client{Transport: NewTransport(nil, c)}
+
+	_, got := get(t, client, srv.URL+"/repos/octo-org/app/contents/big", "a")
+	assert.Equal(t, body, got, "the body is passed on whole")
+	_, _ = get(t, client, srv.URL+"/repos/octo-org/app/contents/big", "a")
+	assert.Empty(t, requests[1].Header.Get("If-None-Match"))
+}
+
+func TestCacheEvictsTheLeastRecentlyUsed(t *testing.T) {
+	c, err := New(800, "")
+	require.NoError(t, err)
+	entry := func() *Entry { return &Entry{StatusCode: http.StatusOK, Body: []byte(strings.Repeat("a", 90))} }
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		c.Set(key, entry())
+	}
+	_, ok := c.Get("a")
+	require.True(t, ok)
+	c.Set("i", entry())
+	c.Set("j", entry())
+
+	_, ok = c.Get("a")
+	assert.True(t, ok, "recently used entries are kept")
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("j")
+	assert.True(t, ok)
+}
+
+func TestCacheOnDisk(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(dir+"/notes.txt", []byte("not an entry"), 0600))
+
+	c, err := New(1<<20, dir)
+	require.NoError(t, err)
+	c.Set("a", &Entry{StatusCode: http.StatusOK, Header: http.Header{"Etag": {`"1"`}}, Body: []byte("body")})
+
+	c, err = New(1<<20, dir)
+	require.NoError(t, err)
+	entry, ok := c.Get("a")
+	require.True(t, ok, "entries are kept across restarts")
+	assert.Equal(t, "body", string(entry.Body))
+	assert.Equal(t, `"1"`, entry.Header.Get("ETag"))
+
+	c, err = New(10, dir)
+	require.NoError(t, err)
+	_, ok = c.Get("a")
+	assert.False(t, ok, "entries beyond the size are dropped")
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "notes.txt", files[0].Name(), "other files are left alone")
+}
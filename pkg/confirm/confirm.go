@@ -0,0 +1,234 @@
+// Package confirm holds back the calls of destructive tools until they are confirmed. The first call of a
+// destructive tool returns a one-time confirmation token instead of running, and the tool only runs when
+// it is called again with the same arguments and that token, which gives the client the chance to ask the
+// user first.
+package confirm
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/dryrun"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Tier classifies a tool call by the harm it can do.
+type Tier int
+
+const (
+	// Read is a call that changes nothing
+	Read Tier = iota
+	// Write is a call that changes something that can be changed back
+	Write
+	// Destructive is a call that deletes or overwrites something that can't be brought back, or takes
+	// away access
+	Destructive
+)
+
+func (t Tier) String() string {
+	switch t {
+	case Read:
+		return "read"
+	case Write:
+		return "write"
+	default:
+		return "destructive"
+	}
+}
+
+// Argument is the argument that carries the confirmation token of a call.
+const Argument = "confirmation"
+
+// ArgumentDescription describes the Argument that AddArgument gives destructive tools.
+const ArgumentDescription = "The confirmation token returned by the previous call of this tool with the same arguments. Only set it after the user confirmed the change"
+
+// DefaultTTL is how long confirmation tokens are valid.
+const DefaultTTL = 5 * time.Minute
+
+// forceArguments are the arguments that make writes destructive, such as the force of update_git_ref,
+// which may move a branch away from commits that nothing else points to.
+var forceArguments = []string{"force"}
+
+// Classify returns the tier of a call of a tool. Tools are destructive if their annotations say so, or if
+// the call sets one of the forceArguments.
+func Classify(tool mcp.Tool, arguments map[string]any) Tier {
+	if tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint {
+		return Read
+	}
+	if tool.Annotations.DestructiveHint != nil && *tool.Annotations.DestructiveHint {
+		return Destructive
+	}
+	for _, argument := range forceArguments {
+		if force, _ := arguments[argument].(bool); force {
+			return Destructive
+		}
+	}
+	return Write
+}
+
+// MayBeDestructive reports whether some calls of a tool are destructive.
+func MayBeDestructive(tool mcp.Tool) bool {
+	if Classify(tool, nil) == Destructive {
+		return true
+	}
+	if tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint {
+		return false
+	}
+	for _, argument := range forceArguments {
+		if _, ok := tool.InputSchema.Properties[argument]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AddArgument gives a tool the Argument.
+func AddArgument(tool *mcp.Tool) {
+	if tool.InputSchema.Properties == nil {
+		tool.InputSchema.Properties = map[string]any{}
+	}
+	tool.InputSchema.Properties[Argument] = map[string]any{
+		"type":        "string",
+		"description": ArgumentDescription,
+	}
+}
+
+// pending is a call waiting for its confirmation.
+type pending struct {
+	session string
+	// caller tells apart the callers that share a session, such as the users of a server that acts with
+	// the token of each tool call
+	caller  string
+	tool    string
+	digest  string
+	expires time.Time
+}
+
+// Confirmer hands out and redeems confirmation tokens. It is safe for concurrent use.
+type Confirmer struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	pending map[string]pending
+}
+
+// NewConfirmer creates a Confirmer whose tokens are valid for ttl, or DefaultTTL if it is zero.
+func NewConfirmer(ttl time.Duration) *Confirmer {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Confirmer{ttl: ttl, now: time.Now, pending: make(map[string]pending)}
+}
+
+// issue returns a new token for a call.
+func (c *Confirmer) issue(call pending) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	token := hex.EncodeToString(b)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for t, p := range c.pending {
+		if !now.Before(p.expires) {
+			delete(c.pending, t)
+		}
+	}
+	call.expires = now.Add(c.ttl)
+	c.pending[token] = call
+	return token, nil
+}
+
+// redeem reports whether a token was issued for a call and hasn't expired. Tokens can only be redeemed
+// once, whether the call matches or not.
+func (c *Confirmer) redeem(token string, call pending) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.pending[token]
+	if !ok {
+		return false
+	}
+	delete(c.pending, token)
+	return c.now().Before(p.expires) && p.session == call.session && p.caller == call.caller && p.tool == call.tool && p.digest == call.digest
+}
+
+// digest hashes the arguments of a call, leaving out the Argument. encoding/json sorts the keys of maps,
+// so equal arguments hash the same.
+func digest(arguments map[string]any) (string, error) {
+	rest := make(map[string]any, len(arguments))
+	for name, value := range arguments {
+		if name != Argument {
+			rest[name] = value
+		}
+	}
+	data, err := json.Marshal(rest)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal arguments: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// isDryRun reports whether a call is a dry run, either of the Transport of package dryrun or of a tool
+// with a dry run of its own.
+func isDryRun(ctx context.Context, arguments map[string]any) bool {
+	dryRun, _ := arguments[dryrun.Argument].(bool)
+	return dryRun || dryrun.RecorderFromContext(ctx) != nil
+}
+
+// ToolHandlerMiddleware holds back destructive calls until they are confirmed. Dry runs change nothing,
+// and run without confirmation. lookup returns the definition of a tool, which tells the tier of its
+// calls. Tokens only confirm calls of the session and the caller they were issued to. caller identifies
+// the caller of a tool call, and may be nil when sessions have a single caller.
+func ToolHandlerMiddleware(c *Confirmer, lookup func(name string) (mcp.Tool, bool), caller func(ctx context.Context) string) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			tool, ok := lookup(request.Params.Name)
+			arguments := request.GetArguments()
+			if !ok || Classify(tool, arguments) != Destructive || isDryRun(ctx, arguments) {
+				return next(ctx, request)
+			}
+
+			call := pending{tool: request.Params.Name}
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				call.session = session.SessionID()
+			}
+			if caller != nil {
+				call.caller = caller(ctx)
+			}
+			var err error
+			if call.digest, err = digest(arguments); err != nil {
+				return nil, err
+			}
+
+			if token, _ := arguments[Argument].(string); token != "" {
+				if !c.redeem(token, call) {
+					return mcp.NewToolResultError(fmt.Sprintf("%s was not run: the confirmation token is unknown, expired, already used or was issued for other arguments. Call %s again without %s to get a new one", request.Params.Name, request.Params.Name, Argument)), nil
+				}
+				rest := make(map[string]any, len(arguments))
+				for name, value := range arguments {
+					if name != Argument {
+						rest[name] = value
+					}
+				}
+				request.Params.Arguments = rest
+				return next(ctx, request)
+			}
+
+			token, err := c.issue(call)
+			if err != nil {
+				return nil, err
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("%s was not run: it is destructive and needs confirmation. Ask the user to confirm the change, then call %s again with the same arguments and %s set to %q within %s", request.Params.Name, request.Params.Name, Argument, token, c.ttl)), nil
+		}
+	}
+}
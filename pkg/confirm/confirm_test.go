@@ -0,0 +1,153 @@
+package confirm
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/dryrun"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	getIssue     = mcp.NewTool("get_issue", mcp.WithReadOnlyHintAnnotation(true))
+	createIssue  = mcp.NewTool("create_issue", mcp.WithReadOnlyHintAnnotation(false), mcp.WithDestructiveHintAnnotation(false))
+	deleteFile   = mcp.NewTool("delete_file", mcp.WithReadOnlyHintAnnotation(false), mcp.WithDestructiveHintAnnotation(true))
+	updateGitRef = mcp.NewTool("update_git_ref", mcp.WithReadOnlyHintAnnotation(false), mcp.WithDestructiveHintAnnotation(false), mcp.WithBoolean("force"))
+)
+
+func TestClassify(t *testing.T) {
+	assert.Equal(t, Read, Classify(getIssue, nil))
+	assert.Equal(t, Write, Classify(createIssue, nil))
+	assert.Equal(t, Destructive, Classify(deleteFile, nil))
+	assert.Equal(t, Write, Classify(updateGitRef, map[string]any{"force": false}))
+	assert.Equal(t, Destructive, Classify(updateGitRef, map[string]any{"force": true}))
+	assert.Equal(t, "destructive", Destructive.String())
+
+	assert.False(t, MayBeDestructive(getIssue))
+	assert.False(t, MayBeDestructive(createIssue))
+	assert.True(t, MayBeDestructive(deleteFile))
+	assert.True(t, MayBeDestructive(updateGitRef))
+}
+
+var tokenPattern = regexp.MustCompile(`confirmation set to "([0-9a-f]+)"`)
+
+func TestToolHandlerMiddleware(t *testing.T) {
+	tools := map[string]mcp.Tool{"get_issue": getIssue, "create_issue": createIssue, "delete_file": deleteFile, "update_git_ref": updateGitRef}
+	c := NewConfirmer(time.Minute)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	var calls []map[string]any
+	handler := ToolHandlerMiddleware(c, func(name string) (mcp.Tool, bool) {
+		tool, ok := tools[name]
+		return tool, ok
+	}, func(ctx context.Context) string {
+		caller, _ := ctx.Value(callerKey{}).(string)
+		return caller
+	})(func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls = append(calls, request.GetArguments())
+		return mcp.NewToolResultText("done"), nil
+	})
+	call := func(ctx context.Context, name string, arguments map[string]any) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = arguments
+		result, err := handler(ctx, request)
+		require.NoError(t, err)
+		return result
+	}
+	text := func(result *mcp.CallToolResult) string {
+		return result.Content[0].(mcp.TextContent).Text
+	}
+	tokenOf := func(result *mcp.CallToolResult) string {
+		require.True(t, result.IsError)
+		match := tokenPattern.FindStringSubmatch(text(result))
+		require.Len(t, match, 2, text(result))
+		return match[1]
+	}
+	ctx := context.Background()
+	arguments := func() map[string]any {
+		return map[string]any{"owner": "octo-org", "repo": "app", "path": "README.md", "branch": "main"}
+	}
+	withToken := func(token string) map[string]any {
+		a := arguments()
+		a[Argument] = token
+		return a
+	}
+
+	t.Run("reads and writes run", func(t *testing.T) {
+		calls = nil
+		assert.Equal(t, "done", text(call(ctx, "get_issue", map[string]any{})))
+		assert.Equal(t, "done", text(call(ctx, "create_issue", map[string]any{})))
+		assert.Equal(t, "done", text(call(ctx, "update_git_ref", map[string]any{"force": false})))
+		assert.Len(t, calls, 3)
+	})
+
+	t.Run("destructive call confirmed", func(t *testing.T) {
+		calls = nil
+		token := tokenOf(call(ctx, "delete_file", arguments()))
+		assert.Empty(t, calls)
+
+		assert.Equal(t, "done", text(call(ctx, "delete_file", withToken(token))))
+		assert.Equal(t, []map[string]any{arguments()}, calls, "the token is not passed on")
+
+		result := call(ctx, "delete_file", withToken(token))
+		assert.True(t, result.IsError, "tokens are used once")
+		assert.Contains(t, text(result), "unknown, expired, already used")
+	})
+
+	t.Run("token of other arguments", func(t *testing.T) {
+		token := tokenOf(call(ctx, "delete_file", arguments()))
+		other := withToken(token)
+		other["path"] = "LICENSE"
+		assert.True(t, call(ctx, "delete_file", other).IsError)
+		assert.True(t, call(ctx, "delete_file", withToken(token)).IsError, "failed confirmations use up the token")
+	})
+
+	t.Run("token of another session", func(t *testing.T) {
+		token := tokenOf(call(ctx, "delete_file", arguments()))
+		sessionCtx := server.NewMCPServer("test", "1").WithContext(ctx, fakeSession{})
+		assert.True(t, call(sessionCtx, "delete_file", withToken(token)).IsError)
+	})
+
+	t.Run("token of another caller of the session", func(t *testing.T) {
+		alice := context.WithValue(ctx, callerKey{}, "alice")
+		token := tokenOf(call(alice, "delete_file", arguments()))
+		assert.True(t, call(context.WithValue(ctx, callerKey{}, "bob"), "delete_file", withToken(token)).IsError)
+
+		token = tokenOf(call(alice, "delete_file", arguments()))
+		assert.Equal(t, "done", text(call(alice, "delete_file", withToken(token))))
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		token := tokenOf(call(ctx, "delete_file", arguments()))
+		now = now.Add(time.Minute)
+		assert.True(t, call(ctx, "delete_file", withToken(token)).IsError)
+	})
+
+	t.Run("forced update", func(t *testing.T) {
+		tokenOf(call(ctx, "update_git_ref", map[string]any{"force": true}))
+	})
+
+	t.Run("dry runs", func(t *testing.T) {
+		calls = nil
+		dryRunCtx, _ := dryrun.ContextWithRecorder(ctx)
+		assert.Equal(t, "done", text(call(dryRunCtx, "delete_file", arguments())))
+		assert.Equal(t, "done", text(call(ctx, "delete_file", map[string]any{dryrun.Argument: true})))
+		assert.Len(t, calls, 2)
+	})
+}
+
+type callerKey struct{}
+
+type fakeSession struct{}
+
+func (fakeSession) SessionID() string                                   { return "session-1" }
+func (fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (fakeSession) Initialize()                                         {}
+func (fakeSession) Initialized() bool                                   { return true }
@@ -0,0 +1,328 @@
+// Package cursor gives list tools opaque pagination cursors. A call of a list tool answers with a
+// next_cursor when there are more results, whether the tool pages through the REST API by page number or
+// through GraphQL by cursor, and the next page is fetched by calling the tool again with the cursor
+// argument set to it. Agents then continue listings without having to work out the next page themselves.
+package cursor
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/structured"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Argument is the argument of list tools that carries the cursor of the page to fetch.
+const Argument = "cursor"
+
+// ArgumentDescription describes the Argument of list tools.
+const ArgumentDescription = "The next_cursor of the previous page of results, to get the page after it. Takes the place of page, perPage and after"
+
+// Field is the field of results that carries the cursor of the next page.
+const Field = "next_cursor"
+
+// defaultPerPage is the page size of the REST API when none is given.
+const defaultPerPage = 30
+
+// allPagesArgument is the argument of the list tools that fetch every page at once, whose results have no
+// next page.
+const allPagesArgument = "allPages"
+
+// ErrInvalid is the error of cursors that weren't returned by a list tool.
+var ErrInvalid = errors.New("invalid cursor, use the next_cursor of a previous result")
+
+// Position is where a page starts: a page number for the REST API, or the end cursor of the previous page
+// for GraphQL.
+type Position struct {
+	Page    int    `json:"p,omitempty"`
+	PerPage int    `json:"n,omitempty"`
+	After   string `json:"a,omitempty"`
+}
+
+// Encode returns the cursor of a position.
+func Encode(p Position) string {
+	data, _ := json.Marshal(p)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode returns the position of a cursor.
+func Decode(cursor string) (Position, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return Position{}, ErrInvalid
+	}
+	var p Position
+	if err := json.Unmarshal(data, &p); err != nil || p.Page < 0 || p.PerPage < 0 || (p.Page == 0 && p.After == "") {
+		return Position{}, ErrInvalid
+	}
+	return p, nil
+}
+
+// page is a REST API page that was fetched during a tool call. Listings that GitHub pages with cursors
+// of its own, such as Dependabot alerts, start pages after a cursor rather than at a number.
+type page struct {
+	number    int
+	perPage   int
+	after     string
+	next      int
+	nextAfter string
+}
+
+// Recorder collects the pages fetched during a single tool call. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	pages []page
+}
+
+func (r *Recorder) add(p page) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pages = append(r.pages, p)
+}
+
+// next returns the position of the page after the first page fetched at a position, or false if it was
+// the last one. Tools may fetch several listings, such as the owners of an organization next to its
+// members, so only the page that the call asked for counts.
+func (r *Recorder) next(at Position) (Position, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range r.pages {
+		if p.perPage != at.PerPage || p.after != at.After || (at.After == "" && p.number != at.Page) {
+			continue
+		}
+		switch {
+		case p.next != 0:
+			return Position{Page: p.next, PerPage: at.PerPage}, true
+		case p.nextAfter != "":
+			return Position{After: p.nextAfter, PerPage: at.PerPage}, true
+		}
+		return Position{}, false
+	}
+	return Position{}, false
+}
+
+type recorderKey struct{}
+
+// ContextWithRecorder returns a context carrying a fresh Recorder, along with the Recorder itself.
+func ContextWithRecorder(ctx context.Context) (context.Context, *Recorder) {
+	r := &Recorder{}
+	return context.WithValue(ctx, recorderKey{}, r), r
+}
+
+// RecorderFromContext returns the Recorder stored in the context, or nil if there is none.
+func RecorderFromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+// Transport is an http.RoundTripper that records the pages of the REST API listings fetched into the
+// Recorder found in the request context. Requests without a Recorder pass through untouched.
+type Transport struct {
+	Transport http.RoundTripper
+}
+
+// NewTransport wraps the given transport, falling back to http.DefaultTransport when it is nil.
+func NewTransport(transport http.RoundTripper) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Transport{Transport: transport}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Transport.RoundTrip(req)
+	if r := RecorderFromContext(req.Context()); r != nil && err == nil && req.Method == http.MethodGet {
+		number, perPage := pageOf(req.URL)
+		next, nextAfter := nextPage(resp.Header.Get("Link"))
+		r.add(page{number: number, perPage: perPage, after: req.URL.Query().Get("after"), next: next, nextAfter: nextAfter})
+	}
+	return resp, err
+}
+
+// pageOf returns the page number and size that a URL asks for.
+func pageOf(u *url.URL) (int, int) {
+	query := u.Query()
+	number, err := strconv.Atoi(query.Get("page"))
+	if err != nil || number < 1 {
+		number = 1
+	}
+	perPage, err := strconv.Atoi(query.Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	return number, perPage
+}
+
+// nextPage returns the page number of the next link of a Link header, or for listings that link to
+// their next page with a cursor of their own the after cursor, or neither if there is no next link.
+func nextPage(link string) (int, string) {
+	for _, part := range strings.Split(link, ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(part), ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err != nil {
+			return 0, ""
+		}
+		query := u.Query()
+		if number, err := strconv.Atoi(query.Get("page")); err == nil && number > 0 {
+			return number, ""
+		}
+		return 0, query.Get("after")
+	}
+	return 0, ""
+}
+
+// FromArguments returns the position that the arguments of a call of a list tool ask for, from its
+// cursor, or else from its page, perPage and after.
+func FromArguments(arguments map[string]any) (Position, error) {
+	if c, _ := arguments[Argument].(string); c != "" {
+		return Decode(c)
+	}
+	p := Position{Page: 1, PerPage: defaultPerPage}
+	if page, ok := arguments["page"].(float64); ok {
+		p.Page = int(page)
+	}
+	// Some list tools spell it per_page
+	for _, name := range []string{"perPage", "per_page"} {
+		if perPage, ok := arguments[name].(float64); ok {
+			p.PerPage = int(perPage)
+		}
+	}
+	p.After, _ = arguments["after"].(string)
+	return p, nil
+}
+
+// Paginated reports whether a tool is a list tool that takes the Argument next to a page or an after.
+// Tools that take a cursor of GitHub's own, such as the ones listing webhook deliveries, have neither.
+func Paginated(tool mcp.Tool) bool {
+	properties := tool.InputSchema.Properties
+	_, hasCursor := properties[Argument]
+	_, hasPage := properties["page"]
+	_, hasAfter := properties["after"]
+	return hasCursor && (hasPage || hasAfter)
+}
+
+// pageInfo is the page info of a GraphQL connection, as the GraphQL tools return it.
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// graphQLNext returns the end cursor of a result that holds the page info of a GraphQL connection with
+// more pages, at the top or one object down.
+func graphQLNext(object map[string]json.RawMessage) (string, bool) {
+	var info pageInfo
+	if raw, ok := object["pageInfo"]; ok && json.Unmarshal(raw, &info) == nil {
+		return info.EndCursor, info.HasNextPage && info.EndCursor != ""
+	}
+	for _, raw := range object {
+		var nested map[string]json.RawMessage
+		if len(raw) == 0 || raw[0] != '{' || json.Unmarshal(raw, &nested) != nil {
+			continue
+		}
+		if raw, ok := nested["pageInfo"]; ok && json.Unmarshal(raw, &info) == nil {
+			return info.EndCursor, info.HasNextPage && info.EndCursor != ""
+		}
+	}
+	return "", false
+}
+
+// marshal encodes a value without escaping HTML, so that the result reads as the tool returned it.
+func marshal(v any) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// ToolHandlerMiddleware adds the cursor of the next page to the results of the list tools that lookup finds
+// Paginated. Results whose first part is a JSON object get it in their Field, and results whose first part
+// is a JSON list in a last part of their own and next to their items in their structured content. Results
+// that already have a Field, such as those of listings that GitHub pages with cursors of its own, are left
+// alone. It must wrap the middleware that truncates results, so that the cursor is added to what is left
+// of the listing rather than cut off with the rest.
+func ToolHandlerMiddleware(lookup func(name string) (mcp.Tool, bool)) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			tool, ok := lookup(request.Params.Name)
+			if !ok || !Paginated(tool) {
+				return next(ctx, request)
+			}
+			arguments := request.GetArguments()
+			at, err := FromArguments(arguments)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ctx, recorder := ContextWithRecorder(ctx)
+
+			result, err := next(ctx, request)
+			if allPages, _ := arguments[allPagesArgument].(bool); err != nil || result == nil || result.IsError || allPages || len(result.Content) == 0 {
+				return result, err
+			}
+			text, ok := result.Content[0].(mcp.TextContent)
+			if !ok {
+				return result, nil
+			}
+			trimmed := strings.TrimSpace(text.Text)
+
+			var object map[string]json.RawMessage
+			if strings.HasPrefix(trimmed, "{") && json.Unmarshal([]byte(trimmed), &object) == nil {
+				if _, ok := object[Field]; ok {
+					return result, nil
+				}
+				var nextCursor string
+				if after, ok := graphQLNext(object); ok {
+					nextCursor = Encode(Position{After: after, PerPage: at.PerPage})
+				} else if position, ok := recorder.next(at); ok {
+					nextCursor = Encode(position)
+				} else {
+					return result, nil
+				}
+				cursorJSON, _ := json.Marshal(nextCursor)
+				object[Field] = cursorJSON
+				out, err := marshal(object)
+				if err != nil {
+					return result, nil
+				}
+				text.Text = out
+				result.Content[0] = text
+				if content, ok := result.StructuredContent.(map[string]any); ok {
+					content[Field] = nextCursor
+				}
+				return result, nil
+			}
+
+			position, ok := recorder.next(at)
+			if !strings.HasPrefix(trimmed, "[") || !ok {
+				return result, nil
+			}
+			nextCursor := Encode(position)
+			out, err := marshal(map[string]string{Field: nextCursor})
+			if err != nil {
+				return result, nil
+			}
+			if result.StructuredContent == nil && len(result.Content) == 1 {
+				result.StructuredContent = structured.Content(text.Text)
+			}
+			if content, ok := result.StructuredContent.(map[string]any); ok {
+				content[Field] = nextCursor
+			}
+			result.Content = append(result.Content, mcp.NewTextContent(out))
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package cursor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	listIssues = mcp.NewTool("list_issues",
+		mcp.WithNumber("page"), mcp.WithNumber("perPage"), mcp.WithString(Argument), mcp.WithBoolean("allPages"))
+	listDiscussions = mcp.NewTool("list_discussions",
+		mcp.WithNumber("perPage"), mcp.WithString("after"), mcp.WithString(Argument))
+	listDeliveries = mcp.NewTool("list_webhook_deliveries",
+		mcp.WithNumber("perPage"), mcp.WithString(Argument))
+	getIssue = mcp.NewTool("get_issue")
+)
+
+func TestEncodeDecode(t *testing.T) {
+	for _, p := range []Position{{Page: 2, PerPage: 50}, {After: "Y3Vyc29yOjI=", PerPage: 10}} {
+		decoded, err := Decode(Encode(p))
+		require.NoError(t, err)
+		assert.Equal(t, p, decoded)
+	}
+	for _, c := range []string{"not a cursor", Encode(Position{}), Encode(Position{Page: -1})} {
+		_, err := Decode(c)
+		assert.ErrorIs(t, err, ErrInvalid, c)
+	}
+}
+
+func TestNextPage(t *testing.T) {
+	for link, want := range map[string]struct {
+		number int
+		after  string
+	}{
+		`<https://api.github.com/repositories/1/issues?page=1&per_page=2>; rel="prev", <https://api.github.com/repositories/1/issues?page=3&per_page=2>; rel="next", <https://api.github.com/repositories/1/issues?page=5&per_page=2>; rel="last"`: {number: 3},
+		`<https://api.github.com/repositories/1/dependabot/alerts?per_page=2&after=Y3Vyc29yOnYyOpHOAAAAAg%3D%3D>; rel="next"`:                                                                                                                      {after: "Y3Vyc29yOnYyOpHOAAAAAg=="},
+		`<https://api.github.com/repositories/1/issues?page=1>; rel="first"`:                       {},
+		`<https://api.github.com/repos/octo-org/app/hooks/1/deliveries?cursor=v1_123>; rel="next"`: {},
+		"": {},
+	} {
+		number, after := nextPage(link)
+		assert.Equal(t, want.number, number, link)
+		assert.Equal(t, want.after, after, link)
+	}
+}
+
+func TestPaginated(t *testing.T) {
+	assert.True(t, Paginated(listIssues))
+	assert.True(t, Paginated(listDiscussions))
+	assert.False(t, Paginated(listDeliveries), "cursors of GitHub's own aren't ours")
+	assert.False(t, Paginated(getIssue))
+}
+
+// newListing serves issues a page at a time, linking to the next page like GitHub does.
+func newListing(t *testing.T, total int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, perPage := pageOf(r.URL)
+		if page*perPage < total {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=%d&per_page=%d>; rel="next"`, r.Host, r.URL.Path, page+1, perPage))
+		}
+		var numbers []int
+		for i := (page-1)*perPage + 1; i <= min(page*perPage, total); i++ {
+			numbers = append(numbers, i)
+		}
+		_ = json.NewEncoder(w).Encode(numbers)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newHandler returns a handler that lists the issues of a listing the way the REST list tools do, and
+// looks up the owners of the organization on the way.
+func newHandler(t *testing.T, srv *httptest.Server) func(arguments map[string]any) *mcp.CallToolResult {
+	client := &http.Client{Transport: NewTransport(nil)}
+	get := func(ctx context.Context, page, perPage int) string {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/repos/octo-org/app/issues?page="+strconv.Itoa(page)+"&per_page="+strconv.Itoa(perPage), nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		var body json.RawMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return string(body)
+	}
+	tools := map[string]mcp.Tool{"list_issues": listIssues, "get_issue": getIssue}
+	handler := ToolHandlerMiddleware(func(name string) (mcp.Tool, bool) {
+		tool, ok := tools[name]
+		return tool, ok
+	})(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		at, err := FromArguments(request.GetArguments())
+		require.NoError(t, err)
+		issues := get(ctx, at.Page, at.PerPage)
+		_ = get(ctx, 1, 100)
+		return mcp.NewToolResultText(issues), nil
+	})
+	return func(arguments map[string]any) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "list_issues"
+		request.Params.Arguments = arguments
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+}
+
+func TestToolHandlerMiddlewareOfListings(t *testing.T) {
+	call := newHandler(t, newListing(t, 250))
+
+	var numbers []int
+	result := call(map[string]any{"perPage": float64(100)})
+	for {
+		var page []int
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &page))
+		numbers = append(numbers, page...)
+		if len(result.Content) == 1 {
+			break
+		}
+
+		require.Len(t, result.Content, 2)
+		var next map[string]string
+		require.NoError(t, json.Unmarshal([]byte(result.Content[1].(mcp.TextContent).Text), &next))
+		content, ok := result.StructuredContent.(map[string]any)
+		require.True(t, ok)
+		assert.Len(t, content["items"], len(page))
+		assert.Equal(t, next[Field], content[Field])
+		position, err := Decode(next[Field])
+		require.NoError(t, err)
+		assert.Equal(t, 100, position.PerPage, "the page size is kept")
+
+		result = call(map[string]any{Argument: next[Field]})
+	}
+	assert.Len(t, numbers, 250)
+	assert.Equal(t, 250, numbers[249])
+}
+
+func TestToolHandlerMiddlewareOfCursorListings(t *testing.T) {
+	// Dependabot alerts link to their next page with an after cursor instead of a page number
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.Atoi(r.URL.Query().Get("after"))
+		if start+2 < 5 {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?per_page=2&after=%d>; rel="next"`, r.Host, r.URL.Path, start+2))
+		}
+		var numbers []int
+		for i := start + 1; i <= min(start+2, 5); i++ {
+			numbers = append(numbers, i)
+		}
+		_ = json.NewEncoder(w).Encode(numbers)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: NewTransport(nil)}
+	handler := ToolHandlerMiddleware(func(_ string) (mcp.Tool, bool) { return listIssues, true })(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		at, err := FromArguments(request.GetArguments())
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/repos/octo-org/app/dependabot/alerts?per_page=2&after="+at.After, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		var body json.RawMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return mcp.NewToolResultText(string(body)), nil
+	})
+
+	var numbers []int
+	arguments := map[string]any{"perPage": float64(2)}
+	for {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = arguments
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		var page []int
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &page))
+		numbers = append(numbers, page...)
+		if len(result.Content) == 1 {
+			break
+		}
+		var next map[string]string
+		require.NoError(t, json.Unmarshal([]byte(result.Content[1].(mcp.TextContent).Text), &next))
+		arguments = map[string]any{Argument: next[Field]}
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, numbers)
+}
+
+func TestToolHandlerMiddlewareLeavesOtherResultsAlone(t *testing.T) {
+	call := newHandler(t, newListing(t, 250))
+
+	result := call(map[string]any{"allPages": true})
+	assert.Len(t, result.Content, 1, "every page was fetched")
+
+	result = call(map[string]any{Argument: "not a cursor"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "invalid cursor")
+}
+
+func TestToolHandlerMiddlewareOfObjects(t *testing.T) {
+	tools := map[string]mcp.Tool{"list_discussions": listDiscussions, "list_webhook_deliveries": listDeliveries}
+	call := func(name string, arguments map[string]any, text string) *mcp.CallToolResult {
+		handler := ToolHandlerMiddleware(func(name string) (mcp.Tool, bool) {
+			tool, ok := tools[name]
+			return tool, ok
+		})(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(text), nil
+		})
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = arguments
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call("list_discussions", map[string]any{"perPage": float64(10)}, `{"discussions":[{"title":"<Ideas>"}],"pageInfo":{"hasNextPage":true,"endCursor":"Y3Vyc29yOjI="},"totalCount":20}`)
+	var object struct {
+		Discussions []map[string]any `json:"discussions"`
+		NextCursor  string           `json:"next_cursor"`
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	require.NoError(t, json.Unmarshal([]byte(text), &object))
+	assert.Contains(t, text, "<Ideas>", "HTML isn't escaped")
+	position, err := Decode(object.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, Position{After: "Y3Vyc29yOjI=", PerPage: 10}, position)
+
+	result = call("list_discussions", nil, `{"discussions":[],"pageInfo":{"hasNextPage":false,"endCursor":"Y3Vyc29yOjI="}}`)
+	assert.NotContains(t, result.Content[0].(mcp.TextContent).Text, Field, "the last page has no next page")
+
+	result = call("list_webhook_deliveries", map[string]any{Argument: "v1_123"}, `{"deliveries":[],"next_cursor":"v1_456"}`)
+	assert.JSONEq(t, `{"deliveries":[],"next_cursor":"v1_456"}`, result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestToolHandlerMiddlewareSkipsOtherTools(t *testing.T) {
+	calls := 0
+	handler := ToolHandlerMiddleware(func(_ string) (mcp.Tool, bool) { return getIssue, true })(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		assert.Nil(t, RecorderFromContext(ctx))
+		return mcp.NewToolResultText(`[]`), nil
+	})
+	_, err := handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
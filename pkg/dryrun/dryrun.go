@@ -0,0 +1,206 @@
+// Package dryrun previews the calls of write tools. Instead of sending the first GitHub API request that
+// would change something, the Transport holds it back, and the tool answers with the method, URL and
+// payload of that request. Requests that only read are sent as usual, as the request held back may
+// depend on them.
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/graphql"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Argument is the argument of write tools that asks for a dry run.
+const Argument = "dry_run"
+
+// ArgumentDescription describes the Argument that AddArgument gives write tools.
+const ArgumentDescription = "Describe the GitHub API request that would make the first change, with its method, URL and payload, instead of making any change"
+
+// ErrHeldBack is the error of the requests that the Transport holds back.
+var ErrHeldBack = errors.New("request held back by a dry run")
+
+// Request is a request that a dry run held back.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// Payload is the JSON body of the request, or a description of a body that isn't JSON
+	Payload any `json:"payload,omitempty"`
+}
+
+// Preview is the result of a dry run of a tool.
+type Preview struct {
+	DryRun  bool    `json:"dry_run"`
+	Message string  `json:"message"`
+	Request Request `json:"request"`
+}
+
+// Recorder holds the first request held back during a single tool call. It is safe for concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	request *Request
+}
+
+func (r *Recorder) hold(request Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.request == nil {
+		r.request = &request
+	}
+}
+
+// Request returns the request that was held back, or nil if the tool made no change.
+func (r *Recorder) Request() *Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.request
+}
+
+type recorderKey struct{}
+
+// ContextWithRecorder returns a context that makes the Transport hold back changes, along with the
+// Recorder of the request held back.
+func ContextWithRecorder(ctx context.Context) (context.Context, *Recorder) {
+	r := &Recorder{}
+	return context.WithValue(ctx, recorderKey{}, r), r
+}
+
+// RecorderFromContext returns the Recorder stored in the context, or nil if there is none.
+func RecorderFromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+// Transport is an http.RoundTripper that holds back the requests that would change something, if their
+// context carries a Recorder. Other requests pass through untouched.
+type Transport struct {
+	Transport http.RoundTripper
+}
+
+// NewTransport wraps the given transport, falling back to http.DefaultTransport when it is nil.
+func NewTransport(transport http.RoundTripper) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Transport{Transport: transport}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := RecorderFromContext(req.Context())
+	if r == nil || req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return t.Transport.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if isGraphQLQuery(req, body) {
+		return t.Transport.RoundTrip(req)
+	}
+
+	request := Request{Method: req.Method, URL: req.URL.String()}
+	if len(body) > 0 {
+		var payload any
+		if json.Unmarshal(body, &payload) == nil {
+			request.Payload = payload
+		} else {
+			request.Payload = fmt.Sprintf("%d bytes of %s", len(body), req.Header.Get("Content-Type"))
+		}
+	}
+	r.hold(request)
+	return nil, ErrHeldBack
+}
+
+// isGraphQLQuery reports whether a request is a GraphQL query, which only reads, rather than a mutation.
+func isGraphQLQuery(req *http.Request, body []byte) bool {
+	if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/graphql") {
+		return false
+	}
+	return graphql.ReadOnlyRequest(body)
+}
+
+// AddArgument gives a tool the Argument, unless it has its own.
+func AddArgument(tool *mcp.Tool) {
+	if _, ok := tool.InputSchema.Properties[Argument]; ok {
+		return
+	}
+	if tool.InputSchema.Properties == nil {
+		tool.InputSchema.Properties = map[string]any{}
+	}
+	tool.InputSchema.Properties[Argument] = map[string]any{
+		"type":        "boolean",
+		"description": ArgumentDescription,
+	}
+}
+
+// hasOwnArgument reports whether a tool implements a dry run of its own, which previews more than the
+// first request.
+func hasOwnArgument(tool mcp.Tool) bool {
+	property, ok := tool.InputSchema.Properties[Argument].(map[string]any)
+	return ok && property["description"] != ArgumentDescription
+}
+
+// ToolHandlerMiddleware previews the calls of write tools that ask for a dry run, or all of them if
+// forced. Tools with their own dry run get their Argument set instead. lookup returns the definition of
+// a tool, which tells write tools and their arguments apart.
+func ToolHandlerMiddleware(forced bool, lookup func(name string) (mcp.Tool, bool)) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			tool, ok := lookup(request.Params.Name)
+			if !ok || (tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint) {
+				return next(ctx, request)
+			}
+			dryRun, _ := request.GetArguments()[Argument].(bool)
+			if !dryRun && !forced {
+				return next(ctx, request)
+			}
+
+			if hasOwnArgument(tool) {
+				arguments := make(map[string]any, len(request.GetArguments())+1)
+				for name, value := range request.GetArguments() {
+					arguments[name] = value
+				}
+				arguments[Argument] = true
+				request.Params.Arguments = arguments
+				return next(ctx, request)
+			}
+
+			ctx, recorder := ContextWithRecorder(ctx)
+			result, err := next(ctx, request)
+			held := recorder.Request()
+			if held == nil {
+				return result, err
+			}
+
+			preview := Preview{
+				DryRun:  true,
+				Message: fmt.Sprintf("Nothing was changed. This is the request %s would have sent to make its first change, any requests after it depend on its response", request.Params.Name),
+				Request: *held,
+			}
+			data, err := json.Marshal(preview)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal dry run: %w", err)
+			}
+			result = mcp.NewToolResultText(string(data))
+			// A preview doesn't look like the results of tools that describe their own
+			result.IsError = tool.RawOutputSchema != nil
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	var sent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: NewTransport(nil)}
+
+	do := func(ctx context.Context, method, path, body string) error {
+		req, err := http.NewRequestWithContext(ctx, method, srv.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if err == nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	require.NoError(t, do(context.Background(), http.MethodPost, "/repos/octo-org/app/issues", `{"title":"Bug"}`), "requests without a recorder are sent")
+
+	ctx, recorder := ContextWithRecorder(context.Background())
+	require.NoError(t, do(ctx, http.MethodGet, "/repos/octo-org/app", ""))
+	require.NoError(t, do(ctx, http.MethodPost, "/graphql", `{"query":"query { viewer { login } }"}`))
+	assert.ErrorIs(t, do(ctx, http.MethodPost, "/graphql", `{"query":"mutation { addStar(input: {}) { clientMutationId } }"}`), ErrHeldBack)
+	assert.ErrorIs(t, do(ctx, http.MethodPatch, "/repos/octo-org/app/issues/1", `{"state":"closed"}`), ErrHeldBack)
+	for _, body := range []string{
+		`{"query":"# comment\nmutation { addStar(input: {}) { clientMutationId } }"}`,
+		`{"query":"fragment F on Starrable { id } mutation { addStar(input: {}) { starrable { ...F } } }"}`,
+		`{"query":"query Q { viewer { login } } mutation M { addStar(input: {}) { clientMutationId } }","operationName":"M"}`,
+		`{"query":"mutation { addStar(input: {}) {"}`,
+	} {
+		assert.ErrorIs(t, do(ctx, http.MethodPost, "/graphql", body), ErrHeldBack, body)
+	}
+
+	assert.Equal(t, []string{"POST /repos/octo-org/app/issues", "GET /repos/octo-org/app", "POST /graphql"}, sent)
+	require.NotNil(t, recorder.Request())
+	assert.Equal(t, Request{
+		Method:  http.MethodPost,
+		URL:     srv.URL + "/graphql",
+		Payload: map[string]any{"query": "mutation { addStar(input: {}) { clientMutationId } }"},
+	}, *recorder.Request(), "the first change is the one held back")
+}
+
+func TestAddArgument(t *testing.T) {
+	tool := mcp.NewTool("create_issue", mcp.WithString("title"))
+	AddArgument(&tool)
+	assert.Equal(t, map[string]any{"type": "boolean", "description": ArgumentDescription}, tool.InputSchema.Properties[Argument])
+	assert.False(t, hasOwnArgument(tool))
+
+	tool = mcp.NewTool("merge_pull_requests", mcp.WithBoolean(Argument, mcp.Description("List the pull requests that would be merged")))
+	AddArgument(&tool)
+	assert.Equal(t, "List the pull requests that would be merged", tool.InputSchema.Properties[Argument].(map[string]any)["description"])
+	assert.True(t, hasOwnArgument(tool))
+}
+
+func TestToolHandlerMiddleware(t *testing.T) {
+	readOnly := mcp.NewTool("get_issue", mcp.WithReadOnlyHintAnnotation(true))
+	createIssue := mcp.NewTool("create_issue", mcp.WithReadOnlyHintAnnotation(false))
+	AddArgument(&createIssue)
+	createRef := mcp.NewTool("create_ref", mcp.WithReadOnlyHintAnnotation(false), mcp.WithOutputSchema[struct {
+		SHA string `json:"sha"`
+	}]())
+	AddArgument(&createRef)
+	mergeAll := mcp.NewTool("merge_pull_requests", mcp.WithReadOnlyHintAnnotation(false), mcp.WithBoolean(Argument, mcp.Description("List the pull requests that would be merged")))
+	tools := map[string]mcp.Tool{"get_issue": readOnly, "create_issue": createIssue, "create_ref": createRef, "merge_pull_requests": mergeAll}
+	lookup := func(name string) (mcp.Tool, bool) {
+		tool, ok := tools[name]
+		return tool, ok
+	}
+
+	var calls []map[string]any
+	next := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls = append(calls, request.GetArguments())
+		if r := RecorderFromContext(ctx); r != nil && request.Params.Name != "get_issue" {
+			r.hold(Request{Method: http.MethodPost, URL: "https://api.github.com/repos/octo-org/app/issues", Payload: map[string]any{"title": "Bug"}})
+			return mcp.NewToolResultError("failed to create issue: " + ErrHeldBack.Error()), nil
+		}
+		return mcp.NewToolResultText("done"), nil
+	}
+	call := func(forced bool, name string, arguments map[string]any) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = arguments
+		result, err := ToolHandlerMiddleware(forced, lookup)(next)(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+	text := func(result *mcp.CallToolResult) string {
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	t.Run("write tool without a dry run", func(t *testing.T) {
+		result := call(false, "create_issue", map[string]any{"title": "Bug"})
+		assert.Equal(t, "done", text(result))
+	})
+
+	t.Run("write tool with dry_run", func(t *testing.T) {
+		result := call(false, "create_issue", map[string]any{"title": "Bug", Argument: true})
+		assert.False(t, result.IsError)
+		var preview Preview
+		require.NoError(t, json.Unmarshal([]byte(text(result)), &preview))
+		assert.True(t, preview.DryRun)
+		assert.Equal(t, http.MethodPost, preview.Request.Method)
+		assert.Equal(t, "https://api.github.com/repos/octo-org/app/issues", preview.Request.URL)
+		assert.Equal(t, map[string]any{"title": "Bug"}, preview.Request.Payload)
+	})
+
+	t.Run("forced dry run", func(t *testing.T) {
+		result := call(true, "create_issue", map[string]any{"title": "Bug"})
+		assert.Contains(t, text(result), `"dry_run":true`)
+	})
+
+	t.Run("tool with an output schema", func(t *testing.T) {
+		result := call(true, "create_ref", map[string]any{})
+		assert.True(t, result.IsError)
+		assert.Contains(t, text(result), `"dry_run":true`)
+	})
+
+	t.Run("tool with its own dry run", func(t *testing.T) {
+		calls = nil
+		result := call(true, "merge_pull_requests", map[string]any{"owner": "octo-org"})
+		assert.Equal(t, "done", text(result))
+		assert.Equal(t, []map[string]any{{"owner": "octo-org", Argument: true}}, calls)
+	})
+
+	t.Run("read-only tool", func(t *testing.T) {
+		result := call(true, "get_issue", map[string]any{})
+		assert.Equal(t, "done", text(result))
+	})
+}
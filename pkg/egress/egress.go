@@ -0,0 +1,78 @@
+// Package egress enumerates the network destinations the server connects to and provides the
+// guard used to confine outbound traffic to the configured GitHub host.
+//
+// The complete list of outbound destinations is:
+//
+//   - The GitHub REST API (api.github.com, or <host>/api/v3 for GitHub Enterprise Server)
+//   - The GitHub GraphQL API (api.github.com/graphql, or <host>/api/graphql)
+//   - The GitHub uploads API (uploads.github.com, or <host>/api/uploads)
+//   - The GitHub raw content host (raw.githubusercontent.com, or <host>/raw)
+//   - Download URLs handed out by the API for workflow logs and artifacts, which point at storage
+//     outside of the API host.
+//   - The GitHub Container registry (ghcr.io), for the manifests of public container images.
+//
+// The last two are the only destinations that are not derived from the configured host.
+//
+// The server sends no telemetry or analytics. Anything that does in future must be off by default,
+// honor the --disable-telemetry switch and go through HTTPClient or a Guard so that
+// --no-external-egress applies to it as well.
+package egress
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrExternalEgress is returned for requests to a host outside the allowed set.
+type ErrExternalEgress struct {
+	Host string
+}
+
+func (e *ErrExternalEgress) Error() string {
+	return fmt.Sprintf("outbound request to %s blocked: the server is running with --no-external-egress and only talks to the configured GitHub host", e.Host)
+}
+
+// Guard is an http.RoundTripper that only lets requests through to a fixed set of hosts.
+type Guard struct {
+	transport http.RoundTripper
+	allowed   map[string]struct{}
+}
+
+// NewGuard wraps the given transport so that only requests to the allowed hosts are sent.
+func NewGuard(transport http.RoundTripper, allowedHosts ...string) *Guard {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	allowed := make(map[string]struct{}, len(allowedHosts))
+	for _, h := range allowedHosts {
+		allowed[strings.ToLower(h)] = struct{}{}
+	}
+	return &Guard{transport: transport, allowed: allowed}
+}
+
+func (g *Guard) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := strings.ToLower(req.URL.Hostname())
+	if _, ok := g.allowed[host]; !ok {
+		return nil, &ErrExternalEgress{Host: host}
+	}
+	return g.transport.RoundTrip(req)
+}
+
+type httpClientKey struct{}
+
+// ContextWithHTTPClient returns a context carrying the client to use for requests to destinations
+// that are not the GitHub API itself, such as log download URLs.
+func ContextWithHTTPClient(ctx context.Context, client *http.Client) context.Context {
+	return context.WithValue(ctx, httpClientKey{}, client)
+}
+
+// HTTPClient returns the client stored in the context, or http.DefaultClient if there is none.
+// It never carries GitHub credentials.
+func HTTPClient(ctx context.Context) *http.Client {
+	if client, ok := ctx.Value(httpClientKey{}).(*http.Client); ok && client != nil {
+		return client
+	}
+	return http.DefaultClient
+}
@@ -0,0 +1,45 @@
+package egress
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuard(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: NewGuard(nil, u.Hostname())}
+
+	resp, err := client.Get(ts.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = client.Get("https://productionresultssa0.blob.core.windows.net/logs")
+	require.Error(t, err)
+
+	var egressErr *ErrExternalEgress
+	require.True(t, errors.As(err, &egressErr))
+	assert.Equal(t, "productionresultssa0.blob.core.windows.net", egressErr.Host)
+}
+
+func TestHTTPClient(t *testing.T) {
+	assert.Same(t, http.DefaultClient, HTTPClient(context.Background()))
+
+	client := &http.Client{}
+	ctx := ContextWithHTTPClient(context.Background(), client)
+	assert.Same(t, client, HTTPClient(ctx))
+}
@@ -2,13 +2,16 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/github/github-mcp-server/pkg/egress"
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
@@ -81,10 +84,10 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 		}
 }
 
-// ListWorkflowRuns creates a tool to list workflow runs for a specific workflow
+// ListWorkflowRuns creates a tool to list workflow runs of a repository or of a specific workflow
 func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_workflow_runs",
-			mcp.WithDescription(t("TOOL_LIST_WORKFLOW_RUNS_DESCRIPTION", "List workflow runs for a specific workflow")),
+			mcp.WithDescription(t("TOOL_LIST_WORKFLOW_RUNS_DESCRIPTION", "List workflow runs of a repository, or of a specific workflow if workflow_id is given, newest first")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_WORKFLOW_RUNS_USER_TITLE", "List workflow runs"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -98,8 +101,7 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Description(DescriptionRepositoryName),
 			),
 			mcp.WithString("workflow_id",
-				mcp.Required(),
-				mcp.Description("The workflow ID or workflow file name"),
+				mcp.Description("The workflow ID or workflow file name, to only list the runs of that workflow"),
 			),
 			mcp.WithString("actor",
 				mcp.Description("Returns someone's workflow runs. Use the login for the user who created the workflow run."),
@@ -145,10 +147,20 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				),
 			),
 			mcp.WithString("status",
-				mcp.Description("Returns workflow runs with the check run status"),
-				mcp.Enum("queued", "in_progress", "completed", "requested", "waiting"),
+				mcp.Description("Returns workflow runs with the check run status or conclusion, for example failure to find failed runs"),
+				mcp.Enum(
+					"queued", "in_progress", "completed", "requested", "waiting", "pending",
+					"action_required", "cancelled", "failure", "neutral", "skipped", "stale", "success", "timed_out",
+				),
+			),
+			mcp.WithString("head_sha",
+				mcp.Description("Returns workflow runs of the commit with this SHA"),
+			),
+			mcp.WithString("created",
+				mcp.Description("Returns workflow runs created in a date range, in search syntax such as >=2025-05-01 or 2025-05-01..2025-05-07"),
 			),
 			WithPagination(),
+			WithAllPages(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -159,7 +171,7 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			workflowID, err := RequiredParam[string](request, "workflow_id")
+			workflowID, err := OptionalParam[string](request, "workflow_id")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -181,12 +193,24 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			headSHA, err := OptionalParam[string](request, "head_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			created, err := OptionalParam[string](request, "created")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			// Get optional pagination parameters
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			allPages, err := OptionalParam[bool](request, "allPages")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -195,17 +219,50 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 
 			// Set up list options
 			opts := &github.ListWorkflowRunsOptions{
-				Actor:  actor,
-				Branch: branch,
-				Event:  event,
-				Status: status,
+				Actor:   actor,
+				Branch:  branch,
+				Event:   event,
+				Status:  status,
+				HeadSHA: headSHA,
+				Created: created,
 				ListOptions: github.ListOptions{
 					PerPage: pagination.PerPage,
 					Page:    pagination.Page,
 				},
 			}
 
-			workflowRuns, resp, err := client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowID, opts)
+			listRuns := func(ctx context.Context, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+				if workflowID == "" {
+					return client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, opts)
+				}
+				return client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowID, opts)
+			}
+
+			if allPages {
+				var totalCount int
+				runs, truncated, resp, err := listAllPages(ctx, 0, func(ctx context.Context, listOpts github.ListOptions) ([]*github.WorkflowRun, *github.Response, error) {
+					pageOpts := *opts
+					pageOpts.ListOptions = listOpts
+					page, resp, err := listRuns(ctx, &pageOpts)
+					if err != nil {
+						return nil, resp, err
+					}
+					if listOpts.Page == 1 {
+						totalCount = page.GetTotalCount()
+					}
+					return page.WorkflowRuns, resp, nil
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow runs", resp, err), nil
+				}
+				return MarshalledTextResult(map[string]any{
+					"total_count":   totalCount,
+					"workflow_runs": runs,
+					"truncated":     truncated,
+				}), nil
+			}
+
+			workflowRuns, resp, err := listRuns(ctx, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to list workflow runs: %w", err)
 			}
@@ -529,6 +586,62 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+// GetWorkflowJob creates a tool to get a workflow job with its steps
+func GetWorkflowJob(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workflow_job",
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_JOB_DESCRIPTION", "Get details of a workflow job, including the status and conclusion of each of its steps")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WORKFLOW_JOB_USER_TITLE", "Get workflow job"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("job_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow job"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			jobIDInt, err := RequiredInt(request, "job_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			jobID := int64(jobIDInt)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			job, resp, err := client.Actions.GetWorkflowJobByID(ctx, owner, repo, jobID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow job", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(job)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // GetJobLogs creates a tool to download logs for a specific workflow job or efficiently get all failed job logs for a workflow run
 func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_job_logs",
@@ -561,6 +674,12 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Description("Number of lines to return from the end of the log"),
 				mcp.DefaultNumber(500),
 			),
+			mcp.WithBoolean("clean",
+				mcp.Description("Strip the timestamps and ANSI color codes from the log lines"),
+			),
+			mcp.WithNumber("failure_context",
+				mcp.Description("Only return the lines that report errors or failures and this many lines before and after each, instead of the whole log. The full log is kept if no such line is found. tail_lines still applies to the result"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -597,6 +716,24 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			if tailLines == 0 {
 				tailLines = 500
 			}
+			clean, err := OptionalParam[bool](request, "clean")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			failureContext, err := OptionalIntParam(request, "failure_context")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if failureContext < 0 {
+				return mcp.NewToolResultError("failure_context cannot be negative"), nil
+			}
+			opts := jobLogOptions{
+				returnContent:  returnContent,
+				tailLines:      tailLines,
+				clean:          clean,
+				failureContext: failureContext,
+				progress:       newProgressReporter(ctx, request),
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -613,10 +750,10 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 
 			if failedOnly && runID > 0 {
 				// Handle failed-only mode: get logs for all failed jobs in the workflow run
-				return handleFailedJobLogs(ctx, client, owner, repo, int64(runID), returnContent, tailLines)
+				return handleFailedJobLogs(ctx, client, owner, repo, int64(runID), opts)
 			} else if jobID > 0 {
 				// Handle single job mode
-				return handleSingleJobLogs(ctx, client, owner, repo, int64(jobID), returnContent, tailLines)
+				return handleSingleJobLogs(ctx, client, owner, repo, int64(jobID), opts)
 			}
 
 			return mcp.NewToolResultError("Either job_id must be provided for single job logs, or run_id with failed_only=true for failed job logs"), nil
@@ -624,7 +761,7 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 }
 
 // handleFailedJobLogs gets logs for all failed jobs in a workflow run
-func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64, returnContent bool, tailLines int) (*mcp.CallToolResult, error) {
+func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64, opts jobLogOptions) (*mcp.CallToolResult, error) {
 	// First, get all jobs for the workflow run
 	jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &github.ListWorkflowJobsOptions{
 		Filter: "latest",
@@ -653,10 +790,12 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 		return mcp.NewToolResultText(string(r)), nil
 	}
 
-	// Collect logs for all failed jobs
+	// Collect logs for all failed jobs, reporting progress per job rather than per downloaded byte
+	progress := opts.progress
+	opts.progress = nil
 	var logResults []map[string]any
-	for _, job := range failedJobs {
-		jobResult, resp, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), returnContent, tailLines)
+	for i, job := range failedJobs {
+		jobResult, resp, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), opts)
 		if err != nil {
 			// Continue with other jobs even if one fails
 			jobResult = map[string]any{
@@ -669,6 +808,7 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 		}
 
 		logResults = append(logResults, jobResult)
+		progress.report(float64(i+1), float64(len(failedJobs)), fmt.Sprintf("Retrieved logs of job %s", job.GetName()))
 	}
 
 	result := map[string]any{
@@ -677,7 +817,7 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 		"total_jobs":    len(jobs.Jobs),
 		"failed_jobs":   len(failedJobs),
 		"logs":          logResults,
-		"return_format": map[string]bool{"content": returnContent, "urls": !returnContent},
+		"return_format": map[string]bool{"content": opts.returnContent, "urls": !opts.returnContent},
 	}
 
 	r, err := json.Marshal(result)
@@ -689,8 +829,8 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 }
 
 // handleSingleJobLogs gets logs for a single job
-func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo string, jobID int64, returnContent bool, tailLines int) (*mcp.CallToolResult, error) {
-	jobResult, resp, err := getJobLogData(ctx, client, owner, repo, jobID, "", returnContent, tailLines)
+func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo string, jobID int64, opts jobLogOptions) (*mcp.CallToolResult, error) {
+	jobResult, resp, err := getJobLogData(ctx, client, owner, repo, jobID, "", opts)
 	if err != nil {
 		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get job logs", resp, err), nil
 	}
@@ -704,7 +844,7 @@ func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo
 }
 
 // getJobLogData retrieves log data for a single job, either as URL or content
-func getJobLogData(ctx context.Context, client *github.Client, owner, repo string, jobID int64, jobName string, returnContent bool, tailLines int) (map[string]any, *github.Response, error) {
+func getJobLogData(ctx context.Context, client *github.Client, owner, repo string, jobID int64, jobName string, opts jobLogOptions) (map[string]any, *github.Response, error) {
 	// Get the download URL for the job logs
 	url, resp, err := client.Actions.GetWorkflowJobLogs(ctx, owner, repo, jobID, 1)
 	if err != nil {
@@ -719,9 +859,9 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 		result["job_name"] = jobName
 	}
 
-	if returnContent {
+	if opts.returnContent {
 		// Download and return the actual log content
-		content, originalLength, httpResp, err := downloadLogContent(url.String(), tailLines) //nolint:bodyclose // Response body is closed in downloadLogContent, but we need to return httpResp
+		content, originalLength, failures, httpResp, err := downloadLogContent(ctx, url.String(), opts) //nolint:bodyclose // Response body is closed in downloadLogContent, but we need to return httpResp
 		if err != nil {
 			// To keep the return value consistent wrap the response as a GitHub Response
 			ghRes := &github.Response{
@@ -732,6 +872,9 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 		result["logs_content"] = content
 		result["message"] = "Job logs content retrieved successfully"
 		result["original_length"] = originalLength
+		if opts.failureContext > 0 {
+			result["failure_lines"] = failures
+		}
 	} else {
 		// Return just the URL
 		result["logs_url"] = url.String()
@@ -742,28 +885,33 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 	return result, resp, nil
 }
 
-// downloadLogContent downloads the actual log content from a GitHub logs URL
-func downloadLogContent(logURL string, tailLines int) (string, int, *http.Response, error) {
-	httpResp, err := http.Get(logURL) //nolint:gosec // URLs are provided by GitHub API and are safe
+// downloadLogContent downloads the actual log content from a GitHub logs URL.
+// The URL points at log storage rather than the API, so it is fetched without GitHub credentials.
+func downloadLogContent(ctx context.Context, logURL string, opts jobLogOptions) (string, int, int, *http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logURL, nil)
 	if err != nil {
-		return "", 0, httpResp, fmt.Errorf("failed to download logs: %w", err)
+		return "", 0, 0, nil, fmt.Errorf("failed to create log download request: %w", err)
+	}
+	httpResp, err := egress.HTTPClient(ctx).Do(req) //nolint:gosec // URLs are provided by GitHub API and are safe
+	if err != nil {
+		return "", 0, 0, httpResp, fmt.Errorf("failed to download logs: %w", err)
 	}
 	defer func() { _ = httpResp.Body.Close() }()
 
 	if httpResp.StatusCode != http.StatusOK {
-		return "", 0, httpResp, fmt.Errorf("failed to download logs: HTTP %d", httpResp.StatusCode)
+		return "", 0, 0, httpResp, fmt.Errorf("failed to download logs: HTTP %d", httpResp.StatusCode)
 	}
 
-	content, err := io.ReadAll(httpResp.Body)
+	content, err := io.ReadAll(opts.progress.reader(httpResp.Body, httpResp.ContentLength, "Downloading job logs"))
 	if err != nil {
-		return "", 0, httpResp, fmt.Errorf("failed to read log content: %w", err)
+		return "", 0, 0, httpResp, fmt.Errorf("failed to read log content: %w", err)
 	}
 
 	// Clean up and format the log content for better readability
 	logContent := strings.TrimSpace(string(content))
 
-	trimmedContent, lineCount := trimContent(logContent, tailLines)
-	return trimmedContent, lineCount, httpResp, nil
+	processedContent, lineCount, failures := processJobLog(logContent, opts)
+	return processedContent, lineCount, failures, httpResp, nil
 }
 
 // trimContent trims the content to a maximum length and returns the trimmed content and an original length
@@ -1109,6 +1257,195 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 		}
 }
 
+// maxArtifactContentSize caps the size of artifacts get_latest_artifact returns inline, larger artifacts
+// are only returned as a download URL.
+const maxArtifactContentSize = 1 << 20 // 1 MiB
+
+// GetLatestArtifact creates a tool to get an artifact from the latest successful run of a workflow
+func GetLatestArtifact(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_latest_artifact",
+			mcp.WithDescription(t("TOOL_GET_LATEST_ARTIFACT_DESCRIPTION", "Get an artifact by name from the most recent successful run of a workflow, optionally on a specific branch. Returns a download URL for the artifact ZIP archive, or its base64 encoded content when return_content is true and the artifact is small enough.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_LATEST_ARTIFACT_USER_TITLE", "Get latest workflow artifact"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("The workflow ID or workflow file name"),
+			),
+			mcp.WithString("artifact_name",
+				mcp.Required(),
+				mcp.Description("The name of the artifact"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Only consider workflow runs on this branch"),
+			),
+			mcp.WithBoolean("return_content",
+				mcp.Description(fmt.Sprintf("Returns the base64 encoded ZIP archive instead of a download URL, for artifacts up to %d bytes", maxArtifactContentSize)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			workflowID, err := RequiredParam[string](request, "workflow_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			artifactName, err := RequiredParam[string](request, "artifact_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			returnContent, err := OptionalParam[bool](request, "return_content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Runs are listed newest first, so the first successful run is the latest one
+			runs, resp, err := client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowID, &github.ListWorkflowRunsOptions{
+				Branch:      branch,
+				Status:      "success",
+				ListOptions: github.ListOptions{PerPage: 1},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow runs", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if len(runs.WorkflowRuns) == 0 {
+				if branch != "" {
+					return mcp.NewToolResultError(fmt.Sprintf("no successful run of workflow %s found on branch %s", workflowID, branch)), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("no successful run of workflow %s found", workflowID)), nil
+			}
+			run := runs.WorkflowRuns[0]
+
+			// The artifacts of a run can't be filtered by name, so page through them until it turns up
+			var artifact *github.Artifact
+			var names []string
+			opts := &github.ListOptions{PerPage: 100}
+			for artifact == nil {
+				artifacts, resp, err := client.Actions.ListWorkflowRunArtifacts(ctx, owner, repo, run.GetID(), opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow run artifacts", resp, err), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, a := range artifacts.Artifacts {
+					if a.GetName() == artifactName {
+						artifact = a
+						break
+					}
+					names = append(names, a.GetName())
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			if artifact == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("artifact %s not found in the latest successful run %d of workflow %s, available artifacts: [%s]",
+					artifactName, run.GetID(), workflowID, strings.Join(names, ", "))), nil
+			}
+			if artifact.GetExpired() {
+				return mcp.NewToolResultError(fmt.Sprintf("artifact %s from the latest successful run %d of workflow %s expired at %s, re-run the workflow to produce a new one",
+					artifactName, run.GetID(), workflowID, artifact.GetExpiresAt().Format(time.RFC3339))), nil
+			}
+
+			url, resp, err := client.Actions.DownloadArtifact(ctx, owner, repo, artifact.GetID(), 1)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get artifact download URL", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"workflow_run": map[string]any{
+					"id":          run.GetID(),
+					"run_number":  run.GetRunNumber(),
+					"head_branch": run.GetHeadBranch(),
+					"head_sha":    run.GetHeadSHA(),
+					"html_url":    run.GetHTMLURL(),
+					"created_at":  run.GetCreatedAt(),
+				},
+				"artifact": artifact,
+			}
+
+			switch {
+			case returnContent && artifact.GetSizeInBytes() <= maxArtifactContentSize:
+				content, httpResp, err := downloadArtifactContent(ctx, url.String()) //nolint:bodyclose // Response body is closed in downloadArtifactContent, but we need to return httpResp
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to download artifact", &github.Response{Response: httpResp}, err), nil
+				}
+				result["content_base64"] = base64.StdEncoding.EncodeToString(content)
+				result["note"] = "content_base64 holds the artifact as a base64 encoded ZIP archive."
+			case returnContent:
+				result["download_url"] = url.String()
+				result["note"] = fmt.Sprintf("The artifact is %d bytes, which is larger than the %d bytes that can be returned inline. The download_url provides a temporary download link for the artifact as a ZIP archive.", artifact.GetSizeInBytes(), maxArtifactContentSize)
+			default:
+				result["download_url"] = url.String()
+				result["note"] = "The download_url provides a download link for the artifact as a ZIP archive. The link is temporary and expires after a short time."
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// downloadArtifactContent downloads an artifact archive from its temporary download URL.
+// The URL points at artifact storage rather than the API, so it is fetched without GitHub credentials.
+func downloadArtifactContent(ctx context.Context, artifactURL string) ([]byte, *http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, artifactURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create artifact download request: %w", err)
+	}
+	httpResp, err := egress.HTTPClient(ctx).Do(req) //nolint:gosec // URLs are provided by GitHub API and are safe
+	if err != nil {
+		return nil, httpResp, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, httpResp, fmt.Errorf("failed to download artifact: HTTP %d", httpResp.StatusCode)
+	}
+
+	// Guard against artifacts that are larger than their reported size
+	content, err := io.ReadAll(io.LimitReader(httpResp.Body, maxArtifactContentSize+1))
+	if err != nil {
+		return nil, httpResp, fmt.Errorf("failed to read artifact content: %w", err)
+	}
+	if len(content) > maxArtifactContentSize {
+		return nil, httpResp, fmt.Errorf("artifact is larger than %d bytes", maxArtifactContentSize)
+	}
+
+	return content, httpResp, nil
+}
+
 // DeleteWorkflowRunLogs creates a tool to delete logs for a workflow run
 func DeleteWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("delete_workflow_run_logs",
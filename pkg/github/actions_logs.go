@@ -0,0 +1,98 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// jobLogOptions controls which part of a job log get_job_logs returns.
+type jobLogOptions struct {
+	// returnContent returns the log itself instead of its download URL
+	returnContent bool
+	// tailLines keeps only the last lines of the log
+	tailLines int
+	// clean strips timestamps and ANSI escape codes from every line
+	clean bool
+	// failureContext keeps only the failure lines and this many lines around each, if above 0
+	failureContext int
+	// progress reports the downloaded bytes of the log
+	progress *progressReporter
+}
+
+var (
+	// logTimestamp matches the timestamp the runner puts in front of every log line.
+	logTimestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z ?`)
+	// ansiEscape matches ANSI color and cursor escape sequences.
+	ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+	// failureLine matches lines that report an error, from the runner or from common tools.
+	failureLine = regexp.MustCompile(`##\[error\]|(?i:\berror\b|\bfatal\b|\bpanic:|\bexception\b)|--- FAIL|\bFAILED\b|\bFAIL\b`)
+)
+
+// cleanLog strips the timestamps and ANSI escape codes from each line of a log.
+func cleanLog(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		line = ansiEscape.ReplaceAllString(line, "")
+		lines[i] = logTimestamp.ReplaceAllString(line, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// failureWindows keeps the lines that report a failure and up to contextLines lines before and after each
+// of them. Skipped lines are replaced by a marker saying how many were left out. It returns the number of
+// failure lines found, and the content unchanged if there are none.
+func failureWindows(content string, contextLines int) (string, int) {
+	lines := strings.Split(content, "\n")
+	keep := make([]bool, len(lines))
+	failures := 0
+	for i, line := range lines {
+		if !failureLine.MatchString(line) {
+			continue
+		}
+		failures++
+		for j := max(0, i-contextLines); j <= min(len(lines)-1, i+contextLines); j++ {
+			keep[j] = true
+		}
+	}
+	if failures == 0 {
+		return content, 0
+	}
+
+	var b strings.Builder
+	skipped := 0
+	flushSkipped := func() {
+		switch {
+		case skipped == 1:
+			b.WriteString("... 1 line omitted ...\n")
+		case skipped > 1:
+			fmt.Fprintf(&b, "... %d lines omitted ...\n", skipped)
+		}
+		skipped = 0
+	}
+	for i, line := range lines {
+		if !keep[i] {
+			skipped++
+			continue
+		}
+		flushSkipped()
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	flushSkipped()
+	return strings.TrimSuffix(b.String(), "\n"), failures
+}
+
+// processJobLog applies the options to a downloaded log. It returns the processed log, the number of lines
+// as reported by trimContent, and the number of failure lines found when failureContext is set.
+func processJobLog(content string, opts jobLogOptions) (string, int, int) {
+	if opts.clean {
+		content = cleanLog(content)
+	}
+	failures := 0
+	if opts.failureContext > 0 {
+		content, failures = failureWindows(content, opts.failureContext)
+	}
+	content, lineCount := trimContent(content, opts.tailLines)
+	return content, lineCount, failures
+}
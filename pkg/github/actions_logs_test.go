@@ -0,0 +1,67 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CleanLog(t *testing.T) {
+	content := "2023-01-01T10:00:00.0000000Z ##[group]Run go test\n" +
+		"2023-01-01T10:00:01.1234567Z \x1b[36;1mgo test ./...\x1b[0m\n" +
+		"no timestamp 2023-01-01T10:00:01Z in the middle"
+
+	assert.Equal(t, "##[group]Run go test\ngo test ./...\nno timestamp 2023-01-01T10:00:01Z in the middle", cleanLog(content))
+}
+
+func Test_FailureWindows(t *testing.T) {
+	lines := "line 1\nline 2\nline 3\n--- FAIL: TestThing\nline 5\nline 6\nline 7\nline 8\n##[error]Process completed with exit code 1.\nline 10"
+
+	tests := []struct {
+		name             string
+		content          string
+		contextLines     int
+		expected         string
+		expectedFailures int
+	}{
+		{
+			name:         "windows around failures",
+			content:      lines,
+			contextLines: 1,
+			expected: "... 2 lines omitted ...\n" +
+				"line 3\n--- FAIL: TestThing\nline 5\n" +
+				"... 2 lines omitted ...\n" +
+				"line 8\n##[error]Process completed with exit code 1.\nline 10",
+			expectedFailures: 2,
+		},
+		{
+			name:             "overlapping windows are merged",
+			content:          lines,
+			contextLines:     3,
+			expected:         "line 1\nline 2\nline 3\n--- FAIL: TestThing\nline 5\nline 6\nline 7\nline 8\n##[error]Process completed with exit code 1.\nline 10",
+			expectedFailures: 2,
+		},
+		{
+			name:             "no failures keeps the log",
+			content:          "line 1\nok  \tgithub.com/owner/repo\t0.01s",
+			contextLines:     5,
+			expected:         "line 1\nok  \tgithub.com/owner/repo\t0.01s",
+			expectedFailures: 0,
+		},
+		{
+			name:             "error words inside other words do not match",
+			content:          "line 1\nhandle errors gracefully\nline 3\nError: something broke",
+			contextLines:     0,
+			expected:         "... 3 lines omitted ...\nError: something broke",
+			expectedFailures: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, failures := failureWindows(tc.content, tc.contextLines)
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.expectedFailures, failures)
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	descriptionPermissionsOwner = "Repository owner, or the organization name when repo is omitted"
+	descriptionPermissionsRepo  = "Repository name. Omit to target the organization named by owner"
+)
+
+// actionsPermissionsTarget describes whether a permissions tool targets a repository or an organization,
+// for use in messages.
+func actionsPermissionsTarget(owner, repo string) string {
+	if repo == "" {
+		return fmt.Sprintf("organization %s", owner)
+	}
+	return fmt.Sprintf("repository %s/%s", owner, repo)
+}
+
+// newActionsPermissionsErrorResponse picks the admin hint matching the level the tool was called at.
+func newActionsPermissionsErrorResponse(ctx context.Context, repo, message string, resp *github.Response, err error) *mcp.CallToolResult {
+	if repo == "" {
+		return newOrgAdminAPIErrorResponse(ctx, message, resp, err)
+	}
+	return newAdminAPIErrorResponse(ctx, message, resp, err)
+}
+
+// GetActionsPermissions creates a tool to get whether GitHub Actions is enabled and which actions are allowed,
+// for a repository or an organization.
+func GetActionsPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_actions_permissions",
+			mcp.WithDescription(t("TOOL_GET_ACTIONS_PERMISSIONS_DESCRIPTION", "Get the GitHub Actions permissions of a repository or an organization: whether Actions is enabled (for organizations, in which repositories) and which actions and reusable workflows are allowed. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ACTIONS_PERMISSIONS_USER_TITLE", "Get Actions permissions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(descriptionPermissionsOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Description(descriptionPermissionsRepo),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var permissions any
+			var resp *github.Response
+			if repo == "" {
+				permissions, resp, err = client.Actions.GetActionsPermissions(ctx, owner)
+			} else {
+				permissions, resp, err = client.Repositories.GetActionsPermissions(ctx, owner, repo)
+			}
+			if err != nil {
+				return newActionsPermissionsErrorResponse(ctx, repo,
+					fmt.Sprintf("failed to get Actions permissions for %s", actionsPermissionsTarget(owner, repo)),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(permissions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetActionsPermissions creates a tool to enable or disable GitHub Actions and restrict the allowed actions,
+// for a repository or an organization.
+func SetActionsPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_actions_permissions",
+			mcp.WithDescription(t("TOOL_SET_ACTIONS_PERMISSIONS_DESCRIPTION", "Set the GitHub Actions permissions of a repository or an organization. Fields that are not given keep their current value. Disabling Actions stops all workflows from running. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_SET_ACTIONS_PERMISSIONS_USER_TITLE", "Set Actions permissions"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(descriptionPermissionsOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Description(descriptionPermissionsRepo),
+			),
+			mcp.WithBoolean("enabled",
+				mcp.Description("Repository level only: whether GitHub Actions is enabled for the repository"),
+			),
+			mcp.WithString("enabled_repositories",
+				mcp.Description("Organization level only: the repositories GitHub Actions is enabled for. Repositories for selected are managed in the organization settings"),
+				mcp.Enum("all", "none", "selected"),
+			),
+			mcp.WithString("allowed_actions",
+				mcp.Description("The actions and reusable workflows that are allowed to run: all, only those in the same repository or organization (local_only), or those selected in the settings (selected)"),
+				mcp.Enum("all", "local_only", "selected"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			enabled, enabledSet, err := OptionalParamOK[bool](request, "enabled")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			enabledRepositories, err := OptionalParam[string](request, "enabled_repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			allowedActions, err := OptionalParam[string](request, "allowed_actions")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if repo == "" && enabledSet {
+				return mcp.NewToolResultError("enabled only applies to repositories, use enabled_repositories for organizations"), nil
+			}
+			if repo != "" && enabledRepositories != "" {
+				return mcp.NewToolResultError("enabled_repositories only applies to organizations, use enabled for repositories"), nil
+			}
+			if !enabledSet && enabledRepositories == "" && allowedActions == "" {
+				return mcp.NewToolResultError("at least one of enabled, enabled_repositories or allowed_actions must be given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			target := actionsPermissionsTarget(owner, repo)
+
+			// The API replaces the whole policy, so start from the current one to leave unset fields alone.
+			// It answers with no content, so the policy we sent is what we return.
+			var updated any
+			var resp *github.Response
+			if repo == "" {
+				current, getResp, getErr := client.Actions.GetActionsPermissions(ctx, owner)
+				if getErr != nil {
+					return newOrgAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to get Actions permissions for %s", target), getResp, getErr), nil
+				}
+				_ = getResp.Body.Close()
+
+				permissions := github.ActionsPermissions{
+					EnabledRepositories: current.EnabledRepositories,
+					AllowedActions:      current.AllowedActions,
+				}
+				if enabledRepositories != "" {
+					permissions.EnabledRepositories = github.Ptr(enabledRepositories)
+				}
+				if allowedActions != "" {
+					permissions.AllowedActions = github.Ptr(allowedActions)
+				}
+				if permissions.GetEnabledRepositories() == "none" {
+					permissions.AllowedActions = nil
+				}
+				updated = permissions
+				_, resp, err = client.Actions.EditActionsPermissions(ctx, owner, permissions)
+			} else {
+				current, getResp, getErr := client.Repositories.GetActionsPermissions(ctx, owner, repo)
+				if getErr != nil {
+					return newAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to get Actions permissions for %s", target), getResp, getErr), nil
+				}
+				_ = getResp.Body.Close()
+
+				permissions := github.ActionsPermissionsRepository{
+					Enabled:        current.Enabled,
+					AllowedActions: current.AllowedActions,
+				}
+				if enabledSet {
+					permissions.Enabled = github.Ptr(enabled)
+				}
+				if allowedActions != "" {
+					permissions.AllowedActions = github.Ptr(allowedActions)
+				}
+				if !permissions.GetEnabled() {
+					permissions.AllowedActions = nil
+				}
+				updated = permissions
+				_, resp, err = client.Repositories.EditActionsPermissions(ctx, owner, repo, permissions)
+			}
+			if err != nil {
+				return newActionsPermissionsErrorResponse(ctx, repo,
+					fmt.Sprintf("failed to set Actions permissions for %s", target),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(updated)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetDefaultWorkflowPermissions creates a tool to get the default permissions of the GITHUB_TOKEN,
+// for a repository or an organization.
+func GetDefaultWorkflowPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_default_workflow_permissions",
+			mcp.WithDescription(t("TOOL_GET_DEFAULT_WORKFLOW_PERMISSIONS_DESCRIPTION", "Get the default permissions granted to the GITHUB_TOKEN in workflows of a repository or an organization (read or write), and whether workflows can approve pull request reviews. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_DEFAULT_WORKFLOW_PERMISSIONS_USER_TITLE", "Get default workflow permissions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(descriptionPermissionsOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Description(descriptionPermissionsRepo),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var permissions any
+			var resp *github.Response
+			if repo == "" {
+				permissions, resp, err = client.Actions.GetDefaultWorkflowPermissionsInOrganization(ctx, owner)
+			} else {
+				permissions, resp, err = client.Repositories.GetDefaultWorkflowPermissions(ctx, owner, repo)
+			}
+			if err != nil {
+				return newActionsPermissionsErrorResponse(ctx, repo,
+					fmt.Sprintf("failed to get default workflow permissions for %s", actionsPermissionsTarget(owner, repo)),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(permissions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetDefaultWorkflowPermissions creates a tool to set the default permissions of the GITHUB_TOKEN,
+// for a repository or an organization.
+func SetDefaultWorkflowPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_default_workflow_permissions",
+			mcp.WithDescription(t("TOOL_SET_DEFAULT_WORKFLOW_PERMISSIONS_DESCRIPTION", "Set the default permissions granted to the GITHUB_TOKEN in workflows of a repository or an organization, and whether workflows can approve pull request reviews. Setting read is the recommended hardening, workflows that need more must then request it with a permissions block. Fields that are not given keep their current value. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_DEFAULT_WORKFLOW_PERMISSIONS_USER_TITLE", "Set default workflow permissions"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(descriptionPermissionsOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Description(descriptionPermissionsRepo),
+			),
+			mcp.WithString("default_workflow_permissions",
+				mcp.Description("The default permissions of the GITHUB_TOKEN"),
+				mcp.Enum("read", "write"),
+			),
+			mcp.WithBoolean("can_approve_pull_request_reviews",
+				mcp.Description("Whether workflows can approve pull requests"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			defaultPermissions, err := OptionalParam[string](request, "default_workflow_permissions")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			canApprove, canApproveSet, err := OptionalParamOK[bool](request, "can_approve_pull_request_reviews")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if defaultPermissions == "" && !canApproveSet {
+				return mcp.NewToolResultError("at least one of default_workflow_permissions or can_approve_pull_request_reviews must be given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			target := actionsPermissionsTarget(owner, repo)
+
+			// Like set_actions_permissions, merge into the current settings and return what we sent
+			var updated any
+			var resp *github.Response
+			if repo == "" {
+				current, getResp, getErr := client.Actions.GetDefaultWorkflowPermissionsInOrganization(ctx, owner)
+				if getErr != nil {
+					return newOrgAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to get default workflow permissions for %s", target), getResp, getErr), nil
+				}
+				_ = getResp.Body.Close()
+
+				permissions := *current
+				if defaultPermissions != "" {
+					permissions.DefaultWorkflowPermissions = github.Ptr(defaultPermissions)
+				}
+				if canApproveSet {
+					permissions.CanApprovePullRequestReviews = github.Ptr(canApprove)
+				}
+				updated = permissions
+				_, resp, err = client.Actions.EditDefaultWorkflowPermissionsInOrganization(ctx, owner, permissions)
+			} else {
+				current, getResp, getErr := client.Repositories.GetDefaultWorkflowPermissions(ctx, owner, repo)
+				if getErr != nil {
+					return newAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to get default workflow permissions for %s", target), getResp, getErr), nil
+				}
+				_ = getResp.Body.Close()
+
+				permissions := *current
+				if defaultPermissions != "" {
+					permissions.DefaultWorkflowPermissions = github.Ptr(defaultPermissions)
+				}
+				if canApproveSet {
+					permissions.CanApprovePullRequestReviews = github.Ptr(canApprove)
+				}
+				updated = permissions
+				_, resp, err = client.Repositories.EditDefaultWorkflowPermissions(ctx, owner, repo, permissions)
+			}
+			if err != nil {
+				return newActionsPermissionsErrorResponse(ctx, repo,
+					fmt.Sprintf("failed to set default workflow permissions for %s", target),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(updated)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,413 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetActionsPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetActionsPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_actions_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedJSON   map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name: "repository level",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsPermissionsByOwnerByRepo,
+					&github.ActionsPermissionsRepository{Enabled: github.Ptr(true), AllowedActions: github.Ptr("local_only")},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedJSON: map[string]interface{}{"enabled": true, "allowed_actions": "local_only"},
+		},
+		{
+			name: "organization level",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsPermissionsByOrg,
+					&github.ActionsPermissions{EnabledRepositories: github.Ptr("all"), AllowedActions: github.Ptr("all")},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "org",
+			},
+			expectedJSON: map[string]interface{}{"enabled_repositories": "all", "allowed_actions": "all"},
+		},
+		{
+			name: "organization non-admin gets permission hint",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsPermissionsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must have admin rights to Organization."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get Actions permissions for organization org (this endpoint requires admin access to the organization",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetActionsPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedJSON, returned)
+		})
+	}
+}
+
+func Test_SetActionsPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetActionsPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_actions_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "enabled")
+	assert.Contains(t, tool.InputSchema.Properties, "enabled_repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "allowed_actions")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedJSON   map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name: "repository keeps current enabled state",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsPermissionsByOwnerByRepo,
+					&github.ActionsPermissionsRepository{Enabled: github.Ptr(true), AllowedActions: github.Ptr("all")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsPermissionsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"enabled":         true,
+						"allowed_actions": "local_only",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"allowed_actions": "local_only",
+			},
+			expectedJSON: map[string]interface{}{"enabled": true, "allowed_actions": "local_only"},
+		},
+		{
+			name: "organization restricts enabled repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsPermissionsByOrg,
+					&github.ActionsPermissions{EnabledRepositories: github.Ptr("all"), AllowedActions: github.Ptr("all")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsActionsPermissionsByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"enabled_repositories": "selected",
+						"allowed_actions":      "all",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                "org",
+				"enabled_repositories": "selected",
+			},
+			expectedJSON: map[string]interface{}{"enabled_repositories": "selected", "allowed_actions": "all"},
+		},
+		{
+			name:         "enabled is rejected at organization level",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "org",
+				"enabled": false,
+			},
+			expectError:    true,
+			expectedErrMsg: "enabled only applies to repositories",
+		},
+		{
+			name:         "nothing to set",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one of enabled, enabled_repositories or allowed_actions must be given",
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsPermissionsByOwnerByRepo,
+					&github.ActionsPermissionsRepository{Enabled: github.Ptr(true), AllowedActions: github.Ptr("all")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsPermissionsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusConflict)
+						_, _ = w.Write([]byte(`{"message": "Conflict: organization policy"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"enabled": false,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to set Actions permissions for repository owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetActionsPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedJSON, returned)
+		})
+	}
+}
+
+func Test_GetDefaultWorkflowPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDefaultWorkflowPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_default_workflow_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposActionsPermissionsWorkflowByOwnerByRepo,
+			&github.DefaultWorkflowPermissionRepository{
+				DefaultWorkflowPermissions:   github.Ptr("write"),
+				CanApprovePullRequestReviews: github.Ptr(false),
+			},
+		),
+	))
+	_, handler := GetDefaultWorkflowPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned github.DefaultWorkflowPermissionRepository
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "write", returned.GetDefaultWorkflowPermissions())
+	assert.False(t, returned.GetCanApprovePullRequestReviews())
+}
+
+func Test_SetDefaultWorkflowPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetDefaultWorkflowPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_default_workflow_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "default_workflow_permissions")
+	assert.Contains(t, tool.InputSchema.Properties, "can_approve_pull_request_reviews")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedJSON   map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name: "organization defaults to read-only token",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsPermissionsWorkflowByOrg,
+					&github.DefaultWorkflowPermissionOrganization{
+						DefaultWorkflowPermissions:   github.Ptr("write"),
+						CanApprovePullRequestReviews: github.Ptr(true),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsActionsPermissionsWorkflowByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"default_workflow_permissions":     "read",
+						"can_approve_pull_request_reviews": true,
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                        "org",
+				"default_workflow_permissions": "read",
+			},
+			expectedJSON: map[string]interface{}{"default_workflow_permissions": "read", "can_approve_pull_request_reviews": true},
+		},
+		{
+			name: "repository disallows pull request approvals",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsPermissionsWorkflowByOwnerByRepo,
+					&github.DefaultWorkflowPermissionRepository{
+						DefaultWorkflowPermissions:   github.Ptr("read"),
+						CanApprovePullRequestReviews: github.Ptr(true),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsPermissionsWorkflowByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"default_workflow_permissions":     "read",
+						"can_approve_pull_request_reviews": false,
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                            "owner",
+				"repo":                             "repo",
+				"can_approve_pull_request_reviews": false,
+			},
+			expectedJSON: map[string]interface{}{"default_workflow_permissions": "read", "can_approve_pull_request_reviews": false},
+		},
+		{
+			name:         "nothing to set",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one of default_workflow_permissions or can_approve_pull_request_reviews must be given",
+		},
+		{
+			name: "non-admin gets permission hint",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsPermissionsWorkflowByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                        "owner",
+				"repo":                         "repo",
+				"default_workflow_permissions": "read",
+			},
+			expectError:    true,
+			expectedErrMsg: "requires admin access to the repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetDefaultWorkflowPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedJSON, returned)
+		})
+	}
+}
@@ -2,10 +2,12 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
@@ -125,6 +127,221 @@ func Test_ListWorkflows(t *testing.T) {
 	}
 }
 
+func Test_ListWorkflowRuns(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWorkflowRuns(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_workflow_runs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "workflow_id")
+	assert.Contains(t, tool.InputSchema.Properties, "head_sha")
+	assert.Contains(t, tool.InputSchema.Properties, "created")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRuns := &github.WorkflowRuns{
+		TotalCount: github.Ptr(1),
+		WorkflowRuns: []*github.WorkflowRun{
+			{
+				ID:         github.Ptr(int64(12345)),
+				Name:       github.Ptr("CI"),
+				Status:     github.Ptr("completed"),
+				Conclusion: github.Ptr("failure"),
+				HeadSHA:    github.Ptr("abc123"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		requestArgs  map[string]any
+	}{
+		{
+			name: "runs of the repository filtered by conclusion and commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"status":   "failure",
+						"head_sha": "abc123",
+						"created":  ">=2025-05-01",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRuns),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"status":   "failure",
+				"head_sha": "abc123",
+				"created":  ">=2025-05-01",
+			},
+		},
+		{
+			name: "runs of a workflow",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					expectPath(t, "/repos/owner/repo/actions/workflows/ci.yml/runs").andThen(
+						mockResponse(t, http.StatusOK, mockRuns),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "ci.yml",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWorkflowRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Unmarshal and verify the result
+			var response github.WorkflowRuns
+			textContent := getTextResult(t, result)
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			require.Len(t, response.WorkflowRuns, 1)
+			assert.Equal(t, int64(12345), response.WorkflowRuns[0].GetID())
+			assert.Equal(t, "failure", response.WorkflowRuns[0].GetConclusion())
+		})
+	}
+}
+
+func Test_ListWorkflowRuns_AllPages(t *testing.T) {
+	page := func(ids ...int64) *github.WorkflowRuns {
+		runs := &github.WorkflowRuns{TotalCount: github.Ptr(3)}
+		for _, id := range ids {
+			runs.WorkflowRuns = append(runs.WorkflowRuns, &github.WorkflowRun{ID: github.Ptr(id)})
+		}
+		return runs
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchPages(
+			mock.GetReposActionsRunsByOwnerByRepo,
+			page(1),
+			page(2),
+			page(3),
+		),
+	))
+	_, handler := ListWorkflowRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":    "owner",
+		"repo":     "repo",
+		"allPages": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response struct {
+		TotalCount   int                   `json:"total_count"`
+		WorkflowRuns []*github.WorkflowRun `json:"workflow_runs"`
+		Truncated    bool                  `json:"truncated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, 3, response.TotalCount)
+	require.Len(t, response.WorkflowRuns, 3)
+	for i, run := range response.WorkflowRuns {
+		assert.Equal(t, int64(i+1), run.GetID(), "runs are merged in the order of their pages")
+	}
+	assert.False(t, response.Truncated)
+}
+
+func Test_GetWorkflowJob(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflowJob(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_workflow_job", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "job_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "job_id"})
+
+	mockJob := &github.WorkflowJob{
+		ID:         github.Ptr(int64(678)),
+		RunID:      github.Ptr(int64(12345)),
+		Name:       github.Ptr("test"),
+		Status:     github.Ptr("completed"),
+		Conclusion: github.Ptr("failure"),
+		Steps: []*github.TaskStep{
+			{Name: github.Ptr("Checkout"), Number: github.Ptr(int64(1)), Conclusion: github.Ptr("success")},
+			{Name: github.Ptr("Run tests"), Number: github.Ptr(int64(2)), Conclusion: github.Ptr("failure")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful job retrieval",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsJobsByOwnerByRepoByJobId,
+					mockJob,
+				),
+			),
+		},
+		{
+			name: "job not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsByOwnerByRepoByJobId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow job",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetWorkflowJob(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"job_id": float64(678),
+			}))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			textContent := getTextResult(t, result)
+			if tc.expectError {
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var response github.WorkflowJob
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			require.Len(t, response.Steps, 2)
+			assert.Equal(t, "Run tests", response.Steps[1].GetName())
+			assert.Equal(t, "failure", response.Steps[1].GetConclusion())
+		})
+	}
+}
+
 func Test_RunWorkflow(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -599,6 +816,263 @@ func Test_DownloadWorkflowRunArtifact(t *testing.T) {
 	}
 }
 
+func Test_GetLatestArtifact(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetLatestArtifact(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_latest_artifact", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "workflow_id")
+	assert.Contains(t, tool.InputSchema.Properties, "artifact_name")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "return_content")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id", "artifact_name"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	artifactContent := []byte("PK\x03\x04 fake zip archive")
+
+	// Create a test server to serve the artifact archive
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(artifactContent)
+	}))
+	defer testServer.Close()
+
+	mockRuns := &github.WorkflowRuns{
+		TotalCount: github.Ptr(1),
+		WorkflowRuns: []*github.WorkflowRun{
+			{
+				ID:         github.Ptr(int64(12345)),
+				RunNumber:  github.Ptr(42),
+				HeadBranch: github.Ptr("main"),
+				HeadSHA:    github.Ptr("abc123"),
+				Status:     github.Ptr("completed"),
+				Conclusion: github.Ptr("success"),
+			},
+		},
+	}
+	mockArtifacts := func(expired bool) *github.ArtifactList {
+		return &github.ArtifactList{
+			TotalCount: github.Ptr(int64(2)),
+			Artifacts: []*github.Artifact{
+				{ID: github.Ptr(int64(1)), Name: github.Ptr("coverage"), SizeInBytes: github.Ptr(int64(512))},
+				{
+					ID:          github.Ptr(int64(2)),
+					Name:        github.Ptr("build-output"),
+					SizeInBytes: github.Ptr(int64(len(artifactContent))),
+					Expired:     github.Ptr(expired),
+					ExpiresAt:   &github.Timestamp{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+		}
+	}
+	downloadRedirect := mock.WithRequestMatchHandler(
+		mock.GetReposActionsArtifactsByOwnerByRepoByArtifactIdByArchiveFormat,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/owner/repo/actions/artifacts/2/zip", r.URL.Path)
+			w.Header().Set("Location", testServer.URL)
+			w.WriteHeader(http.StatusFound)
+		}),
+	)
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse map[string]any
+	}{
+		{
+			name: "returns the download URL of the artifact from the latest successful run",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					expectQueryParams(t, map[string]string{
+						"branch":   "main",
+						"status":   "success",
+						"per_page": "1",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRuns),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsArtifactsByOwnerByRepoByRunId,
+					mockArtifacts(false),
+				),
+				downloadRedirect,
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"workflow_id":   "ci.yml",
+				"artifact_name": "build-output",
+				"branch":        "main",
+			},
+			expectedResponse: map[string]any{
+				"download_url": testServer.URL,
+			},
+		},
+		{
+			name: "pages through the artifacts of the run",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					mockRuns,
+				),
+				mock.WithRequestMatchPages(
+					mock.GetReposActionsRunsArtifactsByOwnerByRepoByRunId,
+					&github.ArtifactList{TotalCount: github.Ptr(int64(2)), Artifacts: mockArtifacts(false).Artifacts[:1]},
+					&github.ArtifactList{TotalCount: github.Ptr(int64(2)), Artifacts: mockArtifacts(false).Artifacts[1:]},
+				),
+				downloadRedirect,
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"workflow_id":   "ci.yml",
+				"artifact_name": "build-output",
+			},
+			expectedResponse: map[string]any{
+				"download_url": testServer.URL,
+			},
+		},
+		{
+			name: "returns the artifact content when requested",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					mockRuns,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsArtifactsByOwnerByRepoByRunId,
+					mockArtifacts(false),
+				),
+				downloadRedirect,
+			),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"workflow_id":    "ci.yml",
+				"artifact_name":  "build-output",
+				"return_content": true,
+			},
+			expectedResponse: map[string]any{
+				"content_base64": base64.StdEncoding.EncodeToString(artifactContent),
+			},
+		},
+		{
+			name: "no successful run",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					&github.WorkflowRuns{TotalCount: github.Ptr(0), WorkflowRuns: []*github.WorkflowRun{}},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"workflow_id":   "ci.yml",
+				"artifact_name": "build-output",
+				"branch":        "release",
+			},
+			expectError:    true,
+			expectedErrMsg: "no successful run of workflow ci.yml found on branch release",
+		},
+		{
+			name: "artifact not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					mockRuns,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsArtifactsByOwnerByRepoByRunId,
+					mockArtifacts(false),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"workflow_id":   "ci.yml",
+				"artifact_name": "docs",
+			},
+			expectError:    true,
+			expectedErrMsg: "artifact docs not found in the latest successful run 12345 of workflow ci.yml, available artifacts: [coverage, build-output]",
+		},
+		{
+			name: "artifact expired",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					mockRuns,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsArtifactsByOwnerByRepoByRunId,
+					mockArtifacts(true),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"workflow_id":   "ci.yml",
+				"artifact_name": "build-output",
+			},
+			expectError:    true,
+			expectedErrMsg: "artifact build-output from the latest successful run 12345 of workflow ci.yml expired at 2025-01-01T00:00:00Z",
+		},
+		{
+			name:         "missing required parameter artifact_name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "ci.yml",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: artifact_name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetLatestArtifact(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var response map[string]any
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			for key, value := range tc.expectedResponse {
+				assert.Equal(t, value, response[key])
+			}
+			assert.Equal(t, float64(12345), response["workflow_run"].(map[string]any)["id"])
+			assert.Equal(t, "build-output", response["artifact"].(map[string]any)["name"])
+		})
+	}
+}
+
 func Test_DeleteWorkflowRunLogs(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1144,3 +1618,121 @@ func Test_GetJobLogs_WithContentReturnAndTailLines(t *testing.T) {
 	assert.Equal(t, "Job logs content retrieved successfully", response["message"])
 	assert.NotContains(t, response, "logs_url") // Should not have URL when returning content
 }
+
+func Test_GetJobLogs_WithCleanAndFailureContext(t *testing.T) {
+	// Test that clean and failure_context shrink the log to the lines around the failure
+	logContent := "2023-01-01T10:00:00.000Z Starting job...\n" +
+		"2023-01-01T10:00:01.000Z \x1b[36;1mgo test ./...\x1b[0m\n" +
+		"2023-01-01T10:00:02.000Z     thing_test.go:12: got 1, want 2\n" +
+		"2023-01-01T10:00:03.000Z --- FAIL: TestThing (0.00s)\n" +
+		"2023-01-01T10:00:04.000Z FAIL\n" +
+		"2023-01-01T10:00:05.000Z Cleaning up...\n" +
+		"2023-01-01T10:00:06.000Z Post job cleanup."
+	expectedLogContent := "... 2 lines omitted ...\n" +
+		"    thing_test.go:12: got 1, want 2\n" +
+		"--- FAIL: TestThing (0.00s)\n" +
+		"FAIL\n" +
+		"Cleaning up...\n" +
+		"... 1 line omitted ..."
+
+	// Create a test server to serve log content
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(logContent))
+	}))
+	defer testServer.Close()
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", testServer.URL)
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	request := createMCPRequest(map[string]any{
+		"owner":           "owner",
+		"repo":            "repo",
+		"job_id":          float64(123),
+		"return_content":  true,
+		"clean":           true,
+		"failure_context": float64(1),
+	})
+
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	var response map[string]any
+	err = json.Unmarshal([]byte(textContent.Text), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedLogContent, response["logs_content"])
+	assert.Equal(t, float64(2), response["failure_lines"])
+}
+
+func Test_GetJobLogs_Progress(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("2023-01-01T10:00:00.000Z Job failed"))
+	}))
+	defer testServer.Close()
+
+	newClient := func() *github.Client {
+		return github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+				&github.Jobs{
+					TotalCount: github.Ptr(2),
+					Jobs: []*github.WorkflowJob{
+						{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Conclusion: github.Ptr("failure")},
+						{ID: github.Ptr(int64(2)), Name: github.Ptr("test"), Conclusion: github.Ptr("failure")},
+					},
+				},
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Location", testServer.URL)
+					w.WriteHeader(http.StatusFound)
+				}),
+			),
+		))
+	}
+
+	t.Run("reports the downloaded bytes of a job", func(t *testing.T) {
+		tool, handler := GetJobLogs(stubGetClientFn(newClient()), translations.NullTranslationHelper)
+		result, notifications := callToolWithProgress(t, tool, handler, map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"job_id":         float64(1),
+			"return_content": true,
+		})
+		require.False(t, result.IsError)
+		require.Len(t, notifications, 1)
+		assert.Equal(t, float64(35), notifications[0]["progress"])
+		assert.Equal(t, float64(35), notifications[0]["total"])
+		assert.Equal(t, "Downloading job logs", notifications[0]["message"])
+	})
+
+	t.Run("reports every failed job", func(t *testing.T) {
+		tool, handler := GetJobLogs(stubGetClientFn(newClient()), translations.NullTranslationHelper)
+		result, notifications := callToolWithProgress(t, tool, handler, map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"run_id":         float64(456),
+			"failed_only":    true,
+			"return_content": true,
+		})
+		require.False(t, result.IsError)
+		require.Len(t, notifications, 2)
+		assert.Equal(t, map[string]any{"progressToken": "token", "progress": float64(1), "total": float64(2), "message": "Retrieved logs of job build"}, notifications[0])
+		assert.Equal(t, map[string]any{"progressToken": "token", "progress": float64(2), "total": float64(2), "message": "Retrieved logs of job test"}, notifications[1])
+	})
+}
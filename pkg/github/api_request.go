@@ -0,0 +1,217 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxAPIResponseBytes caps how much of a response body api_request returns.
+const maxAPIResponseBytes = 1 << 20
+
+// DefaultAPIAllowlist is the allowlist of api_request when none is configured: any GET request.
+var DefaultAPIAllowlist = []string{"GET /**"}
+
+// apiRequestMethods are the methods api_request can send.
+var apiRequestMethods = []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete}
+
+// APIAllowRule lets api_request send requests with a method to the paths matching a pattern.
+type APIAllowRule struct {
+	// Method is an HTTP method, or * for any
+	Method string
+
+	// Pattern is a path whose segments are matched literally, except that * matches any one segment
+	// and a final ** matches any number of them, such as /repos/*/*/issues/**
+	Pattern string
+}
+
+// ParseAPIAllowlist parses allowlist entries of the form "METHOD /path/pattern".
+func ParseAPIAllowlist(entries []string) ([]APIAllowRule, error) {
+	rules := make([]APIAllowRule, 0, len(entries))
+	for _, entry := range entries {
+		fields := strings.Fields(entry)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid API allowlist entry %q: expected a method and a path pattern, such as \"GET /repos/*/*/issues/**\"", entry)
+		}
+		method := strings.ToUpper(fields[0])
+		if method != "*" && !slices.Contains(apiRequestMethods, method) {
+			return nil, fmt.Errorf("invalid API allowlist entry %q: method must be one of %s or *", entry, strings.Join(apiRequestMethods, ", "))
+		}
+		pattern := fields[1]
+		if !strings.HasPrefix(pattern, "/") {
+			return nil, fmt.Errorf("invalid API allowlist entry %q: path pattern must start with /", entry)
+		}
+		if i := strings.Index(pattern, "**"); i >= 0 && i != len(pattern)-2 {
+			return nil, fmt.Errorf("invalid API allowlist entry %q: ** is only allowed at the end of the path pattern", entry)
+		}
+		rules = append(rules, APIAllowRule{Method: method, Pattern: pattern})
+	}
+	return rules, nil
+}
+
+// Allows reports whether the rule matches a request to a path without a query string.
+func (r APIAllowRule) Allows(method, requestPath string) bool {
+	if r.Method != "*" && r.Method != method {
+		return false
+	}
+	patternSegments := strings.Split(strings.Trim(r.Pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(requestPath, "/"), "/")
+	for i, segment := range patternSegments {
+		if segment == "**" {
+			return true
+		}
+		if i >= len(pathSegments) || (segment != "*" && segment != pathSegments[i]) {
+			return false
+		}
+	}
+	return len(patternSegments) == len(pathSegments)
+}
+
+// APIResponse is the result of api_request.
+type APIResponse struct {
+	Status int `json:"status"`
+
+	// Body is the decoded JSON body, or the body as text when it isn't JSON
+	Body      any  `json:"body,omitempty"`
+	Truncated bool `json:"truncated,omitempty"`
+
+	// NextPage is the page to request next, from the Link header of paginated responses
+	NextPage int `json:"next_page,omitempty"`
+}
+
+// APIRequest creates a tool to send an arbitrary request to the GitHub REST API, as an escape hatch for
+// endpoints without a dedicated tool. Only requests matching one of the allowlist rules are sent, and in
+// read-only mode only GET requests.
+func APIRequest(getClient GetClientFn, allowlist []APIAllowRule, readOnly bool, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	allowed := make([]string, 0, len(allowlist))
+	for _, rule := range allowlist {
+		allowed = append(allowed, rule.Method+" "+rule.Pattern)
+	}
+	description := fmt.Sprintf("Send a request to the GitHub REST API, like `gh api`, for endpoints no other tool covers. Prefer the dedicated tools when they exist. Only these requests are allowed: %s, where * matches one path segment and ** any number of them.", strings.Join(allowed, ", "))
+	methods := apiRequestMethods
+	if readOnly {
+		description += " The server is in read-only mode, so only GET requests are sent."
+		methods = []string{http.MethodGet}
+	}
+
+	return mcp.NewTool("api_request",
+			mcp.WithDescription(t("TOOL_API_REQUEST_DESCRIPTION", description)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_API_REQUEST_USER_TITLE", "Send GitHub API request"),
+				ReadOnlyHint: ToBoolPtr(readOnly),
+			}),
+			mcp.WithString("method",
+				mcp.Description("The HTTP method"),
+				mcp.Enum(methods...),
+				mcp.DefaultString(http.MethodGet),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("The path of the endpoint, with any query string, such as /repos/octocat/hello-world/events?per_page=10"),
+			),
+			mcp.WithObject("body",
+				mcp.Description("The JSON body of the request"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			method, err := OptionalParam[string](request, "method")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			method = strings.ToUpper(method)
+			if method == "" {
+				method = http.MethodGet
+			}
+			requestPath, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, hasBody, err := OptionalParamOK[map[string]any](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if !slices.Contains(methods, method) {
+				if readOnly {
+					return mcp.NewToolResultError(fmt.Sprintf("method %s is not allowed in read-only mode, only GET requests can be sent", method)), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("method must be one of %s", strings.Join(methods, ", "))), nil
+			}
+			if !strings.HasPrefix(requestPath, "/") || strings.HasPrefix(requestPath, "//") {
+				return mcp.NewToolResultError("path must be a path on the GitHub API starting with /, not a URL"), nil
+			}
+			// Dot segments, also escaped ones, could step out of the paths the allowlist matched
+			endpoint, query, _ := strings.Cut(requestPath, "?")
+			unescaped, err := url.PathUnescape(endpoint)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid path: %s", err)), nil
+			}
+			for _, segment := range strings.Split(unescaped, "/") {
+				if segment == "." || segment == ".." {
+					return mcp.NewToolResultError("path must not contain . or .. segments"), nil
+				}
+			}
+			permitted := false
+			for _, rule := range allowlist {
+				if rule.Allows(method, endpoint) {
+					permitted = true
+					break
+				}
+			}
+			if !permitted {
+				return mcp.NewToolResultError(fmt.Sprintf("%s %s is not in the API allowlist of the server", method, endpoint)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			u := strings.TrimPrefix(endpoint, "/")
+			if query != "" {
+				u += "?" + query
+			}
+			var payload any
+			if hasBody {
+				payload = body
+			}
+			req, err := client.NewRequest(method, u, payload)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			resp, err := client.BareDo(ctx, req)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to send %s %s", method, endpoint), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			data, err := io.ReadAll(io.LimitReader(resp.Body, maxAPIResponseBytes+1))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read response body: %w", err)
+			}
+
+			result := APIResponse{Status: resp.StatusCode, NextPage: resp.NextPage}
+			if len(data) > maxAPIResponseBytes {
+				data, result.Truncated = data[:maxAPIResponseBytes], true
+			}
+			if len(data) > 0 {
+				var decoded any
+				if !result.Truncated && json.Unmarshal(data, &decoded) == nil {
+					result.Body = decoded
+				} else {
+					result.Body = string(data)
+				}
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,240 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseAPIAllowlist(t *testing.T) {
+	rules, err := ParseAPIAllowlist([]string{"get /repos/*/*/events", "* /repos/*/*/issues/**"})
+	require.NoError(t, err)
+	assert.Equal(t, []APIAllowRule{
+		{Method: "GET", Pattern: "/repos/*/*/events"},
+		{Method: "*", Pattern: "/repos/*/*/issues/**"},
+	}, rules)
+
+	for entry, message := range map[string]string{
+		"/repos/**":           `invalid API allowlist entry "/repos/**": expected a method and a path pattern, such as "GET /repos/*/*/issues/**"`,
+		"TRACE /repos/**":     `invalid API allowlist entry "TRACE /repos/**": method must be one of GET, POST, PATCH, PUT, DELETE or *`,
+		"GET repos/**":        `invalid API allowlist entry "GET repos/**": path pattern must start with /`,
+		"GET /repos/**/hooks": `invalid API allowlist entry "GET /repos/**/hooks": ** is only allowed at the end of the path pattern`,
+	} {
+		_, err := ParseAPIAllowlist([]string{entry})
+		assert.EqualError(t, err, message)
+	}
+}
+
+func Test_APIAllowRule_Allows(t *testing.T) {
+	tests := []struct {
+		rule    APIAllowRule
+		method  string
+		path    string
+		allowed bool
+	}{
+		{APIAllowRule{"GET", "/repos/*/*/events"}, "GET", "/repos/octo/hello/events", true},
+		{APIAllowRule{"GET", "/repos/*/*/events"}, "POST", "/repos/octo/hello/events", false},
+		{APIAllowRule{"GET", "/repos/*/*/events"}, "GET", "/repos/octo/events", false},
+		{APIAllowRule{"GET", "/repos/*/*/events"}, "GET", "/repos/octo/hello/events/1", false},
+		{APIAllowRule{"*", "/repos/*/*/issues/**"}, "DELETE", "/repos/octo/hello/issues/1/labels/bug", true},
+		{APIAllowRule{"*", "/repos/*/*/issues/**"}, "GET", "/repos/octo/hello/issues", true},
+		{APIAllowRule{"*", "/repos/*/*/issues/**"}, "GET", "/repos/octo/hello/pulls", false},
+		{APIAllowRule{"GET", "/**"}, "GET", "/user", true},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.allowed, tc.rule.Allows(tc.method, tc.path), "%s %s against %s %s", tc.method, tc.path, tc.rule.Method, tc.rule.Pattern)
+	}
+}
+
+func Test_APIRequest(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	allowlist := []APIAllowRule{
+		{Method: "GET", Pattern: "/repos/*/*/**"},
+		{Method: "*", Pattern: "/repos/*/*/issues/**"},
+	}
+	tool, _ := APIRequest(stubGetClientFn(mockClient), allowlist, false, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "api_request", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.Description, "GET /repos/*/*/**, * /repos/*/*/issues/**")
+	assert.Contains(t, tool.InputSchema.Properties, "method")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"path"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		readOnly       bool
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedResult string
+	}{
+		{
+			name: "sends a GET request with a query string",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEventsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"per_page": "1"}).andThen(
+						func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/repos/octo/hello/events?per_page=1&page=2>; rel="next"`)
+							w.WriteHeader(http.StatusOK)
+							_, _ = w.Write([]byte(`[{"id": "1", "type": "PushEvent"}]`))
+						},
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"path": "/repos/octo/hello/events?per_page=1",
+			},
+			expectedResult: `{"status": 200, "body": [{"id": "1", "type": "PushEvent"}], "next_page": 2}`,
+		},
+		{
+			name: "returns bodies that are not JSON as text",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphSbomByOwnerByRepo,
+					mockResponse(t, http.StatusOK, "not json"),
+				),
+			),
+			requestArgs: map[string]any{
+				"path": "/repos/octo/hello/dependency-graph/sbom",
+			},
+			expectedResult: `{"status": 200, "body": "not json"}`,
+		},
+		{
+			name: "sends a POST request with a body",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"labels": []any{"bug"}}).andThen(
+						mockResponse(t, http.StatusOK, []map[string]any{{"name": "bug"}}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method": "post",
+				"path":   "/repos/octo/hello/issues/1/labels",
+				"body":   map[string]any{"labels": []any{"bug"}},
+			},
+			expectedResult: `{"status": 200, "body": [{"name": "bug"}]}`,
+		},
+		{
+			name: "handles empty responses",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+					mockResponse(t, http.StatusNoContent, ""),
+				),
+			),
+			requestArgs: map[string]any{
+				"method": "DELETE",
+				"path":   "/repos/octo/hello/issues/1/labels/bug",
+			},
+			expectedResult: `{"status": 204}`,
+		},
+		{
+			name: "reports API errors",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEventsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"path": "/repos/octo/hello/events",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to send GET /repos/octo/hello/events",
+		},
+		{
+			name:         "refuses requests outside the allowlist",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method": "DELETE",
+				"path":   "/repos/octo/hello",
+			},
+			expectError:    true,
+			expectedErrMsg: "DELETE /repos/octo/hello is not in the API allowlist of the server",
+		},
+		{
+			name:         "refuses dot segments",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method": "DELETE",
+				"path":   "/repos/octo/hello/issues/%2e%2e/%2e%2e",
+			},
+			expectError:    true,
+			expectedErrMsg: "path must not contain . or .. segments",
+		},
+		{
+			name:         "refuses URLs",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"path": "https://example.com/repos/octo/hello/events",
+			},
+			expectError:    true,
+			expectedErrMsg: "path must be a path on the GitHub API starting with /, not a URL",
+		},
+		{
+			name:         "refuses writes in read-only mode",
+			readOnly:     true,
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method": "POST",
+				"path":   "/repos/octo/hello/issues/1/labels",
+			},
+			expectError:    true,
+			expectedErrMsg: "method POST is not allowed in read-only mode, only GET requests can be sent",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := APIRequest(stubGetClientFn(client), allowlist, tc.readOnly, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedResult, textContent.Text)
+		})
+	}
+}
+
+func Test_APIRequest_TruncatesLargeBodies(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposEventsByOwnerByRepo,
+			mockResponse(t, http.StatusOK, strings.Repeat("x", maxAPIResponseBytes+10)),
+		),
+	)
+	_, handler := APIRequest(stubGetClientFn(github.NewClient(mockedClient)), []APIAllowRule{{Method: "GET", Pattern: "/**"}}, true, translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"path": "/repos/octo/hello/events"}))
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	assert.Contains(t, textContent.Text, `"truncated":true`)
+	assert.Less(t, len(textContent.Text), maxAPIResponseBytes+100)
+}
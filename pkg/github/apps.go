@@ -0,0 +1,266 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxWebhookPayloadBytes caps how much of a delivery's request or response payload is returned,
+// webhook payloads for busy events can easily be hundreds of kilobytes.
+const maxWebhookPayloadBytes = 16 * 1024
+
+const maskedValue = "********"
+
+// GetAppWebhookConfig creates a tool to get the webhook configuration and subscribed events of the authenticated GitHub App.
+func GetAppWebhookConfig(getAppClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_app_webhook_config",
+			mcp.WithDescription(t("TOOL_GET_APP_WEBHOOK_CONFIG_DESCRIPTION", "Get the webhook configuration of the GitHub App this server is authenticated as, along with the events the app is subscribed to. The webhook secret is never returned.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_APP_WEBHOOK_CONFIG_USER_TITLE", "Get GitHub App webhook configuration"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := getAppClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub App client: %w", err)
+			}
+
+			// An empty slug returns the app the JWT belongs to.
+			app, resp, err := client.Apps.Get(ctx, "")
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get GitHub App",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			config, resp, err := client.Apps.GetHookConfig(ctx)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get GitHub App webhook configuration",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if config.Secret != nil {
+				config.Secret = github.Ptr(maskedValue)
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"app":    app.GetSlug(),
+				"events": app.Events,
+				"config": config,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListAppWebhookDeliveries creates a tool to list recent webhook deliveries of the authenticated GitHub App.
+func ListAppWebhookDeliveries(getAppClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_app_webhook_deliveries",
+			mcp.WithDescription(t("TOOL_LIST_APP_WEBHOOK_DELIVERIES_DESCRIPTION", "List recent deliveries of the GitHub App's own webhook (not repository webhooks), newest first. Use failed_only to find deliveries that did not get a successful response.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_APP_WEBHOOK_DELIVERIES_USER_TITLE", "List GitHub App webhook deliveries"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithBoolean("failed_only",
+				mcp.Description("Only return deliveries that failed or did not get a 2xx response"),
+			),
+			mcp.WithString("cursor",
+				mcp.Description("Cursor for pagination, as returned in next_cursor by a previous call"),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Results per page for pagination (min 1, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			failedOnly, err := OptionalParam[bool](request, "failed_only")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			cursor, err := OptionalParam[string](request, "cursor")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			perPage, err := OptionalIntParamWithDefault(request, "perPage", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getAppClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub App client: %w", err)
+			}
+
+			deliveries, resp, err := client.Apps.ListHookDeliveries(ctx, &github.ListCursorOptions{
+				Cursor:  cursor,
+				PerPage: perPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list GitHub App webhook deliveries",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if failedOnly {
+				deliveries = failedDeliveries(deliveries)
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"deliveries":  deliveries,
+				"next_cursor": resp.Cursor,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetAppWebhookDelivery creates a tool to get a single webhook delivery of the authenticated GitHub App.
+func GetAppWebhookDelivery(getAppClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_app_webhook_delivery",
+			mcp.WithDescription(t("TOOL_GET_APP_WEBHOOK_DELIVERY_DESCRIPTION", "Get a single delivery of the GitHub App's webhook, including request and response headers and payloads. Signature headers are masked and large payloads are truncated.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_APP_WEBHOOK_DELIVERY_USER_TITLE", "Get GitHub App webhook delivery"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithNumber("delivery_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the delivery"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			deliveryID, err := RequiredInt(request, "delivery_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getAppClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub App client: %w", err)
+			}
+
+			delivery, resp, err := client.Apps.GetHookDelivery(ctx, int64(deliveryID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get GitHub App webhook delivery: %d", deliveryID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := webhookDeliveryDetails(delivery)
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// webhookDelivery is the request GitHub sent for a webhook delivery, or the response it received.
+type webhookDelivery struct {
+	Headers   map[string]string `json:"headers,omitempty"`
+	Payload   string            `json:"payload,omitempty"`
+	Truncated bool              `json:"truncated,omitempty"`
+}
+
+func newWebhookDelivery(headers map[string]string, raw *json.RawMessage) *webhookDelivery {
+	p := &webhookDelivery{Headers: maskSignatureHeaders(headers)}
+	if raw != nil {
+		p.Payload = string(*raw)
+		if len(p.Payload) > maxWebhookPayloadBytes {
+			// Back off to the start of a rune, so that a multi-byte character isn't cut in half
+			end := maxWebhookPayloadBytes
+			for end > 0 && !utf8.RuneStart(p.Payload[end]) {
+				end--
+			}
+			p.Payload = p.Payload[:end]
+			p.Truncated = true
+		}
+	}
+	return p
+}
+
+// webhookDeliveryDetails describes a delivery of any kind of webhook, with its signature headers masked and
+// its payloads truncated.
+func webhookDeliveryDetails(delivery *github.HookDelivery) map[string]any {
+	result := map[string]any{
+		"id":           delivery.GetID(),
+		"guid":         delivery.GetGUID(),
+		"delivered_at": delivery.DeliveredAt,
+		"redelivery":   delivery.GetRedelivery(),
+		"duration":     delivery.GetDuration(),
+		"status":       delivery.GetStatus(),
+		"status_code":  delivery.GetStatusCode(),
+		"event":        delivery.GetEvent(),
+		"action":       delivery.GetAction(),
+	}
+	if delivery.Request != nil {
+		result["request"] = newWebhookDelivery(delivery.Request.Headers, delivery.Request.RawPayload)
+	}
+	if delivery.Response != nil {
+		result["response"] = newWebhookDelivery(delivery.Response.Headers, delivery.Response.RawPayload)
+	}
+	return result
+}
+
+// failedDeliveries keeps the deliveries that did not get a successful response.
+func failedDeliveries(deliveries []*github.HookDelivery) []*github.HookDelivery {
+	failed := make([]*github.HookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		if !isSuccessfulDelivery(d) {
+			failed = append(failed, d)
+		}
+	}
+	return failed
+}
+
+// isSuccessfulDelivery reports whether the receiving server acknowledged the delivery with a 2xx status.
+func isSuccessfulDelivery(d *github.HookDelivery) bool {
+	code := d.GetStatusCode()
+	return code >= 200 && code < 300
+}
+
+// maskSignatureHeaders returns a copy of the headers with any webhook signature values masked.
+func maskSignatureHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+	masked := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if strings.HasPrefix(strings.ToLower(k), "x-hub-signature") {
+			v = maskedValue
+		}
+		masked[k] = v
+	}
+	return masked
+}
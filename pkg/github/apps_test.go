@@ -0,0 +1,262 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetAppWebhookConfig(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetAppWebhookConfig(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_app_webhook_config", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetApp,
+			&github.App{
+				Slug:   github.Ptr("my-app"),
+				Events: []string{"push", "pull_request"},
+			},
+		),
+		mock.WithRequestMatch(
+			mock.GetAppHookConfig,
+			&github.HookConfig{
+				ContentType: github.Ptr("json"),
+				URL:         github.Ptr("https://example.com/webhook"),
+				Secret:      github.Ptr("super-secret"),
+			},
+		),
+	))
+	_, handler := GetAppWebhookConfig(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	assert.NotContains(t, textContent.Text, "super-secret")
+
+	var returned struct {
+		App    string             `json:"app"`
+		Events []string           `json:"events"`
+		Config *github.HookConfig `json:"config"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, "my-app", returned.App)
+	assert.Equal(t, []string{"push", "pull_request"}, returned.Events)
+	assert.Equal(t, "https://example.com/webhook", returned.Config.GetURL())
+	assert.Equal(t, maskedValue, returned.Config.GetSecret())
+}
+
+func Test_ListAppWebhookDeliveries(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListAppWebhookDeliveries(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_app_webhook_deliveries", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "failed_only")
+	assert.Contains(t, tool.InputSchema.Properties, "cursor")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockDeliveries := []*github.HookDelivery{
+		{ID: github.Ptr(int64(1)), Event: github.Ptr("push"), Status: github.Ptr("OK"), StatusCode: github.Ptr(200)},
+		{ID: github.Ptr(int64(2)), Event: github.Ptr("push"), Status: github.Ptr("Invalid HTTP Response: 500"), StatusCode: github.Ptr(500)},
+		{ID: github.Ptr(int64(3)), Event: github.Ptr("issues"), Status: github.Ptr("timed out"), StatusCode: github.Ptr(0)},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedIDs    []int64
+		expectedErrMsg string
+	}{
+		{
+			name: "lists all deliveries",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetAppHookDeliveries,
+					expectQueryParams(t, map[string]string{
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockDeliveries),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{},
+			expectError: false,
+			expectedIDs: []int64{1, 2, 3},
+		},
+		{
+			name: "filters failed deliveries",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetAppHookDeliveries,
+					expectQueryParams(t, map[string]string{
+						"per_page": "10",
+						"cursor":   "v1_12345",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockDeliveries),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"failed_only": true,
+				"cursor":      "v1_12345",
+				"perPage":     float64(10),
+			},
+			expectError: false,
+			expectedIDs: []int64{2, 3},
+		},
+		{
+			name: "list fails without app authentication",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetAppHookDeliveries,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnauthorized)
+						_, _ = w.Write([]byte(`{"message": "A JSON web token could not be decoded"}`))
+					}),
+				),
+			),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "failed to list GitHub App webhook deliveries",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListAppWebhookDeliveries(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned struct {
+				Deliveries []*github.HookDelivery `json:"deliveries"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+
+			ids := make([]int64, 0, len(returned.Deliveries))
+			for _, d := range returned.Deliveries {
+				ids = append(ids, d.GetID())
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func Test_GetAppWebhookDelivery(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetAppWebhookDelivery(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_app_webhook_delivery", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"delivery_id"})
+
+	largePayload := json.RawMessage(`{"data":"` + strings.Repeat("a", maxWebhookPayloadBytes) + `"}`)
+	responsePayload := json.RawMessage(`"ok"`)
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetAppHookDeliveriesByDeliveryId,
+			expectPath(t, "/app/hook/deliveries/42").andThen(
+				mockResponse(t, http.StatusOK, &github.HookDelivery{
+					ID:         github.Ptr(int64(42)),
+					Event:      github.Ptr("push"),
+					StatusCode: github.Ptr(200),
+					Request: &github.HookRequest{
+						Headers: map[string]string{
+							"X-GitHub-Event":      "push",
+							"X-Hub-Signature":     "sha1=secret",
+							"X-Hub-Signature-256": "sha256=secret",
+						},
+						RawPayload: &largePayload,
+					},
+					Response: &github.HookResponse{
+						Headers:    map[string]string{"Content-Type": "application/json"},
+						RawPayload: &responsePayload,
+					},
+				}),
+			),
+		),
+	))
+	_, handler := GetAppWebhookDelivery(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"delivery_id": float64(42),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	assert.NotContains(t, textContent.Text, "sha256=secret")
+
+	var returned struct {
+		ID      int64 `json:"id"`
+		Request struct {
+			Headers   map[string]string `json:"headers"`
+			Payload   string            `json:"payload"`
+			Truncated bool              `json:"truncated"`
+		} `json:"request"`
+		Response struct {
+			Payload   string `json:"payload"`
+			Truncated bool   `json:"truncated"`
+		} `json:"response"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, int64(42), returned.ID)
+	assert.Equal(t, "push", returned.Request.Headers["X-GitHub-Event"])
+	assert.Equal(t, maskedValue, returned.Request.Headers["X-Hub-Signature"])
+	assert.Equal(t, maskedValue, returned.Request.Headers["X-Hub-Signature-256"])
+	assert.Len(t, returned.Request.Payload, maxWebhookPayloadBytes)
+	assert.True(t, returned.Request.Truncated)
+	assert.Equal(t, `"ok"`, returned.Response.Payload)
+	assert.False(t, returned.Response.Truncated)
+}
+
+func Test_NewWebhookDeliveryTruncatesOnRuneBoundary(t *testing.T) {
+	payload := json.RawMessage(`{"d":"` + strings.Repeat("€", maxWebhookPayloadBytes) + `"}`)
+	delivery := newWebhookDelivery(nil, &payload)
+	assert.True(t, delivery.Truncated)
+	assert.True(t, utf8.ValidString(delivery.Payload), "no rune is cut in half")
+	assert.LessOrEqual(t, len(delivery.Payload), maxWebhookPayloadBytes)
+	assert.Greater(t, len(delivery.Payload), maxWebhookPayloadBytes-utf8.UTFMax)
+}
@@ -0,0 +1,208 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ListAutolinks creates a tool to list the autolink references of a repository.
+func ListAutolinks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_autolinks",
+			mcp.WithDescription(t("TOOL_LIST_AUTOLINKS_DESCRIPTION", "List the autolink references of a repository, which turn references such as JIRA-123 into links to external systems. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_AUTOLINKS_USER_TITLE", "List autolinks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			autolinks, resp, err := client.Repositories.ListAutolinks(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return newAdminAPIErrorResponse(ctx, "failed to list autolinks", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(autolinks)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateAutolink creates a tool to add an autolink reference to a repository.
+func CreateAutolink(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_autolink",
+			mcp.WithDescription(t("TOOL_CREATE_AUTOLINK_DESCRIPTION", "Create an autolink reference in a repository, so that references starting with key_prefix are linked to an external system. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_AUTOLINK_USER_TITLE", "Create autolink"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("key_prefix",
+				mcp.Required(),
+				mcp.Description("The prefix that triggers the link, e.g. JIRA-. It must be unique in the repository"),
+			),
+			mcp.WithString("url_template",
+				mcp.Required(),
+				mcp.Description("The URL to link to, which must contain <num> for the reference number, e.g. https://jira.example.com/browse/JIRA-<num>"),
+			),
+			mcp.WithBoolean("is_alphanumeric",
+				mcp.Description("Whether the reference matches letters as well as digits after the prefix, defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			keyPrefix, err := RequiredParam[string](request, "key_prefix")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			urlTemplate, err := RequiredParam[string](request, "url_template")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !strings.Contains(urlTemplate, "<num>") {
+				return mcp.NewToolResultError("url_template must contain <num> where the reference number goes"), nil
+			}
+			isAlphanumeric := true
+			if _, ok := request.GetArguments()["is_alphanumeric"]; ok {
+				isAlphanumeric, err = OptionalParam[bool](request, "is_alphanumeric")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			autolink, resp, err := client.Repositories.AddAutolink(ctx, owner, repo, &github.AutolinkOptions{
+				KeyPrefix:      github.Ptr(keyPrefix),
+				URLTemplate:    github.Ptr(urlTemplate),
+				IsAlphanumeric: github.Ptr(isAlphanumeric),
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to create autolink: an autolink with key prefix %s may already exist in %s/%s, use list_autolinks to check", keyPrefix, owner, repo),
+						resp,
+						err,
+					), nil
+				}
+				return newAdminAPIErrorResponse(ctx, "failed to create autolink", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(autolink)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteAutolink creates a tool to remove an autolink reference from a repository.
+func DeleteAutolink(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_autolink",
+			mcp.WithDescription(t("TOOL_DELETE_AUTOLINK_DESCRIPTION", "Delete an autolink reference from a repository. Existing references in issues and pull requests stop being linked. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_AUTOLINK_USER_TITLE", "Delete autolink"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("autolink_id",
+				mcp.Required(),
+				mcp.Description("The ID of the autolink, as returned by list_autolinks"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			autolinkID, err := RequiredInt(request, "autolink_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteAutolink(ctx, owner, repo, int64(autolinkID))
+			if err != nil {
+				return newAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to delete autolink: %d", autolinkID), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Autolink %d deleted from %s/%s", autolinkID, owner, repo)), nil
+		}
+}
@@ -0,0 +1,308 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListAutolinks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListAutolinks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_autolinks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockAutolinks := []*github.Autolink{
+		{
+			ID:             github.Ptr(int64(1)),
+			KeyPrefix:      github.Ptr("JIRA-"),
+			URLTemplate:    github.Ptr("https://jira.example.com/browse/JIRA-<num>"),
+			IsAlphanumeric: github.Ptr(true),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful list",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposAutolinksByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAutolinks),
+					),
+				),
+			),
+		},
+		{
+			name: "non-admin gets permission hint",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposAutolinksByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "requires admin access to the repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListAutolinks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, "failed to list autolinks")
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned []*github.Autolink
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, "JIRA-", returned[0].GetKeyPrefix())
+		})
+	}
+}
+
+func Test_CreateAutolink(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateAutolink(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_autolink", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "is_alphanumeric")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "key_prefix", "url_template"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful creation defaults to alphanumeric",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposAutolinksByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"key_prefix":      "JIRA-",
+						"url_template":    "https://jira.example.com/browse/JIRA-<num>",
+						"is_alphanumeric": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Autolink{
+							ID:        github.Ptr(int64(1)),
+							KeyPrefix: github.Ptr("JIRA-"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"key_prefix":   "JIRA-",
+				"url_template": "https://jira.example.com/browse/JIRA-<num>",
+			},
+		},
+		{
+			name: "numeric only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposAutolinksByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"key_prefix":      "TICKET-",
+						"url_template":    "https://example.com/tickets/<num>",
+						"is_alphanumeric": false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Autolink{
+							ID:        github.Ptr(int64(2)),
+							KeyPrefix: github.Ptr("TICKET-"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"key_prefix":      "TICKET-",
+				"url_template":    "https://example.com/tickets/<num>",
+				"is_alphanumeric": false,
+			},
+		},
+		{
+			name:         "url template without placeholder",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"key_prefix":   "JIRA-",
+				"url_template": "https://jira.example.com/browse/",
+			},
+			expectError:    true,
+			expectedErrMsg: "url_template must contain <num>",
+		},
+		{
+			name: "duplicate key prefix",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposAutolinksByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed", "errors": [{"resource": "KeyPrefix", "code": "already_exists", "field": "key_prefix"}]}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"key_prefix":   "JIRA-",
+				"url_template": "https://jira.example.com/browse/JIRA-<num>",
+			},
+			expectError:    true,
+			expectedErrMsg: "an autolink with key prefix JIRA- may already exist in owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateAutolink(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned github.Autolink
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.requestArgs["key_prefix"], returned.GetKeyPrefix())
+		})
+	}
+}
+
+func Test_DeleteAutolink(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteAutolink(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_autolink", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "autolink_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful deletion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposAutolinksByOwnerByRepoByAutolinkId,
+					expectPath(t, "/repos/owner/repo/autolinks/1").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name: "autolink not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposAutolinksByOwnerByRepoByAutolinkId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to delete autolink: 1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteAutolink(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"autolink_id": float64(1),
+			}))
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, "Autolink 1 deleted from owner/repo")
+		})
+	}
+}
@@ -0,0 +1,1028 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetBranchProtection creates a tool to get the classic protection rules of a branch.
+func GetBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_branch_protection",
+			mcp.WithDescription(t("TOOL_GET_BRANCH_PROTECTION_DESCRIPTION", "Get the branch protection rules of a branch: required reviews, required status checks, push restrictions and the other settings. Rulesets are separate, see list_rulesets. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_BRANCH_PROTECTION_USER_TITLE", "Get branch protection"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("branch %s of %s/%s is not protected or does not exist", branch, owner, repo)), nil
+				}
+				return newAdminAPIErrorResponse(ctx, "failed to get branch protection", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(protection)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func userLogins(users []*github.User) []string {
+	logins := make([]string, 0, len(users))
+	for _, u := range users {
+		logins = append(logins, u.GetLogin())
+	}
+	return logins
+}
+
+func teamSlugs(teams []*github.Team) []string {
+	slugs := make([]string, 0, len(teams))
+	for _, team := range teams {
+		slugs = append(slugs, team.GetSlug())
+	}
+	return slugs
+}
+
+func appSlugs(apps []*github.App) []string {
+	slugs := make([]string, 0, len(apps))
+	for _, app := range apps {
+		slugs = append(slugs, app.GetSlug())
+	}
+	return slugs
+}
+
+// protectionRequestFrom turns the protection of a branch back into the request that sets it. Updating
+// branch protection replaces all of it, so the current settings are the starting point of every update.
+func protectionRequestFrom(p *github.Protection) *github.ProtectionRequest {
+	preq := &github.ProtectionRequest{}
+	if p == nil {
+		return preq
+	}
+
+	if checks := p.RequiredStatusChecks; checks != nil {
+		// Only one of checks and contexts can be sent, checks also keep the app that must report each one
+		preq.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: checks.Strict}
+		switch {
+		case checks.Checks != nil:
+			preq.RequiredStatusChecks.Checks = checks.Checks
+		case checks.Contexts != nil:
+			preq.RequiredStatusChecks.Contexts = checks.Contexts
+		default:
+			preq.RequiredStatusChecks.Checks = &[]*github.RequiredStatusCheck{}
+		}
+	}
+
+	if reviews := p.RequiredPullRequestReviews; reviews != nil {
+		preq.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			RequireLastPushApproval:      github.Ptr(reviews.RequireLastPushApproval),
+		}
+		if d := reviews.DismissalRestrictions; d != nil {
+			users, teams, apps := userLogins(d.Users), teamSlugs(d.Teams), appSlugs(d.Apps)
+			preq.RequiredPullRequestReviews.DismissalRestrictionsRequest = &github.DismissalRestrictionsRequest{
+				Users: &users,
+				Teams: &teams,
+				Apps:  &apps,
+			}
+		}
+		if b := reviews.BypassPullRequestAllowances; b != nil {
+			preq.RequiredPullRequestReviews.BypassPullRequestAllowancesRequest = &github.BypassPullRequestAllowancesRequest{
+				Users: userLogins(b.Users),
+				Teams: teamSlugs(b.Teams),
+				Apps:  appSlugs(b.Apps),
+			}
+		}
+	}
+
+	if p.EnforceAdmins != nil {
+		preq.EnforceAdmins = p.EnforceAdmins.Enabled
+	}
+	if r := p.Restrictions; r != nil {
+		preq.Restrictions = &github.BranchRestrictionsRequest{
+			Users: userLogins(r.Users),
+			Teams: teamSlugs(r.Teams),
+			Apps:  appSlugs(r.Apps),
+		}
+	}
+	if p.RequireLinearHistory != nil {
+		preq.RequireLinearHistory = github.Ptr(p.RequireLinearHistory.Enabled)
+	}
+	if p.AllowForcePushes != nil {
+		preq.AllowForcePushes = github.Ptr(p.AllowForcePushes.Enabled)
+	}
+	if p.AllowDeletions != nil {
+		preq.AllowDeletions = github.Ptr(p.AllowDeletions.Enabled)
+	}
+	if p.RequiredConversationResolution != nil {
+		preq.RequiredConversationResolution = github.Ptr(p.RequiredConversationResolution.Enabled)
+	}
+	if p.BlockCreations != nil {
+		preq.BlockCreations = p.BlockCreations.Enabled
+	}
+	if p.LockBranch != nil {
+		preq.LockBranch = p.LockBranch.Enabled
+	}
+	if p.AllowForkSyncing != nil {
+		preq.AllowForkSyncing = p.AllowForkSyncing.Enabled
+	}
+	return preq
+}
+
+// UpdateBranchProtection creates a tool to change the protection rules of a branch. Only the given settings
+// change, the others keep their current value.
+func UpdateBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_branch_protection",
+			mcp.WithDescription(t("TOOL_UPDATE_BRANCH_PROTECTION_DESCRIPTION", "Protect a branch or change its protection rules. Only the given settings change, the others keep their current value. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_BRANCH_PROTECTION_USER_TITLE", "Update branch protection"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+			mcp.WithBoolean("require_pull_request",
+				mcp.Description("Require changes to go through a pull request. false removes all review requirements"),
+			),
+			mcp.WithNumber("required_approving_review_count",
+				mcp.Description("Number of approving reviews a pull request needs, implies require_pull_request"),
+				mcp.Min(0),
+				mcp.Max(6),
+			),
+			mcp.WithBoolean("dismiss_stale_reviews",
+				mcp.Description("Dismiss approvals when new commits are pushed"),
+			),
+			mcp.WithBoolean("require_code_owner_reviews",
+				mcp.Description("Require an approval from a code owner of the changed files"),
+			),
+			mcp.WithBoolean("require_last_push_approval",
+				mcp.Description("Require the last push to be approved by someone other than its author"),
+			),
+			mcp.WithArray("required_status_checks",
+				mcp.Description("Names of the status checks that must pass, replacing the current ones. An empty list removes the requirement"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithBoolean("strict",
+				mcp.Description("Require branches to be up to date with the base branch before merging"),
+			),
+			mcp.WithBoolean("enforce_admins",
+				mcp.Description("Apply the rules to repository administrators too"),
+			),
+			mcp.WithBoolean("restrict_pushes",
+				mcp.Description("Only allow the push_users and push_teams to push. false lets everyone with write access push"),
+			),
+			mcp.WithArray("push_users",
+				mcp.Description("Logins of the users allowed to push when pushes are restricted, replacing the current ones"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("push_teams",
+				mcp.Description("Slugs of the teams allowed to push when pushes are restricted, replacing the current ones"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithBoolean("allow_force_pushes",
+				mcp.Description("Allow force pushes"),
+			),
+			mcp.WithBoolean("allow_deletions",
+				mcp.Description("Allow deleting the branch"),
+			),
+			mcp.WithBoolean("required_linear_history",
+				mcp.Description("Reject merge commits"),
+			),
+			mcp.WithBoolean("required_conversation_resolution",
+				mcp.Description("Require all review conversations to be resolved before merging"),
+			),
+			mcp.WithBoolean("lock_branch",
+				mcp.Description("Make the branch read-only"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// optionalBool reads a boolean parameter, reporting whether it was given at all
+			args := request.GetArguments()
+			optionalBool := func(name string) (*bool, error) {
+				if _, ok := args[name]; !ok {
+					return nil, nil
+				}
+				v, err := OptionalParam[bool](request, name)
+				if err != nil {
+					return nil, err
+				}
+				return &v, nil
+			}
+			bools := map[string]*bool{}
+			for _, name := range []string{
+				"require_pull_request", "dismiss_stale_reviews", "require_code_owner_reviews", "require_last_push_approval",
+				"strict", "enforce_admins", "restrict_pushes", "allow_force_pushes", "allow_deletions",
+				"required_linear_history", "required_conversation_resolution", "lock_branch",
+			} {
+				v, err := optionalBool(name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				bools[name] = v
+			}
+			_, hasReviewCount := args["required_approving_review_count"]
+			reviewCount, err := OptionalIntParam(request, "required_approving_review_count")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if reviewCount < 0 || reviewCount > 6 {
+				return mcp.NewToolResultError("required_approving_review_count must be between 0 and 6"), nil
+			}
+			_, hasChecks := args["required_status_checks"]
+			checks, err := OptionalStringArrayParam(request, "required_status_checks")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, hasPushUsers := args["push_users"]
+			pushUsers, err := OptionalStringArrayParam(request, "push_users")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, hasPushTeams := args["push_teams"]
+			pushTeams, err := OptionalStringArrayParam(request, "push_teams")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			current, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			if err != nil {
+				// An unprotected branch starts from no protection at all
+				if resp == nil || resp.StatusCode != http.StatusNotFound {
+					return newAdminAPIErrorResponse(ctx, "failed to get branch protection", resp, err), nil
+				}
+				current = nil
+			} else {
+				_ = resp.Body.Close()
+			}
+			preq := protectionRequestFrom(current)
+
+			// Pull request reviews
+			requirePR := bools["require_pull_request"]
+			if requirePR != nil && !*requirePR {
+				if hasReviewCount || bools["dismiss_stale_reviews"] != nil || bools["require_code_owner_reviews"] != nil || bools["require_last_push_approval"] != nil {
+					return mcp.NewToolResultError("review settings can't be changed when require_pull_request is false"), nil
+				}
+				preq.RequiredPullRequestReviews = nil
+			} else if requirePR != nil || hasReviewCount || bools["dismiss_stale_reviews"] != nil || bools["require_code_owner_reviews"] != nil || bools["require_last_push_approval"] != nil {
+				if preq.RequiredPullRequestReviews == nil {
+					preq.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{}
+				}
+				reviews := preq.RequiredPullRequestReviews
+				if hasReviewCount {
+					reviews.RequiredApprovingReviewCount = reviewCount
+				}
+				if v := bools["dismiss_stale_reviews"]; v != nil {
+					reviews.DismissStaleReviews = *v
+				}
+				if v := bools["require_code_owner_reviews"]; v != nil {
+					reviews.RequireCodeOwnerReviews = *v
+				}
+				if v := bools["require_last_push_approval"]; v != nil {
+					reviews.RequireLastPushApproval = v
+				}
+			}
+
+			// Required status checks, keeping the app of the checks that were already required
+			if hasChecks {
+				if len(checks) == 0 {
+					preq.RequiredStatusChecks = nil
+				} else {
+					appIDs := map[string]*int64{}
+					if current != nil && current.RequiredStatusChecks != nil && current.RequiredStatusChecks.Checks != nil {
+						for _, check := range *current.RequiredStatusChecks.Checks {
+							appIDs[check.Context] = check.AppID
+						}
+					}
+					required := make([]*github.RequiredStatusCheck, 0, len(checks))
+					for _, name := range checks {
+						required = append(required, &github.RequiredStatusCheck{Context: name, AppID: appIDs[name]})
+					}
+					strict := false
+					if preq.RequiredStatusChecks != nil {
+						strict = preq.RequiredStatusChecks.Strict
+					}
+					preq.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: strict, Checks: &required}
+				}
+			}
+			if v := bools["strict"]; v != nil {
+				if preq.RequiredStatusChecks == nil {
+					return mcp.NewToolResultError("strict needs required_status_checks, the branch doesn't require any"), nil
+				}
+				preq.RequiredStatusChecks.Strict = *v
+			}
+
+			// Push restrictions
+			restrict := bools["restrict_pushes"]
+			if restrict != nil && !*restrict {
+				if hasPushUsers || hasPushTeams {
+					return mcp.NewToolResultError("push_users and push_teams can't be set when restrict_pushes is false"), nil
+				}
+				preq.Restrictions = nil
+			} else if restrict != nil || hasPushUsers || hasPushTeams {
+				if preq.Restrictions == nil {
+					preq.Restrictions = &github.BranchRestrictionsRequest{Users: []string{}, Teams: []string{}, Apps: []string{}}
+				}
+				if hasPushUsers {
+					preq.Restrictions.Users = pushUsers
+				}
+				if hasPushTeams {
+					preq.Restrictions.Teams = pushTeams
+				}
+			}
+
+			if v := bools["enforce_admins"]; v != nil {
+				preq.EnforceAdmins = *v
+			}
+			if v := bools["allow_force_pushes"]; v != nil {
+				preq.AllowForcePushes = v
+			}
+			if v := bools["allow_deletions"]; v != nil {
+				preq.AllowDeletions = v
+			}
+			if v := bools["required_linear_history"]; v != nil {
+				preq.RequireLinearHistory = v
+			}
+			if v := bools["required_conversation_resolution"]; v != nil {
+				preq.RequiredConversationResolution = v
+			}
+			if v := bools["lock_branch"]; v != nil {
+				preq.LockBranch = v
+			}
+
+			protection, resp, err := client.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, preq)
+			if err != nil {
+				return newAdminAPIErrorResponse(ctx, "failed to update branch protection", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(protection)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListRulesets creates a tool to list the rulesets of a repository.
+func ListRulesets(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_rulesets",
+			mcp.WithDescription(t("TOOL_LIST_RULESETS_DESCRIPTION", "List the rulesets of a repository, including by default the organization rulesets that apply to it. Use get_ruleset to see the rules of one.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_RULESETS_USER_TITLE", "List rulesets"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithBoolean("include_parents",
+				mcp.Description("Include the rulesets of the organization and enterprise, defaults to true"),
+				mcp.DefaultBool(true),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeParents := true
+			if _, ok := request.GetArguments()["include_parents"]; ok {
+				includeParents, err = OptionalParam[bool](request, "include_parents")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rulesets, resp, err := client.Repositories.GetAllRulesets(ctx, owner, repo, &github.RepositoryListRulesetsOptions{
+				IncludesParents: github.Ptr(includeParents),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list rulesets", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(rulesets)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetRuleset creates a tool to get a single ruleset with its conditions and rules.
+func GetRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_ruleset",
+			mcp.WithDescription(t("TOOL_GET_RULESET_DESCRIPTION", "Get a ruleset of a repository with the branches or tags it applies to, its rules and who can bypass it. Organization rulesets that apply to the repository can be read too.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RULESET_USER_TITLE", "Get ruleset"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("ruleset_id",
+				mcp.Required(),
+				mcp.Description("ID of the ruleset, as returned by list_rulesets"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ruleset, resp, err := client.Repositories.GetRuleset(ctx, owner, repo, int64(rulesetID), true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get ruleset %d", rulesetID), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(ruleset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateRuleset creates a tool to change a ruleset of a repository. Only the given fields change.
+func UpdateRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_ruleset",
+			mcp.WithDescription(t("TOOL_UPDATE_RULESET_DESCRIPTION", "Change a ruleset of a repository: its name, enforcement, the branches or tags it applies to, or its rules. Only the given fields change. Organization rulesets can't be changed from a repository. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_RULESET_USER_TITLE", "Update ruleset"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("ruleset_id",
+				mcp.Required(),
+				mcp.Description("ID of the ruleset, as returned by list_rulesets"),
+			),
+			mcp.WithString("name",
+				mcp.Description("New name of the ruleset"),
+			),
+			mcp.WithString("enforcement",
+				mcp.Description("Whether the ruleset is enforced. evaluate only reports what it would block, and needs GitHub Enterprise"),
+				mcp.Enum("active", "evaluate", "disabled"),
+			),
+			mcp.WithArray("include_refs",
+				mcp.Description("Ref patterns the ruleset applies to, replacing the current ones, such as refs/heads/main, refs/heads/release/* or ~DEFAULT_BRANCH"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("exclude_refs",
+				mcp.Description("Ref patterns excluded from the ruleset, replacing the current ones"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("rules",
+				mcp.Description("Rules of the ruleset, replacing all current rules, in the format of the rules returned by get_ruleset, e.g. [{\"type\": \"deletion\"}, {\"type\": \"pull_request\", \"parameters\": {\"required_approving_review_count\": 1, ...}}]"),
+				mcp.Items(map[string]any{"type": "object"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			enforcement, err := OptionalParam[string](request, "enforcement")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			args := request.GetArguments()
+			_, hasInclude := args["include_refs"]
+			include, err := OptionalStringArrayParam(request, "include_refs")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, hasExclude := args["exclude_refs"]
+			exclude, err := OptionalStringArrayParam(request, "exclude_refs")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var rules *github.RepositoryRulesetRules
+			if raw, ok := args["rules"]; ok {
+				data, err := json.Marshal(raw)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid rules: %v", err)), nil
+				}
+				rules = &github.RepositoryRulesetRules{}
+				if err := json.Unmarshal(data, rules); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid rules: %v", err)), nil
+				}
+			}
+			if name == "" && enforcement == "" && !hasInclude && !hasExclude && rules == nil {
+				return mcp.NewToolResultError("nothing to update, give at least one of name, enforcement, include_refs, exclude_refs or rules"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ruleset, resp, err := client.Repositories.GetRuleset(ctx, owner, repo, int64(rulesetID), false)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get ruleset %d", rulesetID), resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			if name != "" {
+				ruleset.Name = name
+			}
+			if enforcement != "" {
+				ruleset.Enforcement = github.RulesetEnforcement(enforcement)
+			}
+			if hasInclude || hasExclude {
+				if ruleset.Conditions == nil {
+					ruleset.Conditions = &github.RepositoryRulesetConditions{}
+				}
+				if ruleset.Conditions.RefName == nil {
+					ruleset.Conditions.RefName = &github.RepositoryRulesetRefConditionParameters{Include: []string{}, Exclude: []string{}}
+				}
+				if hasInclude {
+					ruleset.Conditions.RefName.Include = include
+				}
+				if hasExclude {
+					ruleset.Conditions.RefName.Exclude = exclude
+				}
+			}
+			if rules != nil {
+				ruleset.Rules = rules
+			}
+
+			updated, resp, err := client.Repositories.UpdateRuleset(ctx, owner, repo, int64(rulesetID), *ruleset)
+			if err != nil {
+				return newAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to update ruleset %d", rulesetID), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(updated)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// PushBlocker is a rule that can reject a push to a branch.
+type PushBlocker struct {
+	// Source is branch_protection, or the ruleset the rule comes from
+	Source    string `json:"source"`
+	RulesetID int64  `json:"ruleset_id,omitempty"`
+	Rule      string `json:"rule"`
+	// Blocks is the kind of push the rule rejects: push, force_push, creation or deletion
+	Blocks      string `json:"blocks"`
+	Explanation string `json:"explanation"`
+	// CanBypass reports whether the current user may bypass the ruleset, if known
+	CanBypass *bool `json:"can_bypass,omitempty"`
+}
+
+// blockersFromProtection lists the branch protection rules that can reject a push.
+func blockersFromProtection(p *github.Protection) []*PushBlocker {
+	var blockers []*PushBlocker
+	add := func(rule, blocks, explanation string) {
+		blockers = append(blockers, &PushBlocker{Source: "branch_protection", Rule: rule, Blocks: blocks, Explanation: explanation})
+	}
+
+	if p.LockBranch != nil && p.LockBranch.GetEnabled() {
+		add("lock_branch", "push", "the branch is locked and read-only")
+	}
+	if r := p.RequiredPullRequestReviews; r != nil {
+		explanation := "changes must be made through a pull request"
+		if r.RequiredApprovingReviewCount > 0 {
+			explanation += fmt.Sprintf(" with %d approving review(s)", r.RequiredApprovingReviewCount)
+		}
+		if r.RequireCodeOwnerReviews {
+			explanation += ", including one from a code owner"
+		}
+		add("required_pull_request_reviews", "push", explanation)
+	}
+	if c := p.RequiredStatusChecks; c != nil {
+		var names []string
+		if c.Checks != nil {
+			for _, check := range *c.Checks {
+				names = append(names, check.Context)
+			}
+		} else if c.Contexts != nil {
+			names = *c.Contexts
+		}
+		if len(names) > 0 {
+			add("required_status_checks", "push", fmt.Sprintf("pushed commits must have passed the required status checks %s", strings.Join(names, ", ")))
+		}
+	}
+	if r := p.Restrictions; r != nil {
+		allowed := append(append(userLogins(r.Users), teamSlugs(r.Teams)...), appSlugs(r.Apps)...)
+		explanation := "only repository administrators can push"
+		if len(allowed) > 0 {
+			explanation = fmt.Sprintf("only %s can push", strings.Join(allowed, ", "))
+		}
+		add("restrictions", "push", explanation)
+	}
+	if p.RequireLinearHistory != nil && p.RequireLinearHistory.Enabled {
+		add("required_linear_history", "push", "merge commits are rejected, rebase or squash instead")
+	}
+	if p.RequiredSignatures != nil && p.RequiredSignatures.GetEnabled() {
+		add("required_signatures", "push", "commits must have verified signatures")
+	}
+	if p.AllowForcePushes == nil || !p.AllowForcePushes.Enabled {
+		add("allow_force_pushes", "force_push", "force pushes are rejected")
+	}
+	if p.AllowDeletions == nil || !p.AllowDeletions.Enabled {
+		add("allow_deletions", "deletion", "the branch can't be deleted")
+	}
+	return blockers
+}
+
+// blockersFromRules lists the ruleset rules of a branch that can reject a push.
+func blockersFromRules(rules *github.BranchRules) []*PushBlocker {
+	var blockers []*PushBlocker
+	add := func(meta github.BranchRuleMetadata, rule, blocks, explanation string) {
+		blockers = append(blockers, &PushBlocker{
+			Source:      fmt.Sprintf("ruleset from %s %s", strings.ToLower(string(meta.RulesetSourceType)), meta.RulesetSource),
+			RulesetID:   meta.RulesetID,
+			Rule:        rule,
+			Blocks:      blocks,
+			Explanation: explanation,
+		})
+	}
+	pattern := func(p github.PatternRuleParameters) string {
+		s := fmt.Sprintf("%s %q", strings.ReplaceAll(string(p.Operator), "_", " "), p.Pattern)
+		if p.Negate != nil && *p.Negate {
+			s = "must not " + s
+		} else {
+			s = "must " + s
+		}
+		return s
+	}
+
+	for _, r := range rules.Creation {
+		add(*r, "creation", "creation", "the branch can't be created")
+	}
+	for _, r := range rules.Update {
+		add(r.BranchRuleMetadata, "update", "push", "the branch can't be updated")
+	}
+	for _, r := range rules.Deletion {
+		add(*r, "deletion", "deletion", "the branch can't be deleted")
+	}
+	for _, r := range rules.NonFastForward {
+		add(*r, "non_fast_forward", "force_push", "force pushes are rejected")
+	}
+	for _, r := range rules.PullRequest {
+		explanation := "changes must be made through a pull request"
+		if r.Parameters.RequiredApprovingReviewCount > 0 {
+			explanation += fmt.Sprintf(" with %d approving review(s)", r.Parameters.RequiredApprovingReviewCount)
+		}
+		if r.Parameters.RequireCodeOwnerReview {
+			explanation += ", including one from a code owner"
+		}
+		add(r.BranchRuleMetadata, "pull_request", "push", explanation)
+	}
+	for _, r := range rules.MergeQueue {
+		add(r.BranchRuleMetadata, "merge_queue", "push", "changes must be merged through the merge queue")
+	}
+	for _, r := range rules.RequiredStatusChecks {
+		names := make([]string, 0, len(r.Parameters.RequiredStatusChecks))
+		for _, check := range r.Parameters.RequiredStatusChecks {
+			names = append(names, check.Context)
+		}
+		add(r.BranchRuleMetadata, "required_status_checks", "push", fmt.Sprintf("pushed commits must have passed the required status checks %s", strings.Join(names, ", ")))
+	}
+	for _, r := range rules.RequiredDeployments {
+		add(r.BranchRuleMetadata, "required_deployments", "push", fmt.Sprintf("commits must have been deployed to %s", strings.Join(r.Parameters.RequiredDeploymentEnvironments, ", ")))
+	}
+	for _, r := range rules.RequiredLinearHistory {
+		add(*r, "required_linear_history", "push", "merge commits are rejected, rebase or squash instead")
+	}
+	for _, r := range rules.RequiredSignatures {
+		add(*r, "required_signatures", "push", "commits must have verified signatures")
+	}
+	for _, r := range rules.CommitMessagePattern {
+		add(r.BranchRuleMetadata, "commit_message_pattern", "push", "commit messages "+pattern(r.Parameters))
+	}
+	for _, r := range rules.CommitAuthorEmailPattern {
+		add(r.BranchRuleMetadata, "commit_author_email_pattern", "push", "commit author emails "+pattern(r.Parameters))
+	}
+	for _, r := range rules.CommitterEmailPattern {
+		add(r.BranchRuleMetadata, "committer_email_pattern", "push", "committer emails "+pattern(r.Parameters))
+	}
+	for _, r := range rules.BranchNamePattern {
+		add(r.BranchRuleMetadata, "branch_name_pattern", "creation", "branch names "+pattern(r.Parameters))
+	}
+	for _, r := range rules.FilePathRestriction {
+		add(r.BranchRuleMetadata, "file_path_restriction", "push", fmt.Sprintf("pushes can't change %s", strings.Join(r.Parameters.RestrictedFilePaths, ", ")))
+	}
+	for _, r := range rules.FileExtensionRestriction {
+		add(r.BranchRuleMetadata, "file_extension_restriction", "push", fmt.Sprintf("pushes can't add files with the extensions %s", strings.Join(r.Parameters.RestrictedFileExtensions, ", ")))
+	}
+	for _, r := range rules.MaxFilePathLength {
+		add(r.BranchRuleMetadata, "max_file_path_length", "push", fmt.Sprintf("file paths can't be longer than %d characters", r.Parameters.MaxFilePathLength))
+	}
+	for _, r := range rules.MaxFileSize {
+		add(r.BranchRuleMetadata, "max_file_size", "push", fmt.Sprintf("files can't be larger than %d MB", r.Parameters.MaxFileSize))
+	}
+	for _, r := range rules.Workflows {
+		paths := make([]string, 0, len(r.Parameters.Workflows))
+		for _, w := range r.Parameters.Workflows {
+			paths = append(paths, w.Path)
+		}
+		add(r.BranchRuleMetadata, "workflows", "push", fmt.Sprintf("the workflows %s must pass before changes are merged", strings.Join(paths, ", ")))
+	}
+	for _, r := range rules.CodeScanning {
+		tools := make([]string, 0, len(r.Parameters.CodeScanningTools))
+		for _, tool := range r.Parameters.CodeScanningTools {
+			tools = append(tools, tool.Tool)
+		}
+		add(r.BranchRuleMetadata, "code_scanning", "push", fmt.Sprintf("code scanning results from %s must be below the alert thresholds", strings.Join(tools, ", ")))
+	}
+	return blockers
+}
+
+// ExplainPushBlock creates a tool to explain which rules can reject a push to a branch.
+func ExplainPushBlock(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("explain_push_block",
+			mcp.WithDescription(t("TOOL_EXPLAIN_PUSH_BLOCK_DESCRIPTION", "Explain why a push to a branch is or would be rejected. Combines the branch protection and the active rulesets of the branch into a list of the rules that block pushes, force pushes, creation or deletion, with whether the current user can bypass each ruleset. For repository administrators, the recent rejected pushes to the branch are included.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_EXPLAIN_PUSH_BLOCK_USER_TITLE", "Explain why a push was blocked"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+			mcp.WithString("actor",
+				mcp.Description("Only include the recent rejected pushes of this user"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			actor, err := OptionalParam[string](request, "actor")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var warnings []string
+			blockers := []*PushBlocker{}
+			exists := true
+
+			// Classic branch protection. The details need admin access, the branch itself only tells whether
+			// it is protected, so a failure is reported alongside the rulesets rather than failing the call.
+			b, resp, err := client.Repositories.GetBranch(ctx, owner, repo, branch, 1)
+			switch {
+			case err == nil:
+				_ = resp.Body.Close()
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				// The branch doesn't exist yet, only the ruleset rules about creating it apply
+				exists = false
+			default:
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get branch", resp, err), nil
+			}
+			protected := b.GetProtected()
+			if protected {
+				protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+				if err != nil {
+					warnings = append(warnings, fmt.Sprintf("branch %s is protected but its protection could not be read, which requires admin access: %v", branch, err))
+				} else {
+					_ = resp.Body.Close()
+					blockers = append(blockers, blockersFromProtection(protection)...)
+					if protection.EnforceAdmins == nil || !protection.EnforceAdmins.Enabled {
+						warnings = append(warnings, "branch protection is not enforced for repository administrators")
+					}
+				}
+			}
+
+			rules, resp, err := client.Repositories.GetRulesForBranch(ctx, owner, repo, branch, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get rules for branch", resp, err), nil
+			}
+			_ = resp.Body.Close()
+			if rules != nil {
+				blockers = append(blockers, blockersFromRules(rules)...)
+			}
+
+			if !exists {
+				creation := []*PushBlocker{}
+				for _, blocker := range blockers {
+					if blocker.Blocks == "creation" {
+						creation = append(creation, blocker)
+					}
+				}
+				blockers = creation
+			}
+
+			// Whether the current user can bypass is a property of the ruleset, read it once per ruleset
+			canBypass := map[int64]*bool{}
+			for _, blocker := range blockers {
+				if blocker.RulesetID == 0 {
+					continue
+				}
+				if _, seen := canBypass[blocker.RulesetID]; !seen {
+					ruleset, resp, err := client.Repositories.GetRuleset(ctx, owner, repo, blocker.RulesetID, true)
+					if err != nil {
+						warnings = append(warnings, fmt.Sprintf("ruleset %d could not be read to tell whether you can bypass it: %v", blocker.RulesetID, err))
+						canBypass[blocker.RulesetID] = nil
+					} else {
+						_ = resp.Body.Close()
+						mode := ruleset.GetCurrentUserCanBypass()
+						canBypass[blocker.RulesetID] = github.Ptr(mode != nil && *mode != github.BypassModeNever)
+					}
+				}
+				blocker.CanBypass = canBypass[blocker.RulesetID]
+			}
+
+			sort.SliceStable(blockers, func(i, j int) bool { return blockers[i].Blocks < blockers[j].Blocks })
+
+			result := map[string]any{
+				"branch":    branch,
+				"exists":    exists,
+				"protected": protected,
+				"blockers":  blockers,
+			}
+
+			// The rule suites say what actually rejected recent pushes, but need admin access
+			query := url.Values{}
+			query.Set("ref", "refs/heads/"+branch)
+			query.Set("rule_suite_result", "fail")
+			query.Set("time_period", "day")
+			query.Set("per_page", "5")
+			if actor != "" {
+				query.Set("actor_name", actor)
+			}
+			req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/rulesets/rule-suites?%s", owner, repo, query.Encode()), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var ruleSuites []*RuleSuite
+			resp, err = client.Do(ctx, req, &ruleSuites)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("recent rejected pushes could not be listed, which requires admin access: %v", err))
+			} else {
+				_ = resp.Body.Close()
+				result["recent_rejected_pushes"] = ruleSuites
+			}
+
+			if len(warnings) > 0 {
+				result["warnings"] = warnings
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,663 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mockProtection = &github.Protection{
+	RequiredStatusChecks: &github.RequiredStatusChecks{
+		Strict: true,
+		Checks: &[]*github.RequiredStatusCheck{
+			{Context: "build", AppID: github.Ptr(int64(15368))},
+		},
+	},
+	RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+		RequiredApprovingReviewCount: 1,
+		DismissStaleReviews:          true,
+	},
+	EnforceAdmins: &github.AdminEnforcement{Enabled: false},
+	Restrictions: &github.BranchRestrictions{
+		Users: []*github.User{{Login: github.Ptr("octocat")}},
+		Teams: []*github.Team{{Slug: github.Ptr("maintainers")}},
+	},
+	AllowForcePushes: &github.AllowForcePushes{Enabled: false},
+}
+
+func Test_GetBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_branch_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful branch protection fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockProtection,
+				),
+			),
+		},
+		{
+			name: "branch not protected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "branch main of owner/repo is not protected or does not exist",
+		},
+		{
+			name: "no admin access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "requires admin access to the repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			})
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.Protection
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, 1, returned.RequiredPullRequestReviews.RequiredApprovingReviewCount)
+			assert.True(t, returned.RequiredStatusChecks.Strict)
+		})
+	}
+}
+
+func Test_UpdateBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_branch_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	tests := []struct {
+		name           string
+		current        http.HandlerFunc
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedBody   map[string]any
+	}{
+		{
+			name:    "changes only the given settings",
+			current: mockResponse(t, http.StatusOK, mockProtection),
+			requestArgs: map[string]interface{}{
+				"required_approving_review_count": float64(2),
+				"allow_force_pushes":              true,
+				"push_teams":                      []interface{}{"release"},
+			},
+			expectedBody: map[string]any{
+				"required_status_checks": map[string]any{
+					"strict": true,
+					"checks": []any{map[string]any{"context": "build", "app_id": float64(15368)}},
+				},
+				"required_pull_request_reviews": map[string]any{
+					"dismiss_stale_reviews":           true,
+					"require_code_owner_reviews":      false,
+					"required_approving_review_count": float64(2),
+					"require_last_push_approval":      false,
+				},
+				"enforce_admins": false,
+				"restrictions": map[string]any{
+					"users": []any{"octocat"},
+					"teams": []any{"release"},
+					"apps":  []any{},
+				},
+				"allow_force_pushes": true,
+			},
+		},
+		{
+			name:    "removes requirements",
+			current: mockResponse(t, http.StatusOK, mockProtection),
+			requestArgs: map[string]interface{}{
+				"require_pull_request":   false,
+				"required_status_checks": []interface{}{},
+				"restrict_pushes":        false,
+			},
+			expectedBody: map[string]any{
+				"required_status_checks":        nil,
+				"required_pull_request_reviews": nil,
+				"enforce_admins":                false,
+				"restrictions":                  nil,
+				"allow_force_pushes":            false,
+			},
+		},
+		{
+			name:    "protects an unprotected branch",
+			current: mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`),
+			requestArgs: map[string]interface{}{
+				"required_status_checks": []interface{}{"test"},
+				"strict":                 true,
+				"enforce_admins":         true,
+			},
+			expectedBody: map[string]any{
+				"required_status_checks": map[string]any{
+					"strict": true,
+					"checks": []any{map[string]any{"context": "test"}},
+				},
+				"required_pull_request_reviews": nil,
+				"enforce_admins":                true,
+				"restrictions":                  nil,
+			},
+		},
+		{
+			name:    "conflicting review settings",
+			current: mockResponse(t, http.StatusOK, mockProtection),
+			requestArgs: map[string]interface{}{
+				"require_pull_request":            false,
+				"required_approving_review_count": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "review settings can't be changed when require_pull_request is false",
+		},
+		{
+			name:    "strict without status checks",
+			current: mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`),
+			requestArgs: map[string]interface{}{
+				"strict": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "strict needs required_status_checks",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var body map[string]any
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					tc.current,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write(mock.MustMarshal(mockProtection))
+					}),
+				),
+			))
+			_, handler := UpdateBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				assert.Nil(t, body)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
+
+func Test_ListRulesets(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRulesets(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_rulesets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRulesets := []*github.RepositoryRuleset{
+		{ID: github.Ptr(int64(42)), Name: "main", Enforcement: github.RulesetEnforcementActive},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "includes parent rulesets by default",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"includes_parents": "true",
+						"page":             "1",
+						"per_page":         "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRulesets),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+		},
+		{
+			name: "only repository rulesets",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"includes_parents": "false",
+						"page":             "1",
+						"per_page":         "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRulesets),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"include_parents": false,
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list rulesets",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRulesets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned []*github.RepositoryRuleset
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, "main", returned[0].Name)
+		})
+	}
+}
+
+func Test_GetRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ruleset_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+			expectQueryParams(t, map[string]string{
+				"includes_parents": "true",
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.RepositoryRuleset{
+					ID:          github.Ptr(int64(42)),
+					Name:        "main",
+					Enforcement: github.RulesetEnforcementActive,
+					Rules:       &github.RepositoryRulesetRules{Deletion: &github.EmptyRuleParameters{}},
+				}),
+			),
+		),
+	))
+	_, handler := GetRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":      "owner",
+		"repo":       "repo",
+		"ruleset_id": float64(42),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned github.RepositoryRuleset
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, int64(42), returned.GetID())
+	assert.NotNil(t, returned.Rules.Deletion)
+}
+
+func Test_UpdateRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ruleset_id"})
+
+	current := &github.RepositoryRuleset{
+		ID:          github.Ptr(int64(42)),
+		Name:        "main",
+		Target:      github.Ptr(github.RulesetTargetBranch),
+		Enforcement: github.RulesetEnforcementActive,
+		Conditions: &github.RepositoryRulesetConditions{
+			RefName: &github.RepositoryRulesetRefConditionParameters{
+				Include: []string{"~DEFAULT_BRANCH"},
+				Exclude: []string{},
+			},
+		},
+		Rules: &github.RepositoryRulesetRules{Deletion: &github.EmptyRuleParameters{}},
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		check          func(t *testing.T, body map[string]any)
+	}{
+		{
+			name: "changes the enforcement only",
+			requestArgs: map[string]interface{}{
+				"enforcement": "evaluate",
+			},
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "main", body["name"])
+				assert.Equal(t, "evaluate", body["enforcement"])
+				assert.Equal(t, []any{map[string]any{"type": "deletion"}}, body["rules"])
+			},
+		},
+		{
+			name: "replaces refs and rules",
+			requestArgs: map[string]interface{}{
+				"include_refs": []interface{}{"refs/heads/release/*"},
+				"rules": []interface{}{
+					map[string]interface{}{"type": "non_fast_forward"},
+					map[string]interface{}{"type": "required_linear_history"},
+				},
+			},
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, map[string]any{
+					"ref_name": map[string]any{
+						"include": []any{"refs/heads/release/*"},
+						"exclude": []any{},
+					},
+				}, body["conditions"])
+				assert.ElementsMatch(t, []any{
+					map[string]any{"type": "non_fast_forward"},
+					map[string]any{"type": "required_linear_history"},
+				}, body["rules"])
+			},
+		},
+		{
+			name: "nothing to update",
+			requestArgs: map[string]interface{}{
+				"name": "",
+			},
+			expectError:    true,
+			expectedErrMsg: "nothing to update",
+		},
+		{
+			name: "invalid rules",
+			requestArgs: map[string]interface{}{
+				"rules": []interface{}{map[string]interface{}{"type": "pull_request", "parameters": "none"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid rules",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var body map[string]any
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+					mockResponse(t, http.StatusOK, current),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposRulesetsByOwnerByRepoByRulesetId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write(mock.MustMarshal(current))
+					}),
+				),
+			))
+			_, handler := UpdateRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ruleset_id": float64(42),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				assert.Nil(t, body)
+				return
+			}
+
+			require.False(t, result.IsError)
+			tc.check(t, body)
+		})
+	}
+}
+
+func Test_ExplainPushBlock(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ExplainPushBlock(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "explain_push_block", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	branchRules := `[
+		{"type": "pull_request", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 42,
+		 "parameters": {"required_approving_review_count": 2, "require_code_owner_review": true, "dismiss_stale_reviews_on_push": false,
+		 "require_last_push_approval": false, "required_review_thread_resolution": false}},
+		{"type": "creation", "ruleset_source_type": "Organization", "ruleset_source": "owner", "ruleset_id": 7}
+	]`
+
+	t.Run("protected branch with rulesets", func(t *testing.T) {
+		// The rule suites route is registered first, the ruleset route would match it too
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposBranchesByOwnerByRepoByBranch,
+				&github.Branch{Name: github.Ptr("main"), Protected: github.Ptr(true)},
+			),
+			mock.WithRequestMatch(
+				mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+				mockProtection,
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+				mockResponse(t, http.StatusOK, branchRules),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposRulesetsRuleSuitesByOwnerByRepo,
+				expectQueryParams(t, map[string]string{
+					"ref":               "refs/heads/main",
+					"rule_suite_result": "fail",
+					"time_period":       "day",
+					"per_page":          "5",
+					"actor_name":        "octocat",
+				}).andThen(
+					mockResponse(t, http.StatusOK, []*RuleSuite{{ID: 9, ActorName: "octocat", Result: "fail"}}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					mode := github.BypassModeNever
+					if r.URL.Path == "/repos/owner/repo/rulesets/7" {
+						mode = github.BypassModeAlways
+					}
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write(mock.MustMarshal(&github.RepositoryRuleset{CurrentUserCanBypass: &mode}))
+				}),
+			),
+		))
+		_, handler := ExplainPushBlock(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":  "owner",
+			"repo":   "repo",
+			"branch": "main",
+			"actor":  "octocat",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var returned struct {
+			Protected      bool           `json:"protected"`
+			Blockers       []*PushBlocker `json:"blockers"`
+			RejectedPushes []*RuleSuite   `json:"recent_rejected_pushes"`
+			Warnings       []string       `json:"warnings"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		assert.True(t, returned.Protected)
+		require.Len(t, returned.RejectedPushes, 1)
+		assert.Equal(t, []string{"branch protection is not enforced for repository administrators"}, returned.Warnings)
+
+		rules := map[string]*PushBlocker{}
+		for _, b := range returned.Blockers {
+			rules[b.Source+" "+b.Rule] = b
+		}
+		assert.Equal(t, "changes must be made through a pull request with 1 approving review(s)", rules["branch_protection required_pull_request_reviews"].Explanation)
+		assert.Equal(t, "pushed commits must have passed the required status checks build", rules["branch_protection required_status_checks"].Explanation)
+		assert.Equal(t, "only octocat, maintainers can push", rules["branch_protection restrictions"].Explanation)
+		assert.Equal(t, "force_push", rules["branch_protection allow_force_pushes"].Blocks)
+
+		pr := rules["ruleset from repository owner/repo pull_request"]
+		require.NotNil(t, pr)
+		assert.Equal(t, "changes must be made through a pull request with 2 approving review(s), including one from a code owner", pr.Explanation)
+		assert.False(t, *pr.CanBypass)
+		creation := rules["ruleset from organization owner creation"]
+		require.NotNil(t, creation)
+		assert.True(t, *creation.CanBypass)
+	})
+
+	t.Run("missing branch keeps only creation rules", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposBranchesByOwnerByRepoByBranch,
+				mockResponse(t, http.StatusNotFound, `{"message": "Branch not found"}`),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+				mockResponse(t, http.StatusOK, branchRules),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposRulesetsRuleSuitesByOwnerByRepo,
+				mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			),
+		))
+		_, handler := ExplainPushBlock(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":  "owner",
+			"repo":   "repo",
+			"branch": "feature",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var returned struct {
+			Exists   bool           `json:"exists"`
+			Blockers []*PushBlocker `json:"blockers"`
+			Warnings []string       `json:"warnings"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		assert.False(t, returned.Exists)
+		require.Len(t, returned.Blockers, 1)
+		assert.Equal(t, "creation", returned.Blockers[0].Rule)
+		assert.Nil(t, returned.Blockers[0].CanBypass)
+		require.Len(t, returned.Warnings, 2)
+		assert.Contains(t, returned.Warnings[0], "ruleset 7 could not be read")
+		assert.Contains(t, returned.Warnings[1], "recent rejected pushes could not be listed")
+	})
+}
@@ -0,0 +1,152 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// The MCP methods involved in cancellation, which the MCP server library doesn't define.
+const (
+	methodToolsCall             = "tools/call"
+	methodNotificationCancelled = "notifications/cancelled"
+)
+
+// requestIDMetaKey is the metadata field that carries the JSON-RPC ID of a tool call to its handler.
+const requestIDMetaKey = "github-mcp-server/requestId"
+
+// requestKey identifies a request among all sessions.
+type requestKey struct {
+	sessionID string
+	requestID string
+}
+
+// RequestCancellations implements notifications/cancelled for tool calls, which the MCP server library
+// ignores. Tool handlers don't see the ID of their request, so transports pass incoming messages through
+// PrepareMessage, which hands the ID to the handler in the request metadata and cancels the context of
+// the calls that clients cancel. Middleware must wrap the tool handlers.
+type RequestCancellations struct {
+	mu sync.Mutex
+	// cancels are the cancel functions of the running tool calls
+	cancels map[requestKey]context.CancelFunc
+}
+
+// NewRequestCancellations creates the cancellations of a server.
+func NewRequestCancellations() *RequestCancellations {
+	return &RequestCancellations{cancels: make(map[requestKey]context.CancelFunc)}
+}
+
+// cancellableMessage is the part of a JSON-RPC message that cancellation looks at.
+type cancellableMessage struct {
+	ID     any    `json:"id"`
+	Method string `json:"method"`
+	Params struct {
+		RequestID any `json:"requestId"`
+	} `json:"params"`
+}
+
+// PrepareMessage handles a message of the session before the MCP server does. It cancels the call that
+// a notifications/cancelled notification names and reports false, as the notification needs no further
+// handling. Tool calls get their ID added to their metadata; every other message is returned as is.
+func (c *RequestCancellations) PrepareMessage(sessionID string, message json.RawMessage) (json.RawMessage, bool) {
+	var m cancellableMessage
+	if err := json.Unmarshal(message, &m); err != nil {
+		return message, true
+	}
+
+	switch {
+	case m.Method == methodNotificationCancelled && m.ID == nil:
+		if m.Params.RequestID != nil {
+			c.Cancel(sessionID, m.Params.RequestID)
+		}
+		return nil, false
+	case m.Method == methodToolsCall && m.ID != nil:
+		tagged, err := tagRequestID(message, m.ID)
+		if err != nil {
+			return message, true
+		}
+		return tagged, true
+	default:
+		return message, true
+	}
+}
+
+// tagRequestID adds the request ID to the metadata of a tool call, keeping all other fields as they are.
+func tagRequestID(message json.RawMessage, id any) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(message, &fields); err != nil {
+		return nil, err
+	}
+	params := map[string]json.RawMessage{}
+	if raw, ok := fields["params"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, err
+		}
+	}
+	meta := map[string]any{}
+	if raw, ok := params["_meta"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			return nil, err
+		}
+	}
+
+	meta[requestIDMetaKey] = id
+	var err error
+	if params["_meta"], err = json.Marshal(meta); err != nil {
+		return nil, err
+	}
+	if fields["params"], err = json.Marshal(params); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// Cancel cancels the context of a running tool call of the session. Calls that already finished, or
+// that are unknown, are ignored.
+func (c *RequestCancellations) Cancel(sessionID string, requestID any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cancel, ok := c.cancels[requestKey{sessionID, fmt.Sprint(requestID)}]; ok {
+		cancel()
+	}
+}
+
+// Middleware gives tool handlers a context that is cancelled when the client cancels their call, which
+// aborts the GitHub API requests made with it.
+func (c *RequestCancellations) Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		session := server.ClientSessionFromContext(ctx)
+		if session == nil || request.Params.Meta == nil {
+			return next(ctx, request)
+		}
+		requestID, ok := request.Params.Meta.AdditionalFields[requestIDMetaKey]
+		if !ok {
+			return next(ctx, request)
+		}
+		delete(request.Params.Meta.AdditionalFields, requestIDMetaKey)
+
+		key := requestKey{session.SessionID(), fmt.Sprint(requestID)}
+		ctx, cancel := context.WithCancel(ctx)
+		c.mu.Lock()
+		c.cancels[key] = cancel
+		c.mu.Unlock()
+		defer func() {
+			c.mu.Lock()
+			delete(c.cancels, key)
+			c.mu.Unlock()
+			cancel()
+		}()
+
+		result, err := next(ctx, request)
+		// The requests of a cancelled call fail right away, so whatever the handler made of their errors is
+		// replaced by the cancellation itself
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return result, err
+	}
+}
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RequestCancellations_PrepareMessage(t *testing.T) {
+	cancellations := NewRequestCancellations()
+
+	tests := []struct {
+		name           string
+		message        string
+		expectedOK     bool
+		expectedResult string
+	}{
+		{
+			name:           "tags a tool call with its ID",
+			message:        `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"get_me","arguments":{"a":1}}}`,
+			expectedOK:     true,
+			expectedResult: `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"get_me","arguments":{"a":1},"_meta":{"github-mcp-server/requestId":7}}}`,
+		},
+		{
+			name:           "keeps the metadata of a tool call",
+			message:        `{"jsonrpc":"2.0","id":"abc","method":"tools/call","params":{"name":"get_me","_meta":{"progressToken":"p"}}}`,
+			expectedOK:     true,
+			expectedResult: `{"jsonrpc":"2.0","id":"abc","method":"tools/call","params":{"name":"get_me","_meta":{"progressToken":"p","github-mcp-server/requestId":"abc"}}}`,
+		},
+		{
+			name:           "passes other requests on as they are",
+			message:        `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+			expectedOK:     true,
+			expectedResult: `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+		},
+		{
+			name:           "passes messages that aren't JSON-RPC on as they are",
+			message:        `not json`,
+			expectedOK:     true,
+			expectedResult: `not json`,
+		},
+		{
+			name:       "consumes cancellations",
+			message:    `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7,"reason":"user"}}`,
+			expectedOK: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			message, ok := cancellations.PrepareMessage("session", json.RawMessage(tc.message))
+			require.Equal(t, tc.expectedOK, ok)
+			if !tc.expectedOK {
+				return
+			}
+			if json.Valid([]byte(tc.expectedResult)) {
+				assert.JSONEq(t, tc.expectedResult, string(message))
+			} else {
+				assert.Equal(t, tc.expectedResult, string(message))
+			}
+		})
+	}
+}
+
+func Test_RequestCancellations_Middleware(t *testing.T) {
+	cancellations := NewRequestCancellations()
+	started := make(chan struct{})
+	srv := server.NewMCPServer("test", "1.0.0", server.WithToolHandlerMiddleware(cancellations.Middleware))
+	srv.AddTool(mcp.NewTool("wait"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// The ID is only meant for the middleware
+		assert.NotContains(t, request.Params.Meta.AdditionalFields, requestIDMetaKey)
+		close(started)
+		select {
+		case <-ctx.Done():
+			return mcp.NewToolResultError("failed to list: context canceled"), nil
+		case <-time.After(10 * time.Second):
+			return mcp.NewToolResultText("done"), nil
+		}
+	})
+	session := &progressSession{notifications: make(chan mcp.JSONRPCNotification, 1)}
+	ctx := srv.WithContext(context.Background(), session)
+
+	call, ok := cancellations.PrepareMessage(session.SessionID(), json.RawMessage(`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"wait"}}`))
+	require.True(t, ok)
+	responses := make(chan mcp.JSONRPCMessage, 1)
+	go func() { responses <- srv.HandleMessage(ctx, call) }()
+
+	<-started
+	// Cancellations of other sessions and requests don't affect the call
+	cancellations.Cancel("other", float64(3))
+	cancellations.Cancel(session.SessionID(), float64(4))
+	_, ok = cancellations.PrepareMessage(session.SessionID(), json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":3}}`))
+	require.False(t, ok)
+
+	select {
+	case response := <-responses:
+		jsonErr, ok := response.(mcp.JSONRPCError)
+		require.True(t, ok)
+		assert.Equal(t, context.Canceled.Error(), jsonErr.Error.Message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the call wasn't cancelled")
+	}
+
+	cancellations.mu.Lock()
+	defer cancellations.mu.Unlock()
+	assert.Empty(t, cancellations.cancels)
+}
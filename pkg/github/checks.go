@@ -0,0 +1,608 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxCheckRunAnnotations is how many annotations GitHub accepts in a single create or update of a check run,
+// more have to be sent with further updates.
+const maxCheckRunAnnotations = 50
+
+// ListCheckRuns creates a tool to list the check runs of a commit.
+func ListCheckRuns(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_check_runs",
+			mcp.WithDescription(t("TOOL_LIST_CHECK_RUNS_DESCRIPTION", "List the check runs of a commit, branch or tag, with their status, conclusion and output summary. Use get_check_run_annotations to see the file lines a check run flagged.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CHECK_RUNS_USER_TITLE", "List check runs"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag name"),
+			),
+			mcp.WithString("check_name",
+				mcp.Description("Only return check runs with this name"),
+			),
+			mcp.WithString("status",
+				mcp.Description("Only return check runs with this status"),
+				mcp.Enum("queued", "in_progress", "completed"),
+			),
+			mcp.WithString("filter",
+				mcp.Description("latest returns the most recent run of each check, all includes the runs it replaced. Defaults to latest"),
+				mcp.Enum("latest", "all"),
+			),
+			mcp.WithNumber("app_id",
+				mcp.Description("Only return check runs created by this GitHub App"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkName, err := OptionalParam[string](request, "check_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			status, err := OptionalParam[string](request, "status")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			appID, err := OptionalIntParam(request, "app_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListCheckRunsOptions{
+				CheckName: ToStringPtr(checkName),
+				Status:    ToStringPtr(status),
+				Filter:    ToStringPtr(filter),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if appID != 0 {
+				opts.AppID = github.Ptr(int64(appID))
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list check runs for %s", ref), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetCheckRunAnnotations creates a tool to list the annotations of a check run.
+func GetCheckRunAnnotations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_check_run_annotations",
+			mcp.WithDescription(t("TOOL_GET_CHECK_RUN_ANNOTATIONS_DESCRIPTION", "List the annotations of a check run: the file, lines, level and message of each problem the check reported. Useful to match failing checks with the lines of a pull request diff.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CHECK_RUN_ANNOTATIONS_USER_TITLE", "Get check run annotations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("check_run_id",
+				mcp.Required(),
+				mcp.Description("ID of the check run"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkRunID, err := RequiredInt(request, "check_run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			annotations, resp, err := client.Checks.ListCheckRunAnnotations(ctx, owner, repo, int64(checkRunID), &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get annotations of check run %d", checkRunID), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(annotations)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListCheckSuites creates a tool to list the check suites of a commit.
+func ListCheckSuites(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_check_suites",
+			mcp.WithDescription(t("TOOL_LIST_CHECK_SUITES_DESCRIPTION", "List the check suites of a commit, branch or tag. Each GitHub App that runs checks on a commit has one suite holding its check runs.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CHECK_SUITES_USER_TITLE", "List check suites"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag name"),
+			),
+			mcp.WithString("check_name",
+				mcp.Description("Only return check suites with a check run of this name"),
+			),
+			mcp.WithNumber("app_id",
+				mcp.Description("Only return check suites of this GitHub App"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkName, err := OptionalParam[string](request, "check_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			appID, err := OptionalIntParam(request, "app_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListCheckSuiteOptions{
+				CheckName: ToStringPtr(checkName),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if appID != 0 {
+				opts.AppID = github.Ptr(appID)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result, resp, err := client.Checks.ListCheckSuitesForRef(ctx, owner, repo, ref, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list check suites for %s", ref), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// RerequestCheckSuite creates a tool to run the checks of a check suite again.
+func RerequestCheckSuite(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rerequest_check_suite",
+			mcp.WithDescription(t("TOOL_REREQUEST_CHECK_SUITE_DESCRIPTION", "Ask the GitHub App of a check suite to run its checks again on the same commit. The app receives a rerequested event and creates new check runs.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REREQUEST_CHECK_SUITE_USER_TITLE", "Re-request check suite"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("check_suite_id",
+				mcp.Required(),
+				mcp.Description("ID of the check suite, as returned by list_check_suites or in the check_suite of a check run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkSuiteID, err := RequiredInt(request, "check_suite_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Checks.ReRequestCheckSuite(ctx, owner, repo, int64(checkSuiteID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to re-request check suite %d", checkSuiteID), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to re-request check suite %d: unexpected status %d", checkSuiteID, resp.StatusCode)), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Check suite %d has been re-requested", checkSuiteID)), nil
+		}
+}
+
+// withCheckRunOutput adds the parameters describing the result of a check run, shared by create_check_run
+// and update_check_run.
+func withCheckRunOutput() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("status",
+			mcp.Description("Status of the check run"),
+			mcp.Enum("queued", "in_progress", "completed"),
+		)(tool)
+		mcp.WithString("conclusion",
+			mcp.Description("Conclusion of the check run, which marks it completed"),
+			mcp.Enum("success", "failure", "neutral", "cancelled", "skipped", "timed_out", "action_required"),
+		)(tool)
+		mcp.WithString("details_url",
+			mcp.Description("URL with the full details of the check"),
+		)(tool)
+		mcp.WithString("external_id",
+			mcp.Description("Reference of the check on the system that runs it"),
+		)(tool)
+		mcp.WithString("title",
+			mcp.Description("Title of the check run output, required with summary when giving any output"),
+		)(tool)
+		mcp.WithString("summary",
+			mcp.Description("Summary of the check run output in Markdown"),
+		)(tool)
+		mcp.WithString("text",
+			mcp.Description("Details of the check run output in Markdown"),
+		)(tool)
+		mcp.WithArray("annotations",
+			mcp.Description(fmt.Sprintf("Up to %d annotations on file lines, each with path, start_line, end_line, annotation_level (notice, warning or failure) and message, optionally title, raw_details, start_column and end_column", maxCheckRunAnnotations)),
+			mcp.Items(map[string]any{"type": "object"}),
+		)(tool)
+	}
+}
+
+// checkRunParams holds the parameters of withCheckRunOutput.
+type checkRunParams struct {
+	status     string
+	conclusion string
+	detailsURL string
+	externalID string
+	output     *github.CheckRunOutput
+}
+
+// checkRunParamsFrom reads and validates the parameters of withCheckRunOutput.
+func checkRunParamsFrom(request mcp.CallToolRequest) (*checkRunParams, error) {
+	params := &checkRunParams{}
+	var err error
+	if params.status, err = OptionalParam[string](request, "status"); err != nil {
+		return nil, err
+	}
+	if params.conclusion, err = OptionalParam[string](request, "conclusion"); err != nil {
+		return nil, err
+	}
+	if params.detailsURL, err = OptionalParam[string](request, "details_url"); err != nil {
+		return nil, err
+	}
+	if params.externalID, err = OptionalParam[string](request, "external_id"); err != nil {
+		return nil, err
+	}
+	if params.status == "completed" && params.conclusion == "" {
+		return nil, fmt.Errorf("a completed check run needs a conclusion")
+	}
+	if params.conclusion != "" && params.status != "" && params.status != "completed" {
+		return nil, fmt.Errorf("a check run with a conclusion is completed, its status can't be %s", params.status)
+	}
+
+	title, err := OptionalParam[string](request, "title")
+	if err != nil {
+		return nil, err
+	}
+	summary, err := OptionalParam[string](request, "summary")
+	if err != nil {
+		return nil, err
+	}
+	text, err := OptionalParam[string](request, "text")
+	if err != nil {
+		return nil, err
+	}
+	var annotations []*github.CheckRunAnnotation
+	if raw, ok := request.GetArguments()["annotations"]; ok {
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid annotations: %w", err)
+		}
+		if err := json.Unmarshal(data, &annotations); err != nil {
+			return nil, fmt.Errorf("invalid annotations: %w", err)
+		}
+		if len(annotations) > maxCheckRunAnnotations {
+			return nil, fmt.Errorf("at most %d annotations can be sent at once, update the check run again with the rest", maxCheckRunAnnotations)
+		}
+		for i, a := range annotations {
+			if a.GetPath() == "" || a.StartLine == nil || a.EndLine == nil || a.GetAnnotationLevel() == "" || a.GetMessage() == "" {
+				return nil, fmt.Errorf("annotation %d needs path, start_line, end_line, annotation_level and message", i)
+			}
+		}
+	}
+	if title != "" || summary != "" || text != "" || len(annotations) > 0 {
+		if title == "" || summary == "" {
+			return nil, fmt.Errorf("the output of a check run needs both title and summary")
+		}
+		params.output = &github.CheckRunOutput{
+			Title:       github.Ptr(title),
+			Summary:     github.Ptr(summary),
+			Text:        ToStringPtr(text),
+			Annotations: annotations,
+		}
+	}
+	return params, nil
+}
+
+// CreateCheckRun creates a tool to create a check run on a commit. Only GitHub Apps can create check runs.
+func CreateCheckRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_check_run",
+			mcp.WithDescription(t("TOOL_CREATE_CHECK_RUN_DESCRIPTION", "Create a check run on a commit, as the GitHub App this server is authenticated as. The check run belongs to the app's check suite for the commit.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_CHECK_RUN_USER_TITLE", "Create check run"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the check"),
+			),
+			mcp.WithString("head_sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit to check"),
+			),
+			withCheckRunOutput(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			headSHA, err := RequiredParam[string](request, "head_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			params, err := checkRunParamsFrom(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := github.CreateCheckRunOptions{
+				Name:       name,
+				HeadSHA:    headSHA,
+				DetailsURL: ToStringPtr(params.detailsURL),
+				ExternalID: ToStringPtr(params.externalID),
+				Status:     ToStringPtr(params.status),
+				Conclusion: ToStringPtr(params.conclusion),
+				Output:     params.output,
+			}
+			now := github.Timestamp{Time: time.Now()}
+			if params.status == "in_progress" {
+				opts.StartedAt = &now
+			}
+			if params.conclusion != "" {
+				opts.CompletedAt = &now
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			checkRun, resp, err := client.Checks.CreateCheckRun(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create check run", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(checkRun)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateCheckRun creates a tool to update a check run. Only the GitHub App that created a check run can update it.
+func UpdateCheckRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_check_run",
+			mcp.WithDescription(t("TOOL_UPDATE_CHECK_RUN_DESCRIPTION", "Update a check run created by the GitHub App this server is authenticated as, for example to complete it with a conclusion. Annotations are added to the ones the check run already has.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_CHECK_RUN_USER_TITLE", "Update check run"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("check_run_id",
+				mcp.Required(),
+				mcp.Description("ID of the check run"),
+			),
+			mcp.WithString("name",
+				mcp.Description("New name of the check"),
+			),
+			withCheckRunOutput(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkRunID, err := RequiredInt(request, "check_run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			params, err := checkRunParamsFrom(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The name is always sent, so keep the current one unless a new one is given
+			if name == "" {
+				current, resp, err := client.Checks.GetCheckRun(ctx, owner, repo, int64(checkRunID))
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get check run %d", checkRunID), resp, err), nil
+				}
+				_ = resp.Body.Close()
+				name = current.GetName()
+			}
+
+			opts := github.UpdateCheckRunOptions{
+				Name:       name,
+				DetailsURL: ToStringPtr(params.detailsURL),
+				ExternalID: ToStringPtr(params.externalID),
+				Status:     ToStringPtr(params.status),
+				Conclusion: ToStringPtr(params.conclusion),
+				Output:     params.output,
+			}
+			if params.conclusion != "" {
+				opts.CompletedAt = &github.Timestamp{Time: time.Now()}
+			}
+
+			checkRun, resp, err := client.Checks.UpdateCheckRun(ctx, owner, repo, int64(checkRunID), opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to update check run %d", checkRunID), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(checkRun)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
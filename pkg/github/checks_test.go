@@ -0,0 +1,435 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCheckRuns(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCheckRuns(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_check_runs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	mockResults := &github.ListCheckRunsResults{
+		Total: github.Ptr(1),
+		CheckRuns: []*github.CheckRun{
+			{ID: github.Ptr(int64(4)), Name: github.Ptr("lint"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "filters check runs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					expectQueryParams(t, map[string]string{
+						"check_name": "lint",
+						"status":     "completed",
+						"filter":     "all",
+						"app_id":     "15368",
+						"page":       "1",
+						"per_page":   "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockResults),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ref":        "abc123",
+				"check_name": "lint",
+				"status":     "completed",
+				"filter":     "all",
+				"app_id":     float64(15368),
+			},
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "No commit found for SHA: nope"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "nope",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list check runs for nope",
+		},
+		{
+			name:         "missing ref",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: ref",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCheckRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.ListCheckRunsResults
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned.CheckRuns, 1)
+			assert.Equal(t, "failure", returned.CheckRuns[0].GetConclusion())
+		})
+	}
+}
+
+func Test_GetCheckRunAnnotations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCheckRunAnnotations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_check_run_annotations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "check_run_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposCheckRunsAnnotationsByOwnerByRepoByCheckRunId,
+			expectPath(t, "/repos/owner/repo/check-runs/4/annotations").andThen(
+				mockResponse(t, http.StatusOK, []*github.CheckRunAnnotation{
+					{
+						Path:            github.Ptr("main.go"),
+						StartLine:       github.Ptr(10),
+						EndLine:         github.Ptr(12),
+						AnnotationLevel: github.Ptr("failure"),
+						Message:         github.Ptr("unused variable"),
+					},
+				}),
+			),
+		),
+	))
+	_, handler := GetCheckRunAnnotations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":        "owner",
+		"repo":         "repo",
+		"check_run_id": float64(4),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*github.CheckRunAnnotation
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 1)
+	assert.Equal(t, "main.go", returned[0].GetPath())
+	assert.Equal(t, 10, returned[0].GetStartLine())
+}
+
+func Test_ListCheckSuites(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCheckSuites(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_check_suites", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsCheckSuitesByOwnerByRepoByRef,
+			expectQueryParams(t, map[string]string{
+				"app_id":   "15368",
+				"page":     "1",
+				"per_page": "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.ListCheckSuiteResults{
+					Total:       github.Ptr(1),
+					CheckSuites: []*github.CheckSuite{{ID: github.Ptr(int64(8)), Conclusion: github.Ptr("failure")}},
+				}),
+			),
+		),
+	))
+	_, handler := ListCheckSuites(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":  "owner",
+		"repo":   "repo",
+		"ref":    "main",
+		"app_id": float64(15368),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned github.ListCheckSuiteResults
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned.CheckSuites, 1)
+	assert.Equal(t, int64(8), returned.CheckSuites[0].GetID())
+}
+
+func Test_RerequestCheckSuite(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RerequestCheckSuite(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "rerequest_check_suite", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "check_suite_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful re-request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckSuitesRerequestByOwnerByRepoByCheckSuiteId,
+					mockResponse(t, http.StatusCreated, map[string]any{}),
+				),
+			),
+		},
+		{
+			name: "re-request fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckSuitesRerequestByOwnerByRepoByCheckSuiteId,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to re-request check suite 8",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RerequestCheckSuite(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"check_suite_id": float64(8),
+			}))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "Check suite 8 has been re-requested", getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_CreateCheckRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCheckRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_check_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name", "head_sha"})
+
+	annotation := map[string]interface{}{
+		"path":             "main.go",
+		"start_line":       float64(3),
+		"end_line":         float64(3),
+		"annotation_level": "warning",
+		"message":          "shadowed err",
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		check          func(t *testing.T, body map[string]any)
+	}{
+		{
+			name: "completed check run with annotations",
+			requestArgs: map[string]interface{}{
+				"conclusion":  "neutral",
+				"title":       "Review",
+				"summary":     "1 warning",
+				"annotations": []interface{}{annotation},
+			},
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "review", body["name"])
+				assert.Equal(t, "abc123", body["head_sha"])
+				assert.Equal(t, "neutral", body["conclusion"])
+				assert.NotEmpty(t, body["completed_at"])
+				assert.Equal(t, map[string]any{
+					"title":       "Review",
+					"summary":     "1 warning",
+					"annotations": []any{map[string]any{"path": "main.go", "start_line": float64(3), "end_line": float64(3), "annotation_level": "warning", "message": "shadowed err"}},
+				}, body["output"])
+			},
+		},
+		{
+			name: "in progress check run",
+			requestArgs: map[string]interface{}{
+				"status": "in_progress",
+			},
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "in_progress", body["status"])
+				assert.NotEmpty(t, body["started_at"])
+				assert.Nil(t, body["output"])
+			},
+		},
+		{
+			name: "completed without conclusion",
+			requestArgs: map[string]interface{}{
+				"status": "completed",
+			},
+			expectError:    true,
+			expectedErrMsg: "a completed check run needs a conclusion",
+		},
+		{
+			name: "annotations without summary",
+			requestArgs: map[string]interface{}{
+				"title":       "Review",
+				"annotations": []interface{}{annotation},
+			},
+			expectError:    true,
+			expectedErrMsg: "the output of a check run needs both title and summary",
+		},
+		{
+			name: "incomplete annotation",
+			requestArgs: map[string]interface{}{
+				"title":       "Review",
+				"summary":     "1 warning",
+				"annotations": []interface{}{map[string]interface{}{"path": "main.go", "message": "shadowed err"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "annotation 0 needs path, start_line, end_line, annotation_level and message",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var body map[string]any
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckRunsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						w.WriteHeader(http.StatusCreated)
+						_, _ = w.Write(mock.MustMarshal(&github.CheckRun{ID: github.Ptr(int64(4)), Name: github.Ptr("review")}))
+					}),
+				),
+			))
+			_, handler := CreateCheckRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"name":     "review",
+				"head_sha": "abc123",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				assert.Nil(t, body)
+				return
+			}
+
+			require.False(t, result.IsError)
+			tc.check(t, body)
+		})
+	}
+}
+
+func Test_UpdateCheckRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateCheckRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_check_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "check_run_id"})
+
+	var body map[string]any
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposCheckRunsByOwnerByRepoByCheckRunId,
+			&github.CheckRun{ID: github.Ptr(int64(4)), Name: github.Ptr("review")},
+		),
+		mock.WithRequestMatchHandler(
+			mock.PatchReposCheckRunsByOwnerByRepoByCheckRunId,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(mock.MustMarshal(&github.CheckRun{ID: github.Ptr(int64(4)), Name: github.Ptr("review"), Conclusion: github.Ptr("success")}))
+			}),
+		),
+	))
+	_, handler := UpdateCheckRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":        "owner",
+		"repo":         "repo",
+		"check_run_id": float64(4),
+		"conclusion":   "success",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	// The current name is kept, as the API always needs one
+	assert.Equal(t, "review", body["name"])
+	assert.Equal(t, "success", body["conclusion"])
+	assert.NotEmpty(t, body["completed_at"])
+
+	var returned github.CheckRun
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "success", returned.GetConclusion())
+}
@@ -170,6 +170,8 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 						"state":     "open",
 						"severity":  "high",
 						"tool_name": "codeql",
+						"page":      "1",
+						"per_page":  "30",
 					}).andThen(
 						mockResponse(t, http.StatusOK, mockAlerts),
 					),
@@ -186,6 +188,28 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 			expectError:    false,
 			expectedAlerts: mockAlerts,
 		},
+		{
+			name: "alerts listing of a later page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningAlertsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "1",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAlerts[1:]),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(1),
+			},
+			expectError:    false,
+			expectedAlerts: mockAlerts[1:],
+		},
 		{
 			name: "alerts listing fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -247,3 +271,203 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListOrgCodeScanningAlerts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgCodeScanningAlerts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_code_scanning_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockAlerts := []*github.Alert{
+		{
+			Number:     github.Ptr(42),
+			State:      github.Ptr("open"),
+			Repository: &github.Repository{FullName: github.Ptr("org/repo")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful organization alerts listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCodeScanningAlertsByOrg,
+					expectQueryParams(t, map[string]string{
+						"state":    "open",
+						"severity": "high",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAlerts),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":      "org",
+				"state":    "open",
+				"severity": "high",
+			},
+		},
+		{
+			name: "organization alerts listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCodeScanningAlertsByOrg,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list alerts for organization 'org'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgCodeScanningAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returnedAlerts []*github.Alert
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedAlerts))
+			require.Len(t, returnedAlerts, 1)
+			assert.Equal(t, "org/repo", returnedAlerts[0].GetRepository().GetFullName())
+		})
+	}
+}
+
+func Test_DismissCodeScanningAlert(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DismissCodeScanningAlert(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "dismiss_code_scanning_alert", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "alertNumber"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedState  string
+	}{
+		{
+			name: "successful dismissal",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningAlertsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state":             "dismissed",
+						"dismissed_reason":  "false positive",
+						"dismissed_comment": "Input is sanitized upstream",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Alert{Number: github.Ptr(42), State: github.Ptr("dismissed")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"reason":      "false positive",
+				"comment":     "Input is sanitized upstream",
+			},
+			expectedState: "dismissed",
+		},
+		{
+			name: "successful reopen",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningAlertsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state": "open",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Alert{Number: github.Ptr(42), State: github.Ptr("open")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"reopen":      true,
+			},
+			expectedState: "open",
+		},
+		{
+			name:         "dismissal without reason",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "reason is required to dismiss an alert",
+		},
+		{
+			name: "dismissal fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningAlertsByOwnerByRepoByAlertNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(9999),
+				"reason":      "won't fix",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update alert with number '9999'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DismissCodeScanningAlert(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returnedAlert github.Alert
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedAlert))
+			assert.Equal(t, tc.expectedState, returnedAlert.GetState())
+		})
+	}
+}
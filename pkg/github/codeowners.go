@@ -0,0 +1,584 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GitHub ignores CODEOWNERS files larger than this.
+const maxCodeownersBytes = 3 * 1024 * 1024
+
+var (
+	codeownersUserPattern  = regexp.MustCompile(`^@[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$`)
+	codeownersTeamPattern  = regexp.MustCompile(`^@([A-Za-z0-9][A-Za-z0-9-]*)/([A-Za-z0-9._-]+)$`)
+	codeownersEmailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// CodeownersValidation is the result of validating a CODEOWNERS file. Errors use the same shape as
+// GitHub's CODEOWNERS errors API so that both sources can be handled alike.
+type CodeownersValidation struct {
+	Valid bool `json:"valid"`
+	// Source is "github" when GitHub validated the committed file and "local" when the content was
+	// validated by the server.
+	Source   string                    `json:"source"`
+	Errors   []*github.CodeownersError `json:"errors"`
+	Warnings []*github.CodeownersError `json:"warnings"`
+}
+
+// ValidateCodeowners creates a tool to check a CODEOWNERS file for mistakes before it is committed.
+func ValidateCodeowners(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("validate_codeowners",
+			mcp.WithDescription(t("TOOL_VALIDATE_CODEOWNERS_DESCRIPTION", "Validate a CODEOWNERS file. When content is given it is checked for unsupported patterns, malformed owners, and owners that don't exist or lack write access to the repository. Without content, GitHub's own validation of the committed CODEOWNERS file is returned. Run this before committing CODEOWNERS changes: invalid lines are silently ignored by GitHub.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_VALIDATE_CODEOWNERS_USER_TITLE", "Validate CODEOWNERS"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("content",
+				mcp.Description("The proposed CODEOWNERS content to validate. If omitted, the committed CODEOWNERS file is validated by GitHub"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit of the committed CODEOWNERS file to validate when content is omitted, defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := OptionalParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var validation *CodeownersValidation
+			if content == "" {
+				codeownersErrors, resp, err := client.Repositories.GetCodeownersErrors(ctx, owner, repo, &github.GetCodeownersErrorsOptions{Ref: ref})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get CODEOWNERS errors (the repository may not have a CODEOWNERS file)",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				validation = &CodeownersValidation{
+					Source:   "github",
+					Errors:   codeownersErrors.Errors,
+					Warnings: []*github.CodeownersError{},
+				}
+			} else {
+				validation = newCodeownersValidator(client, owner, repo).validate(ctx, content)
+			}
+			if validation.Errors == nil {
+				validation.Errors = []*github.CodeownersError{}
+			}
+			validation.Valid = len(validation.Errors) == 0
+
+			r, err := json.Marshal(validation)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+const (
+	// defaultUnownedFiles and maxUnownedFiles bound how many unowned files get_codeowners lists.
+	defaultUnownedFiles = 100
+	maxUnownedFiles     = 1000
+)
+
+// CodeownersMatch is the ownership of a path of the output of get_codeowners. Line and Pattern give the
+// rule deciding it and are left out when no rule matches the path.
+type CodeownersMatch struct {
+	Path    string   `json:"path"`
+	Owners  []string `json:"owners"`
+	Line    int      `json:"line,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+}
+
+// CodeownersReport is the output type of get_codeowners.
+type CodeownersReport struct {
+	// Path is where the CODEOWNERS file GitHub uses was found, SHA the commit it was read at
+	Path   string                    `json:"path"`
+	SHA    string                    `json:"sha"`
+	Rules  int                       `json:"rules"`
+	Valid  bool                      `json:"valid"`
+	Errors []*github.CodeownersError `json:"errors"`
+	Owners []CodeownersMatch         `json:"owners,omitempty"`
+
+	// Unowned lists the files of the ref no rule gives an owner, when requested; UnownedTotal counts
+	// them all and Truncated is set when the listing or GitHub's tree was cut short
+	Unowned      []string `json:"unowned,omitempty"`
+	UnownedTotal int      `json:"unowned_total,omitempty"`
+	Truncated    bool     `json:"truncated,omitempty"`
+}
+
+// GetCodeowners creates a tool to read a repository's CODEOWNERS file, resolve the owners of paths and
+// find the files nobody owns.
+func GetCodeowners(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_codeowners",
+			mcp.WithDescription(t("TOOL_GET_CODEOWNERS_DESCRIPTION", "Get the CODEOWNERS file GitHub uses for a repository at a ref, with its syntax checked, the owners of the given paths and the rule deciding each, and optionally the files nobody owns. Owners are resolved the way GitHub does: the last matching rule wins and a rule without owners leaves files unowned. Use validate_codeowners to also check that the owners exist and have write access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODEOWNERS_USER_TITLE", "Get CODEOWNERS"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Accepts optional git refs such as `refs/heads/{branch}` or `refs/tags/{tag}`, defaults to the default branch"),
+			),
+			mcp.WithArray("paths",
+				mcp.Description("Paths of files or directories to resolve the owners of"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithBoolean("list_unowned",
+				mcp.Description("Also list the files of the ref that no rule gives an owner"),
+			),
+			mcp.WithNumber("max_unowned",
+				mcp.Description(fmt.Sprintf("Maximum number of unowned files to list (max %d)", maxUnownedFiles)),
+				mcp.Min(1),
+				mcp.Max(maxUnownedFiles),
+				mcp.DefaultNumber(defaultUnownedFiles),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paths, err := OptionalStringArrayParam(request, "paths")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			listUnowned, err := OptionalParam[bool](request, "list_unowned")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxUnowned, err := OptionalIntParamWithDefault(request, "max_unowned", defaultUnownedFiles)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxUnowned < 1 || maxUnowned > maxUnownedFiles {
+				return mcp.NewToolResultError(fmt.Sprintf("max_unowned must be between 1 and %d", maxUnownedFiles)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, "")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+			}
+
+			codeownersFile, content, resp, err := getCodeownersContent(ctx, client, owner, repo, rawOpts.SHA)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get CODEOWNERS", resp, err), nil
+			}
+			if codeownersFile == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("%s/%s has no CODEOWNERS file in %s at %s", owner, repo, strings.Join(codeownersPaths, ", "), rawOpts.SHA)), nil
+			}
+
+			rules := parseCodeowners(content)
+			validation := newCodeownersValidator(nil, owner, repo).validate(ctx, content)
+			report := CodeownersReport{
+				Path:   codeownersFile,
+				SHA:    rawOpts.SHA,
+				Rules:  len(rules),
+				Valid:  len(validation.Errors) == 0,
+				Errors: validation.Errors,
+			}
+
+			for _, p := range paths {
+				match := CodeownersMatch{Path: strings.Trim(p, "/"), Owners: []string{}}
+				if rule := codeownersRuleFor(rules, match.Path); rule != nil {
+					match.Owners = append(match.Owners, rule.owners...)
+					match.Line = rule.line
+					match.Pattern = rule.pattern
+				}
+				report.Owners = append(report.Owners, match)
+			}
+
+			if listUnowned {
+				tree, resp, err := client.Git.GetTree(ctx, owner, repo, rawOpts.SHA, true)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get repository tree", resp, err), nil
+				}
+				_ = resp.Body.Close()
+
+				report.Unowned = []string{}
+				report.Truncated = tree.GetTruncated()
+				for _, entry := range tree.Entries {
+					if entry.GetType() != "blob" {
+						continue
+					}
+					if rule := codeownersRuleFor(rules, entry.GetPath()); rule != nil && len(rule.owners) > 0 {
+						continue
+					}
+					report.UnownedTotal++
+					if len(report.Unowned) < maxUnowned {
+						report.Unowned = append(report.Unowned, entry.GetPath())
+					} else {
+						report.Truncated = true
+					}
+				}
+			}
+
+			return MarshalledTextResult(report), nil
+		}
+}
+
+// ownerCheck is the outcome of looking up a single owner, cached because owners repeat across lines.
+type ownerCheck struct {
+	kind    string
+	message string
+	warning bool
+}
+
+// codeownersValidator checks CODEOWNERS content. A validator without a client only checks the syntax.
+type codeownersValidator struct {
+	client *github.Client
+	owner  string
+	repo   string
+	checks map[string]*ownerCheck
+}
+
+func newCodeownersValidator(client *github.Client, owner, repo string) *codeownersValidator {
+	return &codeownersValidator{
+		client: client,
+		owner:  owner,
+		repo:   repo,
+		checks: map[string]*ownerCheck{},
+	}
+}
+
+func (v *codeownersValidator) validate(ctx context.Context, content string) *CodeownersValidation {
+	validation := &CodeownersValidation{
+		Source:   "local",
+		Errors:   []*github.CodeownersError{},
+		Warnings: []*github.CodeownersError{},
+	}
+
+	if len(content) > maxCodeownersBytes {
+		validation.Errors = append(validation.Errors, &github.CodeownersError{
+			Line:    1,
+			Column:  1,
+			Kind:    "File too large",
+			Message: fmt.Sprintf("CODEOWNERS files larger than %d bytes are ignored by GitHub", maxCodeownersBytes),
+		})
+		return validation
+	}
+
+	rules := 0
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		tokens := codeownersFields(line)
+		if len(tokens) == 0 {
+			continue
+		}
+		rules++
+
+		newError := func(column int, kind, message string) *github.CodeownersError {
+			return &github.CodeownersError{
+				Line:    i + 1,
+				Column:  column,
+				Kind:    kind,
+				Source:  line,
+				Message: message,
+			}
+		}
+
+		pattern := tokens[0]
+		switch {
+		case strings.HasPrefix(pattern.text, "!"):
+			validation.Errors = append(validation.Errors, newError(pattern.column, "Invalid pattern", "Negated patterns (starting with !) are not supported in CODEOWNERS"))
+		case strings.Contains(pattern.text, "[") && strings.Contains(pattern.text, "]"):
+			validation.Errors = append(validation.Errors, newError(pattern.column, "Invalid pattern", "Character ranges ([ ]) are not supported in CODEOWNERS"))
+		}
+
+		for _, token := range tokens[1:] {
+			if check := v.checkOwner(ctx, token.text); check != nil {
+				finding := newError(token.column, check.kind, check.message)
+				if check.warning {
+					validation.Warnings = append(validation.Warnings, finding)
+				} else {
+					validation.Errors = append(validation.Errors, finding)
+				}
+			}
+		}
+	}
+
+	if rules == 0 {
+		validation.Warnings = append(validation.Warnings, &github.CodeownersError{
+			Line:    1,
+			Column:  1,
+			Kind:    "No rules",
+			Message: "The file does not contain any ownership rules",
+		})
+	}
+
+	return validation
+}
+
+// checkOwner validates the format of an owner and, where the token permits, that it exists and has
+// write access to the repository. It returns nil for owners without problems.
+func (v *codeownersValidator) checkOwner(ctx context.Context, owner string) *ownerCheck {
+	key := strings.ToLower(owner)
+	if check, ok := v.checks[key]; ok {
+		return check
+	}
+
+	var check *ownerCheck
+	switch {
+	case v.client == nil && (codeownersTeamPattern.MatchString(owner) || codeownersUserPattern.MatchString(owner)):
+		// Without a client only the syntax is checked.
+	case codeownersTeamPattern.MatchString(owner):
+		m := codeownersTeamPattern.FindStringSubmatch(owner)
+		check = v.checkTeam(ctx, owner, m[1], m[2])
+	case codeownersUserPattern.MatchString(owner):
+		check = v.checkUser(ctx, owner, owner[1:])
+	case codeownersEmailPattern.MatchString(owner):
+		// Emails can't be resolved to accounts through the API, GitHub matches them against verified emails.
+	default:
+		check = &ownerCheck{
+			kind:    "Invalid owner",
+			message: fmt.Sprintf("%s is not a valid owner, owners must be a @username, @org/team-name or an email address", owner),
+		}
+	}
+
+	v.checks[key] = check
+	return check
+}
+
+func (v *codeownersValidator) checkUser(ctx context.Context, owner, login string) *ownerCheck {
+	_, resp, err := v.client.Users.Get(ctx, login)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return &ownerCheck{kind: "Unknown owner", message: fmt.Sprintf("Unknown owner on this line: make sure %s exists", owner)}
+		}
+		return &ownerCheck{kind: "Unverified owner", message: fmt.Sprintf("Could not check that %s exists: %s", owner, err), warning: true}
+	}
+
+	permission, resp, err := v.client.Repositories.GetPermissionLevel(ctx, v.owner, v.repo, login)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		return &ownerCheck{kind: "Unverified owner", message: fmt.Sprintf("Could not check the access of %s to the repository, the token may lack permission to read collaborators", owner), warning: true}
+	}
+	switch permission.GetPermission() {
+	case "admin", "maintain", "write":
+		return nil
+	default:
+		return &ownerCheck{kind: "Insufficient permissions", message: fmt.Sprintf("%s does not have write access to %s/%s and will not be requested for review", owner, v.owner, v.repo)}
+	}
+}
+
+func (v *codeownersValidator) checkTeam(ctx context.Context, owner, org, slug string) *ownerCheck {
+	_, resp, err := v.client.Teams.GetTeamBySlug(ctx, org, slug)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return &ownerCheck{kind: "Unknown owner", message: fmt.Sprintf("Unknown owner on this line: make sure the team %s exists, is publicly visible and the token can read it", owner)}
+		}
+		return &ownerCheck{kind: "Unverified owner", message: fmt.Sprintf("Could not check that the team %s exists: %s", owner, err), warning: true}
+	}
+
+	repository, resp, err := v.client.Teams.IsTeamRepoBySlug(ctx, org, slug, v.owner, v.repo)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return &ownerCheck{kind: "Insufficient permissions", message: fmt.Sprintf("The team %s does not have access to %s/%s and will not be requested for review", owner, v.owner, v.repo)}
+		}
+		return &ownerCheck{kind: "Unverified owner", message: fmt.Sprintf("Could not check the access of the team %s to the repository: %s", owner, err), warning: true}
+	}
+	permissions := repository.GetPermissions()
+	if permissions["admin"] || permissions["maintain"] || permissions["push"] {
+		return nil
+	}
+	return &ownerCheck{kind: "Insufficient permissions", message: fmt.Sprintf("The team %s does not have write access to %s/%s and will not be requested for review", owner, v.owner, v.repo)}
+}
+
+type codeownersField struct {
+	text   string
+	column int
+}
+
+// codeownersFields splits a CODEOWNERS line into whitespace separated fields with their 1-based
+// columns, dropping comments. Escaped spaces in patterns are kept as part of the field.
+func codeownersFields(line string) []codeownersField {
+	var fields []codeownersField
+	start := -1
+	escaped := false
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			if start < 0 {
+				start = i
+			}
+			escaped = true
+		case unicode.IsSpace(r):
+			if start >= 0 {
+				fields = append(fields, codeownersField{text: line[start:i], column: start + 1})
+				start = -1
+			}
+		case r == '#' && start < 0:
+			return fields
+		default:
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, codeownersField{text: line[start:], column: start + 1})
+	}
+	return fields
+}
+
+// codeownersPaths are the locations GitHub looks for a CODEOWNERS file, in the order it checks them.
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// getCodeownersContent returns the path and content of the CODEOWNERS file GitHub uses at a ref, or an
+// empty path when the repository has none.
+func getCodeownersContent(ctx context.Context, client *github.Client, owner, repo, ref string) (string, string, *github.Response, error) {
+	for _, path := range codeownersPaths {
+		file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return "", "", resp, err
+		}
+		if file == nil {
+			continue
+		}
+		content, err := file.GetContent()
+		if err != nil {
+			return "", "", nil, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		return path, content, nil, nil
+	}
+	return "", "", nil, nil
+}
+
+// codeownersRule is an ownership rule of a CODEOWNERS file. Rules without owners are valid and make the
+// files they match unowned.
+type codeownersRule struct {
+	pattern string
+	owners  []string
+	line    int
+}
+
+// parseCodeowners returns the rules of a CODEOWNERS file in the order they appear.
+func parseCodeowners(content string) []codeownersRule {
+	var rules []codeownersRule
+	for i, line := range strings.Split(content, "\n") {
+		fields := codeownersFields(strings.TrimSuffix(line, "\r"))
+		if len(fields) == 0 {
+			continue
+		}
+		rule := codeownersRule{pattern: fields[0].text, line: i + 1}
+		for _, field := range fields[1:] {
+			rule.owners = append(rule.owners, field.text)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// codeownersPatternMatch reports whether a CODEOWNERS pattern matches a file path. Patterns follow the
+// gitignore rules GitHub documents: a pattern without a slash matches at any depth, one with a leading or
+// inner slash is relative to the repository root, and a directory pattern matches everything below it.
+// Unlike gitignore, a pattern ending in a wildcard such as docs/* only matches the direct children.
+func codeownersPatternMatch(pattern, name string) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" {
+		return false
+	}
+	segments := strings.Split(trimmed, "/")
+	if !strings.HasPrefix(pattern, "/") && len(segments) == 1 {
+		segments = append([]string{"**"}, segments...)
+	}
+	last := segments[len(segments)-1]
+	switch {
+	case dirOnly:
+		segments = append(segments, "*", "**")
+	case !strings.ContainsAny(last, "*?"):
+		segments = append(segments, "**")
+	}
+	return matchGlobSegments(segments, strings.Split(name, "/"))
+}
+
+// codeownersRuleFor returns the rule that decides who owns a file, the last one matching it, or nil.
+func codeownersRuleFor(rules []codeownersRule, name string) *codeownersRule {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if codeownersPatternMatch(rules[i].pattern, name) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,402 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidateCodeowners(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ValidateCodeowners(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "validate_codeowners", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedValid    bool
+		expectedSource   string
+		expectedErrors   []github.CodeownersError
+		expectedWarnings []string
+		expectedErrMsg   string
+	}{
+		{
+			name: "validates committed file with GitHub",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeownersErrorsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"ref": "main"}).andThen(
+						mockResponse(t, http.StatusOK, &github.CodeownersErrors{
+							Errors: []*github.CodeownersError{
+								{Line: 3, Column: 1, Kind: "Invalid pattern", Path: ".github/CODEOWNERS"},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectedValid:  false,
+			expectedSource: "github",
+			expectedErrors: []github.CodeownersError{{Line: 3, Column: 1, Kind: "Invalid pattern"}},
+		},
+		{
+			name: "valid content",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					&github.User{Login: github.Ptr("octocat")},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCollaboratorsPermissionByOwnerByRepoByUsername,
+					&github.RepositoryPermissionLevel{Permission: github.Ptr("write")},
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsTeamsByOrgByTeamSlug,
+					&github.Team{Slug: github.Ptr("docs")},
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					&github.Repository{Permissions: map[string]bool{"pull": true, "push": true}},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"content": "# Owners\n*       @octocat\n/docs/ @owner/docs docs@example.com # docs team\n*.go @octocat\n",
+			},
+			expectedValid:  true,
+			expectedSource: "local",
+			expectedErrors: []github.CodeownersError{},
+		},
+		{
+			name: "invalid patterns and owners",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersByUsername,
+					notFound,
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsTeamsByOrgByTeamSlug,
+					&github.Team{Slug: github.Ptr("readers")},
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					&github.Repository{Permissions: map[string]bool{"pull": true}},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"content": "!/vendor/ @owner/readers\n/src/[ab]/ @ghost\n*.md octocat\n",
+			},
+			expectedValid:  false,
+			expectedSource: "local",
+			expectedErrors: []github.CodeownersError{
+				{Line: 1, Column: 1, Kind: "Invalid pattern"},
+				{Line: 1, Column: 11, Kind: "Insufficient permissions"},
+				{Line: 2, Column: 1, Kind: "Invalid pattern"},
+				{Line: 2, Column: 12, Kind: "Unknown owner"},
+				{Line: 3, Column: 6, Kind: "Invalid owner"},
+			},
+		},
+		{
+			name: "permission check not allowed produces warning",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					&github.User{Login: github.Ptr("octocat")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsPermissionByOwnerByRepoByUsername,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must have push access to view repository collaborators."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"content": "* @octocat\n",
+			},
+			expectedValid:    true,
+			expectedSource:   "local",
+			expectedErrors:   []github.CodeownersError{},
+			expectedWarnings: []string{"Unverified owner"},
+		},
+		{
+			name: "no CODEOWNERS file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeownersErrorsByOwnerByRepo,
+					notFound,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get CODEOWNERS errors",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ValidateCodeowners(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned CodeownersValidation
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedValid, returned.Valid)
+			assert.Equal(t, tc.expectedSource, returned.Source)
+
+			require.Len(t, returned.Errors, len(tc.expectedErrors))
+			for i, expected := range tc.expectedErrors {
+				assert.Equal(t, expected.Line, returned.Errors[i].Line)
+				assert.Equal(t, expected.Column, returned.Errors[i].Column)
+				assert.Equal(t, expected.Kind, returned.Errors[i].Kind)
+			}
+
+			kinds := make([]string, 0, len(returned.Warnings))
+			for _, w := range returned.Warnings {
+				kinds = append(kinds, w.Kind)
+			}
+			if tc.expectedWarnings == nil {
+				tc.expectedWarnings = []string{}
+			}
+			assert.Equal(t, tc.expectedWarnings, kinds)
+		})
+	}
+}
+
+func Test_CodeownersPatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		match   bool
+	}{
+		{"*", "any/file.go", true},
+		{"*.js", "web/app.js", true},
+		{"*.js", "web/app.ts", false},
+		{"/build/logs/", "build/logs/today.log", true},
+		{"/build/logs/", "src/build/logs/today.log", false},
+		{"docs/*", "docs/getting-started.md", true},
+		{"docs/*", "docs/build-app/troubleshooting.md", false},
+		{"apps/", "services/apps/main.go", true},
+		{"apps/", "apps", false},
+		{"/docs", "docs/index.md", true},
+		{"/docs", "docs", true},
+		{"**/logs", "deep/down/logs/today.log", true},
+		{"/scripts/", "scripts/build.sh", true},
+		{"src/*.go", "src/main.go", true},
+		{"src/*.go", "lib/src/main.go", false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.match, codeownersPatternMatch(tc.pattern, tc.name), "%s against %s", tc.pattern, tc.name)
+	}
+
+	rules := parseCodeowners("# Owners\n*       @octocat\n/docs/  @org/docs-team docs@example.com\n/docs/generated/\n")
+	require.Len(t, rules, 3)
+	assert.Equal(t, codeownersRule{pattern: "/docs/", owners: []string{"@org/docs-team", "docs@example.com"}, line: 3}, rules[1])
+	assert.Equal(t, 2, codeownersRuleFor(rules, "main.go").line)
+	assert.Equal(t, 3, codeownersRuleFor(rules, "docs/index.md").line)
+	assert.Empty(t, codeownersRuleFor(rules, "docs/generated/api.md").owners)
+}
+
+func Test_GetCodeowners(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodeowners(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_codeowners", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "paths")
+	assert.Contains(t, tool.InputSchema.Properties, "list_unowned")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	content := "*.go @octocat\n/docs/ @org/docs\n/docs/generated/\n!/vendor/ @octocat\n"
+	codeownersHandler := func(path string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/repos/owner/repo/contents/"+path {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			assert.Equal(t, "abc123", r.URL.Query().Get("ref"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(mock.MustMarshal(&github.RepositoryContent{
+				Type:     github.Ptr("file"),
+				Path:     github.Ptr(path),
+				Encoding: github.Ptr("base64"),
+				Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+			}))
+		}
+	}
+	ref := &github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr("abc123")}}
+	tree := &github.Tree{
+		SHA: github.Ptr("abc123"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("main.go"), Type: github.Ptr("blob")},
+			{Path: github.Ptr("README.md"), Type: github.Ptr("blob")},
+			{Path: github.Ptr("docs"), Type: github.Ptr("tree")},
+			{Path: github.Ptr("docs/index.md"), Type: github.Ptr("blob")},
+			{Path: github.Ptr("docs/generated/api.md"), Type: github.Ptr("blob")},
+			{Path: github.Ptr("docs/generated/cli.md"), Type: github.Ptr("blob")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedReport CodeownersReport
+	}{
+		{
+			name: "owners of paths",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, ref),
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, codeownersHandler(".github/CODEOWNERS")),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/main",
+				"paths": []any{"cmd/main.go", "/docs/index.md", "docs/generated/api.md", "README.md"},
+			},
+			expectedReport: CodeownersReport{
+				Path:  ".github/CODEOWNERS",
+				SHA:   "abc123",
+				Rules: 4,
+				Errors: []*github.CodeownersError{{
+					Line:    4,
+					Column:  1,
+					Kind:    "Invalid pattern",
+					Source:  "!/vendor/ @octocat",
+					Message: "Negated patterns (starting with !) are not supported in CODEOWNERS",
+				}},
+				Owners: []CodeownersMatch{
+					{Path: "cmd/main.go", Owners: []string{"@octocat"}, Line: 1, Pattern: "*.go"},
+					{Path: "docs/index.md", Owners: []string{"@org/docs"}, Line: 2, Pattern: "/docs/"},
+					{Path: "docs/generated/api.md", Owners: []string{}, Line: 3, Pattern: "/docs/generated/"},
+					{Path: "README.md", Owners: []string{}},
+				},
+			},
+		},
+		{
+			name: "unowned files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, ref),
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, codeownersHandler("docs/CODEOWNERS")),
+				mock.WithRequestMatch(mock.GetReposGitTreesByOwnerByRepoByTreeSha, tree),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"ref":          "refs/heads/main",
+				"list_unowned": true,
+				"max_unowned":  float64(2),
+			},
+			expectedReport: CodeownersReport{
+				Path:  "docs/CODEOWNERS",
+				SHA:   "abc123",
+				Rules: 4,
+				Errors: []*github.CodeownersError{{
+					Line:    4,
+					Column:  1,
+					Kind:    "Invalid pattern",
+					Source:  "!/vendor/ @octocat",
+					Message: "Negated patterns (starting with !) are not supported in CODEOWNERS",
+				}},
+				Unowned:      []string{"README.md", "docs/generated/api.md"},
+				UnownedTotal: 3,
+				Truncated:    true,
+			},
+		},
+		{
+			name: "no CODEOWNERS file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, ref),
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, codeownersHandler("OWNERS")),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/main",
+			},
+			expectError:    true,
+			expectedErrMsg: "owner/repo has no CODEOWNERS file",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCodeowners(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var report CodeownersReport
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+			assert.Equal(t, tc.expectedReport, report)
+		})
+	}
+}
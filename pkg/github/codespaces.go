@@ -0,0 +1,451 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// CodespaceSummary is the part of a codespace needed to pick and connect to it.
+type CodespaceSummary struct {
+	Name               string            `json:"name"`
+	DisplayName        string            `json:"display_name,omitempty"`
+	Repository         string            `json:"repository,omitempty"`
+	Ref                string            `json:"ref,omitempty"`
+	Machine            string            `json:"machine,omitempty"`
+	State              string            `json:"state"`
+	WebURL             string            `json:"web_url,omitempty"`
+	PendingOperation   bool              `json:"pending_operation,omitempty"`
+	IdleTimeoutMinutes int               `json:"idle_timeout_minutes,omitempty"`
+	CreatedAt          *github.Timestamp `json:"created_at,omitempty"`
+	LastUsedAt         *github.Timestamp `json:"last_used_at,omitempty"`
+}
+
+func newCodespaceSummary(codespace *github.Codespace) CodespaceSummary {
+	return CodespaceSummary{
+		Name:               codespace.GetName(),
+		DisplayName:        codespace.GetDisplayName(),
+		Repository:         codespace.GetRepository().GetFullName(),
+		Ref:                codespace.GetGitStatus().GetRef(),
+		Machine:            codespace.GetMachine().GetName(),
+		State:              codespace.GetState(),
+		WebURL:             codespace.GetWebURL(),
+		PendingOperation:   codespace.GetPendingOperation(),
+		IdleTimeoutMinutes: codespace.GetIdleTimeoutMinutes(),
+		CreatedAt:          codespace.CreatedAt,
+		LastUsedAt:         codespace.LastUsedAt,
+	}
+}
+
+// acceptedCodespace reads the codespace GitHub returns along with 202 Accepted while it is still being set up.
+func acceptedCodespace(err error) (*github.Codespace, bool) {
+	var acceptedError *github.AcceptedError
+	if !errors.As(err, &acceptedError) {
+		return nil, false
+	}
+	codespace := new(github.Codespace)
+	if len(acceptedError.Raw) > 0 {
+		if err := json.Unmarshal(acceptedError.Raw, codespace); err != nil {
+			return nil, false
+		}
+	}
+	return codespace, true
+}
+
+// getCodespace fetches a codespace of the authenticated user, which go-github has no method for.
+func getCodespace(ctx context.Context, client *github.Client, name string) (*github.Codespace, *github.Response, error) {
+	req, err := client.NewRequest(http.MethodGet, "user/codespaces/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	codespace := new(github.Codespace)
+	resp, err := client.Do(ctx, req, codespace)
+	if err != nil {
+		return nil, resp, err
+	}
+	return codespace, resp, nil
+}
+
+// withCodespaceName adds the codespace_name parameter.
+func withCodespaceName() mcp.ToolOption {
+	return mcp.WithString("codespace_name",
+		mcp.Required(),
+		mcp.Description("Name of the codespace, as returned by list_codespaces"),
+	)
+}
+
+// ListCodespaces creates a tool to list the codespaces of the authenticated user.
+func ListCodespaces(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_codespaces",
+			mcp.WithDescription(t("TOOL_LIST_CODESPACES_DESCRIPTION", "List the codespaces of the authenticated user with their repository, branch, machine type, state and web URL. Give owner and repo to only list the codespaces of that repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CODESPACES_USER_TITLE", "List codespaces"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Description("Repository owner, together with repo"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name, together with owner"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (owner == "") != (repo == "") {
+				return mcp.NewToolResultError("owner and repo must be given together"), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			}
+			var codespaces *github.ListCodespaces
+			var resp *github.Response
+			if repo == "" {
+				codespaces, resp, err = client.Codespaces.List(ctx, &github.ListCodespacesOptions{ListOptions: opts})
+			} else {
+				codespaces, resp, err = client.Codespaces.ListInRepo(ctx, owner, repo, &opts)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list codespaces", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			summaries := make([]CodespaceSummary, 0, len(codespaces.Codespaces))
+			for _, codespace := range codespaces.Codespaces {
+				summaries = append(summaries, newCodespaceSummary(codespace))
+			}
+			return MarshalledTextResult(map[string]any{
+				"total_count": codespaces.GetTotalCount(),
+				"codespaces":  summaries,
+			}), nil
+		}
+}
+
+// ListCodespaceMachineTypes creates a tool to list the machine types a codespace of a repository can use.
+func ListCodespaceMachineTypes(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_codespace_machine_types",
+			mcp.WithDescription(t("TOOL_LIST_CODESPACE_MACHINE_TYPES_DESCRIPTION", "List the machine types available to codespaces of a repository, with their cores, memory, storage and whether a prebuild is ready for the ref. Use the name as machine in create_codespace.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CODESPACE_MACHINE_TYPES_USER_TITLE", "List codespace machine types"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch or tag to check prebuild availability for, defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			path := fmt.Sprintf("repos/%s/%s/codespaces/machines", owner, repo)
+			if ref != "" {
+				path += "?ref=" + url.QueryEscape(ref)
+			}
+			req, err := client.NewRequest(http.MethodGet, path, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var machines struct {
+				Machines []*github.CodespacesMachine `json:"machines"`
+			}
+			resp, err := client.Do(ctx, req, &machines)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list codespace machine types of %s/%s", owner, repo), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(machines.Machines), nil
+		}
+}
+
+// CreateCodespace creates a tool to create a codespace for a repository.
+func CreateCodespace(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_codespace",
+			mcp.WithDescription(t("TOOL_CREATE_CODESPACE_DESCRIPTION", "Create a codespace for a repository branch, billed to the authenticated user or the organization that pays for the repository's codespaces. The codespace may still be provisioning when this returns; check its state with get_codespace.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_CODESPACE_USER_TITLE", "Create codespace"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch to open the codespace on, defaults to the default branch"),
+			),
+			mcp.WithString("machine",
+				mcp.Description("Machine type, as returned by list_codespace_machine_types. Defaults to the smallest available"),
+			),
+			mcp.WithString("devcontainer_path",
+				mcp.Description("Path of the devcontainer.json to use, defaults to the repository's default configuration"),
+			),
+			mcp.WithString("display_name",
+				mcp.Description("Display name of the codespace"),
+			),
+			mcp.WithString("geo",
+				mcp.Description("Geographic area to create the codespace in, defaults to the one closest to GitHub's view of the client"),
+				mcp.Enum("EuropeWest", "SoutheastAsia", "UsEast", "UsWest"),
+			),
+			mcp.WithNumber("idle_timeout_minutes",
+				mcp.Description("Minutes of inactivity after which the codespace stops"),
+				mcp.Min(5),
+				mcp.Max(240),
+			),
+			mcp.WithNumber("retention_period_minutes",
+				mcp.Description("Minutes after stopping after which the codespace is deleted, up to 30 days"),
+				mcp.Min(0),
+				mcp.Max(43200),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts := &github.CreateCodespaceOptions{}
+			for field, target := range map[string]**string{
+				"ref":               &opts.Ref,
+				"machine":           &opts.Machine,
+				"devcontainer_path": &opts.DevcontainerPath,
+				"display_name":      &opts.DisplayName,
+				"geo":               &opts.Geo,
+			} {
+				value, err := OptionalParam[string](request, field)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				*target = ToStringPtr(value)
+			}
+			for field, target := range map[string]**int{
+				"idle_timeout_minutes":     &opts.IdleTimeoutMinutes,
+				"retention_period_minutes": &opts.RetentionPeriodMinutes,
+			} {
+				if _, ok := request.GetArguments()[field]; !ok {
+					continue
+				}
+				value, err := OptionalIntParam(request, field)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				*target = github.Ptr(value)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			codespace, resp, err := client.Codespaces.CreateInRepo(ctx, owner, repo, opts)
+			if err != nil {
+				// GitHub answers 202 Accepted when the codespace is queued for creation
+				accepted, ok := acceptedCodespace(err)
+				if !ok {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to create codespace for %s/%s", owner, repo), resp, err), nil
+				}
+				codespace = accepted
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			return MarshalledTextResult(newCodespaceSummary(codespace)), nil
+		}
+}
+
+// GetCodespace creates a tool to get the state and connection URL of a codespace.
+func GetCodespace(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_codespace",
+			mcp.WithDescription(t("TOOL_GET_CODESPACE_DESCRIPTION", "Get the state of a codespace of the authenticated user and the web URL to connect to it in the browser. The URL only works once the state is Available; start the codespace first if it is Shutdown.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODESPACE_USER_TITLE", "Get codespace"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withCodespaceName(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := RequiredParam[string](request, "codespace_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			codespace, resp, err := getCodespace(ctx, client, name)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get codespace %s", name), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(newCodespaceSummary(codespace)), nil
+		}
+}
+
+// StartCodespace creates a tool to start a stopped codespace.
+func StartCodespace(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("start_codespace",
+			mcp.WithDescription(t("TOOL_START_CODESPACE_DESCRIPTION", "Start a stopped codespace of the authenticated user. Starting takes a while; poll get_codespace until the state is Available.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_START_CODESPACE_USER_TITLE", "Start codespace"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withCodespaceName(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := RequiredParam[string](request, "codespace_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			codespace, resp, err := client.Codespaces.Start(ctx, name)
+			if err != nil {
+				accepted, ok := acceptedCodespace(err)
+				if !ok {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to start codespace %s", name), resp, err), nil
+				}
+				codespace = accepted
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			return MarshalledTextResult(newCodespaceSummary(codespace)), nil
+		}
+}
+
+// StopCodespace creates a tool to stop a running codespace.
+func StopCodespace(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("stop_codespace",
+			mcp.WithDescription(t("TOOL_STOP_CODESPACE_DESCRIPTION", "Stop a running codespace of the authenticated user. Its files are kept until it is deleted or its retention period runs out.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_STOP_CODESPACE_USER_TITLE", "Stop codespace"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withCodespaceName(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := RequiredParam[string](request, "codespace_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			codespace, resp, err := client.Codespaces.Stop(ctx, name)
+			if err != nil {
+				accepted, ok := acceptedCodespace(err)
+				if !ok {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to stop codespace %s", name), resp, err), nil
+				}
+				codespace = accepted
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			return MarshalledTextResult(newCodespaceSummary(codespace)), nil
+		}
+}
+
+// DeleteCodespace creates a tool to delete a codespace.
+func DeleteCodespace(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_codespace",
+			mcp.WithDescription(t("TOOL_DELETE_CODESPACE_DESCRIPTION", "Delete a codespace of the authenticated user, discarding any changes that were not pushed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_CODESPACE_USER_TITLE", "Delete codespace"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			withCodespaceName(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := RequiredParam[string](request, "codespace_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Codespaces.Delete(ctx, name)
+			// GitHub answers 202 Accepted as the deletion is queued
+			if err != nil && !isAcceptedError(err) {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to delete codespace %s", name), resp, err), nil
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("deleted codespace %s", name)), nil
+		}
+}
@@ -0,0 +1,417 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCodespace = &github.Codespace{
+	Name:       github.Ptr("octocat-fluffy-space-7x9q"),
+	State:      github.Ptr("Available"),
+	WebURL:     github.Ptr("https://octocat-fluffy-space-7x9q.github.dev"),
+	Repository: &github.Repository{FullName: github.Ptr("owner/repo")},
+	GitStatus:  &github.CodespacesGitStatus{Ref: github.Ptr("main")},
+	Machine:    &github.CodespacesMachine{Name: github.Ptr("basicLinux32gb")},
+}
+
+func Test_ListCodespaces(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCodespaces(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_codespaces", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	listed := &github.ListCodespaces{
+		TotalCount: github.Ptr(1),
+		Codespaces: []*github.Codespace{testCodespace},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "all codespaces of the user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserCodespaces,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, listed),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{},
+		},
+		{
+			name: "codespaces of a repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodespacesByOwnerByRepo,
+					expectPath(t, "/repos/owner/repo/codespaces").andThen(
+						mockResponse(t, http.StatusOK, listed),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+		},
+		{
+			name:         "owner without repo",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+			},
+			expectError:    true,
+			expectedErrMsg: "owner and repo must be given together",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCodespaces(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned struct {
+				TotalCount int                `json:"total_count"`
+				Codespaces []CodespaceSummary `json:"codespaces"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, 1, returned.TotalCount)
+			require.Len(t, returned.Codespaces, 1)
+			assert.Equal(t, CodespaceSummary{
+				Name:       "octocat-fluffy-space-7x9q",
+				Repository: "owner/repo",
+				Ref:        "main",
+				Machine:    "basicLinux32gb",
+				State:      "Available",
+				WebURL:     "https://octocat-fluffy-space-7x9q.github.dev",
+			}, returned.Codespaces[0])
+		})
+	}
+}
+
+func Test_ListCodespaceMachineTypes(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCodespaceMachineTypes(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_codespace_machine_types", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposCodespacesMachinesByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"ref": "feature/x",
+			}).andThen(
+				mockResponse(t, http.StatusOK, map[string]any{
+					"total_count": 1,
+					"machines": []*github.CodespacesMachine{
+						{Name: github.Ptr("standardLinux32gb"), CPUs: github.Ptr(4), PrebuildAvailability: github.Ptr("ready")},
+					},
+				}),
+			),
+		),
+	))
+	_, handler := ListCodespaceMachineTypes(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"ref":   "feature/x",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*github.CodespacesMachine
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 1)
+	assert.Equal(t, "standardLinux32gb", returned[0].GetName())
+	assert.Equal(t, 4, returned[0].GetCPUs())
+}
+
+func Test_CreateCodespace(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCodespace(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_codespace", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedState  string
+	}{
+		{
+			name: "codespace created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCodespacesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"ref":                  "main",
+						"machine":              "basicLinux32gb",
+						"idle_timeout_minutes": float64(30),
+					}).andThen(
+						mockResponse(t, http.StatusCreated, testCodespace),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"ref":                  "main",
+				"machine":              "basicLinux32gb",
+				"idle_timeout_minutes": float64(30),
+			},
+			expectedState: "Available",
+		},
+		{
+			name: "codespace queued",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCodespacesByOwnerByRepo,
+					mockResponse(t, http.StatusAccepted, &github.Codespace{
+						Name:  github.Ptr("octocat-fluffy-space-7x9q"),
+						State: github.Ptr("Queued"),
+					}),
+				),
+			),
+			requestArgs:   map[string]interface{}{},
+			expectedState: "Queued",
+		},
+		{
+			name: "codespaces not allowed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCodespacesByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Codespaces are not enabled"}`),
+				),
+			),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "failed to create codespace for owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCodespace(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned CodespaceSummary
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "octocat-fluffy-space-7x9q", returned.Name)
+			assert.Equal(t, tc.expectedState, returned.State)
+		})
+	}
+}
+
+func Test_GetCodespace(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodespace(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_codespace", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"codespace_name"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetUserCodespacesByCodespaceName,
+			expectPath(t, "/user/codespaces/octocat-fluffy-space-7x9q").andThen(
+				mockResponse(t, http.StatusOK, testCodespace),
+			),
+		),
+	))
+	_, handler := GetCodespace(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"codespace_name": "octocat-fluffy-space-7x9q",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned CodespaceSummary
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "https://octocat-fluffy-space-7x9q.github.dev", returned.WebURL)
+}
+
+func Test_StartCodespace(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := StartCodespace(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "start_codespace", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"codespace_name"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostUserCodespacesStartByCodespaceName,
+			mockResponse(t, http.StatusOK, &github.Codespace{
+				Name:  github.Ptr("octocat-fluffy-space-7x9q"),
+				State: github.Ptr("Starting"),
+			}),
+		),
+	))
+	_, handler := StartCodespace(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"codespace_name": "octocat-fluffy-space-7x9q",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned CodespaceSummary
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "Starting", returned.State)
+}
+
+func Test_StopCodespace(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := StopCodespace(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "stop_codespace", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"codespace_name"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "codespace stopped",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostUserCodespacesStopByCodespaceName,
+					mockResponse(t, http.StatusOK, &github.Codespace{
+						Name:  github.Ptr("octocat-fluffy-space-7x9q"),
+						State: github.Ptr("ShuttingDown"),
+					}),
+				),
+			),
+		},
+		{
+			name: "codespace not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostUserCodespacesStopByCodespaceName,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to stop codespace octocat-fluffy-space-7x9q",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := StopCodespace(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"codespace_name": "octocat-fluffy-space-7x9q",
+			}))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned CodespaceSummary
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "ShuttingDown", returned.State)
+		})
+	}
+}
+
+func Test_DeleteCodespace(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteCodespace(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_codespace", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"codespace_name"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteUserCodespacesByCodespaceName,
+			expectPath(t, "/user/codespaces/octocat-fluffy-space-7x9q").andThen(
+				mockResponse(t, http.StatusAccepted, `{}`),
+			),
+		),
+	))
+	_, handler := DeleteCodespace(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"codespace_name": "octocat-fluffy-space-7x9q",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "deleted codespace octocat-fluffy-space-7x9q", getTextResult(t, result).Text)
+}
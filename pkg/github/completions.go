@@ -0,0 +1,333 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// methodCompletionComplete is the MCP method of argument completion, which the MCP server library doesn't
+// define.
+const methodCompletionComplete = "completion/complete"
+
+const (
+	// completionCacheTTL is how long the lists that completions are drawn from are kept.
+	completionCacheTTL = 5 * time.Minute
+	// maxCompletionValues is the most values a completion may return.
+	maxCompletionValues = 100
+	// maxCompletionPages caps the pages of 100 entries fetched for one list.
+	maxCompletionPages = 10
+)
+
+// completionEntry is a cached list of candidate values.
+type completionEntry struct {
+	values  []string
+	expires time.Time
+}
+
+// ArgumentCompletions implements completion/complete for the owner, repo, branch, tag and label arguments
+// of prompts and resource templates. The MCP server library doesn't dispatch this method, so transports
+// pass messages through HandleMessage first. The candidates are the repositories of the authenticated
+// user and the branches, tags and labels of a repository, cached per session for completionCacheTTL.
+type ArgumentCompletions struct {
+	getClient GetClientFn
+
+	mu sync.Mutex
+	// cache is keyed by session ID, then by list
+	cache map[string]map[string]completionEntry
+}
+
+// NewArgumentCompletions creates the completions of a server.
+func NewArgumentCompletions(getClient GetClientFn) *ArgumentCompletions {
+	return &ArgumentCompletions{
+		getClient: getClient,
+		cache:     make(map[string]map[string]completionEntry),
+	}
+}
+
+// completionRequest is a JSON-RPC request of the completion method. mcp.CompleteParams lacks the
+// arguments already filled in, so the request is read here.
+type completionRequest struct {
+	ID     *mcp.RequestId `json:"id"`
+	Method string         `json:"method"`
+	Params struct {
+		Argument struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"argument"`
+		Context struct {
+			Arguments map[string]string `json:"arguments"`
+		} `json:"context"`
+	} `json:"params"`
+}
+
+// HandleMessage answers the message if it is a completion request of the session. It reports false for
+// any other message, which the transport then hands to the MCP server.
+func (c *ArgumentCompletions) HandleMessage(ctx context.Context, sessionID string, message json.RawMessage) (mcp.JSONRPCMessage, bool) {
+	var request completionRequest
+	if err := json.Unmarshal(message, &request); err != nil || request.ID == nil || request.Method != methodCompletionComplete {
+		return nil, false
+	}
+
+	result, err := c.Complete(ctx, sessionID, request.Params.Argument.Name, request.Params.Argument.Value, request.Params.Context.Arguments)
+	if err != nil {
+		response := mcp.JSONRPCError{JSONRPC: mcp.JSONRPC_VERSION, ID: *request.ID}
+		response.Error.Code = mcp.INTERNAL_ERROR
+		response.Error.Message = err.Error()
+		return response, true
+	}
+	return mcp.JSONRPCResponse{JSONRPC: mcp.JSONRPC_VERSION, ID: *request.ID, Result: result}, true
+}
+
+// Complete returns the values of an argument that match what was typed so far. arguments holds the
+// arguments filled in before, which name the owner and repository that branches, tags and labels belong
+// to. Arguments that can't be completed get no values.
+func (c *ArgumentCompletions) Complete(ctx context.Context, sessionID, argument, value string, arguments map[string]string) (*mcp.CompleteResult, error) {
+	owner, repo := arguments["owner"], arguments["repo"]
+
+	var candidates []string
+	var err error
+	switch argument {
+	case "owner":
+		candidates, err = c.list(ctx, sessionID, "owners", c.listOwners)
+	case "repo":
+		candidates, err = c.list(ctx, sessionID, "repos:"+owner, func(ctx context.Context, client *github.Client) ([]string, error) {
+			return listRepositoryNames(ctx, client, owner)
+		})
+	case "branch", "tag", "label", "labels":
+		if owner == "" || repo == "" {
+			break
+		}
+		kind := strings.TrimSuffix(argument, "s")
+		candidates, err = c.list(ctx, sessionID, kind+":"+owner+"/"+repo, func(ctx context.Context, client *github.Client) ([]string, error) {
+			return listRepositoryRefsOrLabels(ctx, client, kind, owner, repo)
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// A list of labels is completed at its last entry
+	prefix := ""
+	if argument == "labels" {
+		if i := strings.LastIndex(value, ","); i >= 0 {
+			prefix, value = value[:i+1], strings.TrimLeft(value[i+1:], " ")
+		}
+	}
+
+	matches := matchCompletions(candidates, value)
+	result := &mcp.CompleteResult{}
+	result.Completion.Values = make([]string, 0, min(len(matches), maxCompletionValues))
+	for _, match := range matches[:min(len(matches), maxCompletionValues)] {
+		result.Completion.Values = append(result.Completion.Values, prefix+match)
+	}
+	result.Completion.Total = len(matches)
+	result.Completion.HasMore = len(matches) > maxCompletionValues
+	return result, nil
+}
+
+// matchCompletions returns the candidates that start with value, then the ones that contain it, ignoring
+// case.
+func matchCompletions(candidates []string, value string) []string {
+	value = strings.ToLower(value)
+	var prefixed, contained []string
+	for _, candidate := range candidates {
+		lower := strings.ToLower(candidate)
+		switch {
+		case strings.HasPrefix(lower, value):
+			prefixed = append(prefixed, candidate)
+		case strings.Contains(lower, value):
+			contained = append(contained, candidate)
+		}
+	}
+	return append(prefixed, contained...)
+}
+
+// RemoveSession drops the cached lists of a session.
+func (c *ArgumentCompletions) RemoveSession(sessionID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.cache, sessionID)
+}
+
+// list returns the cached list of the session, fetching it when it is missing or expired.
+func (c *ArgumentCompletions) list(ctx context.Context, sessionID, key string, fetch func(context.Context, *github.Client) ([]string, error)) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.cache[sessionID][key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.values, nil
+	}
+
+	client, err := c.getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+	values, err := fetch(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cache[sessionID] == nil {
+		c.cache[sessionID] = make(map[string]completionEntry)
+	}
+	c.cache[sessionID][key] = completionEntry{values: values, expires: time.Now().Add(completionCacheTTL)}
+	return values, nil
+}
+
+// listOwners returns the organizations of the authenticated user and the owners of the repositories
+// they can access.
+func (c *ArgumentCompletions) listOwners(ctx context.Context, client *github.Client) ([]string, error) {
+	owners := map[string]bool{}
+	err := listCompletionPages(func(opts github.ListOptions) (*github.Response, error) {
+		orgs, resp, err := client.Organizations.List(ctx, "", &opts)
+		for _, org := range orgs {
+			owners[org.GetLogin()] = true
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	err = listCompletionPages(func(opts github.ListOptions) (*github.Response, error) {
+		repos, resp, err := client.Repositories.ListByAuthenticatedUser(ctx, &github.RepositoryListByAuthenticatedUserOptions{Sort: "pushed", ListOptions: opts})
+		for _, repo := range repos {
+			owners[repo.GetOwner().GetLogin()] = true
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	values := make([]string, 0, len(owners))
+	for owner := range owners {
+		values = append(values, owner)
+	}
+	sort.Strings(values)
+	return values, nil
+}
+
+// listRepositoryNames returns the names of the repositories of owner that the authenticated user can
+// access, most recently pushed first. Without an owner, all of them are listed. An owner that none of
+// these belong to has its public repositories listed instead.
+func listRepositoryNames(ctx context.Context, client *github.Client, owner string) ([]string, error) {
+	var names []string
+	seen := map[string]bool{}
+	err := listCompletionPages(func(opts github.ListOptions) (*github.Response, error) {
+		repos, resp, err := client.Repositories.ListByAuthenticatedUser(ctx, &github.RepositoryListByAuthenticatedUserOptions{Sort: "pushed", ListOptions: opts})
+		for _, repo := range repos {
+			if (owner == "" || strings.EqualFold(repo.GetOwner().GetLogin(), owner)) && !seen[repo.GetName()] {
+				seen[repo.GetName()] = true
+				names = append(names, repo.GetName())
+			}
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+	if len(names) > 0 || owner == "" {
+		return names, nil
+	}
+
+	err = listCompletionPages(func(opts github.ListOptions) (*github.Response, error) {
+		repos, resp, err := client.Repositories.ListByUser(ctx, owner, &github.RepositoryListByUserOptions{Sort: "pushed", ListOptions: opts})
+		for _, repo := range repos {
+			names = append(names, repo.GetName())
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories of %s: %w", owner, err)
+	}
+	return names, nil
+}
+
+// listRepositoryRefsOrLabels returns the names of the branches, tags or labels of a repository.
+func listRepositoryRefsOrLabels(ctx context.Context, client *github.Client, kind, owner, repo string) ([]string, error) {
+	var names []string
+	err := listCompletionPages(func(opts github.ListOptions) (*github.Response, error) {
+		switch kind {
+		case "branch":
+			branches, resp, err := client.Repositories.ListBranches(ctx, owner, repo, &github.BranchListOptions{ListOptions: opts})
+			for _, branch := range branches {
+				names = append(names, branch.GetName())
+			}
+			return resp, err
+		case "tag":
+			tags, resp, err := client.Repositories.ListTags(ctx, owner, repo, &opts)
+			for _, tag := range tags {
+				names = append(names, tag.GetName())
+			}
+			return resp, err
+		default:
+			labels, resp, err := client.Issues.ListLabels(ctx, owner, repo, &opts)
+			for _, label := range labels {
+				names = append(names, label.GetName())
+			}
+			return resp, err
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the %ss of %s/%s: %w", kind, owner, repo, err)
+	}
+	return names, nil
+}
+
+// listCompletionPages calls fetch for every page of a list, up to maxCompletionPages.
+func listCompletionPages(fetch func(opts github.ListOptions) (*github.Response, error)) error {
+	opts := github.ListOptions{PerPage: 100}
+	for page := 0; page < maxCompletionPages; page++ {
+		resp, err := fetch(opts)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return nil
+}
+
+// AddCompletionsCapability declares the completions capability in the response to an initialize
+// request, which the MCP server library can't. Other messages are returned as they are.
+func AddCompletionsCapability(message json.RawMessage) json.RawMessage {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(message, &response); err != nil || response["result"] == nil {
+		return message
+	}
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(response["result"], &result); err != nil || result["protocolVersion"] == nil || result["capabilities"] == nil {
+		return message
+	}
+	var capabilities map[string]json.RawMessage
+	if err := json.Unmarshal(result["capabilities"], &capabilities); err != nil {
+		return message
+	}
+
+	capabilities["completions"] = json.RawMessage(`{}`)
+	var err error
+	if result["capabilities"], err = json.Marshal(capabilities); err != nil {
+		return message
+	}
+	if response["result"], err = json.Marshal(result); err != nil {
+		return message
+	}
+	updated, err := json.Marshal(response)
+	if err != nil {
+		return message
+	}
+	return updated
+}
@@ -0,0 +1,192 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ArgumentCompletions(t *testing.T) {
+	userRepoRequests := 0
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetUserOrgs,
+			[]*github.Organization{{Login: github.Ptr("github")}},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetUserRepos,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				userRepoRequests++
+				_, _ = w.Write(mock.MustMarshal([]*github.Repository{
+					{Name: github.Ptr("github-mcp-server"), Owner: &github.User{Login: github.Ptr("github")}},
+					{Name: github.Ptr("go-github"), Owner: &github.User{Login: github.Ptr("google")}},
+					{Name: github.Ptr("dotfiles"), Owner: &github.User{Login: github.Ptr("octocat")}},
+					{Name: github.Ptr("mcp-go"), Owner: &github.User{Login: github.Ptr("octocat")}},
+				}))
+			}),
+		),
+		mock.WithRequestMatch(
+			mock.GetUsersReposByUsername,
+			[]*github.Repository{{Name: github.Ptr("linux")}},
+		),
+		mock.WithRequestMatch(
+			mock.GetReposBranchesByOwnerByRepo,
+			[]*github.Branch{{Name: github.Ptr("main")}, {Name: github.Ptr("feature/main-menu")}, {Name: github.Ptr("release")}},
+		),
+		mock.WithRequestMatch(
+			mock.GetReposTagsByOwnerByRepo,
+			[]*github.RepositoryTag{{Name: github.Ptr("v1.0.0")}, {Name: github.Ptr("v1.1.0")}},
+		),
+		mock.WithRequestMatch(
+			mock.GetReposLabelsByOwnerByRepo,
+			[]*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("Bugfix")}, {Name: github.Ptr("enhancement")}},
+		),
+	))
+	completions := NewArgumentCompletions(stubGetClientFn(client))
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		argument       string
+		value          string
+		arguments      map[string]string
+		expectedValues []string
+	}{
+		{
+			name:           "owners",
+			argument:       "owner",
+			value:          "o",
+			expectedValues: []string{"octocat", "google"},
+		},
+		{
+			name:           "repositories of an owner",
+			argument:       "repo",
+			value:          "",
+			arguments:      map[string]string{"owner": "OctoCat"},
+			expectedValues: []string{"dotfiles", "mcp-go"},
+		},
+		{
+			name:           "repositories without an owner",
+			argument:       "repo",
+			value:          "go",
+			expectedValues: []string{"go-github", "mcp-go"},
+		},
+		{
+			name:           "public repositories of other owners",
+			argument:       "repo",
+			value:          "",
+			arguments:      map[string]string{"owner": "torvalds"},
+			expectedValues: []string{"linux"},
+		},
+		{
+			name:           "branches, prefix matches first",
+			argument:       "branch",
+			value:          "Main",
+			arguments:      map[string]string{"owner": "octocat", "repo": "mcp-go"},
+			expectedValues: []string{"main", "feature/main-menu"},
+		},
+		{
+			name:           "tags",
+			argument:       "tag",
+			value:          "v1.1",
+			arguments:      map[string]string{"owner": "octocat", "repo": "mcp-go"},
+			expectedValues: []string{"v1.1.0"},
+		},
+		{
+			name:           "the last label of a list",
+			argument:       "labels",
+			value:          "enhancement, bu",
+			arguments:      map[string]string{"owner": "octocat", "repo": "mcp-go"},
+			expectedValues: []string{"enhancement,bug", "enhancement,Bugfix"},
+		},
+		{
+			name:           "branches without a repository",
+			argument:       "branch",
+			value:          "main",
+			arguments:      map[string]string{"owner": "octocat"},
+			expectedValues: []string{},
+		},
+		{
+			name:           "unknown arguments",
+			argument:       "state",
+			value:          "open",
+			expectedValues: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := completions.Complete(ctx, "session", tc.argument, tc.value, tc.arguments)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedValues, result.Completion.Values)
+			assert.Equal(t, len(tc.expectedValues), result.Completion.Total)
+			assert.False(t, result.Completion.HasMore)
+		})
+	}
+
+	// The lists are cached per session
+	assert.Equal(t, 4, userRepoRequests)
+	_, err := completions.Complete(ctx, "session", "repo", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 4, userRepoRequests)
+	completions.RemoveSession("session")
+	_, err = completions.Complete(ctx, "session", "repo", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5, userRepoRequests)
+}
+
+func Test_ArgumentCompletions_HandleMessage(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposBranchesByOwnerByRepo,
+			[]*github.Branch{{Name: github.Ptr("main")}},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposLabelsByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			}),
+		),
+	))
+	completions := NewArgumentCompletions(stubGetClientFn(client))
+	ctx := context.Background()
+
+	response, ok := completions.HandleMessage(ctx, "a", json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"completion/complete","params":{"ref":{"type":"ref/resource","uri":"repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}"},"argument":{"name":"branch","value":"m"},"context":{"arguments":{"owner":"octocat","repo":"hello-world"}}}}`))
+	require.True(t, ok)
+	expected := &mcp.CompleteResult{}
+	expected.Completion.Values = []string{"main"}
+	expected.Completion.Total = 1
+	assert.Equal(t, mcp.JSONRPCResponse{JSONRPC: mcp.JSONRPC_VERSION, ID: mcp.NewRequestId(int64(1)), Result: expected}, response)
+
+	response, ok = completions.HandleMessage(ctx, "a", json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"completion/complete","params":{"ref":{"type":"ref/prompt","name":"triage_issues"},"argument":{"name":"labels","value":""},"context":{"arguments":{"owner":"octocat","repo":"hello-world"}}}}`))
+	require.True(t, ok)
+	require.IsType(t, mcp.JSONRPCError{}, response)
+	assert.Equal(t, mcp.INTERNAL_ERROR, response.(mcp.JSONRPCError).Error.Code)
+	assert.Contains(t, response.(mcp.JSONRPCError).Error.Message, "failed to list the labels of octocat/hello-world")
+
+	_, ok = completions.HandleMessage(ctx, "a", json.RawMessage(`{"jsonrpc":"2.0","id":3,"method":"prompts/get","params":{"name":"triage_issues"}}`))
+	assert.False(t, ok)
+}
+
+func Test_AddCompletionsCapability(t *testing.T) {
+	initialize := `{"jsonrpc":"2.0","id":0,"result":{"protocolVersion":"2025-06-18","capabilities":{"tools":{"listChanged":true}},"serverInfo":{"name":"github-mcp-server","version":"dev"}}}`
+	assert.JSONEq(t,
+		`{"jsonrpc":"2.0","id":0,"result":{"protocolVersion":"2025-06-18","capabilities":{"tools":{"listChanged":true},"completions":{}},"serverInfo":{"name":"github-mcp-server","version":"dev"}}}`,
+		string(AddCompletionsCapability(json.RawMessage(initialize))))
+
+	for _, message := range []string{
+		`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"\"protocolVersion\""}]}}`,
+		`{"jsonrpc":"2.0","method":"notifications/tools/list_changed"}`,
+		`not json`,
+	} {
+		assert.Equal(t, message, string(AddCompletionsCapability(json.RawMessage(message))))
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -36,6 +37,7 @@ type UserDetails struct {
 func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	tool := mcp.NewTool("get_me",
 		mcp.WithDescription(t("TOOL_GET_ME_DESCRIPTION", "Get details of the authenticated GitHub user. Use this when a request is about the user's own profile for GitHub. Or when information is missing to build other tool calls.")),
+		mcp.WithOutputSchema[MinimalUser](),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
 			Title:        t("TOOL_GET_ME_USER_TITLE", "Get my user profile"),
 			ReadOnlyHint: ToBoolPtr(true),
@@ -90,3 +92,61 @@ func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Too
 
 	return tool, handler
 }
+
+// RateLimit is the budget of a rate limit of the GitHub API, as get_rate_limit returns it.
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	Reset     time.Time `json:"reset"`
+}
+
+// GetRateLimit creates a tool to get the rate limits of the GitHub API that the server has left.
+func GetRateLimit(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	tool := mcp.NewTool("get_rate_limit",
+		mcp.WithDescription(t("TOOL_GET_RATE_LIMIT_DESCRIPTION", "Get how many requests the server has left in each rate limit of the GitHub API, such as core, search and graphql, and when they reset. Use this before large tasks, or when calls fail because of rate limits. Checking doesn't count against the limits.")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        t("TOOL_GET_RATE_LIMIT_USER_TITLE", "Get rate limits"),
+			ReadOnlyHint: ToBoolPtr(true),
+		}),
+	)
+
+	handler := func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := getClient(ctx)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get GitHub client", err), nil
+		}
+
+		limits, res, err := client.RateLimit.Get(ctx)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to get rate limits",
+				res,
+				err,
+			), nil
+		}
+
+		resources := map[string]*github.Rate{
+			"core":        limits.Core,
+			"search":      limits.Search,
+			"code_search": limits.CodeSearch,
+			"graphql":     limits.GraphQL,
+		}
+		result := make(map[string]RateLimit, len(resources))
+		for name, rate := range resources {
+			if rate == nil {
+				continue
+			}
+			result[name] = RateLimit{
+				Limit:     rate.Limit,
+				Remaining: rate.Remaining,
+				Used:      rate.Used,
+				Reset:     rate.Reset.Time,
+			}
+		}
+
+		return MarshalledTextResult(result), nil
+	}
+
+	return tool, handler
+}
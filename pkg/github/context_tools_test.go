@@ -139,3 +139,88 @@ func Test_GetMe(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetRateLimit(t *testing.T) {
+	t.Parallel()
+
+	tool, _ := GetRateLimit(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_rate_limit", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_rate_limit tool should be read-only")
+
+	reset := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockRateLimits := map[string]any{
+		"resources": map[string]any{
+			"core":    map[string]any{"limit": 5000, "remaining": 4990, "used": 10, "reset": reset.Unix()},
+			"search":  map[string]any{"limit": 30, "remaining": 0, "used": 30, "reset": reset.Unix()},
+			"graphql": map[string]any{"limit": 5000, "remaining": 5000, "used": 0, "reset": reset.Unix()},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		stubbedGetClientFn GetClientFn
+		expectToolError    bool
+		expectedLimits     map[string]RateLimit
+		expectedToolErrMsg string
+	}{
+		{
+			name: "successful get rate limits",
+			stubbedGetClientFn: stubGetClientFromHTTPFn(
+				mock.NewMockedHTTPClient(
+					mock.WithRequestMatch(
+						mock.GetRateLimit,
+						mockRateLimits,
+					),
+				),
+			),
+			expectedLimits: map[string]RateLimit{
+				"core":    {Limit: 5000, Remaining: 4990, Used: 10, Reset: reset},
+				"search":  {Limit: 30, Remaining: 0, Used: 30, Reset: reset},
+				"graphql": {Limit: 5000, Remaining: 5000, Used: 0, Reset: reset},
+			},
+		},
+		{
+			name: "get rate limits fails",
+			stubbedGetClientFn: stubGetClientFromHTTPFn(
+				mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(
+						mock.GetRateLimit,
+						badRequestHandler("expected test failure"),
+					),
+				),
+			),
+			expectToolError:    true,
+			expectedToolErrMsg: "expected test failure",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetRateLimit(tc.stubbedGetClientFn, translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]any{})
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				assert.True(t, result.IsError, "expected tool call result to be an error")
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			var returnedLimits map[string]RateLimit
+			err = json.Unmarshal([]byte(textContent.Text), &returnedLimits)
+			require.NoError(t, err)
+			for name, limit := range tc.expectedLimits {
+				assert.Equal(t, limit.Limit, returnedLimits[name].Limit, name)
+				assert.Equal(t, limit.Remaining, returnedLimits[name].Remaining, name)
+				assert.Equal(t, limit.Used, returnedLimits[name].Used, name)
+				assert.True(t, limit.Reset.Equal(returnedLimits[name].Reset), name)
+			}
+			assert.Len(t, returnedLimits, len(tc.expectedLimits))
+		})
+	}
+}
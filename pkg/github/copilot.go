@@ -0,0 +1,331 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxCopilotSeatPages caps how many pages of 100 seats list_copilot_seats reads.
+const maxCopilotSeatPages = 50
+
+// CopilotSeat is a Copilot seat of an organization with the activity needed to tell whether it is used.
+type CopilotSeat struct {
+	Assignee                string            `json:"assignee"`
+	AssigneeType            string            `json:"assignee_type"`
+	AssigningTeam           string            `json:"assigning_team,omitempty"`
+	PlanType                string            `json:"plan_type,omitempty"`
+	CreatedAt               *github.Timestamp `json:"created_at,omitempty"`
+	LastActivityAt          *github.Timestamp `json:"last_activity_at,omitempty"`
+	LastActivityEditor      string            `json:"last_activity_editor,omitempty"`
+	PendingCancellationDate string            `json:"pending_cancellation_date,omitempty"`
+}
+
+func newCopilotSeat(seat *github.CopilotSeatDetails) CopilotSeat {
+	s := CopilotSeat{
+		AssigningTeam:           seat.GetAssigningTeam().GetSlug(),
+		PlanType:                seat.GetPlanType(),
+		CreatedAt:               seat.CreatedAt,
+		LastActivityAt:          seat.LastActivityAt,
+		LastActivityEditor:      seat.GetLastActivityEditor(),
+		PendingCancellationDate: seat.GetPendingCancellationDate(),
+	}
+	if user, ok := seat.GetUser(); ok {
+		s.Assignee, s.AssigneeType = user.GetLogin(), "User"
+	} else if team, ok := seat.GetTeam(); ok {
+		s.Assignee, s.AssigneeType = team.GetSlug(), "Team"
+	} else if org, ok := seat.GetOrganization(); ok {
+		s.Assignee, s.AssigneeType = org.GetLogin(), "Organization"
+	}
+	return s
+}
+
+// withCopilotAssignees adds the users and teams parameters of the seat management tools.
+func withCopilotAssignees(action string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithArray("users",
+			mcp.Description(fmt.Sprintf("Logins of the organization members to %s", action)),
+			mcp.Items(map[string]any{"type": "string"}),
+		)(tool)
+		mcp.WithArray("teams",
+			mcp.Description(fmt.Sprintf("Slugs of the teams whose members to %s", action)),
+			mcp.Items(map[string]any{"type": "string"}),
+		)(tool)
+	}
+}
+
+// copilotAssignees reads the parameters of withCopilotAssignees, at least one of which must be given.
+func copilotAssignees(request mcp.CallToolRequest) (users, teams []string, err error) {
+	users, err = OptionalStringArrayParam(request, "users")
+	if err != nil {
+		return nil, nil, err
+	}
+	teams, err = OptionalStringArrayParam(request, "teams")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(users) == 0 && len(teams) == 0 {
+		return nil, nil, fmt.Errorf("at least one of users or teams is required")
+	}
+	return users, teams, nil
+}
+
+// ListCopilotSeats creates a tool to list the Copilot seats of an organization.
+func ListCopilotSeats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_copilot_seats",
+			mcp.WithDescription(t("TOOL_LIST_COPILOT_SEATS_DESCRIPTION", "List the Copilot seats of an organization with who holds them, the team that assigned them, and when and in which editor they were last used. Use inactive_since to find the seats that have not been used since a date, including ones never used. Requires organization owner access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COPILOT_SEATS_USER_TITLE", "List Copilot seats"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("inactive_since",
+				mcp.Description("Only return the seats without any activity since this ISO 8601 date (YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			inactiveSince, err := OptionalParam[string](request, "inactive_since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var cutoff *github.Timestamp
+			if inactiveSince != "" {
+				timestamp, err := parseISOTimestamp(inactiveSince)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid inactive_since: %s", err)), nil
+				}
+				cutoff = &github.Timestamp{Time: timestamp}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			progress := newProgressReporter(ctx, request)
+			var totalSeats int64
+			seats := []CopilotSeat{}
+			truncated := false
+			opts := &github.ListOptions{PerPage: 100}
+			for page := 0; ; page++ {
+				if page == maxCopilotSeatPages {
+					truncated = true
+					break
+				}
+				listed, resp, err := client.Copilot.ListCopilotSeats(ctx, org, opts)
+				if err != nil {
+					return newOrgAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to list Copilot seats of %s", org), resp, err), nil
+				}
+				_ = resp.Body.Close()
+				totalSeats = listed.TotalSeats
+				for _, seat := range listed.Seats {
+					if cutoff != nil && seat.LastActivityAt != nil && !seat.LastActivityAt.Before(cutoff.Time) {
+						continue
+					}
+					seats = append(seats, newCopilotSeat(seat))
+				}
+				progress.report(float64(page+1), float64(max(resp.LastPage, page+1)), fmt.Sprintf("Listed page %d of the Copilot seats", page+1))
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			result := map[string]any{
+				"total_seats": totalSeats,
+				"seats":       seats,
+			}
+			if truncated {
+				result["truncated"] = true
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// AddCopilotSeats creates a tool to assign Copilot seats to organization members and teams.
+func AddCopilotSeats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_copilot_seats",
+			mcp.WithDescription(t("TOOL_ADD_COPILOT_SEATS_DESCRIPTION", "Assign Copilot seats of an organization to members and to every member of teams. Each new seat is billed to the organization. Requires organization owner access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_COPILOT_SEATS_USER_TITLE", "Add Copilot seats"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			withCopilotAssignees("give a seat"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			users, teams, err := copilotAssignees(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created := 0
+			if len(users) > 0 {
+				assigned, resp, err := client.Copilot.AddCopilotUsers(ctx, org, users)
+				if err != nil {
+					return newOrgAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to add Copilot seats for users of %s", org), resp, err), nil
+				}
+				_ = resp.Body.Close()
+				created += assigned.SeatsCreated
+			}
+			if len(teams) > 0 {
+				assigned, resp, err := client.Copilot.AddCopilotTeams(ctx, org, teams)
+				if err != nil {
+					return newOrgAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to add Copilot seats for teams of %s", org), resp, err), nil
+				}
+				_ = resp.Body.Close()
+				created += assigned.SeatsCreated
+			}
+
+			return MarshalledTextResult(map[string]int{"seats_created": created}), nil
+		}
+}
+
+// RemoveCopilotSeats creates a tool to cancel the Copilot seats of organization members and teams.
+func RemoveCopilotSeats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_copilot_seats",
+			mcp.WithDescription(t("TOOL_REMOVE_COPILOT_SEATS_DESCRIPTION", "Cancel the Copilot seats of organization members and of teams. Access ends at the end of the billing cycle; until then the seats show a pending_cancellation_date. Seats a user also holds through another team are kept. Requires organization owner access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_COPILOT_SEATS_USER_TITLE", "Remove Copilot seats"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			withCopilotAssignees("take the seat from"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			users, teams, err := copilotAssignees(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			cancelled := 0
+			if len(users) > 0 {
+				removed, resp, err := client.Copilot.RemoveCopilotUsers(ctx, org, users)
+				if err != nil {
+					return newOrgAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to remove Copilot seats of users of %s", org), resp, err), nil
+				}
+				_ = resp.Body.Close()
+				cancelled += removed.SeatsCancelled
+			}
+			if len(teams) > 0 {
+				removed, resp, err := client.Copilot.RemoveCopilotTeams(ctx, org, teams)
+				if err != nil {
+					return newOrgAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to remove Copilot seats of teams of %s", org), resp, err), nil
+				}
+				_ = resp.Body.Close()
+				cancelled += removed.SeatsCancelled
+			}
+
+			return MarshalledTextResult(map[string]int{"seats_cancelled": cancelled}), nil
+		}
+}
+
+// GetCopilotMetrics creates a tool to get the daily Copilot usage metrics of an organization or team.
+func GetCopilotMetrics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_copilot_metrics",
+			mcp.WithDescription(t("TOOL_GET_COPILOT_METRICS_DESCRIPTION", "Get the daily Copilot usage metrics of an organization, or of one of its teams, for up to the last 28 days: active and engaged users and code completion, chat and pull request summary usage by editor, model and language. Days with fewer than 5 active users are left out. Requires organization owner access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COPILOT_METRICS_USER_TITLE", "Get Copilot metrics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Description("Slug of a team to get the metrics of instead of the whole organization"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Start of the period as an ISO 8601 date (YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ), at most 28 days ago"),
+			),
+			mcp.WithString("until",
+				mcp.Description("End of the period as an ISO 8601 date (YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := OptionalParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts := &github.CopilotMetricsListOptions{ListOptions: github.ListOptions{PerPage: 28}}
+			for _, field := range []string{"since", "until"} {
+				value, err := OptionalParam[string](request, field)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if value == "" {
+					continue
+				}
+				timestamp, err := parseISOTimestamp(value)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid %s: %s", field, err)), nil
+				}
+				if field == "since" {
+					opts.Since = &timestamp
+				} else {
+					opts.Until = &timestamp
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var metrics []*github.CopilotMetrics
+			var resp *github.Response
+			scope := org
+			if teamSlug == "" {
+				metrics, resp, err = client.Copilot.GetOrganizationMetrics(ctx, org, opts)
+			} else {
+				scope = org + "/" + teamSlug
+				metrics, resp, err = client.Copilot.GetOrganizationTeamMetrics(ctx, org, teamSlug, opts)
+			}
+			if err != nil {
+				return newOrgAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to get Copilot metrics of %s", scope), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(metrics), nil
+		}
+}
@@ -0,0 +1,368 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCopilotSeats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCopilotSeats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_copilot_seats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	seats := map[string]any{
+		"total_seats": 3,
+		"seats": []map[string]any{
+			{
+				"assignee":             map[string]any{"type": "User", "login": "active"},
+				"last_activity_at":     "2026-10-01T09:00:00Z",
+				"last_activity_editor": "vscode/1.95.0",
+				"plan_type":            "business",
+			},
+			{
+				"assignee":         map[string]any{"type": "User", "login": "idle"},
+				"assigning_team":   map[string]any{"slug": "platform"},
+				"last_activity_at": "2026-07-01T09:00:00Z",
+			},
+			{
+				"assignee": map[string]any{"type": "User", "login": "never"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedLogins []string
+	}{
+		{
+			name: "all seats",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingSeatsByOrg,
+					expectQueryParams(t, map[string]string{
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, seats),
+					),
+				),
+			),
+			requestArgs:    map[string]interface{}{},
+			expectedLogins: []string{"active", "idle", "never"},
+		},
+		{
+			name: "inactive seats",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsCopilotBillingSeatsByOrg,
+					seats,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"inactive_since": "2026-09-01",
+			},
+			expectedLogins: []string{"idle", "never"},
+		},
+		{
+			name:         "invalid date",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"inactive_since": "last month",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid inactive_since",
+		},
+		{
+			name: "not an organization owner",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingSeatsByOrg,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights"}`),
+				),
+			),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "requires admin access to the organization",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCopilotSeats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"org": "octo-org",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned struct {
+				TotalSeats int           `json:"total_seats"`
+				Seats      []CopilotSeat `json:"seats"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, 3, returned.TotalSeats)
+			logins := make([]string, 0, len(returned.Seats))
+			for _, seat := range returned.Seats {
+				assert.Equal(t, "User", seat.AssigneeType)
+				logins = append(logins, seat.Assignee)
+			}
+			assert.Equal(t, tc.expectedLogins, logins)
+		})
+	}
+}
+
+func Test_AddCopilotSeats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddCopilotSeats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_copilot_seats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedCount  int
+	}{
+		{
+			name: "users and teams",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsCopilotBillingSelectedUsersByOrg,
+					expectRequestBody(t, map[string]any{
+						"selected_usernames": []any{"octocat"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.SeatAssignments{SeatsCreated: 1}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsCopilotBillingSelectedTeamsByOrg,
+					expectRequestBody(t, map[string]any{
+						"selected_teams": []any{"platform"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.SeatAssignments{SeatsCreated: 4}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"users": []interface{}{"octocat"},
+				"teams": []interface{}{"platform"},
+			},
+			expectedCount: 5,
+		},
+		{
+			name:           "nobody given",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "at least one of users or teams is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddCopilotSeats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"org": "octo-org",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned map[string]int
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedCount, returned["seats_created"])
+		})
+	}
+}
+
+func Test_RemoveCopilotSeats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveCopilotSeats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_copilot_seats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteOrgsCopilotBillingSelectedUsersByOrg,
+			expectRequestBody(t, map[string]any{
+				"selected_usernames": []any{"idle", "never"},
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.SeatCancellations{SeatsCancelled: 2}),
+			),
+		),
+	))
+	_, handler := RemoveCopilotSeats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":   "octo-org",
+		"users": []interface{}{"idle", "never"},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned map[string]int
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, 2, returned["seats_cancelled"])
+}
+
+func Test_GetCopilotMetrics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCopilotMetrics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_copilot_metrics", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	metrics := []*github.CopilotMetrics{
+		{Date: "2026-10-01", TotalActiveUsers: github.Ptr(12), TotalEngagedUsers: github.Ptr(9)},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "organization metrics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotMetricsByOrg,
+					expectQueryParams(t, map[string]string{
+						"since":    "2026-09-20T00:00:00Z",
+						"per_page": "28",
+					}).andThen(
+						mockResponse(t, http.StatusOK, metrics),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"since": "2026-09-20",
+			},
+		},
+		{
+			name: "team metrics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamCopilotMetricsByOrgByTeamSlug,
+					expectPath(t, "/orgs/octo-org/team/platform/copilot/metrics").andThen(
+						mockResponse(t, http.StatusOK, metrics),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"team_slug": "platform",
+			},
+		},
+		{
+			name: "metrics disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotMetricsByOrg,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Copilot Usage Metrics API setting is disabled"}`),
+				),
+			),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "failed to get Copilot metrics of octo-org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCopilotMetrics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"org": "octo-org",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned []*github.CopilotMetrics
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, 12, *returned[0].TotalActiveUsers)
+		})
+	}
+}
+
+func Test_newCopilotSeat(t *testing.T) {
+	var details github.CopilotSeatDetails
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"assignee": {"type": "Team", "slug": "platform"},
+		"created_at": "2026-01-01T00:00:00Z",
+		"pending_cancellation_date": "2026-11-01"
+	}`), &details))
+
+	seat := newCopilotSeat(&details)
+	assert.Equal(t, "platform", seat.Assignee)
+	assert.Equal(t, "Team", seat.AssigneeType)
+	assert.Equal(t, "2026-11-01", seat.PendingCancellationDate)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), seat.CreatedAt.Time)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -106,6 +107,7 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 				mcp.Description("Filter dependabot alerts by severity"),
 				mcp.Enum("low", "medium", "high", "critical"),
 			),
+			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -124,15 +126,32 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
+			// The alerts of a repository page on after cursors past the first page, which the next_cursor
+			// carries, and take no page next to them. Only the per_page of ListOptions is set, the one of
+			// ListCursorOptions would repeat it.
+			if pagination.After != "" {
+				pagination.Page = 0
+			}
 			alerts, resp, err := client.Dependabot.ListRepoAlerts(ctx, owner, repo, &github.ListAlertsOptions{
 				State:    ToStringPtr(state),
 				Severity: ToStringPtr(severity),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+				ListCursorOptions: github.ListCursorOptions{
+					After: pagination.After,
+				},
 			})
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -159,3 +178,514 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+func ListOrgDependabotAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_org_dependabot_alerts",
+			mcp.WithDescription(t("TOOL_LIST_ORG_DEPENDABOT_ALERTS_DESCRIPTION", "List dependabot alerts across all repositories of a GitHub organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_DEPENDABOT_ALERTS_USER_TITLE", "List organization dependabot alerts"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name."),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter dependabot alerts by state. Defaults to open"),
+				mcp.DefaultString("open"),
+				mcp.Enum("open", "fixed", "dismissed", "auto_dismissed"),
+			),
+			mcp.WithString("severity",
+				mcp.Description("Filter dependabot alerts by severity"),
+				mcp.Enum("low", "medium", "high", "critical"),
+			),
+			mcp.WithString("ecosystem",
+				mcp.Description("Filter dependabot alerts by package ecosystem, such as npm or pip"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			severity, err := OptionalParam[string](request, "severity")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ecosystem, err := OptionalParam[string](request, "ecosystem")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			alerts, resp, err := client.Dependabot.ListOrgAlerts(ctx, org, &github.ListAlertsOptions{
+				State:     ToStringPtr(state),
+				Severity:  ToStringPtr(severity),
+				Ecosystem: ToStringPtr(ecosystem),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list alerts for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(alerts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal alerts: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func DismissDependabotAlert(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"dismiss_dependabot_alert",
+			mcp.WithDescription(t("TOOL_DISMISS_DEPENDABOT_ALERT_DESCRIPTION", "Dismiss a dependabot alert in a GitHub repository, or reopen a dismissed one.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DISMISS_DEPENDABOT_ALERT_USER_TITLE", "Dismiss dependabot alert"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithNumber("alertNumber",
+				mcp.Required(),
+				mcp.Description("The number of the alert."),
+			),
+			mcp.WithString("reason",
+				mcp.Description("The reason for dismissing the alert. Required unless reopen is true"),
+				mcp.Enum("fix_started", "inaccurate", "no_bandwidth", "not_used", "tolerable_risk"),
+			),
+			mcp.WithString("comment",
+				mcp.Description("A comment explaining the dismissal."),
+			),
+			mcp.WithBoolean("reopen",
+				mcp.Description("Reopen the alert instead of dismissing it."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			alertNumber, err := RequiredInt(request, "alertNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reason, err := OptionalParam[string](request, "reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comment, err := OptionalParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reopen, err := OptionalParam[bool](request, "reopen")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			stateInfo := &github.DependabotAlertState{State: "open"}
+			if !reopen {
+				if reason == "" {
+					return mcp.NewToolResultError("reason is required to dismiss an alert"), nil
+				}
+				stateInfo = &github.DependabotAlertState{
+					State:            "dismissed",
+					DismissedReason:  github.Ptr(reason),
+					DismissedComment: ToStringPtr(comment),
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			alert, resp, err := client.Dependabot.UpdateAlert(ctx, owner, repo, alertNumber, stateInfo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update alert with number '%d'", alertNumber),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(alert)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal alert: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// maxBulkDismissAlerts caps how many alerts bulk_dismiss_dependabot_alerts dismisses in a single call.
+const maxBulkDismissAlerts = 100
+
+// dependabotAlertMatch is an alert matched by bulk_dismiss_dependabot_alerts.
+type dependabotAlertMatch struct {
+	Number    int    `json:"number"`
+	Package   string `json:"package"`
+	Ecosystem string `json:"ecosystem"`
+	Severity  string `json:"severity"`
+	GHSAID    string `json:"ghsa_id"`
+	CVEID     string `json:"cve_id,omitempty"`
+	HTMLURL   string `json:"html_url"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BulkDismissDependabotAlerts creates a tool to dismiss all open dependabot alerts of a repository for an
+// advisory or a package. When dryRunOnly is set, as it is in read-only mode, the tool only reports the alerts
+// it would dismiss.
+func BulkDismissDependabotAlerts(getClient GetClientFn, dryRunOnly bool, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	description := "Dismiss the open dependabot alerts of a GitHub repository that match a CVE, a GitHub security advisory or a package. Returns a summary of the alerts that were dismissed, or with dry_run the alerts that would be."
+	if dryRunOnly {
+		description = "Find the open dependabot alerts of a GitHub repository that match a CVE, a GitHub security advisory or a package. The server is in read-only mode, so no alerts are dismissed."
+	}
+
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_BULK_DISMISS_DEPENDABOT_ALERTS_DESCRIPTION", description)),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        t("TOOL_BULK_DISMISS_DEPENDABOT_ALERTS_USER_TITLE", "Bulk dismiss dependabot alerts"),
+			ReadOnlyHint: ToBoolPtr(dryRunOnly),
+		}),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("The owner of the repository."),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("The name of the repository."),
+		),
+		mcp.WithString("cve_id",
+			mcp.Description("Only match alerts for this CVE, e.g. CVE-2024-12345."),
+		),
+		mcp.WithString("ghsa_id",
+			mcp.Description("Only match alerts for this GitHub security advisory, e.g. GHSA-xxxx-xxxx-xxxx."),
+		),
+		mcp.WithString("package",
+			mcp.Description("Only match alerts for this package name."),
+		),
+		mcp.WithString("ecosystem",
+			mcp.Description("Only match alerts for this package ecosystem, such as npm or pip."),
+		),
+		mcp.WithString("reason",
+			mcp.Description("The reason for dismissing the alerts. Required unless dry_run is true"),
+			mcp.Enum("fix_started", "inaccurate", "no_bandwidth", "not_used", "tolerable_risk"),
+		),
+		mcp.WithString("comment",
+			mcp.Description("A comment explaining the dismissal."),
+		),
+		mcp.WithNumber("max_alerts",
+			mcp.Description(fmt.Sprintf("Maximum number of alerts to dismiss in this call (max %d)", maxBulkDismissAlerts)),
+			mcp.Min(1),
+			mcp.Max(maxBulkDismissAlerts),
+			mcp.DefaultNumber(30),
+		),
+	}
+	if !dryRunOnly {
+		options = append(options, mcp.WithBoolean("dry_run",
+			mcp.Description("Only report the matching alerts without dismissing them"),
+		))
+	}
+
+	return mcp.NewTool("bulk_dismiss_dependabot_alerts", options...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			cveID, err := OptionalParam[string](request, "cve_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ghsaID, err := OptionalParam[string](request, "ghsa_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pkg, err := OptionalParam[string](request, "package")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ecosystem, err := OptionalParam[string](request, "ecosystem")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reason, err := OptionalParam[string](request, "reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comment, err := OptionalParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxAlerts, err := OptionalIntParamWithDefault(request, "max_alerts", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxAlerts < 1 || maxAlerts > maxBulkDismissAlerts {
+				return mcp.NewToolResultError(fmt.Sprintf("max_alerts must be between 1 and %d", maxBulkDismissAlerts)), nil
+			}
+			dryRun := dryRunOnly
+			if !dryRunOnly {
+				dryRun, err = OptionalParam[bool](request, "dry_run")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+
+			// Refuse to match every open alert of the repository
+			if cveID == "" && ghsaID == "" && pkg == "" {
+				return mcp.NewToolResultError("at least one of cve_id, ghsa_id or package is required"), nil
+			}
+			if !dryRun && reason == "" {
+				return mcp.NewToolResultError("reason is required to dismiss alerts"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The API filters by package and ecosystem, advisories are matched here. All matches are collected
+			// before dismissing any, as dismissing alerts shifts the pages of the open alerts.
+			opts := &github.ListAlertsOptions{
+				State:       github.Ptr("open"),
+				Package:     ToStringPtr(pkg),
+				Ecosystem:   ToStringPtr(ecosystem),
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			var matched []dependabotAlertMatch
+			total := 0
+			for {
+				alerts, resp, err := client.Dependabot.ListRepoAlerts(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to list alerts for repository '%s/%s'", owner, repo),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, alert := range alerts {
+					advisory := alert.GetSecurityAdvisory()
+					if cveID != "" && !strings.EqualFold(advisory.GetCVEID(), cveID) {
+						continue
+					}
+					if ghsaID != "" && !strings.EqualFold(advisory.GetGHSAID(), ghsaID) {
+						continue
+					}
+					total++
+					if len(matched) == maxAlerts {
+						continue
+					}
+					matched = append(matched, dependabotAlertMatch{
+						Number:    alert.GetNumber(),
+						Package:   alert.GetDependency().GetPackage().GetName(),
+						Ecosystem: alert.GetDependency().GetPackage().GetEcosystem(),
+						Severity:  advisory.GetSeverity(),
+						GHSAID:    advisory.GetGHSAID(),
+						CVEID:     advisory.GetCVEID(),
+						HTMLURL:   alert.GetHTMLURL(),
+					})
+				}
+
+				if resp.After == "" {
+					break
+				}
+				opts.After = resp.After
+			}
+
+			summary := map[string]any{
+				"dry_run":       dryRun,
+				"total_matches": total,
+			}
+			if dryRun {
+				summary["matched"] = matched
+				summary["remaining"] = max(total-len(matched), 0)
+				return MarshalledTextResult(summary), nil
+			}
+
+			dismissed := make([]dependabotAlertMatch, 0, len(matched))
+			failed := make([]dependabotAlertMatch, 0)
+			for _, alert := range matched {
+				_, resp, err := client.Dependabot.UpdateAlert(ctx, owner, repo, alert.Number, &github.DependabotAlertState{
+					State:            "dismissed",
+					DismissedReason:  github.Ptr(reason),
+					DismissedComment: ToStringPtr(comment),
+				})
+				if err != nil {
+					_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to dismiss alert", resp, err)
+					alert.Error = fmt.Sprintf("failed to dismiss alert: %s", err.Error())
+					failed = append(failed, alert)
+					continue
+				}
+				_ = resp.Body.Close()
+
+				dismissed = append(dismissed, alert)
+			}
+
+			summary["dismissed"] = dismissed
+			summary["failed"] = failed
+			summary["remaining"] = max(total-len(dismissed), 0)
+			return MarshalledTextResult(summary), nil
+		}
+}
+
+// dependabotPullRequest is a pull request opened by Dependabot, as listed by list_dependabot_pull_requests.
+type dependabotPullRequest struct {
+	Number    int               `json:"number"`
+	Title     string            `json:"title"`
+	State     string            `json:"state"`
+	HTMLURL   string            `json:"html_url"`
+	Labels    []string          `json:"labels,omitempty"`
+	CreatedAt *github.Timestamp `json:"created_at,omitempty"`
+	ClosedAt  *github.Timestamp `json:"closed_at,omitempty"`
+}
+
+func ListDependabotPullRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_dependabot_pull_requests",
+			mcp.WithDescription(t("TOOL_LIST_DEPENDABOT_PULL_REQUESTS_DESCRIPTION", "List the pull requests Dependabot opened in a GitHub repository, for security updates and version updates. Use list_dependabot_alerts to see which alerts they fix.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPENDABOT_PULL_REQUESTS_USER_TITLE", "List dependabot pull requests"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter pull requests by state. Defaults to open"),
+				mcp.DefaultString("open"),
+				mcp.Enum("open", "closed", "merged", "all"),
+			),
+			mcp.WithString("label",
+				mcp.Description("Only list pull requests with this label, such as security."),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			label, err := OptionalParam[string](request, "label")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			q := fmt.Sprintf("repo:%s/%s is:pr author:app/dependabot", owner, repo)
+			switch state {
+			case "", "open":
+				q += " is:open"
+			case "closed":
+				q += " is:closed"
+			case "merged":
+				q += " is:merged"
+			case "all":
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid state %q, must be open, closed, merged or all", state)), nil
+			}
+			if label != "" {
+				q += fmt.Sprintf(" label:%q", label)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result, resp, err := client.Search.Issues(ctx, q, &github.SearchOptions{
+				Sort:  "created",
+				Order: "desc",
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list dependabot pull requests for repository '%s/%s'", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			pulls := make([]dependabotPullRequest, 0, len(result.Issues))
+			for _, issue := range result.Issues {
+				pr := dependabotPullRequest{
+					Number:    issue.GetNumber(),
+					Title:     issue.GetTitle(),
+					State:     issue.GetState(),
+					HTMLURL:   issue.GetHTMLURL(),
+					CreatedAt: issue.CreatedAt,
+					ClosedAt:  issue.ClosedAt,
+				}
+				for _, l := range issue.Labels {
+					pr.Labels = append(pr.Labels, l.GetName())
+				}
+				pulls = append(pulls, pr)
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"total_count":   result.GetTotal(),
+				"pull_requests": pulls,
+			}), nil
+		}
+}
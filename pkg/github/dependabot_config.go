@@ -0,0 +1,482 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// dependabotConfigPaths are the locations Dependabot reads its configuration from, in order of precedence.
+var dependabotConfigPaths = []string{".github/dependabot.yml", ".github/dependabot.yaml"}
+
+// DependabotConfig is the interpreted content of a Dependabot configuration file.
+type DependabotConfig struct {
+	Path       string                    `json:"path"`
+	Version    int                       `json:"version"`
+	Updates    []DependabotUpdate        `json:"updates"`
+	Registries []string                  `json:"registries,omitempty"`
+	Warnings   []DependabotConfigWarning `json:"warnings"`
+}
+
+// DependabotUpdate is a single entry of the updates list, describing how one package ecosystem in one
+// or more directories is kept up to date.
+type DependabotUpdate struct {
+	PackageEcosystem      string                 `json:"package_ecosystem" yaml:"package-ecosystem"`
+	Directory             string                 `json:"directory,omitempty" yaml:"directory"`
+	Directories           []string               `json:"directories,omitempty" yaml:"directories"`
+	Schedule              DependabotSchedule     `json:"schedule" yaml:"schedule"`
+	TargetBranch          string                 `json:"target_branch,omitempty" yaml:"target-branch"`
+	OpenPullRequestsLimit *int                   `json:"open_pull_requests_limit,omitempty" yaml:"open-pull-requests-limit"`
+	VersioningStrategy    string                 `json:"versioning_strategy,omitempty" yaml:"versioning-strategy"`
+	Labels                []string               `json:"labels,omitempty" yaml:"labels"`
+	Registries            dependabotStringList   `json:"registries,omitempty" yaml:"registries"`
+	Allow                 []DependabotAllowRule  `json:"allow,omitempty" yaml:"allow"`
+	Ignore                []DependabotIgnoreRule `json:"ignore,omitempty" yaml:"ignore"`
+	Groups                []string               `json:"groups,omitempty" yaml:"-"`
+}
+
+// DependabotSchedule is how often Dependabot checks for updates.
+type DependabotSchedule struct {
+	Interval string `json:"interval" yaml:"interval"`
+	Day      string `json:"day,omitempty" yaml:"day"`
+	Time     string `json:"time,omitempty" yaml:"time"`
+	Timezone string `json:"timezone,omitempty" yaml:"timezone"`
+	Cronjob  string `json:"cronjob,omitempty" yaml:"cronjob"`
+}
+
+// DependabotAllowRule limits updates to the matching dependencies.
+type DependabotAllowRule struct {
+	DependencyName string `json:"dependency_name,omitempty" yaml:"dependency-name"`
+	DependencyType string `json:"dependency_type,omitempty" yaml:"dependency-type"`
+}
+
+// DependabotIgnoreRule excludes dependencies, versions or kinds of updates.
+type DependabotIgnoreRule struct {
+	DependencyName string               `json:"dependency_name" yaml:"dependency-name"`
+	Versions       dependabotStringList `json:"versions,omitempty" yaml:"versions"`
+	UpdateTypes    []string             `json:"update_types,omitempty" yaml:"update-types"`
+}
+
+// DependabotConfigWarning is a problem found in the configuration, with the line it was found on.
+type DependabotConfigWarning struct {
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// dependabotStringList accepts either a single string or a list of strings, as Dependabot does for
+// some options.
+type dependabotStringList []string
+
+func (l *dependabotStringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = []string{node.Value}
+		return nil
+	}
+	var values []string
+	if err := node.Decode(&values); err != nil {
+		return err
+	}
+	*l = values
+	return nil
+}
+
+var (
+	dependabotTopLevelKeys = []string{"version", "updates", "registries", "enable-beta-ecosystems", "multi-ecosystem-groups"}
+	dependabotUpdateKeys   = []string{
+		"package-ecosystem", "directory", "directories", "schedule", "allow", "assignees", "commit-message",
+		"cooldown", "exclude-paths", "groups", "ignore", "insecure-external-code-execution", "labels",
+		"milestone", "multi-ecosystem-group", "open-pull-requests-limit", "patterns", "pull-request-branch-name",
+		"rebase-strategy", "registries", "reviewers", "target-branch", "vendor", "versioning-strategy",
+	}
+	dependabotScheduleKeys = []string{"interval", "day", "time", "timezone", "cronjob"}
+	dependabotIgnoreKeys   = []string{"dependency-name", "versions", "update-types"}
+
+	dependabotEcosystems = []string{
+		"bun", "bundler", "cargo", "composer", "devcontainers", "docker", "docker-compose", "dotnet-sdk", "elm",
+		"github-actions", "gitsubmodule", "gomod", "gradle", "helm", "maven", "mix", "npm", "nuget", "pip", "pub",
+		"swift", "terraform", "uv",
+	}
+	dependabotIntervals   = []string{"daily", "weekly", "monthly", "quarterly", "semiannually", "yearly", "cron"}
+	dependabotDays        = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+	dependabotUpdateTypes = []string{"version-update:semver-major", "version-update:semver-minor", "version-update:semver-patch"}
+
+	dependabotTimeRegex = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+)
+
+// parseDependabotConfig interprets the content of a Dependabot configuration file. Problems that don't
+// prevent the file from being read are reported as warnings, only malformed YAML is an error.
+func parseDependabotConfig(content []byte) (*DependabotConfig, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	config := &DependabotConfig{
+		Updates:  []DependabotUpdate{},
+		Warnings: []DependabotConfigWarning{},
+	}
+	warn := func(node *yaml.Node, format string, args ...any) {
+		warning := DependabotConfigWarning{Message: fmt.Sprintf(format, args...)}
+		if node != nil {
+			warning.Line = node.Line
+		}
+		config.Warnings = append(config.Warnings, warning)
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		warn(nil, "the configuration is empty or is not a mapping")
+		return config, nil
+	}
+	root := doc.Content[0]
+	checkUnknownYAMLKeys(root, dependabotTopLevelKeys, "", warn)
+
+	versionNode := yamlMappingValue(root, "version")
+	switch {
+	case versionNode == nil:
+		warn(root, "missing required key version")
+	case versionNode.Decode(&config.Version) != nil || config.Version != 2:
+		warn(versionNode, "version must be 2, got %s", versionNode.Value)
+	}
+
+	if registries := yamlMappingValue(root, "registries"); registries != nil && registries.Kind == yaml.MappingNode {
+		for i := 0; i < len(registries.Content); i += 2 {
+			config.Registries = append(config.Registries, registries.Content[i].Value)
+		}
+	}
+
+	updatesNode := yamlMappingValue(root, "updates")
+	if updatesNode == nil || updatesNode.Kind != yaml.SequenceNode || len(updatesNode.Content) == 0 {
+		warn(root, "updates must be a non-empty list")
+		return config, nil
+	}
+
+	for i, updateNode := range updatesNode.Content {
+		entry := fmt.Sprintf("updates[%d]", i)
+		if updateNode.Kind != yaml.MappingNode {
+			warn(updateNode, "%s must be a mapping", entry)
+			continue
+		}
+		checkUnknownYAMLKeys(updateNode, dependabotUpdateKeys, entry+".", warn)
+
+		var update DependabotUpdate
+		if err := updateNode.Decode(&update); err != nil {
+			warn(updateNode, "%s could not be read: %s", entry, err.Error())
+			continue
+		}
+		if groups := yamlMappingValue(updateNode, "groups"); groups != nil && groups.Kind == yaml.MappingNode {
+			for j := 0; j < len(groups.Content); j += 2 {
+				update.Groups = append(update.Groups, groups.Content[j].Value)
+			}
+		}
+
+		switch {
+		case update.PackageEcosystem == "":
+			warn(updateNode, "%s is missing required key package-ecosystem", entry)
+		case !slices.Contains(dependabotEcosystems, update.PackageEcosystem):
+			warn(yamlMappingValue(updateNode, "package-ecosystem"), "%s has unknown package-ecosystem %q", entry, update.PackageEcosystem)
+		}
+		if update.Directory == "" && len(update.Directories) == 0 {
+			warn(updateNode, "%s must set directory or directories", entry)
+		}
+		for _, registry := range update.Registries {
+			if registry != "*" && !slices.Contains(config.Registries, registry) {
+				warn(yamlMappingValue(updateNode, "registries"), "%s uses registry %q, which is not defined in registries", entry, registry)
+			}
+		}
+
+		validateDependabotSchedule(updateNode, update.Schedule, entry, warn)
+
+		if ignoreNode := yamlMappingValue(updateNode, "ignore"); ignoreNode != nil && ignoreNode.Kind == yaml.SequenceNode {
+			for j, ruleNode := range ignoreNode.Content {
+				rule := fmt.Sprintf("%s.ignore[%d]", entry, j)
+				checkUnknownYAMLKeys(ruleNode, dependabotIgnoreKeys, rule+".", warn)
+				if j >= len(update.Ignore) {
+					continue
+				}
+				if update.Ignore[j].DependencyName == "" {
+					warn(ruleNode, "%s is missing required key dependency-name", rule)
+				}
+				for _, updateType := range update.Ignore[j].UpdateTypes {
+					if !slices.Contains(dependabotUpdateTypes, updateType) {
+						warn(yamlMappingValue(ruleNode, "update-types"), "%s has unknown update type %q", rule, updateType)
+					}
+				}
+			}
+		}
+
+		config.Updates = append(config.Updates, update)
+	}
+
+	return config, nil
+}
+
+// validateDependabotSchedule reports problems with the schedule of an update entry.
+func validateDependabotSchedule(updateNode *yaml.Node, schedule DependabotSchedule, entry string, warn func(*yaml.Node, string, ...any)) {
+	scheduleNode := yamlMappingValue(updateNode, "schedule")
+	if scheduleNode == nil {
+		warn(updateNode, "%s is missing required key schedule", entry)
+		return
+	}
+	checkUnknownYAMLKeys(scheduleNode, dependabotScheduleKeys, entry+".schedule.", warn)
+
+	switch {
+	case schedule.Interval == "":
+		warn(scheduleNode, "%s.schedule is missing required key interval", entry)
+	case !slices.Contains(dependabotIntervals, schedule.Interval):
+		warn(yamlMappingValue(scheduleNode, "interval"), "%s.schedule has invalid interval %q, must be one of %v", entry, schedule.Interval, dependabotIntervals)
+	}
+	if schedule.Interval == "cron" && schedule.Cronjob == "" {
+		warn(scheduleNode, "%s.schedule uses the cron interval but has no cronjob", entry)
+	}
+	if schedule.Day != "" {
+		if !slices.Contains(dependabotDays, schedule.Day) {
+			warn(yamlMappingValue(scheduleNode, "day"), "%s.schedule has invalid day %q", entry, schedule.Day)
+		} else if schedule.Interval != "weekly" {
+			warn(yamlMappingValue(scheduleNode, "day"), "%s.schedule sets day, which only applies to the weekly interval", entry)
+		}
+	}
+	if schedule.Time != "" && !dependabotTimeRegex.MatchString(schedule.Time) {
+		warn(yamlMappingValue(scheduleNode, "time"), "%s.schedule has invalid time %q, must be formatted as hh:mm", entry, schedule.Time)
+	}
+}
+
+// yamlMappingValue returns the value of key in a mapping node, or nil if it is not set.
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// checkUnknownYAMLKeys reports the keys of a mapping node that are not in known.
+func checkUnknownYAMLKeys(node *yaml.Node, known []string, prefix string, warn func(*yaml.Node, string, ...any)) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	var unknown []*yaml.Node
+	for i := 0; i < len(node.Content); i += 2 {
+		if !slices.Contains(known, node.Content[i].Value) {
+			unknown = append(unknown, node.Content[i])
+		}
+	}
+	sort.SliceStable(unknown, func(i, j int) bool { return unknown[i].Line < unknown[j].Line })
+	for _, key := range unknown {
+		warn(key, "unknown key %s%s", prefix, key.Value)
+	}
+}
+
+// GetDependabotConfig creates a tool to read and interpret the Dependabot configuration of a repository.
+func GetDependabotConfig(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"get_dependabot_config",
+			mcp.WithDescription(t("TOOL_GET_DEPENDABOT_CONFIG_DESCRIPTION", "Read the Dependabot version updates configuration (.github/dependabot.yml) of a GitHub repository. Returns the configured package ecosystems, schedules and ignore rules in structured form, with warnings for unknown keys and invalid values.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_DEPENDABOT_CONFIG_USER_TITLE", "Get Dependabot configuration"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithString("ref",
+				mcp.Description("The branch, tag or commit to read the configuration from. Defaults to the default branch."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			for _, path := range dependabotConfigPaths {
+				file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					_ = resp.Body.Close()
+					continue
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get Dependabot configuration", resp, err), nil
+				}
+				_ = resp.Body.Close()
+
+				if file == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is a directory, not a Dependabot configuration file", path)), nil
+				}
+				content, err := file.GetContent()
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode Dependabot configuration: %w", err)
+				}
+
+				config, err := parseDependabotConfig([]byte(content))
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is not valid: %s", path, err.Error())), nil
+				}
+				config.Path = path
+
+				return MarshalledTextResult(config), nil
+			}
+
+			return mcp.NewToolResultError(fmt.Sprintf("no Dependabot configuration found in %s/%s, looked for %v", owner, repo, dependabotConfigPaths)), nil
+		}
+}
+
+// UpdateDependabotConfig creates a tool to replace the Dependabot configuration of a repository, after
+// checking it the same way get_dependabot_config does.
+func UpdateDependabotConfig(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"update_dependabot_config",
+			mcp.WithDescription(t("TOOL_UPDATE_DEPENDABOT_CONFIG_DESCRIPTION", "Create or replace the Dependabot version updates configuration (.github/dependabot.yml) of a GitHub repository. The content is checked first, and it is not committed if it has warnings unless ignore_warnings is set.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_DEPENDABOT_CONFIG_USER_TITLE", "Update Dependabot configuration"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("The complete YAML content of the configuration."),
+			),
+			mcp.WithString("message",
+				mcp.Description("The commit message. Defaults to 'Update Dependabot configuration'."),
+			),
+			mcp.WithString("branch",
+				mcp.Description("The branch to commit to. Defaults to the default branch."),
+			),
+			mcp.WithBoolean("ignore_warnings",
+				mcp.Description("Commit the configuration even if it has warnings."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := RequiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := OptionalParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if message == "" {
+				message = "Update Dependabot configuration"
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ignoreWarnings, err := OptionalParam[bool](request, "ignore_warnings")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			config, err := parseDependabotConfig([]byte(content))
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("the configuration is not valid: %s", err.Error())), nil
+			}
+			if len(config.Warnings) > 0 && !ignoreWarnings {
+				warnings := make([]string, 0, len(config.Warnings))
+				for _, w := range config.Warnings {
+					if w.Line > 0 {
+						warnings = append(warnings, fmt.Sprintf("line %d: %s", w.Line, w.Message))
+					} else {
+						warnings = append(warnings, w.Message)
+					}
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("the configuration was not committed, fix its warnings or set ignore_warnings: %s", strings.Join(warnings, "; "))), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Replace the existing file wherever Dependabot finds it, otherwise create the first path
+			path := dependabotConfigPaths[0]
+			var sha *string
+			for _, p := range dependabotConfigPaths {
+				file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, p, &github.RepositoryContentGetOptions{Ref: branch})
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					_ = resp.Body.Close()
+					continue
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get Dependabot configuration", resp, err), nil
+				}
+				_ = resp.Body.Close()
+
+				if file == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is a directory, not a Dependabot configuration file", p)), nil
+				}
+				path = p
+				sha = file.SHA
+				break
+			}
+
+			opts := &github.RepositoryContentFileOptions{
+				Message: github.Ptr(message),
+				Content: []byte(content),
+				SHA:     sha,
+			}
+			if branch != "" {
+				opts.Branch = github.Ptr(branch)
+			}
+			result, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update Dependabot configuration", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(map[string]any{
+				"path":     path,
+				"created":  sha == nil,
+				"commit":   result.Commit.GetSHA(),
+				"html_url": result.GetContent().GetHTMLURL(),
+				"warnings": config.Warnings,
+			}), nil
+		}
+}
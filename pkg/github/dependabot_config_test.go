@@ -0,0 +1,447 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseDependabotConfig(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          string
+		expectError      bool
+		expectedUpdates  int
+		expectedWarnings []DependabotConfigWarning
+	}{
+		{
+			name: "valid configuration",
+			content: `version: 2
+registries:
+  npm-internal:
+    type: npm-registry
+    url: https://npm.example.com
+updates:
+  - package-ecosystem: npm
+    directory: /
+    registries: [npm-internal]
+    schedule:
+      interval: weekly
+      day: monday
+      time: "09:00"
+      timezone: Europe/Berlin
+    open-pull-requests-limit: 5
+    groups:
+      dev-dependencies:
+        dependency-type: development
+    ignore:
+      - dependency-name: express
+        versions: ["5.x"]
+      - dependency-name: "*"
+        update-types: ["version-update:semver-major"]
+  - package-ecosystem: github-actions
+    directories: ["/", "/tools"]
+    schedule:
+      interval: cron
+      cronjob: "0 6 * * 1"
+`,
+			expectedUpdates:  2,
+			expectedWarnings: []DependabotConfigWarning{},
+		},
+		{
+			name: "unknown keys and invalid values",
+			content: `version: 2
+update:
+  - package-ecosystem: npm
+updates:
+  - package-ecosystem: nmp
+    directory: /
+    registry: npm-internal
+    schedule:
+      interval: hourly
+      day: funday
+      time: "9am"
+    ignore:
+      - dependency-name: lodash
+        update-types: ["major"]
+  - package-ecosystem: docker
+    directory: /
+    schedule:
+      interval: daily
+      day: monday
+  - package-ecosystem: gomod
+    schedule:
+      interval: cron
+`,
+			expectedUpdates: 3,
+			expectedWarnings: []DependabotConfigWarning{
+				{Line: 2, Message: "unknown key update"},
+				{Line: 7, Message: "unknown key updates[0].registry"},
+				{Line: 5, Message: `updates[0] has unknown package-ecosystem "nmp"`},
+				{Line: 9, Message: `updates[0].schedule has invalid interval "hourly", must be one of [daily weekly monthly quarterly semiannually yearly cron]`},
+				{Line: 10, Message: `updates[0].schedule has invalid day "funday"`},
+				{Line: 11, Message: `updates[0].schedule has invalid time "9am", must be formatted as hh:mm`},
+				{Line: 14, Message: `updates[0].ignore[0] has unknown update type "major"`},
+				{Line: 19, Message: "updates[1].schedule sets day, which only applies to the weekly interval"},
+				{Line: 20, Message: "updates[2] must set directory or directories"},
+				{Line: 22, Message: "updates[2].schedule uses the cron interval but has no cronjob"},
+			},
+		},
+		{
+			name: "wrong version and missing updates",
+			content: `version: 1
+`,
+			expectedWarnings: []DependabotConfigWarning{
+				{Line: 1, Message: "version must be 2, got 1"},
+				{Line: 1, Message: "updates must be a non-empty list"},
+			},
+		},
+		{
+			name:        "malformed YAML",
+			content:     "version: 2\nupdates: [\n",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := parseDependabotConfig([]byte(tc.content))
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Len(t, config.Updates, tc.expectedUpdates)
+			assert.Equal(t, tc.expectedWarnings, config.Warnings)
+		})
+	}
+
+	t.Run("interprets ecosystems, schedules and ignore rules", func(t *testing.T) {
+		config, err := parseDependabotConfig([]byte(tests[0].content))
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, config.Version)
+		assert.Equal(t, []string{"npm-internal"}, config.Registries)
+
+		npm := config.Updates[0]
+		assert.Equal(t, "npm", npm.PackageEcosystem)
+		assert.Equal(t, "/", npm.Directory)
+		assert.Equal(t, DependabotSchedule{Interval: "weekly", Day: "monday", Time: "09:00", Timezone: "Europe/Berlin"}, npm.Schedule)
+		require.NotNil(t, npm.OpenPullRequestsLimit)
+		assert.Equal(t, 5, *npm.OpenPullRequestsLimit)
+		assert.Equal(t, []string{"dev-dependencies"}, npm.Groups)
+		assert.Equal(t, []DependabotIgnoreRule{
+			{DependencyName: "express", Versions: dependabotStringList{"5.x"}},
+			{DependencyName: "*", UpdateTypes: []string{"version-update:semver-major"}},
+		}, npm.Ignore)
+
+		actions := config.Updates[1]
+		assert.Equal(t, []string{"/", "/tools"}, actions.Directories)
+		assert.Equal(t, "0 6 * * 1", actions.Schedule.Cronjob)
+	})
+}
+
+func Test_GetDependabotConfig(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDependabotConfig(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_dependabot_config", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	configFile := func(path, content string) *github.RepositoryContent {
+		return &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			Path:     github.Ptr(path),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+		}
+	}
+	validConfig := "version: 2\nupdates:\n  - package-ecosystem: gomod\n    directory: /\n    schedule:\n      interval: weekly\n"
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedPath   string
+	}{
+		{
+			name: "reads dependabot.yml at a ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{"ref": "release"}).andThen(
+						mockResponse(t, http.StatusOK, configFile(".github/dependabot.yml", validConfig)),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "release",
+			},
+			expectedPath: ".github/dependabot.yml",
+		},
+		{
+			name: "falls back to dependabot.yaml",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path != "/repos/owner/repo/contents/.github/dependabot.yaml" {
+							w.WriteHeader(http.StatusNotFound)
+							_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+							return
+						}
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write(mock.MustMarshal(configFile(".github/dependabot.yaml", validConfig)))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedPath: ".github/dependabot.yaml",
+		},
+		{
+			name: "no configuration",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "no Dependabot configuration found in owner/repo",
+		},
+		{
+			name: "malformed configuration",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					configFile(".github/dependabot.yml", "version: 2\nupdates: [\n"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: ".github/dependabot.yml is not valid: failed to parse YAML",
+		},
+		{
+			name: "API error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusForbidden, `{"message": "Forbidden"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get Dependabot configuration",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetDependabotConfig(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var config DependabotConfig
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &config))
+
+			assert.Equal(t, tc.expectedPath, config.Path)
+			assert.Equal(t, 2, config.Version)
+			require.Len(t, config.Updates, 1)
+			assert.Equal(t, "gomod", config.Updates[0].PackageEcosystem)
+			assert.Equal(t, "weekly", config.Updates[0].Schedule.Interval)
+			assert.Empty(t, config.Warnings)
+		})
+	}
+}
+
+func Test_UpdateDependabotConfig(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateDependabotConfig(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_dependabot_config", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "content"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	validConfig := "version: 2\nupdates:\n  - package-ecosystem: gomod\n    directory: /\n    schedule:\n      interval: weekly\n"
+	notFound := mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)
+	commitResponse := &github.RepositoryContentResponse{
+		Content: &github.RepositoryContent{HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/.github/dependabot.yml")},
+		Commit:  github.Commit{SHA: github.Ptr("commit-sha")},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectedErrMsg  string
+		expectedPath    string
+		expectedCreated bool
+	}{
+		{
+			name: "creates the configuration",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, notFound),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectPath(t, "/repos/owner/repo/contents/.github/dependabot.yml").andThen(
+						expectRequestBody(t, map[string]any{
+							"message": "Update Dependabot configuration",
+							"content": base64.StdEncoding.EncodeToString([]byte(validConfig)),
+							"branch":  "deps",
+						}).andThen(
+							mockResponse(t, http.StatusCreated, commitResponse),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"content": validConfig,
+				"branch":  "deps",
+			},
+			expectedPath:    ".github/dependabot.yml",
+			expectedCreated: true,
+		},
+		{
+			name: "replaces the existing dependabot.yaml",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path != "/repos/owner/repo/contents/.github/dependabot.yaml" {
+							notFound(w, r)
+							return
+						}
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write(mock.MustMarshal(&github.RepositoryContent{
+							Type: github.Ptr("file"),
+							Path: github.Ptr(".github/dependabot.yaml"),
+							SHA:  github.Ptr("old-sha"),
+						}))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectPath(t, "/repos/owner/repo/contents/.github/dependabot.yaml").andThen(
+						expectRequestBody(t, map[string]any{
+							"message": "Check gomod weekly",
+							"content": base64.StdEncoding.EncodeToString([]byte(validConfig)),
+							"sha":     "old-sha",
+						}).andThen(
+							mockResponse(t, http.StatusOK, commitResponse),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"content": validConfig,
+				"message": "Check gomod weekly",
+			},
+			expectedPath: ".github/dependabot.yaml",
+		},
+		{
+			name:         "refuses a configuration with warnings",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"content": "version: 2\nupdates:\n  - package-ecosystem: gomod\n    directory: /\n    schedule:\n      interval: hourly\n",
+			},
+			expectError:    true,
+			expectedErrMsg: "fix its warnings or set ignore_warnings: line 6",
+		},
+		{
+			name:         "refuses malformed YAML",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"content": "version: 2\nupdates: [\n",
+			},
+			expectError:    true,
+			expectedErrMsg: "the configuration is not valid: failed to parse YAML",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateDependabotConfig(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				Path    string `json:"path"`
+				Created bool   `json:"created"`
+				Commit  string `json:"commit"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+			assert.Equal(t, tc.expectedPath, response.Path)
+			assert.Equal(t, tc.expectedCreated, response.Created)
+			assert.Equal(t, "commit-sha", response.Commit)
+		})
+	}
+}
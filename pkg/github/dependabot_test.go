@@ -3,10 +3,12 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"testing"
 
 	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/cursor"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
@@ -164,7 +166,9 @@ func Test_ListDependabotAlerts(t *testing.T) {
 				mock.WithRequestMatchHandler(
 					mock.GetReposDependabotAlertsByOwnerByRepo,
 					expectQueryParams(t, map[string]string{
-						"state": "open",
+						"state":    "open",
+						"page":     "1",
+						"per_page": "30",
 					}).andThen(
 						mockResponse(t, http.StatusOK, []*github.DependabotAlert{&criticalAlert}),
 					),
@@ -185,6 +189,8 @@ func Test_ListDependabotAlerts(t *testing.T) {
 					mock.GetReposDependabotAlertsByOwnerByRepo,
 					expectQueryParams(t, map[string]string{
 						"severity": "high",
+						"page":     "1",
+						"per_page": "30",
 					}).andThen(
 						mockResponse(t, http.StatusOK, []*github.DependabotAlert{&highSeverityAlert}),
 					),
@@ -203,7 +209,10 @@ func Test_ListDependabotAlerts(t *testing.T) {
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.GetReposDependabotAlertsByOwnerByRepo,
-					expectQueryParams(t, map[string]string{}).andThen(
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
 						mockResponse(t, http.StatusOK, []*github.DependabotAlert{&criticalAlert, &highSeverityAlert}),
 					),
 				),
@@ -215,6 +224,27 @@ func Test_ListDependabotAlerts(t *testing.T) {
 			expectError:    false,
 			expectedAlerts: []*github.DependabotAlert{&criticalAlert, &highSeverityAlert},
 		},
+		{
+			name: "alerts listing resumes from the after of a cursor",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependabotAlertsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"per_page": "1",
+						"after":    "Y3Vyc29yOnYyOpHOAAAAAQ==",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.DependabotAlert{&highSeverityAlert}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"cursor": cursor.Encode(cursor.Position{PerPage: 1, After: "Y3Vyc29yOnYyOpHOAAAAAQ=="}),
+			},
+			expectError:    false,
+			expectedAlerts: []*github.DependabotAlert{&highSeverityAlert},
+		},
 		{
 			name: "alerts listing fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -274,3 +304,471 @@ func Test_ListDependabotAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListOrgDependabotAlerts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgDependabotAlerts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_dependabot_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "ecosystem")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockAlerts := []*github.DependabotAlert{
+		{
+			Number:     github.Ptr(7),
+			State:      github.Ptr("open"),
+			Repository: &github.Repository{FullName: github.Ptr("org/repo")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful organization alerts listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsDependabotAlertsByOrg,
+					expectQueryParams(t, map[string]string{
+						"state":     "open",
+						"ecosystem": "npm",
+						"page":      "1",
+						"per_page":  "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAlerts),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "org",
+				"state":     "open",
+				"ecosystem": "npm",
+			},
+		},
+		{
+			name: "organization alerts listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsDependabotAlertsByOrg,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list alerts for organization 'org'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgDependabotAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returnedAlerts []*github.DependabotAlert
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedAlerts))
+			require.Len(t, returnedAlerts, 1)
+			assert.Equal(t, "org/repo", returnedAlerts[0].GetRepository().GetFullName())
+		})
+	}
+}
+
+func Test_DismissDependabotAlert(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DismissDependabotAlert(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "dismiss_dependabot_alert", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "alertNumber"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedState  string
+	}{
+		{
+			name: "successful dismissal",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposDependabotAlertsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state":            "dismissed",
+						"dismissed_reason": "not_used",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.DependabotAlert{Number: github.Ptr(7), State: github.Ptr("dismissed")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(7),
+				"reason":      "not_used",
+			},
+			expectedState: "dismissed",
+		},
+		{
+			name: "successful reopen",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposDependabotAlertsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state": "open",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.DependabotAlert{Number: github.Ptr(7), State: github.Ptr("open")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(7),
+				"reopen":      true,
+			},
+			expectedState: "open",
+		},
+		{
+			name:         "dismissal without reason",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(7),
+			},
+			expectError:    true,
+			expectedErrMsg: "reason is required to dismiss an alert",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DismissDependabotAlert(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returnedAlert github.DependabotAlert
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedAlert))
+			assert.Equal(t, tc.expectedState, returnedAlert.GetState())
+		})
+	}
+}
+
+func Test_BulkDismissDependabotAlerts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BulkDismissDependabotAlerts(stubGetClientFn(mockClient), false, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "bulk_dismiss_dependabot_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	readOnlyTool, _ := BulkDismissDependabotAlerts(stubGetClientFn(mockClient), true, translations.NullTranslationHelper)
+	assert.NotContains(t, readOnlyTool.InputSchema.Properties, "dry_run")
+	assert.True(t, *readOnlyTool.Annotations.ReadOnlyHint)
+
+	alert := func(number int, pkg, ghsa, cve string) *github.DependabotAlert {
+		return &github.DependabotAlert{
+			Number:  github.Ptr(number),
+			State:   github.Ptr("open"),
+			HTMLURL: github.Ptr(fmt.Sprintf("https://github.com/owner/repo/security/dependabot/%d", number)),
+			Dependency: &github.Dependency{
+				Package: &github.VulnerabilityPackage{Name: github.Ptr(pkg), Ecosystem: github.Ptr("npm")},
+			},
+			SecurityAdvisory: &github.DependabotSecurityAdvisory{
+				GHSAID:   github.Ptr(ghsa),
+				CVEID:    github.Ptr(cve),
+				Severity: github.Ptr("high"),
+			},
+		}
+	}
+	// The first page links to the second with an after cursor, as the API does
+	pagedAlerts := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+		if r.URL.Query().Get("after") == "" {
+			w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/dependabot/alerts?after=cursor2>; rel="next"`)
+			_, _ = w.Write(mock.MustMarshal([]*github.DependabotAlert{
+				alert(1, "lodash", "GHSA-aaaa-aaaa-aaaa", "CVE-2024-0001"),
+				alert(2, "minimist", "GHSA-bbbb-bbbb-bbbb", "CVE-2024-0002"),
+			}))
+			return
+		}
+		_, _ = w.Write(mock.MustMarshal([]*github.DependabotAlert{
+			alert(3, "lodash", "GHSA-aaaa-aaaa-aaaa", "CVE-2024-0001"),
+		}))
+	})
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedMatched   []int
+		expectedDismissed []int
+		expectedFailed    []int
+	}{
+		{
+			name: "dry run matches a CVE across pages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposDependabotAlertsByOwnerByRepo, pagedAlerts),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"cve_id":  "cve-2024-0001",
+				"dry_run": true,
+			},
+			expectedMatched: []int{1, 3},
+		},
+		{
+			name: "dismisses the alerts of a package",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependabotAlertsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":    "open",
+						"package":  "lodash",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.DependabotAlert{
+							alert(1, "lodash", "GHSA-aaaa-aaaa-aaaa", "CVE-2024-0001"),
+							alert(3, "lodash", "GHSA-aaaa-aaaa-aaaa", "CVE-2024-0001"),
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposDependabotAlertsByOwnerByRepoByAlertNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path == "/repos/owner/repo/dependabot/alerts/3" {
+							w.WriteHeader(http.StatusForbidden)
+							_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+							return
+						}
+						_, _ = w.Write(mock.MustMarshal(alert(1, "lodash", "GHSA-aaaa-aaaa-aaaa", "CVE-2024-0001")))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"package": "lodash",
+				"reason":  "not_used",
+			},
+			expectedDismissed: []int{1},
+			expectedFailed:    []int{3},
+		},
+		{
+			name:         "refuses to match every alert",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"reason": "not_used",
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one of cve_id, ghsa_id or package is required",
+		},
+		{
+			name:         "requires a reason unless dry run",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"ghsa_id": "GHSA-aaaa-aaaa-aaaa",
+			},
+			expectError:    true,
+			expectedErrMsg: "reason is required to dismiss alerts",
+		},
+	}
+
+	numbers := func(alerts []dependabotAlertMatch) []int {
+		result := []int{}
+		for _, a := range alerts {
+			result = append(result, a.Number)
+		}
+		return result
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := BulkDismissDependabotAlerts(stubGetClientFn(client), false, translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var summary struct {
+				DryRun    bool                   `json:"dry_run"`
+				Matched   []dependabotAlertMatch `json:"matched"`
+				Dismissed []dependabotAlertMatch `json:"dismissed"`
+				Failed    []dependabotAlertMatch `json:"failed"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+			if tc.expectedMatched != nil {
+				assert.True(t, summary.DryRun)
+				assert.Equal(t, tc.expectedMatched, numbers(summary.Matched))
+				assert.Equal(t, "lodash", summary.Matched[0].Package)
+				return
+			}
+			assert.Equal(t, tc.expectedDismissed, numbers(summary.Dismissed))
+			assert.Equal(t, tc.expectedFailed, numbers(summary.Failed))
+			assert.Contains(t, summary.Failed[0].Error, "failed to dismiss alert")
+		})
+	}
+}
+
+func Test_ListDependabotPullRequests(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListDependabotPullRequests(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_dependabot_pull_requests", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockResult := &github.IssuesSearchResult{
+		Total: github.Ptr(1),
+		Issues: []*github.Issue{
+			{
+				Number:  github.Ptr(12),
+				Title:   github.Ptr("Bump lodash from 4.17.20 to 4.17.21"),
+				State:   github.Ptr("open"),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/pull/12"),
+				Labels:  []*github.Label{{Name: github.Ptr("dependencies")}, {Name: github.Ptr("security")}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "open security pull requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					expectQueryParams(t, map[string]string{
+						"q":        `repo:owner/repo is:pr author:app/dependabot is:open label:"security"`,
+						"sort":     "created",
+						"order":    "desc",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"label": "security",
+			},
+		},
+		{
+			name:         "invalid state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"state": "draft",
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid state "draft"`,
+		},
+		{
+			name: "search fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"state": "all",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list dependabot pull requests for repository 'owner/repo'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListDependabotPullRequests(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				TotalCount   int                     `json:"total_count"`
+				PullRequests []dependabotPullRequest `json:"pull_requests"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 1, response.TotalCount)
+			require.Len(t, response.PullRequests, 1)
+			assert.Equal(t, 12, response.PullRequests[0].Number)
+			assert.Equal(t, []string{"dependencies", "security"}, response.PullRequests[0].Labels)
+		})
+	}
+}
@@ -0,0 +1,586 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ListDeployments creates a tool to list the deployments of a repository.
+func ListDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_deployments",
+			mcp.WithDescription(t("TOOL_LIST_DEPLOYMENTS_DESCRIPTION", "List the deployments of a repository, newest first. Use list_deployment_statuses to see whether a deployment succeeded.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPLOYMENTS_USER_TITLE", "List deployments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Description("Only return deployments to this environment"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Only return deployments of this branch, tag or SHA"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Only return deployments of this commit SHA"),
+			),
+			mcp.WithString("task",
+				mcp.Description("Only return deployments of this task, e.g. deploy or deploy:migrations"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := OptionalParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			task, err := OptionalParam[string](request, "task")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			deployments, resp, err := client.Repositories.ListDeployments(ctx, owner, repo, &github.DeploymentsListOptions{
+				Environment: environment,
+				Ref:         ref,
+				SHA:         sha,
+				Task:        task,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list deployments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(deployments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListDeploymentStatuses creates a tool to list the statuses of a deployment.
+func ListDeploymentStatuses(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_deployment_statuses",
+			mcp.WithDescription(t("TOOL_LIST_DEPLOYMENT_STATUSES_DESCRIPTION", "List the statuses of a deployment, newest first. The first one is the current state of the deployment.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPLOYMENT_STATUSES_USER_TITLE", "List deployment statuses"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("deployment_id",
+				mcp.Required(),
+				mcp.Description("ID of the deployment"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deploymentID, err := RequiredInt(request, "deployment_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			statuses, resp, err := client.Repositories.ListDeploymentStatuses(ctx, owner, repo, int64(deploymentID), &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list statuses of deployment %d", deploymentID), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(statuses)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateDeployment creates a tool to create a deployment of a ref to an environment.
+func CreateDeployment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_deployment",
+			mcp.WithDescription(t("TOOL_CREATE_DEPLOYMENT_DESCRIPTION", "Create a deployment of a branch, tag or SHA to an environment. GitHub only records the deployment, the systems listening for deployment events carry it out and report back with deployment statuses. By default the commit statuses of the ref must all pass first.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_DEPLOYMENT_USER_TITLE", "Create deployment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Branch, tag or SHA to deploy"),
+			),
+			mcp.WithString("environment",
+				mcp.Description("Environment to deploy to, defaults to production"),
+			),
+			mcp.WithString("task",
+				mcp.Description("Task to run, defaults to deploy"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the deployment"),
+			),
+			mcp.WithBoolean("auto_merge",
+				mcp.Description("Merge the default branch into ref first if it is behind, defaults to true"),
+			),
+			mcp.WithArray("required_contexts",
+				mcp.Description("Status contexts that must pass on ref before deploying. Defaults to all of them, an empty list skips the check"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithObject("payload",
+				mcp.Description("Extra information for the systems carrying out the deployment"),
+			),
+			mcp.WithBoolean("transient_environment",
+				mcp.Description("The environment is temporary and will be destroyed, such as a review app"),
+			),
+			mcp.WithBoolean("production_environment",
+				mcp.Description("The environment is one that end users interact with"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := OptionalParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			task, err := OptionalParam[string](request, "task")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			deployment := &github.DeploymentRequest{
+				Ref:         github.Ptr(ref),
+				Environment: ToStringPtr(environment),
+				Task:        ToStringPtr(task),
+				Description: ToStringPtr(description),
+			}
+			args := request.GetArguments()
+			for name, field := range map[string]**bool{
+				"auto_merge":             &deployment.AutoMerge,
+				"transient_environment":  &deployment.TransientEnvironment,
+				"production_environment": &deployment.ProductionEnvironment,
+			} {
+				if _, ok := args[name]; ok {
+					v, err := OptionalParam[bool](request, name)
+					if err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+					*field = github.Ptr(v)
+				}
+			}
+			if _, ok := args["required_contexts"]; ok {
+				contexts, err := OptionalStringArrayParam(request, "required_contexts")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				// An empty list has to be sent as such, it is what skips the status check
+				if contexts == nil {
+					contexts = []string{}
+				}
+				deployment.RequiredContexts = &contexts
+			}
+			if payload, ok := args["payload"]; ok {
+				if _, isObject := payload.(map[string]any); !isObject {
+					return mcp.NewToolResultError("payload must be an object"), nil
+				}
+				deployment.Payload = payload
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateDeployment(ctx, owner, repo, deployment)
+			// GitHub answers 202 without a deployment when it merged the default branch into ref instead
+			if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
+				return mcp.NewToolResultText(fmt.Sprintf("No deployment was created: the default branch was merged into %s first, create the deployment again once its checks pass", ref)), nil
+			}
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusConflict {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create deployment: the required status contexts of the ref did not pass, or merging the default branch into it conflicted", resp, err), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create deployment", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateDeploymentStatus creates a tool to report the state of a deployment.
+func CreateDeploymentStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_deployment_status",
+			mcp.WithDescription(t("TOOL_CREATE_DEPLOYMENT_STATUS_DESCRIPTION", "Set the state of a deployment, as the system carrying it out. A success status marks the earlier deployments to the same environment inactive unless auto_inactive is false.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_DEPLOYMENT_STATUS_USER_TITLE", "Create deployment status"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("deployment_id",
+				mcp.Required(),
+				mcp.Description("ID of the deployment"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("State of the deployment"),
+				mcp.Enum("error", "failure", "inactive", "in_progress", "queued", "pending", "success"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the status, at most 140 characters"),
+			),
+			mcp.WithString("log_url",
+				mcp.Description("URL of the deployment output"),
+			),
+			mcp.WithString("environment_url",
+				mcp.Description("URL to access the deployed environment"),
+			),
+			mcp.WithBoolean("auto_inactive",
+				mcp.Description("Mark earlier successful deployments to the environment inactive on success, defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deploymentID, err := RequiredInt(request, "deployment_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if n := len([]rune(description)); n > maxStatusDescriptionLength {
+				return mcp.NewToolResultError(fmt.Sprintf("description is %d characters long, at most %d are allowed", n, maxStatusDescriptionLength)), nil
+			}
+			logURL, err := OptionalParam[string](request, "log_url")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environmentURL, err := OptionalParam[string](request, "environment_url")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			status := &github.DeploymentStatusRequest{
+				State:          github.Ptr(state),
+				Description:    ToStringPtr(description),
+				LogURL:         ToStringPtr(logURL),
+				EnvironmentURL: ToStringPtr(environmentURL),
+			}
+			if _, ok := request.GetArguments()["auto_inactive"]; ok {
+				autoInactive, err := OptionalParam[bool](request, "auto_inactive")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				status.AutoInactive = github.Ptr(autoInactive)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateDeploymentStatus(ctx, owner, repo, int64(deploymentID), status)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to create status of deployment %d", deploymentID), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetPendingDeployments creates a tool to list the environments a workflow run is waiting to deploy to.
+func GetPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pending_deployments",
+			mcp.WithDescription(t("TOOL_GET_PENDING_DEPLOYMENTS_DESCRIPTION", "List the environments a workflow run is waiting to deploy to, with their required reviewers, wait timer and whether you can approve them.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PENDING_DEPLOYMENTS_USER_TITLE", "Get pending deployments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pending, resp, err := client.Actions.GetPendingDeployments(ctx, owner, repo, int64(runID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pending deployments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(pending)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ReviewPendingDeployments creates a tool to approve or reject the deployments a workflow run is waiting for.
+func ReviewPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("review_pending_deployments",
+			mcp.WithDescription(t("TOOL_REVIEW_PENDING_DEPLOYMENTS_DESCRIPTION", "Approve or reject the deployments of a workflow run that wait for a review of their environment. Rejecting fails the jobs that deploy to the environment.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REVIEW_PENDING_DEPLOYMENTS_USER_TITLE", "Review pending deployments"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("Whether to approve or reject the deployments"),
+				mcp.Enum("approved", "rejected"),
+			),
+			mcp.WithString("comment",
+				mcp.Required(),
+				mcp.Description("Comment explaining the review"),
+			),
+			mcp.WithArray("environments",
+				mcp.Description("Names of the environments to review. Defaults to every pending environment you can approve"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comment, err := RequiredParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environments, err := OptionalStringArrayParam(request, "environments")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The API takes environment IDs, which only the pending deployments of the run tell
+			pending, resp, err := client.Actions.GetPendingDeployments(ctx, owner, repo, int64(runID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pending deployments", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			byName := map[string]*github.PendingDeployment{}
+			for _, p := range pending {
+				byName[p.GetEnvironment().GetName()] = p
+			}
+			var ids []int64
+			if len(environments) == 0 {
+				for _, p := range pending {
+					if p.GetCurrentUserCanApprove() {
+						ids = append(ids, p.GetEnvironment().GetID())
+					}
+				}
+				if len(ids) == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("workflow run %d has no pending deployments you can review", runID)), nil
+				}
+			} else {
+				for _, name := range environments {
+					p, ok := byName[name]
+					if !ok {
+						names := make([]string, 0, len(pending))
+						for _, p := range pending {
+							names = append(names, p.GetEnvironment().GetName())
+						}
+						return mcp.NewToolResultError(fmt.Sprintf("workflow run %d is not waiting for environment %s, pending environments: [%s]", runID, name, strings.Join(names, ", "))), nil
+					}
+					if !p.GetCurrentUserCanApprove() {
+						return mcp.NewToolResultError(fmt.Sprintf("you are not a required reviewer of environment %s", name)), nil
+					}
+					ids = append(ids, p.GetEnvironment().GetID())
+				}
+			}
+
+			deployments, resp, err := client.Actions.PendingDeployments(ctx, owner, repo, int64(runID), &github.PendingDeploymentsRequest{
+				EnvironmentIDs: ids,
+				State:          state,
+				Comment:        comment,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to review pending deployments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(deployments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,429 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_deployments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "filters deployments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDeploymentsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"environment": "production",
+						"ref":         "main",
+						"task":        "deploy",
+						"page":        "1",
+						"per_page":    "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Deployment{
+							{ID: github.Ptr(int64(7)), Environment: github.Ptr("production"), Ref: github.Ptr("main")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"ref":         "main",
+				"task":        "deploy",
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDeploymentsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list deployments",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned []*github.Deployment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, int64(7), returned[0].GetID())
+		})
+	}
+}
+
+func Test_ListDeploymentStatuses(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListDeploymentStatuses(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_deployment_statuses", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "deployment_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposDeploymentsStatusesByOwnerByRepoByDeploymentId,
+			expectPath(t, "/repos/owner/repo/deployments/7/statuses").andThen(
+				mockResponse(t, http.StatusOK, []*github.DeploymentStatus{
+					{ID: github.Ptr(int64(2)), State: github.Ptr("success")},
+					{ID: github.Ptr(int64(1)), State: github.Ptr("in_progress")},
+				}),
+			),
+		),
+	))
+	_, handler := ListDeploymentStatuses(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":         "owner",
+		"repo":          "repo",
+		"deployment_id": float64(7),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*github.DeploymentStatus
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 2)
+	assert.Equal(t, "success", returned[0].GetState())
+}
+
+func Test_CreateDeployment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateDeployment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_deployment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "successful deployment creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDeploymentsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"ref":               "v1.2.0",
+						"environment":       "staging",
+						"auto_merge":        false,
+						"required_contexts": []any{},
+						"payload":           map[string]any{"migrate": true},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Deployment{ID: github.Ptr(int64(7)), Ref: github.Ptr("v1.2.0")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"environment":       "staging",
+				"auto_merge":        false,
+				"required_contexts": []interface{}{},
+				"payload":           map[string]interface{}{"migrate": true},
+			},
+		},
+		{
+			name: "default branch merged instead",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDeploymentsByOwnerByRepo,
+					mockResponse(t, http.StatusAccepted, `{"message": "Auto-merged main into topic-branch on deployment."}`),
+				),
+			),
+			expectedText: "No deployment was created: the default branch was merged into v1.2.0 first, create the deployment again once its checks pass",
+		},
+		{
+			name: "required contexts failing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDeploymentsByOwnerByRepo,
+					mockResponse(t, http.StatusConflict, `{"message": "Conflict: Commit status checks failed for v1.2.0."}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "the required status contexts of the ref did not pass",
+		},
+		{
+			name:         "payload is not an object",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"payload": "migrate",
+			},
+			expectError:    true,
+			expectedErrMsg: "payload must be an object",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateDeployment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "v1.2.0",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+				return
+			}
+			var returned github.Deployment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, int64(7), returned.GetID())
+		})
+	}
+}
+
+func Test_CreateDeploymentStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateDeploymentStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_deployment_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "deployment_id", "state"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposDeploymentsStatusesByOwnerByRepoByDeploymentId,
+			expectRequestBody(t, map[string]any{
+				"state":           "success",
+				"environment_url": "https://staging.example.com",
+				"auto_inactive":   false,
+			}).andThen(
+				mockResponse(t, http.StatusCreated, &github.DeploymentStatus{ID: github.Ptr(int64(3)), State: github.Ptr("success")}),
+			),
+		),
+	))
+	_, handler := CreateDeploymentStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":           "owner",
+		"repo":            "repo",
+		"deployment_id":   float64(7),
+		"state":           "success",
+		"environment_url": "https://staging.example.com",
+		"auto_inactive":   false,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned github.DeploymentStatus
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "success", returned.GetState())
+}
+
+func Test_GetPendingDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pending_deployments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+			mockPendingDeployments,
+		),
+	))
+	_, handler := GetPendingDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":  "owner",
+		"repo":   "repo",
+		"run_id": float64(12345),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*github.PendingDeployment
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 2)
+	assert.True(t, returned[0].GetCurrentUserCanApprove())
+}
+
+var mockPendingDeployments = []*github.PendingDeployment{
+	{
+		Environment:           &github.PendingDeploymentEnvironment{ID: github.Ptr(int64(1)), Name: github.Ptr("staging")},
+		CurrentUserCanApprove: github.Ptr(true),
+	},
+	{
+		Environment:           &github.PendingDeploymentEnvironment{ID: github.Ptr(int64(2)), Name: github.Ptr("production")},
+		CurrentUserCanApprove: github.Ptr(false),
+	},
+}
+
+func Test_ReviewPendingDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReviewPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "review_pending_deployments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id", "state", "comment"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedBody   map[string]any
+	}{
+		{
+			name: "reviews every environment you can approve",
+			requestArgs: map[string]interface{}{
+				"state": "approved",
+			},
+			expectedBody: map[string]any{
+				"environment_ids": []any{float64(1)},
+				"state":           "approved",
+				"comment":         "ship it",
+			},
+		},
+		{
+			name: "reviews the named environments",
+			requestArgs: map[string]interface{}{
+				"state":        "rejected",
+				"environments": []interface{}{"staging"},
+			},
+			expectedBody: map[string]any{
+				"environment_ids": []any{float64(1)},
+				"state":           "rejected",
+				"comment":         "ship it",
+			},
+		},
+		{
+			name: "environment not pending",
+			requestArgs: map[string]interface{}{
+				"state":        "approved",
+				"environments": []interface{}{"qa"},
+			},
+			expectError:    true,
+			expectedErrMsg: "workflow run 12345 is not waiting for environment qa, pending environments: [staging, production]",
+		},
+		{
+			name: "not a reviewer",
+			requestArgs: map[string]interface{}{
+				"state":        "approved",
+				"environments": []interface{}{"production"},
+			},
+			expectError:    true,
+			expectedErrMsg: "you are not a required reviewer of environment production",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var body map[string]any
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					mockPendingDeployments,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write(mock.MustMarshal([]*github.Deployment{{ID: github.Ptr(int64(7))}}))
+					}),
+				),
+			))
+			_, handler := ReviewPendingDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"run_id":  float64(12345),
+				"comment": "ship it",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				assert.Nil(t, body)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
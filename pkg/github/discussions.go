@@ -15,6 +15,46 @@ import (
 
 const DefaultGraphQLPageSize = 30
 
+// maxDiscussionCommentReplies caps the replies get_discussion_comments returns per comment
+const maxDiscussionCommentReplies = 10
+
+// discussionCommentFragment is a comment of a discussion, or a reply to one.
+type discussionCommentFragment struct {
+	ID        githubv4.ID
+	Body      githubv4.String
+	URL       githubv4.String `graphql:"url"`
+	CreatedAt githubv4.DateTime
+	IsAnswer  githubv4.Boolean
+	Author    struct {
+		Login githubv4.String
+	}
+}
+
+// DiscussionComment is a comment of a discussion. NodeID is what add_discussion_comment replies to and
+// mark_discussion_answer marks as the answer.
+type DiscussionComment struct {
+	NodeID    string               `json:"node_id"`
+	Body      string               `json:"body"`
+	Author    string               `json:"author,omitempty"`
+	CreatedAt *github.Timestamp    `json:"created_at,omitempty"`
+	HTMLURL   string               `json:"html_url,omitempty"`
+	IsAnswer  bool                 `json:"is_answer"`
+	Replies   []*DiscussionComment `json:"replies,omitempty"`
+	// ReplyCount counts all replies, of which at most maxDiscussionCommentReplies are included
+	ReplyCount int `json:"reply_count,omitempty"`
+}
+
+func (c discussionCommentFragment) toDiscussionComment() *DiscussionComment {
+	return &DiscussionComment{
+		NodeID:    fmt.Sprint(c.ID),
+		Body:      string(c.Body),
+		Author:    string(c.Author.Login),
+		CreatedAt: &github.Timestamp{Time: c.CreatedAt.Time},
+		HTMLURL:   string(c.URL),
+		IsAnswer:  bool(c.IsAnswer),
+	}
+}
+
 // Common interface for all discussion query types
 type DiscussionQueryResult interface {
 	GetDiscussionFragment() DiscussionFragment
@@ -335,7 +375,7 @@ func GetDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelper
 
 func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_discussion_comments",
-			mcp.WithDescription(t("TOOL_GET_DISCUSSION_COMMENTS_DESCRIPTION", "Get comments from a discussion")),
+			mcp.WithDescription(t("TOOL_GET_DISCUSSION_COMMENTS_DESCRIPTION", "Get comments from a discussion, with their first replies and which one is the answer")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_DISCUSSION_COMMENTS_USER_TITLE", "Get discussion comments"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -387,7 +427,11 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 					Discussion struct {
 						Comments struct {
 							Nodes []struct {
-								Body githubv4.String
+								discussionCommentFragment
+								Replies struct {
+									Nodes      []discussionCommentFragment
+									TotalCount int
+								} `graphql:"replies(first: $replies)"`
 							}
 							PageInfo struct {
 								HasNextPage     githubv4.Boolean
@@ -405,6 +449,7 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 				"repo":             githubv4.String(params.Repo),
 				"discussionNumber": githubv4.Int(params.DiscussionNumber),
 				"first":            githubv4.Int(*paginationParams.First),
+				"replies":          githubv4.Int(maxDiscussionCommentReplies),
 			}
 			if paginationParams.After != nil {
 				vars["after"] = githubv4.String(*paginationParams.After)
@@ -415,9 +460,14 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			var comments []*github.IssueComment
+			var comments []*DiscussionComment
 			for _, c := range q.Repository.Discussion.Comments.Nodes {
-				comments = append(comments, &github.IssueComment{Body: github.Ptr(string(c.Body))})
+				comment := c.toDiscussionComment()
+				comment.ReplyCount = c.Replies.TotalCount
+				for _, r := range c.Replies.Nodes {
+					comment.Replies = append(comment.Replies, r.toDiscussionComment())
+				}
+				comments = append(comments, comment)
 			}
 
 			// Create response with pagination info
@@ -525,3 +575,336 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 			return mcp.NewToolResultText(string(out)), nil
 		}
 }
+
+func SearchDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("search_discussions",
+			mcp.WithDescription(t("TOOL_SEARCH_DISCUSSIONS_DESCRIPTION", "Search for discussions across GitHub, or in a single repository if owner and repo are given.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_DISCUSSIONS_USER_TITLE", "Search discussions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Search query using GitHub discussions search syntax, for example 'is:unanswered label:bug'"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("Repository owner, to only search the discussions of a repository"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name, to only search the discussions of a repository"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			query, err := RequiredParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (owner == "") != (repo == "") {
+				return mcp.NewToolResultError("owner and repo must be given together"), nil
+			}
+			if owner != "" {
+				query = fmt.Sprintf("repo:%s/%s %s", owner, repo, query)
+			}
+
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return nil, err
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return nil, err
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+			}
+
+			var q struct {
+				Search struct {
+					Nodes []struct {
+						Discussion struct {
+							NodeFragment
+							IsAnswered githubv4.Boolean
+							Repository struct {
+								NameWithOwner githubv4.String
+							}
+						} `graphql:"... on Discussion"`
+					}
+					PageInfo        PageInfoFragment
+					DiscussionCount githubv4.Int
+				} `graphql:"search(query: $query, type: DISCUSSION, first: $first, after: $after)"`
+			}
+			vars := map[string]interface{}{
+				"query": githubv4.String(query),
+				"first": githubv4.Int(*paginationParams.First),
+			}
+			if paginationParams.After != nil {
+				vars["after"] = githubv4.String(*paginationParams.After)
+			} else {
+				vars["after"] = (*githubv4.String)(nil)
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			discussions := []map[string]any{}
+			for _, node := range q.Search.Nodes {
+				discussions = append(discussions, map[string]any{
+					"repository":  string(node.Discussion.Repository.NameWithOwner),
+					"discussion":  fragmentToDiscussion(node.Discussion.NodeFragment),
+					"is_answered": bool(node.Discussion.IsAnswered),
+				})
+			}
+
+			response := map[string]interface{}{
+				"discussions": discussions,
+				"pageInfo": map[string]interface{}{
+					"hasNextPage":     q.Search.PageInfo.HasNextPage,
+					"hasPreviousPage": q.Search.PageInfo.HasPreviousPage,
+					"startCursor":     string(q.Search.PageInfo.StartCursor),
+					"endCursor":       string(q.Search.PageInfo.EndCursor),
+				},
+				"totalCount": q.Search.DiscussionCount,
+			}
+
+			out, err := json.Marshal(response)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal discussions: %w", err)
+			}
+			return mcp.NewToolResultText(string(out)), nil
+		}
+}
+
+func CreateDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_discussion",
+			mcp.WithDescription(t("TOOL_CREATE_DISCUSSION_DESCRIPTION", "Start a new discussion in a repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_DISCUSSION_USER_TITLE", "Create discussion"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("category",
+				mcp.Required(),
+				mcp.Description("ID of the discussion category, as returned by list_discussion_categories"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Discussion title"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Discussion body in Markdown"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			category, err := RequiredParam[string](request, "category")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := RequiredParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+			}
+
+			var repoQuery struct {
+				Repository struct {
+					ID githubv4.ID
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			if err := client.Query(ctx, &repoQuery, map[string]interface{}{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+			}); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get repository ID: %v", err)), nil
+			}
+
+			var mutation struct {
+				CreateDiscussion struct {
+					Discussion NodeFragment
+				} `graphql:"createDiscussion(input: $input)"`
+			}
+			input := githubv4.CreateDiscussionInput{
+				RepositoryID: repoQuery.Repository.ID,
+				CategoryID:   githubv4.ID(category),
+				Title:        githubv4.String(title),
+				Body:         githubv4.String(body),
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create discussion: %v", err)), nil
+			}
+
+			out, err := json.Marshal(fragmentToDiscussion(mutation.CreateDiscussion.Discussion))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal discussion: %w", err)
+			}
+			return mcp.NewToolResultText(string(out)), nil
+		}
+}
+
+func AddDiscussionComment(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_discussion_comment",
+			mcp.WithDescription(t("TOOL_ADD_DISCUSSION_COMMENT_DESCRIPTION", "Add a comment to a discussion, or reply to one of its comments. Replies can only be made to top-level comments, not to other replies.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_DISCUSSION_COMMENT_USER_TITLE", "Add discussion comment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("discussionNumber",
+				mcp.Required(),
+				mcp.Description("Discussion Number"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Comment body in Markdown"),
+			),
+			mcp.WithString("replyTo",
+				mcp.Description("node_id of the comment to reply to, as returned by get_discussion_comments"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			discussionNumber, err := RequiredInt(request, "discussionNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := RequiredParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			replyTo, err := OptionalParam[string](request, "replyTo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+			}
+
+			var discussionQuery struct {
+				Repository struct {
+					Discussion struct {
+						ID githubv4.ID
+					} `graphql:"discussion(number: $discussionNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			if err := client.Query(ctx, &discussionQuery, map[string]interface{}{
+				"owner":            githubv4.String(owner),
+				"repo":             githubv4.String(repo),
+				"discussionNumber": githubv4.Int(discussionNumber), // #nosec G115 - discussion numbers are always small positive integers
+			}); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get discussion ID: %v", err)), nil
+			}
+
+			var mutation struct {
+				AddDiscussionComment struct {
+					Comment discussionCommentFragment
+				} `graphql:"addDiscussionComment(input: $input)"`
+			}
+			input := githubv4.AddDiscussionCommentInput{
+				DiscussionID: discussionQuery.Repository.Discussion.ID,
+				Body:         githubv4.String(body),
+			}
+			if replyTo != "" {
+				input.ReplyToID = githubv4.NewID(githubv4.ID(replyTo))
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to add discussion comment: %v", err)), nil
+			}
+
+			out, err := json.Marshal(mutation.AddDiscussionComment.Comment.toDiscussionComment())
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal comment: %w", err)
+			}
+			return mcp.NewToolResultText(string(out)), nil
+		}
+}
+
+func MarkDiscussionAnswer(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("mark_discussion_answer",
+			mcp.WithDescription(t("TOOL_MARK_DISCUSSION_ANSWER_DESCRIPTION", "Mark a comment as the answer of its discussion, replacing any previous answer. Only discussions in categories that accept answers, such as Q&A, can have one.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MARK_DISCUSSION_ANSWER_USER_TITLE", "Mark discussion answer"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("commentId",
+				mcp.Required(),
+				mcp.Description("node_id of the comment, as returned by get_discussion_comments"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			commentID, err := RequiredParam[string](request, "commentId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+			}
+
+			var mutation struct {
+				MarkDiscussionCommentAsAnswer struct {
+					Discussion NodeFragment
+				} `graphql:"markDiscussionCommentAsAnswer(input: $input)"`
+			}
+			input := githubv4.MarkDiscussionCommentAsAnswerInput{ID: githubv4.ID(commentID)}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to mark discussion answer: %v", err)), nil
+			}
+
+			out, err := json.Marshal(fragmentToDiscussion(mutation.MarkDiscussionCommentAsAnswer.Discussion))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal discussion: %w", err)
+			}
+			return mcp.NewToolResultText(string(out)), nil
+		}
+}
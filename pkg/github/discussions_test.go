@@ -570,7 +570,7 @@ func Test_GetDiscussionComments(t *testing.T) {
 	assert.ElementsMatch(t, toolDef.InputSchema.Required, []string{"owner", "repo", "discussionNumber"})
 
 	// Use exact string query that matches implementation output
-	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{body},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$replies:Int!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,url,createdAt,isAnswer,author{login},replies(first: $replies){nodes{id,body,url,createdAt,isAnswer,author{login}},totalCount}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
 
 	// Variables matching what GraphQL receives after JSON marshaling/unmarshaling
 	vars := map[string]interface{}{
@@ -579,6 +579,7 @@ func Test_GetDiscussionComments(t *testing.T) {
 		"discussionNumber": float64(1),
 		"first":            float64(30),
 		"after":            (*string)(nil),
+		"replies":          float64(10),
 	}
 
 	mockResponse := githubv4mock.DataResponse(map[string]any{
@@ -586,8 +587,17 @@ func Test_GetDiscussionComments(t *testing.T) {
 			"discussion": map[string]any{
 				"comments": map[string]any{
 					"nodes": []map[string]any{
-						{"body": "This is the first comment"},
-						{"body": "This is the second comment"},
+						{
+							"id":       "DC_1",
+							"body":     "This is the first comment",
+							"author":   map[string]any{"login": "user1"},
+							"isAnswer": false,
+							"replies": map[string]any{
+								"nodes":      []map[string]any{{"id": "DC_3", "body": "This is a reply", "author": map[string]any{"login": "user2"}}},
+								"totalCount": 1,
+							},
+						},
+						{"id": "DC_2", "body": "This is the second comment", "isAnswer": true, "replies": map[string]any{"nodes": []map[string]any{}, "totalCount": 0}},
 					},
 					"pageInfo": map[string]any{
 						"hasNextPage":     false,
@@ -635,6 +645,20 @@ func Test_GetDiscussionComments(t *testing.T) {
 	for i, comment := range response.Comments {
 		assert.Equal(t, expectedBodies[i], *comment.Body)
 	}
+
+	var comments struct {
+		Comments []*DiscussionComment `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &comments))
+	require.Len(t, comments.Comments, 2)
+	assert.Equal(t, "DC_1", comments.Comments[0].NodeID)
+	assert.Equal(t, "user1", comments.Comments[0].Author)
+	assert.Equal(t, 1, comments.Comments[0].ReplyCount)
+	require.Len(t, comments.Comments[0].Replies, 1)
+	assert.Equal(t, "DC_3", comments.Comments[0].Replies[0].NodeID)
+	assert.Equal(t, "This is a reply", comments.Comments[0].Replies[0].Body)
+	assert.False(t, comments.Comments[0].IsAnswer)
+	assert.True(t, comments.Comments[1].IsAnswer)
 }
 
 func Test_ListDiscussionCategories(t *testing.T) {
@@ -699,3 +723,391 @@ func Test_ListDiscussionCategories(t *testing.T) {
 	assert.Equal(t, "456", response.Categories[1]["id"])
 	assert.Equal(t, "CategoryTwo", response.Categories[1]["name"])
 }
+
+func Test_SearchDiscussions(t *testing.T) {
+	toolDef, _ := SearchDiscussions(nil, translations.NullTranslationHelper)
+	assert.Equal(t, "search_discussions", toolDef.Name)
+	assert.NotEmpty(t, toolDef.Description)
+	assert.Contains(t, toolDef.InputSchema.Properties, "query")
+	assert.Contains(t, toolDef.InputSchema.Properties, "owner")
+	assert.Contains(t, toolDef.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, toolDef.InputSchema.Required, []string{"query"})
+
+	// Use exact string query that matches implementation output
+	qSearch := "query($after:String$first:Int!$query:String!){search(query: $query, type: DISCUSSION, first: $first, after: $after){nodes{... on Discussion{number,title,createdAt,updatedAt,author{login},category{name},url,isAnswered,repository{nameWithOwner}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},discussionCount}}"
+
+	mockResponse := githubv4mock.DataResponse(map[string]any{
+		"search": map[string]any{
+			"nodes": []map[string]any{
+				{
+					"number":     1,
+					"title":      "Discussion 1 title",
+					"createdAt":  "2023-01-01T00:00:00Z",
+					"updatedAt":  "2023-01-01T00:00:00Z",
+					"author":     map[string]any{"login": "user1"},
+					"url":        "https://github.com/owner/repo/discussions/1",
+					"category":   map[string]any{"name": "Q&A"},
+					"isAnswered": true,
+					"repository": map[string]any{"nameWithOwner": "owner/repo"},
+				},
+			},
+			"pageInfo": map[string]any{
+				"hasNextPage":     true,
+				"hasPreviousPage": false,
+				"startCursor":     "",
+				"endCursor":       "cursor",
+			},
+			"discussionCount": 5,
+		},
+	})
+
+	tests := []struct {
+		name          string
+		reqParams     map[string]interface{}
+		expectedQuery string
+		expectError   bool
+		errContains   string
+	}{
+		{
+			name:          "search all repositories",
+			reqParams:     map[string]interface{}{"query": "is:unanswered"},
+			expectedQuery: "is:unanswered",
+		},
+		{
+			name:          "search a repository",
+			reqParams:     map[string]interface{}{"query": "is:unanswered", "owner": "owner", "repo": "repo"},
+			expectedQuery: "repo:owner/repo is:unanswered",
+		},
+		{
+			name:        "owner without repo",
+			reqParams:   map[string]interface{}{"query": "is:unanswered", "owner": "owner"},
+			expectError: true,
+			errContains: "owner and repo must be given together",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			vars := map[string]interface{}{
+				"query": tc.expectedQuery,
+				"first": float64(30),
+				"after": nil,
+			}
+			matcher := githubv4mock.NewQueryMatcher(qSearch, vars, mockResponse)
+			httpClient := githubv4mock.NewMockedHTTPClient(matcher)
+			gqlClient := githubv4.NewClient(httpClient)
+			_, handler := SearchDiscussions(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			res, err := handler(context.Background(), createMCPRequest(tc.reqParams))
+			require.NoError(t, err)
+			text := getTextResult(t, res).Text
+
+			if tc.expectError {
+				require.True(t, res.IsError)
+				assert.Contains(t, text, tc.errContains)
+				return
+			}
+			require.False(t, res.IsError, text)
+
+			var response struct {
+				Discussions []struct {
+					Repository string            `json:"repository"`
+					Discussion github.Discussion `json:"discussion"`
+					IsAnswered bool              `json:"is_answered"`
+				} `json:"discussions"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				TotalCount int `json:"totalCount"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(text), &response))
+			require.Len(t, response.Discussions, 1)
+			assert.Equal(t, "owner/repo", response.Discussions[0].Repository)
+			assert.Equal(t, 1, *response.Discussions[0].Discussion.Number)
+			assert.Equal(t, "Q&A", *response.Discussions[0].Discussion.DiscussionCategory.Name)
+			assert.True(t, response.Discussions[0].IsAnswered)
+			assert.True(t, response.PageInfo.HasNextPage)
+			assert.Equal(t, "cursor", response.PageInfo.EndCursor)
+			assert.Equal(t, 5, response.TotalCount)
+		})
+	}
+}
+
+func Test_CreateDiscussion(t *testing.T) {
+	toolDef, _ := CreateDiscussion(nil, translations.NullTranslationHelper)
+	assert.Equal(t, "create_discussion", toolDef.Name)
+	assert.NotEmpty(t, toolDef.Description)
+	assert.False(t, *toolDef.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, toolDef.InputSchema.Required, []string{"owner", "repo", "category", "title", "body"})
+
+	repoQuery := githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				ID githubv4.ID
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"id": "R_1"},
+		}),
+	)
+	createMutation := func(response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				CreateDiscussion struct {
+					Discussion NodeFragment
+				} `graphql:"createDiscussion(input: $input)"`
+			}{},
+			githubv4.CreateDiscussionInput{
+				RepositoryID: githubv4.ID("R_1"),
+				CategoryID:   githubv4.ID("DIC_1"),
+				Title:        githubv4.String("New discussion"),
+				Body:         githubv4.String("Body"),
+			},
+			nil,
+			response,
+		)
+	}
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		expectError  bool
+		errContains  string
+	}{
+		{
+			name: "successful creation",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				repoQuery,
+				createMutation(githubv4mock.DataResponse(map[string]any{
+					"createDiscussion": map[string]any{
+						"discussion": map[string]any{
+							"number":    7,
+							"title":     "New discussion",
+							"createdAt": "2025-04-25T12:00:00Z",
+							"updatedAt": "2025-04-25T12:00:00Z",
+							"author":    map[string]any{"login": "user1"},
+							"url":       "https://github.com/owner/repo/discussions/7",
+							"category":  map[string]any{"name": "General"},
+						},
+					},
+				})),
+			),
+		},
+		{
+			name: "category not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				repoQuery,
+				createMutation(githubv4mock.ErrorResponse("Could not resolve to a node with the global id of 'DIC_1'")),
+			),
+			expectError: true,
+			errContains: "failed to create discussion",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(tc.mockedClient)
+			_, handler := CreateDiscussion(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			res, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"category": "DIC_1",
+				"title":    "New discussion",
+				"body":     "Body",
+			}))
+			require.NoError(t, err)
+			text := getTextResult(t, res).Text
+
+			if tc.expectError {
+				require.True(t, res.IsError)
+				assert.Contains(t, text, tc.errContains)
+				return
+			}
+			require.False(t, res.IsError, text)
+
+			var out github.Discussion
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			assert.Equal(t, 7, *out.Number)
+			assert.Equal(t, "https://github.com/owner/repo/discussions/7", *out.HTMLURL)
+		})
+	}
+}
+
+func Test_AddDiscussionComment(t *testing.T) {
+	toolDef, _ := AddDiscussionComment(nil, translations.NullTranslationHelper)
+	assert.Equal(t, "add_discussion_comment", toolDef.Name)
+	assert.NotEmpty(t, toolDef.Description)
+	assert.False(t, *toolDef.Annotations.ReadOnlyHint)
+	assert.Contains(t, toolDef.InputSchema.Properties, "replyTo")
+	assert.ElementsMatch(t, toolDef.InputSchema.Required, []string{"owner", "repo", "discussionNumber", "body"})
+
+	discussionQuery := githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				Discussion struct {
+					ID githubv4.ID
+				} `graphql:"discussion(number: $discussionNumber)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner":            githubv4.String("owner"),
+			"repo":             githubv4.String("repo"),
+			"discussionNumber": githubv4.Int(1),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{"id": "D_1"}},
+		}),
+	)
+	commentMutation := func(input githubv4.AddDiscussionCommentInput) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				AddDiscussionComment struct {
+					Comment discussionCommentFragment
+				} `graphql:"addDiscussionComment(input: $input)"`
+			}{},
+			input,
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"addDiscussionComment": map[string]any{
+					"comment": map[string]any{
+						"id":        "DC_9",
+						"body":      "Thanks!",
+						"url":       "https://github.com/owner/repo/discussions/1#discussioncomment-9",
+						"createdAt": "2025-04-25T12:00:00Z",
+						"isAnswer":  false,
+						"author":    map[string]any{"login": "user1"},
+					},
+				},
+			}),
+		)
+	}
+
+	tests := []struct {
+		name         string
+		requestArgs  map[string]interface{}
+		mockedClient *http.Client
+	}{
+		{
+			name: "comment on the discussion",
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"discussionNumber": float64(1),
+				"body":             "Thanks!",
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				discussionQuery,
+				commentMutation(githubv4.AddDiscussionCommentInput{
+					DiscussionID: githubv4.ID("D_1"),
+					Body:         githubv4.String("Thanks!"),
+				}),
+			),
+		},
+		{
+			name: "reply to a comment",
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"discussionNumber": float64(1),
+				"body":             "Thanks!",
+				"replyTo":          "DC_1",
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				discussionQuery,
+				commentMutation(githubv4.AddDiscussionCommentInput{
+					DiscussionID: githubv4.ID("D_1"),
+					Body:         githubv4.String("Thanks!"),
+					ReplyToID:    githubv4.NewID(githubv4.ID("DC_1")),
+				}),
+			),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(tc.mockedClient)
+			_, handler := AddDiscussionComment(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			res, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			text := getTextResult(t, res).Text
+			require.False(t, res.IsError, text)
+
+			var out DiscussionComment
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			assert.Equal(t, "DC_9", out.NodeID)
+			assert.Equal(t, "Thanks!", out.Body)
+			assert.Equal(t, "user1", out.Author)
+		})
+	}
+}
+
+func Test_MarkDiscussionAnswer(t *testing.T) {
+	toolDef, _ := MarkDiscussionAnswer(nil, translations.NullTranslationHelper)
+	assert.Equal(t, "mark_discussion_answer", toolDef.Name)
+	assert.NotEmpty(t, toolDef.Description)
+	assert.False(t, *toolDef.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, toolDef.InputSchema.Required, []string{"commentId"})
+
+	mutation := struct {
+		MarkDiscussionCommentAsAnswer struct {
+			Discussion NodeFragment
+		} `graphql:"markDiscussionCommentAsAnswer(input: $input)"`
+	}{}
+	input := githubv4.MarkDiscussionCommentAsAnswerInput{ID: githubv4.ID("DC_1")}
+
+	tests := []struct {
+		name        string
+		response    githubv4mock.GQLResponse
+		expectError bool
+		errContains string
+	}{
+		{
+			name: "successful marking",
+			response: githubv4mock.DataResponse(map[string]any{
+				"markDiscussionCommentAsAnswer": map[string]any{
+					"discussion": map[string]any{
+						"number":    1,
+						"title":     "Question",
+						"createdAt": "2025-04-25T12:00:00Z",
+						"updatedAt": "2025-04-25T12:00:00Z",
+						"author":    map[string]any{"login": "user1"},
+						"url":       "https://github.com/owner/repo/discussions/1",
+						"category":  map[string]any{"name": "Q&A"},
+					},
+				},
+			}),
+		},
+		{
+			name:        "category does not accept answers",
+			response:    githubv4mock.ErrorResponse("Discussion category does not accept answers"),
+			expectError: true,
+			errContains: "does not accept answers",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher := githubv4mock.NewMutationMatcher(mutation, input, nil, tc.response)
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher))
+			_, handler := MarkDiscussionAnswer(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			res, err := handler(context.Background(), createMCPRequest(map[string]interface{}{"commentId": "DC_1"}))
+			require.NoError(t, err)
+			text := getTextResult(t, res).Text
+
+			if tc.expectError {
+				require.True(t, res.IsError)
+				assert.Contains(t, text, tc.errContains)
+				return
+			}
+			require.False(t, res.IsError, text)
+
+			var out github.Discussion
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			assert.Equal(t, 1, *out.Number)
+		})
+	}
+}
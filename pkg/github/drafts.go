@@ -0,0 +1,292 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// maxDraftLookups caps the referenced issues and labels a draft validation looks up.
+const maxDraftLookups = 20
+
+// DraftRuleSet are the rules an issue or pull request draft is validated against.
+type DraftRuleSet struct {
+	// MinTitleLength is the minimum number of characters of the title, a title is always required
+	MinTitleLength int `yaml:"min_title_length"`
+	// MinBodyLength is the minimum number of characters of the body, zero allows an empty body
+	MinBodyLength int `yaml:"min_body_length"`
+	// RequiredSections are markdown headings the body must contain, matched case-insensitively
+	RequiredSections []string `yaml:"required_sections"`
+	// CheckReferences looks up the issues and pull requests referenced as #123 or owner/repo#123
+	CheckReferences bool `yaml:"check_references"`
+	// CheckLabels verifies that the labels of the draft exist in the repository
+	CheckLabels bool `yaml:"check_labels"`
+}
+
+// DraftRules are the rules validate_issue_draft and validate_pull_request_draft apply, as configured by
+// the operator.
+type DraftRules struct {
+	Issues       DraftRuleSet `yaml:"issues"`
+	PullRequests DraftRuleSet `yaml:"pull_requests"`
+}
+
+// DefaultDraftRules returns the rules used when the operator configures none: a non-empty title,
+// existing references and existing labels.
+func DefaultDraftRules() DraftRules {
+	defaults := DraftRuleSet{
+		MinTitleLength:  1,
+		CheckReferences: true,
+		CheckLabels:     true,
+	}
+	return DraftRules{Issues: defaults, PullRequests: defaults}
+}
+
+// LoadDraftRules reads draft rules from a YAML or JSON file. Rules the file doesn't set keep their
+// default.
+func LoadDraftRules(path string) (DraftRules, error) {
+	rules := DefaultDraftRules()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return rules, fmt.Errorf("failed to read draft rules: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&rules); err != nil {
+		return rules, fmt.Errorf("failed to parse draft rules %s: %w", path, err)
+	}
+	for _, set := range []*DraftRuleSet{&rules.Issues, &rules.PullRequests} {
+		if set.MinTitleLength < 1 {
+			set.MinTitleLength = 1
+		}
+	}
+	return rules, nil
+}
+
+// DraftProblem is an error or warning found in a draft.
+type DraftProblem struct {
+	// Rule is the rule that found the problem, such as min_body_length
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// DraftValidation is the result of validating a draft.
+type DraftValidation struct {
+	// Valid is true if the draft has no errors, warnings don't make it invalid
+	Valid    bool           `json:"valid"`
+	Errors   []DraftProblem `json:"errors"`
+	Warnings []DraftProblem `json:"warnings"`
+}
+
+var (
+	draftHeadingPattern   = regexp.MustCompile(`(?m)^\s{0,3}#{1,6}\s+(.+?)\s*#*\s*$`)
+	draftReferencePattern = regexp.MustCompile(`(?:^|[^\w/#&])(?:([\w.-]+)/([\w.-]+))?#([0-9]+)\b`)
+)
+
+type draftReference struct {
+	owner, repo string
+	number      int
+}
+
+// draftReferences returns the distinct issue references in text, relative ones resolved against owner/repo.
+func draftReferences(owner, repo, text string) []draftReference {
+	var refs []draftReference
+	seen := make(map[draftReference]bool)
+	for _, m := range draftReferencePattern.FindAllStringSubmatch(text, -1) {
+		ref := draftReference{owner: owner, repo: repo}
+		if m[1] != "" {
+			ref.owner, ref.repo = m[1], m[2]
+		}
+		ref.number, _ = strconv.Atoi(m[3])
+		if ref.number == 0 || seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// validateDraft checks a draft against rules, looking up references and labels with client.
+func validateDraft(ctx context.Context, client *github.Client, rules DraftRuleSet, owner, repo, title, body string, labels []string) (*DraftValidation, error) {
+	result := &DraftValidation{Errors: []DraftProblem{}, Warnings: []DraftProblem{}}
+	addError := func(rule, format string, args ...any) {
+		result.Errors = append(result.Errors, DraftProblem{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+	addWarning := func(rule, format string, args ...any) {
+		result.Warnings = append(result.Warnings, DraftProblem{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	title = strings.TrimSpace(title)
+	switch {
+	case title == "":
+		addError("title", "title must not be empty")
+	case utf8.RuneCountInString(title) < rules.MinTitleLength:
+		addError("min_title_length", "title has %d characters, at least %d are required", utf8.RuneCountInString(title), rules.MinTitleLength)
+	}
+
+	if length := utf8.RuneCountInString(strings.TrimSpace(body)); length < rules.MinBodyLength {
+		addError("min_body_length", "body has %d characters, at least %d are required", length, rules.MinBodyLength)
+	}
+
+	if len(rules.RequiredSections) > 0 {
+		headings := make(map[string]bool)
+		for _, m := range draftHeadingPattern.FindAllStringSubmatch(body, -1) {
+			headings[strings.ToLower(m[1])] = true
+		}
+		for _, section := range rules.RequiredSections {
+			if !headings[strings.ToLower(strings.TrimSpace(section))] {
+				addError("required_sections", "body is missing the required section %q", section)
+			}
+		}
+	}
+
+	if rules.CheckReferences {
+		refs := draftReferences(owner, repo, title+"\n"+body)
+		if len(refs) > maxDraftLookups {
+			addWarning("check_references", "only the first %d of %d references were checked", maxDraftLookups, len(refs))
+			refs = refs[:maxDraftLookups]
+		}
+		for _, ref := range refs {
+			_, resp, err := client.Issues.Get(ctx, ref.owner, ref.repo, ref.number)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			switch {
+			case err == nil:
+			case resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone):
+				// Another repository may also just not be visible to the token, which is not worth an error
+				if ref.owner == owner && ref.repo == repo {
+					addError("check_references", "referenced issue or pull request #%d does not exist", ref.number)
+				} else {
+					addWarning("check_references", "referenced issue or pull request %s/%s#%d does not exist or is not accessible", ref.owner, ref.repo, ref.number)
+				}
+			default:
+				addWarning("check_references", "could not check reference %s/%s#%d: %v", ref.owner, ref.repo, ref.number, err)
+			}
+		}
+	}
+
+	if rules.CheckLabels && len(labels) > 0 {
+		if len(labels) > maxDraftLookups {
+			addWarning("check_labels", "only the first %d of %d labels were checked", maxDraftLookups, len(labels))
+			labels = labels[:maxDraftLookups]
+		}
+		for _, label := range labels {
+			_, resp, err := client.Issues.GetLabel(ctx, owner, repo, label)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			switch {
+			case err == nil:
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				addError("check_labels", "label %q does not exist in %s/%s", label, owner, repo)
+			default:
+				return nil, fmt.Errorf("failed to get label %q: %w", label, err)
+			}
+		}
+	}
+
+	result.Valid = len(result.Errors) == 0
+	return result, nil
+}
+
+// draftTool is the tool definition and handler shared by the issue and pull request draft validations.
+func draftTool(name, description, title string, getClient GetClientFn, rules DraftRuleSet) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool(name,
+			mcp.WithDescription(description),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        title,
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Proposed title"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Proposed body"),
+			),
+			mcp.WithArray("labels",
+				mcp.Description("Labels the draft will be created with"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// An empty title is reported as a validation error rather than rejected as a missing parameter
+			draftTitle, err := OptionalParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := OptionalParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labels, err := OptionalStringArrayParam(request, "labels")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result, err := validateDraft(ctx, client, rules, owner, repo, draftTitle, body, labels)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// ValidateIssueDraft creates a tool to check a proposed issue against the configured rules without creating it.
+func ValidateIssueDraft(getClient GetClientFn, rules DraftRules, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return draftTool("validate_issue_draft",
+		t("TOOL_VALIDATE_ISSUE_DRAFT_DESCRIPTION", "Check a proposed issue title, body and labels against the rules configured for this server, such as required template sections and existing labels and references, without creating anything. Fix the reported errors before calling create_issue."),
+		t("TOOL_VALIDATE_ISSUE_DRAFT_USER_TITLE", "Validate issue draft"),
+		getClient,
+		rules.Issues,
+	)
+}
+
+// ValidatePullRequestDraft creates a tool to check a proposed pull request against the configured rules without creating it.
+func ValidatePullRequestDraft(getClient GetClientFn, rules DraftRules, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return draftTool("validate_pull_request_draft",
+		t("TOOL_VALIDATE_PULL_REQUEST_DRAFT_DESCRIPTION", "Check a proposed pull request title, body and labels against the rules configured for this server, such as required template sections and existing labels and references, without creating anything. Fix the reported errors before calling create_pull_request."),
+		t("TOOL_VALIDATE_PULL_REQUEST_DRAFT_USER_TITLE", "Validate pull request draft"),
+		getClient,
+		rules.PullRequests,
+	)
+}
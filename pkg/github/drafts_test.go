@@ -0,0 +1,240 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoadDraftRules(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("YAML keeps defaults for unset rules", func(t *testing.T) {
+		path := filepath.Join(dir, "rules.yml")
+		require.NoError(t, os.WriteFile(path, []byte(`issues:
+  min_body_length: 30
+  required_sections: ["Steps to reproduce", "Expected behavior"]
+pull_requests:
+  check_labels: false
+`), 0600))
+
+		rules, err := LoadDraftRules(path)
+		require.NoError(t, err)
+		assert.Equal(t, DraftRuleSet{
+			MinTitleLength:   1,
+			MinBodyLength:    30,
+			RequiredSections: []string{"Steps to reproduce", "Expected behavior"},
+			CheckReferences:  true,
+			CheckLabels:      true,
+		}, rules.Issues)
+		assert.Equal(t, DraftRuleSet{
+			MinTitleLength:  1,
+			CheckReferences: true,
+			CheckLabels:     false,
+		}, rules.PullRequests)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		path := filepath.Join(dir, "rules.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"pull_requests": {"min_title_length": 10}}`), 0600))
+
+		rules, err := LoadDraftRules(path)
+		require.NoError(t, err)
+		assert.Equal(t, 10, rules.PullRequests.MinTitleLength)
+		assert.Equal(t, DefaultDraftRules().Issues, rules.Issues)
+	})
+
+	t.Run("unknown rules are rejected", func(t *testing.T) {
+		path := filepath.Join(dir, "typo.yml")
+		require.NoError(t, os.WriteFile(path, []byte("issues:\n  min_body_lenght: 30\n"), 0600))
+
+		_, err := LoadDraftRules(path)
+		assert.ErrorContains(t, err, "min_body_lenght")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadDraftRules(filepath.Join(dir, "missing.yml"))
+		assert.Error(t, err)
+	})
+}
+
+func Test_ValidateIssueDraft(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ValidateIssueDraft(stubGetClientFn(mockClient), DefaultDraftRules(), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "validate_issue_draft", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "title")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "labels")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	rules := DefaultDraftRules()
+	rules.Issues.MinTitleLength = 10
+	rules.Issues.MinBodyLength = 20
+	rules.Issues.RequiredSections = []string{"Steps to reproduce", "Expected behavior"}
+
+	// Issue 1 and label bug exist, everything else doesn't
+	lookups := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/repos/owner/repo/issues/1" {
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write(mock.MustMarshal(&github.Issue{Number: github.Ptr(1)}))
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposLabelsByOwnerByRepoByName,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/repos/owner/repo/labels/bug" {
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write(mock.MustMarshal(&github.Label{Name: github.Ptr("bug")}))
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			}),
+		),
+	)
+
+	tests := []struct {
+		name             string
+		rules            DraftRules
+		requestArgs      map[string]interface{}
+		expectedValid    bool
+		expectedErrors   []DraftProblem
+		expectedWarnings []DraftProblem
+	}{
+		{
+			name:  "valid draft",
+			rules: rules,
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"title":  "Crash when saving settings",
+				"body":   "Follow-up to #1.\n\n## Steps to reproduce\n\nSave.\n\n### Expected behavior ###\n\nNo crash.",
+				"labels": []interface{}{"bug"},
+			},
+			expectedValid:    true,
+			expectedErrors:   []DraftProblem{},
+			expectedWarnings: []DraftProblem{},
+		},
+		{
+			name:  "every rule broken",
+			rules: rules,
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"title":  "Crash",
+				"body":   "See #2 and other/repo#3",
+				"labels": []interface{}{"bug", "crash"},
+			},
+			expectedErrors: []DraftProblem{
+				{Rule: "min_title_length", Message: "title has 5 characters, at least 10 are required"},
+				{Rule: "required_sections", Message: `body is missing the required section "Steps to reproduce"`},
+				{Rule: "required_sections", Message: `body is missing the required section "Expected behavior"`},
+				{Rule: "check_references", Message: "referenced issue or pull request #2 does not exist"},
+				{Rule: "check_labels", Message: `label "crash" does not exist in owner/repo`},
+			},
+			expectedWarnings: []DraftProblem{
+				{Rule: "check_references", Message: "referenced issue or pull request other/repo#3 does not exist or is not accessible"},
+			},
+		},
+		{
+			name:  "empty title",
+			rules: rules,
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"title": "  ",
+				"body":  "## Steps to reproduce\n## Expected behavior",
+			},
+			expectedErrors: []DraftProblem{
+				{Rule: "title", Message: "title must not be empty"},
+			},
+			expectedWarnings: []DraftProblem{},
+		},
+		{
+			name:  "lookups disabled",
+			rules: DraftRules{Issues: DraftRuleSet{MinTitleLength: 1}},
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"title":  "Fix #2",
+				"labels": []interface{}{"crash"},
+			},
+			expectedValid:    true,
+			expectedErrors:   []DraftProblem{},
+			expectedWarnings: []DraftProblem{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(lookups)
+			_, handler := ValidateIssueDraft(stubGetClientFn(client), tc.rules, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var validation DraftValidation
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &validation))
+			assert.Equal(t, tc.expectedValid, validation.Valid)
+			assert.Equal(t, tc.expectedErrors, validation.Errors)
+			assert.Equal(t, tc.expectedWarnings, validation.Warnings)
+		})
+	}
+}
+
+func Test_ValidatePullRequestDraft(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ValidatePullRequestDraft(stubGetClientFn(mockClient), DefaultDraftRules(), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "validate_pull_request_draft", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	// The pull request rules apply, not the issue rules
+	rules := DefaultDraftRules()
+	rules.Issues.MinBodyLength = 1000
+	rules.PullRequests.RequiredSections = []string{"Testing"}
+
+	_, handler := ValidatePullRequestDraft(stubGetClientFn(mockClient), rules, translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"title": "Add retries",
+		"body":  "Adds retries.",
+	}))
+	require.NoError(t, err)
+
+	var validation DraftValidation
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &validation))
+	assert.False(t, validation.Valid)
+	assert.Equal(t, []DraftProblem{
+		{Rule: "required_sections", Message: `body is missing the required section "Testing"`},
+	}, validation.Errors)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"unicode"
 
 	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -19,9 +22,17 @@ func ToolsetEnum(toolsetGroup *toolsets.ToolsetGroup) mcp.PropertyOption {
 	return mcp.Enum(toolsetNames...)
 }
 
+// EnabledToolset is the result of enable_toolset.
+type EnabledToolset struct {
+	Name           string `json:"name"`
+	AlreadyEnabled bool   `json:"already_enabled"`
+	// Tools are the tools of the toolset, which clients are told about with a tools/list_changed notification
+	Tools []string `json:"tools"`
+}
+
 func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("enable_toolset",
-			mcp.WithDescription(t("TOOL_ENABLE_TOOLSET_DESCRIPTION", "Enable one of the sets of tools the GitHub MCP server provides, use get_toolset_tools and list_available_toolsets first to see what this will enable")),
+			mcp.WithDescription(t("TOOL_ENABLE_TOOLSET_DESCRIPTION", "Enable one of the sets of tools the GitHub MCP server provides, use list_available_toolsets with a need first to find the toolset that covers a task, and get_toolset_tools to see what this will enable. The tools of the toolset are available right after this returns")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title: t("TOOL_ENABLE_TOOLSET_USER_TITLE", "Enable a toolset"),
 				// Not modifying GitHub data so no need to show a warning
@@ -43,45 +54,113 @@ func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t t
 			if toolset == nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil
 			}
-			if toolset.Enabled {
-				return mcp.NewToolResultText(fmt.Sprintf("Toolset %s is already enabled", toolsetName)), nil
-			}
 
-			toolset.Enabled = true
+			result := EnabledToolset{Name: toolsetName, AlreadyEnabled: toolset.Enabled, Tools: []string{}}
+			for _, st := range toolset.GetAvailableTools() {
+				result.Tools = append(result.Tools, st.Tool.Name)
+			}
+			if !toolset.Enabled {
+				toolset.Enabled = true
 
-			// caution: this currently affects the global tools and notifies all clients:
-			//
-			// Send notification to all initialized sessions
-			// s.sendNotificationToAllClients("notifications/tools/list_changed", nil)
-			s.AddTools(toolset.GetActiveTools()...)
+				// caution: this affects the tools of every session. Adding the tools at once sends each
+				// initialized session a single tools/list_changed notification, and the resource templates and
+				// prompts of the toolset are announced the same way
+				s.AddTools(toolset.GetActiveTools()...)
+				toolset.RegisterResourcesTemplates(s)
+				toolset.RegisterPrompts(s)
+			}
 
-			return mcp.NewToolResultText(fmt.Sprintf("Toolset %s enabled", toolsetName)), nil
+			return MarshalledTextResult(result), nil
 		}
 }
 
-func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+// AvailableToolset describes a toolset in the catalog returned by list_available_toolsets.
+type AvailableToolset struct {
+	Name             string          `json:"name"`
+	Description      string          `json:"description"`
+	CanEnable        bool            `json:"can_enable"`
+	CurrentlyEnabled bool            `json:"currently_enabled"`
+	Tools            []AvailableTool `json:"tools"`
+	// Relevance ranks the toolset against the need of list_available_toolsets, higher is more relevant
+	Relevance int `json:"relevance,omitempty"`
+	// MatchingTools are the tools that match the need, the most relevant first
+	MatchingTools []string `json:"matching_tools,omitempty"`
+}
+
+// AvailableTool describes a single tool of a toolset in the catalog.
+type AvailableTool struct {
+	Name     string `json:"name"`
+	Title    string `json:"title,omitempty"`
+	ReadOnly bool   `json:"read_only"`
+	// Available is false for write tools when the server runs in read-only mode and for tools withheld with
+	// --tools or --exclude-tools, they can't be enabled at all.
+	Available bool `json:"available"`
+}
+
+// ListAvailableToolsets creates a tool that returns the full catalog of toolsets and their tools, including
+// the ones that are not enabled. canEnable reports whether toolsets can be enabled at runtime with enable_toolset.
+// Given a need, only the toolsets that match it are returned, ranked with toolsetRelevance.
+func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, canEnable bool, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_available_toolsets",
-			mcp.WithDescription(t("TOOL_LIST_AVAILABLE_TOOLSETS_DESCRIPTION", "List all available toolsets this GitHub MCP server can offer, providing the enabled status of each. Use this when a task could be achieved with a GitHub tool and the currently available tools aren't enough. Call get_toolset_tools with these toolset names to discover specific tools you can call")),
+			mcp.WithDescription(t("TOOL_LIST_AVAILABLE_TOOLSETS_DESCRIPTION", "List all available toolsets this GitHub MCP server can offer, providing the enabled status of each and its tools, whether they are read-only or write tools. Use this when a task could be achieved with a GitHub tool and the currently available tools aren't enough. Call get_toolset_tools with these toolset names to discover specific tools you can call")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_AVAILABLE_TOOLSETS_USER_TITLE", "List available toolsets"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			mcp.WithString("need",
+				mcp.Description("What you need to do, in plain words, such as 'rerun a failed workflow'. Only the toolsets whose tools match it are listed, the most relevant first, together with the matching tools"),
+			),
 		),
-		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			// We need to convert the toolsetGroup back to a map for JSON serialization
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			need, err := OptionalParam[string](request, "need")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			terms := needTerms(need)
 
-			payload := []map[string]string{}
+			names := make([]string, 0, len(toolsetGroup.Toolsets))
+			for name := range toolsetGroup.Toolsets {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			payload := make([]AvailableToolset, 0, len(names))
+			for _, name := range names {
+				ts := toolsetGroup.Toolsets[name]
 
-			for name, ts := range toolsetGroup.Toolsets {
-				{
-					t := map[string]string{
-						"name":              name,
-						"description":       ts.Description,
-						"can_enable":        "true",
-						"currently_enabled": fmt.Sprintf("%t", ts.Enabled),
+				tools := []AvailableTool{}
+				for _, st := range ts.GetAllTools() {
+					readOnly := st.Tool.Annotations.ReadOnlyHint != nil && *st.Tool.Annotations.ReadOnlyHint
+					tools = append(tools, AvailableTool{
+						Name:      st.Tool.Name,
+						Title:     st.Tool.Annotations.Title,
+						ReadOnly:  readOnly,
+						Available: (readOnly || !ts.IsReadOnly()) && !ts.IsToolExcluded(st.Tool.Name),
+					})
+				}
+
+				available := AvailableToolset{
+					Name:             name,
+					Description:      ts.Description,
+					CanEnable:        canEnable && !ts.Enabled,
+					CurrentlyEnabled: ts.Enabled,
+					Tools:            tools,
+				}
+				if need != "" {
+					available.Relevance, available.MatchingTools = toolsetRelevance(ts, terms)
+					if available.Relevance == 0 {
+						continue
 					}
-					payload = append(payload, t)
 				}
+				payload = append(payload, available)
+			}
+			if need != "" {
+				sort.SliceStable(payload, func(i, j int) bool {
+					if payload[i].Relevance != payload[j].Relevance {
+						return payload[i].Relevance > payload[j].Relevance
+					}
+					return len(payload[i].MatchingTools) > len(payload[j].MatchingTools)
+				})
 			}
 
 			r, err := json.Marshal(payload)
@@ -93,6 +172,100 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 		}
 }
 
+// needStopWords are the words of a need that say nothing about the toolset that covers it.
+var needStopWords = map[string]bool{
+	"all": true, "and": true, "any": true, "are": true, "can": true, "for": true, "from": true, "get": true,
+	"github": true, "how": true, "into": true, "let": true, "like": true, "need": true, "some": true,
+	"that": true, "the": true, "their": true, "them": true, "then": true, "this": true, "use": true,
+	"want": true, "what": true, "when": true, "which": true, "who": true, "why": true, "with": true,
+	"you": true, "your": true,
+}
+
+// needTerms splits a need into the lowercase stems of its words, leaving out stop words and words too short
+// to tell toolsets apart.
+func needTerms(need string) []string {
+	words := strings.FieldsFunc(strings.ToLower(need), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var terms []string
+	seen := map[string]bool{}
+	for _, word := range words {
+		if len(word) < 3 || needStopWords[word] {
+			continue
+		}
+		// A crude stemmer, so that plurals match the singular names of tools. Terms match as substrings, so
+		// a stem that is cut short still matches
+		switch {
+		case strings.HasSuffix(word, "ies") && len(word) > 4:
+			word = strings.TrimSuffix(word, "ies") + "y"
+		case strings.HasSuffix(word, "es") && len(word) > 4:
+			word = strings.TrimSuffix(word, "es")
+		case strings.HasSuffix(word, "s") && len(word) > 3:
+			word = strings.TrimSuffix(word, "s")
+		}
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// toolsetRelevance scores how well a toolset covers the terms of a need. Each term counts with its best
+// match: the name of the toolset, its description, the name of one of its tools, or the description of one.
+// It also returns the tools that match any term, the ones matching the most first.
+func toolsetRelevance(ts *toolsets.Toolset, terms []string) (int, []string) {
+	const (
+		toolsetNameScore        = 5
+		toolsetDescriptionScore = 3
+		toolNameScore           = 2
+		toolDescriptionScore    = 1
+	)
+
+	name := strings.ReplaceAll(strings.ToLower(ts.Name), "_", " ")
+	description := strings.ToLower(ts.Description)
+	tools := ts.GetAllTools()
+	toolScores := make([]int, len(tools))
+
+	relevance := 0
+	for _, term := range terms {
+		best := 0
+		switch {
+		case strings.Contains(name, term):
+			best = toolsetNameScore
+		case strings.Contains(description, term):
+			best = toolsetDescriptionScore
+		}
+		for i, st := range tools {
+			score := 0
+			switch {
+			case strings.Contains(strings.ReplaceAll(st.Tool.Name, "_", " "), term):
+				score = toolNameScore
+			case strings.Contains(strings.ToLower(st.Tool.Description), term):
+				score = toolDescriptionScore
+			}
+			toolScores[i] += score
+			best = max(best, score)
+		}
+		relevance += best
+	}
+
+	var matching []int
+	for i, score := range toolScores {
+		if score > 0 {
+			matching = append(matching, i)
+		}
+	}
+	sort.SliceStable(matching, func(a, b int) bool {
+		return toolScores[matching[a]] > toolScores[matching[b]]
+	})
+	names := make([]string, 0, len(matching))
+	for _, i := range matching {
+		names = append(names, tools[i].Tool.Name)
+	}
+	return relevance, names
+}
+
 func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_toolset_tools",
 			mcp.WithDescription(t("TOOL_GET_TOOLSET_TOOLS_DESCRIPTION", "Lists all the capabilities that are enabled with the specified toolset, use this to get clarity on whether enabling a toolset would help you to complete a task")),
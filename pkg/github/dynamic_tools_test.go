@@ -0,0 +1,188 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListAvailableToolsets(t *testing.T) {
+	newToolsetGroup := func(readOnly bool) *toolsets.ToolsetGroup {
+		mockClient := github.NewClient(nil)
+		tsg := toolsets.NewToolsetGroup(readOnly)
+		issues := toolsets.NewToolset("issues", "Issues").
+			AddReadTools(toolsets.NewServerTool(GetIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper))).
+			AddWriteTools(toolsets.NewServerTool(CreateIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)))
+		gists := toolsets.NewToolset("gists", "Gists").
+			AddReadTools(toolsets.NewServerTool(ListGists(stubGetClientFn(mockClient), translations.NullTranslationHelper)))
+		tsg.AddToolset(issues)
+		tsg.AddToolset(gists)
+		require.NoError(t, tsg.EnableToolset("issues"))
+		return tsg
+	}
+
+	// Verify tool definition once
+	tool, _ := ListAvailableToolsets(newToolsetGroup(false), true, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_available_toolsets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name              string
+		readOnly          bool
+		canEnable         bool
+		expectedCanEnable map[string]bool
+		expectedAvailable map[string]bool
+	}{
+		{
+			name:              "dynamic toolsets",
+			canEnable:         true,
+			expectedCanEnable: map[string]bool{"gists": true, "issues": false},
+			expectedAvailable: map[string]bool{"get_issue": true, "create_issue": true, "list_gists": true},
+		},
+		{
+			name:              "read-only without dynamic toolsets",
+			readOnly:          true,
+			canEnable:         false,
+			expectedCanEnable: map[string]bool{"gists": false, "issues": false},
+			expectedAvailable: map[string]bool{"get_issue": true, "create_issue": false, "list_gists": true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListAvailableToolsets(newToolsetGroup(tc.readOnly), tc.canEnable, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{}))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned []AvailableToolset
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+
+			// Toolsets are sorted by name
+			require.Len(t, returned, 2)
+			assert.Equal(t, "gists", returned[0].Name)
+			assert.Equal(t, "issues", returned[1].Name)
+			assert.False(t, returned[0].CurrentlyEnabled)
+			assert.True(t, returned[1].CurrentlyEnabled)
+
+			available := map[string]bool{}
+			for _, ts := range returned {
+				assert.Equal(t, tc.expectedCanEnable[ts.Name], ts.CanEnable, ts.Name)
+				for _, tool := range ts.Tools {
+					available[tool.Name] = tool.Available
+					assert.Equal(t, tool.Name != "create_issue", tool.ReadOnly, tool.Name)
+				}
+			}
+			assert.Equal(t, tc.expectedAvailable, available)
+		})
+	}
+}
+
+func Test_ListAvailableToolsets_Need(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("issues", "GitHub Issues related tools").
+		AddReadTools(toolsets.NewServerTool(GetIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper))).
+		AddWriteTools(toolsets.NewServerTool(CreateIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper))))
+	tsg.AddToolset(toolsets.NewToolset("actions", "GitHub Actions workflows and CI/CD operations").
+		AddReadTools(toolsets.NewServerTool(ListWorkflows(stubGetClientFn(mockClient), translations.NullTranslationHelper))).
+		AddWriteTools(toolsets.NewServerTool(RerunFailedJobs(stubGetClientFn(mockClient), translations.NullTranslationHelper))))
+	tsg.AddToolset(toolsets.NewToolset("gists", "GitHub Gist related tools").
+		AddReadTools(toolsets.NewServerTool(ListGists(stubGetClientFn(mockClient), translations.NullTranslationHelper))))
+
+	tests := []struct {
+		name              string
+		need              string
+		expectedToolsets  []string
+		expectedFirstTool string
+	}{
+		{
+			name:              "the toolset of the need comes first",
+			need:              "Rerun the failed jobs of my workflows",
+			expectedToolsets:  []string{"actions"},
+			expectedFirstTool: "rerun_failed_jobs",
+		},
+		{
+			name:              "plurals match",
+			need:              "open new issues",
+			expectedToolsets:  []string{"issues"},
+			expectedFirstTool: "create_issue",
+		},
+		{
+			name:             "needs nothing covers",
+			need:             "order a pizza",
+			expectedToolsets: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListAvailableToolsets(tsg, true, translations.NullTranslationHelper)
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{"need": tc.need}))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned []AvailableToolset
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			names := []string{}
+			for _, ts := range returned {
+				names = append(names, ts.Name)
+				assert.Positive(t, ts.Relevance)
+			}
+			assert.Equal(t, tc.expectedToolsets, names)
+			if tc.expectedFirstTool != "" {
+				assert.Equal(t, tc.expectedFirstTool, returned[0].MatchingTools[0])
+			}
+		})
+	}
+}
+
+func Test_EnableToolset(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("gists", "GitHub Gist related tools").
+		AddReadTools(toolsets.NewServerTool(ListGists(stubGetClientFn(mockClient), translations.NullTranslationHelper))).
+		AddWriteTools(toolsets.NewServerTool(CreateGist(stubGetClientFn(mockClient), translations.NullTranslationHelper))))
+
+	srv := NewServer("test")
+	session := &progressSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	require.NoError(t, srv.RegisterSession(context.Background(), session))
+	_, handler := EnableToolset(srv, tsg, translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{"toolset": "gists"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	var enabled EnabledToolset
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &enabled))
+	assert.Equal(t, EnabledToolset{Name: "gists", Tools: []string{"list_gists", "create_gist"}}, enabled)
+	assert.True(t, tsg.IsEnabled("gists"))
+	response, ok := srv.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)).(mcp.JSONRPCResponse)
+	require.True(t, ok)
+	assert.Len(t, response.Result.(mcp.ListToolsResult).Tools, 2)
+
+	// The session is told once that the tools changed
+	require.Len(t, session.notifications, 1)
+	assert.Equal(t, mcp.MethodNotificationToolsListChanged, (<-session.notifications).Method)
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]interface{}{"toolset": "gists"}))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &enabled))
+	assert.True(t, enabled.AlreadyEnabled)
+	assert.Empty(t, session.notifications)
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]interface{}{"toolset": "unknown"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
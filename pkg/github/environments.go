@@ -0,0 +1,444 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Deployment branch policy modes of an environment, which decide the branches that can deploy to it.
+const (
+	// DeploymentBranchPolicyAll lets any branch deploy
+	DeploymentBranchPolicyAll = "all"
+	// DeploymentBranchPolicyProtected lets branches with branch protection rules deploy
+	DeploymentBranchPolicyProtected = "protected_branches"
+	// DeploymentBranchPolicyCustom lets branches and tags matching the environment's branch policies deploy
+	DeploymentBranchPolicyCustom = "custom"
+)
+
+const descriptionEnvironmentName = "The name of the environment, e.g. production"
+
+// DeploymentBranchPolicies are the branches and tags allowed to deploy to an environment.
+type DeploymentBranchPolicies struct {
+	Environment string `json:"environment"`
+	// Mode is all, protected_branches or custom. Policies only apply in custom mode.
+	Mode           string                           `json:"mode"`
+	TotalCount     int                              `json:"total_count"`
+	BranchPolicies []*github.DeploymentBranchPolicy `json:"branch_policies"`
+}
+
+// deploymentBranchPolicyMode returns the mode of the deployment branch policy of an environment.
+func deploymentBranchPolicyMode(env *github.Environment) string {
+	switch policy := env.DeploymentBranchPolicy; {
+	case policy == nil:
+		return DeploymentBranchPolicyAll
+	case policy.GetCustomBranchPolicies():
+		return DeploymentBranchPolicyCustom
+	case policy.GetProtectedBranches():
+		return DeploymentBranchPolicyProtected
+	default:
+		return DeploymentBranchPolicyAll
+	}
+}
+
+// environmentUpdate returns the update that keeps the protection rules of env as they are. The API
+// replaces the whole environment, so anything not sent, such as the required reviewers, would be cleared.
+func environmentUpdate(env *github.Environment) *github.CreateUpdateEnvironment {
+	update := &github.CreateUpdateEnvironment{
+		WaitTimer:              github.Ptr(0),
+		CanAdminsBypass:        env.CanAdminsBypass,
+		DeploymentBranchPolicy: env.DeploymentBranchPolicy,
+	}
+	for _, rule := range env.ProtectionRules {
+		switch rule.GetType() {
+		case "wait_timer":
+			update.WaitTimer = github.Ptr(rule.GetWaitTimer())
+		case "required_reviewers":
+			update.PreventSelfReview = rule.PreventSelfReview
+			for _, r := range rule.Reviewers {
+				var id int64
+				switch reviewer := r.Reviewer.(type) {
+				case *github.User:
+					id = reviewer.GetID()
+				case *github.Team:
+					id = reviewer.GetID()
+				default:
+					continue
+				}
+				update.Reviewers = append(update.Reviewers, &github.EnvReviewers{Type: r.Type, ID: github.Ptr(id)})
+			}
+		}
+	}
+	return update
+}
+
+// getEnvironment fetches an environment, returning a tool error result if that fails.
+func getEnvironment(ctx context.Context, client *github.Client, owner, repo, environment string) (*github.Environment, *mcp.CallToolResult) {
+	env, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, environment)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+				fmt.Sprintf("environment %s not found in %s/%s", environment, owner, repo),
+				resp,
+				err,
+			)
+		}
+		return nil, ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get environment", resp, err)
+	}
+	_ = resp.Body.Close()
+	return env, nil
+}
+
+// ListDeploymentBranchPolicies creates a tool to list the branches and tags allowed to deploy to an environment.
+func ListDeploymentBranchPolicies(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_deployment_branch_policies",
+			mcp.WithDescription(t("TOOL_LIST_DEPLOYMENT_BRANCH_POLICIES_DESCRIPTION", "List the branches that can deploy to an environment of a repository. The mode is all (any branch), protected_branches (branches with branch protection rules) or custom, in which only branches and tags matching the listed branch policies can deploy.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPLOYMENT_BRANCH_POLICIES_USER_TITLE", "List deployment branch policies"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description(descriptionEnvironmentName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			env, errResult := getEnvironment(ctx, client, owner, repo, environment)
+			if errResult != nil {
+				return errResult, nil
+			}
+
+			result := DeploymentBranchPolicies{
+				Environment:    environment,
+				Mode:           deploymentBranchPolicyMode(env),
+				BranchPolicies: []*github.DeploymentBranchPolicy{},
+			}
+			// Branch policies can only be listed, and only apply, in custom mode
+			if result.Mode == DeploymentBranchPolicyCustom {
+				policies, resp, err := client.Repositories.ListDeploymentBranchPolicies(ctx, owner, repo, environment)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list deployment branch policies", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				result.TotalCount = policies.GetTotalCount()
+				if policies.BranchPolicies != nil {
+					result.BranchPolicies = policies.BranchPolicies
+				}
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// CreateDeploymentBranchPolicy creates a tool to allow the branches or tags matching a pattern to deploy to
+// an environment.
+func CreateDeploymentBranchPolicy(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_deployment_branch_policy",
+			mcp.WithDescription(t("TOOL_CREATE_DEPLOYMENT_BRANCH_POLICY_DESCRIPTION", "Allow the branches or tags matching a name pattern to deploy to an environment. The environment must be in custom mode, see set_deployment_branch_policy_mode. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_DEPLOYMENT_BRANCH_POLICY_USER_TITLE", "Create deployment branch policy"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description(descriptionEnvironmentName),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name pattern branches or tags must match, using fnmatch syntax, e.g. main or release/*"),
+			),
+			mcp.WithString("type",
+				mcp.Description("Whether the pattern matches branches or tags, defaults to branch"),
+				mcp.Enum("branch", "tag"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			policyType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if policyType == "" {
+				policyType = "branch"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The API answers a generic 404 outside custom mode, so check first to say what to do about it
+			env, errResult := getEnvironment(ctx, client, owner, repo, environment)
+			if errResult != nil {
+				return errResult, nil
+			}
+			if mode := deploymentBranchPolicyMode(env); mode != DeploymentBranchPolicyCustom {
+				return mcp.NewToolResultError(fmt.Sprintf("environment %s is in %s mode, branch policies only apply in custom mode: use set_deployment_branch_policy_mode to switch to it first", environment, mode)), nil
+			}
+
+			policy, resp, err := client.Repositories.CreateDeploymentBranchPolicy(ctx, owner, repo, environment, &github.DeploymentBranchPolicyRequest{
+				Name: github.Ptr(name),
+				Type: github.Ptr(policyType),
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusConflict {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to create deployment branch policy: a %s policy %s already exists for environment %s", policyType, name, environment),
+						resp,
+						err,
+					), nil
+				}
+				return newAdminAPIErrorResponse(ctx, "failed to create deployment branch policy", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(policy), nil
+		}
+}
+
+// DeleteDeploymentBranchPolicy creates a tool to remove a branch policy from an environment.
+func DeleteDeploymentBranchPolicy(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_deployment_branch_policy",
+			mcp.WithDescription(t("TOOL_DELETE_DEPLOYMENT_BRANCH_POLICY_DESCRIPTION", "Delete a branch policy from an environment, so that the branches or tags it matched can no longer deploy to it unless another policy matches them. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_DEPLOYMENT_BRANCH_POLICY_USER_TITLE", "Delete deployment branch policy"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description(descriptionEnvironmentName),
+			),
+			mcp.WithNumber("branch_policy_id",
+				mcp.Required(),
+				mcp.Description("The ID of the branch policy, as returned by list_deployment_branch_policies"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			policyID, err := RequiredInt(request, "branch_policy_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteDeploymentBranchPolicy(ctx, owner, repo, environment, int64(policyID))
+			if err != nil {
+				return newAdminAPIErrorResponse(ctx, "failed to delete deployment branch policy", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Deleted branch policy %d from environment %s", policyID, environment)), nil
+		}
+}
+
+// SetDeploymentBranchPolicyMode creates a tool to choose which branches can deploy to an environment.
+func SetDeploymentBranchPolicyMode(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_deployment_branch_policy_mode",
+			mcp.WithDescription(t("TOOL_SET_DEPLOYMENT_BRANCH_POLICY_MODE_DESCRIPTION", "Choose which branches can deploy to an environment: any branch (all), branches with branch protection rules (protected_branches), or only branches and tags matching the environment's branch policies (custom). Leaving custom mode deletes the environment's branch policies. The other protection rules of the environment are kept. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_SET_DEPLOYMENT_BRANCH_POLICY_MODE_USER_TITLE", "Set deployment branch policy mode"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description(descriptionEnvironmentName),
+			),
+			mcp.WithString("mode",
+				mcp.Required(),
+				mcp.Description("Which branches can deploy to the environment"),
+				mcp.Enum(DeploymentBranchPolicyAll, DeploymentBranchPolicyProtected, DeploymentBranchPolicyCustom),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			mode, err := RequiredParam[string](request, "mode")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var policy *github.BranchPolicy
+			switch mode {
+			case DeploymentBranchPolicyAll:
+			case DeploymentBranchPolicyProtected:
+				policy = &github.BranchPolicy{ProtectedBranches: github.Ptr(true), CustomBranchPolicies: github.Ptr(false)}
+			case DeploymentBranchPolicyCustom:
+				policy = &github.BranchPolicy{ProtectedBranches: github.Ptr(false), CustomBranchPolicies: github.Ptr(true)}
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid mode %q, must be one of all, protected_branches or custom", mode)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			env, errResult := getEnvironment(ctx, client, owner, repo, environment)
+			if errResult != nil {
+				return errResult, nil
+			}
+
+			update := environmentUpdate(env)
+			update.DeploymentBranchPolicy = policy
+			updated, resp, err := client.Repositories.CreateUpdateEnvironment(ctx, owner, repo, environment, update)
+			if err != nil {
+				return newAdminAPIErrorResponse(ctx, "failed to update environment", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(map[string]any{
+				"environment": environment,
+				"mode":        deploymentBranchPolicyMode(updated),
+			}), nil
+		}
+}
+
+// ListEnvironments creates a tool to list the deployment environments of a repository.
+func ListEnvironments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_environments",
+			mcp.WithDescription(t("TOOL_LIST_ENVIRONMENTS_DESCRIPTION", "List the deployment environments of a repository with their protection rules: required reviewers, wait timer and the branches allowed to deploy.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ENVIRONMENTS_USER_TITLE", "List environments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			envs, resp, err := client.Repositories.ListEnvironments(ctx, owner, repo, &github.EnvironmentListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list environments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(envs), nil
+		}
+}
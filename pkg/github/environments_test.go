@@ -0,0 +1,491 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockEnvironment(policy *github.BranchPolicy) *github.Environment {
+	return &github.Environment{
+		Name:                   github.Ptr("production"),
+		DeploymentBranchPolicy: policy,
+	}
+}
+
+var customBranchPolicy = &github.BranchPolicy{ProtectedBranches: github.Ptr(false), CustomBranchPolicies: github.Ptr(true)}
+
+func forbiddenHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+	}
+}
+
+func Test_ListDeploymentBranchPolicies(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListDeploymentBranchPolicies(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_deployment_branch_policies", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       DeploymentBranchPolicies
+	}{
+		{
+			name: "custom mode",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockEnvironment(customBranchPolicy),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentName,
+					&github.DeploymentBranchPolicyResponse{
+						TotalCount: github.Ptr(2),
+						BranchPolicies: []*github.DeploymentBranchPolicy{
+							{ID: github.Ptr(int64(1)), Name: github.Ptr("main"), Type: github.Ptr("branch")},
+							{ID: github.Ptr(int64(2)), Name: github.Ptr("release/*"), Type: github.Ptr("branch")},
+						},
+					},
+				),
+			),
+			expected: DeploymentBranchPolicies{
+				Environment: "production",
+				Mode:        DeploymentBranchPolicyCustom,
+				TotalCount:  2,
+				BranchPolicies: []*github.DeploymentBranchPolicy{
+					{ID: github.Ptr(int64(1)), Name: github.Ptr("main"), Type: github.Ptr("branch")},
+					{ID: github.Ptr(int64(2)), Name: github.Ptr("release/*"), Type: github.Ptr("branch")},
+				},
+			},
+		},
+		{
+			name: "protected branches mode has no policies to list",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockEnvironment(&github.BranchPolicy{ProtectedBranches: github.Ptr(true), CustomBranchPolicies: github.Ptr(false)}),
+				),
+			),
+			expected: DeploymentBranchPolicies{
+				Environment:    "production",
+				Mode:           DeploymentBranchPolicyProtected,
+				BranchPolicies: []*github.DeploymentBranchPolicy{},
+			},
+		},
+		{
+			name: "any branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockEnvironment(nil),
+				),
+			),
+			expected: DeploymentBranchPolicies{
+				Environment:    "production",
+				Mode:           DeploymentBranchPolicyAll,
+				BranchPolicies: []*github.DeploymentBranchPolicy{},
+			},
+		},
+		{
+			name: "environment not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "environment production not found in owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListDeploymentBranchPolicies(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var policies DeploymentBranchPolicies
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &policies))
+			assert.Equal(t, tc.expected, policies)
+		})
+	}
+}
+
+func Test_CreateDeploymentBranchPolicy(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateDeploymentBranchPolicy(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_deployment_branch_policy", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "type")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment", "name"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       *github.DeploymentBranchPolicy
+	}{
+		{
+			name: "branch pattern",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockEnvironment(customBranchPolicy),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]interface{}{
+						"name": "release/*",
+						"type": "branch",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.DeploymentBranchPolicy{ID: github.Ptr(int64(3)), Name: github.Ptr("release/*"), Type: github.Ptr("branch")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"name":        "release/*",
+			},
+			expected: &github.DeploymentBranchPolicy{ID: github.Ptr(int64(3)), Name: github.Ptr("release/*"), Type: github.Ptr("branch")},
+		},
+		{
+			name: "environment not in custom mode",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockEnvironment(nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"name":        "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "environment production is in all mode, branch policies only apply in custom mode",
+		},
+		{
+			name: "not an admin",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockEnvironment(customBranchPolicy),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentName,
+					forbiddenHandler(),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"name":        "v*",
+				"type":        "tag",
+			},
+			expectError:    true,
+			expectedErrMsg: "requires admin access to the repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateDeploymentBranchPolicy(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var policy github.DeploymentBranchPolicy
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &policy))
+			assert.Equal(t, tc.expected, &policy)
+		})
+	}
+}
+
+func Test_DeleteDeploymentBranchPolicy(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteDeploymentBranchPolicy(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_deployment_branch_policy", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment", "branch_policy_id"})
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	requestArgs := map[string]interface{}{
+		"owner":            "owner",
+		"repo":             "repo",
+		"environment":      "production",
+		"branch_policy_id": float64(3),
+	}
+
+	t.Run("deleted", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentNameByBranchPolicyId,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "/repos/owner/repo/environments/production/deployment-branch-policies/3", r.URL.Path)
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			),
+		))
+		_, handler := DeleteDeploymentBranchPolicy(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(requestArgs))
+		require.NoError(t, err)
+		assert.Equal(t, "Deleted branch policy 3 from environment production", getTextResult(t, result).Text)
+	})
+
+	t.Run("not an admin", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentNameByBranchPolicyId,
+				forbiddenHandler(),
+			),
+		))
+		_, handler := DeleteDeploymentBranchPolicy(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(requestArgs))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "requires admin access to the repository")
+	})
+}
+
+func Test_SetDeploymentBranchPolicyMode(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetDeploymentBranchPolicyMode(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_deployment_branch_policy_mode", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment", "mode"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	// An environment with required reviewers and a wait timer, which the update must keep
+	environment := `{
+		"name": "production",
+		"can_admins_bypass": false,
+		"protection_rules": [
+			{"id": 1, "type": "wait_timer", "wait_timer": 30},
+			{"id": 2, "type": "required_reviewers", "prevent_self_review": true, "reviewers": [
+				{"type": "User", "reviewer": {"id": 7, "login": "octocat"}},
+				{"type": "Team", "reviewer": {"id": 9, "slug": "releasers"}}
+			]}
+		],
+		"deployment_branch_policy": null
+	}`
+
+	tests := []struct {
+		name           string
+		mode           string
+		expectedPolicy interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name:           "custom",
+			mode:           "custom",
+			expectedPolicy: map[string]interface{}{"protected_branches": false, "custom_branch_policies": true},
+		},
+		{
+			name:           "protected branches",
+			mode:           "protected_branches",
+			expectedPolicy: map[string]interface{}{"protected_branches": true, "custom_branch_policies": false},
+		},
+		{
+			name:           "all",
+			mode:           "all",
+			expectedPolicy: nil,
+		},
+		{
+			name:           "invalid mode",
+			mode:           "main_only",
+			expectError:    true,
+			expectedErrMsg: `invalid mode "main_only"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var sent map[string]interface{}
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						_, _ = w.Write([]byte(environment))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						body, err := io.ReadAll(r.Body)
+						require.NoError(t, err)
+						require.NoError(t, json.Unmarshal(body, &sent))
+
+						var policy *github.BranchPolicy
+						if raw, ok := sent["deployment_branch_policy"].(map[string]interface{}); ok {
+							policy = &github.BranchPolicy{
+								ProtectedBranches:    github.Ptr(raw["protected_branches"].(bool)),
+								CustomBranchPolicies: github.Ptr(raw["custom_branch_policies"].(bool)),
+							}
+						}
+						_, _ = w.Write(mock.MustMarshal(mockEnvironment(policy)))
+					}),
+				),
+			))
+			_, handler := SetDeploymentBranchPolicyMode(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"mode":        tc.mode,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, map[string]interface{}{
+				"wait_timer":          float64(30),
+				"can_admins_bypass":   false,
+				"prevent_self_review": true,
+				"reviewers": []interface{}{
+					map[string]interface{}{"type": "User", "id": float64(7)},
+					map[string]interface{}{"type": "Team", "id": float64(9)},
+				},
+				"deployment_branch_policy": tc.expectedPolicy,
+			}, sent)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, map[string]interface{}{"environment": "production", "mode": tc.mode}, response)
+		})
+	}
+}
+
+func Test_ListEnvironments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListEnvironments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_environments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful environments list",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.EnvResponse{
+							TotalCount:   github.Ptr(1),
+							Environments: []*github.Environment{mockEnvironment(nil)},
+						}),
+					),
+				),
+			),
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list environments",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListEnvironments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.EnvResponse
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, 1, returned.GetTotalCount())
+			require.Len(t, returned.Environments, 1)
+			assert.Equal(t, "production", returned.Environments[0].GetName())
+		})
+	}
+}
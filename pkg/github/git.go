@@ -0,0 +1,649 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxGitBlobSize caps the size of the blobs get_git_blob returns.
+	maxGitBlobSize = 1 << 20 // 1 MiB
+
+	// defaultGitTreeEntries and maxGitTreeEntries bound how many entries get_git_tree returns.
+	defaultGitTreeEntries = 1000
+	maxGitTreeEntries     = 10000
+)
+
+// gitTreeEntryModes are the file modes of git tree entries: file, executable, directory, submodule and symlink.
+var gitTreeEntryModes = []string{"100644", "100755", "040000", "160000", "120000"}
+
+// GitBlob is the output type of get_git_blob.
+type GitBlob struct {
+	SHA  string `json:"sha"`
+	Size int    `json:"size"`
+
+	// Encoding is utf-8 when Content is the text of the blob, or base64 for binary blobs
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// GitTreeEntry is an entry of the output of get_git_tree.
+type GitTreeEntry struct {
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+	Type string `json:"type"`
+	SHA  string `json:"sha"`
+	Size int    `json:"size,omitempty"`
+}
+
+// GitTree is the output type of get_git_tree.
+type GitTree struct {
+	SHA     string         `json:"sha"`
+	Entries []GitTreeEntry `json:"entries"`
+
+	// Truncated is set when GitHub cut the recursive listing short, or when more entries matched than
+	// max_entries; Omitted counts the latter
+	Truncated bool `json:"truncated,omitempty"`
+	Omitted   int  `json:"omitted,omitempty"`
+}
+
+// GitObjectResult is the output type of the tools creating git objects and updating refs.
+type GitObjectResult struct {
+	SHA     string   `json:"sha"`
+	Ref     string   `json:"ref,omitempty"`
+	Tree    string   `json:"tree,omitempty"`
+	Parents []string `json:"parents,omitempty"`
+	HTMLURL string   `json:"html_url,omitempty"`
+}
+
+// GetGitBlob creates a tool to get the content of a git blob by its SHA.
+func GetGitBlob(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_git_blob",
+			mcp.WithDescription(t("TOOL_GET_GIT_BLOB_DESCRIPTION", fmt.Sprintf("Get the content of a git blob by its SHA, as text when it is valid UTF-8 or base64 otherwise. Blobs of more than %d bytes are refused, get the files with get_file_contents instead.", maxGitBlobSize))),
+			mcp.WithOutputSchema[GitBlob](),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_GIT_BLOB_USER_TITLE", "Get git blob"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the blob, such as the sha of a blob entry of get_git_tree"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			blob, resp, err := client.Git.GetBlob(ctx, owner, repo, sha)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get blob %s", sha), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if blob.GetSize() > maxGitBlobSize {
+				return mcp.NewToolResultError(fmt.Sprintf("blob %s is %d bytes, more than the %d bytes get_git_blob returns", sha, blob.GetSize(), maxGitBlobSize)), nil
+			}
+
+			content := blob.GetContent()
+			if blob.GetEncoding() == "base64" {
+				// GitHub wraps base64 content in lines
+				content = strings.ReplaceAll(content, "\n", "")
+				decoded, err := base64.StdEncoding.DecodeString(content)
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode blob content: %w", err)
+				}
+				if !utf8.Valid(decoded) {
+					return MarshalledTextResult(GitBlob{SHA: blob.GetSHA(), Size: blob.GetSize(), Encoding: "base64", Content: content}), nil
+				}
+				content = string(decoded)
+			}
+
+			return MarshalledTextResult(GitBlob{SHA: blob.GetSHA(), Size: blob.GetSize(), Encoding: "utf-8", Content: content}), nil
+		}
+}
+
+// CreateGitBlob creates a tool to store content as a git blob, to reference from create_git_tree.
+func CreateGitBlob(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_git_blob",
+			mcp.WithDescription(t("TOOL_CREATE_GIT_BLOB_DESCRIPTION", "Store content as a git blob and return its SHA, to reference from the entries of create_git_tree. Only needed for binary or large files, create_git_tree also takes text content directly.")),
+			mcp.WithOutputSchema[GitObjectResult](),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_GIT_BLOB_USER_TITLE", "Create git blob"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("Content of the blob"),
+			),
+			mcp.WithString("encoding",
+				mcp.Description("Encoding of content, base64 for binary content"),
+				mcp.Enum("utf-8", "base64"),
+				mcp.DefaultString("utf-8"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := RequiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			encoding, err := OptionalParam[string](request, "encoding")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if encoding == "" {
+				encoding = "utf-8"
+			}
+
+			size := len(content)
+			switch encoding {
+			case "utf-8":
+			case "base64":
+				decoded, err := base64.StdEncoding.DecodeString(content)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("content is not valid base64: %s", err)), nil
+				}
+				size = len(decoded)
+			default:
+				return mcp.NewToolResultError("encoding must be utf-8 or base64"), nil
+			}
+			if size > maxFileSize {
+				return fileTooLargeResult("the blob", size), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			blob, resp, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+				Content:  github.Ptr(content),
+				Encoding: github.Ptr(encoding),
+			})
+			if err != nil {
+				return uploadLargeFileResponse(ctx, "failed to create blob", "the blob", size, resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(GitObjectResult{SHA: blob.GetSHA()}), nil
+		}
+}
+
+// GetGitTree creates a tool to list the entries of a git tree, optionally recursively.
+func GetGitTree(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_git_tree",
+			mcp.WithDescription(t("TOOL_GET_GIT_TREE_DESCRIPTION", "List the entries of a git tree, the files and directories of a commit, with their modes and SHAs. Recursive listings of large repositories are capped by max_entries; narrow them down with path_prefix.")),
+			mcp.WithOutputSchema[GitTree](),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_GIT_TREE_USER_TITLE", "Get git tree"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("tree_sha",
+				mcp.Required(),
+				mcp.Description("SHA of the tree, or a commit SHA, branch or tag name to get its root tree"),
+			),
+			mcp.WithBoolean("recursive",
+				mcp.Description("List the entries of all subtrees too"),
+			),
+			mcp.WithString("path_prefix",
+				mcp.Description("Only return the entries whose path starts with this prefix, such as 'docs/'"),
+			),
+			mcp.WithNumber("max_entries",
+				mcp.Description(fmt.Sprintf("Maximum number of entries to return (max %d)", maxGitTreeEntries)),
+				mcp.Min(1),
+				mcp.Max(maxGitTreeEntries),
+				mcp.DefaultNumber(defaultGitTreeEntries),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			treeSHA, err := RequiredParam[string](request, "tree_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			recursive, err := OptionalParam[bool](request, "recursive")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pathPrefix, err := OptionalParam[string](request, "path_prefix")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxEntries, err := OptionalIntParamWithDefault(request, "max_entries", defaultGitTreeEntries)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxEntries < 1 || maxEntries > maxGitTreeEntries {
+				return mcp.NewToolResultError(fmt.Sprintf("max_entries must be between 1 and %d", maxGitTreeEntries)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, treeSHA, recursive)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get tree %s", treeSHA), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := GitTree{
+				SHA:       tree.GetSHA(),
+				Entries:   make([]GitTreeEntry, 0, min(len(tree.Entries), maxEntries)),
+				Truncated: tree.GetTruncated(),
+			}
+			for _, entry := range tree.Entries {
+				if !strings.HasPrefix(entry.GetPath(), pathPrefix) {
+					continue
+				}
+				if len(result.Entries) == maxEntries {
+					result.Omitted++
+					continue
+				}
+				result.Entries = append(result.Entries, GitTreeEntry{
+					Path: entry.GetPath(),
+					Mode: entry.GetMode(),
+					Type: entry.GetType(),
+					SHA:  entry.GetSHA(),
+					Size: entry.GetSize(),
+				})
+			}
+			if result.Omitted > 0 {
+				result.Truncated = true
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// CreateGitTree creates a tool to create a git tree from a list of entries, on top of an existing tree or from scratch.
+func CreateGitTree(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_git_tree",
+			mcp.WithDescription(t("TOOL_CREATE_GIT_TREE_DESCRIPTION", "Create a git tree and return its SHA, to commit with create_git_commit. Entries are added to base_tree, replacing the entries at the same paths; without base_tree the tree holds only the given entries. Each entry sets either the sha of an existing blob or tree, or the text content of a new file, or neither to delete the path from base_tree.")),
+			mcp.WithOutputSchema[GitObjectResult](),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_GIT_TREE_USER_TITLE", "Create git tree"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("base_tree",
+				mcp.Description("SHA of the tree to add the entries to, such as the tree of the commit a branch points to"),
+			),
+			mcp.WithArray("entries",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"path"},
+						"properties": map[string]interface{}{
+							"path": map[string]interface{}{
+								"type":        "string",
+								"description": "path of the entry",
+							},
+							"mode": map[string]interface{}{
+								"type":        "string",
+								"description": "file mode: 100644 for a file (the default), 100755 for an executable, 040000 for a directory, 160000 for a submodule or 120000 for a symlink",
+								"enum":        gitTreeEntryModes,
+							},
+							"type": map[string]interface{}{
+								"type":        "string",
+								"description": "blob (the default), tree or commit, matching the mode",
+								"enum":        []string{"blob", "tree", "commit"},
+							},
+							"sha": map[string]interface{}{
+								"type":        "string",
+								"description": "SHA of an existing object for the entry",
+							},
+							"content": map[string]interface{}{
+								"type":        "string",
+								"description": "text content of a new file for the entry",
+							},
+						},
+					}),
+				mcp.Description("Entries of the tree"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			baseTree, err := OptionalParam[string](request, "base_tree")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			entriesObj, ok := request.GetArguments()["entries"].([]interface{})
+			if !ok || len(entriesObj) == 0 {
+				return mcp.NewToolResultError("entries must be a non-empty array of objects with a path"), nil
+			}
+			entries := make([]*github.TreeEntry, 0, len(entriesObj))
+			for _, e := range entriesObj {
+				entryMap, ok := e.(map[string]interface{})
+				if !ok {
+					return mcp.NewToolResultError("each entry must be an object with a path"), nil
+				}
+				path, _ := entryMap["path"].(string)
+				if path == "" {
+					return mcp.NewToolResultError("each entry must have a path"), nil
+				}
+				mode, _ := entryMap["mode"].(string)
+				if mode == "" {
+					mode = "100644"
+				}
+				if !slices.Contains(gitTreeEntryModes, mode) {
+					return mcp.NewToolResultError(fmt.Sprintf("entry %s has invalid mode %s, expected one of %s", path, mode, strings.Join(gitTreeEntryModes, ", "))), nil
+				}
+				entryType, _ := entryMap["type"].(string)
+				if entryType == "" {
+					entryType = "blob"
+				}
+
+				entry := &github.TreeEntry{
+					Path: github.Ptr(path),
+					Mode: github.Ptr(mode),
+					Type: github.Ptr(entryType),
+				}
+				sha, hasSHA := entryMap["sha"].(string)
+				content, hasContent := entryMap["content"].(string)
+				switch {
+				case hasSHA && hasContent:
+					return mcp.NewToolResultError(fmt.Sprintf("entry %s can't have both a sha and content", path)), nil
+				case hasSHA:
+					entry.SHA = github.Ptr(sha)
+				case hasContent:
+					if len(content) > largeFileThreshold {
+						return mcp.NewToolResultError(fmt.Sprintf("entry %s has more than %d bytes of content, store it with create_git_blob and set its sha instead", path, largeFileThreshold)), nil
+					}
+					entry.Content = github.Ptr(content)
+				case baseTree == "":
+					// An entry without a sha or content deletes the path, which only makes sense on a base tree
+					return mcp.NewToolResultError(fmt.Sprintf("entry %s needs a sha or content, paths can only be deleted from a base_tree", path)), nil
+				}
+				entries = append(entries, entry)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			tree, resp, err := client.Git.CreateTree(ctx, owner, repo, baseTree, entries)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create tree", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(GitObjectResult{SHA: tree.GetSHA()}), nil
+		}
+}
+
+// CreateGitCommit creates a tool to create a git commit from a tree, without moving any branch to it.
+func CreateGitCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_git_commit",
+			mcp.WithDescription(t("TOOL_CREATE_GIT_COMMIT_DESCRIPTION", "Create a git commit of a tree and return its SHA. No branch points to the commit until it is set with update_git_ref. A commit without parents starts a new history, such as an orphan branch; a commit with the parent of a series of commits and the tree of its last one squashes them.")),
+			mcp.WithOutputSchema[GitObjectResult](),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_GIT_COMMIT_USER_TITLE", "Create git commit"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			mcp.WithString("tree",
+				mcp.Required(),
+				mcp.Description("SHA of the tree of the commit"),
+			),
+			mcp.WithArray("parents",
+				mcp.Description("SHAs of the parent commits: one for a regular commit, several for a merge commit, none for a root commit"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithString("author_name",
+				mcp.Description("Name of the author, the authenticated user when not set"),
+			),
+			mcp.WithString("author_email",
+				mcp.Description("Email of the author, required with author_name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			treeSHA, err := RequiredParam[string](request, "tree")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			parents, err := OptionalStringArrayParam(request, "parents")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			authorName, err := OptionalParam[string](request, "author_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			authorEmail, err := OptionalParam[string](request, "author_email")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (authorName == "") != (authorEmail == "") {
+				return mcp.NewToolResultError("author_name and author_email must be set together"), nil
+			}
+
+			commit := &github.Commit{
+				Message: github.Ptr(message),
+				Tree:    &github.Tree{SHA: github.Ptr(treeSHA)},
+				Parents: make([]*github.Commit, 0, len(parents)),
+			}
+			for _, parent := range parents {
+				commit.Parents = append(commit.Parents, &github.Commit{SHA: github.Ptr(parent)})
+			}
+			if authorName != "" {
+				commit.Author = &github.CommitAuthor{Name: github.Ptr(authorName), Email: github.Ptr(authorEmail)}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create commit", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := GitObjectResult{
+				SHA:     created.GetSHA(),
+				Tree:    created.GetTree().GetSHA(),
+				Parents: make([]string, 0, len(created.Parents)),
+				HTMLURL: created.GetHTMLURL(),
+			}
+			for _, parent := range created.Parents {
+				result.Parents = append(result.Parents, parent.GetSHA())
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// UpdateGitRef creates a tool to point a branch or tag at a commit, creating it if asked to.
+func UpdateGitRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_git_ref",
+			mcp.WithDescription(t("TOOL_UPDATE_GIT_REF_DESCRIPTION", "Point a branch or tag at a commit, such as one made with create_git_commit. Updates that don't fast-forward the ref, because the commit doesn't descend from the one it points to, are refused unless force is set. Set create to make a new ref instead.")),
+			mcp.WithOutputSchema[GitObjectResult](),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_GIT_REF_USER_TITLE", "Update git ref"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("The ref, such as heads/main for a branch or tags/v1.0.0 for a tag"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit to point the ref at"),
+			),
+			mcp.WithBoolean("force",
+				mcp.Description("Update the ref even if it doesn't fast-forward, discarding the commits only it points to"),
+			),
+			mcp.WithBoolean("create",
+				mcp.Description("Create the ref, which must not exist yet"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			force, err := OptionalParam[bool](request, "force")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			create, err := OptionalParam[bool](request, "create")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			ref = "refs/" + strings.TrimPrefix(ref, "refs/")
+			if !strings.HasPrefix(ref, "refs/heads/") && !strings.HasPrefix(ref, "refs/tags/") {
+				return mcp.NewToolResultError("ref must be a branch such as heads/main or a tag such as tags/v1.0.0"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			reference := &github.Reference{
+				Ref:    github.Ptr(ref),
+				Object: &github.GitObject{SHA: github.Ptr(sha)},
+			}
+			var updated *github.Reference
+			var resp *github.Response
+			if create {
+				updated, resp, err = client.Git.CreateRef(ctx, owner, repo, reference)
+			} else {
+				updated, resp, err = client.Git.UpdateRef(ctx, owner, repo, reference, force)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to update %s", ref), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(GitObjectResult{SHA: updated.GetObject().GetSHA(), Ref: updated.GetRef()}), nil
+		}
+}
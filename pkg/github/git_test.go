@@ -0,0 +1,620 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetGitBlob(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGitBlob(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_git_blob", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	binary := base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe, 0x00})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedResult string
+	}{
+		{
+			name: "decodes text blobs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					expectPath(t, "/repos/owner/repo/git/blobs/abc123").andThen(
+						mockResponse(t, http.StatusOK, &github.Blob{
+							SHA:      github.Ptr("abc123"),
+							Size:     github.Ptr(12),
+							Encoding: github.Ptr("base64"),
+							Content:  github.Ptr("aGVsbG8g\nd29ybGQK\n"),
+						}),
+					),
+				),
+			),
+			expectedResult: `{"sha": "abc123", "size": 12, "encoding": "utf-8", "content": "hello world\n"}`,
+		},
+		{
+			name: "returns binary blobs as base64",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					&github.Blob{
+						SHA:      github.Ptr("abc123"),
+						Size:     github.Ptr(3),
+						Encoding: github.Ptr("base64"),
+						Content:  github.Ptr(binary),
+					},
+				),
+			),
+			expectedResult: `{"sha": "abc123", "size": 3, "encoding": "base64", "content": "` + binary + `"}`,
+		},
+		{
+			name: "refuses large blobs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					&github.Blob{
+						SHA:      github.Ptr("abc123"),
+						Size:     github.Ptr(maxGitBlobSize + 1),
+						Encoding: github.Ptr("base64"),
+						Content:  github.Ptr(""),
+					},
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "blob abc123 is 1048577 bytes, more than the 1048576 bytes get_git_blob returns",
+		},
+		{
+			name: "blob not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get blob abc123",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetGitBlob(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, tc.expectedResult, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_CreateGitBlob(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateGitBlob(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_git_blob", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "content"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "creates a base64 blob",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"content":  "/w==",
+						"encoding": "base64",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Blob{SHA: github.Ptr("abc123")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"content":  "/w==",
+				"encoding": "base64",
+			},
+		},
+		{
+			name:         "refuses invalid base64",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"content":  "not base64!",
+				"encoding": "base64",
+			},
+			expectError:    true,
+			expectedErrMsg: "content is not valid base64",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateGitBlob(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, `{"sha": "abc123"}`, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_GetGitTree(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGitTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_git_tree", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "recursive")
+	assert.Contains(t, tool.InputSchema.Properties, "path_prefix")
+	assert.Contains(t, tool.InputSchema.Properties, "max_entries")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tree_sha"})
+
+	mockTree := &github.Tree{
+		SHA: github.Ptr("def456"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("README.md"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), SHA: github.Ptr("a1"), Size: github.Ptr(10)},
+			{Path: github.Ptr("docs"), Mode: github.Ptr("040000"), Type: github.Ptr("tree"), SHA: github.Ptr("a2")},
+			{Path: github.Ptr("docs/a.md"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), SHA: github.Ptr("a3"), Size: github.Ptr(20)},
+			{Path: github.Ptr("docs/b.md"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), SHA: github.Ptr("a4"), Size: github.Ptr(30)},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult string
+	}{
+		{
+			name: "lists a tree recursively",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					expectQueryParams(t, map[string]string{"recursive": "1"}).andThen(
+						mockResponse(t, http.StatusOK, mockTree),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"tree_sha":  "main",
+				"recursive": true,
+			},
+			expectedResult: `{"sha": "def456", "entries": [
+				{"path": "README.md", "mode": "100644", "type": "blob", "sha": "a1", "size": 10},
+				{"path": "docs", "mode": "040000", "type": "tree", "sha": "a2"},
+				{"path": "docs/a.md", "mode": "100644", "type": "blob", "sha": "a3", "size": 20},
+				{"path": "docs/b.md", "mode": "100644", "type": "blob", "sha": "a4", "size": 30}
+			]}`,
+		},
+		{
+			name: "filters by prefix and caps the entries",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					mockTree,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"tree_sha":    "main",
+				"recursive":   true,
+				"path_prefix": "docs/",
+				"max_entries": float64(1),
+			},
+			expectedResult: `{"sha": "def456", "entries": [
+				{"path": "docs/a.md", "mode": "100644", "type": "blob", "sha": "a3", "size": 20}
+			], "truncated": true, "omitted": 1}`,
+		},
+		{
+			name:         "refuses too many entries",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"tree_sha":    "main",
+				"max_entries": float64(maxGitTreeEntries + 1),
+			},
+			expectError:    true,
+			expectedErrMsg: "max_entries must be between 1 and 10000",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetGitTree(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, tc.expectedResult, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_CreateGitTree(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateGitTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_git_tree", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "entries"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "creates a tree on a base tree",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{"path": "bin/run", "mode": "100755", "type": "blob", "content": "#!/bin/sh\n"},
+							map[string]interface{}{"path": "logo.png", "mode": "100644", "type": "blob", "sha": "abc123"},
+							map[string]interface{}{"path": "old.md", "mode": "100644", "type": "blob", "sha": nil},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("ghi789")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"base_tree": "def456",
+				"entries": []interface{}{
+					map[string]interface{}{"path": "bin/run", "mode": "100755", "content": "#!/bin/sh\n"},
+					map[string]interface{}{"path": "logo.png", "sha": "abc123"},
+					map[string]interface{}{"path": "old.md"},
+				},
+			},
+		},
+		{
+			name:         "refuses deletions without a base tree",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"entries": []interface{}{
+					map[string]interface{}{"path": "old.md"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "entry old.md needs a sha or content, paths can only be deleted from a base_tree",
+		},
+		{
+			name:         "refuses entries with a sha and content",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"entries": []interface{}{
+					map[string]interface{}{"path": "a.md", "sha": "abc123", "content": "a"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "entry a.md can't have both a sha and content",
+		},
+		{
+			name:         "refuses invalid modes",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"entries": []interface{}{
+					map[string]interface{}{"path": "a.md", "mode": "644", "content": "a"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "entry a.md has invalid mode 644",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateGitTree(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, `{"sha": "ghi789"}`, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_CreateGitCommit(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateGitCommit(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_git_commit", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "message", "tree"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult string
+	}{
+		{
+			name: "creates a commit with an author",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Squash feature",
+						"tree":    "ghi789",
+						"parents": []interface{}{"abc123"},
+						"author":  map[string]interface{}{"name": "Mona", "email": "mona@example.com"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Commit{
+							SHA:     github.Ptr("jkl012"),
+							Tree:    &github.Tree{SHA: github.Ptr("ghi789")},
+							Parents: []*github.Commit{{SHA: github.Ptr("abc123")}},
+							HTMLURL: github.Ptr("https://github.com/owner/repo/commit/jkl012"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"message":      "Squash feature",
+				"tree":         "ghi789",
+				"parents":      []interface{}{"abc123"},
+				"author_name":  "Mona",
+				"author_email": "mona@example.com",
+			},
+			expectedResult: `{"sha": "jkl012", "tree": "ghi789", "parents": ["abc123"], "html_url": "https://github.com/owner/repo/commit/jkl012"}`,
+		},
+		{
+			name: "creates a root commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Start over",
+						"tree":    "ghi789",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Commit{
+							SHA:  github.Ptr("jkl012"),
+							Tree: &github.Tree{SHA: github.Ptr("ghi789")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"message": "Start over",
+				"tree":    "ghi789",
+			},
+			expectedResult: `{"sha": "jkl012", "tree": "ghi789"}`,
+		},
+		{
+			name:         "refuses an author without an email",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"message":     "Squash feature",
+				"tree":        "ghi789",
+				"author_name": "Mona",
+			},
+			expectError:    true,
+			expectedErrMsg: "author_name and author_email must be set together",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateGitCommit(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, tc.expectedResult, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_UpdateGitRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateGitRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_git_ref", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref", "sha"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	updatedRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/feature"),
+		Object: &github.GitObject{SHA: github.Ptr("jkl012")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "force-updates a branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]interface{}{
+						"sha":   "jkl012",
+						"force": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, updatedRef),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "heads/feature",
+				"sha":   "jkl012",
+				"force": true,
+			},
+		},
+		{
+			name: "creates a branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"ref": "refs/heads/feature",
+						"sha": "jkl012",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, updatedRef),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"ref":    "refs/heads/feature",
+				"sha":    "jkl012",
+				"create": true,
+			},
+		},
+		{
+			name: "reports refused updates",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Update is not a fast forward"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "heads/feature",
+				"sha":   "jkl012",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update refs/heads/feature",
+		},
+		{
+			name:         "refuses other refs",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "pull/1/head",
+				"sha":   "jkl012",
+			},
+			expectError:    true,
+			expectedErrMsg: "ref must be a branch such as heads/main or a tag such as tags/v1.0.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateGitRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, `{"sha": "jkl012", "ref": "refs/heads/feature"}`, getTextResult(t, result).Text)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/graphql"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultGraphQLMaxCost caps the estimated nodes a graphql_query operation may request when no other
+// limit is configured. GitHub itself refuses operations of more than 500,000 nodes.
+const DefaultGraphQLMaxCost = 10000
+
+// GraphQLQueryResult is the result of an operation run by graphql_query.
+type GraphQLQueryResult struct {
+	Data          json.RawMessage `json:"data,omitempty"`
+	Errors        []graphql.Error `json:"errors,omitempty"`
+	EstimatedCost int             `json:"estimated_cost"`
+}
+
+// GraphQLQuery creates a tool to run an arbitrary GraphQL operation against the GitHub GraphQL API. The
+// operation is validated against the schema of the API and its estimated cost is capped by maxCost before
+// it is sent. Mutations are refused unless allowMutations is set.
+func GraphQLQuery(getGraphQLClient graphql.GetClientFn, allowMutations bool, maxCost int, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	description := "Run a GraphQL query against the GitHub GraphQL API, for data the other tools don't expose. The query is validated against the schema of the API before it is sent: every connection needs first or last (at most 100), and the estimated number of nodes requested, each page size times the page sizes it is nested in, must stay within %d. Only queries can be run, mutations are refused."
+	if allowMutations {
+		description = "Run a GraphQL query or mutation against the GitHub GraphQL API, for data and changes the other tools don't expose. The document is validated against the schema of the API before it is sent: every connection needs first or last (at most 100), and the estimated number of nodes requested, each page size times the page sizes it is nested in, must stay within %d."
+	}
+
+	return mcp.NewTool("graphql_query",
+			mcp.WithDescription(t("TOOL_GRAPHQL_QUERY_DESCRIPTION", fmt.Sprintf(description, maxCost))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GRAPHQL_QUERY_USER_TITLE", "Run GraphQL query"),
+				ReadOnlyHint: ToBoolPtr(!allowMutations),
+			}),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("The GraphQL document, with variables declared as in query($owner: String!) rather than inlined"),
+			),
+			mcp.WithObject("variables",
+				mcp.Description("Values of the variables the document declares"),
+			),
+			mcp.WithString("operation_name",
+				mcp.Description("The operation to run, required when the document has more than one"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			query, err := RequiredParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			operationName, err := OptionalParam[string](request, "operation_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var variables map[string]any
+			if v, ok := request.GetArguments()["variables"]; ok && v != nil {
+				if variables, ok = v.(map[string]any); !ok {
+					return mcp.NewToolResultError("variables must be an object"), nil
+				}
+			}
+
+			doc, err := graphql.Parse(query)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGraphQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+			}
+			schema, err := client.Schema(ctx)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to load the GraphQL schema", err), nil
+			}
+
+			analysis, err := graphql.Validate(doc, schema, operationName, variables, graphql.Limits{
+				AllowMutations: allowMutations,
+				MaxCost:        maxCost,
+			})
+			if err != nil {
+				var validationErr *graphql.ValidationError
+				if errors.As(err, &validationErr) {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				return nil, err
+			}
+
+			response, err := client.Do(ctx, graphql.Request{
+				Query:         query,
+				Variables:     variables,
+				OperationName: operationName,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to run GraphQL query", err), nil
+			}
+
+			// Partial data is worth returning along with the errors of the fields that failed
+			if len(response.Errors) > 0 && (len(response.Data) == 0 || string(response.Data) == "null") {
+				messages := make([]string, 0, len(response.Errors))
+				for _, e := range response.Errors {
+					messages = append(messages, e.Message)
+				}
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to run GraphQL query", errors.New(strings.Join(messages, "; "))), nil
+			}
+
+			return MarshalledTextResult(GraphQLQueryResult{
+				Data:          response.Data,
+				Errors:        response.Errors,
+				EstimatedCost: analysis.Cost,
+			}), nil
+		}
+}
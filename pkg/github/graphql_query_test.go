@@ -0,0 +1,252 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/graphql"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const graphQLQueryTestSchema = `{"__schema": {
+  "queryType": {"name": "Query"},
+  "mutationType": {"name": "Mutation"},
+  "types": [
+    {"kind": "OBJECT", "name": "Query", "fields": [
+      {"name": "viewer", "args": [], "type": {"kind": "NON_NULL", "ofType": {"kind": "OBJECT", "name": "User"}}}
+    ]},
+    {"kind": "OBJECT", "name": "Mutation", "fields": [
+      {"name": "followUser", "args": [
+        {"name": "input", "type": {"kind": "NON_NULL", "ofType": {"kind": "INPUT_OBJECT", "name": "FollowUserInput"}}}
+      ], "type": {"kind": "OBJECT", "name": "FollowUserPayload"}}
+    ]},
+    {"kind": "OBJECT", "name": "FollowUserPayload", "fields": [
+      {"name": "clientMutationId", "args": [], "type": {"kind": "SCALAR", "name": "String"}}
+    ]},
+    {"kind": "INPUT_OBJECT", "name": "FollowUserInput"},
+    {"kind": "OBJECT", "name": "User", "fields": [
+      {"name": "login", "args": [], "type": {"kind": "NON_NULL", "ofType": {"kind": "SCALAR", "name": "String"}}},
+      {"name": "repositories", "args": [
+        {"name": "first", "type": {"kind": "SCALAR", "name": "Int"}}
+      ], "type": {"kind": "NON_NULL", "ofType": {"kind": "OBJECT", "name": "RepositoryConnection"}}}
+    ]},
+    {"kind": "OBJECT", "name": "RepositoryConnection", "fields": [
+      {"name": "nodes", "args": [], "type": {"kind": "LIST", "ofType": {"kind": "OBJECT", "name": "Repository"}}},
+      {"name": "pageInfo", "args": [], "type": {"kind": "NON_NULL", "ofType": {"kind": "OBJECT", "name": "PageInfo"}}}
+    ]},
+    {"kind": "OBJECT", "name": "Repository", "fields": [
+      {"name": "name", "args": [], "type": {"kind": "NON_NULL", "ofType": {"kind": "SCALAR", "name": "String"}}}
+    ]},
+    {"kind": "OBJECT", "name": "PageInfo", "fields": [
+      {"name": "hasNextPage", "args": [], "type": {"kind": "NON_NULL", "ofType": {"kind": "SCALAR", "name": "Boolean"}}}
+    ]},
+    {"kind": "SCALAR", "name": "String"},
+    {"kind": "SCALAR", "name": "Int"},
+    {"kind": "SCALAR", "name": "Boolean"}
+  ]
+}}`
+
+// newGraphQLQueryTestClient returns a client for a server that answers every request with the given
+// status and body, collecting the requests it receives.
+func newGraphQLQueryTestClient(t *testing.T, status int, body string, received *[]graphql.Request) graphql.GetClientFn {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request graphql.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		*received = append(*received, request)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	schema, err := graphql.ParseIntrospection([]byte(graphQLQueryTestSchema))
+	require.NoError(t, err)
+	client := graphql.NewClient(server.Client(), server.URL)
+	client.SetSchema(schema)
+	return func(_ context.Context) (*graphql.Client, error) {
+		return client, nil
+	}
+}
+
+func Test_GraphQLQuery(t *testing.T) {
+	// Verify tool definition once
+	var received []graphql.Request
+	tool, _ := GraphQLQuery(newGraphQLQueryTestClient(t, http.StatusOK, `{}`, &received), false, 1000, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "graphql_query", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "variables")
+	assert.Contains(t, tool.InputSchema.Properties, "operation_name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"query"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		allowMutations bool
+		maxCost        int
+		status         int
+		responseBody   string
+		requestArgs    map[string]any
+		expectSent     bool
+		expectError    bool
+		expectedErrMsg string
+		expectedResult string
+	}{
+		{
+			name:         "runs a valid query",
+			status:       http.StatusOK,
+			responseBody: `{"data": {"viewer": {"login": "octocat", "repositories": {"nodes": [{"name": "hello"}]}}}}`,
+			requestArgs: map[string]any{
+				"query":     `query($n: Int!) { viewer { login repositories(first: $n) { nodes { name } } } }`,
+				"variables": map[string]any{"n": float64(5)},
+			},
+			expectSent:     true,
+			expectedResult: `{"data": {"viewer": {"login": "octocat", "repositories": {"nodes": [{"name": "hello"}]}}}, "estimated_cost": 5}`,
+		},
+		{
+			name:         "returns partial data with errors",
+			status:       http.StatusOK,
+			responseBody: `{"data": {"viewer": null}, "errors": [{"message": "Resource not accessible", "type": "FORBIDDEN", "path": ["viewer"]}]}`,
+			requestArgs: map[string]any{
+				"query": `{ viewer { login } }`,
+			},
+			expectSent:     true,
+			expectedResult: `{"data": {"viewer": null}, "errors": [{"message": "Resource not accessible", "type": "FORBIDDEN", "path": ["viewer"]}], "estimated_cost": 0}`,
+		},
+		{
+			name:         "fails when there is no data",
+			status:       http.StatusOK,
+			responseBody: `{"errors": [{"message": "Something went wrong"}, {"message": "And more"}]}`,
+			requestArgs: map[string]any{
+				"query": `{ viewer { login } }`,
+			},
+			expectSent:     true,
+			expectError:    true,
+			expectedErrMsg: "failed to run GraphQL query: Something went wrong; And more",
+		},
+		{
+			name:         "fails on HTTP errors",
+			status:       http.StatusUnauthorized,
+			responseBody: `{"message": "Bad credentials"}`,
+			requestArgs: map[string]any{
+				"query": `{ viewer { login } }`,
+			},
+			expectSent:     true,
+			expectError:    true,
+			expectedErrMsg: "GraphQL request failed with status 401 Unauthorized",
+		},
+		{
+			name: "refuses syntax errors",
+			requestArgs: map[string]any{
+				"query": `{ viewer { login }`,
+			},
+			expectError:    true,
+			expectedErrMsg: "syntax error at 1:19: expected a name, found end of document",
+		},
+		{
+			name: "refuses invalid queries",
+			requestArgs: map[string]any{
+				"query": `{ viewer { email repositories { nodes { name } } } }`,
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid document: 1:12: type User has no field email; 1:18: connection User.repositories needs first or last to set the page size",
+		},
+		{
+			name:    "refuses queries above the cost ceiling",
+			maxCost: 50,
+			requestArgs: map[string]any{
+				"query": `{ viewer { repositories(first: 100) { nodes { name } } } }`,
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid document: the estimated cost of 100 nodes exceeds the limit of 50",
+		},
+		{
+			name: "refuses mutations by default",
+			requestArgs: map[string]any{
+				"query": `mutation { followUser(input: {userId: "U_1"}) { clientMutationId } }`,
+			},
+			expectError:    true,
+			expectedErrMsg: "mutations are not allowed, only queries can be run",
+		},
+		{
+			name:           "runs mutations when allowed",
+			allowMutations: true,
+			status:         http.StatusOK,
+			responseBody:   `{"data": {"followUser": {"clientMutationId": null}}}`,
+			requestArgs: map[string]any{
+				"query": `mutation { followUser(input: {userId: "U_1"}) { clientMutationId } }`,
+			},
+			expectSent:     true,
+			expectedResult: `{"data": {"followUser": {"clientMutationId": null}}, "estimated_cost": 0}`,
+		},
+		{
+			name: "refuses variables that are not an object",
+			requestArgs: map[string]any{
+				"query":     `{ viewer { login } }`,
+				"variables": "n=5",
+			},
+			expectError:    true,
+			expectedErrMsg: "variables must be an object",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var received []graphql.Request
+			maxCost := tc.maxCost
+			if maxCost == 0 {
+				maxCost = DefaultGraphQLMaxCost
+			}
+			_, handler := GraphQLQuery(newGraphQLQueryTestClient(t, tc.status, tc.responseBody, &received), tc.allowMutations, maxCost, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectSent {
+				require.Len(t, received, 1)
+				assert.Equal(t, tc.requestArgs["query"], received[0].Query)
+			} else {
+				assert.Empty(t, received, "invalid documents are not sent")
+			}
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedResult, textContent.Text)
+		})
+	}
+}
+
+func Test_GraphQLToolset(t *testing.T) {
+	getClient := func(_ context.Context) (*graphql.Client, error) { return nil, nil }
+
+	for _, tc := range []struct {
+		name           string
+		readOnly       bool
+		allowMutations bool
+		expectReadOnly bool
+	}{
+		{name: "queries only", expectReadOnly: true},
+		{name: "mutations allowed", allowMutations: true},
+		{name: "read-only server", readOnly: true, allowMutations: true, expectReadOnly: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			toolset := GraphQLToolset(getClient, tc.readOnly, tc.allowMutations, DefaultGraphQLMaxCost, translations.NullTranslationHelper)
+			tools := toolset.GetAllTools()
+			require.Len(t, tools, 1)
+			assert.Equal(t, tc.expectReadOnly, *tools[0].Tool.Annotations.ReadOnlyHint)
+		})
+	}
+}
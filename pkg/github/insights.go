@@ -0,0 +1,333 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// statisticsPending reports whether GitHub answered a statistics request with 202 Accepted, which it does
+// while it computes statistics that are not cached yet.
+func statisticsPending(resp *github.Response, err error) bool {
+	return resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err)
+}
+
+// statisticsPendingResult tells the caller to retry once GitHub has computed the statistics.
+func statisticsPendingResult(owner, repo string) *mcp.CallToolResult {
+	return mcp.NewToolResultText(fmt.Sprintf("GitHub is computing the statistics of %s/%s, try again in a few seconds", owner, repo))
+}
+
+// withStatisticsWeeks adds the parameter that limits the statistics to the most recent weeks.
+func withStatisticsWeeks() mcp.ToolOption {
+	return mcp.WithNumber("weeks",
+		mcp.Description("Only cover this many of the most recent weeks, all weeks since the first commit by default"),
+		mcp.Min(1),
+	)
+}
+
+// recentWeeks returns the last n weeks, or all of them if n is 0.
+func recentWeeks[T any](weeks []T, n int) []T {
+	if n <= 0 || n >= len(weeks) {
+		return weeks
+	}
+	return weeks[len(weeks)-n:]
+}
+
+// RepositoryTraffic is the traffic of a repository over the last 14 days.
+type RepositoryTraffic struct {
+	Views        *github.TrafficViews      `json:"views"`
+	Clones       *github.TrafficClones     `json:"clones"`
+	Referrers    []*github.TrafficReferrer `json:"referrers"`
+	PopularPaths []*github.TrafficPath     `json:"popular_paths"`
+}
+
+// GetRepositoryTraffic creates a tool to get the traffic of a repository.
+func GetRepositoryTraffic(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_traffic",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_TRAFFIC_DESCRIPTION", "Get the traffic of a repository over the last 14 days: views and clones, with their unique visitors and cloners, the top 10 referring sites and the 10 most viewed paths. Requires push access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_TRAFFIC_USER_TITLE", "Get repository traffic"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("per",
+				mcp.Description("Break views and clones down per day or per week, defaults to day"),
+				mcp.Enum("day", "week"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			per, err := OptionalParam[string](request, "per")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.TrafficBreakdownOptions{Per: per}
+			traffic := &RepositoryTraffic{}
+			views, resp, err := client.Repositories.ListTrafficViews(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get views of %s/%s", owner, repo), resp, err), nil
+			}
+			_ = resp.Body.Close()
+			traffic.Views = views
+
+			clones, resp, err := client.Repositories.ListTrafficClones(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get clones of %s/%s", owner, repo), resp, err), nil
+			}
+			_ = resp.Body.Close()
+			traffic.Clones = clones
+
+			referrers, resp, err := client.Repositories.ListTrafficReferrers(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get referrers of %s/%s", owner, repo), resp, err), nil
+			}
+			_ = resp.Body.Close()
+			traffic.Referrers = referrers
+
+			paths, resp, err := client.Repositories.ListTrafficPaths(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get popular paths of %s/%s", owner, repo), resp, err), nil
+			}
+			_ = resp.Body.Close()
+			traffic.PopularPaths = paths
+
+			return MarshalledTextResult(traffic), nil
+		}
+}
+
+// ContributorActivity sums up the commits, additions and deletions of a contributor.
+type ContributorActivity struct {
+	Login       string            `json:"login"`
+	Commits     int               `json:"commits"`
+	Additions   int               `json:"additions"`
+	Deletions   int               `json:"deletions"`
+	ActiveWeeks int               `json:"active_weeks"`
+	FirstWeek   *github.Timestamp `json:"first_active_week,omitempty"`
+	LastWeek    *github.Timestamp `json:"last_active_week,omitempty"`
+}
+
+// GetContributorStats creates a tool to get the activity of the contributors of a repository.
+func GetContributorStats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_contributor_stats",
+			mcp.WithDescription(t("TOOL_GET_CONTRIBUTOR_STATS_DESCRIPTION", "Get the commits, additions and deletions of the top 100 contributors to the default branch of a repository, most active first. Not available for repositories with 10,000 or more commits.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CONTRIBUTOR_STATS_USER_TITLE", "Get contributor statistics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			withStatisticsWeeks(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			weeks, err := OptionalIntParam(request, "weeks")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			stats, resp, err := client.Repositories.ListContributorsStats(ctx, owner, repo)
+			if err != nil {
+				if statisticsPending(resp, err) {
+					return statisticsPendingResult(owner, repo), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get contributor statistics of %s/%s", owner, repo), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			activity := make([]*ContributorActivity, 0, len(stats))
+			for _, s := range stats {
+				a := &ContributorActivity{Login: s.GetAuthor().GetLogin()}
+				for _, w := range recentWeeks(s.Weeks, weeks) {
+					if w.GetCommits() == 0 {
+						continue
+					}
+					a.Commits += w.GetCommits()
+					a.Additions += w.GetAdditions()
+					a.Deletions += w.GetDeletions()
+					a.ActiveWeeks++
+					if a.FirstWeek == nil {
+						a.FirstWeek = w.Week
+					}
+					a.LastWeek = w.Week
+				}
+				if a.Commits > 0 {
+					activity = append(activity, a)
+				}
+			}
+			sort.SliceStable(activity, func(i, j int) bool { return activity[i].Commits > activity[j].Commits })
+
+			return MarshalledTextResult(activity), nil
+		}
+}
+
+// WeeklyCodeFrequency is the number of lines added and deleted in a week.
+type WeeklyCodeFrequency struct {
+	Week      int64 `json:"week"`
+	Additions int   `json:"additions"`
+	Deletions int   `json:"deletions"`
+}
+
+// GetCodeFrequency creates a tool to get the weekly additions and deletions of a repository.
+func GetCodeFrequency(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_code_frequency",
+			mcp.WithDescription(t("TOOL_GET_CODE_FREQUENCY_DESCRIPTION", "Get the number of lines added and deleted on the default branch of a repository per week, oldest week first. Weeks are Unix timestamps of their first day. Not available for repositories with 10,000 or more commits.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODE_FREQUENCY_USER_TITLE", "Get code frequency"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			withStatisticsWeeks(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			weeks, err := OptionalIntParam(request, "weeks")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			stats, resp, err := client.Repositories.ListCodeFrequency(ctx, owner, repo)
+			if err != nil {
+				if statisticsPending(resp, err) {
+					return statisticsPendingResult(owner, repo), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get code frequency of %s/%s", owner, repo), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			frequency := make([]*WeeklyCodeFrequency, 0, len(stats))
+			for _, w := range recentWeeks(stats, weeks) {
+				// Deletions are reported as negative numbers
+				frequency = append(frequency, &WeeklyCodeFrequency{
+					Week:      w.GetWeek().Unix(),
+					Additions: w.GetAdditions(),
+					Deletions: -w.GetDeletions(),
+				})
+			}
+
+			return MarshalledTextResult(frequency), nil
+		}
+}
+
+// HourlyCommits is the number of commits in an hour of a day of the week.
+type HourlyCommits struct {
+	Day     int `json:"day"`
+	Hour    int `json:"hour"`
+	Commits int `json:"commits"`
+}
+
+// GetPunchCard creates a tool to get the commits of a repository per hour of the week.
+func GetPunchCard(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_punch_card",
+			mcp.WithDescription(t("TOOL_GET_PUNCH_CARD_DESCRIPTION", "Get the number of commits to the default branch of a repository in each hour of each day of the week, for the hours that had commits. Days run from 0 for Sunday to 6 for Saturday, hours from 0 to 23 in UTC.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PUNCH_CARD_USER_TITLE", "Get commit punch card"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			card, resp, err := client.Repositories.ListPunchCard(ctx, owner, repo)
+			if err != nil {
+				if statisticsPending(resp, err) {
+					return statisticsPendingResult(owner, repo), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get punch card of %s/%s", owner, repo), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			hours := make([]*HourlyCommits, 0, len(card))
+			for _, c := range card {
+				if c.GetCommits() == 0 {
+					continue
+				}
+				hours = append(hours, &HourlyCommits{Day: c.GetDay(), Hour: c.GetHour(), Commits: c.GetCommits()})
+			}
+
+			return MarshalledTextResult(hours), nil
+		}
+}
@@ -0,0 +1,283 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositoryTraffic(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryTraffic(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_traffic", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful traffic fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTrafficViewsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"per": "week"}).andThen(
+						mockResponse(t, http.StatusOK, &github.TrafficViews{Count: github.Ptr(120), Uniques: github.Ptr(30)}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposTrafficClonesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"per": "week"}).andThen(
+						mockResponse(t, http.StatusOK, &github.TrafficClones{Count: github.Ptr(8), Uniques: github.Ptr(5)}),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposTrafficPopularReferrersByOwnerByRepo,
+					[]*github.TrafficReferrer{{Referrer: github.Ptr("google.com"), Count: github.Ptr(40)}},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposTrafficPopularPathsByOwnerByRepo,
+					[]*github.TrafficPath{{Path: github.Ptr("/owner/repo"), Count: github.Ptr(90)}},
+				),
+			),
+		},
+		{
+			name: "no push access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTrafficViewsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have push access to repository"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get views of owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryTraffic(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"per":   "week",
+			}))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned RepositoryTraffic
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, 120, returned.Views.GetCount())
+			assert.Equal(t, 5, returned.Clones.GetUniques())
+			require.Len(t, returned.Referrers, 1)
+			require.Len(t, returned.PopularPaths, 1)
+		})
+	}
+}
+
+func Test_GetContributorStats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetContributorStats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_contributor_stats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	stats := `[
+		{"author": {"login": "alice"}, "total": 3, "weeks": [
+			{"w": 1700000000, "a": 100, "d": 10, "c": 2},
+			{"w": 1700604800, "a": 0, "d": 0, "c": 0},
+			{"w": 1701209600, "a": 5, "d": 1, "c": 1}
+		]},
+		{"author": {"login": "bob"}, "total": 4, "weeks": [
+			{"w": 1700000000, "a": 0, "d": 0, "c": 0},
+			{"w": 1700604800, "a": 20, "d": 2, "c": 4},
+			{"w": 1701209600, "a": 0, "d": 0, "c": 0}
+		]}
+	]`
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectPending  bool
+		expectedLogins []string
+		expectedAlice  *ContributorActivity
+	}{
+		{
+			name: "all weeks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStatsContributorsByOwnerByRepo,
+					mockResponse(t, http.StatusOK, stats),
+				),
+			),
+			requestArgs:    map[string]interface{}{},
+			expectedLogins: []string{"bob", "alice"},
+			expectedAlice: &ContributorActivity{
+				Login:       "alice",
+				Commits:     3,
+				Additions:   105,
+				Deletions:   11,
+				ActiveWeeks: 2,
+			},
+		},
+		{
+			name: "last week only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStatsContributorsByOwnerByRepo,
+					mockResponse(t, http.StatusOK, stats),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"weeks": float64(1),
+			},
+			expectedLogins: []string{"alice"},
+			expectedAlice: &ContributorActivity{
+				Login:       "alice",
+				Commits:     1,
+				Additions:   5,
+				Deletions:   1,
+				ActiveWeeks: 1,
+			},
+		},
+		{
+			name: "statistics being computed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStatsContributorsByOwnerByRepo,
+					mockResponse(t, http.StatusAccepted, "{}"),
+				),
+			),
+			requestArgs:   map[string]interface{}{},
+			expectPending: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetContributorStats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			if tc.expectPending {
+				assert.Contains(t, getTextResult(t, result).Text, "GitHub is computing the statistics of owner/repo")
+				return
+			}
+
+			var returned []*ContributorActivity
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			logins := make([]string, 0, len(returned))
+			for _, a := range returned {
+				logins = append(logins, a.Login)
+			}
+			assert.Equal(t, tc.expectedLogins, logins)
+
+			alice := returned[len(returned)-1]
+			assert.Equal(t, tc.expectedAlice.Commits, alice.Commits)
+			assert.Equal(t, tc.expectedAlice.Additions, alice.Additions)
+			assert.Equal(t, tc.expectedAlice.Deletions, alice.Deletions)
+			assert.Equal(t, tc.expectedAlice.ActiveWeeks, alice.ActiveWeeks)
+		})
+	}
+}
+
+func Test_GetCodeFrequency(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodeFrequency(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_code_frequency", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposStatsCodeFrequencyByOwnerByRepo,
+			mockResponse(t, http.StatusOK, `[[1700000000, 100, -10], [1700604800, 20, -30], [1701209600, 5, 0]]`),
+		),
+	))
+	_, handler := GetCodeFrequency(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"weeks": float64(2),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*WeeklyCodeFrequency
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 2)
+	assert.Equal(t, &WeeklyCodeFrequency{Week: 1700604800, Additions: 20, Deletions: 30}, returned[0])
+}
+
+func Test_GetPunchCard(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPunchCard(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_punch_card", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposStatsPunchCardByOwnerByRepo,
+			mockResponse(t, http.StatusOK, `[[0, 0, 0], [1, 9, 5], [1, 10, 0], [3, 14, 2]]`),
+		),
+	))
+	_, handler := GetPunchCard(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*HourlyCommits
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, []*HourlyCommits{
+		{Day: 1, Hour: 9, Commits: 5},
+		{Day: 3, Hour: 14, Commits: 2},
+	}, returned)
+}
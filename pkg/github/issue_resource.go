@@ -0,0 +1,95 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetIssueResource defines the resource template and handler for reading an issue. Clients can subscribe to
+// it to be notified when the issue changes.
+func GetIssueResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/issues/{issueNumber}", // Resource template
+			t("RESOURCE_ISSUE_DESCRIPTION", "Issue"),
+			mcp.WithTemplateDescription(t("RESOURCE_ISSUE_TEMPLATE_DESCRIPTION", "An issue as JSON. Subscribe to it to be notified when it is updated")),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		issueResourceHandler(getClient, "issueNumber")
+}
+
+// GetPullRequestResource defines the resource template and handler for reading a pull request. Clients can
+// subscribe to it to be notified when the pull request changes.
+func GetPullRequestResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/pulls/{pullNumber}", // Resource template
+			t("RESOURCE_PULL_REQUEST_DESCRIPTION", "Pull Request"),
+			mcp.WithTemplateDescription(t("RESOURCE_PULL_REQUEST_TEMPLATE_DESCRIPTION", "A pull request as JSON. Subscribe to it to be notified when it is updated, including when commits are pushed")),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		issueResourceHandler(getClient, "pullNumber")
+}
+
+// issueResourceHandler returns a handler that reads the issue or pull request, depending on which number
+// argument the template has.
+func issueResourceHandler(getClient GetClientFn, numberArgument string) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		// the matcher will give []string with one element
+		o, ok := request.Params.Arguments["owner"].([]string)
+		if !ok || len(o) == 0 {
+			return nil, errors.New("owner is required")
+		}
+		r, ok := request.Params.Arguments["repo"].([]string)
+		if !ok || len(r) == 0 {
+			return nil, errors.New("repo is required")
+		}
+		n, ok := request.Params.Arguments[numberArgument].([]string)
+		if !ok || len(n) == 0 {
+			return nil, fmt.Errorf("%s is required", numberArgument)
+		}
+		number, err := strconv.Atoi(n[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", numberArgument, err)
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		var v any
+		if numberArgument == "pullNumber" {
+			pr, resp, err := client.PullRequests.Get(ctx, o[0], r[0], number)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			_ = resp.Body.Close()
+			v = pr
+		} else {
+			issue, resp, err := client.Issues.Get(ctx, o[0], r[0], number)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue: %w", err)
+			}
+			_ = resp.Body.Close()
+			v = issue
+		}
+
+		content, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal response: %w", err)
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(content),
+			},
+		}, nil
+	}
+}
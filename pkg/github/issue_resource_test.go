@@ -0,0 +1,78 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IssueResources(t *testing.T) {
+	tmpl, _ := GetIssueResource(nil, translations.NullTranslationHelper)
+	require.Equal(t, "repo://{owner}/{repo}/issues/{issueNumber}", tmpl.URITemplate.Raw())
+	tmpl, _ = GetPullRequestResource(nil, translations.NullTranslationHelper)
+	require.Equal(t, "repo://{owner}/{repo}/pulls/{pullNumber}", tmpl.URITemplate.Raw())
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			&github.Issue{Number: github.Ptr(1), Title: github.Ptr("Bug")},
+		),
+		mock.WithRequestMatch(
+			mock.GetReposPullsByOwnerByRepoByPullNumber,
+			&github.PullRequest{Number: github.Ptr(2), Title: github.Ptr("Fix")},
+		),
+	))
+
+	tests := []struct {
+		name         string
+		uri          string
+		requestArgs  map[string]any
+		expectError  string
+		expectedText string
+	}{
+		{
+			name:         "issue",
+			uri:          "repo://owner/repo/issues/1",
+			requestArgs:  map[string]any{"owner": []string{"owner"}, "repo": []string{"repo"}, "issueNumber": []string{"1"}},
+			expectedText: `{"number":1,"title":"Bug"}`,
+		},
+		{
+			name:         "pull request",
+			uri:          "repo://owner/repo/pulls/2",
+			requestArgs:  map[string]any{"owner": []string{"owner"}, "repo": []string{"repo"}, "pullNumber": []string{"2"}},
+			expectedText: `{"number":2,"title":"Fix"}`,
+		},
+		{
+			name:        "invalid number",
+			requestArgs: map[string]any{"owner": []string{"owner"}, "repo": []string{"repo"}, "issueNumber": []string{"one"}},
+			expectError: "invalid issueNumber",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			numberArgument := "issueNumber"
+			if _, ok := tc.requestArgs["pullNumber"]; ok {
+				numberArgument = "pullNumber"
+			}
+			handler := issueResourceHandler(stubGetClientFn(client), numberArgument)
+
+			request := mcp.ReadResourceRequest{}
+			request.Params.URI = tc.uri
+			request.Params.Arguments = tc.requestArgs
+			contents, err := handler(context.Background(), request)
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []mcp.ResourceContents{mcp.TextResourceContents{URI: tc.uri, MIMEType: "application/json", Text: tc.expectedText}}, contents)
+		})
+	}
+}
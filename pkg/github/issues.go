@@ -268,6 +268,7 @@ func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc)
 			mcp.WithNumber("per_page",
 				mcp.Description("Number of results per page (max 100, default: 30)"),
 			),
+			withCursor(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -290,6 +291,16 @@ func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			position, ok, err := cursorPosition(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ok {
+				page = max(position.Page, 1)
+				if position.PerPage > 0 {
+					perPage = position.PerPage
+				}
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -763,6 +774,7 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Description("Filter by date (ISO 8601 timestamp)"),
 			),
 			WithPagination(),
+			WithAllPages(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -810,18 +822,38 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				opts.Since = timestamp
 			}
 
-			if page, ok := request.GetArguments()["page"].(float64); ok {
-				opts.ListOptions.Page = int(page)
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
+			opts.ListOptions.Page = pagination.Page
+			opts.ListOptions.PerPage = pagination.PerPage
 
-			if perPage, ok := request.GetArguments()["perPage"].(float64); ok {
-				opts.ListOptions.PerPage = int(perPage)
+			allPages, err := OptionalParam[bool](request, "allPages")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
+
+			if allPages {
+				issues, truncated, resp, err := listAllPages(ctx, 0, func(ctx context.Context, listOpts github.ListOptions) ([]*github.Issue, *github.Response, error) {
+					pageOpts := *opts
+					pageOpts.ListOptions = listOpts
+					return client.Issues.ListByRepo(ctx, owner, repo, &pageOpts)
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list issues", resp, err), nil
+				}
+				return MarshalledTextResult(map[string]any{
+					"issues":    issues,
+					"truncated": truncated,
+				}), nil
+			}
+
 			issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to list issues: %w", err)
@@ -991,6 +1023,763 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 		}
 }
 
+// maxStaleIssues caps how many issues close_stale_issues closes in a single call.
+const maxStaleIssues = 100
+
+// foreignSearchQualifiers are the search qualifiers that reach beyond the issues of a single repository.
+// GitHub ORs repeated repo: qualifiers, so a query may not name any repository of its own.
+var foreignSearchQualifiers = []string{"repo:", "org:", "user:", "is:pr", "is:pull-request", "type:pr", "type:pull-request"}
+
+// checkRepositorySearchQuery returns an error if a query of issue search qualifiers that is added to the
+// search of a repository could match issues or pull requests of other repositories.
+func checkRepositorySearchQuery(query string) error {
+	for _, term := range strings.Fields(query) {
+		term = strings.ToLower(strings.TrimLeft(term, "-(\""))
+		for _, qualifier := range foreignSearchQualifiers {
+			if strings.HasPrefix(term, qualifier) {
+				return fmt.Errorf("query may not use the %s qualifier, the search is always limited to the issues of the repository", strings.TrimSuffix(qualifier, ":"))
+			}
+		}
+	}
+	return nil
+}
+
+// inRepository reports whether an issue found by a search belongs to the given repository.
+func inRepository(issue *github.Issue, owner, repo string) bool {
+	return strings.HasSuffix(strings.ToLower(issue.GetRepositoryURL()), strings.ToLower(fmt.Sprintf("/repos/%s/%s", owner, repo)))
+}
+
+// staleIssue is an issue matched by close_stale_issues.
+type staleIssue struct {
+	Number    int               `json:"number"`
+	Title     string            `json:"title"`
+	HTMLURL   string            `json:"html_url"`
+	UpdatedAt *github.Timestamp `json:"updated_at,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// CloseStaleIssues creates a tool to close the open issues of a repository that match a search. When
+// dryRunOnly is set, as it is in read-only mode, the tool only reports the issues it would close.
+func CloseStaleIssues(getClient GetClientFn, dryRunOnly bool, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	description := "Close the open issues of a repository that match a search query, label or last update date, optionally leaving a comment on each. Returns a summary of the issues that were closed, or with dry_run the issues that would be."
+	if dryRunOnly {
+		description = "Find the open issues of a repository that match a search query, label or last update date, and report them as stale. The server is in read-only mode, so no issues are closed."
+	}
+
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_CLOSE_STALE_ISSUES_DESCRIPTION", description)),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           t("TOOL_CLOSE_STALE_ISSUES_USER_TITLE", "Close stale issues"),
+			ReadOnlyHint:    ToBoolPtr(dryRunOnly),
+			DestructiveHint: ToBoolPtr(!dryRunOnly),
+		}),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+		mcp.WithString("query",
+			mcp.Description("Additional issue search qualifiers, e.g. 'no:assignee -label:pinned'. The search is always limited to open issues of the repository"),
+		),
+		mcp.WithString("label",
+			mcp.Description("Only match issues with this label"),
+		),
+		mcp.WithString("updated_before",
+			mcp.Description("Only match issues last updated before this date (ISO 8601 timestamp or YYYY-MM-DD)"),
+		),
+		mcp.WithString("comment",
+			mcp.Description("Comment to leave on each issue before closing it"),
+		),
+		mcp.WithString("state_reason",
+			mcp.Description("Reason for closing the issues"),
+			mcp.Enum("not_planned", "completed"),
+			mcp.DefaultString("not_planned"),
+		),
+		mcp.WithNumber("max_issues",
+			mcp.Description(fmt.Sprintf("Maximum number of issues to close in this call (max %d)", maxStaleIssues)),
+			mcp.Min(1),
+			mcp.Max(maxStaleIssues),
+			mcp.DefaultNumber(30),
+		),
+	}
+	if !dryRunOnly {
+		options = append(options, mcp.WithBoolean("dry_run",
+			mcp.Description("Only report the matching issues without closing them"),
+		))
+	}
+
+	return mcp.NewTool("close_stale_issues", options...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := OptionalParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			label, err := OptionalParam[string](request, "label")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			updatedBefore, err := OptionalParam[string](request, "updated_before")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comment, err := OptionalParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			stateReason, err := OptionalParam[string](request, "state_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if stateReason == "" {
+				stateReason = "not_planned"
+			}
+			if stateReason != "not_planned" && stateReason != "completed" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid state_reason %q, must be not_planned or completed", stateReason)), nil
+			}
+			maxIssues, err := OptionalIntParamWithDefault(request, "max_issues", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxIssues < 1 || maxIssues > maxStaleIssues {
+				return mcp.NewToolResultError(fmt.Sprintf("max_issues must be between 1 and %d", maxStaleIssues)), nil
+			}
+			dryRun := dryRunOnly
+			if !dryRunOnly {
+				dryRun, err = OptionalParam[bool](request, "dry_run")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+
+			// Refuse to match every open issue of the repository
+			if query == "" && label == "" && updatedBefore == "" {
+				return mcp.NewToolResultError("at least one of query, label or updated_before is required"), nil
+			}
+			if err := checkRepositorySearchQuery(query); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			q := fmt.Sprintf("repo:%s/%s is:issue is:open", owner, repo)
+			if label != "" {
+				q += fmt.Sprintf(" label:%q", label)
+			}
+			if updatedBefore != "" {
+				before, err := parseISOTimestamp(updatedBefore)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid updated_before: %s", err.Error())), nil
+				}
+				q += " updated:<" + before.UTC().Format(time.RFC3339)
+			}
+			if query != "" {
+				q += " " + query
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Collect all matches before closing any, as closing issues shifts the pages of the search
+			opts := &github.SearchOptions{
+				Sort:        "updated",
+				Order:       "asc",
+				ListOptions: github.ListOptions{PerPage: min(maxIssues, 100)},
+			}
+			var matched []staleIssue
+			total := 0
+			for len(matched) < maxIssues {
+				result, resp, err := client.Search.Issues(ctx, q, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to search issues", resp, err), nil
+				}
+				_ = resp.Body.Close()
+
+				total = result.GetTotal()
+				for _, issue := range result.Issues {
+					if len(matched) == maxIssues {
+						break
+					}
+					// Qualifiers in query can contradict the base search, so check again
+					if issue.IsPullRequest() || issue.GetState() != "open" || !inRepository(issue, owner, repo) {
+						continue
+					}
+					matched = append(matched, staleIssue{
+						Number:    issue.GetNumber(),
+						Title:     issue.GetTitle(),
+						HTMLURL:   issue.GetHTMLURL(),
+						UpdatedAt: issue.UpdatedAt,
+					})
+				}
+
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			summary := map[string]any{
+				"query":         q,
+				"dry_run":       dryRun,
+				"total_matches": total,
+			}
+			if dryRun {
+				summary["matched"] = matched
+				summary["remaining"] = max(total-len(matched), 0)
+				return MarshalledTextResult(summary), nil
+			}
+
+			// Closing takes a request or two per issue, so report the progress of each
+			progress := newProgressReporter(ctx, request)
+			closed := make([]staleIssue, 0, len(matched))
+			failed := make([]staleIssue, 0)
+			for i, issue := range matched {
+				progress.report(float64(i), float64(len(matched)), fmt.Sprintf("Closing issue #%d", issue.Number))
+				if comment != "" {
+					_, resp, err := client.Issues.CreateComment(ctx, owner, repo, issue.Number, &github.IssueComment{Body: github.Ptr(comment)})
+					if err != nil {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to comment on issue", resp, err)
+						issue.Error = fmt.Sprintf("failed to comment on issue: %s", err.Error())
+						failed = append(failed, issue)
+						continue
+					}
+					_ = resp.Body.Close()
+				}
+
+				_, resp, err := client.Issues.Edit(ctx, owner, repo, issue.Number, &github.IssueRequest{
+					State:       github.Ptr("closed"),
+					StateReason: github.Ptr(stateReason),
+				})
+				if err != nil {
+					_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to close issue", resp, err)
+					issue.Error = fmt.Sprintf("failed to close issue: %s", err.Error())
+					failed = append(failed, issue)
+					continue
+				}
+				_ = resp.Body.Close()
+
+				closed = append(closed, issue)
+			}
+
+			summary["closed"] = closed
+			summary["failed"] = failed
+			summary["remaining"] = max(total-len(closed), 0)
+			return MarshalledTextResult(summary), nil
+		}
+}
+
+// maxBulkIssues caps how many issues bulk_update_issues changes in a single call.
+const maxBulkIssues = 100
+
+// bulkIssueResult is the outcome of bulk_update_issues for one issue.
+type bulkIssueResult struct {
+	Number int    `json:"number"`
+	Title  string `json:"title,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkUpdateIssues creates a tool to apply the same labels, assignees, milestone and state changes to many
+// issues at once, given by number or by a search.
+func BulkUpdateIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("bulk_update_issues",
+			mcp.WithDescription(t("TOOL_BULK_UPDATE_ISSUES_DESCRIPTION", "Apply the same changes to many issues of a repository in one call: add or remove labels and assignees, set or clear the milestone, and close or reopen them. The issues are given by number or by a search query. Every issue is updated on its own, and the result reports which ones were updated and why the others failed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_BULK_UPDATE_ISSUES_USER_TITLE", "Bulk update issues"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("issue_numbers",
+				mcp.Description(fmt.Sprintf("Numbers of the issues to update (max %d). Either this or query is required", maxBulkIssues)),
+				mcp.Items(map[string]any{"type": "number"}),
+			),
+			mcp.WithString("query",
+				mcp.Description("Issue search qualifiers selecting the issues to update, e.g. 'is:open label:needs-triage no:assignee'. The search is always limited to issues of the repository"),
+			),
+			mcp.WithNumber("max_issues",
+				mcp.Description(fmt.Sprintf("Maximum number of issues matching query to update (max %d)", maxBulkIssues)),
+				mcp.Min(1),
+				mcp.Max(maxBulkIssues),
+				mcp.DefaultNumber(30),
+			),
+			mcp.WithArray("add_labels",
+				mcp.Description("Labels to add"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("remove_labels",
+				mcp.Description("Labels to remove"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("add_assignees",
+				mcp.Description("Usernames to assign"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("remove_assignees",
+				mcp.Description("Usernames to unassign"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithNumber("milestone",
+				mcp.Description("Number of the milestone to set, or 0 to clear the milestone"),
+				mcp.Min(0),
+			),
+			mcp.WithString("state",
+				mcp.Description("New state"),
+				mcp.Enum("open", "closed"),
+			),
+			mcp.WithString("state_reason",
+				mcp.Description("Reason for closing the issues, when state is closed"),
+				mcp.Enum("completed", "not_planned"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := OptionalParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxIssues, err := OptionalIntParamWithDefault(request, "max_issues", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxIssues < 1 || maxIssues > maxBulkIssues {
+				return mcp.NewToolResultError(fmt.Sprintf("max_issues must be between 1 and %d", maxBulkIssues)), nil
+			}
+			addLabels, err := OptionalStringArrayParam(request, "add_labels")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			removeLabels, err := OptionalStringArrayParam(request, "remove_labels")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			addAssignees, err := OptionalStringArrayParam(request, "add_assignees")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			removeAssignees, err := OptionalStringArrayParam(request, "remove_assignees")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestone, hasMilestone, err := OptionalParamOK[float64](request, "milestone")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if hasMilestone && (milestone < 0 || milestone != float64(int(milestone))) {
+				return mcp.NewToolResultError("milestone must be a milestone number, or 0 to clear it"), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if state != "" && state != "open" && state != "closed" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid state %q, must be open or closed", state)), nil
+			}
+			stateReason, err := OptionalParam[string](request, "state_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if stateReason != "" && (state != "closed" || (stateReason != "completed" && stateReason != "not_planned")) {
+				return mcp.NewToolResultError("state_reason must be completed or not_planned, and requires state to be closed"), nil
+			}
+			if len(addLabels) == 0 && len(removeLabels) == 0 && len(addAssignees) == 0 && len(removeAssignees) == 0 && !hasMilestone && state == "" {
+				return mcp.NewToolResultError("nothing to change, give labels, assignees, a milestone or a state"), nil
+			}
+
+			var numbers []int
+			if rawNumbers, ok := request.GetArguments()["issue_numbers"].([]any); ok {
+				for _, raw := range rawNumbers {
+					number, ok := raw.(float64)
+					if !ok || number <= 0 || number != float64(int(number)) {
+						return mcp.NewToolResultError(fmt.Sprintf("issue_numbers must be positive integers, got %v", raw)), nil
+					}
+					numbers = append(numbers, int(number))
+				}
+			}
+			if len(numbers) > 0 && query != "" {
+				return mcp.NewToolResultError("give either issue_numbers or query, not both"), nil
+			}
+			if len(numbers) == 0 && query == "" {
+				return mcp.NewToolResultError("either issue_numbers or query is required"), nil
+			}
+			if err := checkRepositorySearchQuery(query); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(numbers) > maxBulkIssues {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d issues can be updated at once", maxBulkIssues)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Collect all matches before changing any, as the changes can shift the pages of the search
+			issues := make([]bulkIssueResult, 0, len(numbers))
+			for _, number := range numbers {
+				issues = append(issues, bulkIssueResult{Number: number})
+			}
+			total := len(numbers)
+			if query != "" {
+				q := fmt.Sprintf("repo:%s/%s is:issue %s", owner, repo, query)
+				opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: min(maxIssues, 100)}}
+				for len(issues) < maxIssues {
+					result, resp, err := client.Search.Issues(ctx, q, opts)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to search issues", resp, err), nil
+					}
+					_ = resp.Body.Close()
+
+					total = result.GetTotal()
+					for _, issue := range result.Issues {
+						if len(issues) == maxIssues {
+							break
+						}
+						if issue.IsPullRequest() || !inRepository(issue, owner, repo) {
+							continue
+						}
+						issues = append(issues, bulkIssueResult{Number: issue.GetNumber(), Title: issue.GetTitle()})
+					}
+
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			}
+
+			// update applies the changes to one issue, stopping at the first one that fails
+			update := func(number int) error {
+				if len(addLabels) > 0 {
+					_, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, number, addLabels)
+					if err != nil {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to add labels", resp, err)
+						return fmt.Errorf("failed to add labels: %w", err)
+					}
+					_ = resp.Body.Close()
+				}
+				for _, label := range removeLabels {
+					resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, number, label)
+					// A label the issue doesn't have is already removed
+					if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to remove label", resp, err)
+						return fmt.Errorf("failed to remove label %s: %w", label, err)
+					}
+					if resp != nil {
+						_ = resp.Body.Close()
+					}
+				}
+				if len(addAssignees) > 0 {
+					_, resp, err := client.Issues.AddAssignees(ctx, owner, repo, number, addAssignees)
+					if err != nil {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to add assignees", resp, err)
+						return fmt.Errorf("failed to add assignees: %w", err)
+					}
+					_ = resp.Body.Close()
+				}
+				if len(removeAssignees) > 0 {
+					_, resp, err := client.Issues.RemoveAssignees(ctx, owner, repo, number, removeAssignees)
+					if err != nil {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to remove assignees", resp, err)
+						return fmt.Errorf("failed to remove assignees: %w", err)
+					}
+					_ = resp.Body.Close()
+				}
+				if hasMilestone && milestone == 0 {
+					_, resp, err := client.Issues.RemoveMilestone(ctx, owner, repo, number)
+					if err != nil {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to clear milestone", resp, err)
+						return fmt.Errorf("failed to clear milestone: %w", err)
+					}
+					_ = resp.Body.Close()
+				}
+				issueRequest := &github.IssueRequest{}
+				if hasMilestone && milestone > 0 {
+					issueRequest.Milestone = github.Ptr(int(milestone))
+				}
+				if state != "" {
+					issueRequest.State = github.Ptr(state)
+				}
+				if stateReason != "" {
+					issueRequest.StateReason = github.Ptr(stateReason)
+				}
+				if issueRequest.Milestone != nil || issueRequest.State != nil {
+					_, resp, err := client.Issues.Edit(ctx, owner, repo, number, issueRequest)
+					if err != nil {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to update issue", resp, err)
+						return fmt.Errorf("failed to update issue: %w", err)
+					}
+					_ = resp.Body.Close()
+				}
+				return nil
+			}
+
+			// Every issue can take several requests, so report the progress of each
+			progress := newProgressReporter(ctx, request)
+			updated := make([]bulkIssueResult, 0, len(issues))
+			failed := make([]bulkIssueResult, 0)
+			for i, issue := range issues {
+				progress.report(float64(i), float64(len(issues)), fmt.Sprintf("Updating issue #%d", issue.Number))
+				if err := update(issue.Number); err != nil {
+					issue.Error = err.Error()
+					failed = append(failed, issue)
+					continue
+				}
+				updated = append(updated, issue)
+			}
+
+			summary := map[string]any{
+				"updated": updated,
+				"failed":  failed,
+			}
+			if query != "" {
+				summary["total_matches"] = total
+				summary["remaining"] = max(total-len(issues), 0)
+			}
+			return MarshalledTextResult(summary), nil
+		}
+}
+
+// TransferIssue creates a tool to move an issue to another repository of the same owner.
+func TransferIssue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("transfer_issue",
+			mcp.WithDescription(t("TOOL_TRANSFER_ISSUE_DESCRIPTION", "Transfer an issue to another repository of the same owner. The issue gets a new number in the target repository, the old URL redirects to it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_TRANSFER_ISSUE_USER_TITLE", "Transfer issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name the issue currently lives in"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number to transfer"),
+			),
+			mcp.WithString("target_repo",
+				mcp.Required(),
+				mcp.Description("Name of the repository to transfer the issue to, must belong to the same owner"),
+			),
+			mcp.WithBoolean("create_labels_if_missing",
+				mcp.Description("Create labels that don't exist in the target repository instead of dropping them"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetRepo, err := RequiredParam[string](request, "target_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			createLabels, err := OptionalParam[bool](request, "create_labels_if_missing")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+			}
+
+			var idsQuery struct {
+				Repository struct {
+					Issue struct {
+						ID githubv4.ID
+					} `graphql:"issue(number: $number)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+				TargetRepository struct {
+					ID githubv4.ID
+				} `graphql:"targetRepository: repository(owner: $owner, name: $targetRepo)"`
+			}
+
+			err = client.Query(ctx, &idsQuery, map[string]any{
+				"owner":      githubv4.String(owner),
+				"repo":       githubv4.String(repo),
+				"number":     githubv4.Int(issueNumber), // #nosec G115 - issue numbers are always small positive integers
+				"targetRepo": githubv4.String(targetRepo),
+			})
+			if err != nil {
+				// A target repository the token can't see is reported as not found, say so instead of leaving the agent guessing.
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					fmt.Sprintf("failed to find issue #%d in %s/%s or target repository %s/%s, check that both exist and the token has access to them", issueNumber, owner, repo, owner, targetRepo),
+					err,
+				), nil
+			}
+
+			var mutation struct {
+				TransferIssue struct {
+					Issue struct {
+						Number githubv4.Int
+						URL    githubv4.String
+					}
+				} `graphql:"transferIssue(input: $input)"`
+			}
+
+			input := githubv4.TransferIssueInput{
+				IssueID:      idsQuery.Repository.Issue.ID,
+				RepositoryID: idsQuery.TargetRepository.ID,
+			}
+			if createLabels {
+				input.CreateLabelsIfMissing = githubv4.NewBoolean(true)
+			}
+
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					fmt.Sprintf("failed to transfer issue #%d to %s/%s", issueNumber, owner, targetRepo),
+					err,
+				), nil
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"number": mutation.TransferIssue.Issue.Number,
+				"url":    mutation.TransferIssue.Issue.URL,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CloseIssueAsDuplicate creates a tool to close an issue as a duplicate of another one.
+func CloseIssueAsDuplicate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("close_issue_as_duplicate",
+			mcp.WithDescription(t("TOOL_CLOSE_ISSUE_AS_DUPLICATE_DESCRIPTION", "Close an issue as a duplicate of another issue. Leaves a 'Duplicate of' comment, which GitHub shows as a link between the two issues, and closes the issue with the duplicate reason.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CLOSE_ISSUE_AS_DUPLICATE_USER_TITLE", "Close issue as duplicate"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the issue to close"),
+			),
+			mcp.WithNumber("duplicate_of",
+				mcp.Required(),
+				mcp.Description("Number of the issue it duplicates"),
+			),
+			mcp.WithString("duplicate_of_repo",
+				mcp.Description("Repository of the issue it duplicates as owner/repo, when it isn't in the same repository"),
+			),
+			mcp.WithString("comment",
+				mcp.Description("Extra text for the comment, after the 'Duplicate of' line"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			duplicateOf, err := RequiredInt(request, "duplicate_of")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			duplicateOfRepo, err := OptionalParam[string](request, "duplicate_of_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comment, err := OptionalParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			reference := fmt.Sprintf("#%d", duplicateOf)
+			if duplicateOfRepo != "" && !strings.EqualFold(duplicateOfRepo, owner+"/"+repo) {
+				if parts := strings.Split(duplicateOfRepo, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+					return mcp.NewToolResultError("duplicate_of_repo must be of the form owner/repo"), nil
+				}
+				reference = duplicateOfRepo + reference
+			} else if duplicateOf == issueNumber {
+				return mcp.NewToolResultError("an issue can't be a duplicate of itself"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// GitHub links the issues when a comment starts with "Duplicate of", so it goes first
+			body := "Duplicate of " + reference
+			if comment != "" {
+				body += "\n\n" + comment
+			}
+			_, resp, err := client.Issues.CreateComment(ctx, owner, repo, issueNumber, &github.IssueComment{Body: github.Ptr(body)})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to comment on issue", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			issue, resp, err := client.Issues.Edit(ctx, owner, repo, issueNumber, &github.IssueRequest{
+				State:       github.Ptr("closed"),
+				StateReason: github.Ptr("duplicate"),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to close issue", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"number":       issue.GetNumber(),
+				"state":        issue.GetState(),
+				"state_reason": issue.GetStateReason(),
+				"duplicate_of": reference,
+				"url":          issue.GetHTMLURL(),
+			}), nil
+		}
+}
+
 // GetIssueComments creates a tool to get comments for a GitHub issue.
 func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_issue_comments",
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -823,6 +825,35 @@ func Test_ListIssues(t *testing.T) {
 	}
 }
 
+func Test_ListIssues_AllPages(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchPages(
+			mock.GetReposIssuesByOwnerByRepo,
+			[]*github.Issue{{Number: github.Ptr(1)}},
+			[]*github.Issue{{Number: github.Ptr(2)}},
+		),
+	))
+	_, handler := ListIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":    "owner",
+		"repo":     "repo",
+		"allPages": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response struct {
+		Issues    []*github.Issue `json:"issues"`
+		Truncated bool            `json:"truncated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	require.Len(t, response.Issues, 2)
+	assert.Equal(t, 1, response.Issues[0].GetNumber())
+	assert.Equal(t, 2, response.Issues[1].GetNumber())
+	assert.False(t, response.Truncated)
+}
+
 func Test_UpdateIssue(t *testing.T) {
 	// Verify tool definition
 	mockClient := github.NewClient(nil)
@@ -1031,6 +1062,540 @@ func Test_UpdateIssue(t *testing.T) {
 	}
 }
 
+func Test_CloseStaleIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CloseStaleIssues(stubGetClientFn(mockClient), false, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "close_stale_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "label")
+	assert.Contains(t, tool.InputSchema.Properties, "updated_before")
+	assert.Contains(t, tool.InputSchema.Properties, "comment")
+	assert.Contains(t, tool.InputSchema.Properties, "state_reason")
+	assert.Contains(t, tool.InputSchema.Properties, "max_issues")
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	// The read-only variant is a dry run only
+	readOnlyTool, _ := CloseStaleIssues(stubGetClientFn(mockClient), true, translations.NullTranslationHelper)
+	assert.True(t, *readOnlyTool.Annotations.ReadOnlyHint)
+	assert.NotContains(t, readOnlyTool.InputSchema.Properties, "dry_run")
+
+	mockIssues := []*github.Issue{
+		{
+			Number:        github.Ptr(1),
+			Title:         github.Ptr("Old bug"),
+			State:         github.Ptr("open"),
+			HTMLURL:       github.Ptr("https://github.com/owner/repo/issues/1"),
+			RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+			UpdatedAt:     &github.Timestamp{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			Number:        github.Ptr(2),
+			Title:         github.Ptr("Old feature request"),
+			State:         github.Ptr("open"),
+			HTMLURL:       github.Ptr("https://github.com/owner/repo/issues/2"),
+			RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+		},
+		{
+			Number:        github.Ptr(3),
+			Title:         github.Ptr("Another old bug"),
+			State:         github.Ptr("open"),
+			HTMLURL:       github.Ptr("https://github.com/owner/repo/issues/3"),
+			RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+		},
+	}
+
+	closeIssue := mock.WithRequestMatchHandler(
+		mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+		expectRequestBody(t, map[string]any{
+			"state":        "closed",
+			"state_reason": "not_planned",
+		}).andThen(
+			mockResponse(t, http.StatusOK, &github.Issue{State: github.Ptr("closed")}),
+		),
+	)
+
+	tests := []struct {
+		name            string
+		dryRunOnly      bool
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectedErrMsg  string
+		expectedClosed  []int
+		expectedFailed  []int
+		expectedMatched []int
+		expectedRemain  float64
+	}{
+		{
+			name: "closes matching issues with a comment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					expectQueryParams(t, map[string]string{
+						"q":        `repo:owner/repo is:issue is:open label:"needs info" updated:<2025-01-01T00:00:00Z no:assignee`,
+						"sort":     "updated",
+						"order":    "asc",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+							Total:  github.Ptr(2),
+							Issues: mockIssues[:2],
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"body": "Closing due to inactivity.",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(100))}),
+					),
+				),
+				closeIssue,
+			),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"label":          "needs info",
+				"updated_before": "2025-01-01",
+				"query":          "no:assignee",
+				"comment":        "Closing due to inactivity.",
+			},
+			expectedClosed: []int{1, 2},
+			expectedFailed: []int{},
+			expectedRemain: 0,
+		},
+		{
+			name: "caps the number of closed issues and pages through matches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchPages(
+					mock.GetSearchIssues,
+					&github.IssuesSearchResult{Total: github.Ptr(5), Issues: mockIssues[:2]},
+					&github.IssuesSearchResult{Total: github.Ptr(5), Issues: mockIssues[2:]},
+				),
+				closeIssue,
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"label":      "stale",
+				"max_issues": float64(3),
+			},
+			expectedClosed: []int{1, 2, 3},
+			expectedFailed: []int{},
+			expectedRemain: 2,
+		},
+		{
+			name: "reports issues that failed to close",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetSearchIssues,
+					&github.IssuesSearchResult{Total: github.Ptr(2), Issues: mockIssues[:2]},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path == "/repos/owner/repo/issues/2" {
+							w.WriteHeader(http.StatusForbidden)
+							_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+							return
+						}
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write(mock.MustMarshal(&github.Issue{State: github.Ptr("closed")}))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"label": "stale",
+			},
+			expectedClosed: []int{1},
+			expectedFailed: []int{2},
+			expectedRemain: 1,
+		},
+		{
+			name: "skips issues of other repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetSearchIssues,
+					&github.IssuesSearchResult{Total: github.Ptr(2), Issues: []*github.Issue{
+						mockIssues[0],
+						{
+							Number:        github.Ptr(2),
+							Title:         github.Ptr("Issue of another repository"),
+							State:         github.Ptr("open"),
+							HTMLURL:       github.Ptr("https://github.com/victim/other/issues/2"),
+							RepositoryURL: github.Ptr("https://api.github.com/repos/victim/other"),
+						},
+					}},
+				),
+				closeIssue,
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"label": "stale",
+			},
+			expectedClosed: []int{1},
+			expectedFailed: []int{},
+			expectedRemain: 1,
+		},
+		{
+			name:         "rejects qualifiers reaching other repositories",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"query": "no:assignee OR repo:victim/other",
+			},
+			expectError:    true,
+			expectedErrMsg: "query may not use the repo qualifier",
+		},
+		{
+			name:         "rejects qualifiers matching pull requests",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"query": "-label:pinned is:pr",
+			},
+			expectError:    true,
+			expectedErrMsg: "query may not use the is:pr qualifier",
+		},
+		{
+			name: "dry run only reports matches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetSearchIssues,
+					&github.IssuesSearchResult{Total: github.Ptr(2), Issues: mockIssues[:2]},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"label":   "stale",
+				"dry_run": true,
+			},
+			expectedMatched: []int{1, 2},
+			expectedRemain:  0,
+		},
+		{
+			name:       "read-only mode always does a dry run",
+			dryRunOnly: true,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetSearchIssues,
+					&github.IssuesSearchResult{Total: github.Ptr(3), Issues: mockIssues},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"label":      "stale",
+				"max_issues": float64(2),
+				"dry_run":    false,
+			},
+			expectedMatched: []int{1, 2},
+			expectedRemain:  1,
+		},
+		{
+			name:         "requires a filter",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one of query, label or updated_before is required",
+		},
+		{
+			name:         "invalid updated_before",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"updated_before": "last year",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid updated_before",
+		},
+		{
+			name:         "max_issues above the cap",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"label":      "stale",
+				"max_issues": float64(500),
+			},
+			expectError:    true,
+			expectedErrMsg: "max_issues must be between 1 and 100",
+		},
+	}
+
+	numbers := func(t *testing.T, v any) []int {
+		t.Helper()
+		issues, ok := v.([]any)
+		require.True(t, ok, "expected an array of issues, got %v", v)
+		result := make([]int, 0, len(issues))
+		for _, issue := range issues {
+			result = append(result, int(issue.(map[string]any)["number"].(float64)))
+		}
+		return result
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CloseStaleIssues(stubGetClientFn(client), tc.dryRunOnly, translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var summary map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &summary))
+
+			assert.Equal(t, tc.expectedRemain, summary["remaining"])
+			if tc.expectedMatched != nil {
+				assert.Equal(t, true, summary["dry_run"])
+				assert.Equal(t, tc.expectedMatched, numbers(t, summary["matched"]))
+				assert.NotContains(t, summary, "closed")
+				return
+			}
+
+			assert.Equal(t, false, summary["dry_run"])
+			assert.Equal(t, tc.expectedClosed, numbers(t, summary["closed"]))
+			assert.Equal(t, tc.expectedFailed, numbers(t, summary["failed"]))
+		})
+	}
+}
+
+func Test_BulkUpdateIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BulkUpdateIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "bulk_update_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "issue_numbers")
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "add_labels")
+	assert.Contains(t, tool.InputSchema.Properties, "remove_labels")
+	assert.Contains(t, tool.InputSchema.Properties, "add_assignees")
+	assert.Contains(t, tool.InputSchema.Properties, "remove_assignees")
+	assert.Contains(t, tool.InputSchema.Properties, "milestone")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "state_reason")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	// Issue 2 is locked, so labels can't be added to it
+	addLabels := func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/issues/2/labels") {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "Issue is locked"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"name": "triaged"}]`))
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedResult string
+	}{
+		{
+			name: "updates issues by number and reports failures",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, []any{"triaged"}).andThen(addLabels),
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+					// The issue doesn't have the label, which is fine
+					mockResponse(t, http.StatusNotFound, `{"message": "Label does not exist"}`),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"state":        "closed",
+						"state_reason": "not_planned",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(1), State: github.Ptr("closed")}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(2)},
+				"add_labels":    []any{"triaged"},
+				"remove_labels": []any{"needs-triage"},
+				"state":         "closed",
+				"state_reason":  "not_planned",
+			},
+			expectedResult: `{
+				"updated": [{"number": 1}],
+				"failed": [{"number": 2, "error": "failed to add labels: POST {server}/repos/owner/repo/issues/2/labels: 403 Issue is locked []"}]
+			}`,
+		},
+		{
+			name: "updates the issues matching a search",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					expectQueryParams(t, map[string]string{
+						"q":        "repo:owner/repo is:issue is:open no:assignee",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+							Total: github.Ptr(3),
+							Issues: []*github.Issue{
+								{Number: github.Ptr(7), Title: github.Ptr("Crash on start"), RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo")},
+								{Number: github.Ptr(8), Title: github.Ptr("Add dark mode"), RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"), PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/8")}},
+								{Number: github.Ptr(9), Title: github.Ptr("Issue of another repository"), RepositoryURL: github.Ptr("https://api.github.com/repos/victim/other")},
+							},
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"assignees": []any{"octocat"}}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Issue{Number: github.Ptr(7)}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"milestone": float64(3)}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(7)}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"query":         "is:open no:assignee",
+				"add_assignees": []any{"octocat"},
+				"milestone":     float64(3),
+			},
+			expectedResult: `{
+				"updated": [{"number": 7, "title": "Crash on start"}],
+				"failed": [],
+				"total_matches": 3,
+				"remaining": 2
+			}`,
+		},
+		{
+			name:         "query reaching other repositories",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"query": "is:open repo:victim/other",
+				"state": "closed",
+			},
+			expectError:    true,
+			expectedErrMsg: "query may not use the repo qualifier",
+		},
+		{
+			name:         "issue numbers and query together",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1)},
+				"query":         "is:open",
+				"state":         "closed",
+			},
+			expectError:    true,
+			expectedErrMsg: "give either issue_numbers or query, not both",
+		},
+		{
+			name:         "nothing to change",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1)},
+			},
+			expectError:    true,
+			expectedErrMsg: "nothing to change",
+		},
+		{
+			name:         "state reason without closing",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1)},
+				"state_reason":  "completed",
+			},
+			expectError:    true,
+			expectedErrMsg: "state_reason must be completed or not_planned, and requires state to be closed",
+		},
+		{
+			name:         "invalid issue number",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{"one"},
+				"state":         "closed",
+			},
+			expectError:    true,
+			expectedErrMsg: "issue_numbers must be positive integers, got one",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := BulkUpdateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Errors name the URL of the mock server, which listens on a random port
+			textContent := getTextResult(t, result)
+			text := regexp.MustCompile(`http://127\.0\.0\.1:\d+`).ReplaceAllString(textContent.Text, "{server}")
+			assert.JSONEq(t, tc.expectedResult, text)
+		})
+	}
+}
+
 func Test_ParseISOTimestamp(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1636,6 +2201,313 @@ func TestAssignCopilotToIssue(t *testing.T) {
 	}
 }
 
+func Test_TransferIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := TransferIssue(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "transfer_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "target_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "create_labels_if_missing")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "target_repo"})
+
+	idsQuery := struct {
+		Repository struct {
+			Issue struct {
+				ID githubv4.ID
+			} `graphql:"issue(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+		TargetRepository struct {
+			ID githubv4.ID
+		} `graphql:"targetRepository: repository(owner: $owner, name: $targetRepo)"`
+	}{}
+
+	idsVars := map[string]any{
+		"owner":      githubv4.String("owner"),
+		"repo":       githubv4.String("repo"),
+		"number":     githubv4.Int(42),
+		"targetRepo": githubv4.String("other-repo"),
+	}
+
+	idsResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"issue": map[string]any{
+				"id": "issue-node-id",
+			},
+		},
+		"targetRepository": map[string]any{
+			"id": "repo-node-id",
+		},
+	})
+
+	transferMutation := struct {
+		TransferIssue struct {
+			Issue struct {
+				Number githubv4.Int
+				URL    githubv4.String
+			}
+		} `graphql:"transferIssue(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful transfer",
+			requestArgs: map[string]any{
+				"owner":                    "owner",
+				"repo":                     "repo",
+				"issue_number":             float64(42),
+				"target_repo":              "other-repo",
+				"create_labels_if_missing": true,
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(idsQuery, idsVars, idsResponse),
+				githubv4mock.NewMutationMatcher(
+					transferMutation,
+					githubv4.TransferIssueInput{
+						IssueID:               githubv4.ID("issue-node-id"),
+						RepositoryID:          githubv4.ID("repo-node-id"),
+						CreateLabelsIfMissing: githubv4.NewBoolean(true),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"transferIssue": map[string]any{
+							"issue": map[string]any{
+								"number": 7,
+								"url":    "https://github.com/owner/other-repo/issues/7",
+							},
+						},
+					}),
+				),
+			),
+			expectError: false,
+		},
+		{
+			name: "target repository not accessible",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"target_repo":  "other-repo",
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(idsQuery, idsVars,
+					githubv4mock.ErrorResponse("Could not resolve to a Repository with the name 'owner/other-repo'."),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "check that both exist and the token has access to them",
+		},
+		{
+			name: "transfer mutation fails",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"target_repo":  "other-repo",
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(idsQuery, idsVars, idsResponse),
+				githubv4mock.NewMutationMatcher(
+					transferMutation,
+					githubv4.TransferIssueInput{
+						IssueID:      githubv4.ID("issue-node-id"),
+						RepositoryID: githubv4.ID("repo-node-id"),
+					},
+					nil,
+					githubv4mock.ErrorResponse("owner does not have the correct permissions to execute `TransferIssue`"),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to transfer issue #42 to owner/other-repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := TransferIssue(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, fmt.Sprintf("expected there to be no tool error, text was %s", textContent.Text))
+
+			var returned struct {
+				Number int    `json:"number"`
+				URL    string `json:"url"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, 7, returned.Number)
+			assert.Equal(t, "https://github.com/owner/other-repo/issues/7", returned.URL)
+		})
+	}
+}
+
+func Test_CloseIssueAsDuplicate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CloseIssueAsDuplicate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "close_issue_as_duplicate", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "duplicate_of")
+	assert.Contains(t, tool.InputSchema.Properties, "duplicate_of_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "comment")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "duplicate_of"})
+
+	closedIssue := &github.Issue{
+		Number:      github.Ptr(12),
+		State:       github.Ptr("closed"),
+		StateReason: github.Ptr("duplicate"),
+		HTMLURL:     github.Ptr("https://github.com/owner/repo/issues/12"),
+	}
+	closeAsDuplicate := mock.WithRequestMatchHandler(
+		mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+		expectRequestBody(t, map[string]any{
+			"state":        "closed",
+			"state_reason": "duplicate",
+		}).andThen(
+			mockResponse(t, http.StatusOK, closedIssue),
+		),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedResult string
+	}{
+		{
+			name: "duplicate in the same repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"body": "Duplicate of #7\n\nThanks for the report, let's continue there."}).andThen(
+						mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(1))}),
+					),
+				),
+				closeAsDuplicate,
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(12),
+				"duplicate_of": float64(7),
+				"comment":      "Thanks for the report, let's continue there.",
+			},
+			expectedResult: `{"number": 12, "state": "closed", "state_reason": "duplicate", "duplicate_of": "#7", "url": "https://github.com/owner/repo/issues/12"}`,
+		},
+		{
+			name: "duplicate in another repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"body": "Duplicate of owner/other#12"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(1))}),
+					),
+				),
+				closeAsDuplicate,
+			),
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"issue_number":      float64(12),
+				"duplicate_of":      float64(12),
+				"duplicate_of_repo": "owner/other",
+			},
+			expectedResult: `{"number": 12, "state": "closed", "state_reason": "duplicate", "duplicate_of": "owner/other#12", "url": "https://github.com/owner/repo/issues/12"}`,
+		},
+		{
+			name:         "duplicate of itself",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(12),
+				"duplicate_of": float64(12),
+			},
+			expectError:    true,
+			expectedErrMsg: "an issue can't be a duplicate of itself",
+		},
+		{
+			name:         "invalid repository",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"issue_number":      float64(12),
+				"duplicate_of":      float64(7),
+				"duplicate_of_repo": "other",
+			},
+			expectError:    true,
+			expectedErrMsg: "duplicate_of_repo must be of the form owner/repo",
+		},
+		{
+			name: "comment fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusForbidden, `{"message": "Issue is locked"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(12),
+				"duplicate_of": float64(7),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to comment on issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CloseIssueAsDuplicate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedResult, textContent.Text)
+		})
+	}
+}
+
 func Test_AddSubIssue(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -2601,3 +3473,28 @@ func Test_ReprioritizeSubIssue(t *testing.T) {
 		})
 	}
 }
+
+func Test_BulkUpdateIssues_Progress(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+			[]*github.Label{{Name: github.Ptr("triaged")}},
+			[]*github.Label{{Name: github.Ptr("triaged")}},
+		),
+	)
+	tool, handler := BulkUpdateIssues(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, notifications := callToolWithProgress(t, tool, handler, map[string]any{
+		"owner":         "owner",
+		"repo":          "repo",
+		"issue_numbers": []any{float64(1), float64(2)},
+		"add_labels":    []any{"triaged"},
+	})
+	require.False(t, result.IsError)
+	require.Len(t, notifications, 2)
+	for i, notification := range notifications {
+		assert.Equal(t, float64(i), notification["progress"])
+		assert.Equal(t, float64(2), notification["total"])
+		assert.Equal(t, fmt.Sprintf("Updating issue #%d", i+1), notification["message"])
+	}
+}
@@ -0,0 +1,574 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// maxSyncLabels caps the number of labels a sync_labels spec can declare.
+const maxSyncLabels = 200
+
+var labelColorPattern = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+// normalizeLabelColor strips the leading # GitHub doesn't accept and checks the color is a hex triplet.
+func normalizeLabelColor(color string) (string, error) {
+	color = strings.ToLower(strings.TrimPrefix(color, "#"))
+	if !labelColorPattern.MatchString(color) {
+		return "", fmt.Errorf("invalid color %q, must be six hexadecimal digits such as d73a4a", color)
+	}
+	return color, nil
+}
+
+// labelPatch is the body of a label update. GitHub renames labels through new_name, which
+// github.Label has no field for.
+type labelPatch struct {
+	NewName     *string `json:"new_name,omitempty"`
+	Color       *string `json:"color,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// editLabel updates the label with the given name.
+func editLabel(ctx context.Context, client *github.Client, owner, repo, name string, patch *labelPatch) (*github.Label, *github.Response, error) {
+	req, err := client.NewRequest(http.MethodPatch, fmt.Sprintf("repos/%s/%s/labels/%s", owner, repo, url.PathEscape(name)), patch)
+	if err != nil {
+		return nil, nil, err
+	}
+	label := new(github.Label)
+	resp, err := client.Do(ctx, req, label)
+	if err != nil {
+		return nil, resp, err
+	}
+	return label, resp, nil
+}
+
+// listAllLabels collects every label of a repository, failing for repositories with more labels than
+// listAllPages collects.
+func listAllLabels(ctx context.Context, client *github.Client, owner, repo string) ([]*github.Label, *github.Response, error) {
+	labels, truncated, resp, err := listAllPages(ctx, 0, func(ctx context.Context, opts github.ListOptions) ([]*github.Label, *github.Response, error) {
+		return client.Issues.ListLabels(ctx, owner, repo, &opts)
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	if truncated {
+		return nil, nil, fmt.Errorf("%s/%s has more than %d labels", owner, repo, MaxPaginatedItems)
+	}
+	return labels, resp, nil
+}
+
+// ListLabels creates a tool to list the labels of a repository.
+func ListLabels(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_labels",
+			mcp.WithDescription(t("TOOL_LIST_LABELS_DESCRIPTION", "List the labels of a repository with their color and description.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_LABELS_USER_TITLE", "List labels"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			labels, resp, err := client.Issues.ListLabels(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list labels of %s/%s", owner, repo), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(labels), nil
+		}
+}
+
+// CreateLabel creates a tool to create a label in a repository.
+func CreateLabel(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_label",
+			mcp.WithDescription(t("TOOL_CREATE_LABEL_DESCRIPTION", "Create a label in a repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_LABEL_USER_TITLE", "Create label"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the label"),
+			),
+			mcp.WithString("color",
+				mcp.Required(),
+				mcp.Description("Color of the label as six hexadecimal digits, e.g. d73a4a"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the label, at most 100 characters"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			color, err := RequiredParam[string](request, "color")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			color, err = normalizeLabelColor(color)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			label, resp, err := client.Issues.CreateLabel(ctx, owner, repo, &github.Label{
+				Name:        github.Ptr(name),
+				Color:       github.Ptr(color),
+				Description: ToStringPtr(description),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to create label %s", name), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(label), nil
+		}
+}
+
+// UpdateLabel creates a tool to rename a label or change its color or description.
+func UpdateLabel(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_label",
+			mcp.WithDescription(t("TOOL_UPDATE_LABEL_DESCRIPTION", "Rename a label or change its color or description. Issues and pull requests keep a renamed label.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_LABEL_USER_TITLE", "Update label"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Current name of the label"),
+			),
+			mcp.WithString("new_name",
+				mcp.Description("New name of the label"),
+			),
+			mcp.WithString("color",
+				mcp.Description("New color of the label as six hexadecimal digits"),
+			),
+			mcp.WithString("description",
+				mcp.Description("New description of the label"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newName, err := OptionalParam[string](request, "new_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			color, err := OptionalParam[string](request, "color")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if color != "" {
+				color, err = normalizeLabelColor(color)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+			patch := &labelPatch{
+				NewName: ToStringPtr(newName),
+				Color:   ToStringPtr(color),
+			}
+			// An empty description clears it, so only a missing one leaves it unchanged
+			if _, ok := request.GetArguments()["description"]; ok {
+				description, err := OptionalParam[string](request, "description")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				patch.Description = github.Ptr(description)
+			}
+			if patch.NewName == nil && patch.Color == nil && patch.Description == nil {
+				return mcp.NewToolResultError("at least one of new_name, color or description is required"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			label, resp, err := editLabel(ctx, client, owner, repo, name, patch)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to update label %s", name), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(label), nil
+		}
+}
+
+// DeleteLabel creates a tool to delete a label from a repository.
+func DeleteLabel(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_label",
+			mcp.WithDescription(t("TOOL_DELETE_LABEL_DESCRIPTION", "Delete a label from a repository, removing it from every issue and pull request that has it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_LABEL_USER_TITLE", "Delete label"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the label"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Issues.DeleteLabel(ctx, owner, repo, url.PathEscape(name))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to delete label %s", name), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("deleted label %s", name)), nil
+		}
+}
+
+// LabelSpec is a label declared in a sync_labels spec. Aliases are former names of the label, an existing
+// label with one of them is renamed so that issues and pull requests keep it.
+type LabelSpec struct {
+	Name        string   `yaml:"name" json:"name"`
+	Color       string   `yaml:"color" json:"color"`
+	Description string   `yaml:"description" json:"description,omitempty"`
+	Aliases     []string `yaml:"aliases" json:"aliases,omitempty"`
+}
+
+// parseLabelSpec reads a list of labels from YAML or JSON, which is valid YAML as well. The list can also
+// be given under a top-level labels key.
+func parseLabelSpec(spec string) ([]LabelSpec, error) {
+	var labels []LabelSpec
+	if err := yaml.Unmarshal([]byte(spec), &labels); err != nil {
+		var wrapped struct {
+			Labels []LabelSpec `yaml:"labels"`
+		}
+		if wrappedErr := yaml.Unmarshal([]byte(spec), &wrapped); wrappedErr != nil {
+			return nil, fmt.Errorf("failed to parse spec: %w", err)
+		}
+		labels = wrapped.Labels
+	}
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("spec declares no labels")
+	}
+	if len(labels) > maxSyncLabels {
+		return nil, fmt.Errorf("spec declares %d labels, at most %d are allowed", len(labels), maxSyncLabels)
+	}
+
+	seen := map[string]bool{}
+	for i := range labels {
+		l := &labels[i]
+		if l.Name == "" {
+			return nil, fmt.Errorf("label %d of the spec has no name", i+1)
+		}
+		color, err := normalizeLabelColor(l.Color)
+		if err != nil {
+			return nil, fmt.Errorf("label %s: %w", l.Name, err)
+		}
+		l.Color = color
+		// Label names are case-insensitive on GitHub
+		for _, name := range append([]string{l.Name}, l.Aliases...) {
+			key := strings.ToLower(name)
+			if seen[key] {
+				return nil, fmt.Errorf("label %s is declared more than once in the spec", name)
+			}
+			seen[key] = true
+		}
+	}
+	return labels, nil
+}
+
+// labelChange is a change sync_labels makes, or would make, to a label.
+type labelChange struct {
+	Name    string   `json:"name"`
+	OldName string   `json:"old_name,omitempty"`
+	Changes []string `json:"changes,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// SyncLabels creates a tool to reconcile the labels of a repository with a declarative spec.
+func SyncLabels(getClient GetClientFn, dryRunOnly bool, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	description := "Reconcile the labels of a repository with a spec: create the labels that are missing, rename labels found under one of their aliases, fix colors and descriptions, and with delete_missing delete the labels the spec doesn't declare. Returns the changes that were made, or with dry_run the changes that would be."
+	if dryRunOnly {
+		description = "Compare the labels of a repository with a spec and report the labels that would be created, renamed, updated or deleted to match it. The server is in read-only mode, so no labels are changed."
+	}
+
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_SYNC_LABELS_DESCRIPTION", description)),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           t("TOOL_SYNC_LABELS_USER_TITLE", "Sync labels"),
+			ReadOnlyHint:    ToBoolPtr(dryRunOnly),
+			DestructiveHint: ToBoolPtr(!dryRunOnly),
+		}),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description(DescriptionRepositoryOwner),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description(DescriptionRepositoryName),
+		),
+		mcp.WithString("spec",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("YAML or JSON list of at most %d labels, each with a name, a color of six hexadecimal digits, and optionally a description and aliases, the former names of the label. E.g. [{\"name\": \"bug\", \"color\": \"d73a4a\", \"aliases\": [\"defect\"]}]", maxSyncLabels)),
+		),
+		mcp.WithBoolean("delete_missing",
+			mcp.Description("Delete the labels of the repository that the spec doesn't declare, removing them from their issues and pull requests"),
+		),
+	}
+	if !dryRunOnly {
+		options = append(options, mcp.WithBoolean("dry_run",
+			mcp.Description("Only report the changes without making them"),
+		))
+	}
+
+	return mcp.NewTool("sync_labels", options...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			spec, err := RequiredParam[string](request, "spec")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deleteMissing, err := OptionalParam[bool](request, "delete_missing")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dryRun := dryRunOnly
+			if !dryRunOnly {
+				dryRun, err = OptionalParam[bool](request, "dry_run")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+			declared, err := parseLabelSpec(spec)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			labels, resp, err := listAllLabels(ctx, client, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list labels of %s/%s", owner, repo), resp, err), nil
+			}
+			existing := make(map[string]*github.Label, len(labels))
+			for _, l := range labels {
+				existing[strings.ToLower(l.GetName())] = l
+			}
+
+			created := []labelChange{}
+			updated := []labelChange{}
+			deleted := []labelChange{}
+			failed := []labelChange{}
+			unchanged := 0
+			kept := map[string]bool{}
+
+			for _, want := range declared {
+				current := existing[strings.ToLower(want.Name)]
+				if current == nil {
+					for _, alias := range want.Aliases {
+						if current = existing[strings.ToLower(alias)]; current != nil {
+							break
+						}
+					}
+				}
+
+				if current == nil {
+					change := labelChange{Name: want.Name}
+					if !dryRun {
+						_, resp, err := client.Issues.CreateLabel(ctx, owner, repo, &github.Label{
+							Name:        github.Ptr(want.Name),
+							Color:       github.Ptr(want.Color),
+							Description: github.Ptr(want.Description),
+						})
+						if err != nil {
+							_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, fmt.Sprintf("failed to create label %s", want.Name), resp, err)
+							change.Error = fmt.Sprintf("failed to create label: %s", err.Error())
+							failed = append(failed, change)
+							continue
+						}
+						_ = resp.Body.Close()
+					}
+					created = append(created, change)
+					continue
+				}
+
+				kept[strings.ToLower(current.GetName())] = true
+				patch := &labelPatch{}
+				change := labelChange{Name: want.Name}
+				if current.GetName() != want.Name {
+					patch.NewName = github.Ptr(want.Name)
+					change.OldName = current.GetName()
+					change.Changes = append(change.Changes, "name")
+				}
+				if strings.ToLower(current.GetColor()) != want.Color {
+					patch.Color = github.Ptr(want.Color)
+					change.Changes = append(change.Changes, "color")
+				}
+				if current.GetDescription() != want.Description {
+					patch.Description = github.Ptr(want.Description)
+					change.Changes = append(change.Changes, "description")
+				}
+				if len(change.Changes) == 0 {
+					unchanged++
+					continue
+				}
+				if !dryRun {
+					_, resp, err := editLabel(ctx, client, owner, repo, current.GetName(), patch)
+					if err != nil {
+						_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, fmt.Sprintf("failed to update label %s", current.GetName()), resp, err)
+						change.Error = fmt.Sprintf("failed to update label: %s", err.Error())
+						failed = append(failed, change)
+						continue
+					}
+					_ = resp.Body.Close()
+				}
+				updated = append(updated, change)
+			}
+
+			if deleteMissing {
+				for _, l := range labels {
+					if kept[strings.ToLower(l.GetName())] {
+						continue
+					}
+					change := labelChange{Name: l.GetName()}
+					if !dryRun {
+						resp, err := client.Issues.DeleteLabel(ctx, owner, repo, url.PathEscape(l.GetName()))
+						if err != nil {
+							_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, fmt.Sprintf("failed to delete label %s", l.GetName()), resp, err)
+							change.Error = fmt.Sprintf("failed to delete label: %s", err.Error())
+							failed = append(failed, change)
+							continue
+						}
+						_ = resp.Body.Close()
+					}
+					deleted = append(deleted, change)
+				}
+			}
+
+			summary := map[string]any{
+				"dry_run":   dryRun,
+				"created":   created,
+				"updated":   updated,
+				"deleted":   deleted,
+				"unchanged": unchanged,
+			}
+			if len(failed) > 0 {
+				summary["failed"] = failed
+			}
+			return MarshalledTextResult(summary), nil
+		}
+}
@@ -0,0 +1,414 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListLabels(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListLabels(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_labels", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposLabelsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"page":     "1",
+				"per_page": "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.Label{
+					{Name: github.Ptr("bug"), Color: github.Ptr("d73a4a")},
+				}),
+			),
+		),
+	))
+	_, handler := ListLabels(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*github.Label
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 1)
+	assert.Equal(t, "bug", returned[0].GetName())
+}
+
+func Test_CreateLabel(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateLabel(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_label", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name", "color"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		color          string
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposLabelsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"name":        "needs triage",
+						"color":       "fbca04",
+						"description": "Waiting for a maintainer",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Label{Name: github.Ptr("needs triage"), Color: github.Ptr("fbca04")}),
+					),
+				),
+			),
+			color: "#FBCA04",
+		},
+		{
+			name:           "invalid color",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			color:          "yellow",
+			expectError:    true,
+			expectedErrMsg: `invalid color "yellow", must be six hexadecimal digits`,
+		},
+		{
+			name: "label exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposLabelsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed", "errors": [{"code": "already_exists"}]}`),
+				),
+			),
+			color:          "fbca04",
+			expectError:    true,
+			expectedErrMsg: "failed to create label needs triage",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateLabel(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "needs triage",
+				"color":       tc.color,
+				"description": "Waiting for a maintainer",
+			}))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.Label
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "fbca04", returned.GetColor())
+		})
+	}
+}
+
+func Test_UpdateLabel(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateLabel(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_label", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "rename and clear description",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposLabelsByOwnerByRepoByName,
+					expectPath(t, "/repos/owner/repo/labels/good first issue").andThen(
+						expectRequestBody(t, map[string]any{
+							"new_name":    "good-first-issue",
+							"description": "",
+						}).andThen(
+							mockResponse(t, http.StatusOK, &github.Label{Name: github.Ptr("good-first-issue")}),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"new_name":    "good-first-issue",
+				"description": "",
+			},
+		},
+		{
+			name:           "nothing to change",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "at least one of new_name, color or description is required",
+		},
+		{
+			name: "label not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposLabelsByOwnerByRepoByName,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"color": "000000",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update label good first issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateLabel(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "good first issue",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.Label
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "good-first-issue", returned.GetName())
+		})
+	}
+}
+
+func Test_DeleteLabel(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteLabel(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_label", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposLabelsByOwnerByRepoByName,
+			expectPath(t, "/repos/owner/repo/labels/wontfix").andThen(
+				mockResponse(t, http.StatusNoContent, ""),
+			),
+		),
+	))
+	_, handler := DeleteLabel(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"name":  "wontfix",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "deleted label wontfix", getTextResult(t, result).Text)
+}
+
+func Test_SyncLabels(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SyncLabels(stubGetClientFn(mockClient), false, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "sync_labels", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "spec"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	// The read-only variant is a dry run only
+	readOnlyTool, _ := SyncLabels(stubGetClientFn(mockClient), true, translations.NullTranslationHelper)
+	assert.True(t, *readOnlyTool.Annotations.ReadOnlyHint)
+	assert.NotContains(t, readOnlyTool.InputSchema.Properties, "dry_run")
+
+	existing := []*github.Label{
+		{Name: github.Ptr("bug"), Color: github.Ptr("d73a4a"), Description: github.Ptr("Something isn't working")},
+		{Name: github.Ptr("Enhancement"), Color: github.Ptr("a2eeef")},
+		{Name: github.Ptr("defect"), Color: github.Ptr("000000")},
+		{Name: github.Ptr("wontfix"), Color: github.Ptr("ffffff")},
+	}
+	spec := `
+labels:
+  - name: bug
+    color: "d73a4a"
+    description: Something isn't working
+  - name: enhancement
+    color: "#A2EEEF"
+    description: New feature or request
+  - name: regression
+    color: "b60205"
+    aliases: [defect]
+  - name: needs triage
+    color: "fbca04"
+`
+
+	type call struct {
+		method string
+		path   string
+		body   map[string]any
+	}
+	newClient := func(calls *[]call) *http.Client {
+		var mu sync.Mutex
+		record := func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			c := call{method: r.Method, path: r.URL.Path}
+			if r.Method != http.MethodDelete {
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&c.body))
+			}
+			*calls = append(*calls, c)
+			if r.Method == http.MethodDelete {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			if r.Method == http.MethodPost && c.body["name"] == "needs triage" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message": "Must have push access"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposLabelsByOwnerByRepo, existing),
+			mock.WithRequestMatchHandler(mock.PostReposLabelsByOwnerByRepo, http.HandlerFunc(record)),
+			mock.WithRequestMatchHandler(mock.PatchReposLabelsByOwnerByRepoByName, http.HandlerFunc(record)),
+			mock.WithRequestMatchHandler(mock.DeleteReposLabelsByOwnerByRepoByName, http.HandlerFunc(record)),
+		)
+	}
+
+	type syncSummary struct {
+		DryRun    bool          `json:"dry_run"`
+		Created   []labelChange `json:"created"`
+		Updated   []labelChange `json:"updated"`
+		Deleted   []labelChange `json:"deleted"`
+		Failed    []labelChange `json:"failed"`
+		Unchanged int           `json:"unchanged"`
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		var calls []call
+		_, handler := SyncLabels(stubGetClientFn(github.NewClient(newClient(&calls))), true, translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":          "owner",
+			"repo":           "repo",
+			"spec":           spec,
+			"delete_missing": true,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var summary syncSummary
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+		assert.True(t, summary.DryRun)
+		assert.Empty(t, calls)
+		assert.Equal(t, 1, summary.Unchanged)
+		assert.Equal(t, []labelChange{{Name: "needs triage"}}, summary.Created)
+		assert.Equal(t, []labelChange{
+			{Name: "enhancement", OldName: "Enhancement", Changes: []string{"name", "description"}},
+			{Name: "regression", OldName: "defect", Changes: []string{"name", "color"}},
+		}, summary.Updated)
+		assert.Equal(t, []labelChange{{Name: "wontfix"}}, summary.Deleted)
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		var calls []call
+		_, handler := SyncLabels(stubGetClientFn(github.NewClient(newClient(&calls))), false, translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner": "owner",
+			"repo":  "repo",
+			"spec":  spec,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var summary syncSummary
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+		assert.False(t, summary.DryRun)
+		assert.Empty(t, summary.Created)
+		require.Len(t, summary.Failed, 1)
+		assert.Equal(t, "needs triage", summary.Failed[0].Name)
+		assert.Len(t, summary.Updated, 2)
+		// Without delete_missing the labels the spec doesn't declare stay
+		assert.Empty(t, summary.Deleted)
+
+		assert.Equal(t, []call{
+			{method: http.MethodPatch, path: "/repos/owner/repo/labels/Enhancement", body: map[string]any{"new_name": "enhancement", "description": "New feature or request"}},
+			{method: http.MethodPatch, path: "/repos/owner/repo/labels/defect", body: map[string]any{"new_name": "regression", "color": "b60205"}},
+			{method: http.MethodPost, path: "/repos/owner/repo/labels", body: map[string]any{"name": "needs triage", "color": "fbca04", "description": ""}},
+		}, calls)
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		_, handler := SyncLabels(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient())), false, translations.NullTranslationHelper)
+
+		for spec, expectedErrMsg := range map[string]string{
+			`[{"name": "bug"}]`: `label bug: invalid color ""`,
+			`[{"name": "bug", "color": "d73a4a"}, {"name": "Bug", "color": "d73a4a"}]`: "label Bug is declared more than once in the spec",
+			`[]`:         "spec declares no labels",
+			`not a list`: "failed to parse spec",
+		} {
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"spec":  spec,
+			}))
+			require.NoError(t, err)
+			require.True(t, result.IsError, spec)
+			assert.Contains(t, getErrorResult(t, result).Text, expectedErrMsg)
+		}
+	})
+}
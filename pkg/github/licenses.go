@@ -0,0 +1,339 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RepositoryLicenseInfo is the license GitHub detected for a repository.
+type RepositoryLicenseInfo struct {
+	// Detected is true if GitHub identified the license, a license file it couldn't identify leaves it false
+	Detected bool   `json:"detected"`
+	SPDXID   string `json:"spdx_id,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Path     string `json:"path,omitempty"`
+	HTMLURL  string `json:"html_url,omitempty"`
+	Content  string `json:"content,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// License compatibility verdicts, see checkLicenseCompatibility.
+const (
+	LicenseCompatible   = "compatible"
+	LicenseConditional  = "conditional"
+	LicenseIncompatible = "incompatible"
+	LicenseUnknown      = "unknown"
+)
+
+// LicenseCompatibility is the verdict on using code under one license in a project under another.
+type LicenseCompatibility struct {
+	// License is the license of the code to be used
+	License string `json:"license"`
+	// TargetLicense is the license of the project the code is used in
+	TargetLicense string `json:"target_license"`
+	Verdict       string `json:"verdict"`
+	Reason        string `json:"reason"`
+}
+
+type licenseKind int
+
+const (
+	licensePermissive licenseKind = iota
+	// licenseFileCopyleft covers licenses whose copyleft applies to the files they cover, such as MPL-2.0
+	licenseFileCopyleft
+	licenseLGPL
+	licenseGPL
+	licenseAGPL
+)
+
+// licenseTerms are the properties of a license the compatibility matrix looks at.
+type licenseTerms struct {
+	id   string
+	kind licenseKind
+	// versions are the GPL family versions the license can be distributed under, 2 standing for GPL-2.0
+	// and LGPL-2.1. Versions that don't exist yet are not counted for -or-later licenses.
+	versions []int
+}
+
+// knownLicenses is the built-in compatibility matrix, keyed by upper-cased SPDX identifier.
+var knownLicenses = func() map[string]licenseTerms {
+	licenses := make(map[string]licenseTerms)
+	add := func(terms licenseTerms, aliases ...string) {
+		for _, id := range append([]string{terms.id}, aliases...) {
+			licenses[strings.ToUpper(id)] = terms
+		}
+	}
+	for _, id := range []string{"0BSD", "BSD-2-Clause", "BSD-3-Clause", "BSL-1.0", "CC0-1.0", "ISC", "MIT", "Unlicense", "Zlib", "Apache-2.0"} {
+		add(licenseTerms{id: id, kind: licensePermissive})
+	}
+	add(licenseTerms{id: "MPL-2.0", kind: licenseFileCopyleft})
+	add(licenseTerms{id: "EPL-2.0", kind: licenseFileCopyleft})
+	// The deprecated identifiers without a suffix, which GitHub still reports, mean -only
+	add(licenseTerms{id: "LGPL-2.1-only", kind: licenseLGPL, versions: []int{2}}, "LGPL-2.1")
+	add(licenseTerms{id: "LGPL-2.1-or-later", kind: licenseLGPL, versions: []int{2, 3}}, "LGPL-2.1+")
+	add(licenseTerms{id: "LGPL-3.0-only", kind: licenseLGPL, versions: []int{3}}, "LGPL-3.0")
+	add(licenseTerms{id: "LGPL-3.0-or-later", kind: licenseLGPL, versions: []int{3}}, "LGPL-3.0+")
+	add(licenseTerms{id: "GPL-2.0-only", kind: licenseGPL, versions: []int{2}}, "GPL-2.0")
+	add(licenseTerms{id: "GPL-2.0-or-later", kind: licenseGPL, versions: []int{2, 3}}, "GPL-2.0+")
+	add(licenseTerms{id: "GPL-3.0-only", kind: licenseGPL, versions: []int{3}}, "GPL-3.0")
+	add(licenseTerms{id: "GPL-3.0-or-later", kind: licenseGPL, versions: []int{3}}, "GPL-3.0+")
+	add(licenseTerms{id: "AGPL-3.0-only", kind: licenseAGPL, versions: []int{3}}, "AGPL-3.0")
+	add(licenseTerms{id: "AGPL-3.0-or-later", kind: licenseAGPL, versions: []int{3}}, "AGPL-3.0+")
+	return licenses
+}()
+
+// gplVersionVerdict compares the GPL versions code can be distributed under with the versions of the target.
+func gplVersionVerdict(license string, versions []int, target licenseTerms) (string, string) {
+	var common []int
+	for _, v := range target.versions {
+		if slices.Contains(versions, v) {
+			common = append(common, v)
+		}
+	}
+	switch {
+	case len(common) == 0:
+		return LicenseIncompatible, fmt.Sprintf("%s can't be distributed under the GPL version %s requires", license, target.id)
+	case len(common) < len(target.versions):
+		return LicenseConditional, fmt.Sprintf("only if the combined work is distributed under version %d, which %s permits", common[0], target.id)
+	default:
+		return LicenseCompatible, fmt.Sprintf("%s can be distributed under %s", license, target.id)
+	}
+}
+
+// checkLicenseCompatibility looks up in the built-in matrix whether code under license can be used in a
+// project under target. This is a simplification for common cases, not legal advice.
+func checkLicenseCompatibility(license, target string) LicenseCompatibility {
+	result := LicenseCompatibility{License: license, TargetLicense: target}
+	s, ok := knownLicenses[strings.ToUpper(strings.TrimSpace(license))]
+	if !ok {
+		result.Verdict, result.Reason = LicenseUnknown, fmt.Sprintf("%s is not in the built-in compatibility matrix", license)
+		return result
+	}
+	t, ok := knownLicenses[strings.ToUpper(strings.TrimSpace(target))]
+	if !ok {
+		result.Verdict, result.Reason = LicenseUnknown, fmt.Sprintf("%s is not in the built-in compatibility matrix", target)
+		return result
+	}
+	result.License, result.TargetLicense = s.id, t.id
+
+	if s.id == t.id {
+		result.Verdict, result.Reason = LicenseCompatible, "both use the same license"
+		return result
+	}
+
+	switch s.kind {
+	case licensePermissive:
+		if s.id == "Apache-2.0" && t.kind >= licenseLGPL {
+			result.Verdict, result.Reason = gplVersionVerdict(s.id, []int{3}, t)
+			if result.Verdict == LicenseIncompatible {
+				result.Reason = "the patent terms of Apache-2.0 are additional restrictions that version 2 of the GPL doesn't allow"
+			}
+			return result
+		}
+		result.Verdict, result.Reason = LicenseCompatible, fmt.Sprintf("%s is permissive, keep its copyright and license notices", s.id)
+	case licenseFileCopyleft:
+		switch {
+		case s.id == "MPL-2.0" && t.kind >= licenseLGPL:
+			result.Verdict, result.Reason = LicenseCompatible, fmt.Sprintf("MPL-2.0 allows distribution under %s as a secondary license, unless the files are marked Incompatible With Secondary Licenses", t.id)
+		case s.id == "EPL-2.0" && t.kind >= licenseLGPL:
+			result.Verdict, result.Reason = LicenseConditional, "only if the EPL-2.0 code designates GPL-2.0 or later as a secondary license"
+		default:
+			result.Verdict, result.Reason = LicenseConditional, fmt.Sprintf("files under %s must stay under %s with their source available, the rest of the project can use %s", s.id, s.id, t.id)
+		}
+	case licenseLGPL:
+		versions := s.versions
+		if t.kind != licenseLGPL && slices.Contains(s.versions, 2) {
+			// LGPL-2.1 code can be relicensed under GPL-2.0 or any later version
+			versions = []int{2, 3}
+		}
+		if t.kind >= licenseLGPL {
+			if verdict, reason := gplVersionVerdict(s.id, versions, t); verdict != LicenseIncompatible || t.kind != licenseLGPL {
+				result.Verdict, result.Reason = verdict, reason
+				return result
+			}
+		}
+		result.Verdict, result.Reason = LicenseConditional, fmt.Sprintf("only as a separate library users can replace, changes to the library itself stay under %s", s.id)
+	case licenseGPL:
+		switch t.kind {
+		case licenseGPL:
+			result.Verdict, result.Reason = gplVersionVerdict(s.id, s.versions, t)
+		case licenseAGPL:
+			if slices.Contains(s.versions, 3) {
+				result.Verdict, result.Reason = LicenseCompatible, "section 13 of GPL-3.0 allows combining with AGPL-3.0 code"
+			} else {
+				result.Verdict, result.Reason = LicenseIncompatible, fmt.Sprintf("%s can't be distributed under version 3 of the GPL, which AGPL-3.0 requires", s.id)
+			}
+		default:
+			result.Verdict, result.Reason = LicenseIncompatible, fmt.Sprintf("%s is a strong copyleft license, a project that includes it must be distributed as a whole under %s", s.id, s.id)
+		}
+	case licenseAGPL:
+		switch {
+		case t.kind == licenseAGPL:
+			result.Verdict, result.Reason = LicenseCompatible, "both are version 3 of the AGPL"
+		case t.kind == licenseGPL && slices.Contains(t.versions, 3):
+			result.Verdict, result.Reason = LicenseConditional, "section 13 of GPL-3.0 allows combining with AGPL-3.0 code, but the AGPL-3.0 part keeps its requirement to offer the source to users interacting with it over a network"
+		default:
+			result.Verdict, result.Reason = LicenseIncompatible, fmt.Sprintf("%s is a strong copyleft license, a project that includes it must be distributed as a whole under %s, including to users interacting with it over a network", s.id, s.id)
+		}
+	}
+	return result
+}
+
+// getRepositoryLicense fetches the license GitHub detected for a repository. A repository without a license
+// file is not an error, it returns a RepositoryLicenseInfo with Detected unset.
+func getRepositoryLicense(ctx context.Context, client *github.Client, owner, repo string) (*RepositoryLicenseInfo, *github.Response, error) {
+	license, resp, err := client.Repositories.License(ctx, owner, repo)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return &RepositoryLicenseInfo{
+			Message: fmt.Sprintf("no license file was found in %s/%s, without a license the code can't be reused", owner, repo),
+		}, resp, nil
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+
+	content, err := (&github.RepositoryContent{Content: license.Content, Encoding: license.Encoding}).GetContent()
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to decode license file: %w", err)
+	}
+
+	info := &RepositoryLicenseInfo{
+		Path:    license.GetPath(),
+		HTMLURL: license.GetHTMLURL(),
+		Content: content,
+	}
+	// GitHub reports NOASSERTION for license files it couldn't identify, such as custom licenses
+	if spdx := license.GetLicense().GetSPDXID(); spdx != "" && spdx != "NOASSERTION" {
+		info.Detected = true
+		info.SPDXID = spdx
+		info.Key = license.GetLicense().GetKey()
+		info.Name = license.GetLicense().GetName()
+	} else {
+		info.Message = fmt.Sprintf("%s was found but GitHub could not identify the license, read its content", license.GetPath())
+	}
+	return info, resp, nil
+}
+
+// GetRepositoryLicense creates a tool to get the license of a repository.
+func GetRepositoryLicense(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_license",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_LICENSE_DESCRIPTION", "Get the license GitHub detected for a repository, with its SPDX identifier, name and the content of the license file. Returns detected false if the repository has no license file or one GitHub couldn't identify.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_LICENSE_USER_TITLE", "Get repository license"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			info, resp, err := getRepositoryLicense(ctx, client, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get repository license", resp, err), nil
+			}
+
+			return MarshalledTextResult(info), nil
+		}
+}
+
+// CheckLicenseCompatibility creates a tool to check whether code under one license can be used in a project
+// under another.
+func CheckLicenseCompatibility(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("check_license_compatibility",
+			mcp.WithDescription(t("TOOL_CHECK_LICENSE_COMPATIBILITY_DESCRIPTION", "Check whether code under a license can be used in a project under target_license, using a built-in matrix of common open source licenses. Give the license of the code as an SPDX identifier, or owner and repo to use the license GitHub detected for that repository. The verdict is compatible, conditional, incompatible or unknown, with a reason. This is a basic check, not legal advice.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CHECK_LICENSE_COMPATIBILITY_USER_TITLE", "Check license compatibility"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("target_license",
+				mcp.Required(),
+				mcp.Description("SPDX identifier of the license of the project the code would be used in, such as MIT or GPL-3.0-only"),
+			),
+			mcp.WithString("license",
+				mcp.Description("SPDX identifier of the license of the code to use. Required unless owner and repo are given."),
+			),
+			mcp.WithString("owner",
+				mcp.Description("Owner of the repository whose code to use, instead of license"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Name of the repository whose code to use, instead of license"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			target, err := RequiredParam[string](request, "target_license")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			license, err := OptionalParam[string](request, "license")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if license == "" {
+				if owner == "" || repo == "" {
+					return mcp.NewToolResultError("either license or owner and repo are required"), nil
+				}
+
+				client, err := getClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				}
+
+				info, resp, err := getRepositoryLicense(ctx, client, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get repository license", resp, err), nil
+				}
+				if !info.Detected {
+					result := LicenseCompatibility{TargetLicense: target, Verdict: LicenseUnknown, Reason: info.Message}
+					if info.Path == "" {
+						// Code without a license is all rights reserved
+						result.Verdict = LicenseIncompatible
+					}
+					return MarshalledTextResult(result), nil
+				}
+				license = info.SPDXID
+			}
+
+			return MarshalledTextResult(checkLicenseCompatibility(license, target)), nil
+		}
+}
@@ -0,0 +1,302 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockRepositoryLicense(spdx, key, name string) *github.RepositoryLicense {
+	return &github.RepositoryLicense{
+		Name:     github.Ptr("LICENSE"),
+		Path:     github.Ptr("LICENSE"),
+		HTMLURL:  github.Ptr("https://github.com/owner/repo/blob/main/LICENSE"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("License text"))),
+		Encoding: github.Ptr("base64"),
+		License: &github.License{
+			Key:    github.Ptr(key),
+			Name:   github.Ptr(name),
+			SPDXID: github.Ptr(spdx),
+		},
+	}
+}
+
+func Test_GetRepositoryLicense(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryLicense(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_license", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedInfo   RepositoryLicenseInfo
+	}{
+		{
+			name: "detected license",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposLicenseByOwnerByRepo,
+					mockRepositoryLicense("MIT", "mit", "MIT License"),
+				),
+			),
+			expectedInfo: RepositoryLicenseInfo{
+				Detected: true,
+				SPDXID:   "MIT",
+				Key:      "mit",
+				Name:     "MIT License",
+				Path:     "LICENSE",
+				HTMLURL:  "https://github.com/owner/repo/blob/main/LICENSE",
+				Content:  "License text",
+			},
+		},
+		{
+			name: "unidentified license file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposLicenseByOwnerByRepo,
+					mockRepositoryLicense("NOASSERTION", "other", "Other"),
+				),
+			),
+			expectedInfo: RepositoryLicenseInfo{
+				Path:    "LICENSE",
+				HTMLURL: "https://github.com/owner/repo/blob/main/LICENSE",
+				Content: "License text",
+				Message: "LICENSE was found but GitHub could not identify the license, read its content",
+			},
+		},
+		{
+			name: "no license",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLicenseByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectedInfo: RepositoryLicenseInfo{
+				Message: "no license file was found in owner/repo, without a license the code can't be reused",
+			},
+		},
+		{
+			name: "API error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLicenseByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get repository license",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryLicense(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var info RepositoryLicenseInfo
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &info))
+			assert.Equal(t, tc.expectedInfo, info)
+		})
+	}
+}
+
+func Test_CheckLicenseCompatibility(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CheckLicenseCompatibility(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "check_license_compatibility", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "license")
+	assert.Contains(t, tool.InputSchema.Properties, "target_license")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"target_license"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	notFound := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposLicenseByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			}),
+		),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       LicenseCompatibility
+	}{
+		{
+			name:         "given license",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"license":        "mit",
+				"target_license": "GPL-3.0",
+			},
+			expected: LicenseCompatibility{
+				License:       "MIT",
+				TargetLicense: "GPL-3.0-only",
+				Verdict:       LicenseCompatible,
+				Reason:        "MIT is permissive, keep its copyright and license notices",
+			},
+		},
+		{
+			name: "license detected for the repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposLicenseByOwnerByRepo,
+					mockRepositoryLicense("GPL-3.0", "gpl-3.0", "GNU General Public License v3.0"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"target_license": "MIT",
+			},
+			expected: LicenseCompatibility{
+				License:       "GPL-3.0-only",
+				TargetLicense: "MIT",
+				Verdict:       LicenseIncompatible,
+				Reason:        "GPL-3.0-only is a strong copyleft license, a project that includes it must be distributed as a whole under GPL-3.0-only",
+			},
+		},
+		{
+			name:         "repository without a license",
+			mockedClient: notFound,
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"target_license": "MIT",
+			},
+			expected: LicenseCompatibility{
+				TargetLicense: "MIT",
+				Verdict:       LicenseIncompatible,
+				Reason:        "no license file was found in owner/repo, without a license the code can't be reused",
+			},
+		},
+		{
+			name:         "neither license nor repository",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"target_license": "MIT",
+			},
+			expectError:    true,
+			expectedErrMsg: "either license or owner and repo are required",
+		},
+		{
+			name:         "missing target license",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"license": "MIT",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: target_license",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CheckLicenseCompatibility(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var compatibility LicenseCompatibility
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &compatibility))
+			assert.Equal(t, tc.expected, compatibility)
+		})
+	}
+}
+
+func Test_checkLicenseCompatibility(t *testing.T) {
+	tests := []struct {
+		license string
+		target  string
+		verdict string
+	}{
+		{"MIT", "Apache-2.0", LicenseCompatible},
+		{"BSD-3-Clause", "AGPL-3.0-only", LicenseCompatible},
+		{"Apache-2.0", "MIT", LicenseCompatible},
+		{"Apache-2.0", "GPL-3.0-or-later", LicenseCompatible},
+		{"Apache-2.0", "GPL-2.0-only", LicenseIncompatible},
+		{"Apache-2.0", "GPL-2.0-or-later", LicenseConditional},
+		{"MPL-2.0", "MIT", LicenseConditional},
+		{"MPL-2.0", "GPL-2.0-only", LicenseCompatible},
+		{"EPL-2.0", "GPL-3.0-only", LicenseConditional},
+		{"LGPL-2.1-only", "GPL-3.0-only", LicenseCompatible},
+		{"LGPL-2.1-only", "LGPL-3.0-only", LicenseConditional},
+		{"LGPL-3.0-only", "GPL-2.0-only", LicenseIncompatible},
+		{"LGPL-3.0-only", "Apache-2.0", LicenseConditional},
+		{"GPL-2.0-only", "GPL-3.0-only", LicenseIncompatible},
+		{"GPL-2.0-or-later", "GPL-3.0-only", LicenseCompatible},
+		{"GPL-3.0-only", "GPL-2.0-or-later", LicenseConditional},
+		{"GPL-3.0-only", "AGPL-3.0-only", LicenseCompatible},
+		{"GPL-2.0-only", "AGPL-3.0-only", LicenseIncompatible},
+		{"GPL-2.0-only", "MIT", LicenseIncompatible},
+		{"AGPL-3.0-only", "GPL-3.0-only", LicenseConditional},
+		{"AGPL-3.0-only", "AGPL-3.0-or-later", LicenseCompatible},
+		{"AGPL-3.0", "LGPL-3.0", LicenseIncompatible},
+		{"gpl-3.0", "GPL-3.0-only", LicenseCompatible},
+		{"WTFPL", "MIT", LicenseUnknown},
+		{"MIT", "Proprietary", LicenseUnknown},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.license+" in "+tc.target, func(t *testing.T) {
+			result := checkLicenseCompatibility(tc.license, tc.target)
+			assert.Equal(t, tc.verdict, result.Verdict, result.Reason)
+			assert.NotEmpty(t, result.Reason)
+		})
+	}
+}
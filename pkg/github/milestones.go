@@ -0,0 +1,309 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// withMilestoneFields adds the parameters shared by create_milestone and update_milestone.
+func withMilestoneFields() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("state",
+			mcp.Description("State of the milestone"),
+			mcp.Enum("open", "closed"),
+		)(tool)
+		mcp.WithString("description",
+			mcp.Description("Description of the milestone"),
+		)(tool)
+		mcp.WithString("due_on",
+			mcp.Description("Due date of the milestone (ISO 8601 timestamp or YYYY-MM-DD)"),
+		)(tool)
+	}
+}
+
+// milestoneFrom reads the parameters of withMilestoneFields into a milestone.
+func milestoneFrom(request mcp.CallToolRequest) (*github.Milestone, error) {
+	state, err := OptionalParam[string](request, "state")
+	if err != nil {
+		return nil, err
+	}
+	description, err := OptionalParam[string](request, "description")
+	if err != nil {
+		return nil, err
+	}
+	dueOn, err := OptionalParam[string](request, "due_on")
+	if err != nil {
+		return nil, err
+	}
+
+	milestone := &github.Milestone{
+		State:       ToStringPtr(state),
+		Description: ToStringPtr(description),
+	}
+	if dueOn != "" {
+		due, err := parseISOTimestamp(dueOn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due_on: %s", err.Error())
+		}
+		milestone.DueOn = &github.Timestamp{Time: due}
+	}
+	return milestone, nil
+}
+
+// ListMilestones creates a tool to list the milestones of a repository.
+func ListMilestones(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_milestones",
+			mcp.WithDescription(t("TOOL_LIST_MILESTONES_DESCRIPTION", "List the milestones of a repository with their due date and number of open and closed issues.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_MILESTONES_USER_TITLE", "List milestones"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter by state, defaults to open"),
+				mcp.Enum("open", "closed", "all"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort by due date or by the share of closed issues, defaults to due_on"),
+				mcp.Enum("due_on", "completeness"),
+			),
+			mcp.WithString("direction",
+				mcp.Description("Sort direction, defaults to asc"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			direction, err := OptionalParam[string](request, "direction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			milestones, resp, err := client.Issues.ListMilestones(ctx, owner, repo, &github.MilestoneListOptions{
+				State:     state,
+				Sort:      sort,
+				Direction: direction,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list milestones of %s/%s", owner, repo), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(milestones), nil
+		}
+}
+
+// CreateMilestone creates a tool to create a milestone in a repository.
+func CreateMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_milestone",
+			mcp.WithDescription(t("TOOL_CREATE_MILESTONE_DESCRIPTION", "Create a milestone in a repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_MILESTONE_USER_TITLE", "Create milestone"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Title of the milestone"),
+			),
+			withMilestoneFields(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestone, err := milestoneFrom(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestone.Title = github.Ptr(title)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Issues.CreateMilestone(ctx, owner, repo, milestone)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to create milestone %s", title), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(created), nil
+		}
+}
+
+// UpdateMilestone creates a tool to edit a milestone.
+func UpdateMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_milestone",
+			mcp.WithDescription(t("TOOL_UPDATE_MILESTONE_DESCRIPTION", "Change the title, state, description or due date of a milestone. Only the given fields change.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_MILESTONE_USER_TITLE", "Update milestone"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("milestone_number",
+				mcp.Required(),
+				mcp.Description("Number of the milestone"),
+			),
+			mcp.WithString("title",
+				mcp.Description("New title of the milestone"),
+			),
+			withMilestoneFields(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "milestone_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := OptionalParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestone, err := milestoneFrom(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestone.Title = ToStringPtr(title)
+			if milestone.Title == nil && milestone.State == nil && milestone.Description == nil && milestone.DueOn == nil {
+				return mcp.NewToolResultError("at least one of title, state, description or due_on is required"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			updated, resp, err := client.Issues.EditMilestone(ctx, owner, repo, number, milestone)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to update milestone %d", number), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(updated), nil
+		}
+}
+
+// DeleteMilestone creates a tool to delete a milestone.
+func DeleteMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_milestone",
+			mcp.WithDescription(t("TOOL_DELETE_MILESTONE_DESCRIPTION", "Delete a milestone. Its issues and pull requests stay, without a milestone.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_MILESTONE_USER_TITLE", "Delete milestone"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("milestone_number",
+				mcp.Required(),
+				mcp.Description("Number of the milestone"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "milestone_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Issues.DeleteMilestone(ctx, owner, repo, number)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to delete milestone %d", number), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("deleted milestone %d", number)), nil
+		}
+}
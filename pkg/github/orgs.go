@@ -0,0 +1,576 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// OrgMember is a member of an organization or team along with their role in it.
+type OrgMember struct {
+	Login   string `json:"login"`
+	ID      int64  `json:"id"`
+	Role    string `json:"role"`
+	HTMLURL string `json:"html_url,omitempty"`
+}
+
+// RepositoryAccess is the access of a team or collaborator to a repository. RoleName is the
+// repository role, such as read, write or a custom role, Permissions what that role allows.
+type RepositoryAccess struct {
+	Name        string          `json:"name"`
+	RoleName    string          `json:"role_name,omitempty"`
+	Permissions map[string]bool `json:"permissions,omitempty"`
+}
+
+// listAllLogins collects the logins of every page of a user listing, failing for listings of more users
+// than listAllPages collects.
+func listAllLogins(ctx context.Context, fetch func(ctx context.Context, opts github.ListOptions) ([]*github.User, *github.Response, error)) (map[string]bool, *github.Response, error) {
+	users, truncated, resp, err := listAllPages(ctx, 0, fetch)
+	if err != nil {
+		return nil, resp, err
+	}
+	if truncated {
+		return nil, nil, fmt.Errorf("more than %d users", MaxPaginatedItems)
+	}
+	logins := make(map[string]bool, len(users))
+	for _, u := range users {
+		logins[u.GetLogin()] = true
+	}
+	return logins, resp, nil
+}
+
+// membersWithRole pairs users with their role. Users whose login is in elevated get elevatedRole,
+// the others role.
+func membersWithRole(users []*github.User, elevated map[string]bool, elevatedRole, role string) []*OrgMember {
+	members := make([]*OrgMember, 0, len(users))
+	for _, u := range users {
+		member := &OrgMember{
+			Login:   u.GetLogin(),
+			ID:      u.GetID(),
+			Role:    role,
+			HTMLURL: u.GetHTMLURL(),
+		}
+		if elevated[u.GetLogin()] {
+			member.Role = elevatedRole
+		}
+		members = append(members, member)
+	}
+	return members
+}
+
+// ListOrgMembers creates a tool to list the members of an organization with their role.
+func ListOrgMembers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_members",
+			mcp.WithDescription(t("TOOL_LIST_ORG_MEMBERS_DESCRIPTION", "List the members of an organization with their role, admin for owners and member otherwise. Members who conceal their membership are only listed for members of the organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_MEMBERS_USER_TITLE", "List organization members"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Only list members with this role"),
+				mcp.Enum("admin", "member"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			users, resp, err := client.Organizations.ListMembers(ctx, org, &github.ListMembersOptions{
+				Role: role,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list members of %s", org), resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			// The listing doesn't carry the role, so without a filter the owners are looked up separately
+			if role != "" {
+				return MarshalledTextResult(membersWithRole(users, nil, "", role)), nil
+			}
+			admins, resp, err := listAllLogins(ctx, func(ctx context.Context, opts github.ListOptions) ([]*github.User, *github.Response, error) {
+				return client.Organizations.ListMembers(ctx, org, &github.ListMembersOptions{Role: "admin", ListOptions: opts})
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list owners of %s", org), resp, err), nil
+			}
+
+			return MarshalledTextResult(membersWithRole(users, admins, "admin", "member")), nil
+		}
+}
+
+// ListTeams creates a tool to list the teams of an organization.
+func ListTeams(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_teams",
+			mcp.WithDescription(t("TOOL_LIST_TEAMS_DESCRIPTION", "List the teams of an organization that are visible to the authenticated user, including their slug, privacy and parent team.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAMS_USER_TITLE", "List teams"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			teams, resp, err := client.Teams.ListTeams(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list teams of %s", org), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(teams), nil
+		}
+}
+
+// ListTeamMembers creates a tool to list the members of a team with their role.
+func ListTeamMembers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_team_members",
+			mcp.WithDescription(t("TOOL_LIST_TEAM_MEMBERS_DESCRIPTION", "List the members of a team, including the members of its child teams, with their role in the team: maintainer or member.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAM_MEMBERS_USER_TITLE", "List team members"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Slug of the team"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Only list members with this role"),
+				mcp.Enum("maintainer", "member"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			users, resp, err := client.Teams.ListTeamMembersBySlug(ctx, org, teamSlug, &github.TeamListTeamMembersOptions{
+				Role: role,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list members of team %s", teamSlug), resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			if role != "" {
+				return MarshalledTextResult(membersWithRole(users, nil, "", role)), nil
+			}
+			maintainers, resp, err := listAllLogins(ctx, func(ctx context.Context, opts github.ListOptions) ([]*github.User, *github.Response, error) {
+				return client.Teams.ListTeamMembersBySlug(ctx, org, teamSlug, &github.TeamListTeamMembersOptions{Role: "maintainer", ListOptions: opts})
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list maintainers of team %s", teamSlug), resp, err), nil
+			}
+
+			return MarshalledTextResult(membersWithRole(users, maintainers, "maintainer", "member")), nil
+		}
+}
+
+// ListTeamRepositories creates a tool to list the repositories a team has access to.
+func ListTeamRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_team_repositories",
+			mcp.WithDescription(t("TOOL_LIST_TEAM_REPOSITORIES_DESCRIPTION", "List the repositories a team has access to, with the role the team has on each of them.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAM_REPOSITORIES_USER_TITLE", "List team repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Slug of the team"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repos, resp, err := client.Teams.ListTeamReposBySlug(ctx, org, teamSlug, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list repositories of team %s", teamSlug), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			access := make([]*RepositoryAccess, 0, len(repos))
+			for _, r := range repos {
+				access = append(access, &RepositoryAccess{
+					Name:        r.GetFullName(),
+					RoleName:    r.GetRoleName(),
+					Permissions: r.Permissions,
+				})
+			}
+
+			return MarshalledTextResult(access), nil
+		}
+}
+
+// ListRepositoryCollaborators creates a tool to list who has access to a repository.
+func ListRepositoryCollaborators(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_collaborators",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_COLLABORATORS_DESCRIPTION", "List the users with access to a repository and their role on it, whether the access is direct, through a team or as an organization owner. Filter by permission, e.g. push, to find who can write to the repository. Requires push access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_COLLABORATORS_USER_TITLE", "List repository collaborators"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("affiliation",
+				mcp.Description("outside for outside collaborators only, direct for users with direct access regardless of organization membership, all by default"),
+				mcp.Enum("outside", "direct", "all"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Only list users with at least this permission"),
+				mcp.Enum("pull", "triage", "push", "maintain", "admin"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			affiliation, err := OptionalParam[string](request, "affiliation")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			users, resp, err := client.Repositories.ListCollaborators(ctx, owner, repo, &github.ListCollaboratorsOptions{
+				Affiliation: affiliation,
+				Permission:  permission,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list collaborators of %s/%s", owner, repo), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			access := make([]*RepositoryAccess, 0, len(users))
+			for _, u := range users {
+				access = append(access, &RepositoryAccess{
+					Name:        u.GetLogin(),
+					RoleName:    u.GetRoleName(),
+					Permissions: u.Permissions,
+				})
+			}
+
+			return MarshalledTextResult(access), nil
+		}
+}
+
+// GetOrgMembership creates a tool to check the membership of a user in an organization and optionally a team.
+func GetOrgMembership(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_membership",
+			mcp.WithDescription(t("TOOL_GET_ORG_MEMBERSHIP_DESCRIPTION", "Check whether a user is a member of an organization, and optionally of one of its teams, with their role and whether the membership is active or an invitation is pending.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_MEMBERSHIP_USER_TITLE", "Get organization membership"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Login of the user"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Description("Slug of a team to check the membership of as well"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := OptionalParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := map[string]any{
+				"org":      org,
+				"username": username,
+			}
+
+			// A 404 means the user is not a member, or their membership is not visible to the caller
+			membership, resp, err := client.Organizations.GetOrgMembership(ctx, username, org)
+			switch {
+			case err == nil:
+				_ = resp.Body.Close()
+				result["org_membership"] = map[string]any{
+					"role":  membership.GetRole(),
+					"state": membership.GetState(),
+				}
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				result["org_membership"] = nil
+			default:
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get membership of %s in %s", username, org), resp, err), nil
+			}
+
+			if teamSlug != "" {
+				membership, resp, err := client.Teams.GetTeamMembershipBySlug(ctx, org, teamSlug, username)
+				switch {
+				case err == nil:
+					_ = resp.Body.Close()
+					result["team_membership"] = map[string]any{
+						"team":  teamSlug,
+						"role":  membership.GetRole(),
+						"state": membership.GetState(),
+					}
+				case resp != nil && resp.StatusCode == http.StatusNotFound:
+					result["team_membership"] = nil
+				default:
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get membership of %s in team %s", username, teamSlug), resp, err), nil
+				}
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// AddTeamMember creates a tool to add a user to a team or change their role in it.
+func AddTeamMember(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_team_member",
+			mcp.WithDescription(t("TOOL_ADD_TEAM_MEMBER_DESCRIPTION", "Add a user to a team, or change the role of a member of the team. A user who is not yet a member of the organization is invited to it, and the team membership stays pending until they accept. Requires owner or team maintainer access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_TEAM_MEMBER_USER_TITLE", "Add team member"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Slug of the team"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Login of the user"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Role of the user in the team, defaults to member"),
+				mcp.Enum("member", "maintainer"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			membership, resp, err := client.Teams.AddTeamMembershipBySlug(ctx, org, teamSlug, username, &github.TeamAddTeamMembershipOptions{
+				Role: role,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to add %s to team %s", username, teamSlug), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"team":     teamSlug,
+				"username": username,
+				"role":     membership.GetRole(),
+				"state":    membership.GetState(),
+			}), nil
+		}
+}
+
+// RemoveTeamMember creates a tool to remove a user from a team.
+func RemoveTeamMember(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_team_member",
+			mcp.WithDescription(t("TOOL_REMOVE_TEAM_MEMBER_DESCRIPTION", "Remove a user from a team, or cancel their pending team invitation. The user stays a member of the organization. Requires owner or team maintainer access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_TEAM_MEMBER_USER_TITLE", "Remove team member"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Slug of the team"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Login of the user"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Teams.RemoveTeamMembershipBySlug(ctx, org, teamSlug, username)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to remove %s from team %s", username, teamSlug), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("removed %s from team %s", username, teamSlug)), nil
+		}
+}
@@ -0,0 +1,475 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgMembers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgMembers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_members", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	members := []*github.User{
+		{Login: github.Ptr("alice"), ID: github.Ptr(int64(1))},
+		{Login: github.Ptr("bob"), ID: github.Ptr(int64(2))},
+	}
+	admins := []*github.User{
+		{Login: github.Ptr("alice"), ID: github.Ptr(int64(1))},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRoles  map[string]string
+	}{
+		{
+			name: "roles of all members",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembersByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Query().Get("role") == "admin" {
+							mockResponse(t, http.StatusOK, admins)(w, r)
+							return
+						}
+						mockResponse(t, http.StatusOK, members)(w, r)
+					}),
+				),
+			),
+			requestArgs:   map[string]interface{}{},
+			expectedRoles: map[string]string{"alice": "admin", "bob": "member"},
+		},
+		{
+			name: "filtered by role",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembersByOrg,
+					expectQueryParams(t, map[string]string{
+						"role":     "admin",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, admins),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"role": "admin",
+			},
+			expectedRoles: map[string]string{"alice": "admin"},
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembersByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "failed to list members of octo-org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgMembers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"org": "octo-org",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned []*OrgMember
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			roles := map[string]string{}
+			for _, m := range returned {
+				roles[m.Login] = m.Role
+			}
+			assert.Equal(t, tc.expectedRoles, roles)
+		})
+	}
+}
+
+func Test_ListTeams(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeams(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_teams", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetOrgsTeamsByOrg,
+			[]*github.Team{
+				{ID: github.Ptr(int64(1)), Slug: github.Ptr("platform"), Privacy: github.Ptr("closed")},
+			},
+		),
+	))
+	_, handler := ListTeams(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org": "octo-org",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*github.Team
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 1)
+	assert.Equal(t, "platform", returned[0].GetSlug())
+}
+
+func Test_ListTeamMembers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeamMembers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_team_members", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("role") == "maintainer" {
+					mockResponse(t, http.StatusOK, []*github.User{{Login: github.Ptr("carol")}})(w, r)
+					return
+				}
+				mockResponse(t, http.StatusOK, []*github.User{{Login: github.Ptr("carol")}, {Login: github.Ptr("dave")}})(w, r)
+			}),
+		),
+	))
+	_, handler := ListTeamMembers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":       "octo-org",
+		"team_slug": "platform",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*OrgMember
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 2)
+	assert.Equal(t, "maintainer", returned[0].Role)
+	assert.Equal(t, "member", returned[1].Role)
+}
+
+func Test_ListTeamRepositories(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeamRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_team_repositories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsTeamsReposByOrgByTeamSlug,
+			expectPath(t, "/orgs/octo-org/teams/platform/repos").andThen(
+				mockResponse(t, http.StatusOK, []*github.Repository{
+					{
+						FullName:    github.Ptr("octo-org/api"),
+						RoleName:    github.Ptr("write"),
+						Permissions: map[string]bool{"pull": true, "push": true, "admin": false},
+					},
+				}),
+			),
+		),
+	))
+	_, handler := ListTeamRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":       "octo-org",
+		"team_slug": "platform",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*RepositoryAccess
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 1)
+	assert.Equal(t, "octo-org/api", returned[0].Name)
+	assert.Equal(t, "write", returned[0].RoleName)
+	assert.True(t, returned[0].Permissions["push"])
+}
+
+func Test_ListRepositoryCollaborators(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryCollaborators(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repository_collaborators", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposCollaboratorsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"permission": "push",
+				"page":       "1",
+				"per_page":   "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.User{
+					{Login: github.Ptr("alice"), RoleName: github.Ptr("admin"), Permissions: map[string]bool{"push": true, "admin": true}},
+					{Login: github.Ptr("bob"), RoleName: github.Ptr("write"), Permissions: map[string]bool{"push": true}},
+				}),
+			),
+		),
+	))
+	_, handler := ListRepositoryCollaborators(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":      "octo-org",
+		"repo":       "api",
+		"permission": "push",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*RepositoryAccess
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 2)
+	assert.Equal(t, "bob", returned[1].Name)
+	assert.Equal(t, "write", returned[1].RoleName)
+}
+
+func Test_GetOrgMembership(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgMembership(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org_membership", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "username"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectOrg      bool
+		expectTeam     bool
+	}{
+		{
+			name: "member of organization and team",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsMembershipsByOrgByUsername,
+					github.Membership{Role: github.Ptr("member"), State: github.Ptr("active")},
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					github.Membership{Role: github.Ptr("maintainer"), State: github.Ptr("active")},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"team_slug": "platform",
+			},
+			expectOrg:  true,
+			expectTeam: true,
+		},
+		{
+			name: "not a member",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembershipsByOrgByUsername,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{},
+		},
+		{
+			name: "forbidden",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembershipsByOrgByUsername,
+					mockResponse(t, http.StatusForbidden, `{"message": "Forbidden"}`),
+				),
+			),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "failed to get membership of alice in octo-org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrgMembership(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"org":      "octo-org",
+				"username": "alice",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			if tc.expectOrg {
+				assert.Equal(t, "member", returned["org_membership"].(map[string]any)["role"])
+			} else {
+				assert.Nil(t, returned["org_membership"])
+			}
+			if tc.expectTeam {
+				assert.Equal(t, "maintainer", returned["team_membership"].(map[string]any)["role"])
+			} else {
+				assert.NotContains(t, returned, "team_membership")
+			}
+		})
+	}
+}
+
+func Test_AddTeamMember(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddTeamMember(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_team_member", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "username"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+			expectRequestBody(t, map[string]any{
+				"role": "maintainer",
+			}).andThen(
+				mockResponse(t, http.StatusOK, github.Membership{Role: github.Ptr("maintainer"), State: github.Ptr("pending")}),
+			),
+		),
+	))
+	_, handler := AddTeamMember(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":       "octo-org",
+		"team_slug": "platform",
+		"username":  "erin",
+		"role":      "maintainer",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "pending", returned["state"])
+	assert.Equal(t, "maintainer", returned["role"])
+}
+
+func Test_RemoveTeamMember(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveTeamMember(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_team_member", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "username"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful removal",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					mockResponse(t, http.StatusNoContent, ""),
+				),
+			),
+		},
+		{
+			name: "not allowed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to remove erin from team platform",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveTeamMember(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "platform",
+				"username":  "erin",
+			}))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "removed erin from team platform", getTextResult(t, result).Text)
+		})
+	}
+}
@@ -0,0 +1,503 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/egress"
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// containerRegistryURL is the GitHub Container registry, which serves the manifests of container packages.
+var containerRegistryURL = "https://ghcr.io"
+
+// maxDeletePackageVersions caps how many versions delete_package_versions deletes in a single call.
+const maxDeletePackageVersions = 100
+
+// manifestMediaTypes are the manifest formats asked from the registry, image indexes first so that
+// multi-platform images are described as such.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+var packageTypes = []string{"npm", "maven", "rubygems", "docker", "nuget", "container"}
+
+// withPackageOwner adds the parameters that locate the owner of packages.
+func withPackageOwner() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Organization or user that owns the packages"),
+		)(tool)
+		mcp.WithString("owner_type",
+			mcp.Required(),
+			mcp.Description("Whether owner is an organization or a user"),
+			mcp.Enum("org", "user"),
+		)(tool)
+		mcp.WithString("package_type",
+			mcp.Required(),
+			mcp.Description("Type of the packages, container for images in the GitHub Container registry"),
+			mcp.Enum(packageTypes...),
+		)(tool)
+	}
+}
+
+// packageOwnerParams reads the parameters of withPackageOwner.
+func packageOwnerParams(request mcp.CallToolRequest) (owner string, isOrg bool, packageType string, err error) {
+	owner, err = RequiredParam[string](request, "owner")
+	if err != nil {
+		return "", false, "", err
+	}
+	ownerType, err := RequiredParam[string](request, "owner_type")
+	if err != nil {
+		return "", false, "", err
+	}
+	if ownerType != "org" && ownerType != "user" {
+		return "", false, "", fmt.Errorf("owner_type must be org or user")
+	}
+	packageType, err = RequiredParam[string](request, "package_type")
+	if err != nil {
+		return "", false, "", err
+	}
+	return owner, ownerType == "org", packageType, nil
+}
+
+// ListPackages creates a tool to list the packages of an organization or user.
+func ListPackages(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_packages",
+			mcp.WithDescription(t("TOOL_LIST_PACKAGES_DESCRIPTION", "List the packages of an organization or user in GitHub Packages, such as the container images in the GitHub Container registry. Requires the read:packages scope.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PACKAGES_USER_TITLE", "List packages"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withPackageOwner(),
+			mcp.WithString("visibility",
+				mcp.Description("Only return packages with this visibility"),
+				mcp.Enum("public", "private", "internal"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, isOrg, packageType, err := packageOwnerParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibility, err := OptionalParam[string](request, "visibility")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.PackageListOptions{
+				PackageType: github.Ptr(packageType),
+				Visibility:  ToStringPtr(visibility),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			var packages []*github.Package
+			var resp *github.Response
+			if isOrg {
+				packages, resp, err = client.Organizations.ListPackages(ctx, owner, opts)
+			} else {
+				packages, resp, err = client.Users.ListPackages(ctx, owner, opts)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list packages of %s", owner), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(packages)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// PackageVersionSummary is a version of a package. For container images the name is the digest of the
+// image and the tags are the ones pointing at it, an untagged version is usually a stale one.
+type PackageVersionSummary struct {
+	ID        int64             `json:"id"`
+	Name      string            `json:"name"`
+	Tags      []string          `json:"tags,omitempty"`
+	CreatedAt *github.Timestamp `json:"created_at,omitempty"`
+	UpdatedAt *github.Timestamp `json:"updated_at,omitempty"`
+	DeletedAt *github.Timestamp `json:"deleted_at,omitempty"`
+	HTMLURL   string            `json:"html_url,omitempty"`
+}
+
+// ListPackageVersions creates a tool to list the versions of a package.
+func ListPackageVersions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_package_versions",
+			mcp.WithDescription(t("TOOL_LIST_PACKAGE_VERSIONS_DESCRIPTION", "List the versions of a package, newest first, with the tags of each container image version. Requires the read:packages scope.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PACKAGE_VERSIONS_USER_TITLE", "List package versions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withPackageOwner(),
+			mcp.WithString("package_name",
+				mcp.Required(),
+				mcp.Description("Name of the package"),
+			),
+			mcp.WithString("state",
+				mcp.Description("active versions, or deleted ones that can still be restored. Defaults to active"),
+				mcp.Enum("active", "deleted"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, isOrg, packageType, err := packageOwnerParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := RequiredParam[string](request, "package_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.PackageListOptions{
+				State: ToStringPtr(state),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			var versions []*github.PackageVersion
+			var resp *github.Response
+			if isOrg {
+				versions, resp, err = client.Organizations.PackageGetAllVersions(ctx, owner, packageType, packageName, opts)
+			} else {
+				versions, resp, err = client.Users.PackageGetAllVersions(ctx, owner, packageType, packageName, opts)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list versions of package %s", packageName), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			summaries := make([]*PackageVersionSummary, 0, len(versions))
+			for _, v := range versions {
+				summary := &PackageVersionSummary{
+					ID:        v.GetID(),
+					Name:      v.GetName(),
+					CreatedAt: v.CreatedAt,
+					UpdatedAt: v.UpdatedAt,
+					DeletedAt: v.DeletedAt,
+					HTMLURL:   v.GetHTMLURL(),
+				}
+				var metadata github.PackageMetadata
+				if len(v.Metadata) > 0 && json.Unmarshal(v.Metadata, &metadata) == nil && metadata.Container != nil {
+					summary.Tags = metadata.Container.Tags
+				}
+				summaries = append(summaries, summary)
+			}
+
+			return MarshalledTextResult(summaries), nil
+		}
+}
+
+// ContainerManifest describes a container image manifest. An image index lists the images of each platform,
+// an image manifest the config and layers of a single image.
+type ContainerManifest struct {
+	Reference    string               `json:"reference"`
+	Digest       string               `json:"digest,omitempty"`
+	MediaType    string               `json:"media_type"`
+	Platforms    []*ContainerPlatform `json:"platforms,omitempty"`
+	ConfigDigest string               `json:"config_digest,omitempty"`
+	Layers       []*ContainerLayer    `json:"layers,omitempty"`
+	TotalSize    int64                `json:"total_size,omitempty"`
+	Annotations  map[string]string    `json:"annotations,omitempty"`
+	Subject      *ContainerDescriptor `json:"subject,omitempty"`
+}
+
+// ContainerPlatform is the image of one platform in an image index.
+type ContainerPlatform struct {
+	Digest       string `json:"digest"`
+	OS           string `json:"os,omitempty"`
+	Architecture string `json:"architecture,omitempty"`
+	Variant      string `json:"variant,omitempty"`
+	Size         int64  `json:"size,omitempty"`
+}
+
+// ContainerLayer is a layer of an image.
+type ContainerLayer struct {
+	Digest    string `json:"digest"`
+	MediaType string `json:"media_type"`
+	Size      int64  `json:"size"`
+}
+
+// ContainerDescriptor references another manifest, such as the image an attestation is about.
+type ContainerDescriptor struct {
+	Digest    string `json:"digest"`
+	MediaType string `json:"media_type,omitempty"`
+}
+
+// registryManifest is the part of the OCI and Docker manifest formats that ContainerManifest reports.
+type registryManifest struct {
+	MediaType string `json:"mediaType"`
+	Manifests []struct {
+		Digest   string `json:"digest"`
+		Size     int64  `json:"size"`
+		Platform *struct {
+			OS           string `json:"os"`
+			Architecture string `json:"architecture"`
+			Variant      string `json:"variant"`
+		} `json:"platform"`
+	} `json:"manifests"`
+	Config *struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+	Layers []struct {
+		Digest    string `json:"digest"`
+		MediaType string `json:"mediaType"`
+		Size      int64  `json:"size"`
+	} `json:"layers"`
+	Annotations map[string]string `json:"annotations"`
+	Subject     *struct {
+		Digest    string `json:"digest"`
+		MediaType string `json:"mediaType"`
+	} `json:"subject"`
+}
+
+// fetchContainerManifest gets a manifest from the container registry. The registry hands out anonymous
+// pull tokens for public images only, the GitHub credentials of the server are never sent to it.
+func fetchContainerManifest(ctx context.Context, image, reference string) (*ContainerManifest, error) {
+	httpClient := egress.HTTPClient(ctx)
+
+	tokenURL := fmt.Sprintf("%s/token?scope=%s", containerRegistryURL, url.QueryEscape("repository:"+image+":pull"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := httpClient.Do(req) //nolint:gosec // the registry URL is fixed, only the image and reference vary
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a registry token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get a registry token for %s: status %d, only public images can be inspected", image, resp.StatusCode)
+	}
+	var token struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("failed to decode registry token: %w", err)
+	}
+
+	manifestURL := fmt.Sprintf("%s/v2/%s/manifests/%s", containerRegistryURL, image, url.PathEscape(reference))
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+	resp, err = httpClient.Do(req) //nolint:gosec // the registry URL is fixed, only the image and reference vary
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("manifest %s of %s not found", reference, image)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("not allowed to pull %s, only public images can be inspected", image)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("failed to get manifest %s of %s: status %d: %s", reference, image, resp.StatusCode, string(body))
+	}
+
+	var m registryManifest
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	manifest := &ContainerManifest{
+		Reference:   reference,
+		Digest:      resp.Header.Get("Docker-Content-Digest"),
+		MediaType:   m.MediaType,
+		Annotations: m.Annotations,
+	}
+	if manifest.MediaType == "" {
+		manifest.MediaType = resp.Header.Get("Content-Type")
+	}
+	for _, p := range m.Manifests {
+		platform := &ContainerPlatform{Digest: p.Digest, Size: p.Size}
+		if p.Platform != nil {
+			platform.OS = p.Platform.OS
+			platform.Architecture = p.Platform.Architecture
+			platform.Variant = p.Platform.Variant
+		}
+		manifest.Platforms = append(manifest.Platforms, platform)
+	}
+	if m.Config != nil {
+		manifest.ConfigDigest = m.Config.Digest
+	}
+	for _, l := range m.Layers {
+		manifest.Layers = append(manifest.Layers, &ContainerLayer{Digest: l.Digest, MediaType: l.MediaType, Size: l.Size})
+		manifest.TotalSize += l.Size
+	}
+	if m.Subject != nil {
+		manifest.Subject = &ContainerDescriptor{Digest: m.Subject.Digest, MediaType: m.Subject.MediaType}
+	}
+	return manifest, nil
+}
+
+// GetContainerManifest creates a tool to get the manifest of a container image.
+func GetContainerManifest(t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_container_manifest",
+			mcp.WithDescription(t("TOOL_GET_CONTAINER_MANIFEST_DESCRIPTION", "Get the manifest of a public container image in the GitHub Container registry (ghcr.io) by tag or digest: the platforms of a multi-platform image, or the config, layers and total size of a single image.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CONTAINER_MANIFEST_USER_TITLE", "Get container image manifest"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Organization or user that owns the image"),
+			),
+			mcp.WithString("package_name",
+				mcp.Required(),
+				mcp.Description("Name of the container package, e.g. my-app for ghcr.io/owner/my-app"),
+			),
+			mcp.WithString("reference",
+				mcp.Required(),
+				mcp.Description("Tag, such as latest, or digest, such as sha256:..., of the image"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := RequiredParam[string](request, "package_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reference, err := RequiredParam[string](request, "reference")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Image names in the registry are lowercase
+			image := strings.ToLower(owner + "/" + packageName)
+			manifest, err := fetchContainerManifest(ctx, image, reference)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return MarshalledTextResult(manifest), nil
+		}
+}
+
+// DeletePackageVersions creates a tool to delete versions of a package.
+func DeletePackageVersions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_package_versions",
+			mcp.WithDescription(t("TOOL_DELETE_PACKAGE_VERSIONS_DESCRIPTION", fmt.Sprintf("Delete up to %d versions of a package, such as stale untagged container images. Deleted versions can be restored for 30 days. The last version of a package can't be deleted. Requires the delete:packages scope and admin access to the package.", maxDeletePackageVersions))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_PACKAGE_VERSIONS_USER_TITLE", "Delete package versions"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			withPackageOwner(),
+			mcp.WithString("package_name",
+				mcp.Required(),
+				mcp.Description("Name of the package"),
+			),
+			mcp.WithArray("version_ids",
+				mcp.Required(),
+				mcp.Description("IDs of the versions to delete, as returned by list_package_versions"),
+				mcp.Items(map[string]any{"type": "number"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, isOrg, packageType, err := packageOwnerParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := RequiredParam[string](request, "package_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rawIDs, ok := request.GetArguments()["version_ids"].([]any)
+			if !ok || len(rawIDs) == 0 {
+				return mcp.NewToolResultError("missing required parameter: version_ids"), nil
+			}
+			if len(rawIDs) > maxDeletePackageVersions {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d versions can be deleted at once", maxDeletePackageVersions)), nil
+			}
+			ids := make([]int64, 0, len(rawIDs))
+			for _, raw := range rawIDs {
+				id, ok := raw.(float64)
+				if !ok || id <= 0 || id != float64(int64(id)) {
+					return mcp.NewToolResultError(fmt.Sprintf("version_ids must be positive integers, got %v", raw)), nil
+				}
+				ids = append(ids, int64(id))
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			deleted := []int64{}
+			failed := map[int64]string{}
+			for _, id := range ids {
+				var resp *github.Response
+				if isOrg {
+					resp, err = client.Organizations.PackageDeleteVersion(ctx, owner, packageType, packageName, id)
+				} else {
+					resp, err = client.Users.PackageDeleteVersion(ctx, owner, packageType, packageName, id)
+				}
+				if err != nil {
+					_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, fmt.Sprintf("failed to delete version %d of package %s", id, packageName), resp, err)
+					failed[id] = err.Error()
+					continue
+				}
+				_ = resp.Body.Close()
+				deleted = append(deleted, id)
+			}
+
+			result := map[string]any{
+				"deleted": deleted,
+			}
+			if len(failed) > 0 {
+				result["failed"] = failed
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,400 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListPackages(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPackages(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_packages", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "owner_type", "package_type"})
+
+	packages := []*github.Package{
+		{ID: github.Ptr(int64(1)), Name: github.Ptr("app"), PackageType: github.Ptr("container")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "organization packages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesByOrg,
+					expectQueryParams(t, map[string]string{
+						"package_type": "container",
+						"visibility":   "private",
+						"page":         "1",
+						"per_page":     "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, packages),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner_type": "org",
+				"visibility": "private",
+			},
+		},
+		{
+			name: "user packages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersPackagesByUsername,
+					expectPath(t, "/users/octo/packages").andThen(
+						mockResponse(t, http.StatusOK, packages),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner_type": "user",
+			},
+		},
+		{
+			name:         "invalid owner type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner_type": "enterprise",
+			},
+			expectError:    true,
+			expectedErrMsg: "owner_type must be org or user",
+		},
+		{
+			name: "missing scope",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesByOrg,
+					mockResponse(t, http.StatusForbidden, `{"message": "You need at least read:packages scope to list packages."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner_type": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list packages of octo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPackages(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner":        "octo",
+				"package_type": "container",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned []*github.Package
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, "app", returned[0].GetName())
+		})
+	}
+}
+
+func Test_ListPackageVersions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPackageVersions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_package_versions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "owner_type", "package_type", "package_name"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsPackagesVersionsByOrgByPackageTypeByPackageName,
+			expectQueryParams(t, map[string]string{
+				"state":    "active",
+				"page":     "1",
+				"per_page": "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.PackageVersion{
+					{
+						ID:       github.Ptr(int64(2)),
+						Name:     github.Ptr("sha256:bbb"),
+						Metadata: json.RawMessage(`{"package_type":"container","container":{"tags":["latest","v2"]}}`),
+					},
+					{
+						ID:       github.Ptr(int64(1)),
+						Name:     github.Ptr("sha256:aaa"),
+						Metadata: json.RawMessage(`{"package_type":"container","container":{"tags":[]}}`),
+					},
+				}),
+			),
+		),
+	))
+	_, handler := ListPackageVersions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":        "octo-org",
+		"owner_type":   "org",
+		"package_type": "container",
+		"package_name": "app",
+		"state":        "active",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*PackageVersionSummary
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 2)
+	assert.Equal(t, []string{"latest", "v2"}, returned[0].Tags)
+	assert.Equal(t, "sha256:aaa", returned[1].Name)
+	assert.Empty(t, returned[1].Tags)
+}
+
+func Test_GetContainerManifest(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetContainerManifest(translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_container_manifest", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "package_name", "reference"})
+
+	manifests := map[string]string{
+		"latest": `{
+			"mediaType": "application/vnd.oci.image.index.v1+json",
+			"manifests": [
+				{"digest": "sha256:amd", "size": 100, "platform": {"os": "linux", "architecture": "amd64"}},
+				{"digest": "sha256:arm", "size": 100, "platform": {"os": "linux", "architecture": "arm64", "variant": "v8"}}
+			]
+		}`,
+		"sha256:amd": `{
+			"mediaType": "application/vnd.oci.image.manifest.v1+json",
+			"config": {"digest": "sha256:cfg"},
+			"layers": [
+				{"digest": "sha256:l1", "mediaType": "application/vnd.oci.image.layer.v1.tar+gzip", "size": 1000},
+				{"digest": "sha256:l2", "mediaType": "application/vnd.oci.image.layer.v1.tar+gzip", "size": 234}
+			],
+			"annotations": {"org.opencontainers.image.source": "https://github.com/octo-org/app"}
+		}`,
+	}
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if r.URL.Query().Get("scope") != "repository:octo-org/app:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token": "anonymous"}`))
+		case "/v2/octo-org/app/manifests/latest", "/v2/octo-org/app/manifests/sha256:amd":
+			if r.Header.Get("Authorization") != "Bearer anonymous" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			ref := r.URL.Path[len("/v2/octo-org/app/manifests/"):]
+			w.Header().Set("Docker-Content-Digest", "sha256:"+ref)
+			_, _ = w.Write([]byte(manifests[ref]))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer registry.Close()
+	originalURL := containerRegistryURL
+	containerRegistryURL = registry.URL
+	defer func() { containerRegistryURL = originalURL }()
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		check          func(t *testing.T, manifest *ContainerManifest)
+	}{
+		{
+			name: "multi-platform image",
+			requestArgs: map[string]interface{}{
+				"owner":        "Octo-Org",
+				"package_name": "app",
+				"reference":    "latest",
+			},
+			check: func(t *testing.T, manifest *ContainerManifest) {
+				assert.Equal(t, "application/vnd.oci.image.index.v1+json", manifest.MediaType)
+				require.Len(t, manifest.Platforms, 2)
+				assert.Equal(t, "arm64", manifest.Platforms[1].Architecture)
+				assert.Equal(t, "v8", manifest.Platforms[1].Variant)
+			},
+		},
+		{
+			name: "single image",
+			requestArgs: map[string]interface{}{
+				"owner":        "octo-org",
+				"package_name": "app",
+				"reference":    "sha256:amd",
+			},
+			check: func(t *testing.T, manifest *ContainerManifest) {
+				assert.Equal(t, "sha256:cfg", manifest.ConfigDigest)
+				assert.Len(t, manifest.Layers, 2)
+				assert.Equal(t, int64(1234), manifest.TotalSize)
+				assert.Equal(t, "https://github.com/octo-org/app", manifest.Annotations["org.opencontainers.image.source"])
+			},
+		},
+		{
+			name: "private image",
+			requestArgs: map[string]interface{}{
+				"owner":        "octo-org",
+				"package_name": "secret",
+				"reference":    "latest",
+			},
+			expectError:    true,
+			expectedErrMsg: "only public images can be inspected",
+		},
+		{
+			name: "unknown tag",
+			requestArgs: map[string]interface{}{
+				"owner":        "octo-org",
+				"package_name": "app",
+				"reference":    "v9",
+			},
+			expectError:    true,
+			expectedErrMsg: "manifest v9 of octo-org/app not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetContainerManifest(translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var manifest ContainerManifest
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &manifest))
+			tc.check(t, &manifest)
+		})
+	}
+}
+
+func Test_DeletePackageVersions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeletePackageVersions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_package_versions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "owner_type", "package_type", "package_name", "version_ids"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		versionIDs     []any
+		expectError    bool
+		expectedErrMsg string
+		expectDeleted  []int64
+		expectFailed   []string
+	}{
+		{
+			name: "partial deletion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteUsersPackagesVersionsByUsernameByPackageTypeByPackageNameByPackageVersionId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path == "/users/octo/packages/container/app/versions/2" {
+							w.WriteHeader(http.StatusBadRequest)
+							_, _ = w.Write([]byte(`{"message": "You cannot delete the last tagged version of a package."}`))
+							return
+						}
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			versionIDs:    []any{float64(1), float64(2), float64(3)},
+			expectDeleted: []int64{1, 3},
+			expectFailed:  []string{"2"},
+		},
+		{
+			name:           "invalid version id",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			versionIDs:     []any{float64(1), "abc"},
+			expectError:    true,
+			expectedErrMsg: "version_ids must be positive integers, got abc",
+		},
+		{
+			name:           "no version ids",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			versionIDs:     []any{},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: version_ids",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeletePackageVersions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":        "octo",
+				"owner_type":   "user",
+				"package_type": "container",
+				"package_name": "app",
+				"version_ids":  tc.versionIDs,
+			}))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned struct {
+				Deleted []int64           `json:"deleted"`
+				Failed  map[string]string `json:"failed"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectDeleted, returned.Deleted)
+			failed := make([]string, 0, len(returned.Failed))
+			for id := range returned.Failed {
+				failed = append(failed, id)
+			}
+			assert.ElementsMatch(t, tc.expectFailed, failed)
+		})
+	}
+}
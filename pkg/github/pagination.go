@@ -0,0 +1,127 @@
+package github
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MaxPaginatedItems caps how many items listAllPages collects across all pages.
+const MaxPaginatedItems = 1000
+
+// maxConcurrentPages is how many pages listAllPages fetches at once. A few concurrent requests can run into
+// the secondary rate limits of GitHub, which the ratelimit.Transport that every client of the server goes
+// through handles: it holds back the requests of the token until the Retry-After of the limit, then
+// retries them.
+const maxConcurrentPages = 4
+
+// pageSize is the number of items per page that listAllPages asks for, the most the REST API allows.
+const pageSize = 100
+
+// WithAllPages adds the allPages parameter to a list tool, whose handler fetches every page with
+// listAllPages when it is set.
+func WithAllPages() mcp.ToolOption {
+	return mcp.WithBoolean("allPages",
+		mcp.Description("Fetch every page, up to 1000 items, instead of only the one given by page and perPage. The result is then an object with the items and whether they were truncated"),
+	)
+}
+
+// listAllPages collects the items of every page of a REST listing, up to maxItems, or
+// MaxPaginatedItems if it is zero. The first page tells from its Link header how many pages there are,
+// and the pages after it are then fetched concurrently and merged in order. Listings whose Link header
+// only names the next page are fetched one page after the other. It reports whether items were left
+// out, and returns the response of the first page.
+func listAllPages[T any](ctx context.Context, maxItems int, fetch func(ctx context.Context, opts github.ListOptions) ([]T, *github.Response, error)) ([]T, bool, *github.Response, error) {
+	if maxItems <= 0 || maxItems > MaxPaginatedItems {
+		maxItems = MaxPaginatedItems
+	}
+	perPage := min(maxItems, pageSize)
+	items, first, err := fetch(ctx, github.ListOptions{Page: 1, PerPage: perPage})
+	if err != nil {
+		return nil, false, first, err
+	}
+	_ = first.Body.Close()
+
+	truncated := false
+	switch {
+	case first.NextPage == 0:
+	case first.LastPage == 0:
+		next := first.NextPage
+		for next != 0 && len(items) < maxItems {
+			page, resp, err := fetch(ctx, github.ListOptions{Page: next, PerPage: perPage})
+			if err != nil {
+				return nil, false, resp, err
+			}
+			_ = resp.Body.Close()
+			items = append(items, page...)
+			next = resp.NextPage
+		}
+		truncated = next != 0
+	default:
+		lastPage := min(first.LastPage, (maxItems+perPage-1)/perPage)
+		pages, resp, err := fetchPages(ctx, first.NextPage, lastPage, perPage, fetch)
+		if err != nil {
+			return nil, false, resp, err
+		}
+		for _, page := range pages {
+			items = append(items, page...)
+		}
+		truncated = lastPage < first.LastPage
+	}
+	if len(items) > maxItems {
+		items, truncated = items[:maxItems], true
+	}
+	return items, truncated, first, nil
+}
+
+// fetchPages fetches the pages from firstPage to lastPage with up to maxConcurrentPages workers, and
+// returns them in order. It stops at the first page that fails, and returns its response and error.
+func fetchPages[T any](ctx context.Context, firstPage, lastPage, perPage int, fetch func(ctx context.Context, opts github.ListOptions) ([]T, *github.Response, error)) ([][]T, *github.Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([][]T, lastPage-firstPage+1)
+	numbers := make(chan int)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var failed *github.Response
+	var failure error
+	for range min(maxConcurrentPages, len(pages)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for number := range numbers {
+				page, resp, err := fetch(ctx, github.ListOptions{Page: number, PerPage: perPage})
+				if err != nil {
+					once.Do(func() {
+						failed, failure = resp, err
+						cancel()
+					})
+					continue
+				}
+				_ = resp.Body.Close()
+				pages[number-firstPage] = page
+			}
+		}()
+	}
+send:
+	for number := firstPage; number <= lastPage; number++ {
+		select {
+		case numbers <- number:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(numbers)
+	wg.Wait()
+
+	if failure != nil {
+		return nil, failed, failure
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	return pages, nil, nil
+}
@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePages serves pages of numbered items, with Link headers that name the last page unless
+// withoutLastPage is set.
+type fakePages struct {
+	total           int
+	withoutLastPage bool
+	failPage        int
+
+	mu       sync.Mutex
+	inFlight int
+	maxIn    int
+	fetched  []int
+}
+
+func (f *fakePages) fetch(_ context.Context, opts github.ListOptions) ([]int, *github.Response, error) {
+	f.mu.Lock()
+	f.inFlight++
+	f.maxIn = max(f.maxIn, f.inFlight)
+	f.fetched = append(f.fetched, opts.Page)
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.inFlight--
+		f.mu.Unlock()
+	}()
+
+	resp := &github.Response{Response: &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}}
+	if opts.Page == f.failPage {
+		resp.StatusCode = http.StatusBadGateway
+		return nil, resp, errors.New("bad gateway")
+	}
+	lastPage := (f.total + opts.PerPage - 1) / opts.PerPage
+	var items []int
+	for i := (opts.Page - 1) * opts.PerPage; i < min(opts.Page*opts.PerPage, f.total); i++ {
+		items = append(items, i)
+	}
+	if opts.Page < lastPage {
+		resp.NextPage = opts.Page + 1
+		if !f.withoutLastPage {
+			resp.LastPage = lastPage
+		}
+	}
+	return items, resp, nil
+}
+
+func sequence(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+func Test_listAllPages(t *testing.T) {
+	t.Run("single page", func(t *testing.T) {
+		pages := &fakePages{total: 42}
+		items, truncated, resp, err := listAllPages(context.Background(), 0, pages.fetch)
+		require.NoError(t, err)
+		assert.Equal(t, sequence(42), items)
+		assert.False(t, truncated)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []int{1}, pages.fetched)
+	})
+
+	t.Run("pages fetched concurrently in order", func(t *testing.T) {
+		pages := &fakePages{total: 950}
+		items, truncated, _, err := listAllPages(context.Background(), 0, pages.fetch)
+		require.NoError(t, err)
+		assert.Equal(t, sequence(950), items)
+		assert.False(t, truncated)
+		assert.Len(t, pages.fetched, 10)
+		assert.LessOrEqual(t, pages.maxIn, maxConcurrentPages)
+	})
+
+	t.Run("capped at the maximum", func(t *testing.T) {
+		pages := &fakePages{total: 5000}
+		items, truncated, _, err := listAllPages(context.Background(), 0, pages.fetch)
+		require.NoError(t, err)
+		assert.Equal(t, sequence(MaxPaginatedItems), items)
+		assert.True(t, truncated)
+		assert.Len(t, pages.fetched, MaxPaginatedItems/pageSize, "pages past the cap aren't fetched")
+	})
+
+	t.Run("capped at fewer items", func(t *testing.T) {
+		pages := &fakePages{total: 500}
+		items, truncated, _, err := listAllPages(context.Background(), 150, pages.fetch)
+		require.NoError(t, err)
+		assert.Equal(t, sequence(150), items)
+		assert.True(t, truncated)
+	})
+
+	t.Run("exactly the maximum", func(t *testing.T) {
+		pages := &fakePages{total: 200}
+		items, truncated, _, err := listAllPages(context.Background(), 200, pages.fetch)
+		require.NoError(t, err)
+		assert.Len(t, items, 200)
+		assert.False(t, truncated)
+	})
+
+	t.Run("pages without a last page fetched one after the other", func(t *testing.T) {
+		pages := &fakePages{total: 350, withoutLastPage: true}
+		items, truncated, _, err := listAllPages(context.Background(), 0, pages.fetch)
+		require.NoError(t, err)
+		assert.Equal(t, sequence(350), items)
+		assert.False(t, truncated)
+		assert.Equal(t, []int{1, 2, 3, 4}, pages.fetched)
+		assert.Equal(t, 1, pages.maxIn)
+	})
+
+	t.Run("failed page", func(t *testing.T) {
+		pages := &fakePages{total: 950, failPage: 5}
+		_, _, resp, err := listAllPages(context.Background(), 0, pages.fetch)
+		require.EqualError(t, err, "bad gateway")
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	})
+
+	t.Run("failed first page", func(t *testing.T) {
+		pages := &fakePages{total: 950, failPage: 1}
+		_, _, resp, err := listAllPages(context.Background(), 0, pages.fetch)
+		require.EqualError(t, err, "bad gateway")
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	})
+}
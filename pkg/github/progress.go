@@ -0,0 +1,81 @@
+package github
+
+import (
+	"context"
+	"io"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// methodNotificationProgress is the MCP method of progress notifications.
+const methodNotificationProgress = "notifications/progress"
+
+// progressDownloadStep is how many downloaded bytes are reported at once.
+const progressDownloadStep = 1 << 20
+
+// progressReporter sends progress notifications for a tool call. Clients ask for them by giving a progress
+// token in the request metadata; without one, or outside of an MCP server, reporting does nothing. A nil
+// reporter is valid and does nothing as well.
+type progressReporter struct {
+	ctx   context.Context
+	srv   *server.MCPServer
+	token mcp.ProgressToken
+}
+
+// newProgressReporter returns the reporter of a tool call.
+func newProgressReporter(ctx context.Context, request mcp.CallToolRequest) *progressReporter {
+	p := &progressReporter{ctx: ctx, srv: server.ServerFromContext(ctx)}
+	if request.Params.Meta != nil {
+		p.token = request.Params.Meta.ProgressToken
+	}
+	return p
+}
+
+// report notifies the client of the progress so far. A total of 0 means the total is unknown.
+func (p *progressReporter) report(progress, total float64, message string) {
+	if p == nil || p.srv == nil || p.token == nil {
+		return
+	}
+	params := map[string]any{
+		"progressToken": p.token,
+		"progress":      progress,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	// Progress is best effort, a client that can't be notified still gets the result
+	_ = p.srv.SendNotificationToClient(p.ctx, methodNotificationProgress, params)
+}
+
+// reader wraps r to report the bytes read from it, every progressDownloadStep bytes and at the end. A
+// total of 0 means the size is unknown.
+func (p *progressReporter) reader(r io.Reader, total int64, message string) io.Reader {
+	if p == nil || p.srv == nil || p.token == nil {
+		return r
+	}
+	return &progressReader{r: r, p: p, total: total, message: message}
+}
+
+// progressReader reports the progress of reading a download.
+type progressReader struct {
+	r        io.Reader
+	p        *progressReporter
+	total    int64
+	message  string
+	read     int64
+	reported int64
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.read += int64(n)
+	if r.read-r.reported >= progressDownloadStep || (err == io.EOF && r.read > r.reported) {
+		r.reported = r.read
+		r.p.report(float64(r.read), float64(max(r.total, 0)), r.message)
+	}
+	return n, err
+}
@@ -0,0 +1,98 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// progressSession is a client session that keeps the notifications it is sent.
+type progressSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *progressSession) Initialize()       {}
+func (s *progressSession) Initialized() bool { return true }
+func (s *progressSession) SessionID() string { return "progress" }
+func (s *progressSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+// callToolWithProgress calls a tool through an MCP server with a progress token, and returns its result
+// and the parameters of the progress notifications it sent.
+func callToolWithProgress(t *testing.T, tool mcp.Tool, handler server.ToolHandlerFunc, args map[string]any) (mcp.CallToolResult, []map[string]any) {
+	t.Helper()
+	srv := server.NewMCPServer("test", "1.0.0")
+	srv.AddTool(tool, handler)
+	session := &progressSession{notifications: make(chan mcp.JSONRPCNotification, 100)}
+	ctx := srv.WithContext(context.Background(), session)
+
+	message, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tools/call",
+		"params": map[string]any{
+			"name":      tool.Name,
+			"arguments": args,
+			"_meta":     map[string]any{"progressToken": "token"},
+		},
+	})
+	require.NoError(t, err)
+	response, ok := srv.HandleMessage(ctx, message).(mcp.JSONRPCResponse)
+	require.True(t, ok)
+	result, ok := response.Result.(mcp.CallToolResult)
+	require.True(t, ok)
+
+	var notifications []map[string]any
+	for len(session.notifications) > 0 {
+		notification := <-session.notifications
+		require.Equal(t, methodNotificationProgress, notification.Method)
+		assert.Equal(t, "token", notification.Params.AdditionalFields["progressToken"])
+		notifications = append(notifications, notification.Params.AdditionalFields)
+	}
+	return result, notifications
+}
+
+func Test_ProgressReporter(t *testing.T) {
+	tool := mcp.NewTool("report")
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		progress := newProgressReporter(ctx, request)
+		progress.report(1, 2, "Halfway")
+		progress.report(2, 0, "")
+		_, err := io.Copy(io.Discard, progress.reader(strings.NewReader(strings.Repeat("a", progressDownloadStep+1)), 0, "Reading"))
+		require.NoError(t, err)
+		return mcp.NewToolResultText("done"), nil
+	}
+
+	t.Run("reports with a progress token", func(t *testing.T) {
+		_, notifications := callToolWithProgress(t, tool, handler, nil)
+		require.Len(t, notifications, 4)
+		assert.Equal(t, float64(1), notifications[0]["progress"])
+		assert.Equal(t, float64(2), notifications[0]["total"])
+		assert.Equal(t, "Halfway", notifications[0]["message"])
+		assert.NotContains(t, notifications[1], "total")
+		assert.NotContains(t, notifications[1], "message")
+		assert.Equal(t, float64(progressDownloadStep), notifications[2]["progress"])
+		assert.Equal(t, float64(progressDownloadStep+1), notifications[3]["progress"])
+		assert.Equal(t, "Reading", notifications[3]["message"])
+	})
+
+	t.Run("does nothing without a progress token or server", func(t *testing.T) {
+		progress := newProgressReporter(context.Background(), createMCPRequest(nil))
+		progress.report(1, 1, "ignored")
+		r := bytes.NewReader(nil)
+		assert.Same(t, r, progress.reader(r, 0, "ignored"))
+
+		var none *progressReporter
+		none.report(1, 1, "ignored")
+		assert.Same(t, r, none.reader(r, 0, "ignored"))
+	})
+}
@@ -0,0 +1,706 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// maxProjectFields is the most fields a project can have, so they are all listed in one page.
+	maxProjectFields = 50
+	// maxProjectItemFieldValues is how many field values are listed per item.
+	maxProjectItemFieldValues = 50
+)
+
+// orgProjectOwnerQuery and userProjectOwnerQuery query the same selection of an organization or a user.
+// Both alias the owner, so the selection is read from Owner either way.
+type orgProjectOwnerQuery[T any] struct {
+	Owner T `graphql:"owner: organization(login: $owner)"`
+}
+
+type userProjectOwnerQuery[T any] struct {
+	Owner T `graphql:"owner: user(login: $owner)"`
+}
+
+// queryProjectOwner runs the selection T on the organization or user named by the $owner variable.
+func queryProjectOwner[T any](ctx context.Context, client *githubv4.Client, ownerType string, vars map[string]any) (*T, error) {
+	switch ownerType {
+	case "org":
+		var q orgProjectOwnerQuery[T]
+		if err := client.Query(ctx, &q, vars); err != nil {
+			return nil, err
+		}
+		return &q.Owner, nil
+	case "user":
+		var q userProjectOwnerQuery[T]
+		if err := client.Query(ctx, &q, vars); err != nil {
+			return nil, err
+		}
+		return &q.Owner, nil
+	default:
+		return nil, fmt.Errorf("owner_type must be org or user, got %q", ownerType)
+	}
+}
+
+// projectIDQuery selects the node ID of a project, which the project mutations take.
+type projectIDQuery struct {
+	ProjectV2 struct {
+		ID githubv4.ID
+	} `graphql:"projectV2(number: $projectNumber)"`
+}
+
+// projectOwnerParams reads the parameters that identify a project.
+func projectOwnerParams(request mcp.CallToolRequest) (owner, ownerType string, projectNumber int, err error) {
+	owner, err = RequiredParam[string](request, "owner")
+	if err != nil {
+		return "", "", 0, err
+	}
+	ownerType, err = RequiredParam[string](request, "owner_type")
+	if err != nil {
+		return "", "", 0, err
+	}
+	projectNumber, err = RequiredInt(request, "project_number")
+	if err != nil {
+		return "", "", 0, err
+	}
+	return owner, ownerType, projectNumber, nil
+}
+
+// withProjectOwner adds the parameters that identify a project owner.
+func withProjectOwner() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Login of the organization or user that owns the project"),
+		)(tool)
+		mcp.WithString("owner_type",
+			mcp.Required(),
+			mcp.Description("Whether the owner is an organization or a user"),
+			mcp.Enum("org", "user"),
+		)(tool)
+	}
+}
+
+// withProject adds the parameters that identify a project.
+func withProject() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		withProjectOwner()(tool)
+		mcp.WithNumber("project_number",
+			mcp.Required(),
+			mcp.Description("Project number, as shown in the project URL"),
+		)(tool)
+	}
+}
+
+// Project is a GitHub Projects (v2) project.
+type Project struct {
+	NodeID           string `json:"node_id"`
+	Number           int    `json:"number"`
+	Title            string `json:"title"`
+	ShortDescription string `json:"short_description,omitempty"`
+	URL              string `json:"url"`
+	Closed           bool   `json:"closed"`
+}
+
+// ProjectFieldOption is an option of a single select field, or an iteration of an iteration field.
+type ProjectFieldOption struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	StartDate string `json:"start_date,omitempty"`
+	Duration  int    `json:"duration,omitempty"`
+	Completed bool   `json:"completed,omitempty"`
+}
+
+// ProjectField is a field of a project, with the options and iterations the field can be set to.
+type ProjectField struct {
+	NodeID   string                `json:"node_id"`
+	Name     string                `json:"name"`
+	DataType string                `json:"data_type"`
+	Options  []*ProjectFieldOption `json:"options,omitempty"`
+}
+
+// ProjectItemContent is the issue, pull request or draft issue a project item stands for.
+type ProjectItemContent struct {
+	Repository string `json:"repository,omitempty"`
+	Number     int    `json:"number,omitempty"`
+	Title      string `json:"title"`
+	State      string `json:"state,omitempty"`
+	URL        string `json:"url,omitempty"`
+}
+
+// ProjectItem is an item of a project with the values of its fields, keyed by field name.
+type ProjectItem struct {
+	NodeID     string              `json:"node_id"`
+	Type       string              `json:"type"`
+	IsArchived bool                `json:"is_archived"`
+	Content    *ProjectItemContent `json:"content,omitempty"`
+	Fields     map[string]any      `json:"fields"`
+}
+
+func ListProjects(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_projects",
+			mcp.WithDescription(t("TOOL_LIST_PROJECTS_DESCRIPTION", "List the projects (Projects v2) of an organization or user.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PROJECTS_USER_TITLE", "List projects"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withProjectOwner(),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ownerType, err := RequiredParam[string](request, "owner_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+			}
+
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"first": githubv4.Int(*paginationParams.First),
+				"after": (*githubv4.String)(paginationParams.After),
+			}
+			result, err := queryProjectOwner[struct {
+				ProjectsV2 struct {
+					Nodes []struct {
+						ID               githubv4.ID
+						Number           githubv4.Int
+						Title            githubv4.String
+						ShortDescription githubv4.String
+						URL              githubv4.String `graphql:"url"`
+						Closed           githubv4.Boolean
+					}
+					PageInfo   PageInfoFragment
+					TotalCount githubv4.Int
+				} `graphql:"projectsV2(first: $first, after: $after)"`
+			}](ctx, client, ownerType, vars)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to list projects of %s", owner), err), nil
+			}
+
+			projects := make([]*Project, 0, len(result.ProjectsV2.Nodes))
+			for _, p := range result.ProjectsV2.Nodes {
+				projects = append(projects, &Project{
+					NodeID:           fmt.Sprint(p.ID),
+					Number:           int(p.Number),
+					Title:            string(p.Title),
+					ShortDescription: string(p.ShortDescription),
+					URL:              string(p.URL),
+					Closed:           bool(p.Closed),
+				})
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"projects": projects,
+				"pageInfo": map[string]any{
+					"hasNextPage": result.ProjectsV2.PageInfo.HasNextPage,
+					"endCursor":   string(result.ProjectsV2.PageInfo.EndCursor),
+				},
+				"totalCount": result.ProjectsV2.TotalCount,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func ListProjectFields(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_project_fields",
+			mcp.WithDescription(t("TOOL_LIST_PROJECT_FIELDS_DESCRIPTION", "List the fields of a project, with the options of single select fields such as Status and the iterations of iteration fields. The IDs are needed to update the fields of items.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PROJECT_FIELDS_USER_TITLE", "List project fields"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withProject(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, ownerType, projectNumber, err := projectOwnerParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+			}
+
+			type iteration struct {
+				ID        githubv4.String
+				Title     githubv4.String
+				StartDate githubv4.String
+				Duration  githubv4.Int
+			}
+			vars := map[string]any{
+				"owner":         githubv4.String(owner),
+				"projectNumber": githubv4.Int(projectNumber), // #nosec G115 - project numbers are always small positive integers
+				"first":         githubv4.Int(maxProjectFields),
+			}
+			result, err := queryProjectOwner[struct {
+				ProjectV2 struct {
+					Fields struct {
+						Nodes []struct {
+							Common struct {
+								ID       githubv4.ID
+								Name     githubv4.String
+								DataType githubv4.String
+							} `graphql:"... on ProjectV2FieldCommon"`
+							SingleSelect struct {
+								Options []struct {
+									ID   githubv4.String
+									Name githubv4.String
+								}
+							} `graphql:"... on ProjectV2SingleSelectField"`
+							Iteration struct {
+								Configuration struct {
+									Iterations          []iteration
+									CompletedIterations []iteration
+								}
+							} `graphql:"... on ProjectV2IterationField"`
+						}
+					} `graphql:"fields(first: $first)"`
+				} `graphql:"projectV2(number: $projectNumber)"`
+			}](ctx, client, ownerType, vars)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to list fields of project %d of %s", projectNumber, owner), err), nil
+			}
+
+			fields := make([]*ProjectField, 0, len(result.ProjectV2.Fields.Nodes))
+			for _, node := range result.ProjectV2.Fields.Nodes {
+				field := &ProjectField{
+					NodeID:   fmt.Sprint(node.Common.ID),
+					Name:     string(node.Common.Name),
+					DataType: string(node.Common.DataType),
+				}
+				for _, o := range node.SingleSelect.Options {
+					field.Options = append(field.Options, &ProjectFieldOption{ID: string(o.ID), Name: string(o.Name)})
+				}
+				addIterations := func(iterations []iteration, completed bool) {
+					for _, i := range iterations {
+						field.Options = append(field.Options, &ProjectFieldOption{
+							ID:        string(i.ID),
+							Name:      string(i.Title),
+							StartDate: string(i.StartDate),
+							Duration:  int(i.Duration),
+							Completed: completed,
+						})
+					}
+				}
+				addIterations(node.Iteration.Configuration.Iterations, false)
+				addIterations(node.Iteration.Configuration.CompletedIterations, true)
+				fields = append(fields, field)
+			}
+
+			r, err := json.Marshal(fields)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// projectItemContentFragment selects the content of an issue or pull request item.
+type projectItemContentFragment struct {
+	Number     githubv4.Int
+	Title      githubv4.String
+	State      githubv4.String
+	URL        githubv4.String `graphql:"url"`
+	Repository struct {
+		NameWithOwner githubv4.String
+	}
+}
+
+func (c projectItemContentFragment) toProjectItemContent() *ProjectItemContent {
+	return &ProjectItemContent{
+		Repository: string(c.Repository.NameWithOwner),
+		Number:     int(c.Number),
+		Title:      string(c.Title),
+		State:      string(c.State),
+		URL:        string(c.URL),
+	}
+}
+
+// projectFieldNameFragment selects the name of the field a value belongs to.
+type projectFieldNameFragment struct {
+	Common struct {
+		Name githubv4.String
+	} `graphql:"... on ProjectV2FieldCommon"`
+}
+
+func ListProjectItems(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_project_items",
+			mcp.WithDescription(t("TOOL_LIST_PROJECT_ITEMS_DESCRIPTION", "List the items of a project with the values of their fields, such as Status and Iteration. Items are issues, pull requests or draft issues.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PROJECT_ITEMS_USER_TITLE", "List project items"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withProject(),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, ownerType, projectNumber, err := projectOwnerParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+			}
+
+			vars := map[string]any{
+				"owner":         githubv4.String(owner),
+				"projectNumber": githubv4.Int(projectNumber), // #nosec G115 - project numbers are always small positive integers
+				"first":         githubv4.Int(*paginationParams.First),
+				"after":         (*githubv4.String)(paginationParams.After),
+				"fieldValues":   githubv4.Int(maxProjectItemFieldValues),
+			}
+			result, err := queryProjectOwner[struct {
+				ProjectV2 struct {
+					Items struct {
+						Nodes []struct {
+							ID         githubv4.ID
+							Type       githubv4.String
+							IsArchived githubv4.Boolean
+							Content    struct {
+								Issue       projectItemContentFragment `graphql:"... on Issue"`
+								PullRequest projectItemContentFragment `graphql:"... on PullRequest"`
+								DraftIssue  struct {
+									Title githubv4.String
+								} `graphql:"... on DraftIssue"`
+							}
+							FieldValues struct {
+								Nodes []struct {
+									TypeName string `graphql:"__typename"`
+									Text     struct {
+										Text  githubv4.String
+										Field projectFieldNameFragment
+									} `graphql:"... on ProjectV2ItemFieldTextValue"`
+									Number struct {
+										Number githubv4.Float
+										Field  projectFieldNameFragment
+									} `graphql:"... on ProjectV2ItemFieldNumberValue"`
+									Date struct {
+										Date  githubv4.String
+										Field projectFieldNameFragment
+									} `graphql:"... on ProjectV2ItemFieldDateValue"`
+									SingleSelect struct {
+										Name  githubv4.String
+										Field projectFieldNameFragment
+									} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+									Iteration struct {
+										Title     githubv4.String
+										StartDate githubv4.String
+										Field     projectFieldNameFragment
+									} `graphql:"... on ProjectV2ItemFieldIterationValue"`
+								}
+							} `graphql:"fieldValues(first: $fieldValues)"`
+						}
+						PageInfo   PageInfoFragment
+						TotalCount githubv4.Int
+					} `graphql:"items(first: $first, after: $after)"`
+				} `graphql:"projectV2(number: $projectNumber)"`
+			}](ctx, client, ownerType, vars)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to list items of project %d of %s", projectNumber, owner), err), nil
+			}
+
+			items := make([]*ProjectItem, 0, len(result.ProjectV2.Items.Nodes))
+			for _, node := range result.ProjectV2.Items.Nodes {
+				item := &ProjectItem{
+					NodeID:     fmt.Sprint(node.ID),
+					Type:       string(node.Type),
+					IsArchived: bool(node.IsArchived),
+					Fields:     map[string]any{},
+				}
+				switch item.Type {
+				case "ISSUE":
+					item.Content = node.Content.Issue.toProjectItemContent()
+				case "PULL_REQUEST":
+					item.Content = node.Content.PullRequest.toProjectItemContent()
+				case "DRAFT_ISSUE":
+					item.Content = &ProjectItemContent{Title: string(node.Content.DraftIssue.Title)}
+				}
+
+				// The fields of a value decode into every fragment, so the type name tells which one it is.
+				for _, v := range node.FieldValues.Nodes {
+					switch v.TypeName {
+					case "ProjectV2ItemFieldTextValue":
+						item.Fields[string(v.Text.Field.Common.Name)] = string(v.Text.Text)
+					case "ProjectV2ItemFieldNumberValue":
+						item.Fields[string(v.Number.Field.Common.Name)] = float64(v.Number.Number)
+					case "ProjectV2ItemFieldDateValue":
+						item.Fields[string(v.Date.Field.Common.Name)] = string(v.Date.Date)
+					case "ProjectV2ItemFieldSingleSelectValue":
+						item.Fields[string(v.SingleSelect.Field.Common.Name)] = string(v.SingleSelect.Name)
+					case "ProjectV2ItemFieldIterationValue":
+						item.Fields[string(v.Iteration.Field.Common.Name)] = map[string]any{
+							"title":      string(v.Iteration.Title),
+							"start_date": string(v.Iteration.StartDate),
+						}
+					}
+				}
+				items = append(items, item)
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"items": items,
+				"pageInfo": map[string]any{
+					"hasNextPage": result.ProjectV2.Items.PageInfo.HasNextPage,
+					"endCursor":   string(result.ProjectV2.Items.PageInfo.EndCursor),
+				},
+				"totalCount": result.ProjectV2.Items.TotalCount,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func AddProjectItem(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_project_item",
+			mcp.WithDescription(t("TOOL_ADD_PROJECT_ITEM_DESCRIPTION", "Add an issue or pull request to a project. Adding an item that is already in the project returns the existing item.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_PROJECT_ITEM_USER_TITLE", "Add project item"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withProject(),
+			mcp.WithString("item_owner",
+				mcp.Required(),
+				mcp.Description("Owner of the repository of the issue or pull request"),
+			),
+			mcp.WithString("item_repo",
+				mcp.Required(),
+				mcp.Description("Name of the repository of the issue or pull request"),
+			),
+			mcp.WithNumber("item_number",
+				mcp.Required(),
+				mcp.Description("Number of the issue or pull request"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, ownerType, projectNumber, err := projectOwnerParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			itemOwner, err := RequiredParam[string](request, "item_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			itemRepo, err := RequiredParam[string](request, "item_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			itemNumber, err := RequiredInt(request, "item_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+			}
+
+			project, err := queryProjectOwner[projectIDQuery](ctx, client, ownerType, map[string]any{
+				"owner":         githubv4.String(owner),
+				"projectNumber": githubv4.Int(projectNumber), // #nosec G115 - project numbers are always small positive integers
+			})
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to find project %d of %s", projectNumber, owner), err), nil
+			}
+
+			var contentQuery struct {
+				Repository struct {
+					IssueOrPullRequest struct {
+						Issue struct {
+							ID githubv4.ID
+						} `graphql:"... on Issue"`
+						PullRequest struct {
+							ID githubv4.ID
+						} `graphql:"... on PullRequest"`
+					} `graphql:"issueOrPullRequest(number: $number)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			err = client.Query(ctx, &contentQuery, map[string]any{
+				"owner":  githubv4.String(itemOwner),
+				"repo":   githubv4.String(itemRepo),
+				"number": githubv4.Int(itemNumber), // #nosec G115 - issue numbers are always small positive integers
+			})
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to find issue or pull request #%d in %s/%s", itemNumber, itemOwner, itemRepo), err), nil
+			}
+			contentID := contentQuery.Repository.IssueOrPullRequest.Issue.ID
+			if contentID == nil {
+				contentID = contentQuery.Repository.IssueOrPullRequest.PullRequest.ID
+			}
+
+			var mutation struct {
+				AddProjectV2ItemByID struct {
+					Item struct {
+						ID githubv4.ID
+					}
+				} `graphql:"addProjectV2ItemById(input: $input)"`
+			}
+			input := githubv4.AddProjectV2ItemByIdInput{
+				ProjectID: project.ProjectV2.ID,
+				ContentID: contentID,
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to add %s/%s#%d to project %d of %s", itemOwner, itemRepo, itemNumber, projectNumber, owner), err), nil
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"node_id": fmt.Sprint(mutation.AddProjectV2ItemByID.Item.ID),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func UpdateProjectItemField(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_project_item_field",
+			mcp.WithDescription(t("TOOL_UPDATE_PROJECT_ITEM_FIELD_DESCRIPTION", "Set a field of a project item, such as its Status or the iteration it is planned in. Give exactly one of single_select_option_id, iteration_id, text or number. Get the field, option and iteration IDs from list_project_fields and the item ID from list_project_items.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_PROJECT_ITEM_FIELD_USER_TITLE", "Update project item field"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withProject(),
+			mcp.WithString("item_id",
+				mcp.Required(),
+				mcp.Description("node_id of the project item"),
+			),
+			mcp.WithString("field_id",
+				mcp.Required(),
+				mcp.Description("node_id of the field"),
+			),
+			mcp.WithString("single_select_option_id",
+				mcp.Description("ID of the option to set a single select field, such as Status, to"),
+			),
+			mcp.WithString("iteration_id",
+				mcp.Description("ID of the iteration to move the item to"),
+			),
+			mcp.WithString("text",
+				mcp.Description("Value of a text field"),
+			),
+			mcp.WithNumber("number",
+				mcp.Description("Value of a number field"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, ownerType, projectNumber, err := projectOwnerParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			itemID, err := RequiredParam[string](request, "item_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fieldID, err := RequiredParam[string](request, "field_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var value githubv4.ProjectV2FieldValue
+			values := 0
+			for _, name := range []string{"single_select_option_id", "iteration_id", "text"} {
+				v, err := OptionalParam[string](request, name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if v == "" {
+					continue
+				}
+				values++
+				switch name {
+				case "single_select_option_id":
+					value.SingleSelectOptionID = githubv4.NewString(githubv4.String(v))
+				case "iteration_id":
+					value.IterationID = githubv4.NewString(githubv4.String(v))
+				case "text":
+					value.Text = githubv4.NewString(githubv4.String(v))
+				}
+			}
+			if _, ok := request.GetArguments()["number"]; ok {
+				number, err := OptionalParam[float64](request, "number")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				values++
+				value.Number = githubv4.NewFloat(githubv4.Float(number))
+			}
+			if values != 1 {
+				return mcp.NewToolResultError("exactly one of single_select_option_id, iteration_id, text or number must be given"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+			}
+
+			project, err := queryProjectOwner[projectIDQuery](ctx, client, ownerType, map[string]any{
+				"owner":         githubv4.String(owner),
+				"projectNumber": githubv4.Int(projectNumber), // #nosec G115 - project numbers are always small positive integers
+			})
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to find project %d of %s", projectNumber, owner), err), nil
+			}
+
+			var mutation struct {
+				UpdateProjectV2ItemFieldValue struct {
+					ProjectV2Item struct {
+						ID githubv4.ID
+					}
+				} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
+			}
+			input := githubv4.UpdateProjectV2ItemFieldValueInput{
+				ProjectID: project.ProjectV2.ID,
+				ItemID:    githubv4.ID(itemID),
+				FieldID:   githubv4.ID(fieldID),
+				Value:     value,
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to update field %s of project item %s", fieldID, itemID), err), nil
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"node_id": fmt.Sprint(mutation.UpdateProjectV2ItemFieldValue.ProjectV2Item.ID),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
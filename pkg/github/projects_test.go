@@ -0,0 +1,545 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListProjects(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListProjects(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_projects", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "owner_type")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "owner_type"})
+
+	// Use exact string queries that match implementation output
+	qOrg := "query($after:String$first:Int!$owner:String!){owner: organization(login: $owner){projectsV2(first: $first, after: $after){nodes{id,number,title,shortDescription,url,closed},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+	qUser := "query($after:String$first:Int!$owner:String!){owner: user(login: $owner){projectsV2(first: $first, after: $after){nodes{id,number,title,shortDescription,url,closed},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+
+	vars := map[string]any{
+		"owner": "octo-org",
+		"first": float64(30),
+		"after": nil,
+	}
+	response := githubv4mock.DataResponse(map[string]any{
+		"owner": map[string]any{
+			"projectsV2": map[string]any{
+				"nodes": []map[string]any{
+					{"id": "PVT_1", "number": 1, "title": "Roadmap", "shortDescription": "What is next", "url": "https://github.com/orgs/octo-org/projects/1", "closed": false},
+					{"id": "PVT_2", "number": 2, "title": "Old board", "url": "https://github.com/orgs/octo-org/projects/2", "closed": true},
+				},
+				"pageInfo": map[string]any{
+					"hasNextPage":     true,
+					"hasPreviousPage": false,
+					"startCursor":     "",
+					"endCursor":       "cursor",
+				},
+				"totalCount": 3,
+			},
+		},
+	})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name:         "organization projects",
+			requestArgs:  map[string]any{"owner": "octo-org", "owner_type": "org"},
+			mockedClient: githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qOrg, vars, response)),
+		},
+		{
+			name:         "user projects",
+			requestArgs:  map[string]any{"owner": "octo-org", "owner_type": "user"},
+			mockedClient: githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qUser, vars, response)),
+		},
+		{
+			name:           "owner not found",
+			requestArgs:    map[string]any{"owner": "octo-org", "owner_type": "org"},
+			mockedClient:   githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qOrg, vars, githubv4mock.ErrorResponse("Could not resolve to an Organization with the login of 'octo-org'."))),
+			expectError:    true,
+			expectedErrMsg: "failed to list projects of octo-org",
+		},
+		{
+			name:           "invalid owner type",
+			requestArgs:    map[string]any{"owner": "octo-org", "owner_type": "enterprise"},
+			mockedClient:   githubv4mock.NewMockedHTTPClient(),
+			expectError:    true,
+			expectedErrMsg: "owner_type must be org or user",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := ListProjects(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, fmt.Sprintf("expected there to be no tool error, text was %s", textContent.Text))
+
+			var returned struct {
+				Projects []Project `json:"projects"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				TotalCount int `json:"totalCount"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			require.Len(t, returned.Projects, 2)
+			assert.Equal(t, Project{
+				NodeID:           "PVT_1",
+				Number:           1,
+				Title:            "Roadmap",
+				ShortDescription: "What is next",
+				URL:              "https://github.com/orgs/octo-org/projects/1",
+			}, returned.Projects[0])
+			assert.True(t, returned.Projects[1].Closed)
+			assert.True(t, returned.PageInfo.HasNextPage)
+			assert.Equal(t, "cursor", returned.PageInfo.EndCursor)
+			assert.Equal(t, 3, returned.TotalCount)
+		})
+	}
+}
+
+func Test_ListProjectFields(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListProjectFields(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_project_fields", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "owner_type", "project_number"})
+
+	// Use exact string query that matches implementation output
+	qFields := "query($first:Int!$owner:String!$projectNumber:Int!){owner: organization(login: $owner){projectV2(number: $projectNumber){fields(first: $first){nodes{... on ProjectV2FieldCommon{id,name,dataType},... on ProjectV2SingleSelectField{options{id,name}},... on ProjectV2IterationField{configuration{iterations{id,title,startDate,duration},completedIterations{id,title,startDate,duration}}}}}}}}"
+
+	vars := map[string]any{
+		"owner":         "octo-org",
+		"projectNumber": float64(1),
+		"first":         float64(50),
+	}
+	response := githubv4mock.DataResponse(map[string]any{
+		"owner": map[string]any{
+			"projectV2": map[string]any{
+				"fields": map[string]any{
+					"nodes": []map[string]any{
+						{"id": "PVTF_title", "name": "Title", "dataType": "TITLE"},
+						{"id": "PVTSSF_status", "name": "Status", "dataType": "SINGLE_SELECT", "options": []map[string]any{
+							{"id": "opt_todo", "name": "Todo"},
+							{"id": "opt_done", "name": "Done"},
+						}},
+						{"id": "PVTIF_sprint", "name": "Sprint", "dataType": "ITERATION", "configuration": map[string]any{
+							"iterations": []map[string]any{
+								{"id": "it_2", "title": "Sprint 2", "startDate": "2025-05-12", "duration": 14},
+							},
+							"completedIterations": []map[string]any{
+								{"id": "it_1", "title": "Sprint 1", "startDate": "2025-04-28", "duration": 14},
+							},
+						}},
+					},
+				},
+			},
+		},
+	})
+
+	client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qFields, vars, response)))
+	_, handler := ListProjectFields(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":          "octo-org",
+		"owner_type":     "org",
+		"project_number": float64(1),
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, fmt.Sprintf("expected there to be no tool error, text was %s", textContent.Text))
+
+	var returned []ProjectField
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, []ProjectField{
+		{NodeID: "PVTF_title", Name: "Title", DataType: "TITLE"},
+		{NodeID: "PVTSSF_status", Name: "Status", DataType: "SINGLE_SELECT", Options: []*ProjectFieldOption{
+			{ID: "opt_todo", Name: "Todo"},
+			{ID: "opt_done", Name: "Done"},
+		}},
+		{NodeID: "PVTIF_sprint", Name: "Sprint", DataType: "ITERATION", Options: []*ProjectFieldOption{
+			{ID: "it_2", Name: "Sprint 2", StartDate: "2025-05-12", Duration: 14},
+			{ID: "it_1", Name: "Sprint 1", StartDate: "2025-04-28", Duration: 14, Completed: true},
+		}},
+	}, returned)
+}
+
+func Test_ListProjectItems(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListProjectItems(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_project_items", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "owner_type", "project_number"})
+
+	// Use exact string query that matches implementation output
+	qItems := "query($after:String$fieldValues:Int!$first:Int!$owner:String!$projectNumber:Int!){owner: user(login: $owner){projectV2(number: $projectNumber){items(first: $first, after: $after){nodes{id,type,isArchived,content{... on Issue{number,title,state,url,repository{nameWithOwner}},... on PullRequest{number,title,state,url,repository{nameWithOwner}},... on DraftIssue{title}},fieldValues(first: $fieldValues){nodes{__typename,... on ProjectV2ItemFieldTextValue{text,field{... on ProjectV2FieldCommon{name}}},... on ProjectV2ItemFieldNumberValue{number,field{... on ProjectV2FieldCommon{name}}},... on ProjectV2ItemFieldDateValue{date,field{... on ProjectV2FieldCommon{name}}},... on ProjectV2ItemFieldSingleSelectValue{name,field{... on ProjectV2FieldCommon{name}}},... on ProjectV2ItemFieldIterationValue{title,startDate,field{... on ProjectV2FieldCommon{name}}}}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
+
+	vars := map[string]any{
+		"owner":         "octocat",
+		"projectNumber": float64(4),
+		"first":         float64(30),
+		"after":         nil,
+		"fieldValues":   float64(50),
+	}
+	response := githubv4mock.DataResponse(map[string]any{
+		"owner": map[string]any{
+			"projectV2": map[string]any{
+				"items": map[string]any{
+					"nodes": []map[string]any{
+						{
+							"id":         "PVTI_1",
+							"type":       "ISSUE",
+							"isArchived": false,
+							"content": map[string]any{
+								"number":     42,
+								"title":      "Fix the thing",
+								"state":      "OPEN",
+								"url":        "https://github.com/octocat/repo/issues/42",
+								"repository": map[string]any{"nameWithOwner": "octocat/repo"},
+							},
+							"fieldValues": map[string]any{
+								"nodes": []map[string]any{
+									{"__typename": "ProjectV2ItemFieldTextValue", "text": "Fix the thing", "field": map[string]any{"name": "Title"}},
+									{"__typename": "ProjectV2ItemFieldSingleSelectValue", "name": "Todo", "field": map[string]any{"name": "Status"}},
+									{"__typename": "ProjectV2ItemFieldNumberValue", "number": 3, "field": map[string]any{"name": "Estimate"}},
+									{"__typename": "ProjectV2ItemFieldIterationValue", "title": "Sprint 2", "startDate": "2025-05-12", "field": map[string]any{"name": "Sprint"}},
+								},
+							},
+						},
+						{
+							"id":          "PVTI_2",
+							"type":        "DRAFT_ISSUE",
+							"isArchived":  true,
+							"content":     map[string]any{"title": "An idea"},
+							"fieldValues": map[string]any{"nodes": []map[string]any{}},
+						},
+					},
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       "",
+					},
+					"totalCount": 2,
+				},
+			},
+		},
+	})
+
+	client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qItems, vars, response)))
+	_, handler := ListProjectItems(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":          "octocat",
+		"owner_type":     "user",
+		"project_number": float64(4),
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, fmt.Sprintf("expected there to be no tool error, text was %s", textContent.Text))
+
+	var returned struct {
+		Items      []ProjectItem `json:"items"`
+		TotalCount int           `json:"totalCount"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	require.Len(t, returned.Items, 2)
+	assert.Equal(t, ProjectItem{
+		NodeID: "PVTI_1",
+		Type:   "ISSUE",
+		Content: &ProjectItemContent{
+			Repository: "octocat/repo",
+			Number:     42,
+			Title:      "Fix the thing",
+			State:      "OPEN",
+			URL:        "https://github.com/octocat/repo/issues/42",
+		},
+		Fields: map[string]any{
+			"Title":    "Fix the thing",
+			"Status":   "Todo",
+			"Estimate": float64(3),
+			"Sprint":   map[string]any{"title": "Sprint 2", "start_date": "2025-05-12"},
+		},
+	}, returned.Items[0])
+	assert.Equal(t, ProjectItem{
+		NodeID:     "PVTI_2",
+		Type:       "DRAFT_ISSUE",
+		IsArchived: true,
+		Content:    &ProjectItemContent{Title: "An idea"},
+		Fields:     map[string]any{},
+	}, returned.Items[1])
+	assert.Equal(t, 2, returned.TotalCount)
+}
+
+func Test_AddProjectItem(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := AddProjectItem(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_project_item", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "owner_type", "project_number", "item_owner", "item_repo", "item_number"})
+
+	projectQuery := githubv4mock.NewQueryMatcher(
+		orgProjectOwnerQuery[projectIDQuery]{},
+		map[string]any{
+			"owner":         githubv4.String("octo-org"),
+			"projectNumber": githubv4.Int(1),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"owner": map[string]any{"projectV2": map[string]any{"id": "PVT_1"}},
+		}),
+	)
+	contentQuery := struct {
+		Repository struct {
+			IssueOrPullRequest struct {
+				Issue struct {
+					ID githubv4.ID
+				} `graphql:"... on Issue"`
+				PullRequest struct {
+					ID githubv4.ID
+				} `graphql:"... on PullRequest"`
+			} `graphql:"issueOrPullRequest(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	contentVars := map[string]any{
+		"owner":  githubv4.String("octo-org"),
+		"repo":   githubv4.String("repo"),
+		"number": githubv4.Int(42),
+	}
+	addMutation := struct {
+		AddProjectV2ItemByID struct {
+			Item struct {
+				ID githubv4.ID
+			}
+		} `graphql:"addProjectV2ItemById(input: $input)"`
+	}{}
+
+	requestArgs := map[string]any{
+		"owner":          "octo-org",
+		"owner_type":     "org",
+		"project_number": float64(1),
+		"item_owner":     "octo-org",
+		"item_repo":      "repo",
+		"item_number":    float64(42),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful addition",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				projectQuery,
+				githubv4mock.NewQueryMatcher(contentQuery, contentVars, githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{"issueOrPullRequest": map[string]any{"id": "PR_42"}},
+				})),
+				githubv4mock.NewMutationMatcher(
+					addMutation,
+					githubv4.AddProjectV2ItemByIdInput{
+						ProjectID: githubv4.ID("PVT_1"),
+						ContentID: githubv4.ID("PR_42"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"addProjectV2ItemById": map[string]any{"item": map[string]any{"id": "PVTI_9"}},
+					}),
+				),
+			),
+		},
+		{
+			name: "issue not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				projectQuery,
+				githubv4mock.NewQueryMatcher(contentQuery, contentVars,
+					githubv4mock.ErrorResponse("Could not resolve to an issue or pull request with the number of 42."),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to find issue or pull request #42 in octo-org/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := AddProjectItem(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, fmt.Sprintf("expected there to be no tool error, text was %s", textContent.Text))
+			assert.JSONEq(t, `{"node_id":"PVTI_9"}`, textContent.Text)
+		})
+	}
+}
+
+func Test_UpdateProjectItemField(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := UpdateProjectItemField(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_project_item_field", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "single_select_option_id")
+	assert.Contains(t, tool.InputSchema.Properties, "iteration_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "owner_type", "project_number", "item_id", "field_id"})
+
+	projectQuery := githubv4mock.NewQueryMatcher(
+		userProjectOwnerQuery[projectIDQuery]{},
+		map[string]any{
+			"owner":         githubv4.String("octocat"),
+			"projectNumber": githubv4.Int(4),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"owner": map[string]any{"projectV2": map[string]any{"id": "PVT_4"}},
+		}),
+	)
+	updateMutation := func(value githubv4.ProjectV2FieldValue) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				UpdateProjectV2ItemFieldValue struct {
+					ProjectV2Item struct {
+						ID githubv4.ID
+					}
+				} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
+			}{},
+			githubv4.UpdateProjectV2ItemFieldValueInput{
+				ProjectID: githubv4.ID("PVT_4"),
+				ItemID:    githubv4.ID("PVTI_1"),
+				FieldID:   githubv4.ID("PVTF_1"),
+				Value:     value,
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"updateProjectV2ItemFieldValue": map[string]any{"projectV2Item": map[string]any{"id": "PVTI_1"}},
+			}),
+		)
+	}
+	requestArgs := func(value map[string]any) map[string]any {
+		args := map[string]any{
+			"owner":          "octocat",
+			"owner_type":     "user",
+			"project_number": float64(4),
+			"item_id":        "PVTI_1",
+			"field_id":       "PVTF_1",
+		}
+		for k, v := range value {
+			args[k] = v
+		}
+		return args
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name:        "set status",
+			requestArgs: requestArgs(map[string]any{"single_select_option_id": "opt_done"}),
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				projectQuery,
+				updateMutation(githubv4.ProjectV2FieldValue{SingleSelectOptionID: githubv4.NewString("opt_done")}),
+			),
+		},
+		{
+			name:        "move to iteration",
+			requestArgs: requestArgs(map[string]any{"iteration_id": "it_2"}),
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				projectQuery,
+				updateMutation(githubv4.ProjectV2FieldValue{IterationID: githubv4.NewString("it_2")}),
+			),
+		},
+		{
+			name:        "set number to zero",
+			requestArgs: requestArgs(map[string]any{"number": float64(0)}),
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				projectQuery,
+				updateMutation(githubv4.ProjectV2FieldValue{Number: githubv4.NewFloat(0)}),
+			),
+		},
+		{
+			name:           "no value",
+			requestArgs:    requestArgs(nil),
+			mockedClient:   githubv4mock.NewMockedHTTPClient(),
+			expectError:    true,
+			expectedErrMsg: "exactly one of single_select_option_id, iteration_id, text or number must be given",
+		},
+		{
+			name:           "two values",
+			requestArgs:    requestArgs(map[string]any{"single_select_option_id": "opt_done", "text": "done"}),
+			mockedClient:   githubv4mock.NewMockedHTTPClient(),
+			expectError:    true,
+			expectedErrMsg: "exactly one of single_select_option_id, iteration_id, text or number must be given",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := UpdateProjectItemField(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, fmt.Sprintf("expected there to be no tool error, text was %s", textContent.Text))
+			assert.JSONEq(t, `{"node_id":"PVTI_1"}`, textContent.Text)
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"path"
+	"strings"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v73/github"
@@ -74,7 +76,21 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(pr)
+			// The pulls API doesn't return reactions, they live on the issue backing the pull request.
+			// They are only a signal on top of the pull request, so failing to fetch them doesn't fail the tool.
+			result := struct {
+				*github.PullRequest
+				Reactions *github.Reactions `json:"reactions,omitempty"`
+			}{PullRequest: pr}
+			issue, issueResp, err := client.Issues.Get(ctx, owner, repo, pullNumber)
+			if issueResp != nil {
+				_ = issueResp.Body.Close()
+			}
+			if err == nil {
+				result.Reactions = issue.Reactions
+			}
+
+			r, err := json.Marshal(result)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -883,10 +899,253 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 		}
 }
 
+// RequiredCheckStatus is the state of one required status check on the head commit of a pull request.
+type RequiredCheckStatus struct {
+	Context string `json:"context"`
+	// AppID is the GitHub App that must provide the check, if the requirement names one
+	AppID *int64 `json:"app_id,omitempty"`
+	// Sources are where the requirement comes from, branch protection or a ruleset
+	Sources    []string `json:"sources"`
+	State      string   `json:"state"`
+	Conclusion string   `json:"conclusion,omitempty"`
+	DetailsURL string   `json:"details_url,omitempty"`
+}
+
+const (
+	requiredCheckPassing = "passing"
+	requiredCheckFailing = "failing"
+	requiredCheckPending = "pending"
+	requiredCheckMissing = "missing"
+)
+
+// requiredCheckSeverity orders the states of a check, so that the worst of several results for the same
+// context wins.
+var requiredCheckSeverity = map[string]int{
+	requiredCheckPassing: 0,
+	requiredCheckPending: 1,
+	requiredCheckFailing: 2,
+}
+
+// checkRunState maps a check run to the state of a required check.
+func checkRunState(run *github.CheckRun) string {
+	if run.GetStatus() != "completed" {
+		return requiredCheckPending
+	}
+	switch run.GetConclusion() {
+	case "success", "neutral", "skipped":
+		return requiredCheckPassing
+	default:
+		return requiredCheckFailing
+	}
+}
+
+// commitStatusState maps a commit status to the state of a required check.
+func commitStatusState(status *github.RepoStatus) string {
+	switch status.GetState() {
+	case "success":
+		return requiredCheckPassing
+	case "pending":
+		return requiredCheckPending
+	default:
+		return requiredCheckFailing
+	}
+}
+
+// GetPullRequestRequiredChecksStatus creates a tool to get the state of the status checks a pull request must pass to be merged.
+func GetPullRequestRequiredChecksStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_required_checks_status",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_REQUIRED_CHECKS_STATUS_DESCRIPTION", "Get the status checks a pull request must pass to be merged, as required by branch protection and rulesets of its base branch, and whether each is passing, failing, pending or missing on the head commit.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_REQUIRED_CHECKS_STATUS_USER_TITLE", "Get pull request required checks status"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+			base := pr.GetBase().GetRef()
+			headSHA := pr.GetHead().GetSHA()
+
+			var warnings []string
+			var required []*RequiredCheckStatus
+			addRequirement := func(name string, appID *int64, source string) {
+				for _, r := range required {
+					if r.Context == name && (r.AppID == nil || appID == nil || *r.AppID == *appID) {
+						if r.AppID == nil {
+							r.AppID = appID
+						}
+						r.Sources = append(r.Sources, source)
+						return
+					}
+				}
+				required = append(required, &RequiredCheckStatus{Context: name, AppID: appID, Sources: []string{source}})
+			}
+
+			// Classic branch protection. Reading it needs more access than reading the pull request, so a
+			// failure is reported alongside the rulesets rather than failing the whole call.
+			protection, resp, err := client.Repositories.GetRequiredStatusChecks(ctx, owner, repo, base)
+			switch {
+			case err == nil:
+				defer func() { _ = resp.Body.Close() }()
+				if protection.Checks != nil {
+					for _, check := range *protection.Checks {
+						addRequirement(check.Context, check.AppID, "branch_protection")
+					}
+				} else if protection.Contexts != nil {
+					for _, name := range *protection.Contexts {
+						addRequirement(name, nil, "branch_protection")
+					}
+				}
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				// The branch is not protected, or its protection doesn't require status checks
+			default:
+				warnings = append(warnings, fmt.Sprintf("branch protection of %s could not be read, only rulesets were considered: %v", base, err))
+			}
+
+			rules, resp, err := client.Repositories.GetRulesForBranch(ctx, owner, repo, base, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get rules for branch",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if rules != nil {
+				for _, rule := range rules.RequiredStatusChecks {
+					source := fmt.Sprintf("ruleset %d", rule.RulesetID)
+					for _, check := range rule.Parameters.RequiredStatusChecks {
+						addRequirement(check.Context, check.IntegrationID, source)
+					}
+				}
+			}
+
+			result := map[string]any{
+				"pull_number": pullNumber,
+				"base":        base,
+				"head_sha":    headSHA,
+			}
+			if len(warnings) > 0 {
+				result["warnings"] = warnings
+			}
+			if len(required) == 0 {
+				result["required"] = []*RequiredCheckStatus{}
+				result["all_required_passing"] = true
+				return MarshalledTextResult(result), nil
+			}
+
+			var checkRuns []*github.CheckRun
+			checkRunOpts := &github.ListCheckRunsOptions{
+				Filter:      github.Ptr("latest"),
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			for {
+				page, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, headSHA, checkRunOpts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list check runs",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				checkRuns = append(checkRuns, page.CheckRuns...)
+				if resp.NextPage == 0 {
+					break
+				}
+				checkRunOpts.Page = resp.NextPage
+			}
+
+			// The combined status already holds only the latest status of each context
+			combined, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, headSHA, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get combined status",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			byState := map[string][]string{
+				requiredCheckPassing: {},
+				requiredCheckFailing: {},
+				requiredCheckPending: {},
+				requiredCheckMissing: {},
+			}
+			for _, r := range required {
+				r.State = requiredCheckMissing
+				report := func(state, conclusion, detailsURL string) {
+					if r.State != requiredCheckMissing && requiredCheckSeverity[state] <= requiredCheckSeverity[r.State] {
+						return
+					}
+					r.State, r.Conclusion, r.DetailsURL = state, conclusion, detailsURL
+				}
+				for _, run := range checkRuns {
+					if run.GetName() != r.Context || (r.AppID != nil && run.GetApp().GetID() != *r.AppID) {
+						continue
+					}
+					report(checkRunState(run), run.GetConclusion(), run.GetDetailsURL())
+				}
+				for _, status := range combined.Statuses {
+					if status.GetContext() != r.Context {
+						continue
+					}
+					report(commitStatusState(status), status.GetState(), status.GetTargetURL())
+				}
+				byState[r.State] = append(byState[r.State], r.Context)
+			}
+
+			result["required"] = required
+			for state, contexts := range byState {
+				result[state] = contexts
+			}
+			result["all_required_passing"] = len(byState[requiredCheckPassing]) == len(required)
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
 // UpdatePullRequestBranch creates a tool to update a pull request branch with the latest changes from the base branch.
-func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+func UpdatePullRequestBranch(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("update_pull_request_branch",
-			mcp.WithDescription(t("TOOL_UPDATE_PULL_REQUEST_BRANCH_DESCRIPTION", "Update the branch of a pull request with the latest changes from the base branch.")),
+			mcp.WithDescription(t("TOOL_UPDATE_PULL_REQUEST_BRANCH_DESCRIPTION", "Update the branch of a pull request with the latest changes from the base branch, by merging the base branch into it or by rebasing it onto the base branch.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_UPDATE_PULL_REQUEST_BRANCH_USER_TITLE", "Update pull request branch"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -906,6 +1165,11 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 			mcp.WithString("expectedHeadSha",
 				mcp.Description("The expected SHA of the pull request's HEAD ref"),
 			),
+			mcp.WithString("method",
+				mcp.Description("How to update the branch: merge the base branch into it, or rebase it onto the base branch, rewriting its commits"),
+				mcp.Enum("merge", "rebase"),
+				mcp.DefaultString("merge"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -924,6 +1188,46 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			method, err := OptionalParam[string](request, "method")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			switch method {
+			case "", "merge":
+			case "rebase":
+				// The REST API can only merge, rebasing is only possible through GraphQL
+				client, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+				pullRequestID, err := getPullRequestID(ctx, client, owner, repo, pullNumber)
+				if err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request", err), nil
+				}
+
+				var updateMutation struct {
+					UpdatePullRequestBranch struct {
+						PullRequest struct {
+							HeadRefOid githubv4.GitObjectID
+						}
+					} `graphql:"updatePullRequestBranch(input: $input)"`
+				}
+				input := githubv4.UpdatePullRequestBranchInput{
+					PullRequestID: pullRequestID,
+					UpdateMethod:  github.Ptr(githubv4.PullRequestBranchUpdateMethodRebase),
+				}
+				if expectedHeadSHA != "" {
+					input.ExpectedHeadOid = newGQLStringlikePtr[githubv4.GitObjectID](&expectedHeadSHA)
+				}
+				if err := client.Mutate(ctx, &updateMutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to rebase pull request branch", err), nil
+				}
+				return mcp.NewToolResultText(fmt.Sprintf("Pull request branch rebased onto the base branch, its head is now %s", updateMutation.UpdatePullRequestBranch.PullRequest.HeadRefOid)), nil
+			default:
+				return mcp.NewToolResultError("method must be merge or rebase"), nil
+			}
+
 			opts := &github.PullRequestBranchUpdateOptions{}
 			if expectedHeadSHA != "" {
 				opts.ExpectedHeadSHA = github.Ptr(expectedHeadSHA)
@@ -965,13 +1269,32 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 		}
 }
 
-// GetPullRequestComments creates a tool to get the review comments on a pull request.
-func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
-	return mcp.NewTool("get_pull_request_comments",
-			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_COMMENTS_DESCRIPTION", "Get comments for a specific pull request.")),
+// getPullRequestID looks up the GraphQL node ID of a pull request, which mutations take instead of its number.
+func getPullRequestID(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int) (githubv4.ID, error) {
+	var getPullRequestQuery struct {
+		Repository struct {
+			PullRequest struct {
+				ID githubv4.ID
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	if err := client.Query(ctx, &getPullRequestQuery, map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"prNum": githubv4.Int(pullNumber), // #nosec G115 - pull request numbers are always small positive integers
+	}); err != nil {
+		return nil, err
+	}
+	return getPullRequestQuery.Repository.PullRequest.ID, nil
+}
+
+// EnablePullRequestAutoMerge creates a tool to merge a pull request automatically once its requirements are met.
+func EnablePullRequestAutoMerge(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("enable_pull_request_auto_merge",
+			mcp.WithDescription(t("TOOL_ENABLE_PULL_REQUEST_AUTO_MERGE_DESCRIPTION", "Enable auto-merge on a pull request, so that it is merged as soon as its required reviews and status checks pass. Auto-merge must be allowed in the repository settings.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_GET_PULL_REQUEST_COMMENTS_USER_TITLE", "Get pull request comments"),
-				ReadOnlyHint: ToBoolPtr(true),
+				Title:        t("TOOL_ENABLE_PULL_REQUEST_AUTO_MERGE_USER_TITLE", "Enable pull request auto-merge"),
+				ReadOnlyHint: ToBoolPtr(false),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -985,6 +1308,19 @@ func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHel
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			mcp.WithString("merge_method",
+				mcp.Description("Merge method once the pull request can be merged"),
+				mcp.Enum("merge", "squash", "rebase"),
+			),
+			mcp.WithString("commit_title",
+				mcp.Description("Title for the merge commit"),
+			),
+			mcp.WithString("commit_message",
+				mcp.Description("Extra detail for the merge commit"),
+			),
+			mcp.WithString("expectedHeadSha",
+				mcp.Description("The expected SHA of the pull request's HEAD ref, so that commits pushed in the meantime aren't merged unseen"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -999,51 +1335,75 @@ func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHel
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			opts := &github.PullRequestListCommentsOptions{
-				ListOptions: github.ListOptions{
-					PerPage: 100,
-				},
+			mergeMethod, err := OptionalParam[string](request, "merge_method")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			client, err := getClient(ctx)
+			commitTitle, err := OptionalParam[string](request, "commit_title")
 			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			comments, resp, err := client.PullRequests.ListComments(ctx, owner, repo, pullNumber, opts)
+			commitMessage, err := OptionalParam[string](request, "commit_message")
 			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get pull request comments",
-					resp,
-					err,
-				), nil
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedHeadSHA, err := OptionalParam[string](request, "expectedHeadSha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request comments: %s", string(body))), nil
+			input := githubv4.EnablePullRequestAutoMergeInput{}
+			switch mergeMethod {
+			case "":
+			case "merge", "squash", "rebase":
+				input.MergeMethod = github.Ptr(githubv4.PullRequestMergeMethod(strings.ToUpper(mergeMethod)))
+			default:
+				return mcp.NewToolResultError("merge_method must be merge, squash or rebase"), nil
+			}
+			if commitTitle != "" {
+				input.CommitHeadline = github.Ptr(githubv4.String(commitTitle))
+			}
+			if commitMessage != "" {
+				input.CommitBody = github.Ptr(githubv4.String(commitMessage))
+			}
+			if expectedHeadSHA != "" {
+				input.ExpectedHeadOid = newGQLStringlikePtr[githubv4.GitObjectID](&expectedHeadSHA)
 			}
 
-			r, err := json.Marshal(comments)
+			client, err := getGQLClient(ctx)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+			input.PullRequestID, err = getPullRequestID(ctx, client, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request", err), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			var enableMutation struct {
+				EnablePullRequestAutoMerge struct {
+					PullRequest struct {
+						AutoMergeRequest struct {
+							MergeMethod githubv4.PullRequestMergeMethod
+						}
+					}
+				} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &enableMutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to enable auto-merge", err), nil
+			}
+
+			method := strings.ToLower(string(enableMutation.EnablePullRequestAutoMerge.PullRequest.AutoMergeRequest.MergeMethod))
+			return mcp.NewToolResultText(fmt.Sprintf("auto-merge enabled, the pull request will be merged with the %s method once its requirements are met", method)), nil
 		}
 }
 
-// GetPullRequestReviews creates a tool to get the reviews on a pull request.
-func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
-	return mcp.NewTool("get_pull_request_reviews",
-			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_REVIEWS_DESCRIPTION", "Get reviews for a specific pull request.")),
+// DisablePullRequestAutoMerge creates a tool to turn off auto-merge on a pull request.
+func DisablePullRequestAutoMerge(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("disable_pull_request_auto_merge",
+			mcp.WithDescription(t("TOOL_DISABLE_PULL_REQUEST_AUTO_MERGE_DESCRIPTION", "Disable auto-merge on a pull request, so that it is no longer merged when its requirements are met.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_GET_PULL_REQUEST_REVIEWS_USER_TITLE", "Get pull request reviews"),
-				ReadOnlyHint: ToBoolPtr(true),
+				Title:        t("TOOL_DISABLE_PULL_REQUEST_AUTO_MERGE_USER_TITLE", "Disable pull request auto-merge"),
+				ReadOnlyHint: ToBoolPtr(false),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -1072,47 +1432,40 @@ func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelp
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			client, err := getClient(ctx)
+			client, err := getGQLClient(ctx)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
 			}
-			reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, nil)
+			pullRequestID, err := getPullRequestID(ctx, client, owner, repo, pullNumber)
 			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get pull request reviews",
-					resp,
-					err,
-				), nil
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request", err), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request reviews: %s", string(body))), nil
+			var disableMutation struct {
+				DisablePullRequestAutoMerge struct {
+					PullRequest struct {
+						ID githubv4.ID // We don't need this, but a selector is required or GQL complains.
+					}
+				} `graphql:"disablePullRequestAutoMerge(input: $input)"`
 			}
-
-			r, err := json.Marshal(reviews)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			if err := client.Mutate(ctx, &disableMutation, githubv4.DisablePullRequestAutoMergeInput{
+				PullRequestID: pullRequestID,
+			}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to disable auto-merge", err), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return mcp.NewToolResultText("auto-merge disabled"), nil
 		}
 }
 
-func CreateAndSubmitPullRequestReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
-	return mcp.NewTool("create_and_submit_pull_request_review",
-			mcp.WithDescription(t("TOOL_CREATE_AND_SUBMIT_PULL_REQUEST_REVIEW_DESCRIPTION", "Create and submit a review for a pull request without review comments.")),
+// GetPullRequestComments creates a tool to get the review comments on a pull request.
+func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_comments",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_COMMENTS_DESCRIPTION", "Get comments for a specific pull request.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_CREATE_AND_SUBMIT_PULL_REQUEST_REVIEW_USER_TITLE", "Create and submit a pull request review without comments"),
-				ReadOnlyHint: ToBoolPtr(false),
+				Title:        t("TOOL_GET_PULL_REQUEST_COMMENTS_USER_TITLE", "Get pull request comments"),
+				ReadOnlyHint: ToBoolPtr(true),
 			}),
-			// Either we need the PR GQL Id directly, or we need owner, repo and PR number to look it up.
-			// Since our other Pull Request tools are working with the REST Client, will handle the lookup
-			// internally for now.
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -1125,10 +1478,150 @@ func CreateAndSubmitPullRequestReview(getGQLClient GetGQLClientFn, t translation
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
-			mcp.WithString("body",
-				mcp.Required(),
-				mcp.Description("Review comment text"),
-			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.PullRequestListCommentsOptions{
+				ListOptions: github.ListOptions{
+					PerPage: 100,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			comments, resp, err := client.PullRequests.ListComments(ctx, owner, repo, pullNumber, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request comments",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request comments: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(comments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetPullRequestReviews creates a tool to get the reviews on a pull request.
+func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_reviews",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_REVIEWS_DESCRIPTION", "Get reviews for a specific pull request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_REVIEWS_USER_TITLE", "Get pull request reviews"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request reviews",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request reviews: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(reviews)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func CreateAndSubmitPullRequestReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("create_and_submit_pull_request_review",
+			mcp.WithDescription(t("TOOL_CREATE_AND_SUBMIT_PULL_REQUEST_REVIEW_DESCRIPTION", "Create and submit a review for a pull request without review comments.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_AND_SUBMIT_PULL_REQUEST_REVIEW_USER_TITLE", "Create and submit a pull request review without comments"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			// Either we need the PR GQL Id directly, or we need owner, repo and PR number to look it up.
+			// Since our other Pull Request tools are working with the REST Client, will handle the lookup
+			// internally for now.
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Review comment text"),
+			),
 			mcp.WithString("event",
 				mcp.Required(),
 				mcp.Description("Review action to perform"),
@@ -1205,6 +1698,189 @@ func CreateAndSubmitPullRequestReview(getGQLClient GetGQLClientFn, t translation
 		}
 }
 
+// SubmitPullRequestReview creates a tool to submit a review on a pull request together with its inline comments.
+func SubmitPullRequestReview(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("submit_pull_request_review",
+			mcp.WithDescription(t("TOOL_SUBMIT_PULL_REQUEST_REVIEW_DESCRIPTION", "Submit a complete review of a pull request in one call: the review body, its event and any number of inline comments on lines of the diff. Either the whole review is submitted or nothing is, so prefer this over building a pending review comment by comment.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SUBMIT_PULL_REQUEST_REVIEW_USER_TITLE", "Submit pull request review with comments"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("event",
+				mcp.Required(),
+				mcp.Description("Review action to perform"),
+				mcp.Enum("APPROVE", "REQUEST_CHANGES", "COMMENT"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Review comment text, required to request changes"),
+			),
+			mcp.WithString("commitID",
+				mcp.Description("SHA of commit to review, the latest commit of the pull request when not set"),
+			),
+			mcp.WithArray("comments",
+				mcp.Description("Inline comments of the review, each on a line or range of lines of a file in the diff"),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"path", "body", "line"},
+						"properties": map[string]interface{}{
+							"path": map[string]interface{}{
+								"type":        "string",
+								"description": "The relative path to the file that necessitates a comment",
+							},
+							"body": map[string]interface{}{
+								"type":        "string",
+								"description": "The text of the review comment",
+							},
+							"line": map[string]interface{}{
+								"type":        "number",
+								"description": "The line of the blob in the pull request diff that the comment applies to. For multi-line comments, the last line of the range",
+							},
+							"side": map[string]interface{}{
+								"type":        "string",
+								"description": "The side of the diff to comment on. LEFT indicates the previous state, RIGHT (the default) indicates the new state",
+								"enum":        []string{"LEFT", "RIGHT"},
+							},
+							"startLine": map[string]interface{}{
+								"type":        "number",
+								"description": "For multi-line comments, the first line of the range that the comment applies to",
+							},
+							"startSide": map[string]interface{}{
+								"type":        "string",
+								"description": "For multi-line comments, the starting side of the diff that the comment applies to, side when not set",
+								"enum":        []string{"LEFT", "RIGHT"},
+							},
+						},
+					}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			event, err := RequiredParam[string](request, "event")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := OptionalParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commitID, err := OptionalParam[string](request, "commitID")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var comments []struct {
+				Path      string
+				Body      string
+				Line      int
+				Side      string
+				StartLine int
+				StartSide string
+			}
+			if err := mapstructure.Decode(request.GetArguments()["comments"], &comments); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("comments must be an array of objects with path, body and line: %s", err)), nil
+			}
+
+			switch event {
+			case "APPROVE":
+			case "REQUEST_CHANGES":
+				if body == "" {
+					return mcp.NewToolResultError("body is required to request changes"), nil
+				}
+			case "COMMENT":
+				if body == "" && len(comments) == 0 {
+					return mcp.NewToolResultError("a COMMENT review needs a body or comments"), nil
+				}
+			default:
+				return mcp.NewToolResultError("event must be one of APPROVE, REQUEST_CHANGES or COMMENT"), nil
+			}
+
+			review := &github.PullRequestReviewRequest{
+				Event:    github.Ptr(event),
+				Comments: make([]*github.DraftReviewComment, 0, len(comments)),
+			}
+			if body != "" {
+				review.Body = github.Ptr(body)
+			}
+			if commitID != "" {
+				review.CommitID = github.Ptr(commitID)
+			}
+			for i, c := range comments {
+				if c.Path == "" || c.Body == "" || c.Line < 1 {
+					return mcp.NewToolResultError(fmt.Sprintf("comment %d needs a path, a body and a line", i+1)), nil
+				}
+				side := c.Side
+				if side == "" {
+					side = "RIGHT"
+				}
+				comment := &github.DraftReviewComment{
+					Path: github.Ptr(c.Path),
+					Body: github.Ptr(c.Body),
+					Line: github.Ptr(c.Line),
+					Side: github.Ptr(side),
+				}
+				if c.StartLine > 0 {
+					if c.StartLine >= c.Line {
+						return mcp.NewToolResultError(fmt.Sprintf("comment %d on %s has a startLine that is not before its line", i+1, c.Path)), nil
+					}
+					startSide := c.StartSide
+					if startSide == "" {
+						startSide = side
+					}
+					comment.StartLine = github.Ptr(c.StartLine)
+					comment.StartSide = github.Ptr(startSide)
+				}
+				review.Comments = append(review.Comments, comment)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			submitted, resp, err := client.PullRequests.CreateReview(ctx, owner, repo, pullNumber, review)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to submit pull request review",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"id":       submitted.GetID(),
+				"state":    submitted.GetState(),
+				"html_url": submitted.GetHTMLURL(),
+				"comments": len(review.Comments),
+			}), nil
+		}
+}
+
 // CreatePendingPullRequestReview creates a tool to create a pending review on a pull request.
 func CreatePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("create_pending_pull_request_review",
@@ -1297,6 +1973,148 @@ func CreatePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 		}
 }
 
+// pendingReview is the pending review of the viewer on a pull request.
+type pendingReview struct {
+	ID  githubv4.ID
+	URL githubv4.URI
+}
+
+// getViewerPendingReview looks up the pending review of the viewer on a pull request, of which there can be
+// only one. The error result is set when there is none or the lookup fails.
+func getViewerPendingReview(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int32) (*pendingReview, *mcp.CallToolResult) {
+	var getViewerQuery struct {
+		Viewer struct {
+			Login githubv4.String
+		}
+	}
+	if err := client.Query(ctx, &getViewerQuery, nil); err != nil {
+		return nil, ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+			"failed to get current user",
+			err,
+		)
+	}
+
+	// Filtering by state matters, the first review of the viewer is usually one they submitted long ago
+	var getPendingReviewForViewerQuery struct {
+		Repository struct {
+			PullRequest struct {
+				Reviews struct {
+					Nodes []pendingReview
+				} `graphql:"reviews(first: 1, author: $author, states: [PENDING])"`
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	vars := map[string]any{
+		"author": getViewerQuery.Viewer.Login,
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(repo),
+		"prNum":  githubv4.Int(pullNumber),
+	}
+	if err := client.Query(ctx, &getPendingReviewForViewerQuery, vars); err != nil {
+		return nil, ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+			"failed to get pending review for current user",
+			err,
+		)
+	}
+
+	if len(getPendingReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes) == 0 {
+		return nil, mcp.NewToolResultError("No pending review found for the viewer")
+	}
+	return &getPendingReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes[0], nil
+}
+
+// GetPendingPullRequestReview creates a tool to get the requester's pending review of a pull request with its draft comments.
+func GetPendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pending_pull_request_review",
+			mcp.WithDescription(t("TOOL_GET_PENDING_PULL_REQUEST_REVIEW_DESCRIPTION", "Get the requester's pending pull request review with the draft comments added to it so far. Use this to pick up a review built across several steps before submitting or deleting it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PENDING_PULL_REQUEST_REVIEW_USER_TITLE", "Get the requester's pending pull request review"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner      string
+				Repo       string
+				PullNumber int32
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			review, errResult := getViewerPendingReview(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if errResult != nil {
+				return errResult, nil
+			}
+
+			var getReviewQuery struct {
+				Node struct {
+					PullRequestReview struct {
+						Body     githubv4.String
+						Comments struct {
+							TotalCount githubv4.Int
+							Nodes      []struct {
+								Path      githubv4.String
+								Body      githubv4.String
+								Line      *githubv4.Int
+								StartLine *githubv4.Int
+							}
+						} `graphql:"comments(first: 100)"`
+					} `graphql:"... on PullRequestReview"`
+				} `graphql:"node(id: $id)"`
+			}
+			if err := client.Query(ctx, &getReviewQuery, map[string]any{"id": review.ID}); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to get pending review comments",
+					err,
+				), nil
+			}
+
+			type draftComment struct {
+				Path      string `json:"path"`
+				Line      int    `json:"line,omitempty"`
+				StartLine int    `json:"start_line,omitempty"`
+				Body      string `json:"body"`
+			}
+			pending := getReviewQuery.Node.PullRequestReview
+			comments := make([]draftComment, 0, len(pending.Comments.Nodes))
+			for _, c := range pending.Comments.Nodes {
+				comment := draftComment{Path: string(c.Path), Body: string(c.Body)}
+				if c.Line != nil {
+					comment.Line = int(*c.Line)
+				}
+				if c.StartLine != nil {
+					comment.StartLine = int(*c.StartLine)
+				}
+				comments = append(comments, comment)
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"url":            review.URL.String(),
+				"body":           string(pending.Body),
+				"total_comments": int(pending.Comments.TotalCount),
+				"comments":       comments,
+			}), nil
+		}
+}
+
 // AddCommentToPendingReview creates a tool to add a comment to a pull request review.
 func AddCommentToPendingReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("add_comment_to_pending_review",
@@ -1360,73 +2178,25 @@ func AddCommentToPendingReview(getGQLClient GetGQLClientFn, t translations.Trans
 				Repo        string
 				PullNumber  int32
 				Path        string
-				Body        string
-				SubjectType string
-				Line        *int32
-				Side        *string
-				StartLine   *int32
-				StartSide   *string
-			}
-			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			client, err := getGQLClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
-			}
-
-			// First we'll get the current user
-			var getViewerQuery struct {
-				Viewer struct {
-					Login githubv4.String
-				}
-			}
-
-			if err := client.Query(ctx, &getViewerQuery, nil); err != nil {
-				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
-					"failed to get current user",
-					err,
-				), nil
-			}
-
-			var getLatestReviewForViewerQuery struct {
-				Repository struct {
-					PullRequest struct {
-						Reviews struct {
-							Nodes []struct {
-								ID    githubv4.ID
-								State githubv4.PullRequestReviewState
-								URL   githubv4.URI
-							}
-						} `graphql:"reviews(first: 1, author: $author)"`
-					} `graphql:"pullRequest(number: $prNum)"`
-				} `graphql:"repository(owner: $owner, name: $name)"`
-			}
-
-			vars := map[string]any{
-				"author": githubv4.String(getViewerQuery.Viewer.Login),
-				"owner":  githubv4.String(params.Owner),
-				"name":   githubv4.String(params.Repo),
-				"prNum":  githubv4.Int(params.PullNumber),
+				Body        string
+				SubjectType string
+				Line        *int32
+				Side        *string
+				StartLine   *int32
+				StartSide   *string
 			}
-
-			if err := client.Query(context.Background(), &getLatestReviewForViewerQuery, vars); err != nil {
-				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
-					"failed to get latest review for current user",
-					err,
-				), nil
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			// Validate there is one review and the state is pending
-			if len(getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes) == 0 {
-				return mcp.NewToolResultError("No pending review found for the viewer"), nil
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
 			}
 
-			review := getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes[0]
-			if review.State != githubv4.PullRequestReviewStatePending {
-				errText := fmt.Sprintf("The latest review, found at %s is not pending", review.URL)
-				return mcp.NewToolResultError(errText), nil
+			review, errResult := getViewerPendingReview(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if errResult != nil {
+				return errResult, nil
 			}
 
 			// Then we can create a new review thread comment on the review.
@@ -1513,57 +2283,9 @@ func SubmitPendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
 			}
 
-			// First we'll get the current user
-			var getViewerQuery struct {
-				Viewer struct {
-					Login githubv4.String
-				}
-			}
-
-			if err := client.Query(ctx, &getViewerQuery, nil); err != nil {
-				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
-					"failed to get current user",
-					err,
-				), nil
-			}
-
-			var getLatestReviewForViewerQuery struct {
-				Repository struct {
-					PullRequest struct {
-						Reviews struct {
-							Nodes []struct {
-								ID    githubv4.ID
-								State githubv4.PullRequestReviewState
-								URL   githubv4.URI
-							}
-						} `graphql:"reviews(first: 1, author: $author)"`
-					} `graphql:"pullRequest(number: $prNum)"`
-				} `graphql:"repository(owner: $owner, name: $name)"`
-			}
-
-			vars := map[string]any{
-				"author": githubv4.String(getViewerQuery.Viewer.Login),
-				"owner":  githubv4.String(params.Owner),
-				"name":   githubv4.String(params.Repo),
-				"prNum":  githubv4.Int(params.PullNumber),
-			}
-
-			if err := client.Query(context.Background(), &getLatestReviewForViewerQuery, vars); err != nil {
-				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
-					"failed to get latest review for current user",
-					err,
-				), nil
-			}
-
-			// Validate there is one review and the state is pending
-			if len(getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes) == 0 {
-				return mcp.NewToolResultError("No pending review found for the viewer"), nil
-			}
-
-			review := getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes[0]
-			if review.State != githubv4.PullRequestReviewStatePending {
-				errText := fmt.Sprintf("The latest review, found at %s is not pending", review.URL)
-				return mcp.NewToolResultError(errText), nil
+			review, errResult := getViewerPendingReview(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if errResult != nil {
+				return errResult, nil
 			}
 
 			// Prepare the mutation
@@ -1637,57 +2359,9 @@ func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
 			}
 
-			// First we'll get the current user
-			var getViewerQuery struct {
-				Viewer struct {
-					Login githubv4.String
-				}
-			}
-
-			if err := client.Query(ctx, &getViewerQuery, nil); err != nil {
-				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
-					"failed to get current user",
-					err,
-				), nil
-			}
-
-			var getLatestReviewForViewerQuery struct {
-				Repository struct {
-					PullRequest struct {
-						Reviews struct {
-							Nodes []struct {
-								ID    githubv4.ID
-								State githubv4.PullRequestReviewState
-								URL   githubv4.URI
-							}
-						} `graphql:"reviews(first: 1, author: $author)"`
-					} `graphql:"pullRequest(number: $prNum)"`
-				} `graphql:"repository(owner: $owner, name: $name)"`
-			}
-
-			vars := map[string]any{
-				"author": githubv4.String(getViewerQuery.Viewer.Login),
-				"owner":  githubv4.String(params.Owner),
-				"name":   githubv4.String(params.Repo),
-				"prNum":  githubv4.Int(params.PullNumber),
-			}
-
-			if err := client.Query(context.Background(), &getLatestReviewForViewerQuery, vars); err != nil {
-				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
-					"failed to get latest review for current user",
-					err,
-				), nil
-			}
-
-			// Validate there is one review and the state is pending
-			if len(getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes) == 0 {
-				return mcp.NewToolResultError("No pending review found for the viewer"), nil
-			}
-
-			review := getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes[0]
-			if review.State != githubv4.PullRequestReviewStatePending {
-				errText := fmt.Sprintf("The latest review, found at %s is not pending", review.URL)
-				return mcp.NewToolResultError(errText), nil
+			review, errResult := getViewerPendingReview(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if errResult != nil {
+				return errResult, nil
 			}
 
 			// Prepare the mutation
@@ -1717,9 +2391,81 @@ func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 		}
 }
 
+// defaultDiffFilesPerPage is how many files get_pull_request_diff returns per page when only page is given.
+const defaultDiffFilesPerPage = 30
+
+// diffFile is the part of a unified diff for one file.
+type diffFile struct {
+	path string
+	text string
+}
+
+// splitDiff splits a unified diff into its files. The path of a file is the one in the new version, so
+// the new name of a renamed file and the old name of a deleted one.
+func splitDiff(diff string) []diffFile {
+	var files []diffFile
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if header, ok := strings.CutPrefix(line, "diff --git "); ok || len(files) == 0 {
+			file := diffFile{}
+			if i := strings.LastIndex(header, " b/"); ok && i >= 0 {
+				file.path = strings.TrimRight(header[i+len(" b/"):], "\r\n")
+			}
+			files = append(files, file)
+		}
+		files[len(files)-1].text += line
+	}
+	return files
+}
+
+// capDiffHunks cuts the hunks of a file's diff after maxLines lines, replacing the rest with a line
+// saying how many were left out.
+func capDiffHunks(text string, maxLines int) string {
+	var b strings.Builder
+	inHunk, kept, omitted := false, 0, 0
+	flush := func() {
+		if omitted > 0 {
+			if !strings.HasSuffix(b.String(), "\n") {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "[... %d more lines of this hunk omitted]\n", omitted)
+		}
+		kept, omitted = 0, 0
+	}
+	for _, line := range strings.SplitAfter(text, "\n") {
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "@@"):
+			flush()
+			inHunk = true
+		case inHunk && kept >= maxLines:
+			omitted++
+			continue
+		case inHunk:
+			kept++
+		}
+		b.WriteString(line)
+	}
+	flush()
+	return b.String()
+}
+
+// matchesPathOrParent reports whether a path, or one of the directories it is in, matches one of the glob
+// patterns, so that vendor excludes everything below a vendor directory.
+func matchesPathOrParent(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		for p := name; p != "." && p != "/"; p = path.Dir(p) {
+			if matchGlob(pattern, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_diff",
-			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DIFF_DESCRIPTION", "Get the diff of a pull request.")),
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DIFF_DESCRIPTION", "Get the unified diff of a pull request. The diff of a large pull request can be too much to read at once: use files and exclude to only get the files you need, leaving out generated or vendored ones, maxHunkLines to cut long hunks short, and page and perPage to go through the files in batches.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_PULL_REQUEST_DIFF_USER_TITLE", "Get pull request diff"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -1736,16 +2482,53 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			mcp.WithArray("files",
+				mcp.Description("Only return the diff of the files matching one of these paths or glob patterns. * matches within a path segment and ** any number of segments; patterns without a slash match file names at any depth. Examples: 'pkg/server/server.go', '*.go'"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("exclude",
+				mcp.Description("Leave out the files matching one of these glob patterns, or in a directory matching one, such as generated or vendored files. Examples: 'vendor', '*.pb.go', 'package-lock.json'"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithNumber("maxHunkLines",
+				mcp.Description("Maximum number of lines to return of each hunk. The rest of a longer hunk is replaced with a line saying how many lines were left out"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("page",
+				mcp.Description("Page of files to return (min 1). Files are paginated only when page or perPage is set"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description(fmt.Sprintf("Files per page (min 1, max 100, default %d)", defaultDiffFilesPerPage)),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
-				Owner      string
-				Repo       string
-				PullNumber int32
+				Owner        string
+				Repo         string
+				PullNumber   int32
+				Files        []string
+				Exclude      []string
+				MaxHunkLines int
+				Page         int
+				PerPage      int
 			}
 			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			if params.MaxHunkLines < 0 {
+				return mcp.NewToolResultError("maxHunkLines must be at least 1"), nil
+			}
+			if params.Page < 0 || params.PerPage < 0 || params.PerPage > 100 {
+				return mcp.NewToolResultError("page must be at least 1 and perPage between 1 and 100"), nil
+			}
+			for _, pattern := range append(params.Files, params.Exclude...) {
+				if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid glob pattern %q: %s", pattern, err)), nil
+				}
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -1777,8 +2560,58 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 
 			defer func() { _ = resp.Body.Close() }()
 
-			// Return the raw response
-			return mcp.NewToolResultText(string(raw)), nil
+			paginate := params.Page > 0 || params.PerPage > 0
+			if len(params.Files) == 0 && len(params.Exclude) == 0 && params.MaxHunkLines == 0 && !paginate {
+				// Return the raw response
+				return mcp.NewToolResultText(raw), nil
+			}
+
+			var files []diffFile
+			for _, file := range splitDiff(raw) {
+				if file.path == "" {
+					continue
+				}
+				if len(params.Files) > 0 && !matchesPathOrParent(params.Files, file.path) {
+					continue
+				}
+				if matchesPathOrParent(params.Exclude, file.path) {
+					continue
+				}
+				files = append(files, file)
+			}
+			if len(files) == 0 {
+				return mcp.NewToolResultText("No changed files match the filters"), nil
+			}
+
+			var remaining, nextPage int
+			if paginate {
+				page, perPage := max(params.Page, 1), params.PerPage
+				if perPage == 0 {
+					perPage = defaultDiffFilesPerPage
+				}
+				start := min((page-1)*perPage, len(files))
+				end := min(start+perPage, len(files))
+				remaining, nextPage = len(files)-end, page+1
+				files = files[start:end]
+				if len(files) == 0 {
+					return mcp.NewToolResultText(fmt.Sprintf("No files on page %d, the filtered diff has %d files", page, end)), nil
+				}
+			}
+
+			var b strings.Builder
+			for _, file := range files {
+				if params.MaxHunkLines > 0 {
+					file.text = capDiffHunks(file.text, params.MaxHunkLines)
+				}
+				b.WriteString(file.text)
+			}
+			if remaining > 0 {
+				if !strings.HasSuffix(b.String(), "\n") {
+					b.WriteString("\n")
+				}
+				fmt.Fprintf(&b, "[%d more files, request page %d for the next ones]\n", remaining, nextPage)
+			}
+			return mcp.NewToolResultText(b.String()), nil
 		}
 }
 
@@ -1858,6 +2691,235 @@ func RequestCopilotReview(getClient GetClientFn, t translations.TranslationHelpe
 		}
 }
 
+// RequestPullRequestReviewers creates a tool to request reviews of a pull request from users and teams.
+func RequestPullRequestReviewers(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("request_pull_request_reviewers",
+			mcp.WithDescription(t("TOOL_REQUEST_PULL_REQUEST_REVIEWERS_DESCRIPTION", "Request a review of a pull request from users and teams. Requesting a review from someone who already reviewed the pull request asks them to review it again; set rerequest_reviewed to do that for everyone who reviewed it, for instance after pushing changes they asked for.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REQUEST_PULL_REQUEST_REVIEWERS_USER_TITLE", "Request pull request reviewers"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithArray("reviewers",
+				mcp.Description("GitHub usernames to request a review from"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("team_reviewers",
+				mcp.Description("Slugs of teams of the repository owner to request a review from, such as 'backend' for @octo-org/backend"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithBoolean("rerequest_reviewed",
+				mcp.Description("Also request a new review from every user who already reviewed the pull request"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reviewers, err := OptionalStringArrayParam(request, "reviewers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamReviewers, err := OptionalStringArrayParam(request, "team_reviewers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rerequestReviewed, err := OptionalParam[bool](request, "rerequest_reviewed")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(reviewers) == 0 && len(teamReviewers) == 0 && !rerequestReviewed {
+				return mcp.NewToolResultError("at least one of reviewers, team_reviewers or rerequest_reviewed is required"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if rerequestReviewed {
+				pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get pull request",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				// The author can comment on their own pull request but can't be asked to review it
+				seen := map[string]bool{strings.ToLower(pr.GetUser().GetLogin()): true}
+				for _, reviewer := range reviewers {
+					seen[strings.ToLower(reviewer)] = true
+				}
+				opts := &github.ListOptions{PerPage: 100}
+				for {
+					reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, opts)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							"failed to get pull request reviews",
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					for _, review := range reviews {
+						login := review.GetUser().GetLogin()
+						if login == "" || review.GetState() == "PENDING" || seen[strings.ToLower(login)] {
+							continue
+						}
+						seen[strings.ToLower(login)] = true
+						reviewers = append(reviewers, login)
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+				if len(reviewers) == 0 && len(teamReviewers) == 0 {
+					return mcp.NewToolResultError("nobody has reviewed the pull request yet, so there is no review to request again"), nil
+				}
+			}
+
+			pr, resp, err := client.PullRequests.RequestReviewers(ctx, owner, repo, pullNumber, github.ReviewersRequest{
+				Reviewers:     reviewers,
+				TeamReviewers: teamReviewers,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to request reviewers",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to request reviewers: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(pullRequestReviewRequests(pr)), nil
+		}
+}
+
+// RemovePullRequestReviewers creates a tool to cancel review requests of a pull request.
+func RemovePullRequestReviewers(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_pull_request_reviewers",
+			mcp.WithDescription(t("TOOL_REMOVE_PULL_REQUEST_REVIEWERS_DESCRIPTION", "Remove review requests of a pull request from users and teams. Reviews they already submitted are kept.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REMOVE_PULL_REQUEST_REVIEWERS_USER_TITLE", "Remove pull request reviewers"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithArray("reviewers",
+				mcp.Description("GitHub usernames to remove the review request of"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("team_reviewers",
+				mcp.Description("Slugs of teams to remove the review request of"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reviewers, err := OptionalStringArrayParam(request, "reviewers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamReviewers, err := OptionalStringArrayParam(request, "team_reviewers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(reviewers) == 0 && len(teamReviewers) == 0 {
+				return mcp.NewToolResultError("at least one of reviewers or team_reviewers is required"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.PullRequests.RemoveReviewers(ctx, owner, repo, pullNumber, github.ReviewersRequest{
+				Reviewers:     reviewers,
+				TeamReviewers: teamReviewers,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to remove reviewers",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText("review requests removed"), nil
+		}
+}
+
+// PullRequestReviewRequests lists who is asked to review a pull request.
+type PullRequestReviewRequests struct {
+	Reviewers     []string `json:"requested_reviewers"`
+	TeamReviewers []string `json:"requested_teams"`
+}
+
+func pullRequestReviewRequests(pr *github.PullRequest) PullRequestReviewRequests {
+	requests := PullRequestReviewRequests{Reviewers: []string{}, TeamReviewers: []string{}}
+	for _, user := range pr.RequestedReviewers {
+		requests.Reviewers = append(requests.Reviewers, user.GetLogin())
+	}
+	for _, team := range pr.RequestedTeams {
+		requests.TeamReviewers = append(requests.TeamReviewers, team.GetSlug())
+	}
+	return requests
+}
+
 // newGQLString like takes something that approximates a string (of which there are many types in shurcooL/githubv4)
 // and constructs a pointer to it, or nil if the string is empty. This is extremely useful because when we parse
 // params from the MCP request, we need to convert them to types that are pointers of type def strings and it's
@@ -134,6 +134,79 @@ func Test_GetPullRequest(t *testing.T) {
 	}
 }
 
+func Test_GetPullRequest_Reactions(t *testing.T) {
+	mockPR := &github.PullRequest{
+		Number: github.Ptr(42),
+		Title:  github.Ptr("Test PR"),
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		expectedReactions *github.Reactions
+	}{
+		{
+			name: "reactions from the issue are included",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					&github.Issue{
+						Number: github.Ptr(42),
+						Reactions: &github.Reactions{
+							TotalCount: github.Ptr(3),
+							PlusOne:    github.Ptr(2),
+							Heart:      github.Ptr(1),
+							MinusOne:   github.Ptr(0),
+						},
+					},
+				),
+			),
+			expectedReactions: &github.Reactions{
+				TotalCount: github.Ptr(3),
+				PlusOne:    github.Ptr(2),
+				Heart:      github.Ptr(1),
+				MinusOne:   github.Ptr(0),
+			},
+		},
+		{
+			name: "pull request is returned when reactions can't be fetched",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+			),
+			expectedReactions: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned struct {
+				Number    int               `json:"number"`
+				Reactions *github.Reactions `json:"reactions"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, 42, returned.Number)
+			assert.Equal(t, tc.expectedReactions, returned.Reactions)
+		})
+	}
+}
 func Test_UpdatePullRequest(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1397,10 +1470,225 @@ func Test_GetPullRequestStatus(t *testing.T) {
 	}
 }
 
+func Test_GetPullRequestRequiredChecksStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPullRequestRequiredChecksStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pull_request_required_checks_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	mockPR := &github.PullRequest{
+		Number: github.Ptr(42),
+		Base:   &github.PullRequestBranch{Ref: github.Ptr("main")},
+		Head:   &github.PullRequestBranch{SHA: github.Ptr("abcd1234")},
+	}
+	notFound := mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`)
+	noRules := mockResponse(t, http.StatusOK, []any{})
+
+	mockProtection := &github.RequiredStatusChecks{
+		Checks: &[]*github.RequiredStatusCheck{
+			{Context: "build"},
+			{Context: "lint", AppID: github.Ptr(int64(15368))},
+		},
+	}
+	mockRules := []map[string]any{
+		{
+			"type":                "required_status_checks",
+			"ruleset_source_type": "Repository",
+			"ruleset_source":      "owner/repo",
+			"ruleset_id":          7,
+			"parameters": map[string]any{
+				"required_status_checks": []map[string]any{
+					{"context": "build"},
+					{"context": "test"},
+					{"context": "security/scan"},
+					{"context": "e2e"},
+				},
+				"strict_required_status_checks_policy": false,
+			},
+		},
+	}
+	mockCheckRuns := &github.ListCheckRunsResults{
+		Total: github.Ptr(4),
+		CheckRuns: []*github.CheckRun{
+			{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success"), App: &github.App{ID: github.Ptr(int64(15368))}},
+			{Name: github.Ptr("lint"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure"), App: &github.App{ID: github.Ptr(int64(15368))}, DetailsURL: github.Ptr("https://github.com/owner/repo/runs/2")},
+			{Name: github.Ptr("test"), Status: github.Ptr("in_progress"), App: &github.App{ID: github.Ptr(int64(15368))}},
+			// A check by another app with the same name doesn't satisfy lint
+			{Name: github.Ptr("lint"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success"), App: &github.App{ID: github.Ptr(int64(1))}},
+		},
+	}
+	mockStatus := &github.CombinedStatus{
+		State: github.Ptr("success"),
+		Statuses: []*github.RepoStatus{
+			{Context: github.Ptr("security/scan"), State: github.Ptr("success")},
+		},
+	}
+
+	type checksResult struct {
+		Base               string                 `json:"base"`
+		HeadSHA            string                 `json:"head_sha"`
+		Required           []*RequiredCheckStatus `json:"required"`
+		Passing            []string               `json:"passing"`
+		Failing            []string               `json:"failing"`
+		Pending            []string               `json:"pending"`
+		Missing            []string               `json:"missing"`
+		AllRequiredPassing bool                   `json:"all_required_passing"`
+		Warnings           []string               `json:"warnings"`
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       checksResult
+		assertRequired func(t *testing.T, required []*RequiredCheckStatus)
+	}{
+		{
+			name: "combines branch protection and rulesets with check results",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR),
+				mock.WithRequestMatch(mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch, mockProtection),
+				mock.WithRequestMatch(mock.GetReposRulesBranchesByOwnerByRepoByBranch, mockRules),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					expectQueryParams(t, map[string]string{"filter": "latest", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, mockCheckRuns),
+					),
+				),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, mockStatus),
+			),
+			expected: checksResult{
+				Base:    "main",
+				HeadSHA: "abcd1234",
+				Passing: []string{"build", "security/scan"},
+				Failing: []string{"lint"},
+				Pending: []string{"test"},
+				Missing: []string{"e2e"},
+			},
+			assertRequired: func(t *testing.T, required []*RequiredCheckStatus) {
+				require.Len(t, required, 5)
+				assert.Equal(t, "build", required[0].Context)
+				assert.Equal(t, []string{"branch_protection", "ruleset 7"}, required[0].Sources)
+				assert.Equal(t, "lint", required[1].Context)
+				assert.Equal(t, "failure", required[1].Conclusion)
+				assert.Equal(t, "https://github.com/owner/repo/runs/2", required[1].DetailsURL)
+			},
+		},
+		{
+			name: "branch without required checks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR),
+				mock.WithRequestMatchHandler(mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch, notFound),
+				mock.WithRequestMatchHandler(mock.GetReposRulesBranchesByOwnerByRepoByBranch, noRules),
+			),
+			expected: checksResult{
+				Base:               "main",
+				HeadSHA:            "abcd1234",
+				AllRequiredPassing: true,
+			},
+			assertRequired: func(t *testing.T, required []*RequiredCheckStatus) {
+				assert.NotNil(t, required)
+				assert.Empty(t, required)
+			},
+		},
+		{
+			name: "unreadable branch protection falls back to rulesets",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR),
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+				mock.WithRequestMatch(mock.GetReposRulesBranchesByOwnerByRepoByBranch, []map[string]any{
+					{
+						"type":       "required_status_checks",
+						"ruleset_id": 7,
+						"parameters": map[string]any{
+							"required_status_checks": []map[string]any{{"context": "build"}},
+						},
+					},
+				}),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, mockCheckRuns),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, mockStatus),
+			),
+			expected: checksResult{
+				Base:               "main",
+				HeadSHA:            "abcd1234",
+				Passing:            []string{"build"},
+				Failing:            []string{},
+				Pending:            []string{},
+				Missing:            []string{},
+				AllRequiredPassing: true,
+				Warnings:           []string{"branch protection of main could not be read, only rulesets were considered"},
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestRequiredChecksStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			})
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned checksResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+
+			assert.Equal(t, tc.expected.Base, returned.Base)
+			assert.Equal(t, tc.expected.HeadSHA, returned.HeadSHA)
+			assert.Equal(t, tc.expected.Passing, returned.Passing)
+			assert.Equal(t, tc.expected.Failing, returned.Failing)
+			assert.Equal(t, tc.expected.Pending, returned.Pending)
+			assert.Equal(t, tc.expected.Missing, returned.Missing)
+			assert.Equal(t, tc.expected.AllRequiredPassing, returned.AllRequiredPassing)
+			require.Len(t, returned.Warnings, len(tc.expected.Warnings))
+			for i, warning := range tc.expected.Warnings {
+				assert.Contains(t, returned.Warnings[i], warning)
+			}
+			if tc.assertRequired != nil {
+				tc.assertRequired(t, returned.Required)
+			}
+		})
+	}
+}
+
 func Test_UpdatePullRequestBranch(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := UpdatePullRequestBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := UpdatePullRequestBranch(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "update_pull_request_branch", tool.Name)
@@ -1489,7 +1777,7 @@ func Test_UpdatePullRequestBranch(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := UpdatePullRequestBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := UpdatePullRequestBranch(stubGetClientFn(client), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -1517,91 +1805,540 @@ func Test_UpdatePullRequestBranch(t *testing.T) {
 	}
 }
 
-func Test_GetPullRequestComments(t *testing.T) {
-	// Verify tool definition once
-	mockClient := github.NewClient(nil)
-	tool, _ := GetPullRequestComments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+func pullRequestIDQuery(owner, repo string, prNum int32, id string) githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				PullRequest struct {
+					ID githubv4.ID
+				} `graphql:"pullRequest(number: $prNum)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner": githubv4.String(owner),
+			"repo":  githubv4.String(repo),
+			"prNum": githubv4.Int(prNum),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequest": map[string]any{"id": id},
+			},
+		}),
+	)
+}
+
+func Test_UpdatePullRequestBranch_Rebase(t *testing.T) {
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		pullRequestIDQuery("owner", "repo", 42, "PR_kwDODKw3uc6WYN1T"),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				UpdatePullRequestBranch struct {
+					PullRequest struct {
+						HeadRefOid githubv4.GitObjectID
+					}
+				} `graphql:"updatePullRequestBranch(input: $input)"`
+			}{},
+			githubv4.UpdatePullRequestBranchInput{
+				PullRequestID:   githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+				UpdateMethod:    githubv4mock.Ptr(githubv4.PullRequestBranchUpdateMethodRebase),
+				ExpectedHeadOid: githubv4mock.Ptr(githubv4.GitObjectID("abcd1234")),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"updatePullRequestBranch": map[string]any{
+					"pullRequest": map[string]any{"headRefOid": "ef567890"},
+				},
+			}),
+		),
+	)
+	// The REST client is not used when rebasing
+	_, handler := UpdatePullRequestBranch(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient())), stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":           "owner",
+		"repo":            "repo",
+		"pullNumber":      float64(42),
+		"expectedHeadSha": "abcd1234",
+		"method":          "rebase",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	assert.Equal(t, "Pull request branch rebased onto the base branch, its head is now ef567890", textContent.Text)
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(42),
+		"method":     "squash",
+	}))
+	require.NoError(t, err)
+	errorContent := getErrorResult(t, result)
+	assert.Equal(t, "method must be merge or rebase", errorContent.Text)
+}
+
+func Test_EnablePullRequestAutoMerge(t *testing.T) {
+	tool, _ := EnablePullRequestAutoMerge(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "get_pull_request_comments", tool.Name)
+	assert.Equal(t, "enable_pull_request_auto_merge", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "merge_method")
+	assert.Contains(t, tool.InputSchema.Properties, "commit_title")
+	assert.Contains(t, tool.InputSchema.Properties, "commit_message")
+	assert.Contains(t, tool.InputSchema.Properties, "expectedHeadSha")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
 
-	// Setup mock PR comments for success case
-	mockComments := []*github.PullRequestComment{
-		{
-			ID:      github.Ptr(int64(101)),
-			Body:    github.Ptr("This looks good"),
-			HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42#discussion_r101"),
-			User: &github.User{
-				Login: github.Ptr("reviewer1"),
-			},
-			Path:      github.Ptr("file1.go"),
-			Position:  github.Ptr(5),
-			CommitID:  github.Ptr("abcdef123456"),
-			CreatedAt: &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)},
-			UpdatedAt: &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)},
-		},
-		{
-			ID:      github.Ptr(int64(102)),
-			Body:    github.Ptr("Please fix this"),
-			HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42#discussion_r102"),
-			User: &github.User{
-				Login: github.Ptr("reviewer2"),
-			},
-			Path:      github.Ptr("file2.go"),
-			Position:  github.Ptr(10),
-			CommitID:  github.Ptr("abcdef123456"),
-			CreatedAt: &github.Timestamp{Time: time.Now().Add(-12 * time.Hour)},
-			UpdatedAt: &github.Timestamp{Time: time.Now().Add(-12 * time.Hour)},
-		},
-	}
+	enableMutation := struct {
+		EnablePullRequestAutoMerge struct {
+			PullRequest struct {
+				AutoMergeRequest struct {
+					MergeMethod githubv4.PullRequestMergeMethod
+				}
+			}
+		} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+	}{}
 
 	tests := []struct {
-		name             string
-		mockedClient     *http.Client
-		requestArgs      map[string]interface{}
-		expectError      bool
-		expectedComments []*github.PullRequestComment
-		expectedErrMsg   string
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedResult string
 	}{
 		{
-			name: "successful comments fetch",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
-					mock.GetReposPullsCommentsByOwnerByRepoByPullNumber,
-					mockComments,
+			name: "enables auto-merge with a squash commit",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				pullRequestIDQuery("owner", "repo", 42, "PR_kwDODKw3uc6WYN1T"),
+				githubv4mock.NewMutationMatcher(
+					enableMutation,
+					githubv4.EnablePullRequestAutoMergeInput{
+						PullRequestID:  githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+						MergeMethod:    githubv4mock.Ptr(githubv4.PullRequestMergeMethodSquash),
+						CommitHeadline: githubv4mock.Ptr(githubv4.String("Add feature (#42)")),
+						CommitBody:     githubv4mock.Ptr(githubv4.String("Details")),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"enablePullRequestAutoMerge": map[string]any{
+							"pullRequest": map[string]any{
+								"autoMergeRequest": map[string]any{"mergeMethod": "SQUASH"},
+							},
+						},
+					}),
 				),
 			),
-			requestArgs: map[string]interface{}{
-				"owner":      "owner",
-				"repo":       "repo",
-				"pullNumber": float64(42),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"pullNumber":     float64(42),
+				"merge_method":   "squash",
+				"commit_title":   "Add feature (#42)",
+				"commit_message": "Details",
 			},
-			expectError:      false,
-			expectedComments: mockComments,
+			expectedResult: "auto-merge enabled, the pull request will be merged with the squash method once its requirements are met",
 		},
 		{
-			name: "comments fetch fails",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.GetReposPullsCommentsByOwnerByRepoByPullNumber,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
-					}),
+			name: "auto-merge not allowed",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				pullRequestIDQuery("owner", "repo", 42, "PR_kwDODKw3uc6WYN1T"),
+				githubv4mock.NewMutationMatcher(
+					enableMutation,
+					githubv4.EnablePullRequestAutoMergeInput{
+						PullRequestID: githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+					},
+					nil,
+					githubv4mock.ErrorResponse("Pull request Auto merge is not allowed for this repository"),
 				),
 			),
-			requestArgs: map[string]interface{}{
+			requestArgs: map[string]any{
 				"owner":      "owner",
 				"repo":       "repo",
-				"pullNumber": float64(999),
+				"pullNumber": float64(42),
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to get pull request comments",
+			expectedResult: "failed to enable auto-merge: Pull request Auto merge is not allowed for this repository",
+		},
+		{
+			name:         "invalid merge method",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"merge_method": "fast-forward",
+			},
+			expectError:    true,
+			expectedResult: "merge_method must be merge, squash or rebase",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := EnablePullRequestAutoMerge(stubGetGQLClientFn(githubv4.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedResult)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedResult, textContent.Text)
+		})
+	}
+}
+
+func Test_DisablePullRequestAutoMerge(t *testing.T) {
+	tool, _ := DisablePullRequestAutoMerge(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "disable_pull_request_auto_merge", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		pullRequestIDQuery("owner", "repo", 42, "PR_kwDODKw3uc6WYN1T"),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				DisablePullRequestAutoMerge struct {
+					PullRequest struct {
+						ID githubv4.ID
+					}
+				} `graphql:"disablePullRequestAutoMerge(input: $input)"`
+			}{},
+			githubv4.DisablePullRequestAutoMergeInput{
+				PullRequestID: githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{}),
+		),
+	)
+	_, handler := DisablePullRequestAutoMerge(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(42),
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	assert.Equal(t, "auto-merge disabled", textContent.Text)
+}
+
+func Test_RequestPullRequestReviewers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RequestPullRequestReviewers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "request_pull_request_reviewers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "reviewers")
+	assert.Contains(t, tool.InputSchema.Properties, "team_reviewers")
+	assert.Contains(t, tool.InputSchema.Properties, "rerequest_reviewed")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	requestedPR := &github.PullRequest{
+		Number:             github.Ptr(42),
+		RequestedReviewers: []*github.User{{Login: github.Ptr("alice")}, {Login: github.Ptr("bob")}},
+		RequestedTeams:     []*github.Team{{Slug: github.Ptr("backend")}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedResult string
+	}{
+		{
+			name: "requests users and teams",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]any{
+						"reviewers":      []any{"alice", "bob"},
+						"team_reviewers": []any{"backend"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, requestedPR),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"pullNumber":     float64(42),
+				"reviewers":      []any{"alice", "bob"},
+				"team_reviewers": []any{"backend"},
+			},
+			expectedResult: `{"requested_reviewers": ["alice", "bob"], "requested_teams": ["backend"]}`,
+		},
+		{
+			name: "re-requests previous reviewers",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					&github.PullRequest{Number: github.Ptr(42), User: &github.User{Login: github.Ptr("author")}},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+					[]*github.PullRequestReview{
+						{User: &github.User{Login: github.Ptr("author")}, State: github.Ptr("COMMENTED")},
+						{User: &github.User{Login: github.Ptr("Alice")}, State: github.Ptr("CHANGES_REQUESTED")},
+						{User: &github.User{Login: github.Ptr("bob")}, State: github.Ptr("APPROVED")},
+						{User: &github.User{Login: github.Ptr("bob")}, State: github.Ptr("COMMENTED")},
+						{User: &github.User{Login: github.Ptr("carol")}, State: github.Ptr("PENDING")},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]any{
+						"reviewers": []any{"alice", "bob"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, requestedPR),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":              "owner",
+				"repo":               "repo",
+				"pullNumber":         float64(42),
+				"reviewers":          []any{"alice"},
+				"rerequest_reviewed": true,
+			},
+			expectedResult: `{"requested_reviewers": ["alice", "bob"], "requested_teams": ["backend"]}`,
+		},
+		{
+			name: "nobody reviewed yet",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					&github.PullRequest{Number: github.Ptr(42), User: &github.User{Login: github.Ptr("author")}},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+					[]*github.PullRequestReview{},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":              "owner",
+				"repo":               "repo",
+				"pullNumber":         float64(42),
+				"rerequest_reviewed": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "nobody has reviewed the pull request yet",
+		},
+		{
+			name:         "nobody to request",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one of reviewers, team_reviewers or rerequest_reviewed is required",
+		},
+		{
+			name: "request fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Reviews may only be requested from collaborators."}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"reviewers":  []any{"stranger"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to request reviewers",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RequestPullRequestReviewers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedResult, textContent.Text)
+		})
+	}
+}
+
+func Test_RemovePullRequestReviewers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemovePullRequestReviewers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_pull_request_reviewers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "removes a team review request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]any{
+						"reviewers":      []any{},
+						"team_reviewers": []any{"backend"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.PullRequest{Number: github.Ptr(42)}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"pullNumber":     float64(42),
+				"team_reviewers": []any{"backend"},
+			},
+		},
+		{
+			name:         "nobody to remove",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one of reviewers or team_reviewers is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemovePullRequestReviewers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.Equal(t, "review requests removed", textContent.Text)
+		})
+	}
+}
+
+func Test_GetPullRequestComments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPullRequestComments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pull_request_comments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	// Setup mock PR comments for success case
+	mockComments := []*github.PullRequestComment{
+		{
+			ID:      github.Ptr(int64(101)),
+			Body:    github.Ptr("This looks good"),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42#discussion_r101"),
+			User: &github.User{
+				Login: github.Ptr("reviewer1"),
+			},
+			Path:      github.Ptr("file1.go"),
+			Position:  github.Ptr(5),
+			CommitID:  github.Ptr("abcdef123456"),
+			CreatedAt: &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)},
+			UpdatedAt: &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)},
+		},
+		{
+			ID:      github.Ptr(int64(102)),
+			Body:    github.Ptr("Please fix this"),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42#discussion_r102"),
+			User: &github.User{
+				Login: github.Ptr("reviewer2"),
+			},
+			Path:      github.Ptr("file2.go"),
+			Position:  github.Ptr(10),
+			CommitID:  github.Ptr("abcdef123456"),
+			CreatedAt: &github.Timestamp{Time: time.Now().Add(-12 * time.Hour)},
+			UpdatedAt: &github.Timestamp{Time: time.Now().Add(-12 * time.Hour)},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedComments []*github.PullRequestComment
+		expectedErrMsg   string
+	}{
+		{
+			name: "successful comments fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsCommentsByOwnerByRepoByPullNumber,
+					mockComments,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError:      false,
+			expectedComments: mockComments,
+		},
+		{
+			name: "comments fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsCommentsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request comments",
 		},
 	}
 
@@ -1671,106 +2408,245 @@ func Test_GetPullRequestReviews(t *testing.T) {
 			User: &github.User{
 				Login: github.Ptr("approver"),
 			},
-			CommitID:    github.Ptr("abcdef123456"),
-			SubmittedAt: &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)},
+			CommitID:    github.Ptr("abcdef123456"),
+			SubmittedAt: &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)},
+		},
+		{
+			ID:      github.Ptr(int64(202)),
+			State:   github.Ptr("CHANGES_REQUESTED"),
+			Body:    github.Ptr("Please address the following issues"),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42#pullrequestreview-202"),
+			User: &github.User{
+				Login: github.Ptr("reviewer"),
+			},
+			CommitID:    github.Ptr("abcdef123456"),
+			SubmittedAt: &github.Timestamp{Time: time.Now().Add(-12 * time.Hour)},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedReviews []*github.PullRequestReview
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful reviews fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+					mockReviews,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError:     false,
+			expectedReviews: mockReviews,
+		},
+		{
+			name: "reviews fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request reviews",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestReviews(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedReviews []*github.PullRequestReview
+			err = json.Unmarshal([]byte(textContent.Text), &returnedReviews)
+			require.NoError(t, err)
+			assert.Len(t, returnedReviews, len(tc.expectedReviews))
+			for i, review := range returnedReviews {
+				assert.Equal(t, *tc.expectedReviews[i].ID, *review.ID)
+				assert.Equal(t, *tc.expectedReviews[i].State, *review.State)
+				assert.Equal(t, *tc.expectedReviews[i].Body, *review.Body)
+				assert.Equal(t, *tc.expectedReviews[i].User.Login, *review.User.Login)
+				assert.Equal(t, *tc.expectedReviews[i].HTMLURL, *review.HTMLURL)
+			}
+		})
+	}
+}
+
+func Test_SubmitPullRequestReview(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SubmitPullRequestReview(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "submit_pull_request_review", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "commitID")
+	assert.Contains(t, tool.InputSchema.Properties, "comments")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "event"})
+
+	mockReview := &github.PullRequestReview{
+		ID:      github.Ptr(int64(301)),
+		State:   github.Ptr("CHANGES_REQUESTED"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42#pullrequestreview-301"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "submits a review with inline comments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsReviewsByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"commit_id": "abc123",
+						"body":      "A few things to fix",
+						"event":     "REQUEST_CHANGES",
+						"comments": []interface{}{
+							map[string]interface{}{"path": "main.go", "body": "Handle the error", "line": float64(12), "side": "RIGHT"},
+							map[string]interface{}{"path": "old.go", "body": "Why remove this?", "line": float64(8), "side": "LEFT", "start_line": float64(5), "start_side": "LEFT"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockReview),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"event":      "REQUEST_CHANGES",
+				"body":       "A few things to fix",
+				"commitID":   "abc123",
+				"comments": []interface{}{
+					map[string]interface{}{"path": "main.go", "body": "Handle the error", "line": float64(12)},
+					map[string]interface{}{"path": "old.go", "body": "Why remove this?", "line": float64(8), "side": "LEFT", "startLine": float64(5)},
+				},
+			},
 		},
 		{
-			ID:      github.Ptr(int64(202)),
-			State:   github.Ptr("CHANGES_REQUESTED"),
-			Body:    github.Ptr("Please address the following issues"),
-			HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42#pullrequestreview-202"),
-			User: &github.User{
-				Login: github.Ptr("reviewer"),
+			name:         "requires a body to request changes",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"event":      "REQUEST_CHANGES",
 			},
-			CommitID:    github.Ptr("abcdef123456"),
-			SubmittedAt: &github.Timestamp{Time: time.Now().Add(-12 * time.Hour)},
+			expectError:    true,
+			expectedErrMsg: "body is required to request changes",
 		},
-	}
-
-	tests := []struct {
-		name            string
-		mockedClient    *http.Client
-		requestArgs     map[string]interface{}
-		expectError     bool
-		expectedReviews []*github.PullRequestReview
-		expectedErrMsg  string
-	}{
 		{
-			name: "successful reviews fetch",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
-					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
-					mockReviews,
-				),
-			),
+			name:         "refuses comments without a line",
+			mockedClient: mock.NewMockedHTTPClient(),
 			requestArgs: map[string]interface{}{
 				"owner":      "owner",
 				"repo":       "repo",
 				"pullNumber": float64(42),
+				"event":      "COMMENT",
+				"comments": []interface{}{
+					map[string]interface{}{"path": "main.go", "body": "Nit"},
+				},
 			},
-			expectError:     false,
-			expectedReviews: mockReviews,
+			expectError:    true,
+			expectedErrMsg: "comment 1 needs a path, a body and a line",
 		},
 		{
-			name: "reviews fetch fails",
+			name:         "refuses ranges that end before they start",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"event":      "COMMENT",
+				"comments": []interface{}{
+					map[string]interface{}{"path": "main.go", "body": "Nit", "line": float64(3), "startLine": float64(3)},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "comment 1 on main.go has a startLine that is not before its line",
+		},
+		{
+			name: "reports comments GitHub can't place",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
-					}),
+					mock.PostReposPullsReviewsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Unprocessable Entity", "errors": ["Line could not be resolved"]}`),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner":      "owner",
 				"repo":       "repo",
-				"pullNumber": float64(999),
+				"pullNumber": float64(42),
+				"event":      "COMMENT",
+				"comments": []interface{}{
+					map[string]interface{}{"path": "main.go", "body": "Nit", "line": float64(999)},
+				},
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to get pull request reviews",
+			expectedErrMsg: "failed to submit pull request review",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := GetPullRequestReviews(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
+			_, handler := SubmitPullRequestReview(stubGetClientFn(client), translations.NullTranslationHelper)
 
-			// Call handler
-			result, err := handler(context.Background(), request)
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
 
-			// Verify results
 			if tc.expectError {
-				require.NoError(t, err)
-				require.True(t, result.IsError)
-				errorContent := getErrorResult(t, result)
-				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
-			require.False(t, result.IsError)
-
-			// Parse the result and get the text content if no error
-			textContent := getTextResult(t, result)
-
-			// Unmarshal and verify the result
-			var returnedReviews []*github.PullRequestReview
-			err = json.Unmarshal([]byte(textContent.Text), &returnedReviews)
-			require.NoError(t, err)
-			assert.Len(t, returnedReviews, len(tc.expectedReviews))
-			for i, review := range returnedReviews {
-				assert.Equal(t, *tc.expectedReviews[i].ID, *review.ID)
-				assert.Equal(t, *tc.expectedReviews[i].State, *review.State)
-				assert.Equal(t, *tc.expectedReviews[i].Body, *review.Body)
-				assert.Equal(t, *tc.expectedReviews[i].User.Login, *review.User.Login)
-				assert.Equal(t, *tc.expectedReviews[i].HTMLURL, *review.HTMLURL)
-			}
+			assert.JSONEq(t, `{"id": 301, "state": "CHANGES_REQUESTED", "html_url": "https://github.com/owner/repo/pull/42#pullrequestreview-301", "comments": 2}`, getTextResult(t, result).Text)
 		})
 	}
 }
@@ -2477,9 +3353,8 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 
 					reviews: []getLatestPendingReviewQueryReview{
 						{
-							id:    "PR_kwDODKw3uc6WYN1T",
-							state: "PENDING",
-							url:   "https://github.com/owner/repo/pull/42",
+							id:  "PR_kwDODKw3uc6WYN1T",
+							url: "https://github.com/owner/repo/pull/42",
 						},
 					},
 				}),
@@ -2580,9 +3455,8 @@ func TestSubmitPendingPullRequestReview(t *testing.T) {
 
 					reviews: []getLatestPendingReviewQueryReview{
 						{
-							id:    "PR_kwDODKw3uc6WYN1T",
-							state: "PENDING",
-							url:   "https://github.com/owner/repo/pull/42",
+							id:  "PR_kwDODKw3uc6WYN1T",
+							url: "https://github.com/owner/repo/pull/42",
 						},
 					},
 				}),
@@ -2674,9 +3548,8 @@ func TestDeletePendingPullRequestReview(t *testing.T) {
 
 					reviews: []getLatestPendingReviewQueryReview{
 						{
-							id:    "PR_kwDODKw3uc6WYN1T",
-							state: "PENDING",
-							url:   "https://github.com/owner/repo/pull/42",
+							id:  "PR_kwDODKw3uc6WYN1T",
+							url: "https://github.com/owner/repo/pull/42",
 						},
 					},
 				}),
@@ -2727,6 +3600,137 @@ func TestDeletePendingPullRequestReview(t *testing.T) {
 	}
 }
 
+func TestGetPendingPullRequestReview(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := GetPendingPullRequestReview(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pending_pull_request_review", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	pendingReviewQuery := githubv4mock.NewQueryMatcher(
+		struct {
+			Node struct {
+				PullRequestReview struct {
+					Body     githubv4.String
+					Comments struct {
+						TotalCount githubv4.Int
+						Nodes      []struct {
+							Path      githubv4.String
+							Body      githubv4.String
+							Line      *githubv4.Int
+							StartLine *githubv4.Int
+						}
+					} `graphql:"comments(first: 100)"`
+				} `graphql:"... on PullRequestReview"`
+			} `graphql:"node(id: $id)"`
+		}{},
+		map[string]any{
+			"id": githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"node": map[string]any{
+				"body": "Looks mostly good",
+				"comments": map[string]any{
+					"totalCount": 2,
+					"nodes": []any{
+						map[string]any{"path": "file.go", "body": "Typo", "line": 10, "startLine": nil},
+						map[string]any{"path": "main.go", "body": "Split this up", "line": 20, "startLine": 15},
+					},
+				},
+			},
+		}),
+	)
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedResult     string
+	}{
+		{
+			name: "returns the pending review with its draft comments",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				viewerQuery("williammartin"),
+				getLatestPendingReviewQuery(getLatestPendingReviewQueryParams{
+					author: "williammartin",
+					owner:  "owner",
+					repo:   "repo",
+					prNum:  42,
+
+					reviews: []getLatestPendingReviewQueryReview{
+						{
+							id:  "PR_kwDODKw3uc6WYN1T",
+							url: "https://github.com/owner/repo/pull/42#pullrequestreview-1",
+						},
+					},
+				}),
+				pendingReviewQuery,
+			),
+			expectedResult: `{
+				"url": "https://github.com/owner/repo/pull/42#pullrequestreview-1",
+				"body": "Looks mostly good",
+				"total_comments": 2,
+				"comments": [
+					{"path": "file.go", "line": 10, "body": "Typo"},
+					{"path": "main.go", "line": 20, "start_line": 15, "body": "Split this up"}
+				]
+			}`,
+		},
+		{
+			name: "no pending review",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				viewerQuery("williammartin"),
+				getLatestPendingReviewQuery(getLatestPendingReviewQueryParams{
+					author: "williammartin",
+					owner:  "owner",
+					repo:   "repo",
+					prNum:  42,
+				}),
+			),
+			expectToolError:    true,
+			expectedToolErrMsg: "No pending review found for the viewer",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := GetPendingPullRequestReview(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			})
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, tc.expectedResult, textContent.Text)
+		})
+	}
+}
+
 func TestGetPullRequestDiff(t *testing.T) {
 	t.Parallel()
 
@@ -2811,6 +3815,149 @@ index 5d6e7b2..8a4f5c3 100644
 	}
 }
 
+func TestGetPullRequestDiff_Filters(t *testing.T) {
+	t.Parallel()
+
+	stubbedDiff := `diff --git a/README.md b/README.md
+index 5d6e7b2..8a4f5c3 100644
+--- a/README.md
++++ b/README.md
+@@ -1,2 +1,5 @@
+ # Hello-World
++one
++two
++three
+diff --git a/pkg/server/server.go b/pkg/server/server.go
+index 1111111..2222222 100644
+--- a/pkg/server/server.go
++++ b/pkg/server/server.go
+@@ -10,1 +10,2 @@
+ func main() {
++	run()
+diff --git a/vendor/lib/lib.go b/vendor/lib/lib.go
+deleted file mode 100644
+index 3333333..0000000
+--- a/vendor/lib/lib.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package lib
+`
+
+	readme := `diff --git a/README.md b/README.md
+index 5d6e7b2..8a4f5c3 100644
+--- a/README.md
++++ b/README.md
+@@ -1,2 +1,5 @@
+ # Hello-World
++one
++two
++three
+`
+	server := `diff --git a/pkg/server/server.go b/pkg/server/server.go
+index 1111111..2222222 100644
+--- a/pkg/server/server.go
++++ b/pkg/server/server.go
+@@ -10,1 +10,2 @@
+ func main() {
++	run()
+`
+
+	tests := []struct {
+		name               string
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedResult     string
+	}{
+		{
+			name:           "only the requested files",
+			requestArgs:    map[string]any{"files": []any{"*.go"}, "exclude": []any{"vendor"}},
+			expectedResult: server,
+		},
+		{
+			name:           "exact path",
+			requestArgs:    map[string]any{"files": []any{"pkg/server/server.go"}},
+			expectedResult: server,
+		},
+		{
+			name:           "excluded directories",
+			requestArgs:    map[string]any{"exclude": []any{"vendor", "pkg/**"}},
+			expectedResult: readme,
+		},
+		{
+			name:        "hunks cut short",
+			requestArgs: map[string]any{"files": []any{"README.md"}, "maxHunkLines": float64(2)},
+			expectedResult: `diff --git a/README.md b/README.md
+index 5d6e7b2..8a4f5c3 100644
+--- a/README.md
++++ b/README.md
+@@ -1,2 +1,5 @@
+ # Hello-World
++one
+[... 2 more lines of this hunk omitted]
+`,
+		},
+		{
+			name:           "first page of files",
+			requestArgs:    map[string]any{"perPage": float64(1)},
+			expectedResult: readme + "[2 more files, request page 2 for the next ones]\n",
+		},
+		{
+			name:           "later page of files",
+			requestArgs:    map[string]any{"page": float64(2), "perPage": float64(1)},
+			expectedResult: server + "[1 more files, request page 3 for the next ones]\n",
+		},
+		{
+			name:           "no matching files",
+			requestArgs:    map[string]any{"files": []any{"*.rs"}},
+			expectedResult: "No changed files match the filters",
+		},
+		{
+			name:               "invalid pattern",
+			requestArgs:        map[string]any{"exclude": []any{"[vendor"}},
+			expectToolError:    true,
+			expectedToolErrMsg: `invalid glob pattern "[vendor"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					expectPath(t, "/repos/owner/repo/pulls/42").andThen(
+						mockResponse(t, http.StatusOK, stubbedDiff),
+					),
+				),
+			))
+			_, handler := GetPullRequestDiff(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedResult, textContent.Text)
+		})
+	}
+}
+
 func viewerQuery(login string) githubv4mock.Matcher {
 	return githubv4mock.NewQueryMatcher(
 		struct {
@@ -2828,9 +3975,8 @@ func viewerQuery(login string) githubv4mock.Matcher {
 }
 
 type getLatestPendingReviewQueryReview struct {
-	id    string
-	state string
-	url   string
+	id  string
+	url string
 }
 
 type getLatestPendingReviewQueryParams struct {
@@ -2843,17 +3989,23 @@ type getLatestPendingReviewQueryParams struct {
 }
 
 func getLatestPendingReviewQuery(p getLatestPendingReviewQueryParams) githubv4mock.Matcher {
+	nodes := make([]any, 0, len(p.reviews))
+	for _, review := range p.reviews {
+		nodes = append(nodes, map[string]any{
+			"id":  review.id,
+			"url": review.url,
+		})
+	}
 	return githubv4mock.NewQueryMatcher(
 		struct {
 			Repository struct {
 				PullRequest struct {
 					Reviews struct {
 						Nodes []struct {
-							ID    githubv4.ID
-							State githubv4.PullRequestReviewState
-							URL   githubv4.URI
+							ID  githubv4.ID
+							URL githubv4.URI
 						}
-					} `graphql:"reviews(first: 1, author: $author)"`
+					} `graphql:"reviews(first: 1, author: $author, states: [PENDING])"`
 				} `graphql:"pullRequest(number: $prNum)"`
 			} `graphql:"repository(owner: $owner, name: $name)"`
 		}{},
@@ -2868,13 +4020,7 @@ func getLatestPendingReviewQuery(p getLatestPendingReviewQueryParams) githubv4mo
 				"repository": map[string]any{
 					"pullRequest": map[string]any{
 						"reviews": map[string]any{
-							"nodes": []any{
-								map[string]any{
-									"id":    p.reviews[0].id,
-									"state": p.reviews[0].state,
-									"url":   p.reviews[0].url,
-								},
-							},
+							"nodes": nodes,
 						},
 					},
 				},
@@ -0,0 +1,525 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// reactionContents are the reaction types of the REST API, in the order GitHub shows them.
+var reactionContents = []string{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"}
+
+// graphQLReactionContents maps the REST reaction types to their GraphQL names.
+var graphQLReactionContents = map[string]githubv4.ReactionContent{
+	"+1":       githubv4.ReactionContentThumbsUp,
+	"-1":       githubv4.ReactionContentThumbsDown,
+	"laugh":    githubv4.ReactionContentLaugh,
+	"confused": githubv4.ReactionContentConfused,
+	"heart":    githubv4.ReactionContentHeart,
+	"hooray":   githubv4.ReactionContentHooray,
+	"rocket":   githubv4.ReactionContentRocket,
+	"eyes":     githubv4.ReactionContentEyes,
+}
+
+// reactionSubject is what a reaction is on, as given by the subject_type parameter and the parameter
+// identifying a subject of that type.
+type reactionSubject struct {
+	Type             string
+	IssueNumber      int
+	CommentID        int64
+	DiscussionNumber int
+	CommentNodeID    string
+}
+
+// isDiscussion reports whether the subject is only reachable through the GraphQL API.
+func (s reactionSubject) isDiscussion() bool {
+	return s.Type == "discussion" || s.Type == "discussion_comment"
+}
+
+// reactionSubjectParams reads the subject of the reaction tools from a request, checking the parameter
+// its subject_type needs is set. Discussion subject types are only accepted when discussions is set.
+func reactionSubjectParams(request mcp.CallToolRequest, discussions bool) (reactionSubject, error) {
+	subjectType, err := RequiredParam[string](request, "subject_type")
+	if err != nil {
+		return reactionSubject{}, err
+	}
+	subject := reactionSubject{Type: subjectType}
+	switch subjectType {
+	case "issue":
+		if subject.IssueNumber, err = OptionalIntParam(request, "issue_number"); err != nil {
+			return reactionSubject{}, err
+		}
+		if subject.IssueNumber == 0 {
+			return reactionSubject{}, errors.New("issue_number is required when subject_type is issue")
+		}
+	case "issue_comment", "pull_request_review_comment", "commit_comment":
+		commentID, err := OptionalIntParam(request, "comment_id")
+		if err != nil {
+			return reactionSubject{}, err
+		}
+		if commentID == 0 {
+			return reactionSubject{}, fmt.Errorf("comment_id is required when subject_type is %s", subjectType)
+		}
+		subject.CommentID = int64(commentID)
+	case "discussion":
+		if !discussions {
+			return reactionSubject{}, fmt.Errorf("unsupported subject_type: %s", subjectType)
+		}
+		if subject.DiscussionNumber, err = OptionalIntParam(request, "discussion_number"); err != nil {
+			return reactionSubject{}, err
+		}
+		if subject.DiscussionNumber == 0 {
+			return reactionSubject{}, errors.New("discussion_number is required when subject_type is discussion")
+		}
+	case "discussion_comment":
+		if !discussions {
+			return reactionSubject{}, fmt.Errorf("unsupported subject_type: %s", subjectType)
+		}
+		if subject.CommentNodeID, err = OptionalParam[string](request, "comment_node_id"); err != nil {
+			return reactionSubject{}, err
+		}
+		if subject.CommentNodeID == "" {
+			return reactionSubject{}, errors.New("comment_node_id is required when subject_type is discussion_comment")
+		}
+	default:
+		return reactionSubject{}, fmt.Errorf("unsupported subject_type: %s", subjectType)
+	}
+	return subject, nil
+}
+
+// listSubjectReactions lists the reactions on an issue or comment through the REST API.
+func listSubjectReactions(ctx context.Context, client *github.Client, owner, repo string, subject reactionSubject, opts *github.ListReactionOptions) ([]*github.Reaction, *github.Response, error) {
+	switch subject.Type {
+	case "issue":
+		return client.Reactions.ListIssueReactions(ctx, owner, repo, subject.IssueNumber, opts)
+	case "issue_comment":
+		return client.Reactions.ListIssueCommentReactions(ctx, owner, repo, subject.CommentID, opts)
+	case "pull_request_review_comment":
+		return client.Reactions.ListPullRequestCommentReactions(ctx, owner, repo, subject.CommentID, opts)
+	default:
+		return client.Reactions.ListCommentReactions(ctx, owner, repo, subject.CommentID, opts)
+	}
+}
+
+// getReactionCounts gets the reaction rollup GitHub keeps on an issue or comment.
+func getReactionCounts(ctx context.Context, client *github.Client, owner, repo string, subject reactionSubject) (*github.Reactions, *github.Response, error) {
+	switch subject.Type {
+	case "issue":
+		issue, resp, err := client.Issues.Get(ctx, owner, repo, subject.IssueNumber)
+		return issue.GetReactions(), resp, err
+	case "issue_comment":
+		comment, resp, err := client.Issues.GetComment(ctx, owner, repo, subject.CommentID)
+		return comment.GetReactions(), resp, err
+	case "pull_request_review_comment":
+		comment, resp, err := client.PullRequests.GetComment(ctx, owner, repo, subject.CommentID)
+		return comment.GetReactions(), resp, err
+	default:
+		comment, resp, err := client.Repositories.GetComment(ctx, owner, repo, subject.CommentID)
+		return comment.GetReactions(), resp, err
+	}
+}
+
+// getReactionSubjectID resolves the node ID of a discussion or discussion comment subject. The node ID of a
+// discussion comment names no repository, so it must be one of a discussion of owner/repo.
+func getReactionSubjectID(ctx context.Context, client *githubv4.Client, owner, repo string, subject reactionSubject) (githubv4.ID, error) {
+	if subject.Type == "discussion_comment" {
+		var query struct {
+			Node struct {
+				DiscussionComment struct {
+					Discussion struct {
+						Repository struct {
+							NameWithOwner githubv4.String
+						}
+					}
+				} `graphql:"... on DiscussionComment"`
+			} `graphql:"node(id: $id)"`
+		}
+		if err := client.Query(ctx, &query, map[string]any{"id": githubv4.ID(subject.CommentNodeID)}); err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(string(query.Node.DiscussionComment.Discussion.Repository.NameWithOwner), owner+"/"+repo) {
+			return nil, fmt.Errorf("%s is not a comment on a discussion of %s/%s", subject.CommentNodeID, owner, repo)
+		}
+		return githubv4.ID(subject.CommentNodeID), nil
+	}
+	var query struct {
+		Repository struct {
+			Discussion struct {
+				ID githubv4.ID
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	if err := client.Query(ctx, &query, map[string]any{
+		"owner":            githubv4.String(owner),
+		"repo":             githubv4.String(repo),
+		"discussionNumber": githubv4.Int(subject.DiscussionNumber), // #nosec G115 - discussion numbers are always small positive integers
+	}); err != nil {
+		return nil, err
+	}
+	return query.Repository.Discussion.ID, nil
+}
+
+// withReactionSubjectParams adds the subject parameters of add_reaction and remove_reaction.
+func withReactionSubjectParams() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description(DescriptionRepositoryOwner),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description(DescriptionRepositoryName),
+		),
+		mcp.WithString("subject_type",
+			mcp.Required(),
+			mcp.Description("What to react to: an issue or pull request, an issue or pull request conversation comment, a pull request review comment, a commit comment, a discussion or a discussion comment"),
+			mcp.Enum("issue", "issue_comment", "pull_request_review_comment", "commit_comment", "discussion", "discussion_comment"),
+		),
+		mcp.WithNumber("issue_number",
+			mcp.Description("The number of the issue or pull request, required when subject_type is issue"),
+		),
+		mcp.WithNumber("comment_id",
+			mcp.Description("The ID of the comment, required for the issue, pull request review and commit comment subject types"),
+		),
+		mcp.WithNumber("discussion_number",
+			mcp.Description("The number of the discussion, required when subject_type is discussion"),
+		),
+		mcp.WithString("comment_node_id",
+			mcp.Description("node_id of the discussion comment as returned by get_discussion_comments, required when subject_type is discussion_comment"),
+		),
+		mcp.WithString("content",
+			mcp.Required(),
+			mcp.Description("The reaction emoji"),
+			mcp.Enum(reactionContents...),
+		),
+	}
+}
+
+// ListReactions creates a tool to list who reacted to an issue, pull request or comment, and with what.
+func ListReactions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_reactions",
+			mcp.WithDescription(t("TOOL_LIST_REACTIONS_DESCRIPTION", "List the individual reactions to an issue, pull request or comment, including who reacted with which emoji, or with counts_only just the number of reactions per emoji.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REACTIONS_USER_TITLE", "List reactions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("subject_type",
+				mcp.Required(),
+				mcp.Description("What was reacted to: an issue or pull request, an issue or pull request conversation comment, a pull request review comment, or a commit comment"),
+				mcp.Enum("issue", "issue_comment", "pull_request_review_comment", "commit_comment"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Description("The number of the issue or pull request, required when subject_type is issue"),
+			),
+			mcp.WithNumber("comment_id",
+				mcp.Description("The ID of the comment, required for the comment subject types"),
+			),
+			mcp.WithString("content",
+				mcp.Description("Only return reactions of this type"),
+				mcp.Enum(reactionContents...),
+			),
+			mcp.WithBoolean("counts_only",
+				mcp.Description("Return the number of reactions per emoji instead of the individual reactions"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subject, err := reactionSubjectParams(request, false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := OptionalParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			countsOnly, err := OptionalParam[bool](request, "counts_only")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListReactionOptions{
+				Content: content,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+
+			if countsOnly {
+				counts, resp, err := getReactionCounts(ctx, client, owner, repo, subject)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get reaction counts",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return MarshalledTextResult(counts), nil
+			}
+
+			reactions, resp, err := listSubjectReactions(ctx, client, owner, repo, subject, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list reactions",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(reactions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// AddReaction creates a tool to react to an issue, pull request, comment or discussion.
+func AddReaction(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := append([]mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_ADD_REACTION_DESCRIPTION", "React with an emoji to an issue, pull request, comment or discussion, for example +1 to acknowledge a request or eyes to show it is being looked at. Reacting again with the same emoji has no effect.")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        t("TOOL_ADD_REACTION_USER_TITLE", "Add reaction"),
+			ReadOnlyHint: ToBoolPtr(false),
+		}),
+	}, withReactionSubjectParams()...)
+
+	return mcp.NewTool("add_reaction", options...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subject, err := reactionSubjectParams(request, true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := RequiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			graphQLContent, ok := graphQLReactionContents[content]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("unsupported content: %s", content)), nil
+			}
+
+			if subject.isDiscussion() {
+				client, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+				subjectID, err := getReactionSubjectID(ctx, client, owner, repo, subject)
+				if err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get discussion", err), nil
+				}
+
+				var mutation struct {
+					AddReaction struct {
+						Reaction struct {
+							Content githubv4.ReactionContent
+						}
+					} `graphql:"addReaction(input: $input)"`
+				}
+				input := githubv4.AddReactionInput{SubjectID: subjectID, Content: graphQLContent}
+				if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to add reaction", err), nil
+				}
+				return mcp.NewToolResultText(fmt.Sprintf("added %s reaction to %s", content, strings.ReplaceAll(subject.Type, "_", " "))), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var reaction *github.Reaction
+			var resp *github.Response
+			switch subject.Type {
+			case "issue":
+				reaction, resp, err = client.Reactions.CreateIssueReaction(ctx, owner, repo, subject.IssueNumber, content)
+			case "issue_comment":
+				reaction, resp, err = client.Reactions.CreateIssueCommentReaction(ctx, owner, repo, subject.CommentID, content)
+			case "pull_request_review_comment":
+				reaction, resp, err = client.Reactions.CreatePullRequestCommentReaction(ctx, owner, repo, subject.CommentID, content)
+			default:
+				reaction, resp, err = client.Reactions.CreateCommentReaction(ctx, owner, repo, subject.CommentID, content)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to add reaction",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(reaction), nil
+		}
+}
+
+// RemoveReaction creates a tool to remove the authenticated user's reaction from an issue, pull request,
+// comment or discussion.
+func RemoveReaction(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := append([]mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_REMOVE_REACTION_DESCRIPTION", "Remove your own emoji reaction from an issue, pull request, comment or discussion. Reactions of other users can't be removed.")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        t("TOOL_REMOVE_REACTION_USER_TITLE", "Remove reaction"),
+			ReadOnlyHint: ToBoolPtr(false),
+		}),
+	}, withReactionSubjectParams()...)
+
+	return mcp.NewTool("remove_reaction", options...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subject, err := reactionSubjectParams(request, true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := RequiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			graphQLContent, ok := graphQLReactionContents[content]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("unsupported content: %s", content)), nil
+			}
+			removed := fmt.Sprintf("removed %s reaction from %s", content, strings.ReplaceAll(subject.Type, "_", " "))
+
+			if subject.isDiscussion() {
+				client, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+				subjectID, err := getReactionSubjectID(ctx, client, owner, repo, subject)
+				if err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get discussion", err), nil
+				}
+
+				var mutation struct {
+					RemoveReaction struct {
+						Reaction struct {
+							Content githubv4.ReactionContent
+						}
+					} `graphql:"removeReaction(input: $input)"`
+				}
+				input := githubv4.RemoveReactionInput{SubjectID: subjectID, Content: graphQLContent}
+				if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to remove reaction", err), nil
+				}
+				return mcp.NewToolResultText(removed), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			user, resp, err := client.Users.Get(ctx, "")
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get current user",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			// The REST API deletes reactions by ID, so find the user's reaction of this type first
+			var reactionID int64
+			opts := &github.ListReactionOptions{Content: content, ListOptions: github.ListOptions{PerPage: 100}}
+			for reactionID == 0 {
+				reactions, resp, err := listSubjectReactions(ctx, client, owner, repo, subject, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list reactions",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				for _, reaction := range reactions {
+					if strings.EqualFold(reaction.GetUser().GetLogin(), user.GetLogin()) {
+						reactionID = reaction.GetID()
+						break
+					}
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			if reactionID == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("%s has no %s reaction on this %s", user.GetLogin(), content, strings.ReplaceAll(subject.Type, "_", " "))), nil
+			}
+
+			switch subject.Type {
+			case "issue":
+				resp, err = client.Reactions.DeleteIssueReaction(ctx, owner, repo, subject.IssueNumber, reactionID)
+			case "issue_comment":
+				resp, err = client.Reactions.DeleteIssueCommentReaction(ctx, owner, repo, subject.CommentID, reactionID)
+			case "pull_request_review_comment":
+				resp, err = client.Reactions.DeletePullRequestCommentReaction(ctx, owner, repo, subject.CommentID, reactionID)
+			default:
+				resp, err = client.Reactions.DeleteCommentReaction(ctx, owner, repo, subject.CommentID, reactionID)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to remove reaction",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(removed), nil
+		}
+}
@@ -0,0 +1,489 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListReactions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListReactions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_reactions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "comment_id")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type"})
+
+	mockReactions := []*github.Reaction{
+		{ID: github.Ptr(int64(1)), Content: github.Ptr("+1"), User: &github.User{Login: github.Ptr("octocat")}},
+		{ID: github.Ptr(int64(2)), Content: github.Ptr("heart"), User: &github.User{Login: github.Ptr("hubot")}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedCount  int
+		expectedErrMsg string
+	}{
+		{
+			name: "issue reactions filtered by content",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					expectQueryParams(t, map[string]string{
+						"content":  "+1",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockReactions[:1]),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"issue_number": float64(42),
+				"content":      "+1",
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "issue comment reactions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesCommentsReactionsByOwnerByRepoByCommentId,
+					expectPath(t, "/repos/owner/repo/issues/comments/123/reactions").andThen(
+						mockResponse(t, http.StatusOK, mockReactions),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue_comment",
+				"comment_id":   float64(123),
+			},
+			expectedCount: 2,
+		},
+		{
+			name: "pull request review comment reactions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsCommentsReactionsByOwnerByRepoByCommentId,
+					expectPath(t, "/repos/owner/repo/pulls/comments/456/reactions").andThen(
+						mockResponse(t, http.StatusOK, mockReactions),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "pull_request_review_comment",
+				"comment_id":   float64(456),
+			},
+			expectedCount: 2,
+		},
+		{
+			name:         "missing comment id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "commit_comment",
+			},
+			expectError:    true,
+			expectedErrMsg: "comment_id is required when subject_type is commit_comment",
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"issue_number": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list reactions",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListReactions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned []*github.Reaction
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Len(t, returned, tc.expectedCount)
+		})
+	}
+}
+
+func Test_ListReactions_CountsOnly(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposPullsCommentsByOwnerByRepoByCommentId,
+			expectPath(t, "/repos/owner/repo/pulls/comments/7").andThen(
+				mockResponse(t, http.StatusOK, &github.PullRequestComment{
+					ID:        github.Ptr(int64(7)),
+					Reactions: &github.Reactions{TotalCount: github.Ptr(3), PlusOne: github.Ptr(2), Hooray: github.Ptr(1)},
+				}),
+			),
+		),
+	))
+	_, handler := ListReactions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"subject_type": "pull_request_review_comment",
+		"comment_id":   float64(7),
+		"counts_only":  true,
+	}))
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	var counts github.Reactions
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &counts))
+	assert.Equal(t, 3, counts.GetTotalCount())
+	assert.Equal(t, 2, counts.GetPlusOne())
+	assert.Equal(t, 1, counts.GetHooray())
+}
+
+// discussionIDQuery matches the lookup of a discussion's node ID by the reaction tools.
+func discussionIDQuery(number int, id string) githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				Discussion struct {
+					ID githubv4.ID
+				} `graphql:"discussion(number: $discussionNumber)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner":            githubv4.String("owner"),
+			"repo":             githubv4.String("repo"),
+			"discussionNumber": githubv4.Int(number),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{"id": id}},
+		}),
+	)
+}
+
+// discussionCommentRepositoryQuery matches the lookup of the repository of a discussion comment by the
+// reaction tools.
+func discussionCommentRepositoryQuery(id, nameWithOwner string) githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		struct {
+			Node struct {
+				DiscussionComment struct {
+					Discussion struct {
+						Repository struct {
+							NameWithOwner githubv4.String
+						}
+					}
+				} `graphql:"... on DiscussionComment"`
+			} `graphql:"node(id: $id)"`
+		}{},
+		map[string]any{"id": githubv4.ID(id)},
+		githubv4mock.DataResponse(map[string]any{
+			"node": map[string]any{"discussion": map[string]any{"repository": map[string]any{"nameWithOwner": nameWithOwner}}},
+		}),
+	)
+}
+
+func Test_AddReaction(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddReaction(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_reaction", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "discussion_number")
+	assert.Contains(t, tool.InputSchema.Properties, "comment_node_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type", "content"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		mockedGQL      *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "reaction to an issue comment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsReactionsByOwnerByRepoByCommentId,
+					expectRequestBody(t, map[string]any{"content": "eyes"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reaction{ID: github.Ptr(int64(9)), Content: github.Ptr("eyes")}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"subject_type": "issue_comment",
+				"comment_id":   float64(123),
+				"content":      "eyes",
+			},
+			expectedText: `"content":"eyes"`,
+		},
+		{
+			name: "reaction to a discussion",
+			mockedGQL: githubv4mock.NewMockedHTTPClient(
+				discussionIDQuery(3, "D_kwDOA0xdyM4AAAAB"),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						AddReaction struct {
+							Reaction struct {
+								Content githubv4.ReactionContent
+							}
+						} `graphql:"addReaction(input: $input)"`
+					}{},
+					githubv4.AddReactionInput{SubjectID: "D_kwDOA0xdyM4AAAAB", Content: githubv4.ReactionContentHooray},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"addReaction": map[string]any{"reaction": map[string]any{"content": "HOORAY"}},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"subject_type":      "discussion",
+				"discussion_number": float64(3),
+				"content":           "hooray",
+			},
+			expectedText: "added hooray reaction to discussion",
+		},
+		{
+			name: "missing comment node ID",
+			requestArgs: map[string]any{
+				"subject_type": "discussion_comment",
+				"content":      "+1",
+			},
+			expectError:    true,
+			expectedErrMsg: "comment_node_id is required when subject_type is discussion_comment",
+		},
+		{
+			name: "reaction fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"subject_type": "issue",
+				"issue_number": float64(42),
+				"content":      "+1",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add reaction",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(tc.mockedGQL)
+			_, handler := AddReaction(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo"}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.Contains(t, textContent.Text, tc.expectedText)
+		})
+	}
+}
+
+func Test_RemoveReaction(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveReaction(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_reaction", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type", "content"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		mockedGQL      *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "removes the user's reaction from an issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUser, &github.User{Login: github.Ptr("octocat")}),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					expectQueryParams(t, map[string]string{"content": "+1", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Reaction{
+							{ID: github.Ptr(int64(1)), Content: github.Ptr("+1"), User: &github.User{Login: github.Ptr("hubot")}},
+							{ID: github.Ptr(int64(2)), Content: github.Ptr("+1"), User: &github.User{Login: github.Ptr("OctoCat")}},
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesReactionsByOwnerByRepoByIssueNumberByReactionId,
+					expectPath(t, "/repos/owner/repo/issues/42/reactions/2").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"subject_type": "issue",
+				"issue_number": float64(42),
+				"content":      "+1",
+			},
+			expectedText: "removed +1 reaction from issue",
+		},
+		{
+			name: "user has not reacted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUser, &github.User{Login: github.Ptr("octocat")}),
+				mock.WithRequestMatch(
+					mock.GetReposCommentsReactionsByOwnerByRepoByCommentId,
+					[]*github.Reaction{
+						{ID: github.Ptr(int64(1)), Content: github.Ptr("heart"), User: &github.User{Login: github.Ptr("hubot")}},
+					},
+				),
+			),
+			requestArgs: map[string]any{
+				"subject_type": "commit_comment",
+				"comment_id":   float64(5),
+				"content":      "heart",
+			},
+			expectError:    true,
+			expectedErrMsg: "octocat has no heart reaction on this commit comment",
+		},
+		{
+			name: "removes a reaction from a discussion comment",
+			mockedGQL: githubv4mock.NewMockedHTTPClient(
+				discussionCommentRepositoryQuery("DC_kwDOA0xdyM4AAAAC", "Owner/Repo"),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						RemoveReaction struct {
+							Reaction struct {
+								Content githubv4.ReactionContent
+							}
+						} `graphql:"removeReaction(input: $input)"`
+					}{},
+					githubv4.RemoveReactionInput{SubjectID: "DC_kwDOA0xdyM4AAAAC", Content: githubv4.ReactionContentRocket},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"removeReaction": map[string]any{"reaction": map[string]any{"content": "ROCKET"}},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"subject_type":    "discussion_comment",
+				"comment_node_id": "DC_kwDOA0xdyM4AAAAC",
+				"content":         "rocket",
+			},
+			expectedText: "removed rocket reaction from discussion comment",
+		},
+		{
+			name: "discussion comment of another repository",
+			mockedGQL: githubv4mock.NewMockedHTTPClient(
+				discussionCommentRepositoryQuery("DC_kwDOA0xdyM4AAAAC", "other-org/private"),
+			),
+			requestArgs: map[string]any{
+				"subject_type":    "discussion_comment",
+				"comment_node_id": "DC_kwDOA0xdyM4AAAAC",
+				"content":         "rocket",
+			},
+			expectError:    true,
+			expectedErrMsg: "DC_kwDOA0xdyM4AAAAC is not a comment on a discussion of owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(tc.mockedGQL)
+			_, handler := RemoveReaction(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo"}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
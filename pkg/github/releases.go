@@ -0,0 +1,703 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ListReleases creates a tool to list the releases of a repository.
+func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_releases",
+			mcp.WithDescription(t("TOOL_LIST_RELEASES_DESCRIPTION", "List the releases of a repository, newest first. Draft releases are only listed for users with push access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_RELEASES_USER_TITLE", "List releases"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			releases, resp, err := client.Repositories.ListReleases(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list releases", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(releases)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetRelease creates a tool to get the latest release of a repository, or the release of a tag.
+func GetRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_release",
+			mcp.WithDescription(t("TOOL_GET_RELEASE_DESCRIPTION", "Get the release of a tag, or the latest release of a repository if no tag is given. The latest release is the most recent non-draft, non-prerelease release unless another one was marked as latest.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RELEASE_USER_TITLE", "Get release"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("tag",
+				mcp.Description("Tag of the release, the latest release if omitted"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := OptionalParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var release *github.RepositoryRelease
+			var resp *github.Response
+			if tag == "" {
+				release, resp, err = client.Repositories.GetLatestRelease(ctx, owner, repo)
+			} else {
+				release, resp, err = client.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+			}
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					message := fmt.Sprintf("failed to get release: %s/%s has no published releases", owner, repo)
+					if tag != "" {
+						message = fmt.Sprintf("failed to get release: there is no release for tag %s in %s/%s, draft releases can only be found with list_releases", tag, owner, repo)
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, message, resp, err), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get release", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(release)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// withReleaseContent adds the parameters that describe a release, shared by create_release and update_release.
+func withReleaseContent() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("target_commitish",
+			mcp.Description("Branch or commit SHA the tag is created from if it doesn't exist yet, the default branch if omitted"),
+		)(tool)
+		mcp.WithString("name",
+			mcp.Description("Release title"),
+		)(tool)
+		mcp.WithString("body",
+			mcp.Description("Release notes in Markdown"),
+		)(tool)
+		mcp.WithBoolean("draft",
+			mcp.Description("Whether the release is a draft, which is only visible to users with push access"),
+		)(tool)
+		mcp.WithBoolean("prerelease",
+			mcp.Description("Whether the release is marked as a prerelease"),
+		)(tool)
+		mcp.WithString("make_latest",
+			mcp.Description("Whether the release becomes the latest release. legacy picks the latest by creation date and semantic version"),
+			mcp.Enum("true", "false", "legacy"),
+		)(tool)
+	}
+}
+
+// releaseContentParams reads the parameters added by withReleaseContent into release. Only the
+// parameters that were given are set, so an update leaves the others unchanged.
+func releaseContentParams(request mcp.CallToolRequest, release *github.RepositoryRelease) error {
+	for _, name := range []string{"target_commitish", "name", "body", "make_latest"} {
+		if _, ok := request.GetArguments()[name]; !ok {
+			continue
+		}
+		v, err := OptionalParam[string](request, name)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "target_commitish":
+			release.TargetCommitish = github.Ptr(v)
+		case "name":
+			release.Name = github.Ptr(v)
+		case "body":
+			release.Body = github.Ptr(v)
+		case "make_latest":
+			release.MakeLatest = github.Ptr(v)
+		}
+	}
+	for _, name := range []string{"draft", "prerelease"} {
+		if _, ok := request.GetArguments()[name]; !ok {
+			continue
+		}
+		v, err := OptionalParam[bool](request, name)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "draft":
+			release.Draft = github.Ptr(v)
+		case "prerelease":
+			release.Prerelease = github.Ptr(v)
+		}
+	}
+	return nil
+}
+
+// CreateRelease creates a tool to create a release.
+func CreateRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_release",
+			mcp.WithDescription(t("TOOL_CREATE_RELEASE_DESCRIPTION", "Create a release for a tag, creating the tag from target_commitish if it doesn't exist. Create it as a draft to review it before publishing it with update_release. With generate_release_notes the notes are generated from the pull requests merged since the previous release and appended to body.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_RELEASE_USER_TITLE", "Create release"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Tag of the release"),
+			),
+			withReleaseContent(),
+			mcp.WithBoolean("generate_release_notes",
+				mcp.Description("Generate the release notes automatically"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := RequiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			release := &github.RepositoryRelease{TagName: github.Ptr(tag)}
+			if err := releaseContentParams(request, release); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			generateNotes, err := OptionalParam[bool](request, "generate_release_notes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if generateNotes {
+				release.GenerateReleaseNotes = github.Ptr(true)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateRelease(ctx, owner, repo, release)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to create release: a release for tag %s may already exist in %s/%s, or target_commitish does not exist", tag, owner, repo),
+						resp,
+						err,
+					), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create release", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateRelease creates a tool to edit a release, which also publishes drafts.
+func UpdateRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_release",
+			mcp.WithDescription(t("TOOL_UPDATE_RELEASE_DESCRIPTION", "Edit a release. Only the given fields change. Publish a draft release by setting draft to false.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_RELEASE_USER_TITLE", "Update release"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("ID of the release, as returned by list_releases"),
+			),
+			mcp.WithString("tag",
+				mcp.Description("New tag of the release"),
+			),
+			withReleaseContent(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := OptionalParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			release := &github.RepositoryRelease{}
+			if tag != "" {
+				release.TagName = github.Ptr(tag)
+			}
+			if err := releaseContentParams(request, release); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			updated, resp, err := client.Repositories.EditRelease(ctx, owner, repo, int64(releaseID), release)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to update release %d", releaseID), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(updated)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GenerateReleaseNotes creates a tool to generate the notes of a release without creating it.
+func GenerateReleaseNotes(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("generate_release_notes",
+			mcp.WithDescription(t("TOOL_GENERATE_RELEASE_NOTES_DESCRIPTION", "Generate the name and notes of a release from the pull requests merged since the previous release, without creating the release. The repository's .github/release.yml configures the notes.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GENERATE_RELEASE_NOTES_USER_TITLE", "Generate release notes"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Tag of the release, it doesn't need to exist yet"),
+			),
+			mcp.WithString("previous_tag",
+				mcp.Description("Tag of the previous release to start from, the latest release before tag if omitted"),
+			),
+			mcp.WithString("target_commitish",
+				mcp.Description("Branch or commit SHA the release is made from if tag doesn't exist yet"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := RequiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			previousTag, err := OptionalParam[string](request, "previous_tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetCommitish, err := OptionalParam[string](request, "target_commitish")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.GenerateNotesOptions{TagName: tag}
+			if previousTag != "" {
+				opts.PreviousTagName = github.Ptr(previousTag)
+			}
+			if targetCommitish != "" {
+				opts.TargetCommitish = github.Ptr(targetCommitish)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			notes, resp, err := client.Repositories.GenerateReleaseNotes(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to generate release notes", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(notes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UploadReleaseAsset creates a tool to upload a file to a release.
+func UploadReleaseAsset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("upload_release_asset",
+			mcp.WithDescription(t("TOOL_UPLOAD_RELEASE_ASSET_DESCRIPTION", "Upload a file as an asset of a release. Binary files must be given base64 encoded.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPLOAD_RELEASE_ASSET_USER_TITLE", "Upload release asset"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("ID of the release, as returned by list_releases"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("File name of the asset"),
+			),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("Content of the asset"),
+			),
+			mcp.WithBoolean("base64",
+				mcp.Description("Whether content is base64 encoded"),
+			),
+			mcp.WithString("label",
+				mcp.Description("Label shown instead of the file name"),
+			),
+			mcp.WithString("content_type",
+				mcp.Description("Media type of the asset, guessed from the file name if omitted"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := RequiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			isBase64, err := OptionalParam[bool](request, "base64")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			label, err := OptionalParam[string](request, "label")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentType, err := OptionalParam[string](request, "content_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			data := []byte(content)
+			if isBase64 {
+				data, err = base64.StdEncoding.DecodeString(content)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("content is not valid base64: %v", err)), nil
+				}
+			}
+			if contentType == "" {
+				contentType = mime.TypeByExtension(path.Ext(name))
+			}
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github only uploads from an *os.File, so the upload request is built here from the content.
+			query := url.Values{"name": {name}}
+			if label != "" {
+				query.Set("label", label)
+			}
+			u := fmt.Sprintf("repos/%s/%s/releases/%d/assets?%s", owner, repo, releaseID, query.Encode())
+			req, err := client.NewUploadRequest(u, bytes.NewReader(data), int64(len(data)), contentType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create upload request: %w", err)
+			}
+			asset := new(github.ReleaseAsset)
+			resp, err := client.Do(ctx, req, asset)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to upload release asset: release %d may already have an asset named %s, delete it first with delete_release_asset", releaseID, name),
+						resp,
+						err,
+					), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to upload release asset", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(asset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteReleaseAsset creates a tool to delete an asset of a release.
+func DeleteReleaseAsset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_release_asset",
+			mcp.WithDescription(t("TOOL_DELETE_RELEASE_ASSET_DESCRIPTION", "Delete an asset of a release. Download links to it stop working.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_RELEASE_ASSET_USER_TITLE", "Delete release asset"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("asset_id",
+				mcp.Required(),
+				mcp.Description("ID of the asset, as listed in the assets of the release"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assetID, err := RequiredInt(request, "asset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteReleaseAsset(ctx, owner, repo, int64(assetID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to delete release asset %d", assetID), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Deleted release asset %d", assetID)), nil
+		}
+}
+
+// CreateTag creates a tool to create a lightweight or annotated tag.
+func CreateTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_tag",
+			mcp.WithDescription(t("TOOL_CREATE_TAG_DESCRIPTION", "Create a git tag pointing at a commit. With a message the tag is an annotated tag, otherwise a lightweight one.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_TAG_USER_TITLE", "Create tag"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Tag name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit to tag"),
+			),
+			mcp.WithString("message",
+				mcp.Description("Message of an annotated tag"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := RequiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := OptionalParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// An annotated tag is a tag object, which the ref then points at instead of the commit.
+			refSHA := sha
+			if message != "" {
+				tagObj, resp, err := client.Git.CreateTag(ctx, owner, repo, &github.Tag{
+					Tag:     github.Ptr(tag),
+					Message: github.Ptr(message),
+					Object: &github.GitObject{
+						Type: github.Ptr("commit"),
+						SHA:  github.Ptr(sha),
+					},
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create tag object", resp, err), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+				refSHA = tagObj.GetSHA()
+			}
+
+			ref, resp, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr("refs/tags/" + tag),
+				Object: &github.GitObject{SHA: github.Ptr(refSHA)},
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to create tag: tag %s may already exist in %s/%s, or %s is not a commit of the repository", tag, owner, repo, sha),
+						resp,
+						err,
+					), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create tag", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(ref)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,196 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Fixture is a canned response to a GitHub API request, used to exercise tools without a token or
+// network access.
+type Fixture struct {
+	// Method is the HTTP method to match, any method matches if empty
+	Method string `json:"method,omitempty"`
+	// Path is the request path to match, such as /repos/owner/repo/issues/1. A `*` segment matches any
+	// single segment.
+	Path string `json:"path"`
+	// Query holds query parameters the request must carry, other parameters are ignored
+	Query map[string]string `json:"query,omitempty"`
+
+	// Status is the response status code, 200 if unset
+	Status int `json:"status,omitempty"`
+	// Headers are added to the response
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body is the response body. Strings and byte slices are sent as is, anything else is encoded as JSON.
+	Body any `json:"body,omitempty"`
+}
+
+// LoadFixtures reads a JSON array of fixtures from a file.
+func LoadFixtures(path string) ([]Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+	var fixtures []Fixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures %s: %w", path, err)
+	}
+	return fixtures, nil
+}
+
+// FixtureTransport is an http.RoundTripper that answers requests from fixtures instead of the network.
+// The first matching fixture answers a request, fixtures can answer any number of requests. Requests
+// without a matching fixture get a 404 naming the request, so that a missing fixture shows up in the
+// tool result.
+type FixtureTransport struct {
+	fixtures []Fixture
+
+	mu       sync.Mutex
+	requests []string
+}
+
+// NewFixtureTransport creates a transport that answers requests from fixtures.
+func NewFixtureTransport(fixtures ...Fixture) *FixtureTransport {
+	return &FixtureTransport{fixtures: fixtures}
+}
+
+// NewFixtureClient creates a GitHub REST client that answers requests from fixtures.
+func NewFixtureClient(fixtures ...Fixture) *github.Client {
+	return github.NewClient(&http.Client{Transport: NewFixtureTransport(fixtures...)})
+}
+
+// Requests returns the requests made so far, as `METHOD path`.
+func (t *FixtureTransport) Requests() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.requests...)
+}
+
+func (t *FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		// The request body is never read, but must be closed per the RoundTripper contract
+		_ = req.Body.Close()
+	}
+
+	t.mu.Lock()
+	t.requests = append(t.requests, req.Method+" "+req.URL.Path)
+	t.mu.Unlock()
+
+	for _, f := range t.fixtures {
+		if f.matches(req) {
+			return f.response(req)
+		}
+	}
+
+	body, _ := json.Marshal(map[string]string{
+		"message": fmt.Sprintf("no fixture for %s %s", req.Method, req.URL.Path),
+	})
+	return newFixtureResponse(req, http.StatusNotFound, nil, body), nil
+}
+
+func (f Fixture) matches(req *http.Request) bool {
+	if f.Method != "" && !strings.EqualFold(f.Method, req.Method) {
+		return false
+	}
+
+	want := strings.Split(strings.Trim(f.Path, "/"), "/")
+	got := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if want[i] != "*" && want[i] != got[i] {
+			return false
+		}
+	}
+
+	query := req.URL.Query()
+	for k, v := range f.Query {
+		if query.Get(k) != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (f Fixture) response(req *http.Request) (*http.Response, error) {
+	var body []byte
+	switch b := f.Body.(type) {
+	case nil:
+	case string:
+		body = []byte(b)
+	case []byte:
+		body = b
+	default:
+		var err error
+		if body, err = json.Marshal(b); err != nil {
+			return nil, fmt.Errorf("failed to marshal fixture body for %s: %w", f.Path, err)
+		}
+	}
+
+	status := f.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return newFixtureResponse(req, status, f.Headers, body), nil
+}
+
+func newFixtureResponse(req *http.Request, status int, headers map[string]string, body []byte) *http.Response {
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		header.Set(k, v)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// InvokeTool calls a tool registered on s the way an MCP client would, through its middleware and
+// hooks, and returns the tool result. Failures to call the tool, such as an unknown tool name, are
+// returned as errors, failures reported by the tool are a result with IsError set.
+func InvokeTool(ctx context.Context, s *server.MCPServer, name string, args map[string]any) (*mcp.CallToolResult, error) {
+	message, err := json.Marshal(map[string]any{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"id":      1,
+		"method":  string(mcp.MethodToolsCall),
+		"params": map[string]any{
+			"name":      name,
+			"arguments": args,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool call: %w", err)
+	}
+
+	switch response := s.HandleMessage(ctx, message).(type) {
+	case mcp.JSONRPCResponse:
+		result, ok := response.Result.(mcp.CallToolResult)
+		if !ok {
+			return nil, fmt.Errorf("unexpected result type %T for tool %s", response.Result, name)
+		}
+		return &result, nil
+	case mcp.JSONRPCError:
+		return nil, fmt.Errorf("failed to call tool %s: %s", name, response.Error.Message)
+	default:
+		return nil, fmt.Errorf("unexpected response type %T for tool %s", response, name)
+	}
+}
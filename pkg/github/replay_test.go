@@ -0,0 +1,162 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtureTransport(t *testing.T) {
+	transport := NewFixtureTransport(
+		Fixture{
+			Method: http.MethodGet,
+			Path:   "/repos/owner/repo/issues",
+			Query:  map[string]string{"state": "closed"},
+			Body:   []map[string]any{{"number": 2}},
+		},
+		Fixture{
+			Method: http.MethodGet,
+			Path:   "/repos/*/*/issues",
+			Body:   `[{"number": 1}]`,
+		},
+		Fixture{
+			Method:  http.MethodPost,
+			Path:    "/repos/owner/repo/issues",
+			Status:  http.StatusCreated,
+			Headers: map[string]string{"X-GitHub-Request-Id": "ABCD"},
+			Body:    map[string]any{"number": 3},
+		},
+	)
+	client := github.NewClient(&http.Client{Transport: transport})
+	ctx := context.Background()
+
+	closed, _, err := client.Issues.ListByRepo(ctx, "owner", "repo", &github.IssueListByRepoOptions{State: "closed"})
+	require.NoError(t, err)
+	require.Len(t, closed, 1)
+	assert.Equal(t, 2, closed[0].GetNumber())
+
+	open, _, err := client.Issues.ListByRepo(ctx, "other", "repo", nil)
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	assert.Equal(t, 1, open[0].GetNumber())
+
+	created, resp, err := client.Issues.Create(ctx, "owner", "repo", &github.IssueRequest{Title: github.Ptr("title")})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "ABCD", resp.Header.Get("X-GitHub-Request-Id"))
+	assert.Equal(t, 3, created.GetNumber())
+
+	_, resp, err = client.Issues.Get(ctx, "owner", "repo", 1)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Contains(t, err.Error(), "no fixture for GET /repos/owner/repo/issues/1")
+
+	assert.Equal(t, []string{
+		"GET /repos/owner/repo/issues",
+		"GET /repos/other/repo/issues",
+		"POST /repos/owner/repo/issues",
+		"GET /repos/owner/repo/issues/1",
+	}, transport.Requests())
+}
+
+func TestLoadFixtures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"method": "GET", "path": "/user", "body": {"login": "octocat"}},
+		{"path": "/repos/owner/repo", "status": 404, "body": {"message": "Not Found"}}
+	]`), 0600))
+
+	fixtures, err := LoadFixtures(path)
+	require.NoError(t, err)
+	require.Len(t, fixtures, 2)
+	assert.Equal(t, "/user", fixtures[0].Path)
+	assert.Equal(t, http.StatusNotFound, fixtures[1].Status)
+
+	user, _, err := NewFixtureClient(fixtures...).Users.Get(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "octocat", user.GetLogin())
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"path": "/user"}`), 0600))
+	_, err = LoadFixtures(path)
+	assert.Error(t, err)
+
+	_, err = LoadFixtures(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestInvokeTool(t *testing.T) {
+	client := NewFixtureClient(
+		Fixture{
+			Method: http.MethodGet,
+			Path:   "/user",
+			Body:   map[string]any{"login": "octocat", "id": 1},
+		},
+		Fixture{
+			Method: http.MethodGet,
+			Path:   "/repos/owner/repo/issues/42",
+			Body:   map[string]any{"number": 42, "title": "Fixture issue", "state": "open"},
+		},
+	)
+
+	s := NewServer("test")
+	s.AddTool(GetMe(stubGetClientFn(client), translations.NullTranslationHelper))
+	s.AddTool(GetIssue(stubGetClientFn(client), translations.NullTranslationHelper))
+
+	ctx := context.Background()
+
+	t.Run("get_me", func(t *testing.T) {
+		result, err := InvokeTool(ctx, s, "get_me", nil)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var user MinimalUser
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &user))
+		assert.Equal(t, "octocat", user.Login)
+	})
+
+	t.Run("get_issue", func(t *testing.T) {
+		result, err := InvokeTool(ctx, s, "get_issue", map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"issue_number": 42,
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var issue github.Issue
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &issue))
+		assert.Equal(t, 42, issue.GetNumber())
+		assert.Equal(t, "Fixture issue", issue.GetTitle())
+	})
+
+	t.Run("missing fixture", func(t *testing.T) {
+		_, err := InvokeTool(ctx, s, "get_issue", map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"issue_number": 7,
+		})
+		assert.ErrorContains(t, err, "no fixture for GET /repos/owner/repo/issues/7")
+	})
+
+	t.Run("invalid arguments are a tool error", func(t *testing.T) {
+		result, err := InvokeTool(ctx, s, "get_issue", map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "missing required parameter: issue_number")
+	})
+
+	t.Run("unknown tool", func(t *testing.T) {
+		_, err := InvokeTool(ctx, s, "no_such_tool", nil)
+		assert.ErrorContains(t, err, "failed to call tool no_such_tool")
+	})
+}
@@ -1,14 +1,20 @@
 package github
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/raw"
@@ -16,11 +22,12 @@ import (
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
 )
 
 func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_commit",
-			mcp.WithDescription(t("TOOL_GET_COMMITS_DESCRIPTION", "Get details for a commit from a GitHub repository")),
+			mcp.WithDescription(t("TOOL_GET_COMMITS_DESCRIPTION", "Get details for a commit from a GitHub repository, including its diff as the patch of each changed file")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_COMMITS_USER_TITLE", "Get commit details"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -115,6 +122,15 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithString("author",
 				mcp.Description("Author username or email address to filter commits by"),
 			),
+			mcp.WithString("path",
+				mcp.Description("Only list commits that changed this file or directory"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only list commits made at or after this date (ISO 8601 timestamp or YYYY-MM-DD)"),
+			),
+			mcp.WithString("until",
+				mcp.Description("Only list commits made at or before this date (ISO 8601 timestamp or YYYY-MM-DD)"),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -134,6 +150,10 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			path, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -145,12 +165,33 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			}
 			opts := &github.CommitsListOptions{
 				SHA:    sha,
+				Path:   path,
 				Author: author,
 				ListOptions: github.ListOptions{
 					Page:    pagination.Page,
 					PerPage: perPage,
 				},
 			}
+			for _, date := range []struct {
+				name  string
+				field *time.Time
+			}{{"since", &opts.Since}, {"until", &opts.Until}} {
+				value, err := OptionalParam[string](request, date.name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if value == "" {
+					continue
+				}
+				timestamp, err := parseISOTimestamp(value)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid %s: %s", date.name, err.Error())), nil
+				}
+				*date.field = timestamp
+			}
+			if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Until.Before(opts.Since) {
+				return mcp.NewToolResultError("until must not be before since"), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -183,6 +224,209 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 		}
 }
 
+// maxCommitGraphCommits caps the number of commits get_commit_graph returns.
+const maxCommitGraphCommits = 500
+
+// CommitGraphNode is a commit in a commit graph, with the parents that connect it to the rest of the graph.
+type CommitGraphNode struct {
+	SHA     string   `json:"sha"`
+	Parents []string `json:"parents"`
+	Subject string   `json:"subject"`
+	Author  string   `json:"author,omitempty"`
+	Date    string   `json:"date,omitempty"`
+	IsMerge bool     `json:"is_merge,omitempty"`
+}
+
+func newCommitGraphNode(commit *github.RepositoryCommit) CommitGraphNode {
+	node := CommitGraphNode{
+		SHA:     commit.GetSHA(),
+		Parents: make([]string, 0, len(commit.Parents)),
+	}
+	for _, parent := range commit.Parents {
+		node.Parents = append(node.Parents, parent.GetSHA())
+	}
+	node.IsMerge = len(node.Parents) > 1
+	node.Subject, _, _ = strings.Cut(commit.GetCommit().GetMessage(), "\n")
+	node.Author = commit.GetAuthor().GetLogin()
+	if node.Author == "" {
+		node.Author = commit.GetCommit().GetAuthor().GetName()
+	}
+	if date := commit.GetCommit().GetAuthor().GetDate(); !date.IsZero() {
+		node.Date = date.UTC().Format(time.RFC3339)
+	}
+	return node
+}
+
+// GetCommitGraph creates a tool to get the ancestry of a ref or range of commits, with the parents of each commit.
+func GetCommitGraph(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_commit_graph",
+			mcp.WithDescription(t("TOOL_GET_COMMIT_GRAPH_DESCRIPTION", "Get the commit ancestry of a branch, tag or commit, or of the range base..head, newest first and with the parent SHAs of each commit, so that merges and branch topology can be reconstructed like `git log --graph`.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COMMIT_GRAPH_USER_TITLE", "Get commit graph"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("head",
+				mcp.Description("Branch, tag or commit SHA to get the ancestry of. Defaults to the default branch of the repository."),
+			),
+			mcp.WithString("base",
+				mcp.Description("Branch, tag or commit SHA to start the range from. When set, only commits reachable from head but not from base are returned, like `git log base..head`."),
+			),
+			mcp.WithNumber("max_commits",
+				mcp.Description(fmt.Sprintf("Maximum number of commits to return, newest first (default 100, max %d)", maxCommitGraphCommits)),
+				mcp.Min(1),
+				mcp.Max(maxCommitGraphCommits),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := OptionalParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := OptionalParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxCommits, err := OptionalIntParamWithDefault(request, "max_commits", 100)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxCommits < 1 || maxCommits > maxCommitGraphCommits {
+				return mcp.NewToolResultError(fmt.Sprintf("max_commits must be between 1 and %d", maxCommitGraphCommits)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			progress := newProgressReporter(ctx, request)
+			var commits []*github.RepositoryCommit
+			result := map[string]any{}
+			if base != "" {
+				if head == "" {
+					repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							"failed to get repository",
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					head = repository.GetDefaultBranch()
+				}
+
+				// The comparison lists commits oldest first. Start with the last pages so that the newest
+				// commits are kept when the range is larger than max_commits.
+				const perPage = 100
+				comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, &github.ListOptions{PerPage: perPage})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to compare %s...%s", base, head),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				total := comparison.GetTotalCommits()
+				result["total_commits"] = total
+				result["merge_base"] = comparison.GetMergeBaseCommit().GetSHA()
+
+				pages := [][]*github.RepositoryCommit{comparison.Commits}
+				lastPage := (total + perPage - 1) / perPage
+				firstPage := 1
+				if total > maxCommits {
+					firstPage = (total-maxCommits)/perPage + 1
+					if firstPage > 1 {
+						pages = nil
+					}
+				}
+				for page := max(firstPage, 2); page <= lastPage; page++ {
+					next, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, &github.ListOptions{PerPage: perPage, Page: page})
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							fmt.Sprintf("failed to compare %s...%s", base, head),
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					pages = append(pages, next.Commits)
+					progress.report(float64(page-firstPage+1), float64(lastPage-firstPage+1), fmt.Sprintf("Compared page %d of %d", page, lastPage))
+				}
+				for i := len(pages) - 1; i >= 0; i-- {
+					for j := len(pages[i]) - 1; j >= 0; j-- {
+						commits = append(commits, pages[i][j])
+					}
+				}
+				result["truncated"] = total > maxCommits
+			} else {
+				opts := &github.CommitsListOptions{
+					SHA:         head,
+					ListOptions: github.ListOptions{PerPage: min(maxCommits, 100)},
+				}
+				for {
+					page, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							fmt.Sprintf("failed to list commits: %s", head),
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					commits = append(commits, page...)
+					progress.report(float64(min(len(commits), maxCommits)), float64(maxCommits), fmt.Sprintf("Listed %d commits", len(commits)))
+					if len(commits) >= maxCommits || resp.NextPage == 0 {
+						result["truncated"] = resp.NextPage != 0 || len(commits) > maxCommits
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			}
+			if len(commits) > maxCommits {
+				commits = commits[:maxCommits]
+			}
+
+			nodes := make([]CommitGraphNode, 0, len(commits))
+			merges := 0
+			for _, commit := range commits {
+				node := newCommitGraphNode(commit)
+				if node.IsMerge {
+					merges++
+				}
+				nodes = append(nodes, node)
+			}
+
+			if head != "" {
+				result["head"] = head
+			}
+			if base != "" {
+				result["base"] = base
+			}
+			result["merges"] = merges
+			result["commits"] = nodes
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
 // ListBranches creates a tool to list branches in a GitHub repository.
 func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_branches",
@@ -335,11 +579,22 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 				opts.SHA = github.Ptr(sha)
 			}
 
+			if len(contentBytes) > maxFileSize {
+				return fileTooLargeResult(path, len(contentBytes)), nil
+			}
+
 			// Create or update the file
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
+
+			// Large files would be inlined in the contents API request as base64, which the API rejects well
+			// before the file size limit, so upload them as a blob and commit them through the git data API.
+			if len(contentBytes) > largeFileThreshold {
+				return commitLargeFile(ctx, client, owner, repo, branch, path, message, sha, content)
+			}
+
 			fileContent, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, opts)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -367,10 +622,210 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 		}
 }
 
+const (
+	// largeFileThreshold is the size above which files are uploaded as git blobs instead of being inlined in
+	// a contents or trees API request.
+	largeFileThreshold = 1 << 20 // 1 MiB
+
+	// maxFileSize is the largest file GitHub accepts, larger files are rejected by pushes and the API alike.
+	maxFileSize = 100 << 20 // 100 MiB
+)
+
+// fileTooLargeResult returns an error result explaining that a file can't be committed through the API.
+func fileTooLargeResult(path string, size int) *mcp.CallToolResult {
+	return mcp.NewToolResultError(fmt.Sprintf(
+		"file too large for API: %s is %d bytes, and GitHub rejects files larger than %d bytes. "+
+			"Track the file with Git LFS and push it with git, or publish it as a release asset instead",
+		path, size, maxFileSize))
+}
+
+// uploadLargeFileResponse maps the errors GitHub returns for oversized request bodies to the same
+// guidance as fileTooLargeResult, and falls back to a regular API error otherwise.
+func uploadLargeFileResponse(ctx context.Context, message, path string, size int, resp *github.Response, err error) *mcp.CallToolResult {
+	if resp != nil && resp.StatusCode == http.StatusRequestEntityTooLarge {
+		return fileTooLargeResult(path, size)
+	}
+	return ghErrors.NewGitHubAPIErrorResponse(ctx, message, resp, err)
+}
+
+// createLargeFileBlob uploads the content of a large file as a git blob and returns a tree entry for it.
+func createLargeFileBlob(ctx context.Context, client *github.Client, owner, repo, path, content string) (*github.TreeEntry, *mcp.CallToolResult) {
+	blob, resp, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+		Content:  github.Ptr(content),
+		Encoding: github.Ptr("utf-8"),
+	})
+	if err != nil {
+		return nil, uploadLargeFileResponse(ctx, "failed to create blob for "+path, path, len(content), resp, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return &github.TreeEntry{
+		Path: github.Ptr(path),
+		Mode: github.Ptr("100644"), // Regular file mode
+		Type: github.Ptr("blob"),
+		SHA:  blob.SHA,
+	}, nil
+}
+
+// treeEntryMode returns the mode of the entry at path in a tree, such as 100755 for an executable, or an
+// empty mode if there is none. It walks down one tree per directory, as recursive trees of large
+// repositories are truncated.
+func treeEntryMode(ctx context.Context, client *github.Client, owner, repo, treeSHA, path string) (string, *github.Response, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		tree, resp, err := client.Git.GetTree(ctx, owner, repo, treeSHA, false)
+		if err != nil {
+			return "", resp, err
+		}
+		_ = resp.Body.Close()
+
+		var found *github.TreeEntry
+		for _, entry := range tree.Entries {
+			if entry.GetPath() == segment {
+				found = entry
+				break
+			}
+		}
+		if found == nil {
+			return "", nil, nil
+		}
+		if i == len(segments)-1 {
+			return found.GetMode(), nil, nil
+		}
+		treeSHA = found.GetSHA()
+	}
+	return "", nil, nil
+}
+
+// commitLargeFile creates or updates a single file through the git data API, uploading its content as a
+// blob. It enforces the same sha rules as the contents API, so callers see no difference in behavior.
+func commitLargeFile(ctx context.Context, client *github.Client, owner, repo, branch, path, message, sha, content string) (*mcp.CallToolResult, error) {
+	ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get branch reference",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Look up the file currently at path, to reject stale or missing SHAs like the contents API does
+	existing, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: *ref.Object.SHA})
+	switch {
+	case err == nil:
+		defer func() { _ = resp.Body.Close() }()
+		if existing == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to create/update file: %s is a directory", path)), nil
+		}
+		if sha == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to create/update file: %s already exists, provide the sha of the file being replaced", path)), nil
+		}
+		if sha != existing.GetSHA() {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to create/update file: %s does not match the current sha of %s (%s)", sha, path, existing.GetSHA())), nil
+		}
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		if sha != "" {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to create/update file: sha was provided but %s does not exist", path)), nil
+		}
+	default:
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get current file",
+			resp,
+			err,
+		), nil
+	}
+
+	baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get base commit",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	entry, errResult := createLargeFileBlob(ctx, client, owner, repo, path, content)
+	if errResult != nil {
+		return errResult, nil
+	}
+	// Keep the mode of the file being replaced, such as the executable bit of a script
+	if existing != nil {
+		mode, resp, err := treeEntryMode(ctx, client, owner, repo, baseCommit.GetTree().GetSHA(), path)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to get current file mode",
+				resp,
+				err,
+			), nil
+		}
+		if mode != "" {
+			entry.Mode = github.Ptr(mode)
+		}
+	}
+
+	newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, []*github.TreeEntry{entry})
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to create tree",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+		Message: github.Ptr(message),
+		Tree:    newTree,
+		Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+	}, nil)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to create commit",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	ref.Object.SHA = newCommit.SHA
+	_, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, false)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to update reference",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Respond with the same shape as the contents API
+	name := path
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		name = path[i+1:]
+	}
+	r, err := json.Marshal(&github.RepositoryContentResponse{
+		Content: &github.RepositoryContent{
+			Type: github.Ptr("file"),
+			Name: github.Ptr(name),
+			Path: github.Ptr(path),
+			SHA:  entry.SHA,
+			Size: github.Ptr(len(content)),
+		},
+		Commit: *newCommit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
+
 // CreateRepository creates a tool to create a new GitHub repository.
 func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("create_repository",
-			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_DESCRIPTION", "Create a new GitHub repository in your account")),
+			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_DESCRIPTION", "Create a new GitHub repository in your account, or in an organization. To create a repository from a template, use create_repository_from_template")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_CREATE_REPOSITORY_USER_TITLE", "Create repository"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -388,6 +843,9 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 			mcp.WithBoolean("autoInit",
 				mcp.Description("Initialize with README"),
 			),
+			mcp.WithString("organization",
+				mcp.Description("Organization to create the repository in, defaults to your account"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			name, err := RequiredParam[string](request, "name")
@@ -406,6 +864,10 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			organization, err := OptionalParam[string](request, "organization")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			repo := &github.Repository{
 				Name:        github.Ptr(name),
@@ -418,7 +880,7 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			createdRepo, resp, err := client.Repositories.Create(ctx, "", repo)
+			createdRepo, resp, err := client.Repositories.Create(ctx, organization, repo)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to create repository",
@@ -448,7 +910,7 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 // GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
 func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_file_contents",
-			mcp.WithDescription(t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository")),
+			mcp.WithDescription(t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", fmt.Sprintf("Get the contents of a file or directory from a GitHub repository. Files larger than max_size (%d bytes by default) are not returned, only their path, size and type; fetch a range of their lines or bytes instead.", defaultFileContentsMaxSize))),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_FILE_CONTENTS_USER_TITLE", "Get file or directory contents"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -471,6 +933,961 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			mcp.WithString("sha",
 				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
 			),
+			mcp.WithNumber("start_line",
+				mcp.Description("First line of the range of a text file to return, counting from 1"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line of the range of a text file to return, the end of the file when not set"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("start_byte",
+				mcp.Description("Offset of the first byte of the range to return, counting from 0. Can't be combined with a line range"),
+				mcp.Min(0),
+			),
+			mcp.WithNumber("end_byte",
+				mcp.Description("Offset just past the last byte of the range to return, the end of the file when not set"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("max_size",
+				mcp.Description(fmt.Sprintf("Largest file or range in bytes to return (max %d)", maxFileContentsMaxSize)),
+				mcp.Min(1),
+				mcp.Max(maxFileContentsMaxSize),
+				mcp.DefaultNumber(defaultFileContentsMaxSize),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentRange, err := optionalFileRange(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxSize, err := OptionalIntParamWithDefault(request, "max_size", defaultFileContentsMaxSize)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxSize < 1 || maxSize > maxFileContentsMaxSize {
+				return mcp.NewToolResultError(fmt.Sprintf("max_size must be between 1 and %d", maxFileContentsMaxSize)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError("failed to get GitHub client"), nil
+			}
+
+			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+			}
+
+			// If the path is (most likely) not to be a directory, we will
+			// first try to get the raw content from the GitHub raw content API.
+			if path != "" && !strings.HasSuffix(path, "/") {
+				// First, get file info from Contents API to retrieve SHA and size
+				opts := &github.RepositoryContentGetOptions{Ref: ref}
+				fileContent, _, respContents, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
+				if respContents != nil {
+					defer func() { _ = respContents.Body.Close() }()
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get file SHA",
+						respContents,
+						err,
+					), nil
+				}
+				if fileContent == nil || fileContent.SHA == nil {
+					return mcp.NewToolResultError("file content SHA is nil"), nil
+				}
+
+				// Check the size up front, so that giant files aren't downloaded only to be refused
+				if !contentRange.set() && fileContent.GetSize() > maxSize {
+					return fileSummaryResult(fileContent, maxSize), nil
+				}
+
+				var resourceURI string
+				switch {
+				case sha != "":
+					resourceURI, err = url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
+					if err != nil {
+						return nil, fmt.Errorf("failed to create resource URI: %w", err)
+					}
+				case ref != "":
+					resourceURI, err = url.JoinPath("repo://", owner, repo, ref, "contents", path)
+					if err != nil {
+						return nil, fmt.Errorf("failed to create resource URI: %w", err)
+					}
+				default:
+					resourceURI, err = url.JoinPath("repo://", owner, repo, "contents", path)
+					if err != nil {
+						return nil, fmt.Errorf("failed to create resource URI: %w", err)
+					}
+				}
+
+				rawClient, err := getRawClient(ctx)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
+				}
+				resp, err := rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get raw repository content"), nil
+				}
+				defer func() {
+					_ = resp.Body.Close()
+				}()
+
+				if resp.StatusCode == http.StatusOK {
+					// If the raw content is found, return it directly
+					body, err := io.ReadAll(resp.Body)
+					if err != nil {
+						return mcp.NewToolResultError("failed to read response body"), nil
+					}
+					return fileContentsResult(fileContent, resourceURI, resp.Header.Get("Content-Type"), body, contentRange, maxSize), nil
+				}
+
+				// The contents API embeds small files base64 encoded, which is as good when the raw content isn't available
+				if fileContent.GetEncoding() == "base64" {
+					decoded, err := fileContent.GetContent()
+					if err != nil {
+						return nil, fmt.Errorf("failed to decode file content: %w", err)
+					}
+					body := []byte(decoded)
+					return fileContentsResult(fileContent, resourceURI, http.DetectContentType(body), body, contentRange, maxSize), nil
+				}
+			}
+
+			if rawOpts.SHA != "" {
+				ref = rawOpts.SHA
+			}
+			if strings.HasSuffix(path, "/") {
+				opts := &github.RepositoryContentGetOptions{Ref: ref}
+				_, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
+				if err == nil && resp.StatusCode == http.StatusOK {
+					defer func() { _ = resp.Body.Close() }()
+					r, err := json.Marshal(dirContent)
+					if err != nil {
+						return mcp.NewToolResultError("failed to marshal response"), nil
+					}
+					return mcp.NewToolResultText(string(r)), nil
+				}
+			}
+
+			// The path does not point to a file or directory.
+			// Instead let's try to find it in the Git Tree by matching the end of the path.
+
+			// Step 1: Get Git Tree recursively
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get git tree",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Step 2: Filter tree for matching paths
+			const maxMatchingFiles = 3
+			matchingFiles := filterPaths(tree.Entries, path, maxMatchingFiles)
+			if len(matchingFiles) > 0 {
+				matchingFilesJSON, err := json.Marshal(matchingFiles)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to marshal matching files: %s", err)), nil
+				}
+				resolvedRefs, err := json.Marshal(rawOpts)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to marshal resolved refs: %s", err)), nil
+				}
+				return mcp.NewToolResultText(fmt.Sprintf("Path did not point to a file or directory, but resolved git ref to %s with possible path matches: %s", resolvedRefs, matchingFilesJSON)), nil
+			}
+
+			return mcp.NewToolResultError("Failed to get file contents. The path does not point to a file or directory, or the file does not exist in the repository."), nil
+		}
+}
+
+const (
+	// defaultFileContentsMaxSize and maxFileContentsMaxSize bound the size of the files and ranges get_file_contents returns.
+	defaultFileContentsMaxSize = 1 << 20  // 1 MiB
+	maxFileContentsMaxSize     = 10 << 20 // 10 MiB
+)
+
+// fileRange selects part of a file for get_file_contents. Lines count from 1 and the range includes
+// endLine; bytes count from 0 and the range stops before endByte. Zero values leave a bound open.
+type fileRange struct {
+	startLine, endLine int
+	startByte, endByte int
+}
+
+func (r fileRange) lines() bool { return r.startLine > 0 || r.endLine > 0 }
+
+func (r fileRange) bytes() bool { return r.startByte > 0 || r.endByte > 0 }
+
+func (r fileRange) set() bool { return r.lines() || r.bytes() }
+
+// optionalFileRange reads the range parameters of get_file_contents.
+func optionalFileRange(request mcp.CallToolRequest) (fileRange, error) {
+	var r fileRange
+	var err error
+	if r.startLine, err = OptionalIntParam(request, "start_line"); err != nil {
+		return r, err
+	}
+	if r.endLine, err = OptionalIntParam(request, "end_line"); err != nil {
+		return r, err
+	}
+	if r.startByte, err = OptionalIntParam(request, "start_byte"); err != nil {
+		return r, err
+	}
+	if r.endByte, err = OptionalIntParam(request, "end_byte"); err != nil {
+		return r, err
+	}
+
+	switch {
+	case r.startLine < 0 || r.endLine < 0 || r.startByte < 0 || r.endByte < 0:
+		return r, fmt.Errorf("range bounds must not be negative")
+	case r.lines() && r.bytes():
+		return r, fmt.Errorf("a line range can't be combined with a byte range")
+	case r.endLine > 0 && r.endLine < max(r.startLine, 1):
+		return r, fmt.Errorf("end_line must not be before start_line")
+	case r.endByte > 0 && r.endByte <= r.startByte:
+		return r, fmt.Errorf("end_byte must be after start_byte")
+	}
+	return r, nil
+}
+
+// slice returns the part of body the range selects, and a description of it for the result message.
+func (r fileRange) slice(body []byte) ([]byte, string, error) {
+	if r.bytes() {
+		if r.startByte >= len(body) {
+			return nil, "", fmt.Errorf("start_byte %d is past the end of the file, which has %d bytes", r.startByte, len(body))
+		}
+		end := len(body)
+		if r.endByte > 0 && r.endByte < end {
+			end = r.endByte
+		}
+		return body[r.startByte:end], fmt.Sprintf("bytes %d-%d of %d", r.startByte, end, len(body)), nil
+	}
+
+	lines := bytes.SplitAfter(body, []byte("\n"))
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	start := max(r.startLine, 1)
+	if start > len(lines) {
+		return nil, "", fmt.Errorf("start_line %d is past the end of the file, which has %d lines", start, len(lines))
+	}
+	end := len(lines)
+	if r.endLine > 0 && r.endLine < end {
+		end = r.endLine
+	}
+	return bytes.Join(lines[start-1:end], nil), fmt.Sprintf("lines %d-%d of %d", start, end, len(lines)), nil
+}
+
+// isBinaryContent reports whether content is binary rather than UTF-8 text.
+func isBinaryContent(content []byte) bool {
+	return bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content)
+}
+
+// FileSummary describes a file get_file_contents doesn't return because it is too large.
+type FileSummary struct {
+	Path    string `json:"path"`
+	Size    int    `json:"size"`
+	Type    string `json:"type"`
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+}
+
+// fileSummaryResult returns the summary of a file larger than maxSize in place of its content.
+func fileSummaryResult(file *github.RepositoryContent, maxSize int) *mcp.CallToolResult {
+	fileType := mime.TypeByExtension(filepath.Ext(file.GetPath()))
+	if fileType == "" {
+		fileType = "unknown"
+	}
+	return MarshalledTextResult(FileSummary{
+		Path:    file.GetPath(),
+		Size:    file.GetSize(),
+		Type:    fileType,
+		SHA:     file.GetSHA(),
+		Message: fmt.Sprintf("The file is larger than max_size (%d bytes) and was not returned. Fetch a range of it with start_line and end_line, or start_byte and end_byte, or raise max_size.", maxSize),
+	})
+}
+
+// fileContentsResult returns the content of a file, or the part of it selected by contentRange, as a text
+// resource when it is text and a blob resource otherwise.
+func fileContentsResult(file *github.RepositoryContent, resourceURI, contentType string, body []byte, contentRange fileRange, maxSize int) *mcp.CallToolResult {
+	// Content types can't be trusted to tell text apart, GitHub serves many binary files as text
+	binary := !strings.HasPrefix(contentType, "application") && !strings.HasPrefix(contentType, "text") || isBinaryContent(body)
+	kind := "text"
+	if binary {
+		kind = "binary"
+	}
+	message := fmt.Sprintf("successfully downloaded %s file", kind)
+
+	if contentRange.set() {
+		if binary && contentRange.lines() {
+			return mcp.NewToolResultError(fmt.Sprintf("%s is a binary file, fetch a byte range of it instead of lines", file.GetPath()))
+		}
+		part, description, err := contentRange.slice(body)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error())
+		}
+		body = part
+		message = fmt.Sprintf("successfully downloaded %s of %s file", description, kind)
+	}
+	if len(body) > maxSize {
+		if !contentRange.set() {
+			return fileSummaryResult(file, maxSize)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("the range is %d bytes, more than max_size (%d bytes), fetch a smaller one", len(body), maxSize))
+	}
+	if sha := file.GetSHA(); sha != "" {
+		message += fmt.Sprintf(" (SHA: %s)", sha)
+	}
+
+	if binary {
+		return mcp.NewToolResultResource(message, mcp.BlobResourceContents{
+			URI:      resourceURI,
+			Blob:     base64.StdEncoding.EncodeToString(body),
+			MIMEType: contentType,
+		})
+	}
+	return mcp.NewToolResultResource(message, mcp.TextResourceContents{
+		URI:      resourceURI,
+		Text:     string(body),
+		MIMEType: contentType,
+	})
+}
+
+// fetchSubtreeConcurrency bounds how many files fetch_subtree downloads at the same time.
+const fetchSubtreeConcurrency = 8
+
+// FetchSubtree creates a tool to fetch the contents of all text files below a path in a repository.
+func FetchSubtree(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("fetch_subtree",
+			mcp.WithDescription(t("TOOL_FETCH_SUBTREE_DESCRIPTION", "Fetch the contents of every text file below a directory of a GitHub repository in one call, e.g. a single service of a monorepo. Binary files and files over the size cap are listed as skipped, and fetching stops once the total byte budget is used up.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FETCH_SUBTREE_USER_TITLE", "Fetch directory subtree"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Directory to fetch, relative to the repository root (e.g. services/api)"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or `refs/pull/{pr_number}/head`"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+			),
+			mcp.WithNumber("max_file_bytes",
+				mcp.Description("Skip files larger than this many bytes (default 100000)"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("max_total_bytes",
+				mcp.Description("Stop fetching once this many bytes of content have been collected (default 1000000)"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxFileBytes, err := OptionalIntParamWithDefault(request, "max_file_bytes", 100000)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxTotalBytes, err := OptionalIntParamWithDefault(request, "max_total_bytes", 1000000)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, rawOpts.SHA, true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository tree",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			type subtreeFile struct {
+				Path    string `json:"path"`
+				Size    int    `json:"size"`
+				Content string `json:"content,omitempty"`
+			}
+			type skippedFile struct {
+				Path   string `json:"path"`
+				Size   int    `json:"size"`
+				Reason string `json:"reason"`
+			}
+
+			prefix := strings.Trim(path, "/") + "/"
+			if prefix == "/" {
+				prefix = ""
+			}
+
+			// Decide what to fetch up front using the sizes from the tree, so the budget is applied
+			// in path order regardless of the order in which the concurrent downloads complete.
+			var files []*subtreeFile
+			var skipped []skippedFile
+			totalBytes := 0
+			for _, entry := range tree.Entries {
+				if entry.GetType() != "blob" || !strings.HasPrefix(entry.GetPath(), prefix) {
+					continue
+				}
+				size := entry.GetSize()
+				switch {
+				case size > maxFileBytes:
+					skipped = append(skipped, skippedFile{Path: entry.GetPath(), Size: size, Reason: "too_large"})
+				case totalBytes+size > maxTotalBytes:
+					skipped = append(skipped, skippedFile{Path: entry.GetPath(), Size: size, Reason: "budget_exceeded"})
+				default:
+					totalBytes += size
+					files = append(files, &subtreeFile{Path: entry.GetPath(), Size: size})
+				}
+			}
+
+			if len(files) == 0 && len(skipped) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("no files found under %s", path)), nil
+			}
+
+			rawClient, err := getRawClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
+			}
+
+			errs := make([]error, len(files))
+			binary := make([]bool, len(files))
+			sem := make(chan struct{}, fetchSubtreeConcurrency)
+			var wg sync.WaitGroup
+			for i, f := range files {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					sem <- struct{}{}
+					defer func() { <-sem }()
+
+					resp, err := rawClient.GetRawContent(ctx, owner, repo, f.Path, &raw.ContentOpts{SHA: rawOpts.SHA})
+					if err != nil {
+						errs[i] = fmt.Errorf("failed to get raw content of %s: %w", f.Path, err)
+						return
+					}
+					defer func() { _ = resp.Body.Close() }()
+					if resp.StatusCode != http.StatusOK {
+						errs[i] = fmt.Errorf("failed to get raw content of %s: unexpected status %d", f.Path, resp.StatusCode)
+						return
+					}
+
+					body, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxFileBytes)+1))
+					if err != nil {
+						errs[i] = fmt.Errorf("failed to read content of %s: %w", f.Path, err)
+						return
+					}
+					if isBinaryContent(body) {
+						binary[i] = true
+						return
+					}
+					f.Content = string(body)
+				}()
+			}
+			wg.Wait()
+
+			fetched := make([]*subtreeFile, 0, len(files))
+			for i, f := range files {
+				switch {
+				case errs[i] != nil:
+					return mcp.NewToolResultError(errs[i].Error()), nil
+				case binary[i]:
+					skipped = append(skipped, skippedFile{Path: f.Path, Size: f.Size, Reason: "binary"})
+				default:
+					fetched = append(fetched, f)
+				}
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"sha":     rawOpts.SHA,
+				"path":    path,
+				"files":   fetched,
+				"skipped": skipped,
+				// A truncated tree means GitHub did not return every entry, so the listing may be incomplete.
+				"tree_truncated": tree.GetTruncated(),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ForkRepository creates a tool to fork a repository.
+func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("fork_repository",
+			mcp.WithDescription(t("TOOL_FORK_REPOSITORY_DESCRIPTION", "Fork a GitHub repository to your account or specified organization")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FORK_REPOSITORY_USER_TITLE", "Fork repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("organization",
+				mcp.Description("Organization to fork to"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			org, err := OptionalParam[string](request, "organization")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.RepositoryCreateForkOptions{}
+			if org != "" {
+				opts.Organization = org
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			forkedRepo, resp, err := client.Repositories.CreateFork(ctx, owner, repo, opts)
+			if err != nil {
+				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
+				// and it's not a real error.
+				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
+					return mcp.NewToolResultText("Fork is in progress"), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to fork repository",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusAccepted {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to fork repository: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(forkedRepo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ChangedFile is a file changed between two commits.
+type ChangedFile struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	Status           string `json:"status"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	Changes          int    `json:"changes"`
+	Patch            string `json:"patch,omitempty"`
+}
+
+func newChangedFile(file *github.CommitFile, includePatch bool) ChangedFile {
+	changed := ChangedFile{
+		Filename:         file.GetFilename(),
+		PreviousFilename: file.GetPreviousFilename(),
+		Status:           file.GetStatus(),
+		Additions:        file.GetAdditions(),
+		Deletions:        file.GetDeletions(),
+		Changes:          file.GetChanges(),
+	}
+	if includePatch {
+		changed.Patch = file.GetPatch()
+	}
+	return changed
+}
+
+// CompareCommits creates a tool to compare two refs of a repository.
+func CompareCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("compare_commits",
+			mcp.WithDescription(t("TOOL_COMPARE_COMMITS_DESCRIPTION", "Compare two commits, branches or tags of a GitHub repository. Returns how far head is ahead of and behind base, the commits head has that base lacks, oldest first, and the files that differ, with their patches if requested. Useful for changelogs and for finding what changed between releases.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMPARE_COMMITS_USER_TITLE", "Compare commits"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag name to compare from"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag name to compare to. Use owner:branch for a branch of a fork"),
+			),
+			mcp.WithBoolean("include_patches",
+				mcp.Description("Include the patch of each changed file, which can be large"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includePatches, err := OptionalParam[bool](request, "include_patches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The pagination applies to the commits, the files are always the first 300 that differ
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to compare %s...%s", base, head),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			commits := make([]CommitGraphNode, 0, len(comparison.Commits))
+			for _, commit := range comparison.Commits {
+				commits = append(commits, newCommitGraphNode(commit))
+			}
+			files := make([]ChangedFile, 0, len(comparison.Files))
+			for _, f := range comparison.Files {
+				files = append(files, newChangedFile(f, includePatches))
+			}
+
+			result := map[string]any{
+				"base":           base,
+				"head":           head,
+				"status":         comparison.GetStatus(),
+				"ahead_by":       comparison.GetAheadBy(),
+				"behind_by":      comparison.GetBehindBy(),
+				"total_commits":  comparison.GetTotalCommits(),
+				"merge_base_sha": comparison.GetMergeBaseCommit().GetSHA(),
+				"html_url":       comparison.GetHTMLURL(),
+				"commits":        commits,
+				"files":          files,
+			}
+			if resp.NextPage != 0 {
+				result["next_page"] = resp.NextPage
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// CompareForkWithUpstream creates a tool to compare a fork's default branch with its upstream's default branch.
+func CompareForkWithUpstream(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("compare_fork_with_upstream",
+			mcp.WithDescription(t("TOOL_COMPARE_FORK_WITH_UPSTREAM_DESCRIPTION", "Compare a fork with the repository it was forked from. Returns how many commits the fork's default branch is ahead of and behind the upstream default branch, along with the files that differ.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMPARE_FORK_WITH_UPSTREAM_USER_TITLE", "Compare fork with upstream"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Fork owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Fork repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			fork, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if !fork.GetFork() || fork.GetParent() == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s/%s is not a fork, so there is no upstream repository to compare with", owner, repo)), nil
+			}
+			upstream := fork.GetParent()
+
+			// Comparing across repositories is done from the upstream's point of view, with the fork's
+			// branch qualified by its owner. "ahead" therefore means commits in the fork that upstream lacks.
+			head := fmt.Sprintf("%s:%s", fork.GetOwner().GetLogin(), fork.GetDefaultBranch())
+			comparison, resp, err := client.Repositories.CompareCommits(ctx,
+				upstream.GetOwner().GetLogin(),
+				upstream.GetName(),
+				upstream.GetDefaultBranch(),
+				head,
+				&github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to compare fork with upstream",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			files := make([]ChangedFile, 0, len(comparison.Files))
+			for _, f := range comparison.Files {
+				files = append(files, newChangedFile(f, false))
+			}
+
+			result := map[string]any{
+				"fork":            fork.GetFullName(),
+				"fork_branch":     fork.GetDefaultBranch(),
+				"upstream":        upstream.GetFullName(),
+				"upstream_branch": upstream.GetDefaultBranch(),
+				"status":          comparison.GetStatus(),
+				"ahead_by":        comparison.GetAheadBy(),
+				"behind_by":       comparison.GetBehindBy(),
+				"merge_base_sha":  comparison.GetMergeBaseCommit().GetSHA(),
+				"html_url":        comparison.GetHTMLURL(),
+				"files":           files,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SyncForkBranch creates a tool to sync a branch of a fork with its upstream repository.
+func SyncForkBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("sync_fork_branch",
+			mcp.WithDescription(t("TOOL_SYNC_FORK_BRANCH_DESCRIPTION", "Sync a branch of a fork with the upstream repository, as the \"Sync fork\" button on GitHub does. Fails with a conflict error if the branch has diverged and cannot be updated cleanly.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SYNC_FORK_BRANCH_USER_TITLE", "Sync fork branch with upstream"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Fork owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Fork repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch of the fork to sync. Defaults to the fork's default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The merge-upstream endpoint answers a non-fork with the same 422 it uses for
+			// other sync failures, so check up front to be able to say what went wrong.
+			fork, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if !fork.GetFork() {
+				return mcp.NewToolResultError(fmt.Sprintf("%s/%s is not a fork, so there is no upstream repository to sync with", owner, repo)), nil
+			}
+			if branch == "" {
+				branch = fork.GetDefaultBranch()
+			}
+
+			result, resp, err := client.Repositories.MergeUpstream(ctx, owner, repo, &github.RepoMergeUpstreamRequest{
+				Branch: github.Ptr(branch),
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusConflict {
+					return mcp.NewToolResultError(fmt.Sprintf("branch %s has diverged from upstream and cannot be synced automatically; merge or rebase the upstream changes manually", branch)), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to sync branch %s with upstream", branch),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			status := "synced"
+			if result.GetMergeType() == "none" {
+				status = "already_up_to_date"
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"status":      status,
+				"branch":      branch,
+				"merge_type":  result.GetMergeType(),
+				"base_branch": result.GetBaseBranch(),
+				"message":     result.GetMessage(),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetDefaultBranch creates a tool to get the default branch of a repository.
+func GetDefaultBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_default_branch",
+			mcp.WithDescription(t("TOOL_GET_DEFAULT_BRANCH_DESCRIPTION", "Get the default branch of a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_DEFAULT_BRANCH_USER_TITLE", "Get default branch"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -481,173 +1898,42 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			path, err := RequiredParam[string](request, "path")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			ref, err := OptionalParam[string](request, "ref")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			sha, err := OptionalParam[string](request, "sha")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
 
 			client, err := getClient(ctx)
 			if err != nil {
-				return mcp.NewToolResultError("failed to get GitHub client"), nil
-			}
-
-			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
-			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
-			}
-
-			// If the path is (most likely) not to be a directory, we will
-			// first try to get the raw content from the GitHub raw content API.
-			if path != "" && !strings.HasSuffix(path, "/") {
-				// First, get file info from Contents API to retrieve SHA
-				var fileSHA string
-				opts := &github.RepositoryContentGetOptions{Ref: ref}
-				fileContent, _, respContents, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
-				if respContents != nil {
-					defer func() { _ = respContents.Body.Close() }()
-				}
-				if err != nil {
-					return ghErrors.NewGitHubAPIErrorResponse(ctx,
-						"failed to get file SHA",
-						respContents,
-						err,
-					), nil
-				}
-				if fileContent == nil || fileContent.SHA == nil {
-					return mcp.NewToolResultError("file content SHA is nil"), nil
-				}
-				fileSHA = *fileContent.SHA
-
-				rawClient, err := getRawClient(ctx)
-				if err != nil {
-					return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
-				}
-				resp, err := rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
-				if err != nil {
-					return mcp.NewToolResultError("failed to get raw repository content"), nil
-				}
-				defer func() {
-					_ = resp.Body.Close()
-				}()
-
-				if resp.StatusCode == http.StatusOK {
-					// If the raw content is found, return it directly
-					body, err := io.ReadAll(resp.Body)
-					if err != nil {
-						return mcp.NewToolResultError("failed to read response body"), nil
-					}
-					contentType := resp.Header.Get("Content-Type")
-
-					var resourceURI string
-					switch {
-					case sha != "":
-						resourceURI, err = url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					case ref != "":
-						resourceURI, err = url.JoinPath("repo://", owner, repo, ref, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					default:
-						resourceURI, err = url.JoinPath("repo://", owner, repo, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					}
-
-					if strings.HasPrefix(contentType, "application") || strings.HasPrefix(contentType, "text") {
-						result := mcp.TextResourceContents{
-							URI:      resourceURI,
-							Text:     string(body),
-							MIMEType: contentType,
-						}
-						// Include SHA in the result metadata
-						if fileSHA != "" {
-							return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded text file (SHA: %s)", fileSHA), result), nil
-						}
-						return mcp.NewToolResultResource("successfully downloaded text file", result), nil
-					}
-
-					result := mcp.BlobResourceContents{
-						URI:      resourceURI,
-						Blob:     base64.StdEncoding.EncodeToString(body),
-						MIMEType: contentType,
-					}
-					// Include SHA in the result metadata
-					if fileSHA != "" {
-						return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded binary file (SHA: %s)", fileSHA), result), nil
-					}
-					return mcp.NewToolResultResource("successfully downloaded binary file", result), nil
-
-				}
-			}
-
-			if rawOpts.SHA != "" {
-				ref = rawOpts.SHA
-			}
-			if strings.HasSuffix(path, "/") {
-				opts := &github.RepositoryContentGetOptions{Ref: ref}
-				_, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
-				if err == nil && resp.StatusCode == http.StatusOK {
-					defer func() { _ = resp.Body.Close() }()
-					r, err := json.Marshal(dirContent)
-					if err != nil {
-						return mcp.NewToolResultError("failed to marshal response"), nil
-					}
-					return mcp.NewToolResultText(string(r)), nil
-				}
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// The path does not point to a file or directory.
-			// Instead let's try to find it in the Git Tree by matching the end of the path.
-
-			// Step 1: Get Git Tree recursively
-			tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, true)
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get git tree",
+					"failed to get repository",
 					resp,
 					err,
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			// Step 2: Filter tree for matching paths
-			const maxMatchingFiles = 3
-			matchingFiles := filterPaths(tree.Entries, path, maxMatchingFiles)
-			if len(matchingFiles) > 0 {
-				matchingFilesJSON, err := json.Marshal(matchingFiles)
-				if err != nil {
-					return mcp.NewToolResultError(fmt.Sprintf("failed to marshal matching files: %s", err)), nil
-				}
-				resolvedRefs, err := json.Marshal(rawOpts)
-				if err != nil {
-					return mcp.NewToolResultError(fmt.Sprintf("failed to marshal resolved refs: %s", err)), nil
-				}
-				return mcp.NewToolResultText(fmt.Sprintf("Path did not point to a file or directory, but resolved git ref to %s with possible path matches: %s", resolvedRefs, matchingFilesJSON)), nil
+			r, err := json.Marshal(map[string]string{
+				"repository":     repository.GetFullName(),
+				"default_branch": repository.GetDefaultBranch(),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultError("Failed to get file contents. The path does not point to a file or directory, or the file does not exist in the repository."), nil
+			return mcp.NewToolResultText(string(r)), nil
 		}
 }
 
-// ForkRepository creates a tool to fork a repository.
-func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("fork_repository",
-			mcp.WithDescription(t("TOOL_FORK_REPOSITORY_DESCRIPTION", "Fork a GitHub repository to your account or specified organization")),
+// SetDefaultBranch creates a tool to change the default branch of a repository.
+func SetDefaultBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_default_branch",
+			mcp.WithDescription(t("TOOL_SET_DEFAULT_BRANCH_DESCRIPTION", "Change the default branch of a GitHub repository. The branch must already exist. This has downstream effects: it becomes the base for new pull requests and the target of clones, and GitHub may retarget open pull requests and update branch protection and Pages settings that refer to the old default branch. Only use this when the user explicitly asks for it.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_FORK_REPOSITORY_USER_TITLE", "Fork repository"),
-				ReadOnlyHint: ToBoolPtr(false),
+				Title:           t("TOOL_SET_DEFAULT_BRANCH_USER_TITLE", "Set default branch"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -657,8 +1943,9 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("organization",
-				mcp.Description("Organization to fork to"),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Name of the existing branch to make the default"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -670,44 +1957,46 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			org, err := OptionalParam[string](request, "organization")
+			branch, err := RequiredParam[string](request, "branch")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			opts := &github.RepositoryCreateForkOptions{}
-			if org != "" {
-				opts.Organization = org
-			}
-
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			forkedRepo, resp, err := client.Repositories.CreateFork(ctx, owner, repo, opts)
+
+			// Verify the branch exists first, the repository update API otherwise fails with a generic validation error.
+			_, resp, err := client.Repositories.GetBranch(ctx, owner, repo, branch, 1)
 			if err != nil {
-				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
-				// and it's not a real error.
-				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
-					return mcp.NewToolResultText("Fork is in progress"), nil
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("branch %s does not exist in %s/%s", branch, owner, repo)), nil
 				}
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to fork repository",
+					fmt.Sprintf("failed to get branch: %s", branch),
 					resp,
 					err,
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusAccepted {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to fork repository: %s", string(body))), nil
+			repository, resp, err := client.Repositories.Edit(ctx, owner, repo, &github.Repository{
+				DefaultBranch: github.Ptr(branch),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to set default branch",
+					resp,
+					err,
+				), nil
 			}
+			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(forkedRepo)
+			r, err := json.Marshal(map[string]string{
+				"repository":     repository.GetFullName(),
+				"default_branch": repository.GetDefaultBranch(),
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -996,7 +2285,7 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 // PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
 func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("push_files",
-			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit")),
+			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit, creating, updating and deleting files together")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -1019,7 +2308,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 					map[string]interface{}{
 						"type":                 "object",
 						"additionalProperties": false,
-						"required":             []string{"path", "content"},
+						"required":             []string{"path"},
 						"properties": map[string]interface{}{
 							"path": map[string]interface{}{
 								"type":        "string",
@@ -1027,11 +2316,15 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 							},
 							"content": map[string]interface{}{
 								"type":        "string",
-								"description": "file content",
+								"description": "file content, required unless the file is deleted",
+							},
+							"delete": map[string]interface{}{
+								"type":        "boolean",
+								"description": "delete the file instead of writing content to it",
 							},
 						},
 					}),
-				mcp.Description("Array of file objects to push, each object with path (string) and content (string)"),
+				mcp.Description("Array of file objects to push, each object with path (string) and either content (string) or delete (true)"),
 			),
 			mcp.WithString("message",
 				mcp.Required(),
@@ -1062,6 +2355,49 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
 			}
 
+			type pushFile struct {
+				path    string
+				content string
+				delete  bool
+			}
+			files := make([]pushFile, 0, len(filesObj))
+			seen := make(map[string]bool, len(filesObj))
+			for _, file := range filesObj {
+				fileMap, ok := file.(map[string]interface{})
+				if !ok {
+					return mcp.NewToolResultError("each file must be an object with path and content"), nil
+				}
+
+				path, ok := fileMap["path"].(string)
+				if !ok || path == "" {
+					return mcp.NewToolResultError("each file must have a path"), nil
+				}
+				if seen[path] {
+					return mcp.NewToolResultError(fmt.Sprintf("file %s is listed more than once", path)), nil
+				}
+				seen[path] = true
+
+				if del, _ := fileMap["delete"].(bool); del {
+					if _, hasContent := fileMap["content"]; hasContent {
+						return mcp.NewToolResultError(fmt.Sprintf("file %s can't have content and be deleted", path)), nil
+					}
+					files = append(files, pushFile{path: path, delete: true})
+					continue
+				}
+
+				content, ok := fileMap["content"].(string)
+				if !ok {
+					return mcp.NewToolResultError("each file must have content, or delete set to true"), nil
+				}
+
+				// Check sizes up front, so that nothing is uploaded when one of the files can't be committed
+				if len(content) > maxFileSize {
+					return fileTooLargeResult(path, len(content)), nil
+				}
+
+				files = append(files, pushFile{path: path, content: content})
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -1092,28 +2428,33 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			// Create tree entries for all files
 			var entries []*github.TreeEntry
 
-			for _, file := range filesObj {
-				fileMap, ok := file.(map[string]interface{})
-				if !ok {
-					return mcp.NewToolResultError("each file must be an object with path and content"), nil
-				}
-
-				path, ok := fileMap["path"].(string)
-				if !ok || path == "" {
-					return mcp.NewToolResultError("each file must have a path"), nil
+			for _, file := range files {
+				// An entry without a SHA or content removes the path from the tree
+				if file.delete {
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(file.path),
+						Mode: github.Ptr("100644"),
+						Type: github.Ptr("blob"),
+					})
+					continue
 				}
 
-				content, ok := fileMap["content"].(string)
-				if !ok {
-					return mcp.NewToolResultError("each file must have content"), nil
+				// Large files are uploaded as blobs first, to keep the tree request small
+				if len(file.content) > largeFileThreshold {
+					entry, errResult := createLargeFileBlob(ctx, client, owner, repo, file.path, file.content)
+					if errResult != nil {
+						return errResult, nil
+					}
+					entries = append(entries, entry)
+					continue
 				}
 
 				// Create a tree entry for the file
 				entries = append(entries, &github.TreeEntry{
-					Path:    github.Ptr(path),
+					Path:    github.Ptr(file.path),
 					Mode:    github.Ptr("100644"), // Regular file mode
 					Type:    github.Ptr("blob"),
-					Content: github.Ptr(content),
+					Content: github.Ptr(file.content),
 				})
 			}
 
@@ -1321,6 +2662,215 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 		}
 }
 
+// maxTagReleasePages caps the pages of releases scanned to find the releases of a page of tags.
+const maxTagReleasePages = 10
+
+type tagsWithReleasesQuery struct {
+	Repository struct {
+		Refs struct {
+			Nodes []struct {
+				Name   githubv4.String
+				Target struct {
+					OID    githubv4.GitObjectID `graphql:"oid"`
+					Commit struct {
+						CommittedDate githubv4.DateTime
+					} `graphql:"... on Commit"`
+					Tag struct {
+						Tagger *struct {
+							Date githubv4.GitTimestamp
+						}
+						Target struct {
+							OID    githubv4.GitObjectID `graphql:"oid"`
+							Commit struct {
+								CommittedDate githubv4.DateTime
+							} `graphql:"... on Commit"`
+						}
+					} `graphql:"... on Tag"`
+				}
+			}
+			PageInfo   PageInfoFragment
+			TotalCount githubv4.Int
+		} `graphql:"refs(refPrefix: \"refs/tags/\", first: $first, after: $after, orderBy: {field: TAG_COMMIT_DATE, direction: DESC})"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+type tagReleasesQuery struct {
+	Repository struct {
+		Releases struct {
+			Nodes []struct {
+				TagName       githubv4.String
+				Name          githubv4.String
+				IsDraft       githubv4.Boolean
+				IsPrerelease  githubv4.Boolean
+				PublishedAt   *githubv4.DateTime
+				URL           githubv4.URI
+				ReleaseAssets struct {
+					TotalCount githubv4.Int
+				} `graphql:"releaseAssets(first: 1)"`
+			}
+			PageInfo PageInfoFragment
+		} `graphql:"releases(first: 100, after: $after, orderBy: {field: CREATED_AT, direction: DESC})"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// TagRelease is the release published for a tag.
+type TagRelease struct {
+	Name        string     `json:"name"`
+	Draft       bool       `json:"draft"`
+	Prerelease  bool       `json:"prerelease"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	URL         string     `json:"url"`
+	AssetCount  int        `json:"asset_count"`
+}
+
+// TagWithRelease is a tag together with the release published for it, if any.
+type TagWithRelease struct {
+	Name string `json:"name"`
+	// SHA is the commit the tag points at, TagSHA the annotated tag object if it is one
+	SHA        string      `json:"sha"`
+	TagSHA     string      `json:"tag_sha,omitempty"`
+	CommitDate time.Time   `json:"commit_date"`
+	TaggedAt   *time.Time  `json:"tagged_at,omitempty"`
+	Release    *TagRelease `json:"release"`
+}
+
+// ListTagsWithReleases creates a tool to list the tags of a repository joined with their releases.
+func ListTagsWithReleases(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_tags_with_releases",
+			mcp.WithDescription(t("TOOL_LIST_TAGS_WITH_RELEASES_DESCRIPTION", "List git tags in a GitHub repository, newest commit first, each with its target commit and date and the release published for it, including its draft and prerelease status and asset count")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TAGS_WITH_RELEASES_USER_TITLE", "List tags with releases"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var tagsQuery tagsWithReleasesQuery
+			vars := map[string]interface{}{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"first": githubv4.Int(*paginationParams.First),
+				"after": (*githubv4.String)(nil),
+			}
+			if paginationParams.After != nil {
+				vars["after"] = githubv4.String(*paginationParams.After)
+			}
+			if err := client.Query(ctx, &tagsQuery, vars); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list tags: %v", err)), nil
+			}
+
+			tags := make([]TagWithRelease, 0, len(tagsQuery.Repository.Refs.Nodes))
+			for _, node := range tagsQuery.Repository.Refs.Nodes {
+				tag := TagWithRelease{
+					Name:       string(node.Name),
+					SHA:        string(node.Target.OID),
+					CommitDate: node.Target.Commit.CommittedDate.Time,
+				}
+				// Annotated tags point at a tag object, which in turn points at the commit
+				if node.Target.Tag.Target.OID != "" {
+					tag.TagSHA = tag.SHA
+					tag.SHA = string(node.Target.Tag.Target.OID)
+					tag.CommitDate = node.Target.Tag.Target.Commit.CommittedDate.Time
+					if node.Target.Tag.Tagger != nil {
+						tag.TaggedAt = &node.Target.Tag.Tagger.Date.Time
+					}
+				}
+				tags = append(tags, tag)
+			}
+			byName := make(map[string]*TagWithRelease, len(tags))
+			for i := range tags {
+				byName[tags[i].Name] = &tags[i]
+			}
+
+			// Releases can't be looked up by a list of tags, so scan them newest first until every tag
+			// on this page is matched
+			unmatched := len(tags)
+			releasesComplete := true
+			releaseVars := map[string]interface{}{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"after": (*githubv4.String)(nil),
+			}
+			for page := 0; unmatched > 0; page++ {
+				if page == maxTagReleasePages {
+					releasesComplete = false
+					break
+				}
+				var releasesQuery tagReleasesQuery
+				if err := client.Query(ctx, &releasesQuery, releaseVars); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list releases: %v", err)), nil
+				}
+				for _, node := range releasesQuery.Repository.Releases.Nodes {
+					tag, ok := byName[string(node.TagName)]
+					if !ok || tag.Release != nil {
+						continue
+					}
+					tag.Release = &TagRelease{
+						Name:       string(node.Name),
+						Draft:      bool(node.IsDraft),
+						Prerelease: bool(node.IsPrerelease),
+						URL:        node.URL.String(),
+						AssetCount: int(node.ReleaseAssets.TotalCount),
+					}
+					if node.PublishedAt != nil {
+						tag.Release.PublishedAt = &node.PublishedAt.Time
+					}
+					unmatched--
+				}
+				pageInfo := releasesQuery.Repository.Releases.PageInfo
+				if !pageInfo.HasNextPage {
+					break
+				}
+				releaseVars["after"] = pageInfo.EndCursor
+			}
+
+			pageInfo := tagsQuery.Repository.Refs.PageInfo
+			result := map[string]interface{}{
+				"tags":       tags,
+				"totalCount": int(tagsQuery.Repository.Refs.TotalCount),
+				"pageInfo": map[string]interface{}{
+					"hasNextPage": pageInfo.HasNextPage,
+					"endCursor":   string(pageInfo.EndCursor),
+				},
+			}
+			if !releasesComplete {
+				result["note"] = fmt.Sprintf("only the %d most recent releases were searched, tags without a release may still have an older one", maxTagReleasePages*100)
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
 // filterPaths filters the entries in a GitHub tree to find paths that
 // match the given suffix.
 // maxResults limits the number of results returned to first maxResults entries,
@@ -4,17 +4,21 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -33,6 +37,11 @@ func Test_GetFileContents(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "path")
 	assert.Contains(t, tool.InputSchema.Properties, "ref")
 	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "start_line")
+	assert.Contains(t, tool.InputSchema.Properties, "end_line")
+	assert.Contains(t, tool.InputSchema.Properties, "start_byte")
+	assert.Contains(t, tool.InputSchema.Properties, "end_byte")
+	assert.Contains(t, tool.InputSchema.Properties, "max_size")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
 	// Mock response for raw content
@@ -281,6 +290,337 @@ func Test_GetFileContents(t *testing.T) {
 	}
 }
 
+func Test_GetFileContents_RangesAndSizes(t *testing.T) {
+	text := []byte("one\ntwo\nthree\nfour\n")
+
+	// mockedFile serves a file from the contents API and, unless rawStatus is 0, from the raw content API
+	mockedFile := func(fileContent *github.RepositoryContent, rawStatus int, contentType string, body []byte) *http.Client {
+		options := []mock.MockBackendOption{
+			mock.WithRequestMatch(
+				mock.GetReposGitRefByOwnerByRepoByRef,
+				&github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr("")}},
+			),
+			mock.WithRequestMatch(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				fileContent,
+			),
+		}
+		if rawStatus != 0 {
+			options = append(options, mock.WithRequestMatchHandler(
+				raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", contentType)
+					w.WriteHeader(rawStatus)
+					_, _ = w.Write(body)
+				}),
+			))
+		}
+		return mock.NewMockedHTTPClient(options...)
+	}
+	textFile := &github.RepositoryContent{
+		Path: github.Ptr("notes.txt"),
+		SHA:  github.Ptr("abc123"),
+		Type: github.Ptr("file"),
+		Size: github.Ptr(len(text)),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedMessage string
+		expectedText    string
+		expectedBlob    []byte
+		expectedSummary *FileSummary
+	}{
+		{
+			name:            "returns a line range",
+			mockedClient:    mockedFile(textFile, http.StatusOK, "text/plain; charset=utf-8", text),
+			requestArgs:     map[string]interface{}{"start_line": float64(2), "end_line": float64(3)},
+			expectedMessage: "successfully downloaded lines 2-3 of 4 of text file (SHA: abc123)",
+			expectedText:    "two\nthree\n",
+		},
+		{
+			name:            "clamps line ranges to the end of the file",
+			mockedClient:    mockedFile(textFile, http.StatusOK, "text/plain; charset=utf-8", text),
+			requestArgs:     map[string]interface{}{"start_line": float64(4), "end_line": float64(10)},
+			expectedMessage: "successfully downloaded lines 4-4 of 4 of text file (SHA: abc123)",
+			expectedText:    "four\n",
+		},
+		{
+			name:            "returns a byte range",
+			mockedClient:    mockedFile(textFile, http.StatusOK, "text/plain; charset=utf-8", text),
+			requestArgs:     map[string]interface{}{"start_byte": float64(4), "end_byte": float64(7)},
+			expectedMessage: "successfully downloaded bytes 4-7 of 19 of text file (SHA: abc123)",
+			expectedText:    "two",
+		},
+		{
+			name:           "refuses ranges past the end of the file",
+			mockedClient:   mockedFile(textFile, http.StatusOK, "text/plain; charset=utf-8", text),
+			requestArgs:    map[string]interface{}{"start_line": float64(5)},
+			expectError:    true,
+			expectedErrMsg: "start_line 5 is past the end of the file, which has 4 lines",
+		},
+		{
+			name:           "refuses line and byte ranges together",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{"start_line": float64(1), "end_byte": float64(10)},
+			expectError:    true,
+			expectedErrMsg: "a line range can't be combined with a byte range",
+		},
+		{
+			name: "summarizes files larger than max_size without downloading them",
+			mockedClient: mockedFile(&github.RepositoryContent{
+				Path: github.Ptr("data/dump.json"),
+				SHA:  github.Ptr("def456"),
+				Type: github.Ptr("file"),
+				Size: github.Ptr(5000),
+			}, 0, "", nil),
+			requestArgs: map[string]interface{}{"path": "data/dump.json", "max_size": float64(1000)},
+			expectedSummary: &FileSummary{
+				Path:    "data/dump.json",
+				Size:    5000,
+				Type:    "application/json",
+				SHA:     "def456",
+				Message: "The file is larger than max_size (1000 bytes) and was not returned. Fetch a range of it with start_line and end_line, or start_byte and end_byte, or raise max_size.",
+			},
+		},
+		{
+			name:           "refuses ranges larger than max_size",
+			mockedClient:   mockedFile(textFile, http.StatusOK, "text/plain; charset=utf-8", text),
+			requestArgs:    map[string]interface{}{"start_line": float64(1), "max_size": float64(5)},
+			expectError:    true,
+			expectedErrMsg: "the range is 19 bytes, more than max_size (5 bytes), fetch a smaller one",
+		},
+		{
+			name:            "detects binary files served as text",
+			mockedClient:    mockedFile(textFile, http.StatusOK, "text/plain; charset=utf-8", []byte{0x00, 0x01, 0xff}),
+			requestArgs:     map[string]interface{}{},
+			expectedMessage: "successfully downloaded binary file (SHA: abc123)",
+			expectedBlob:    []byte{0x00, 0x01, 0xff},
+		},
+		{
+			name:           "refuses line ranges of binary files",
+			mockedClient:   mockedFile(textFile, http.StatusOK, "application/octet-stream", []byte{0x00, 0x01, 0xff}),
+			requestArgs:    map[string]interface{}{"start_line": float64(1)},
+			expectError:    true,
+			expectedErrMsg: "notes.txt is a binary file, fetch a byte range of it instead of lines",
+		},
+		{
+			name: "decodes the content of the contents API when the raw content is unavailable",
+			mockedClient: mockedFile(&github.RepositoryContent{
+				Path:     github.Ptr("notes.txt"),
+				SHA:      github.Ptr("abc123"),
+				Type:     github.Ptr("file"),
+				Size:     github.Ptr(len(text)),
+				Encoding: github.Ptr("base64"),
+				Content:  github.Ptr(base64.StdEncoding.EncodeToString(text)),
+			}, http.StatusNotFound, "text/plain", nil),
+			requestArgs:     map[string]interface{}{"end_line": float64(1)},
+			expectedMessage: "successfully downloaded lines 1-1 of 4 of text file (SHA: abc123)",
+			expectedText:    "one\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			mockRawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := GetFileContents(stubGetClientFn(client), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "notes.txt",
+				"ref":   "refs/heads/main",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			switch {
+			case tc.expectError:
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+			case tc.expectedSummary != nil:
+				var summary FileSummary
+				require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+				assert.Equal(t, *tc.expectedSummary, summary)
+			case tc.expectedBlob != nil:
+				require.Len(t, result.Content, 2)
+				assert.Equal(t, tc.expectedMessage, result.Content[0].(mcp.TextContent).Text)
+				assert.Equal(t, base64.StdEncoding.EncodeToString(tc.expectedBlob), getBlobResourceResult(t, result).Blob)
+			default:
+				require.Len(t, result.Content, 2)
+				assert.Equal(t, tc.expectedMessage, result.Content[0].(mcp.TextContent).Text)
+				assert.Equal(t, tc.expectedText, getTextResourceResult(t, result).Text)
+			}
+		})
+	}
+}
+func Test_FetchSubtree(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
+	tool, _ := FetchSubtree(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "fetch_subtree", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "max_file_bytes")
+	assert.Contains(t, tool.InputSchema.Properties, "max_total_bytes")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	mockTree := &github.Tree{
+		SHA: github.Ptr("abc123"),
+		Entries: []*github.TreeEntry{
+			{SHA: github.Ptr("README.md-sha"), Path: github.Ptr("README.md"), Type: github.Ptr("blob"), Size: github.Ptr(10)},
+			{Path: github.Ptr("services/api"), Type: github.Ptr("tree")},
+			{SHA: github.Ptr("main.go-sha"), Path: github.Ptr("services/api/main.go"), Type: github.Ptr("blob"), Size: github.Ptr(12)},
+			{SHA: github.Ptr("logo.png-sha"), Path: github.Ptr("services/api/logo.png"), Type: github.Ptr("blob"), Size: github.Ptr(4)},
+			{SHA: github.Ptr("big.json-sha"), Path: github.Ptr("services/api/big.json"), Type: github.Ptr("blob"), Size: github.Ptr(500)},
+			{SHA: github.Ptr("util.go-sha"), Path: github.Ptr("services/api/util.go"), Type: github.Ptr("blob"), Size: github.Ptr(12)},
+			{SHA: github.Ptr("index.html-sha"), Path: github.Ptr("services/web/index.html"), Type: github.Ptr("blob"), Size: github.Ptr(10)},
+		},
+	}
+
+	rawContents := map[string]string{
+		"/owner/repo/abc123/services/api/main.go":  "package main",
+		"/owner/repo/abc123/services/api/logo.png": "\x89PNG\x00",
+		"/owner/repo/abc123/services/api/util.go":  "package util",
+	}
+
+	tests := []struct {
+		name            string
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedFiles   []string
+		expectedSkipped map[string]string
+		expectedErrMsg  string
+	}{
+		{
+			name: "fetches text files under the prefix",
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"path":           "services/api/",
+				"sha":            "abc123",
+				"max_file_bytes": float64(100),
+			},
+			expectError:   false,
+			expectedFiles: []string{"services/api/main.go", "services/api/util.go"},
+			expectedSkipped: map[string]string{
+				"services/api/logo.png": "binary",
+				"services/api/big.json": "too_large",
+			},
+		},
+		{
+			name: "stops once the byte budget is used",
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"path":            "services/api",
+				"sha":             "abc123",
+				"max_file_bytes":  float64(100),
+				"max_total_bytes": float64(20),
+			},
+			expectError:   false,
+			expectedFiles: []string{"services/api/main.go"},
+			expectedSkipped: map[string]string{
+				"services/api/logo.png": "binary",
+				"services/api/big.json": "too_large",
+				"services/api/util.go":  "budget_exceeded",
+			},
+		},
+		{
+			name: "no files under the prefix",
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "docs",
+				"sha":   "abc123",
+			},
+			expectError:    true,
+			expectedErrMsg: "no files found under docs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					mockTree,
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						content, ok := rawContents[r.URL.Path]
+						if !ok {
+							w.WriteHeader(http.StatusNotFound)
+							return
+						}
+						_, _ = w.Write([]byte(content))
+					}),
+				),
+			)
+			client := github.NewClient(mockedClient)
+			rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := FetchSubtree(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned struct {
+				SHA   string `json:"sha"`
+				Files []struct {
+					Path    string `json:"path"`
+					Content string `json:"content"`
+				} `json:"files"`
+				Skipped []struct {
+					Path   string `json:"path"`
+					Reason string `json:"reason"`
+				} `json:"skipped"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "abc123", returned.SHA)
+
+			files := make([]string, 0, len(returned.Files))
+			for _, f := range returned.Files {
+				files = append(files, f.Path)
+				assert.Equal(t, rawContents["/owner/repo/abc123/"+f.Path], f.Content)
+			}
+			assert.Equal(t, tc.expectedFiles, files)
+
+			skipped := map[string]string{}
+			for _, s := range returned.Skipped {
+				skipped[s.Path] = s.Reason
+			}
+			assert.Equal(t, tc.expectedSkipped, skipped)
+		})
+	}
+}
+
 func Test_ForkRepository(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -302,52 +642,684 @@ func Test_ForkRepository(t *testing.T) {
 		Owner: &github.User{
 			Login: github.Ptr("new-owner"),
 		},
-		HTMLURL:       github.Ptr("https://github.com/new-owner/repo"),
-		DefaultBranch: github.Ptr("main"),
-		Fork:          github.Ptr(true),
-		ForksCount:    github.Ptr(0),
-	}
-
-	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedRepo   *github.Repository
-		expectedErrMsg string
-	}{
+		HTMLURL:       github.Ptr("https://github.com/new-owner/repo"),
+		DefaultBranch: github.Ptr("main"),
+		Fork:          github.Ptr(true),
+		ForksCount:    github.Ptr(0),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedRepo   *github.Repository
+		expectedErrMsg string
+	}{
+		{
+			name: "successful repository fork",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposForksByOwnerByRepo,
+					mockResponse(t, http.StatusAccepted, mockForkedRepo),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:  false,
+			expectedRepo: mockForkedRepo,
+		},
+		{
+			name: "repository fork fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposForksByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to fork repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ForkRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			assert.Contains(t, textContent.Text, "Fork is in progress")
+		})
+	}
+}
+
+func Test_CompareCommits(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "compare_commits", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "head")
+	assert.Contains(t, tool.InputSchema.Properties, "include_patches")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+
+	mockComparison := &github.CommitsComparison{
+		Status:       github.Ptr("ahead"),
+		AheadBy:      github.Ptr(1),
+		BehindBy:     github.Ptr(0),
+		TotalCommits: github.Ptr(1),
+		HTMLURL:      github.Ptr("https://github.com/owner/repo/compare/v1.0.0...main"),
+		MergeBaseCommit: &github.RepositoryCommit{
+			SHA: github.Ptr("base-sha"),
+		},
+		Commits: []*github.RepositoryCommit{
+			{
+				SHA:     github.Ptr("abc123"),
+				Parents: []*github.Commit{{SHA: github.Ptr("base-sha")}},
+				Commit: &github.Commit{
+					Message: github.Ptr("Add feature\n\nLonger description"),
+					Author: &github.CommitAuthor{
+						Name: github.Ptr("Test User"),
+						Date: &github.Timestamp{Time: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)},
+					},
+				},
+				Author: &github.User{Login: github.Ptr("testuser")},
+			},
+		},
+		Files: []*github.CommitFile{
+			{
+				Filename:         github.Ptr("docs/feature.md"),
+				PreviousFilename: github.Ptr("docs/draft.md"),
+				Status:           github.Ptr("renamed"),
+				Additions:        github.Ptr(2),
+				Deletions:        github.Ptr(0),
+				Changes:          github.Ptr(2),
+				Patch:            github.Ptr("@@ -1 +1,3 @@\n # Feature\n+\n+Details"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult string
+	}{
+		{
+			name: "compares two refs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/owner/repo/compare/v1.0.0...main").andThen(
+						mockResponse(t, http.StatusOK, mockComparison),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.0.0",
+				"head":  "main",
+			},
+			expectedResult: `{
+				"base": "v1.0.0",
+				"head": "main",
+				"status": "ahead",
+				"ahead_by": 1,
+				"behind_by": 0,
+				"total_commits": 1,
+				"merge_base_sha": "base-sha",
+				"html_url": "https://github.com/owner/repo/compare/v1.0.0...main",
+				"commits": [{"sha": "abc123", "parents": ["base-sha"], "subject": "Add feature", "author": "testuser", "date": "2024-03-01T10:00:00Z"}],
+				"files": [{"filename": "docs/feature.md", "previous_filename": "docs/draft.md", "status": "renamed", "additions": 2, "deletions": 0, "changes": 2}]
+			}`,
+		},
+		{
+			name: "includes patches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockComparison,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"base":            "v1.0.0",
+				"head":            "main",
+				"include_patches": true,
+			},
+			expectedResult: `{
+				"base": "v1.0.0",
+				"head": "main",
+				"status": "ahead",
+				"ahead_by": 1,
+				"behind_by": 0,
+				"total_commits": 1,
+				"merge_base_sha": "base-sha",
+				"html_url": "https://github.com/owner/repo/compare/v1.0.0...main",
+				"commits": [{"sha": "abc123", "parents": ["base-sha"], "subject": "Add feature", "author": "testuser", "date": "2024-03-01T10:00:00Z"}],
+				"files": [{"filename": "docs/feature.md", "previous_filename": "docs/draft.md", "status": "renamed", "additions": 2, "deletions": 0, "changes": 2, "patch": "@@ -1 +1,3 @@\n # Feature\n+\n+Details"}]
+			}`,
+		},
+		{
+			name: "comparison fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.0.0",
+				"head":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare v1.0.0...missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CompareCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedResult, textContent.Text)
+		})
+	}
+}
+
+func Test_CompareForkWithUpstream(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareForkWithUpstream(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "compare_fork_with_upstream", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockFork := &github.Repository{
+		Name:          github.Ptr("repo"),
+		FullName:      github.Ptr("forker/repo"),
+		Owner:         &github.User{Login: github.Ptr("forker")},
+		DefaultBranch: github.Ptr("main"),
+		Fork:          github.Ptr(true),
+		Parent: &github.Repository{
+			Name:          github.Ptr("repo"),
+			FullName:      github.Ptr("upstream/repo"),
+			Owner:         &github.User{Login: github.Ptr("upstream")},
+			DefaultBranch: github.Ptr("trunk"),
+		},
+	}
+
+	mockComparison := &github.CommitsComparison{
+		Status:   github.Ptr("diverged"),
+		AheadBy:  github.Ptr(2),
+		BehindBy: github.Ptr(5),
+		MergeBaseCommit: &github.RepositoryCommit{
+			SHA: github.Ptr("base-sha"),
+		},
+		Files: []*github.CommitFile{
+			{
+				Filename:  github.Ptr("README.md"),
+				Status:    github.Ptr("modified"),
+				Additions: github.Ptr(3),
+				Deletions: github.Ptr(1),
+				Changes:   github.Ptr(4),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful comparison",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockFork,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/upstream/repo/compare/trunk...forker:main").andThen(
+						mockResponse(t, http.StatusOK, mockComparison),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "forker",
+				"repo":  "repo",
+			},
+			expectError: false,
+		},
+		{
+			name: "repository is not a fork",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{
+						Name:     github.Ptr("repo"),
+						FullName: github.Ptr("owner/repo"),
+						Fork:     github.Ptr(false),
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "owner/repo is not a fork",
+		},
+		{
+			name: "comparison fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockFork,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "forker",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare fork with upstream",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CompareForkWithUpstream(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent := getTextResult(t, result)
+
+			var returned struct {
+				Fork           string `json:"fork"`
+				Upstream       string `json:"upstream"`
+				UpstreamBranch string `json:"upstream_branch"`
+				Status         string `json:"status"`
+				AheadBy        int    `json:"ahead_by"`
+				BehindBy       int    `json:"behind_by"`
+				Files          []struct {
+					Filename string `json:"filename"`
+				} `json:"files"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, "forker/repo", returned.Fork)
+			assert.Equal(t, "upstream/repo", returned.Upstream)
+			assert.Equal(t, "trunk", returned.UpstreamBranch)
+			assert.Equal(t, "diverged", returned.Status)
+			assert.Equal(t, 2, returned.AheadBy)
+			assert.Equal(t, 5, returned.BehindBy)
+			require.Len(t, returned.Files, 1)
+			assert.Equal(t, "README.md", returned.Files[0].Filename)
+		})
+	}
+}
+
+func Test_SyncForkBranch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SyncForkBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "sync_fork_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockFork := &github.Repository{
+		Name:          github.Ptr("repo"),
+		FullName:      github.Ptr("forker/repo"),
+		DefaultBranch: github.Ptr("main"),
+		Fork:          github.Ptr(true),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedStatus string
+		expectedErrMsg string
+	}{
+		{
+			name: "fast-forwards default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockFork,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"branch": "main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepoMergeUpstreamResult{
+							Message:    github.Ptr("Successfully fetched and fast-forwarded from upstream upstream:main."),
+							MergeType:  github.Ptr("fast-forward"),
+							BaseBranch: github.Ptr("upstream:main"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "forker",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedStatus: "synced",
+		},
+		{
+			name: "already up to date",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockFork,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"branch": "dev",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepoMergeUpstreamResult{
+							Message:    github.Ptr("This branch is not behind the upstream upstream:dev."),
+							MergeType:  github.Ptr("none"),
+							BaseBranch: github.Ptr("upstream:dev"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "forker",
+				"repo":   "repo",
+				"branch": "dev",
+			},
+			expectError:    false,
+			expectedStatus: "already_up_to_date",
+		},
+		{
+			name: "repository is not a fork",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{
+						Name: github.Ptr("repo"),
+						Fork: github.Ptr(false),
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "owner/repo is not a fork",
+		},
+		{
+			name: "diverged branch cannot be synced",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockFork,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusConflict)
+						_, _ = w.Write([]byte(`{"message": "There are merge conflicts"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "forker",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "branch main has diverged from upstream",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SyncForkBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent := getTextResult(t, result)
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, returned["status"])
+		})
+	}
+}
+
+func Test_GetDefaultBranch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDefaultBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_default_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposByOwnerByRepo,
+			&github.Repository{
+				FullName:      github.Ptr("owner/repo"),
+				DefaultBranch: github.Ptr("master"),
+			},
+		),
+	))
+	_, handler := GetDefaultBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned map[string]string
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "master", returned["default_branch"])
+}
+
+func Test_SetDefaultBranch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetDefaultBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_default_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successfully sets default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					&github.Branch{Name: github.Ptr("main")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"default_branch": "main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{
+							FullName:      github.Ptr("owner/repo"),
+							DefaultBranch: github.Ptr("main"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectError: false,
+		},
 		{
-			name: "successful repository fork",
+			name: "branch does not exist",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.PostReposForksByOwnerByRepo,
-					mockResponse(t, http.StatusAccepted, mockForkedRepo),
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Branch not found"}`))
+					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
 			},
-			expectError:  false,
-			expectedRepo: mockForkedRepo,
+			expectError:    true,
+			expectedErrMsg: "branch main does not exist in owner/repo",
 		},
 		{
-			name: "repository fork fails",
+			name: "repository update fails",
 			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					&github.Branch{Name: github.Ptr("main")},
+				),
 				mock.WithRequestMatchHandler(
-					mock.PostReposForksByOwnerByRepo,
+					mock.PatchReposByOwnerByRepo,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 						w.WriteHeader(http.StatusForbidden)
-						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+						_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
 					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to fork repository",
+			expectedErrMsg: "failed to set default branch",
 		},
 	}
 
@@ -355,7 +1327,7 @@ func Test_ForkRepository(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := ForkRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := SetDefaultBranch(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -375,10 +1347,9 @@ func Test_ForkRepository(t *testing.T) {
 			require.NoError(t, err)
 			require.False(t, result.IsError)
 
-			// Parse the result and get the text content if no error
-			textContent := getTextResult(t, result)
-
-			assert.Contains(t, textContent.Text, "Fork is in progress")
+			var returned map[string]string
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "main", returned["default_branch"])
 		})
 	}
 }
@@ -719,6 +1690,9 @@ func Test_ListCommits(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "sha")
 	assert.Contains(t, tool.InputSchema.Properties, "author")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "until")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
@@ -826,6 +1800,55 @@ func Test_ListCommits(t *testing.T) {
 			expectError:     false,
 			expectedCommits: mockCommits,
 		},
+		{
+			name: "successful commits fetch with path and dates",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"path":     "pkg/github",
+						"since":    "2024-01-01T00:00:00Z",
+						"until":    "2024-02-01T12:00:00Z",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockCommits),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "pkg/github",
+				"since": "2024-01-01",
+				"until": "2024-02-01T12:00:00Z",
+			},
+			expectError:     false,
+			expectedCommits: mockCommits,
+		},
+		{
+			name:         "invalid date",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"until": "last week",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid until",
+		},
+		{
+			name:         "until before since",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "2024-02-01",
+				"until": "2024-01-01",
+			},
+			expectError:    true,
+			expectedErrMsg: "until must not be before since",
+		},
 		{
 			name: "commits fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -889,6 +1912,229 @@ func Test_ListCommits(t *testing.T) {
 	}
 }
 
+func Test_GetCommitGraph(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCommitGraph(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_commit_graph", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "head")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "max_commits")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	commit := func(sha, message string, parents ...string) *github.RepositoryCommit {
+		c := &github.RepositoryCommit{
+			SHA: github.Ptr(sha),
+			Commit: &github.Commit{
+				Message: github.Ptr(message),
+				Author: &github.CommitAuthor{
+					Name: github.Ptr("Mona"),
+					Date: &github.Timestamp{Time: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)},
+				},
+			},
+			Author: &github.User{Login: github.Ptr("octocat")},
+		}
+		for _, parent := range parents {
+			c.Parents = append(c.Parents, &github.Commit{SHA: github.Ptr(parent)})
+		}
+		return c
+	}
+
+	// main: c1 <- c2 <- c4 (merge of c3 from feature)
+	mainHistory := []*github.RepositoryCommit{
+		commit("c4", "Merge pull request #7 from owner/feature\n\nAdd feature", "c2", "c3"),
+		commit("c3", "Add feature", "c1"),
+		commit("c2", "Fix typo", "c1"),
+		commit("c1", "Initial commit"),
+	}
+
+	type graphResult struct {
+		Head         string            `json:"head"`
+		Base         string            `json:"base"`
+		MergeBase    string            `json:"merge_base"`
+		TotalCommits int               `json:"total_commits"`
+		Truncated    bool              `json:"truncated"`
+		Merges       int               `json:"merges"`
+		Commits      []CommitGraphNode `json:"commits"`
+	}
+
+	t.Run("ancestry of a branch", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsByOwnerByRepo,
+				expectQueryParams(t, map[string]string{"sha": "main", "per_page": "100"}).andThen(
+					mockResponse(t, http.StatusOK, mainHistory),
+				),
+			),
+		))
+		_, handler := GetCommitGraph(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner": "owner",
+			"repo":  "repo",
+			"head":  "main",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var graph graphResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &graph))
+		assert.Equal(t, "main", graph.Head)
+		assert.False(t, graph.Truncated)
+		assert.Equal(t, 1, graph.Merges)
+		require.Len(t, graph.Commits, 4)
+		assert.Equal(t, CommitGraphNode{
+			SHA:     "c4",
+			Parents: []string{"c2", "c3"},
+			Subject: "Merge pull request #7 from owner/feature",
+			Author:  "octocat",
+			Date:    "2025-06-01T12:00:00Z",
+			IsMerge: true,
+		}, graph.Commits[0])
+		assert.Equal(t, []string{}, graph.Commits[3].Parents)
+	})
+
+	t.Run("stops at max_commits", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsByOwnerByRepo,
+				expectQueryParams(t, map[string]string{"per_page": "2"}).andThen(
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Link", `<https://api.github.com/repositories/1/commits?page=2&per_page=2>; rel="next"`)
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write(mock.MustMarshal(mainHistory[:2]))
+					}),
+				),
+			),
+		))
+		_, handler := GetCommitGraph(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":       "owner",
+			"repo":        "repo",
+			"max_commits": float64(2),
+		}))
+		require.NoError(t, err)
+
+		var graph graphResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &graph))
+		assert.True(t, graph.Truncated)
+		require.Len(t, graph.Commits, 2)
+		assert.Equal(t, "c4", graph.Commits[0].SHA)
+	})
+
+	t.Run("range between two refs", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposCompareByOwnerByRepoByBasehead,
+				&github.CommitsComparison{
+					MergeBaseCommit: commit("c1", "Initial commit"),
+					TotalCommits:    github.Ptr(3),
+					// Comparisons list commits oldest first
+					Commits: []*github.RepositoryCommit{mainHistory[2], mainHistory[1], mainHistory[0]},
+				},
+			),
+		))
+		_, handler := GetCommitGraph(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner": "owner",
+			"repo":  "repo",
+			"base":  "v1.0.0",
+			"head":  "main",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var graph graphResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &graph))
+		assert.Equal(t, "v1.0.0", graph.Base)
+		assert.Equal(t, "c1", graph.MergeBase)
+		assert.Equal(t, 3, graph.TotalCommits)
+		assert.False(t, graph.Truncated)
+		require.Len(t, graph.Commits, 3)
+		assert.Equal(t, []string{"c4", "c3", "c2"}, []string{graph.Commits[0].SHA, graph.Commits[1].SHA, graph.Commits[2].SHA})
+	})
+
+	t.Run("large range keeps the newest commits", func(t *testing.T) {
+		page := func(from, to int) []*github.RepositoryCommit {
+			var commits []*github.RepositoryCommit
+			for i := from; i <= to; i++ {
+				commits = append(commits, commit(fmt.Sprintf("c%d", i), "Commit"))
+			}
+			return commits
+		}
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposCompareByOwnerByRepoByBasehead,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					commits := page(1, 100)
+					if r.URL.Query().Get("page") == "2" {
+						commits = page(101, 150)
+					}
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write(mock.MustMarshal(&github.CommitsComparison{
+						TotalCommits: github.Ptr(150),
+						Commits:      commits,
+					}))
+				}),
+			),
+		))
+		_, handler := GetCommitGraph(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":       "owner",
+			"repo":        "repo",
+			"base":        "v1.0.0",
+			"head":        "main",
+			"max_commits": float64(40),
+		}))
+		require.NoError(t, err)
+
+		var graph graphResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &graph))
+		assert.True(t, graph.Truncated)
+		require.Len(t, graph.Commits, 40)
+		assert.Equal(t, "c150", graph.Commits[0].SHA)
+		assert.Equal(t, "c111", graph.Commits[39].SHA)
+	})
+
+	t.Run("invalid max_commits", func(t *testing.T) {
+		_, handler := GetCommitGraph(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":       "owner",
+			"repo":        "repo",
+			"max_commits": float64(1000),
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "max_commits must be between 1 and 500")
+	})
+
+	t.Run("unknown ref", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsByOwnerByRepo,
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			),
+		))
+		_, handler := GetCommitGraph(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner": "owner",
+			"repo":  "repo",
+			"head":  "missing",
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to list commits: missing")
+	})
+}
+
 func Test_CreateOrUpdateFile(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -928,6 +2174,13 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 		},
 	}
 
+	// Setup mocks for files committed through the git data API
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("base123")},
+	}
+	largeContent := strings.Repeat("a", largeFileThreshold+1)
+
 	tests := []struct {
 		name            string
 		mockedClient    *http.Client
@@ -954,61 +2207,265 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 				"owner":   "owner",
 				"repo":    "repo",
 				"path":    "docs/example.md",
-				"content": "# Example\n\nThis is an example file.",
+				"content": "# Example\n\nThis is an example file.",
+				"message": "Add example file",
+				"branch":  "main",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "successful file update with SHA",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Update example file",
+						"content": "IyBVcGRhdGVkIEV4YW1wbGUKClRoaXMgZmlsZSBoYXMgYmVlbiB1cGRhdGVkLg==", // Base64 encoded content
+						"branch":  "main",
+						"sha":     "abc123def456",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "# Updated Example\n\nThis file has been updated.",
+				"message": "Update example file",
+				"branch":  "main",
+				"sha":     "abc123def456",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "file creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Invalid request"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "#Invalid Content",
+				"message": "Invalid request",
+				"branch":  "nonexistent-branch",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create/update file",
+		},
+		{
+			name: "large file is committed through the git data API, keeping its mode",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{"ref": "base123"}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepositoryContent{
+							Type: github.Ptr("file"),
+							Path: github.Ptr("docs/example.md"),
+							SHA:  github.Ptr("abc123def456"),
+						}),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					&github.Commit{SHA: github.Ptr("base123"), Tree: &github.Tree{SHA: github.Ptr("tree123")}},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"content":  largeContent,
+						"encoding": "utf-8",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Blob{SHA: github.Ptr("newblob123")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						trees := map[string]*github.Tree{
+							"/repos/owner/repo/git/trees/tree123": {Entries: []*github.TreeEntry{
+								{Path: github.Ptr("README.md"), Mode: github.Ptr("100644"), Type: github.Ptr("blob")},
+								{Path: github.Ptr("docs"), Mode: github.Ptr("040000"), Type: github.Ptr("tree"), SHA: github.Ptr("docs123")},
+							}},
+							"/repos/owner/repo/git/trees/docs123": {Entries: []*github.TreeEntry{
+								{Path: github.Ptr("example.md"), Mode: github.Ptr("100755"), Type: github.Ptr("blob")},
+							}},
+						}
+						tree, ok := trees[r.URL.Path]
+						require.True(t, ok, "unexpected tree %s", r.URL.Path)
+						_, _ = w.Write(mock.MustMarshal(tree))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "tree123",
+						"tree": []interface{}{
+							map[string]interface{}{
+								"path": "docs/example.md",
+								"mode": "100755",
+								"type": "blob",
+								"sha":  "newblob123",
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("newtree123")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Update example file",
+						"tree":    "newtree123",
+						"parents": []interface{}{"base123"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockFileResponse.Commit),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]interface{}{
+						"sha":   "def456abc789",
+						"force": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRef),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": largeContent,
+				"message": "Update example file",
+				"branch":  "main",
+				"sha":     "abc123def456",
+			},
+			expectError: false,
+			expectedContent: &github.RepositoryContentResponse{
+				Content: &github.RepositoryContent{
+					Name: github.Ptr("example.md"),
+					Path: github.Ptr("docs/example.md"),
+					SHA:  github.Ptr("newblob123"),
+				},
+				Commit: mockFileResponse.Commit,
+			},
+		},
+		{
+			name: "large file update with stale sha fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Type: github.Ptr("file"),
+						Path: github.Ptr("docs/example.md"),
+						SHA:  github.Ptr("current456"),
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": largeContent,
+				"message": "Update example file",
+				"branch":  "main",
+				"sha":     "abc123def456",
+			},
+			expectError:    true,
+			expectedErrMsg: "does not match the current sha of docs/example.md",
+		},
+		{
+			name: "large file creation without sha fails when the file exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Type: github.Ptr("file"),
+						Path: github.Ptr("docs/example.md"),
+						SHA:  github.Ptr("current456"),
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": largeContent,
 				"message": "Add example file",
 				"branch":  "main",
 			},
-			expectError:     false,
-			expectedContent: mockFileResponse,
+			expectError:    true,
+			expectedErrMsg: "docs/example.md already exists, provide the sha",
 		},
 		{
-			name: "successful file update with SHA",
+			name: "blob upload rejected as too large",
 			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
 				mock.WithRequestMatchHandler(
-					mock.PutReposContentsByOwnerByRepoByPath,
-					expectRequestBody(t, map[string]interface{}{
-						"message": "Update example file",
-						"content": "IyBVcGRhdGVkIEV4YW1wbGUKClRoaXMgZmlsZSBoYXMgYmVlbiB1cGRhdGVkLg==", // Base64 encoded content
-						"branch":  "main",
-						"sha":     "abc123def456",
-					}).andThen(
-						mockResponse(t, http.StatusOK, mockFileResponse),
-					),
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					&github.Commit{SHA: github.Ptr("base123"), Tree: &github.Tree{SHA: github.Ptr("tree123")}},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					mockResponse(t, http.StatusRequestEntityTooLarge, `{"message": "Request Entity Too Large"}`),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner":   "owner",
 				"repo":    "repo",
 				"path":    "docs/example.md",
-				"content": "# Updated Example\n\nThis file has been updated.",
-				"message": "Update example file",
+				"content": largeContent,
+				"message": "Add example file",
 				"branch":  "main",
-				"sha":     "abc123def456",
 			},
-			expectError:     false,
-			expectedContent: mockFileResponse,
+			expectError:    true,
+			expectedErrMsg: "file too large for API",
 		},
 		{
-			name: "file creation fails",
+			name:         "file over the size limit fails without calling the API",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.PutReposContentsByOwnerByRepoByPath,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusUnprocessableEntity)
-						_, _ = w.Write([]byte(`{"message": "Invalid request"}`))
-					}),
-				),
+			// No requests expected
 			),
 			requestArgs: map[string]interface{}{
 				"owner":   "owner",
 				"repo":    "repo",
-				"path":    "docs/example.md",
-				"content": "#Invalid Content",
-				"message": "Invalid request",
-				"branch":  "nonexistent-branch",
+				"path":    "assets/huge.bin",
+				"content": strings.Repeat("a", maxFileSize+1),
+				"message": "Add huge file",
+				"branch":  "main",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to create/update file",
+			expectedErrMsg: "Git LFS",
 		},
 	}
 
@@ -1068,6 +2525,7 @@ func Test_CreateRepository(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "description")
 	assert.Contains(t, tool.InputSchema.Properties, "private")
 	assert.Contains(t, tool.InputSchema.Properties, "autoInit")
+	assert.Contains(t, tool.InputSchema.Properties, "organization")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
 
 	// Setup mock repository response
@@ -1142,6 +2600,23 @@ func Test_CreateRepository(t *testing.T) {
 			expectError:  false,
 			expectedRepo: mockRepo,
 		},
+		{
+			name: "successful repository creation in an organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsReposByOrg,
+					expectPath(t, "/orgs/octo-org/repos").andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name":         "test-repo",
+				"organization": "octo-org",
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
 		{
 			name: "repository creation fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -1255,6 +2730,9 @@ func Test_PushFiles(t *testing.T) {
 		},
 	}
 
+	largeContent := strings.Repeat("a", largeFileThreshold+1)
+	tooLargeContent := strings.Repeat("a", maxFileSize+1)
+
 	// Define test cases
 	tests := []struct {
 		name           string
@@ -1341,6 +2819,109 @@ func Test_PushFiles(t *testing.T) {
 			expectError: false,
 			expectedRef: mockUpdatedRef,
 		},
+		{
+			name: "successful push of written and deleted files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				// Deleted files are tree entries with a null SHA
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{
+								"path":    "docs/new.md",
+								"mode":    "100644",
+								"type":    "blob",
+								"content": "# New",
+							},
+							map[string]interface{}{
+								"path": "docs/old.md",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  nil,
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTree),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					mockNewCommit,
+				),
+				mock.WithRequestMatch(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockUpdatedRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "docs/new.md",
+						"content": "# New",
+					},
+					map[string]interface{}{
+						"path":   "docs/old.md",
+						"delete": true,
+					},
+				},
+				"message": "Move docs",
+			},
+			expectError: false,
+			expectedRef: mockUpdatedRef,
+		},
+		{
+			name:         "fails when a deleted file has content",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "docs/old.md",
+						"content": "# Old",
+						"delete":  true,
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    false,
+			expectedErrMsg: "file docs/old.md can't have content and be deleted",
+		},
+		{
+			name:         "fails when a file is listed twice",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# One",
+					},
+					map[string]interface{}{
+						"path":   "README.md",
+						"delete": true,
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    false,
+			expectedErrMsg: "file README.md is listed more than once",
+		},
 		{
 			name:         "fails when files parameter is invalid",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -1499,6 +3080,96 @@ func Test_PushFiles(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to create tree",
 		},
+		{
+			name: "large file is uploaded as a blob",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"content":  largeContent,
+						"encoding": "utf-8",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Blob{SHA: github.Ptr("blob123")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{
+								"path":    "README.md",
+								"mode":    "100644",
+								"type":    "blob",
+								"content": "# README",
+							},
+							map[string]interface{}{
+								"path": "assets/data.txt",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  "blob123",
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTree),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					mockNewCommit,
+				),
+				mock.WithRequestMatch(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockUpdatedRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+					},
+					map[string]interface{}{
+						"path":    "assets/data.txt",
+						"content": largeContent,
+					},
+				},
+				"message": "Update multiple files",
+			},
+			expectError: false,
+			expectedRef: mockUpdatedRef,
+		},
+		{
+			name:         "fails before uploading when a file exceeds the size limit",
+			mockedClient: mock.NewMockedHTTPClient(
+			// No requests expected
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "assets/huge.bin",
+						"content": tooLargeContent,
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    true,
+			expectedErrMsg: "file too large for API: assets/huge.bin",
+		},
 	}
 
 	for _, tc := range tests {
@@ -2113,6 +3784,189 @@ func Test_GetTag(t *testing.T) {
 	}
 }
 
+func Test_ListTagsWithReleases(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListTagsWithReleases(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_tags_with_releases", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tagsVars := map[string]interface{}{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+		"first": githubv4.Int(2),
+		"after": (*githubv4.String)(nil),
+	}
+	tagsResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"refs": map[string]any{
+				"nodes": []map[string]any{
+					{
+						"name": "v2.0.0",
+						"target": map[string]any{
+							"oid": "tag-object-sha",
+							"tagger": map[string]any{
+								"date": "2025-06-02T10:00:00+02:00",
+							},
+							"target": map[string]any{
+								"oid":           "commit-sha-2",
+								"committedDate": "2025-06-01T12:00:00Z",
+							},
+						},
+					},
+					{
+						"name": "v1.0.0",
+						"target": map[string]any{
+							"oid":           "commit-sha-1",
+							"committedDate": "2025-01-01T12:00:00Z",
+						},
+					},
+				},
+				"pageInfo": map[string]any{
+					"hasNextPage": true,
+					"endCursor":   "tags-cursor",
+				},
+				"totalCount": 5,
+			},
+		},
+	})
+	releasesVars := func(after any) map[string]interface{} {
+		return map[string]interface{}{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"after": after,
+		}
+	}
+	releasesPage := func(hasNextPage bool, nodes ...map[string]any) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"releases": map[string]any{
+					"nodes": nodes,
+					"pageInfo": map[string]any{
+						"hasNextPage": hasNextPage,
+						"endCursor":   "releases-cursor",
+					},
+				},
+			},
+		})
+	}
+	release := func(tag string, draft bool, assets int) map[string]any {
+		return map[string]any{
+			"tagName":       tag,
+			"name":          "Release " + tag,
+			"isDraft":       draft,
+			"isPrerelease":  false,
+			"publishedAt":   "2025-06-03T09:00:00Z",
+			"url":           "https://github.com/owner/repo/releases/tag/" + tag,
+			"releaseAssets": map[string]any{"totalCount": assets},
+		}
+	}
+
+	t.Run("joins tags with releases across release pages", func(t *testing.T) {
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(tagsWithReleasesQuery{}, tagsVars, tagsResponse),
+			githubv4mock.NewQueryMatcher(tagReleasesQuery{}, releasesVars((*githubv4.String)(nil)),
+				releasesPage(true, release("v3.0.0-rc1", true, 0), release("v2.0.0", false, 3))),
+			githubv4mock.NewQueryMatcher(tagReleasesQuery{}, releasesVars(githubv4.String("releases-cursor")),
+				releasesPage(false, release("v1.0.0", false, 1))),
+		))
+		_, handler := ListTagsWithReleases(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":   "owner",
+			"repo":    "repo",
+			"perPage": float64(2),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var response struct {
+			Tags       []TagWithRelease `json:"tags"`
+			TotalCount int              `json:"totalCount"`
+			PageInfo   struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			Note string `json:"note"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+
+		assert.Equal(t, 5, response.TotalCount)
+		assert.True(t, response.PageInfo.HasNextPage)
+		assert.Equal(t, "tags-cursor", response.PageInfo.EndCursor)
+		assert.Empty(t, response.Note)
+		require.Len(t, response.Tags, 2)
+
+		annotated := response.Tags[0]
+		assert.Equal(t, "v2.0.0", annotated.Name)
+		assert.Equal(t, "commit-sha-2", annotated.SHA)
+		assert.Equal(t, "tag-object-sha", annotated.TagSHA)
+		assert.True(t, annotated.CommitDate.Equal(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)))
+		require.NotNil(t, annotated.TaggedAt)
+		assert.True(t, annotated.TaggedAt.Equal(time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)))
+		require.NotNil(t, annotated.Release)
+		assert.Equal(t, "Release v2.0.0", annotated.Release.Name)
+		assert.False(t, annotated.Release.Draft)
+		assert.Equal(t, 3, annotated.Release.AssetCount)
+
+		lightweight := response.Tags[1]
+		assert.Equal(t, "v1.0.0", lightweight.Name)
+		assert.Equal(t, "commit-sha-1", lightweight.SHA)
+		assert.Empty(t, lightweight.TagSHA)
+		assert.Nil(t, lightweight.TaggedAt)
+		require.NotNil(t, lightweight.Release)
+		assert.Equal(t, 1, lightweight.Release.AssetCount)
+	})
+
+	t.Run("tags without a release", func(t *testing.T) {
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(tagsWithReleasesQuery{}, tagsVars, tagsResponse),
+			githubv4mock.NewQueryMatcher(tagReleasesQuery{}, releasesVars((*githubv4.String)(nil)),
+				releasesPage(false, release("v2.0.0", false, 3))),
+		))
+		_, handler := ListTagsWithReleases(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":   "owner",
+			"repo":    "repo",
+			"perPage": float64(2),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var response struct {
+			Tags []TagWithRelease `json:"tags"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		require.Len(t, response.Tags, 2)
+		assert.NotNil(t, response.Tags[0].Release)
+		assert.Nil(t, response.Tags[1].Release)
+	})
+
+	t.Run("query fails", func(t *testing.T) {
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(tagsWithReleasesQuery{}, tagsVars, githubv4mock.ErrorResponse("Could not resolve to a Repository")),
+		))
+		_, handler := ListTagsWithReleases(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":   "owner",
+			"repo":    "repo",
+			"perPage": float64(2),
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to list tags")
+	})
+}
+
 func Test_filterPaths(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,442 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// repositorySwitches are the on/off settings update_repository_settings can change.
+var repositorySwitches = []struct {
+	name        string
+	description string
+	set         func(repo *github.Repository, value bool)
+}{
+	{"allow_merge_commit", "Allow merging pull requests with a merge commit", func(r *github.Repository, v bool) { r.AllowMergeCommit = github.Ptr(v) }},
+	{"allow_squash_merge", "Allow squash merging pull requests", func(r *github.Repository, v bool) { r.AllowSquashMerge = github.Ptr(v) }},
+	{"allow_rebase_merge", "Allow rebase merging pull requests", func(r *github.Repository, v bool) { r.AllowRebaseMerge = github.Ptr(v) }},
+	{"allow_auto_merge", "Allow auto-merge on pull requests", func(r *github.Repository, v bool) { r.AllowAutoMerge = github.Ptr(v) }},
+	{"allow_update_branch", "Always suggest updating pull request branches", func(r *github.Repository, v bool) { r.AllowUpdateBranch = github.Ptr(v) }},
+	{"delete_branch_on_merge", "Delete head branches once their pull request is merged", func(r *github.Repository, v bool) { r.DeleteBranchOnMerge = github.Ptr(v) }},
+	{"has_issues", "Enable issues", func(r *github.Repository, v bool) { r.HasIssues = github.Ptr(v) }},
+	{"has_wiki", "Enable the wiki", func(r *github.Repository, v bool) { r.HasWiki = github.Ptr(v) }},
+	{"has_projects", "Enable projects", func(r *github.Repository, v bool) { r.HasProjects = github.Ptr(v) }},
+	{"has_discussions", "Enable discussions", func(r *github.Repository, v bool) { r.HasDiscussions = github.Ptr(v) }},
+}
+
+// RepositorySettings are the settings of a repository that update_repository_settings can change.
+type RepositorySettings struct {
+	FullName            string   `json:"full_name"`
+	Description         string   `json:"description"`
+	Homepage            string   `json:"homepage"`
+	Visibility          string   `json:"visibility"`
+	DefaultBranch       string   `json:"default_branch"`
+	Archived            bool     `json:"archived"`
+	AllowMergeCommit    bool     `json:"allow_merge_commit"`
+	AllowSquashMerge    bool     `json:"allow_squash_merge"`
+	AllowRebaseMerge    bool     `json:"allow_rebase_merge"`
+	AllowAutoMerge      bool     `json:"allow_auto_merge"`
+	AllowUpdateBranch   bool     `json:"allow_update_branch"`
+	DeleteBranchOnMerge bool     `json:"delete_branch_on_merge"`
+	HasIssues           bool     `json:"has_issues"`
+	HasWiki             bool     `json:"has_wiki"`
+	HasProjects         bool     `json:"has_projects"`
+	HasDiscussions      bool     `json:"has_discussions"`
+	Topics              []string `json:"topics"`
+}
+
+func newRepositorySettings(repo *github.Repository) *RepositorySettings {
+	return &RepositorySettings{
+		FullName:            repo.GetFullName(),
+		Description:         repo.GetDescription(),
+		Homepage:            repo.GetHomepage(),
+		Visibility:          repo.GetVisibility(),
+		DefaultBranch:       repo.GetDefaultBranch(),
+		Archived:            repo.GetArchived(),
+		AllowMergeCommit:    repo.GetAllowMergeCommit(),
+		AllowSquashMerge:    repo.GetAllowSquashMerge(),
+		AllowRebaseMerge:    repo.GetAllowRebaseMerge(),
+		AllowAutoMerge:      repo.GetAllowAutoMerge(),
+		AllowUpdateBranch:   repo.GetAllowUpdateBranch(),
+		DeleteBranchOnMerge: repo.GetDeleteBranchOnMerge(),
+		HasIssues:           repo.GetHasIssues(),
+		HasWiki:             repo.GetHasWiki(),
+		HasProjects:         repo.GetHasProjects(),
+		HasDiscussions:      repo.GetHasDiscussions(),
+		Topics:              repo.Topics,
+	}
+}
+
+// CreateRepositoryFromTemplate creates a tool to create a repository from a template repository.
+func CreateRepositoryFromTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_repository_from_template",
+			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_FROM_TEMPLATE_DESCRIPTION", "Create a repository from a template repository, copying its files and directory structure into a new history. The template must be marked as a template repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_REPOSITORY_FROM_TEMPLATE_USER_TITLE", "Create repository from template"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("template_owner",
+				mcp.Required(),
+				mcp.Description("Owner of the template repository"),
+			),
+			mcp.WithString("template_repo",
+				mcp.Required(),
+				mcp.Description("Name of the template repository"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the new repository"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("User or organization to create the repository in, defaults to your account"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Description of the new repository"),
+			),
+			mcp.WithBoolean("private",
+				mcp.Description("Whether the new repository should be private"),
+			),
+			mcp.WithBoolean("include_all_branches",
+				mcp.Description("Copy every branch of the template instead of only the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			templateOwner, err := RequiredParam[string](request, "template_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			templateRepo, err := RequiredParam[string](request, "template_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			private, err := OptionalParam[bool](request, "private")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeAllBranches, err := OptionalParam[bool](request, "include_all_branches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repo, resp, err := client.Repositories.CreateFromTemplate(ctx, templateOwner, templateRepo, &github.TemplateRepoRequest{
+				Name:               github.Ptr(name),
+				Owner:              ToStringPtr(owner),
+				Description:        ToStringPtr(description),
+				Private:            github.Ptr(private),
+				IncludeAllBranches: github.Ptr(includeAllBranches),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to create repository from template %s/%s", templateOwner, templateRepo), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(repo), nil
+		}
+}
+
+// UpdateRepositorySettings creates a tool to change the settings of a repository.
+func UpdateRepositorySettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_UPDATE_REPOSITORY_SETTINGS_DESCRIPTION", "Change the settings of a repository: description, homepage, visibility, default branch, topics, the merge strategies allowed for pull requests, and whether issues, the wiki, projects and discussions are enabled. Only the given settings change. Returns the resulting settings. Requires admin access to the repository.")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        t("TOOL_UPDATE_REPOSITORY_SETTINGS_USER_TITLE", "Update repository settings"),
+			ReadOnlyHint: ToBoolPtr(false),
+		}),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description(DescriptionRepositoryOwner),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description(DescriptionRepositoryName),
+		),
+		mcp.WithString("description",
+			mcp.Description("Description of the repository, empty to clear it"),
+		),
+		mcp.WithString("homepage",
+			mcp.Description("URL of the homepage of the repository, empty to clear it"),
+		),
+		mcp.WithString("visibility",
+			mcp.Description("Visibility of the repository. Making a private repository public exposes its whole history"),
+			mcp.Enum("public", "private", "internal"),
+		),
+		mcp.WithString("default_branch",
+			mcp.Description("Existing branch to make the default branch"),
+		),
+		mcp.WithArray("topics",
+			mcp.Description("Topics of the repository, replacing the current ones. An empty list removes all topics"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+	}
+	for _, s := range repositorySwitches {
+		options = append(options, mcp.WithBoolean(s.name, mcp.Description(s.description)))
+	}
+
+	return mcp.NewTool("update_repository_settings", options...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			edit := &github.Repository{}
+			changed := false
+			for _, field := range []struct {
+				name string
+				dst  **string
+			}{
+				{"description", &edit.Description},
+				{"homepage", &edit.Homepage},
+				{"visibility", &edit.Visibility},
+				{"default_branch", &edit.DefaultBranch},
+			} {
+				value, ok, err := OptionalParamOK[string](request, field.name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if !ok {
+					continue
+				}
+				// Only description and homepage can be cleared
+				if value == "" && (field.name == "visibility" || field.name == "default_branch") {
+					continue
+				}
+				*field.dst = github.Ptr(value)
+				changed = true
+			}
+			for _, s := range repositorySwitches {
+				value, ok, err := OptionalParamOK[bool](request, s.name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					s.set(edit, value)
+					changed = true
+				}
+			}
+			_, topicsSet := request.GetArguments()["topics"]
+			topics, err := OptionalStringArrayParam(request, "topics")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for i, topic := range topics {
+				topics[i] = strings.ToLower(strings.TrimSpace(topic))
+			}
+			if !changed && !topicsSet {
+				return mcp.NewToolResultError("no settings to change were given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var updated *github.Repository
+			if changed {
+				var resp *github.Response
+				updated, resp, err = client.Repositories.Edit(ctx, owner, repo, edit)
+				if err != nil {
+					return newAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to update settings of %s/%s", owner, repo), resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+			if topicsSet {
+				replaced, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+				if err != nil {
+					return newAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to replace topics of %s/%s", owner, repo), resp, err), nil
+				}
+				_ = resp.Body.Close()
+				if updated == nil {
+					updated, resp, err = client.Repositories.Get(ctx, owner, repo)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get repository %s/%s", owner, repo), resp, err), nil
+					}
+					_ = resp.Body.Close()
+				}
+				updated.Topics = replaced
+			}
+
+			return MarshalledTextResult(newRepositorySettings(updated)), nil
+		}
+}
+
+// ArchiveRepository creates a tool to archive or unarchive a repository.
+func ArchiveRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("archive_repository",
+			mcp.WithDescription(t("TOOL_ARCHIVE_REPOSITORY_DESCRIPTION", "Archive a repository, making it read-only for everyone, or unarchive it. Issues, pull requests, branches and settings of an archived repository can't be changed until it is unarchived. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_ARCHIVE_REPOSITORY_USER_TITLE", "Archive repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithBoolean("unarchive",
+				mcp.Description("Unarchive the repository instead of archiving it"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			unarchive, err := OptionalParam[bool](request, "unarchive")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			action := "archive"
+			if unarchive {
+				action = "unarchive"
+			}
+			updated, resp, err := client.Repositories.Edit(ctx, owner, repo, &github.Repository{
+				Archived: github.Ptr(!unarchive),
+			})
+			if err != nil {
+				return newAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to %s %s/%s", action, owner, repo), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"repository": updated.GetFullName(),
+				"archived":   updated.GetArchived(),
+			}), nil
+		}
+}
+
+// TransferRepository creates a tool to transfer a repository to another user or organization.
+func TransferRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("transfer_repository",
+			mcp.WithDescription(t("TOOL_TRANSFER_REPOSITORY_DESCRIPTION", "Transfer a repository to another user or organization. A transfer to a user only completes once they accept it. The previous owner loses access unless granted it by the new owner. Requires admin access to the repository, and permission to create repositories in the target organization. Only use this when the user explicitly asks for it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_TRANSFER_REPOSITORY_USER_TITLE", "Transfer repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("new_owner",
+				mcp.Required(),
+				mcp.Description("User or organization to transfer the repository to"),
+			),
+			mcp.WithString("new_name",
+				mcp.Description("New name of the repository, defaults to its current name"),
+			),
+			mcp.WithArray("team_ids",
+				mcp.Description("IDs of teams of the target organization to give access to the repository"),
+				mcp.Items(map[string]any{"type": "number"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newOwner, err := RequiredParam[string](request, "new_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newName, err := OptionalParam[string](request, "new_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var teamIDs []int64
+			if raw, ok := request.GetArguments()["team_ids"]; ok {
+				ids, ok := raw.([]any)
+				if !ok {
+					return mcp.NewToolResultError("team_ids must be an array of numbers"), nil
+				}
+				for _, id := range ids {
+					n, ok := id.(float64)
+					if !ok || n <= 0 || n != float64(int64(n)) {
+						return mcp.NewToolResultError(fmt.Sprintf("team_ids must be positive integers, got %v", id)), nil
+					}
+					teamIDs = append(teamIDs, int64(n))
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// GitHub answers 202 Accepted as the transfer happens asynchronously
+			transferred, resp, err := client.Repositories.Transfer(ctx, owner, repo, github.TransferRequest{
+				NewOwner: newOwner,
+				NewName:  ToStringPtr(newName),
+				TeamID:   teamIDs,
+			})
+			if err != nil && !isAcceptedError(err) {
+				return newAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to transfer %s/%s to %s", owner, repo, newOwner), resp, err), nil
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			name := repo
+			if newName != "" {
+				name = newName
+			}
+			result := map[string]any{
+				"repository":     fmt.Sprintf("%s/%s", owner, repo),
+				"new_repository": fmt.Sprintf("%s/%s", newOwner, name),
+				"status":         "transfer started, a transfer to a user completes once they accept it",
+			}
+			if transferred != nil && transferred.GetHTMLURL() != "" {
+				result["html_url"] = transferred.GetHTMLURL()
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,351 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateRepositoryFromTemplate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRepositoryFromTemplate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_repository_from_template", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"template_owner", "template_repo", "name"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+					expectPath(t, "/repos/octo-org/service-template/generate").andThen(
+						expectRequestBody(t, map[string]any{
+							"name":                 "billing",
+							"owner":                "octo-org",
+							"private":              true,
+							"include_all_branches": false,
+						}).andThen(
+							mockResponse(t, http.StatusCreated, &github.Repository{FullName: github.Ptr("octo-org/billing")}),
+						),
+					),
+				),
+			),
+		},
+		{
+			name: "not a template",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "octo-org/service-template is not a template repository"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to create repository from template octo-org/service-template",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRepositoryFromTemplate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"template_owner": "octo-org",
+				"template_repo":  "service-template",
+				"name":           "billing",
+				"owner":          "octo-org",
+				"private":        true,
+			}))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.Repository
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "octo-org/billing", returned.GetFullName())
+		})
+	}
+}
+
+func Test_UpdateRepositorySettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRepositorySettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_repository_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "has_discussions")
+	assert.Contains(t, tool.InputSchema.Properties, "allow_squash_merge")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	repo := &github.Repository{
+		FullName:         github.Ptr("owner/repo"),
+		Visibility:       github.Ptr("private"),
+		DefaultBranch:    github.Ptr("main"),
+		AllowSquashMerge: github.Ptr(true),
+		AllowMergeCommit: github.Ptr(false),
+		HasWiki:          github.Ptr(false),
+		Topics:           []string{"old"},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedSettings func(t *testing.T, settings *RepositorySettings)
+	}{
+		{
+			name: "settings and topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"description":        "",
+						"allow_merge_commit": false,
+						"has_wiki":           false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, repo),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"names": []any{"billing", "go"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{"names": []string{"billing", "go"}}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"description":        "",
+				"allow_merge_commit": false,
+				"has_wiki":           false,
+				"topics":             []any{"Billing", " go"},
+			},
+			expectedSettings: func(t *testing.T, settings *RepositorySettings) {
+				assert.False(t, settings.AllowMergeCommit)
+				assert.True(t, settings.AllowSquashMerge)
+				assert.Equal(t, []string{"billing", "go"}, settings.Topics)
+			},
+		},
+		{
+			name: "topics only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					mockResponse(t, http.StatusOK, map[string]any{"names": []string{}}),
+				),
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, repo),
+			),
+			requestArgs: map[string]interface{}{
+				"topics": []any{},
+			},
+			expectedSettings: func(t *testing.T, settings *RepositorySettings) {
+				assert.Equal(t, "main", settings.DefaultBranch)
+				assert.Empty(t, settings.Topics)
+			},
+		},
+		{
+			name:           "nothing to change",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "no settings to change were given",
+		},
+		{
+			name: "not an admin",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"visibility": "public",
+			},
+			expectError:    true,
+			expectedErrMsg: "requires admin access to the repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRepositorySettings(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var settings RepositorySettings
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &settings))
+			tc.expectedSettings(t, &settings)
+		})
+	}
+}
+
+func Test_ArchiveRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ArchiveRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "archive_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	for _, unarchive := range []bool{false, true} {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PatchReposByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"archived": !unarchive,
+				}).andThen(
+					mockResponse(t, http.StatusOK, &github.Repository{FullName: github.Ptr("owner/repo"), Archived: github.Ptr(!unarchive)}),
+				),
+			),
+		))
+		_, handler := ArchiveRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":     "owner",
+			"repo":      "repo",
+			"unarchive": unarchive,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var returned map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		assert.Equal(t, !unarchive, returned["archived"])
+	}
+}
+
+func Test_TransferRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := TransferRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "transfer_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "new_owner"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "transfer accepted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposTransferByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"new_owner": "octo-org",
+						"new_name":  "renamed",
+						"team_ids":  []any{float64(12)},
+					}).andThen(
+						mockResponse(t, http.StatusAccepted, &github.Repository{FullName: github.Ptr("octo-org/renamed")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"new_name": "renamed",
+				"team_ids": []any{float64(12)},
+			},
+		},
+		{
+			name:         "invalid team id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"team_ids": []any{"platform"},
+			},
+			expectError:    true,
+			expectedErrMsg: "team_ids must be positive integers, got platform",
+		},
+		{
+			name: "name taken",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposTransferByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "octo-org already has a repository with this name"}`),
+				),
+			),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "failed to transfer owner/repo to octo-org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := TransferRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"new_owner": "octo-org",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "octo-org/renamed", returned["new_repository"])
+		})
+	}
+}
@@ -0,0 +1,348 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxOverviewReadmeChars caps the README excerpt of get_repository_overview
+	maxOverviewReadmeChars = 1500
+	// maxOverviewLanguages caps the languages get_repository_overview lists, the rest are summed as Other
+	maxOverviewLanguages = 5
+)
+
+// contributingPaths are the places GitHub looks for contribution guidelines, in its order of precedence.
+var contributingPaths = []string{".github/CONTRIBUTING.md", "CONTRIBUTING.md", "docs/CONTRIBUTING.md"}
+
+// LanguageShare is the share of a language in the code of a repository.
+type LanguageShare struct {
+	Name    string  `json:"name"`
+	Percent float64 `json:"percent"`
+}
+
+// ReadmeExcerpt is the beginning of the README of a repository.
+type ReadmeExcerpt struct {
+	Path      string `json:"path"`
+	Excerpt   string `json:"excerpt"`
+	Truncated bool   `json:"truncated"`
+}
+
+// OverviewRelease is the latest release of a repository.
+type OverviewRelease struct {
+	TagName     string     `json:"tag_name"`
+	Name        string     `json:"name,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	HTMLURL     string     `json:"html_url"`
+}
+
+// RepositoryOverview is a compact summary of a repository for someone encountering it for the first time.
+type RepositoryOverview struct {
+	FullName      string          `json:"full_name"`
+	Description   string          `json:"description,omitempty"`
+	HTMLURL       string          `json:"html_url"`
+	Homepage      string          `json:"homepage,omitempty"`
+	Topics        []string        `json:"topics"`
+	DefaultBranch string          `json:"default_branch"`
+	License       string          `json:"license,omitempty"`
+	Archived      bool            `json:"archived"`
+	Fork          bool            `json:"fork"`
+	Stars         int             `json:"stars"`
+	Forks         int             `json:"forks"`
+	Languages     []LanguageShare `json:"languages"`
+	Readme        *ReadmeExcerpt  `json:"readme,omitempty"`
+	// OpenIssues and OpenPullRequests are nil if they couldn't be counted
+	OpenIssues       *int             `json:"open_issues"`
+	OpenPullRequests *int             `json:"open_pull_requests"`
+	LatestRelease    *OverviewRelease `json:"latest_release,omitempty"`
+	// Contributing is the path of the contribution guidelines, empty if the repository has none
+	Contributing    string   `json:"contributing,omitempty"`
+	HasContributing bool     `json:"has_contributing"`
+	Warnings        []string `json:"warnings,omitempty"`
+}
+
+// languageShares turns the bytes of code per language into the largest shares, rounded to a tenth of a percent.
+func languageShares(languages map[string]int) []LanguageShare {
+	total := 0
+	names := make([]string, 0, len(languages))
+	for name, size := range languages {
+		total += size
+		names = append(names, name)
+	}
+	if total == 0 {
+		return []LanguageShare{}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if languages[names[i]] != languages[names[j]] {
+			return languages[names[i]] > languages[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	percent := func(size int) float64 {
+		return math.Round(float64(size)*1000/float64(total)) / 10
+	}
+	shares := make([]LanguageShare, 0, min(len(names), maxOverviewLanguages+1))
+	other := 0
+	for i, name := range names {
+		if i >= maxOverviewLanguages {
+			other += languages[name]
+			continue
+		}
+		shares = append(shares, LanguageShare{Name: name, Percent: percent(languages[name])})
+	}
+	if other > 0 {
+		shares = append(shares, LanguageShare{Name: "Other", Percent: percent(other)})
+	}
+	return shares
+}
+
+// readmeExcerpt returns the first maxChars characters of content.
+func readmeExcerpt(path, content string, maxChars int) *ReadmeExcerpt {
+	excerpt := &ReadmeExcerpt{Path: path, Excerpt: content}
+	if utf8.RuneCountInString(content) > maxChars {
+		excerpt.Excerpt = string([]rune(content)[:maxChars])
+		excerpt.Truncated = true
+	}
+	return excerpt
+}
+
+// contributingPath finds the contribution guidelines of a repository. The community profile knows where they
+// are but is only available for public repositories, so the usual paths are tried next.
+func contributingPath(ctx context.Context, client *github.Client, owner, repo string) (string, error) {
+	metrics, resp, err := client.Repositories.GetCommunityHealthMetrics(ctx, owner, repo)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err == nil {
+		if metrics.GetFiles().GetContributing() == nil {
+			return "", nil
+		}
+		// The community profile has the URL of the file, the longest of the usual paths it ends with is its path
+		path := "CONTRIBUTING.md"
+		for _, p := range contributingPaths {
+			if strings.HasSuffix(metrics.GetFiles().GetContributing().GetHTMLURL(), "/"+p) && len(p) > len(path) {
+				path = p
+			}
+		}
+		return path, nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return "", err
+	}
+
+	for _, path := range contributingPaths {
+		_, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, nil)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		switch {
+		case err == nil:
+			return path, nil
+		case resp != nil && resp.StatusCode == http.StatusNotFound:
+			continue
+		default:
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// GetRepositoryOverview creates a tool that summarizes a repository in a single call.
+func GetRepositoryOverview(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_overview",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_OVERVIEW_DESCRIPTION", "Get a compact overview of a repository in one call: description, topics, default branch, license, language breakdown, the beginning of the README, the number of open issues and pull requests, the latest release and whether it has contribution guidelines. Use it when first encountering a repository, instead of many separate calls.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_OVERVIEW_USER_TITLE", "Get repository overview"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			overview := &RepositoryOverview{Topics: []string{}, Languages: []LanguageShare{}}
+			var (
+				mu       sync.Mutex
+				wg       sync.WaitGroup
+				repoErr  error
+				repoResp *github.Response
+			)
+			// Only the repository itself is required, the other parts are left out with a warning
+			warn := func(format string, args ...any) {
+				mu.Lock()
+				defer mu.Unlock()
+				overview.Warnings = append(overview.Warnings, fmt.Sprintf(format, args...))
+			}
+			run := func(f func()) {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					f()
+				}()
+			}
+
+			run(func() {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					repoErr, repoResp = err, resp
+					return
+				}
+				_ = resp.Body.Close()
+
+				mu.Lock()
+				defer mu.Unlock()
+				overview.FullName = repository.GetFullName()
+				overview.Description = repository.GetDescription()
+				overview.HTMLURL = repository.GetHTMLURL()
+				overview.Homepage = repository.GetHomepage()
+				if repository.Topics != nil {
+					overview.Topics = repository.Topics
+				}
+				overview.DefaultBranch = repository.GetDefaultBranch()
+				if spdx := repository.GetLicense().GetSPDXID(); spdx != "NOASSERTION" {
+					overview.License = spdx
+				}
+				overview.Archived = repository.GetArchived()
+				overview.Fork = repository.GetFork()
+				overview.Stars = repository.GetStargazersCount()
+				overview.Forks = repository.GetForksCount()
+			})
+			run(func() {
+				languages, resp, err := client.Repositories.ListLanguages(ctx, owner, repo)
+				if err != nil {
+					warn("failed to get languages: %v", err)
+					return
+				}
+				_ = resp.Body.Close()
+
+				shares := languageShares(languages)
+				mu.Lock()
+				defer mu.Unlock()
+				overview.Languages = shares
+			})
+			run(func() {
+				readme, resp, err := client.Repositories.GetReadme(ctx, owner, repo, nil)
+				if resp != nil {
+					_ = resp.Body.Close()
+				}
+				if err != nil {
+					if resp == nil || resp.StatusCode != http.StatusNotFound {
+						warn("failed to get README: %v", err)
+					}
+					return
+				}
+				content, err := readme.GetContent()
+				if err != nil {
+					warn("failed to decode README: %v", err)
+					return
+				}
+
+				excerpt := readmeExcerpt(readme.GetPath(), content, maxOverviewReadmeChars)
+				mu.Lock()
+				defer mu.Unlock()
+				overview.Readme = excerpt
+			})
+			for _, kind := range []string{"issue", "pr"} {
+				run(func() {
+					// The open_issues_count of the repository counts pull requests as issues, search tells them apart
+					result, resp, err := client.Search.Issues(ctx, fmt.Sprintf("repo:%s/%s is:%s is:open", owner, repo, kind), &github.SearchOptions{
+						ListOptions: github.ListOptions{PerPage: 1},
+					})
+					if err != nil {
+						warn("failed to count open %ss: %v", kind, err)
+						return
+					}
+					_ = resp.Body.Close()
+
+					total := result.GetTotal()
+					mu.Lock()
+					defer mu.Unlock()
+					if kind == "issue" {
+						overview.OpenIssues = &total
+					} else {
+						overview.OpenPullRequests = &total
+					}
+				})
+			}
+			run(func() {
+				release, resp, err := client.Repositories.GetLatestRelease(ctx, owner, repo)
+				if resp != nil {
+					_ = resp.Body.Close()
+				}
+				if err != nil {
+					if resp == nil || resp.StatusCode != http.StatusNotFound {
+						warn("failed to get latest release: %v", err)
+					}
+					return
+				}
+
+				latest := &OverviewRelease{
+					TagName: release.GetTagName(),
+					Name:    release.GetName(),
+					HTMLURL: release.GetHTMLURL(),
+				}
+				if release.PublishedAt != nil {
+					latest.PublishedAt = &release.PublishedAt.Time
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				overview.LatestRelease = latest
+			})
+			run(func() {
+				path, err := contributingPath(ctx, client, owner, repo)
+				if err != nil {
+					warn("failed to check for contribution guidelines: %v", err)
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				overview.Contributing = path
+				overview.HasContributing = path != ""
+			})
+			wg.Wait()
+
+			if repoErr != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get repository %s/%s", owner, repo),
+					repoResp,
+					repoErr,
+				), nil
+			}
+			sort.Strings(overview.Warnings)
+
+			return MarshalledTextResult(overview), nil
+		}
+}
@@ -0,0 +1,211 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositoryOverview(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryOverview(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_overview", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+	// Responses of WithRequestMatch are used up, so each test needs its own
+	mockRepo := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(
+			mock.GetReposByOwnerByRepo,
+			&github.Repository{
+				FullName:        github.Ptr("owner/repo"),
+				Description:     github.Ptr("A test repository"),
+				HTMLURL:         github.Ptr("https://github.com/owner/repo"),
+				Topics:          []string{"go", "mcp"},
+				DefaultBranch:   github.Ptr("main"),
+				License:         &github.License{SPDXID: github.Ptr("MIT")},
+				StargazersCount: github.Ptr(42),
+				ForksCount:      github.Ptr(7),
+			},
+		)
+	}
+	mockSearch := mock.WithRequestMatchHandler(
+		mock.GetSearchIssues,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			total := 12
+			if strings.Contains(r.URL.Query().Get("q"), "is:pr") {
+				total = 3
+			}
+			assert.Contains(t, r.URL.Query().Get("q"), "repo:owner/repo")
+			assert.Contains(t, r.URL.Query().Get("q"), "is:open")
+			_, _ = w.Write(mock.MustMarshal(&github.IssuesSearchResult{Total: github.Ptr(total)}))
+		}),
+	)
+	publishedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	readme := strings.Repeat("é", maxOverviewReadmeChars+10)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		check          func(t *testing.T, overview RepositoryOverview)
+	}{
+		{
+			name: "every part available",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockRepo(),
+				mockSearch,
+				mock.WithRequestMatch(
+					mock.GetReposLanguagesByOwnerByRepo,
+					map[string]int{"Go": 700, "Shell": 150, "Makefile": 50, "Dockerfile": 40, "HTML": 30, "CSS": 20, "JavaScript": 10},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposReadmeByOwnerByRepo,
+					&github.RepositoryContent{
+						Path:     github.Ptr("README.md"),
+						Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(readme))),
+						Encoding: github.Ptr("base64"),
+					},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposReleasesLatestByOwnerByRepo,
+					&github.RepositoryRelease{
+						TagName:     github.Ptr("v1.2.0"),
+						Name:        github.Ptr("1.2.0"),
+						HTMLURL:     github.Ptr("https://github.com/owner/repo/releases/tag/v1.2.0"),
+						PublishedAt: &github.Timestamp{Time: publishedAt},
+					},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommunityProfileByOwnerByRepo,
+					&github.CommunityHealthMetrics{
+						Files: &github.CommunityHealthFiles{
+							Contributing: &github.Metric{HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/docs/CONTRIBUTING.md")},
+						},
+					},
+				),
+			),
+			check: func(t *testing.T, overview RepositoryOverview) {
+				assert.Equal(t, "owner/repo", overview.FullName)
+				assert.Equal(t, "A test repository", overview.Description)
+				assert.Equal(t, []string{"go", "mcp"}, overview.Topics)
+				assert.Equal(t, "main", overview.DefaultBranch)
+				assert.Equal(t, "MIT", overview.License)
+				assert.Equal(t, 42, overview.Stars)
+				assert.Equal(t, 7, overview.Forks)
+				assert.Equal(t, []LanguageShare{
+					{Name: "Go", Percent: 70},
+					{Name: "Shell", Percent: 15},
+					{Name: "Makefile", Percent: 5},
+					{Name: "Dockerfile", Percent: 4},
+					{Name: "HTML", Percent: 3},
+					{Name: "Other", Percent: 3},
+				}, overview.Languages)
+				require.NotNil(t, overview.Readme)
+				assert.Equal(t, "README.md", overview.Readme.Path)
+				assert.True(t, overview.Readme.Truncated)
+				assert.Equal(t, strings.Repeat("é", maxOverviewReadmeChars), overview.Readme.Excerpt)
+				require.NotNil(t, overview.OpenIssues)
+				assert.Equal(t, 12, *overview.OpenIssues)
+				require.NotNil(t, overview.OpenPullRequests)
+				assert.Equal(t, 3, *overview.OpenPullRequests)
+				require.NotNil(t, overview.LatestRelease)
+				assert.Equal(t, "v1.2.0", overview.LatestRelease.TagName)
+				assert.Equal(t, publishedAt, *overview.LatestRelease.PublishedAt)
+				assert.True(t, overview.HasContributing)
+				assert.Equal(t, "docs/CONTRIBUTING.md", overview.Contributing)
+				assert.Empty(t, overview.Warnings)
+			},
+		},
+		{
+			name: "missing and failing parts",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockRepo(),
+				mockSearch,
+				mock.WithRequestMatchHandler(
+					mock.GetReposLanguagesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(mock.GetReposReadmeByOwnerByRepo, notFound),
+				mock.WithRequestMatchHandler(mock.GetReposReleasesLatestByOwnerByRepo, notFound),
+				// Private repositories have no community profile, so the usual paths are looked up
+				mock.WithRequestMatchHandler(mock.GetReposCommunityProfileByOwnerByRepo, notFound),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path == "/repos/owner/repo/contents/CONTRIBUTING.md" {
+							_, _ = w.Write(mock.MustMarshal(&github.RepositoryContent{Path: github.Ptr("CONTRIBUTING.md"), Type: github.Ptr("file")}))
+							return
+						}
+						notFound(w, r)
+					}),
+				),
+			),
+			check: func(t *testing.T, overview RepositoryOverview) {
+				assert.Equal(t, "owner/repo", overview.FullName)
+				assert.Empty(t, overview.Languages)
+				assert.Nil(t, overview.Readme)
+				assert.Nil(t, overview.LatestRelease)
+				assert.True(t, overview.HasContributing)
+				assert.Equal(t, "CONTRIBUTING.md", overview.Contributing)
+				require.Len(t, overview.Warnings, 1)
+				assert.Contains(t, overview.Warnings[0], "failed to get languages")
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposByOwnerByRepo, notFound),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get repository owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryOverview(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var overview RepositoryOverview
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &overview))
+			tc.check(t, overview)
+		})
+	}
+}
@@ -3,11 +3,13 @@ package github
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"mime"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -19,11 +21,25 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
-// GetRepositoryResourceContent defines the resource template and handler for getting repository content.
+// RepositoryDirectoryEntry is an entry of the listing returned when a repository resource is a directory.
+// URI is the resource of the entry, at the same ref as the directory.
+type RepositoryDirectoryEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+
+	// Type is file, dir, symlink or submodule
+	Type string `json:"type"`
+	Size int    `json:"size,omitempty"`
+	URI  string `json:"uri"`
+}
+
+// GetRepositoryResourceContent defines the resource template and handler for getting repository content,
+// at the default branch or at the ref given in the query.
 func GetRepositoryResourceContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
 	return mcp.NewResourceTemplate(
-			"repo://{owner}/{repo}/contents{/path*}", // Resource template
+			"repo://{owner}/{repo}/contents{/path*}{?ref}", // Resource template
 			t("RESOURCE_REPOSITORY_CONTENT_DESCRIPTION", "Repository Content"),
+			mcp.WithTemplateDescription(t("RESOURCE_REPOSITORY_CONTENT_TEMPLATE_DESCRIPTION", "A file of a repository, or the listing of a directory as JSON with the URI of each entry. The ref query parameter selects a branch, tag or commit, such as ?ref=main or ?ref=refs/tags/v1.0.0, and defaults to the default branch")),
 		),
 		RepositoryResourceContentsHandler(getClient, getRawClient)
 }
@@ -108,6 +124,12 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 			opts.Ref = "refs/tags/" + tag[0]
 			rawOpts.Ref = "refs/tags/" + tag[0]
 		}
+
+		ref, ok := request.Params.Arguments["ref"].([]string)
+		if ok && len(ref) > 0 && ref[0] != "" {
+			opts.Ref = ref[0]
+			rawOpts.Ref = ref[0]
+		}
 		prNumber, ok := request.Params.Arguments["prNumber"].([]string)
 		if ok && len(prNumber) > 0 {
 			// fetch the PR from the API to get the latest commit and use SHA
@@ -129,7 +151,7 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 		}
 		//  if it's a directory
 		if path == "" || strings.HasSuffix(path, "/") {
-			return nil, fmt.Errorf("directories are not supported: %s", path)
+			return repositoryDirectoryContents(ctx, getClient, owner, repo, strings.TrimSuffix(path, "/"), opts, request.Params.URI)
 		}
 		rawClient, err := getRawClient(ctx)
 
@@ -185,8 +207,58 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 			}
 			return nil, fmt.Errorf("failed to fetch raw content: %s", string(body))
 		default:
-			// This should be unreachable because GetContents should return an error if neither file nor directory content is found.
-			return nil, errors.New("404 Not Found")
+			// The path may be a directory, which raw content doesn't serve
+			return repositoryDirectoryContents(ctx, getClient, owner, repo, path, opts, request.Params.URI)
+		}
+	}
+}
+
+// repositoryDirectoryContents returns the listing of a directory as a JSON resource. The URIs of the
+// entries are built from the URI of the directory, keeping its query so that they stay at the same ref.
+func repositoryDirectoryContents(ctx context.Context, getClient GetClientFn, owner, repo, path string, opts *github.RepositoryContentGetOptions, uri string) ([]mcp.ResourceContents, error) {
+	githubClient, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+	file, dir, resp, err := githubClient.Repositories.GetContents(ctx, owner, repo, path, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contents: %w", err)
+	}
+	_ = resp.Body.Close()
+	if file != nil {
+		// Only reached when raw content missed a file, which GetContents then found
+		return nil, errors.New("404 Not Found")
+	}
+
+	base, query, _ := strings.Cut(uri, "?")
+	base = strings.TrimSuffix(base, "/")
+	if query != "" {
+		query = "?" + query
+	}
+	entries := make([]RepositoryDirectoryEntry, 0, len(dir))
+	for _, entry := range dir {
+		kind := entry.GetType()
+		if kind != "dir" && kind != "symlink" && kind != "submodule" {
+			kind = "file"
 		}
+		entries = append(entries, RepositoryDirectoryEntry{
+			Name: entry.GetName(),
+			Path: entry.GetPath(),
+			Type: kind,
+			Size: entry.GetSize(),
+			URI:  base + "/" + url.PathEscape(entry.GetName()) + query,
+		})
+	}
+
+	listing, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal directory listing: %w", err)
 	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(listing),
+		},
+	}, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,6 +19,7 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 	base, _ := url.Parse("https://raw.example.com/")
 	tests := []struct {
 		name           string
+		uri            string
 		mockedClient   *http.Client
 		requestArgs    map[string]any
 		expectError    string
@@ -207,6 +209,64 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 				URI:      "",
 			}},
 		},
+		{
+			name: "successful text content fetch (ref query)",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/owner/repo/refs/heads/dev/README.md", r.URL.Path)
+						w.Header().Set("Content-Type", "text/markdown")
+						_, err := w.Write([]byte("# Test Repository\n\nThis is a test repository."))
+						require.NoError(t, err)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"path":  []string{"README.md"},
+				"ref":   []string{"refs/heads/dev"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     "# Test Repository\n\nThis is a test repository.",
+				MIMEType: "text/markdown",
+				URI:      "",
+			}},
+		},
+		{
+			name: "directory listing",
+			uri:  "repo://owner/repo/contents/docs?ref=main",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "main", r.URL.Query().Get("ref"))
+						_, _ = w.Write(mock.MustMarshal([]*github.RepositoryContent{
+							{Name: github.Ptr("guides"), Path: github.Ptr("docs/guides"), Type: github.Ptr("dir")},
+							{Name: github.Ptr("index.md"), Path: github.Ptr("docs/index.md"), Type: github.Ptr("file"), Size: github.Ptr(42)},
+						}))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"path":  []string{"docs"},
+				"ref":   []string{"main"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     `[{"name":"guides","path":"docs/guides","type":"dir","uri":"repo://owner/repo/contents/docs/guides?ref=main"},{"name":"index.md","path":"docs/index.md","type":"file","size":42,"uri":"repo://owner/repo/contents/docs/index.md?ref=main"}]`,
+				MIMEType: "application/json",
+				URI:      "repo://owner/repo/contents/docs?ref=main",
+			}},
+		},
 		{
 			name: "content fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -239,6 +299,7 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 					URI       string         `json:"uri"`
 					Arguments map[string]any `json:"arguments,omitempty"`
 				}{
+					URI:       tc.uri,
 					Arguments: tc.requestArgs,
 				},
 			}
@@ -259,7 +320,7 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 func Test_GetRepositoryResourceContent(t *testing.T) {
 	mockRawClient := raw.NewClient(github.NewClient(nil), &url.URL{})
 	tmpl, _ := GetRepositoryResourceContent(nil, stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
-	require.Equal(t, "repo://{owner}/{repo}/contents{/path*}", tmpl.URITemplate.Raw())
+	require.Equal(t, "repo://{owner}/{repo}/contents{/path*}{?ref}", tmpl.URITemplate.Raw())
 }
 
 func Test_GetRepositoryResourceBranchContent(t *testing.T) {
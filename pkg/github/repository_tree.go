@@ -0,0 +1,255 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultRepoTreeEntries and maxRepoTreeEntries bound how many entries get_repo_tree returns.
+	defaultRepoTreeEntries = 1000
+	maxRepoTreeEntries     = 10000
+)
+
+// RepoTreeEntry is a file or directory of the output of get_repo_tree.
+type RepoTreeEntry struct {
+	Path string `json:"path"`
+
+	// Type is file, dir or submodule
+	Type string `json:"type"`
+	Size int    `json:"size,omitempty"`
+}
+
+// RepoTree is the output type of get_repo_tree.
+type RepoTree struct {
+	SHA     string          `json:"sha"`
+	Path    string          `json:"path,omitempty"`
+	Entries []RepoTreeEntry `json:"entries"`
+
+	// Truncated is set when GitHub cut the listing short because the repository is too large, or when
+	// more entries matched than max_entries; Omitted counts the latter
+	Truncated bool `json:"truncated,omitempty"`
+	Omitted   int  `json:"omitted,omitempty"`
+}
+
+// matchGlob reports whether a slash separated path matches a glob pattern. Segments are matched with
+// path.Match, and a ** segment matches any number of segments. Patterns without a slash match the last
+// segment of the path, so *.go matches Go files at any depth.
+func matchGlob(pattern, name string) bool {
+	pattern = strings.Trim(pattern, "/")
+	if !strings.Contains(pattern, "/") && pattern != "**" {
+		matched, _ := path.Match(pattern, path.Base(name))
+		return matched
+	}
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchGlobSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// GetRepoTree creates a tool to list the files and directories of a repository at a ref in one call.
+func GetRepoTree(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_tree",
+			mcp.WithDescription(t("TOOL_GET_REPO_TREE_DESCRIPTION", "Get the structure of a GitHub repository in one call: its files, with their sizes, and directories at a ref, optionally below a path, filtered by glob patterns and cut off at a depth. Use it to find your way around a repository instead of listing directories one by one with get_file_contents.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPO_TREE_USER_TITLE", "Get repository tree"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or `refs/pull/{pr_number}/head`"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Only list the entries below this directory, such as services/api"),
+			),
+			mcp.WithArray("include",
+				mcp.Description("Only list the entries matching one of these glob patterns, relative to the repository root. * matches within a path segment and ** any number of segments; patterns without a slash match file names at any depth. Examples: '*.go', 'docs/**/*.md'"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("exclude",
+				mcp.Description("Leave out the entries matching one of these glob patterns, and the contents of the directories matching them. Examples: 'vendor', 'node_modules', '*_test.go'"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithNumber("max_depth",
+				mcp.Description("Only list entries at most this many levels below path, 1 for its direct children. Unlimited when not set"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("max_entries",
+				mcp.Description(fmt.Sprintf("Maximum number of entries to return (max %d)", maxRepoTreeEntries)),
+				mcp.Min(1),
+				mcp.Max(maxRepoTreeEntries),
+				mcp.DefaultNumber(defaultRepoTreeEntries),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			basePath, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			include, err := OptionalStringArrayParam(request, "include")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			exclude, err := OptionalStringArrayParam(request, "exclude")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxDepth, err := OptionalIntParam(request, "max_depth")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxEntries, err := OptionalIntParamWithDefault(request, "max_entries", defaultRepoTreeEntries)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxEntries < 1 || maxEntries > maxRepoTreeEntries {
+				return mcp.NewToolResultError(fmt.Sprintf("max_entries must be between 1 and %d", maxRepoTreeEntries)), nil
+			}
+			if maxDepth < 0 {
+				return mcp.NewToolResultError("max_depth must be at least 1"), nil
+			}
+			for _, pattern := range append(include, exclude...) {
+				if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid glob pattern %q: %s", pattern, err)), nil
+				}
+			}
+			basePath = strings.Trim(basePath, "/")
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, rawOpts.SHA, true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository tree",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			matchesAny := func(patterns []string, name string) bool {
+				for _, pattern := range patterns {
+					if matchGlob(pattern, name) {
+						return true
+					}
+				}
+				return false
+			}
+
+			result := RepoTree{
+				SHA:       rawOpts.SHA,
+				Path:      basePath,
+				Entries:   []RepoTreeEntry{},
+				Truncated: tree.GetTruncated(),
+			}
+			// Entries come in tree order, so the directories leading to an entry are seen before it
+			excludedDirs := map[string]bool{}
+			for _, entry := range tree.Entries {
+				entryPath := entry.GetPath()
+				relative := entryPath
+				if basePath != "" {
+					if !strings.HasPrefix(entryPath, basePath+"/") {
+						continue
+					}
+					relative = strings.TrimPrefix(entryPath, basePath+"/")
+				}
+				if maxDepth > 0 && strings.Count(relative, "/") >= maxDepth {
+					continue
+				}
+				if parent := path.Dir(entryPath); excludedDirs[parent] {
+					if entry.GetType() == "tree" {
+						excludedDirs[entryPath] = true
+					}
+					continue
+				}
+				if matchesAny(exclude, entryPath) {
+					if entry.GetType() == "tree" {
+						excludedDirs[entryPath] = true
+					}
+					continue
+				}
+				if len(include) > 0 && !matchesAny(include, entryPath) {
+					continue
+				}
+
+				if len(result.Entries) == maxEntries {
+					result.Omitted++
+					continue
+				}
+				treeEntry := RepoTreeEntry{Path: entryPath, Size: entry.GetSize()}
+				switch entry.GetType() {
+				case "tree":
+					treeEntry.Type = "dir"
+				case "commit":
+					treeEntry.Type = "submodule"
+				default:
+					treeEntry.Type = "file"
+				}
+				result.Entries = append(result.Entries, treeEntry)
+			}
+			if result.Omitted > 0 {
+				result.Truncated = true
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,174 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_matchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		matched bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "pkg/github/tools.go", true},
+		{"*.go", "pkg/github/tools.go.orig", false},
+		{"pkg/*.go", "pkg/main.go", true},
+		{"pkg/*.go", "pkg/github/tools.go", false},
+		{"docs/**/*.md", "docs/guide.md", true},
+		{"docs/**/*.md", "docs/installation-guides/vscode.md", true},
+		{"docs/**/*.md", "README.md", false},
+		{"docs/**", "docs/a/b", true},
+		{"**", "anything/at/all", true},
+		{"vendor", "vendor", true},
+		{"vendor", "pkg/vendor", true},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.matched, matchGlob(tc.pattern, tc.name), "%s against %s", tc.name, tc.pattern)
+	}
+}
+
+func Test_GetRepoTree(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepoTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repo_tree", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "include")
+	assert.Contains(t, tool.InputSchema.Properties, "exclude")
+	assert.Contains(t, tool.InputSchema.Properties, "max_depth")
+	assert.Contains(t, tool.InputSchema.Properties, "max_entries")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// Entries need a SHA to be sent with their size
+	mockTree := &github.Tree{
+		SHA: github.Ptr("abc123"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("README.md"), SHA: github.Ptr("s1"), Type: github.Ptr("blob"), Size: github.Ptr(100)},
+			{Path: github.Ptr("cmd"), SHA: github.Ptr("s2"), Type: github.Ptr("tree")},
+			{Path: github.Ptr("cmd/main.go"), SHA: github.Ptr("s3"), Type: github.Ptr("blob"), Size: github.Ptr(200)},
+			{Path: github.Ptr("pkg"), SHA: github.Ptr("s4"), Type: github.Ptr("tree")},
+			{Path: github.Ptr("pkg/tools.go"), SHA: github.Ptr("s5"), Type: github.Ptr("blob"), Size: github.Ptr(300)},
+			{Path: github.Ptr("pkg/tools_test.go"), SHA: github.Ptr("s6"), Type: github.Ptr("blob"), Size: github.Ptr(400)},
+			{Path: github.Ptr("pkg/vendor"), SHA: github.Ptr("s7"), Type: github.Ptr("tree")},
+			{Path: github.Ptr("pkg/vendor/lib.go"), SHA: github.Ptr("s8"), Type: github.Ptr("blob"), Size: github.Ptr(500)},
+			{Path: github.Ptr("third_party"), SHA: github.Ptr("s9"), Type: github.Ptr("commit")},
+		},
+	}
+	mockedClient := func() *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposGitRefByOwnerByRepoByRef,
+				&github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr("abc123")}},
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+				expectQueryParams(t, map[string]string{"recursive": "1"}).andThen(
+					mockResponse(t, http.StatusOK, mockTree),
+				),
+			),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult string
+	}{
+		{
+			name:        "lists the whole tree",
+			requestArgs: map[string]interface{}{},
+			expectedResult: `{"sha": "abc123", "entries": [
+				{"path": "README.md", "type": "file", "size": 100},
+				{"path": "cmd", "type": "dir"},
+				{"path": "cmd/main.go", "type": "file", "size": 200},
+				{"path": "pkg", "type": "dir"},
+				{"path": "pkg/tools.go", "type": "file", "size": 300},
+				{"path": "pkg/tools_test.go", "type": "file", "size": 400},
+				{"path": "pkg/vendor", "type": "dir"},
+				{"path": "pkg/vendor/lib.go", "type": "file", "size": 500},
+				{"path": "third_party", "type": "submodule"}
+			]}`,
+		},
+		{
+			name: "filters by glob patterns",
+			requestArgs: map[string]interface{}{
+				"include": []interface{}{"*.go"},
+				"exclude": []interface{}{"vendor", "*_test.go"},
+			},
+			expectedResult: `{"sha": "abc123", "entries": [
+				{"path": "cmd/main.go", "type": "file", "size": 200},
+				{"path": "pkg/tools.go", "type": "file", "size": 300}
+			]}`,
+		},
+		{
+			name: "lists a directory to a depth",
+			requestArgs: map[string]interface{}{
+				"path":      "pkg/",
+				"max_depth": float64(1),
+			},
+			expectedResult: `{"sha": "abc123", "path": "pkg", "entries": [
+				{"path": "pkg/tools.go", "type": "file", "size": 300},
+				{"path": "pkg/tools_test.go", "type": "file", "size": 400},
+				{"path": "pkg/vendor", "type": "dir"}
+			]}`,
+		},
+		{
+			name: "caps the entries",
+			requestArgs: map[string]interface{}{
+				"max_entries": float64(2),
+			},
+			expectedResult: `{"sha": "abc123", "entries": [
+				{"path": "README.md", "type": "file", "size": 100},
+				{"path": "cmd", "type": "dir"}
+			], "truncated": true, "omitted": 7}`,
+		},
+		{
+			name: "refuses invalid patterns",
+			requestArgs: map[string]interface{}{
+				"include": []interface{}{"[a-"},
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid glob pattern "[a-"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mockedClient())
+			_, handler := GetRepoTree(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/main",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, tc.expectedResult, getTextResult(t, result).Text)
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxReviewerSuggestionFiles bounds how many files of a pull request are matched against CODEOWNERS.
+	maxReviewerSuggestionFiles = 300
+	// maxReviewerHistoryFiles bounds how many files have their commit history read, the most changed first.
+	maxReviewerHistoryFiles = 20
+	// codeownersScore is what owning a file through CODEOWNERS counts for, against 1 for a recent commit.
+	codeownersScore = 3
+
+	defaultReviewerSuggestions = 5
+	maxReviewerSuggestions     = 20
+	defaultReviewerHistoryDays = 365
+)
+
+// ReviewerCandidate is a suggested reviewer or assignee of the output of suggest_reviewers.
+type ReviewerCandidate struct {
+	// Login is the username, the org/team or the email address as written in CODEOWNERS, without the @
+	Login string `json:"login"`
+
+	// Type is user, team or email
+	Type       string   `json:"type"`
+	Score      int      `json:"score"`
+	OwnedFiles []string `json:"owned_files,omitempty"`
+
+	// Commits counts the distinct recent commits to the files, LastCommitAt is the date of the latest
+	Commits      int      `json:"commits,omitempty"`
+	LastCommitAt string   `json:"last_commit_at,omitempty"`
+	Evidence     []string `json:"evidence"`
+}
+
+// ReviewerSuggestions is the output type of suggest_reviewers.
+type ReviewerSuggestions struct {
+	// CodeownersFile is the path of the CODEOWNERS file that was used, empty when there is none
+	CodeownersFile string              `json:"codeowners_file,omitempty"`
+	Files          int                 `json:"files"`
+	HistoryFiles   int                 `json:"history_files"`
+	Candidates     []ReviewerCandidate `json:"candidates"`
+}
+
+// reviewerFile is a file to suggest reviewers for, with the number of changed lines used to pick the
+// files whose history is read.
+type reviewerFile struct {
+	path    string
+	changes int
+}
+
+// getCodeowners returns the path and rules of the CODEOWNERS file GitHub uses at a ref, or an empty path
+// when the repository has none.
+func getCodeowners(ctx context.Context, client *github.Client, owner, repo, ref string) (string, []codeownersRule, *github.Response, error) {
+	path, content, resp, err := getCodeownersContent(ctx, client, owner, repo, ref)
+	if err != nil || path == "" {
+		return "", nil, resp, err
+	}
+	return path, parseCodeowners(content), nil, nil
+}
+
+// SuggestReviewers creates a tool to suggest reviewers or assignees for files or a pull request, from
+// CODEOWNERS and recent commit history.
+func SuggestReviewers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("suggest_reviewers",
+			mcp.WithDescription(t("TOOL_SUGGEST_REVIEWERS_DESCRIPTION", fmt.Sprintf("Suggest likely reviewers of a pull request, or assignees for work on some files, ranked with the evidence for each. Candidates are the CODEOWNERS owners of the files and the authors of recent commits to them; owning a file counts %d points and each recent commit to one of the files 1 point. The author of the pull request and bots are left out.", codeownersScore))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SUGGEST_REVIEWERS_USER_TITLE", "Suggest reviewers"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Description("Pull request to suggest reviewers for, its changed files are used. Give either pullNumber or paths"),
+			),
+			mcp.WithArray("paths",
+				mcp.Description("Paths of files to suggest reviewers or assignees for. Give either pullNumber or paths"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit to read CODEOWNERS and history from when paths are given, defaults to the default branch. For pull requests the base branch is used"),
+			),
+			mcp.WithNumber("since_days",
+				mcp.Description("How many days of commit history to consider"),
+				mcp.Min(1),
+				mcp.DefaultNumber(defaultReviewerHistoryDays),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description(fmt.Sprintf("Maximum number of candidates to return (max %d)", maxReviewerSuggestions)),
+				mcp.Min(1),
+				mcp.Max(maxReviewerSuggestions),
+				mcp.DefaultNumber(defaultReviewerSuggestions),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := OptionalIntParam(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paths, err := OptionalStringArrayParam(request, "paths")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sinceDays, err := OptionalIntParamWithDefault(request, "since_days", defaultReviewerHistoryDays)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", defaultReviewerSuggestions)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (pullNumber == 0) == (len(paths) == 0) {
+				return mcp.NewToolResultError("give either pullNumber or paths"), nil
+			}
+			if sinceDays < 1 {
+				return mcp.NewToolResultError("since_days must be at least 1"), nil
+			}
+			if limit < 1 || limit > maxReviewerSuggestions {
+				return mcp.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", maxReviewerSuggestions)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var files []reviewerFile
+			excluded := map[string]bool{}
+			if pullNumber != 0 {
+				pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				excluded[strings.ToLower(pr.GetUser().GetLogin())] = true
+				ref = pr.GetBase().GetRef()
+
+				opts := &github.ListOptions{PerPage: 100}
+				for len(files) < maxReviewerSuggestionFiles {
+					prFiles, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request files", resp, err), nil
+					}
+					_ = resp.Body.Close()
+					for _, f := range prFiles {
+						files = append(files, reviewerFile{path: f.GetFilename(), changes: f.GetChanges()})
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			} else {
+				for _, p := range paths {
+					files = append(files, reviewerFile{path: strings.Trim(p, "/")})
+				}
+			}
+
+			codeownersFile, rules, resp, err := getCodeowners(ctx, client, owner, repo, ref)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get CODEOWNERS", resp, err), nil
+			}
+
+			candidates := map[string]*ReviewerCandidate{}
+			var order []string
+			candidate := func(login, kind string) *ReviewerCandidate {
+				key := strings.ToLower(login)
+				if c, ok := candidates[key]; ok {
+					return c
+				}
+				c := &ReviewerCandidate{Login: login, Type: kind, Evidence: []string{}}
+				candidates[key] = c
+				order = append(order, key)
+				return c
+			}
+
+			// CODEOWNERS evidence is reported per rule, as one rule often covers many of the files
+			ruleFiles := map[*codeownersRule][]string{}
+			var matchedRules []*codeownersRule
+			for _, f := range files {
+				rule := codeownersRuleFor(rules, f.path)
+				if rule == nil || len(rule.owners) == 0 {
+					continue
+				}
+				if _, ok := ruleFiles[rule]; !ok {
+					matchedRules = append(matchedRules, rule)
+				}
+				ruleFiles[rule] = append(ruleFiles[rule], f.path)
+			}
+			for _, rule := range matchedRules {
+				for _, o := range rule.owners {
+					kind := "email"
+					login := o
+					switch {
+					case codeownersTeamPattern.MatchString(o):
+						kind, login = "team", o[1:]
+					case codeownersUserPattern.MatchString(o):
+						kind, login = "user", o[1:]
+					}
+					if excluded[strings.ToLower(login)] {
+						continue
+					}
+					c := candidate(login, kind)
+					c.OwnedFiles = append(c.OwnedFiles, ruleFiles[rule]...)
+					c.Score += codeownersScore * len(ruleFiles[rule])
+					c.Evidence = append(c.Evidence, fmt.Sprintf("owns %d of the files through %s line %d (%s)", len(ruleFiles[rule]), codeownersFile, rule.line, rule.pattern))
+				}
+			}
+
+			historyFiles := append([]reviewerFile(nil), files...)
+			sort.SliceStable(historyFiles, func(i, j int) bool { return historyFiles[i].changes > historyFiles[j].changes })
+			if len(historyFiles) > maxReviewerHistoryFiles {
+				historyFiles = historyFiles[:maxReviewerHistoryFiles]
+			}
+			since := time.Now().AddDate(0, 0, -sinceDays)
+			commits := map[string]map[string]bool{}
+			for _, f := range historyFiles {
+				history, resp, err := client.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{
+					SHA:         ref,
+					Path:        f.path,
+					Since:       since,
+					ListOptions: github.ListOptions{PerPage: 30},
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list commits of %s", f.path), resp, err), nil
+				}
+				_ = resp.Body.Close()
+
+				perAuthor := map[string]int{}
+				var authors []string
+				for _, commit := range history {
+					login := commit.GetAuthor().GetLogin()
+					key := strings.ToLower(login)
+					if login == "" || excluded[key] || strings.HasSuffix(key, "[bot]") || commit.GetAuthor().GetType() == "Bot" {
+						continue
+					}
+					c := candidate(login, "user")
+					c.Score++
+					if commits[key] == nil {
+						commits[key] = map[string]bool{}
+					}
+					commits[key][commit.GetSHA()] = true
+					if date := commit.GetCommit().GetAuthor().GetDate().Format("2006-01-02"); date > c.LastCommitAt {
+						c.LastCommitAt = date
+					}
+					if perAuthor[key] == 0 {
+						authors = append(authors, key)
+					}
+					perAuthor[key]++
+				}
+				for _, key := range authors {
+					candidates[key].Evidence = append(candidates[key].Evidence, fmt.Sprintf("authored %d of the recent commits to %s", perAuthor[key], f.path))
+				}
+			}
+
+			result := ReviewerSuggestions{
+				CodeownersFile: codeownersFile,
+				Files:          len(files),
+				HistoryFiles:   len(historyFiles),
+				Candidates:     []ReviewerCandidate{},
+			}
+			for _, key := range order {
+				c := candidates[key]
+				c.Commits = len(commits[key])
+				result.Candidates = append(result.Candidates, *c)
+			}
+			sort.SliceStable(result.Candidates, func(i, j int) bool {
+				a, b := result.Candidates[i], result.Candidates[j]
+				if a.Score != b.Score {
+					return a.Score > b.Score
+				}
+				return a.LastCommitAt > b.LastCommitAt
+			})
+			if len(result.Candidates) > limit {
+				result.Candidates = result.Candidates[:limit]
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
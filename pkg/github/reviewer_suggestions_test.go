@@ -0,0 +1,188 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SuggestReviewers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SuggestReviewers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "suggest_reviewers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "paths")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	codeowners := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Path:     github.Ptr("CODEOWNERS"),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("*.go @octocat\n/docs/ @org/docs @author\n"))),
+	}
+	codeownersHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/contents/CODEOWNERS" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		assert.Equal(t, "main", r.URL.Query().Get("ref"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(mock.MustMarshal(codeowners))
+	})
+	commit := func(sha, login string, date time.Time) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:    github.Ptr(sha),
+			Author: &github.User{Login: github.Ptr(login)},
+			Commit: &github.Commit{Author: &github.CommitAuthor{Date: &github.Timestamp{Time: date}}},
+		}
+	}
+	history := map[string][]*github.RepositoryCommit{
+		"docs/index.md": {
+			commit("a1", "hubot", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)),
+			commit("a2", "dependabot[bot]", time.Date(2026, 9, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		"main.go": {
+			commit("b1", "hubot", time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)),
+			commit("b2", "author", time.Date(2026, 8, 2, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+	historyHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "main", r.URL.Query().Get("sha"))
+		assert.NotEmpty(t, r.URL.Query().Get("since"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(mock.MustMarshal(history[r.URL.Query().Get("path")]))
+	})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectError        bool
+		expectedErrMsg     string
+		expectedCandidates []ReviewerCandidate
+	}{
+		{
+			name: "pull request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					&github.PullRequest{
+						User: &github.User{Login: github.Ptr("author")},
+						Base: &github.PullRequestBranch{Ref: github.Ptr("main")},
+					},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					[]*github.CommitFile{
+						{Filename: github.Ptr("main.go"), Changes: github.Ptr(10)},
+						{Filename: github.Ptr("docs/index.md"), Changes: github.Ptr(5)},
+					},
+				),
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, codeownersHandler),
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepo, historyHandler),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedCandidates: []ReviewerCandidate{
+				{
+					Login:      "octocat",
+					Type:       "user",
+					Score:      3,
+					OwnedFiles: []string{"main.go"},
+					Evidence:   []string{"owns 1 of the files through CODEOWNERS line 1 (*.go)"},
+				},
+				{
+					Login:      "org/docs",
+					Type:       "team",
+					Score:      3,
+					OwnedFiles: []string{"docs/index.md"},
+					Evidence:   []string{"owns 1 of the files through CODEOWNERS line 2 (/docs/)"},
+				},
+				{
+					Login:        "hubot",
+					Type:         "user",
+					Score:        2,
+					Commits:      2,
+					LastCommitAt: "2026-09-01",
+					Evidence:     []string{"authored 1 of the recent commits to main.go", "authored 1 of the recent commits to docs/index.md"},
+				},
+			},
+		},
+		{
+			name: "paths with a limit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, codeownersHandler),
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepo, historyHandler),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"paths": []any{"/main.go"},
+				"ref":   "main",
+				"limit": float64(1),
+			},
+			expectedCandidates: []ReviewerCandidate{
+				{
+					Login:      "octocat",
+					Type:       "user",
+					Score:      3,
+					OwnedFiles: []string{"main.go"},
+					Evidence:   []string{"owns 1 of the files through CODEOWNERS line 1 (*.go)"},
+				},
+			},
+		},
+		{
+			name:         "both pull request and paths",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"paths":      []any{"main.go"},
+			},
+			expectError:    true,
+			expectedErrMsg: "give either pullNumber or paths",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SuggestReviewers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var suggestions ReviewerSuggestions
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &suggestions))
+			assert.Equal(t, "CODEOWNERS", suggestions.CodeownersFile)
+			assert.Equal(t, tc.expectedCandidates, suggestions.Candidates)
+		})
+	}
+}
@@ -0,0 +1,505 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RuleSuite is a single evaluation of the rulesets that apply to a push.
+// go-github does not model the rule suites API yet, so we define our own type.
+type RuleSuite struct {
+	ID               int64             `json:"id"`
+	ActorID          int64             `json:"actor_id,omitempty"`
+	ActorName        string            `json:"actor_name,omitempty"`
+	BeforeSHA        string            `json:"before_sha,omitempty"`
+	AfterSHA         string            `json:"after_sha,omitempty"`
+	Ref              string            `json:"ref,omitempty"`
+	RepositoryID     int64             `json:"repository_id,omitempty"`
+	RepositoryName   string            `json:"repository_name,omitempty"`
+	PushedAt         *github.Timestamp `json:"pushed_at,omitempty"`
+	Result           string            `json:"result,omitempty"`
+	EvaluationResult string            `json:"evaluation_result,omitempty"`
+	RuleEvaluations  []*RuleEvaluation `json:"rule_evaluations,omitempty"`
+}
+
+// RuleEvaluation is the outcome of a single rule within a rule suite.
+type RuleEvaluation struct {
+	RuleSource struct {
+		Type string `json:"type,omitempty"`
+		ID   int64  `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"rule_source"`
+	Enforcement string `json:"enforcement,omitempty"`
+	Result      string `json:"result,omitempty"`
+	RuleType    string `json:"rule_type,omitempty"`
+	Details     string `json:"details,omitempty"`
+}
+
+// ListRuleSuites creates a tool to list recent rule evaluations for a repository.
+func ListRuleSuites(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_rule_suites",
+			mcp.WithDescription(t("TOOL_LIST_RULE_SUITES_DESCRIPTION", "List recent rule suites (ruleset evaluations of pushes) for a repository. Useful to understand why rulesets blocked or allowed pushes. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_RULE_SUITES_USER_TITLE", "List rule suites"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Description("The name of the ref to filter by, e.g. refs/heads/main. Branch names without the refs/heads/ prefix are also accepted."),
+			),
+			mcp.WithString("actor_name",
+				mcp.Description("The login of the user who performed the push"),
+			),
+			mcp.WithString("rule_suite_result",
+				mcp.Description("Filter by the result of the rule suite evaluation"),
+				mcp.Enum("pass", "fail", "bypass", "all"),
+			),
+			mcp.WithString("time_period",
+				mcp.Description("The time period to filter by, defaults to day"),
+				mcp.Enum("hour", "day", "week", "month"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			actorName, err := OptionalParam[string](request, "actor_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			result, err := OptionalParam[string](request, "rule_suite_result")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			timePeriod, err := OptionalParam[string](request, "time_period")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			query := url.Values{}
+			if ref != "" {
+				if !strings.HasPrefix(ref, "refs/") {
+					ref = "refs/heads/" + ref
+				}
+				query.Set("ref", ref)
+			}
+			if actorName != "" {
+				query.Set("actor_name", actorName)
+			}
+			if result != "" {
+				query.Set("rule_suite_result", result)
+			}
+			if timePeriod != "" {
+				query.Set("time_period", timePeriod)
+			}
+			query.Set("page", strconv.Itoa(pagination.Page))
+			query.Set("per_page", strconv.Itoa(pagination.PerPage))
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			u := fmt.Sprintf("repos/%s/%s/rulesets/rule-suites?%s", owner, repo, query.Encode())
+			req, err := client.NewRequest(http.MethodGet, u, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			var ruleSuites []*RuleSuite
+			resp, err := client.Do(ctx, req, &ruleSuites)
+			if err != nil {
+				return newAdminAPIErrorResponse(ctx, "failed to list rule suites", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(ruleSuites)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetRuleSuite creates a tool to get the per-rule results of a single rule suite.
+func GetRuleSuite(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_rule_suite",
+			mcp.WithDescription(t("TOOL_GET_RULE_SUITE_DESCRIPTION", "Get a single rule suite for a repository, including the pass/fail result of every rule that was evaluated. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RULE_SUITE_USER_TITLE", "Get rule suite"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("rule_suite_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the rule suite"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ruleSuiteID, err := RequiredInt(request, "rule_suite_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			u := fmt.Sprintf("repos/%s/%s/rulesets/rule-suites/%d", owner, repo, ruleSuiteID)
+			req, err := client.NewRequest(http.MethodGet, u, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			ruleSuite := new(RuleSuite)
+			resp, err := client.Do(ctx, req, ruleSuite)
+			if err != nil {
+				return newAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to get rule suite: %d", ruleSuiteID), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(ruleSuite)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// newAdminAPIErrorResponse wraps NewGitHubAPIErrorResponse for endpoints that are restricted to repository
+// administrators. GitHub answers those with a 403 or 404 for everyone else, which is otherwise hard to tell
+// apart from a missing resource, so we point at the likely cause in the message.
+func newAdminAPIErrorResponse(ctx context.Context, message string, resp *github.Response, err error) *mcp.CallToolResult {
+	return newScopedAdminAPIErrorResponse(ctx, "repository", message, resp, err)
+}
+
+// newOrgAdminAPIErrorResponse is the organization counterpart of newAdminAPIErrorResponse.
+func newOrgAdminAPIErrorResponse(ctx context.Context, message string, resp *github.Response, err error) *mcp.CallToolResult {
+	return newScopedAdminAPIErrorResponse(ctx, "organization", message, resp, err)
+}
+
+func newScopedAdminAPIErrorResponse(ctx context.Context, scope, message string, resp *github.Response, err error) *mcp.CallToolResult {
+	if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+		message += fmt.Sprintf(" (this endpoint requires admin access to the %s; check that the token has the required permissions)", scope)
+	}
+	return ghErrors.NewGitHubAPIErrorResponse(ctx, message, resp, err)
+}
+
+// BypassRequest is a request to bypass a push rule, waiting for or having received a review.
+// go-github does not model the bypass requests API yet, so we define our own type.
+type BypassRequest struct {
+	ID         int64 `json:"id"`
+	Number     int64 `json:"number"`
+	Repository *struct {
+		ID       int64  `json:"id,omitempty"`
+		Name     string `json:"name,omitempty"`
+		FullName string `json:"full_name,omitempty"`
+	} `json:"repository,omitempty"`
+	Requester *struct {
+		ActorID   int64  `json:"actor_id,omitempty"`
+		ActorName string `json:"actor_name,omitempty"`
+	} `json:"requester,omitempty"`
+	RequestType        string            `json:"request_type,omitempty"`
+	Data               json.RawMessage   `json:"data,omitempty"`
+	ResourceIdentifier string            `json:"resource_identifier,omitempty"`
+	Status             string            `json:"status,omitempty"`
+	RequesterComment   string            `json:"requester_comment,omitempty"`
+	ExpiresAt          *github.Timestamp `json:"expires_at,omitempty"`
+	CreatedAt          *github.Timestamp `json:"created_at,omitempty"`
+	Responses          []*BypassResponse `json:"responses,omitempty"`
+	HTMLURL            string            `json:"html_url,omitempty"`
+}
+
+// BypassResponse is a reviewer's decision on a bypass request.
+type BypassResponse struct {
+	ID       int64 `json:"id,omitempty"`
+	Reviewer *struct {
+		ActorID   int64  `json:"actor_id,omitempty"`
+		ActorName string `json:"actor_name,omitempty"`
+	} `json:"reviewer,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	CreatedAt *github.Timestamp `json:"created_at,omitempty"`
+}
+
+// ListPushRuleBypassRequests creates a tool to list push rule bypass requests for a repository.
+func ListPushRuleBypassRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_push_rule_bypass_requests",
+			mcp.WithDescription(t("TOOL_LIST_PUSH_RULE_BYPASS_REQUESTS_DESCRIPTION", "List requests to bypass push rules in a repository, e.g. to review which pushes are waiting for approval. Requires admin access to the repository or permission to review bypass requests.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PUSH_RULE_BYPASS_REQUESTS_USER_TITLE", "List push rule bypass requests"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("request_status",
+				mcp.Description("Filter by the status of the bypass request, defaults to all"),
+				mcp.Enum("open", "completed", "cancelled", "expired", "denied", "all"),
+			),
+			mcp.WithString("requester",
+				mcp.Description("The login of the user who requested the bypass"),
+			),
+			mcp.WithString("reviewer",
+				mcp.Description("The login of the user who reviewed the bypass request"),
+			),
+			mcp.WithString("time_period",
+				mcp.Description("The time period to filter by, defaults to day"),
+				mcp.Enum("hour", "day", "week", "month"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query := url.Values{}
+			for _, name := range []string{"request_status", "requester", "reviewer", "time_period"} {
+				value, err := OptionalParam[string](request, name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if value != "" {
+					query.Set(name, value)
+				}
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query.Set("page", strconv.Itoa(pagination.Page))
+			query.Set("per_page", strconv.Itoa(pagination.PerPage))
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			u := fmt.Sprintf("repos/%s/%s/bypass-requests/push-rules?%s", owner, repo, query.Encode())
+			req, err := client.NewRequest(http.MethodGet, u, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			var bypassRequests []*BypassRequest
+			resp, err := client.Do(ctx, req, &bypassRequests)
+			if err != nil {
+				return newAdminAPIErrorResponse(ctx, "failed to list push rule bypass requests", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(bypassRequests)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetBypassRequest creates a tool to get a single push rule bypass request.
+func GetBypassRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_bypass_request",
+			mcp.WithDescription(t("TOOL_GET_BYPASS_REQUEST_DESCRIPTION", "Get a single push rule bypass request, including the rules it bypasses, the requester's comment and any reviews it has received.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_BYPASS_REQUEST_USER_TITLE", "Get bypass request"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("bypass_request_number",
+				mcp.Required(),
+				mcp.Description("The number of the bypass request"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "bypass_request_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			u := fmt.Sprintf("repos/%s/%s/bypass-requests/push-rules/%d", owner, repo, number)
+			req, err := client.NewRequest(http.MethodGet, u, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			bypassRequest := new(BypassRequest)
+			resp, err := client.Do(ctx, req, bypassRequest)
+			if err != nil {
+				return newAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to get bypass request: %d", number), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(bypassRequest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ReviewBypassRequest creates a tool to approve or deny a push rule bypass request.
+func ReviewBypassRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("review_bypass_request",
+			mcp.WithDescription(t("TOOL_REVIEW_BYPASS_REQUEST_DESCRIPTION", "Approve or deny a push rule bypass request. Approving lets the requester push the commits that the push rules blocked. Requires permission to review bypass requests in the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REVIEW_BYPASS_REQUEST_USER_TITLE", "Review bypass request"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("bypass_request_number",
+				mcp.Required(),
+				mcp.Description("The number of the bypass request"),
+			),
+			mcp.WithString("status",
+				mcp.Required(),
+				mcp.Description("The review decision"),
+				mcp.Enum("approve", "deny"),
+			),
+			mcp.WithString("comment",
+				mcp.Description("A comment explaining the decision, shown to the requester"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "bypass_request_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			status, err := RequiredParam[string](request, "status")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status != "approve" && status != "deny" {
+				return mcp.NewToolResultError("status must be either approve or deny"), nil
+			}
+			comment, err := OptionalParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			body := map[string]string{"status": status}
+			if comment != "" {
+				body["message"] = comment
+			}
+
+			u := fmt.Sprintf("repos/%s/%s/bypass-requests/push-rules/%d", owner, repo, number)
+			req, err := client.NewRequest(http.MethodPatch, u, body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			bypassResponse := new(BypassResponse)
+			resp, err := client.Do(ctx, req, bypassResponse)
+			if err != nil {
+				return newAdminAPIErrorResponse(ctx, fmt.Sprintf("failed to review bypass request: %d", number), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(bypassResponse)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,589 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListRuleSuites(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRuleSuites(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_rule_suites", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "actor_name")
+	assert.Contains(t, tool.InputSchema.Properties, "rule_suite_result")
+	assert.Contains(t, tool.InputSchema.Properties, "time_period")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRuleSuites := []*RuleSuite{
+		{
+			ID:               42,
+			ActorName:        "octocat",
+			Ref:              "refs/heads/main",
+			Result:           "bypass",
+			EvaluationResult: "fail",
+		},
+		{
+			ID:        43,
+			ActorName: "octocat",
+			Ref:       "refs/heads/main",
+			Result:    "pass",
+		},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedRuleSuites []*RuleSuite
+		expectedErrMsg     string
+	}{
+		{
+			name: "successful rule suites list with filters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsRuleSuitesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"ref":               "refs/heads/main",
+						"actor_name":        "octocat",
+						"rule_suite_result": "bypass",
+						"time_period":       "week",
+						"page":              "1",
+						"per_page":          "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRuleSuites[:1]),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"ref":               "main",
+				"actor_name":        "octocat",
+				"rule_suite_result": "bypass",
+				"time_period":       "week",
+			},
+			expectError:        false,
+			expectedRuleSuites: mockRuleSuites[:1],
+		},
+		{
+			name: "successful rule suites list without filters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsRuleSuitesByOwnerByRepo,
+					expectPath(t, "/repos/owner/repo/rulesets/rule-suites").andThen(
+						mockResponse(t, http.StatusOK, mockRuleSuites),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:        false,
+			expectedRuleSuites: mockRuleSuites,
+		},
+		{
+			name: "non-admin gets permission hint",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsRuleSuitesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "requires admin access to the repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRuleSuites(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, "failed to list rule suites")
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent := getTextResult(t, result)
+
+			var returnedRuleSuites []*RuleSuite
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRuleSuites)
+			require.NoError(t, err)
+			require.Equal(t, len(tc.expectedRuleSuites), len(returnedRuleSuites))
+			for i, expected := range tc.expectedRuleSuites {
+				assert.Equal(t, expected.ID, returnedRuleSuites[i].ID)
+				assert.Equal(t, expected.Result, returnedRuleSuites[i].Result)
+			}
+		})
+	}
+}
+
+func Test_GetRuleSuite(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRuleSuite(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_rule_suite", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "rule_suite_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "rule_suite_id"})
+
+	mockRuleSuite := &RuleSuite{
+		ID:        42,
+		ActorName: "octocat",
+		Ref:       "refs/heads/main",
+		Result:    "fail",
+		RuleEvaluations: []*RuleEvaluation{
+			{
+				Enforcement: "active",
+				Result:      "fail",
+				RuleType:    "pull_request",
+				Details:     "Changes must be made through a pull request.",
+			},
+		},
+	}
+	mockRuleSuite.RuleEvaluations[0].RuleSource.Type = "ruleset"
+	mockRuleSuite.RuleEvaluations[0].RuleSource.ID = 7
+	mockRuleSuite.RuleEvaluations[0].RuleSource.Name = "Protect main"
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedRuleSuite *RuleSuite
+		expectedErrMsg    string
+	}{
+		{
+			name: "successful rule suite fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsRuleSuitesByOwnerByRepoByRuleSuiteId,
+					expectPath(t, "/repos/owner/repo/rulesets/rule-suites/42").andThen(
+						mockResponse(t, http.StatusOK, mockRuleSuite),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"rule_suite_id": float64(42),
+			},
+			expectError:       false,
+			expectedRuleSuite: mockRuleSuite,
+		},
+		{
+			name: "rule suite not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsRuleSuitesByOwnerByRepoByRuleSuiteId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"rule_suite_id": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get rule suite: 999",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRuleSuite(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent := getTextResult(t, result)
+
+			var returnedRuleSuite RuleSuite
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRuleSuite)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRuleSuite.ID, returnedRuleSuite.ID)
+			assert.Equal(t, tc.expectedRuleSuite.Result, returnedRuleSuite.Result)
+			require.Len(t, returnedRuleSuite.RuleEvaluations, 1)
+			assert.Equal(t, "Protect main", returnedRuleSuite.RuleEvaluations[0].RuleSource.Name)
+			assert.Equal(t, "pull_request", returnedRuleSuite.RuleEvaluations[0].RuleType)
+		})
+	}
+}
+
+func Test_ListPushRuleBypassRequests(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPushRuleBypassRequests(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_push_rule_bypass_requests", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "request_status")
+	assert.Contains(t, tool.InputSchema.Properties, "requester")
+	assert.Contains(t, tool.InputSchema.Properties, "reviewer")
+	assert.Contains(t, tool.InputSchema.Properties, "time_period")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockBypassRequests := []*BypassRequest{
+		{ID: 1, Number: 10, Status: "open", RequesterComment: "hotfix"},
+		{ID: 2, Number: 11, Status: "denied"},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedNums   []int64
+		expectedErrMsg string
+	}{
+		{
+			name: "successful list with filters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBypassRequestsPushRulesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"request_status": "open",
+						"requester":      "octocat",
+						"page":           "1",
+						"per_page":       "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockBypassRequests[:1]),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"request_status": "open",
+				"requester":      "octocat",
+			},
+			expectError:  false,
+			expectedNums: []int64{10},
+		},
+		{
+			name: "non-admin gets permission hint",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBypassRequestsPushRulesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "requires admin access to the repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPushRuleBypassRequests(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, "failed to list push rule bypass requests")
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned []*BypassRequest
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			nums := make([]int64, 0, len(returned))
+			for _, r := range returned {
+				nums = append(nums, r.Number)
+			}
+			assert.Equal(t, tc.expectedNums, nums)
+		})
+	}
+}
+
+func Test_GetBypassRequest(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetBypassRequest(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_bypass_request", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "bypass_request_number"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBypassRequestsPushRulesByOwnerByRepoByBypassRequestNumber,
+					expectPath(t, "/repos/owner/repo/bypass-requests/push-rules/10").andThen(
+						mockResponse(t, http.StatusOK, &BypassRequest{
+							ID:               1,
+							Number:           10,
+							Status:           "open",
+							RequesterComment: "hotfix",
+							Responses:        []*BypassResponse{{ID: 5, Status: "dismissed"}},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"bypass_request_number": float64(10),
+			},
+			expectError: false,
+		},
+		{
+			name: "bypass request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBypassRequestsPushRulesByOwnerByRepoByBypassRequestNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"bypass_request_number": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get bypass request: 999",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBypassRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned BypassRequest
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, int64(10), returned.Number)
+			assert.Equal(t, "hotfix", returned.RequesterComment)
+			require.Len(t, returned.Responses, 1)
+			assert.Equal(t, "dismissed", returned.Responses[0].Status)
+		})
+	}
+}
+
+func Test_ReviewBypassRequest(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReviewBypassRequest(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "review_bypass_request", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "comment")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "bypass_request_number", "status"})
+
+	patchBypassRequest := mock.EndpointPattern{
+		Pattern: "/repos/{owner}/{repo}/bypass-requests/push-rules/{bypass_request_number}",
+		Method:  "PATCH",
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedStatus string
+		expectedErrMsg string
+	}{
+		{
+			name: "approve with comment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					patchBypassRequest,
+					expectRequestBody(t, map[string]interface{}{
+						"status":  "approve",
+						"message": "Approved for the hotfix",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &BypassResponse{ID: 7, Status: "approved"}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"bypass_request_number": float64(10),
+				"status":                "approve",
+				"comment":               "Approved for the hotfix",
+			},
+			expectError:    false,
+			expectedStatus: "approved",
+		},
+		{
+			name:         "invalid status",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"bypass_request_number": float64(10),
+				"status":                "dismiss",
+			},
+			expectError:    true,
+			expectedErrMsg: "status must be either approve or deny",
+		},
+		{
+			name: "reviewer without permission gets hint",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					patchBypassRequest,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"bypass_request_number": float64(10),
+				"status":                "deny",
+			},
+			expectError:    true,
+			expectedErrMsg: "requires admin access to the repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReviewBypassRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned BypassResponse
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedStatus, returned.Status)
+		})
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
+	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -79,7 +82,7 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 // SearchCode creates a tool to search for code across GitHub repositories.
 func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_code",
-			mcp.WithDescription(t("TOOL_SEARCH_CODE_DESCRIPTION", "Fast and precise code search across ALL GitHub repositories using GitHub's native search engine. Best for finding exact symbols, functions, classes, or specific code patterns.")),
+			mcp.WithDescription(t("TOOL_SEARCH_CODE_DESCRIPTION", "Fast and precise code search across ALL GitHub repositories using GitHub's native search engine. Best for finding exact symbols, functions, classes, or specific code patterns, and for answering where something is implemented. Returns the path and repository of each matching file with the fragments of it that matched.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_SEARCH_CODE_USER_TITLE", "Search code"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -95,6 +98,12 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Description("Sort order for results"),
 				mcp.Enum("asc", "desc"),
 			),
+			mcp.WithNumber("max_fragments",
+				mcp.Description(fmt.Sprintf("Maximum number of matching fragments to return per file, 0 returns none (max %d)", maxCodeSearchFragments)),
+				mcp.Min(0),
+				mcp.Max(maxCodeSearchFragments),
+				mcp.DefaultNumber(defaultCodeSearchFragments),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -110,14 +119,22 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			maxFragments, err := OptionalIntParamWithDefault(request, "max_fragments", defaultCodeSearchFragments)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxFragments < 0 || maxFragments > maxCodeSearchFragments {
+				return mcp.NewToolResultError(fmt.Sprintf("max_fragments must be between 0 and %d", maxCodeSearchFragments)), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.SearchOptions{
-				Sort:  sort,
-				Order: order,
+				Sort:      sort,
+				Order:     order,
+				TextMatch: maxFragments > 0,
 				ListOptions: github.ListOptions{
 					PerPage: pagination.PerPage,
 					Page:    pagination.Page,
@@ -147,13 +164,82 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return mcp.NewToolResultError(fmt.Sprintf("failed to search code: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			minimalResult := MinimalSearchCodeResult{
+				TotalCount:        result.GetTotal(),
+				IncompleteResults: result.GetIncompleteResults(),
+				Items:             make([]MinimalCodeResult, 0, len(result.CodeResults)),
+				NextPage:          resp.NextPage,
+			}
+			for _, code := range result.CodeResults {
+				minimalResult.Items = append(minimalResult.Items, newMinimalCodeResult(code, maxFragments))
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(minimalResult), nil
+		}
+}
+
+// defaultCodeSearchFragments and maxCodeSearchFragments bound the fragments search_code returns per file.
+const (
+	defaultCodeSearchFragments = 3
+	maxCodeSearchFragments     = 10
+)
+
+// MinimalCodeResult is the output type for code search results.
+type MinimalCodeResult struct {
+	Name       string         `json:"name"`
+	Path       string         `json:"path"`
+	Repository string         `json:"repository"`
+	SHA        string         `json:"sha,omitempty"`
+	HTMLURL    string         `json:"html_url,omitempty"`
+	Fragments  []CodeFragment `json:"fragments,omitempty"`
+}
+
+// CodeFragment is a part of a file that matched a code search, with the offsets of the matches in it.
+type CodeFragment struct {
+	Fragment string      `json:"fragment"`
+	Matches  []CodeMatch `json:"matches,omitempty"`
+}
+
+// CodeMatch is a match in a CodeFragment, Start and End are byte offsets into the fragment.
+type CodeMatch struct {
+	Text  string `json:"text"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+type MinimalSearchCodeResult struct {
+	TotalCount        int                 `json:"total_count"`
+	IncompleteResults bool                `json:"incomplete_results"`
+	Items             []MinimalCodeResult `json:"items"`
+	NextPage          int                 `json:"next_page,omitempty"`
+}
+
+func newMinimalCodeResult(code *github.CodeResult, maxFragments int) MinimalCodeResult {
+	result := MinimalCodeResult{
+		Name:       code.GetName(),
+		Path:       code.GetPath(),
+		Repository: code.GetRepository().GetFullName(),
+		SHA:        code.GetSHA(),
+		HTMLURL:    code.GetHTMLURL(),
+	}
+	for _, textMatch := range code.TextMatches {
+		if len(result.Fragments) == maxFragments {
+			break
+		}
+		// Text matches can also be on the path of the file, which is already in the result
+		if textMatch.GetProperty() != "content" {
+			continue
 		}
+		fragment := CodeFragment{Fragment: textMatch.GetFragment()}
+		for _, match := range textMatch.Matches {
+			if len(match.Indices) != 2 {
+				continue
+			}
+			fragment.Matches = append(fragment.Matches, CodeMatch{Text: match.GetText(), Start: match.Indices[0], End: match.Indices[1]})
+		}
+		result.Fragments = append(result.Fragments, fragment)
+	}
+	return result
 }
 
 // MinimalUser is the output type for user and organization search results.
@@ -204,7 +290,11 @@ func userOrOrgHandler(accountType string, getClient GetClientFn) server.ToolHand
 			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 		}
 
-		searchQuery := "type:" + accountType + " " + query
+		qualifiers, err := searchQualifiers(request, "location", "language", "followers")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		searchQuery := "type:" + accountType + " " + query + qualifiers
 		result, resp, err := client.Search.Users(ctx, searchQuery, opts)
 		if err != nil {
 			return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -256,6 +346,37 @@ func userOrOrgHandler(accountType string, getClient GetClientFn) server.ToolHand
 	}
 }
 
+// searchRangePattern matches the values of numeric search qualifiers: n, >n, >=n, <n, <=n and n..m.
+var searchRangePattern = regexp.MustCompile(`^([<>]=?)?\d+$|^\d+\.\.\d+$`)
+
+// searchQualifiers turns the optional parameters of the given qualifier names into search qualifiers to
+// append to a query. The followers and repositories qualifiers take numbers or ranges of them.
+func searchQualifiers(request mcp.CallToolRequest, names ...string) (string, error) {
+	var qualifiers strings.Builder
+	for _, name := range names {
+		value, err := OptionalParam[string](request, name)
+		if err != nil {
+			return "", err
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		switch name {
+		case "followers", "repositories":
+			if !searchRangePattern.MatchString(value) {
+				return "", fmt.Errorf("%s must be a number, a comparison such as >100 or a range such as 10..50", name)
+			}
+		default:
+			if strings.ContainsAny(value, " \t") {
+				value = `"` + strings.ReplaceAll(value, `"`, "") + `"`
+			}
+		}
+		fmt.Fprintf(&qualifiers, " %s:%s", name, value)
+	}
+	return qualifiers.String(), nil
+}
+
 // SearchUsers creates a tool to search for GitHub users.
 func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_users",
@@ -268,6 +389,15 @@ func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.Required(),
 			mcp.Description("User search query. Examples: 'john smith', 'location:seattle', 'followers:>100'. Search is automatically scoped to type:user."),
 		),
+		mcp.WithString("location",
+			mcp.Description("Only find users whose profile location matches, such as 'seattle' or 'san francisco'"),
+		),
+		mcp.WithString("language",
+			mcp.Description("Only find users whose repositories are mostly in this language, such as 'go'"),
+		),
+		mcp.WithString("followers",
+			mcp.Description("Only find users with this many followers: a number, a comparison such as '>100' or '<=10', or a range such as '10..50'"),
+		),
 		mcp.WithString("sort",
 			mcp.Description("Sort users by number of followers or repositories, or when the person joined GitHub."),
 			mcp.Enum("followers", "repositories", "joined"),
@@ -304,3 +434,220 @@ func SearchOrgs(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 		WithPagination(),
 	), userOrOrgHandler("org", getClient)
 }
+
+// MinimalCommitResult is the output type for commit search results.
+type MinimalCommitResult struct {
+	SHA        string `json:"sha"`
+	Repository string `json:"repository"`
+	Message    string `json:"message"`
+	Author     string `json:"author,omitempty"`
+	AuthorDate string `json:"author_date,omitempty"`
+	HTMLURL    string `json:"html_url,omitempty"`
+}
+
+type MinimalSearchCommitsResult struct {
+	TotalCount        int                   `json:"total_count"`
+	IncompleteResults bool                  `json:"incomplete_results"`
+	Items             []MinimalCommitResult `json:"items"`
+	NextPage          int                   `json:"next_page,omitempty"`
+}
+
+// SearchCommits creates a tool to search for commits across GitHub repositories.
+func SearchCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("search_commits",
+			mcp.WithDescription(t("TOOL_SEARCH_COMMITS_DESCRIPTION", "Search commits across GitHub repositories by message, author, committer, date or hash. Only default branches are searched. Useful for finding when and why something changed, such as the commit that introduced a feature or fixed a bug.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_COMMITS_USER_TITLE", "Search commits"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Commit search query. Examples: 'fix memory leak repo:github/github-mcp-server', 'author:octocat author-date:>2024-01-01', 'hash:124a9a0ee1d8f1e15e833aff432fbb3b02632105'. Supports the commit search qualifiers of GitHub."),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort field, by default the best match"),
+				mcp.Enum("author-date", "committer-date"),
+			),
+			mcp.WithString("order",
+				mcp.Description("Sort order for results"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			query, err := RequiredParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			order, err := OptionalParam[string](request, "order")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.SearchOptions{
+				Sort:  sort,
+				Order: order,
+				ListOptions: github.ListOptions{
+					PerPage: pagination.PerPage,
+					Page:    pagination.Page,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result, resp, err := client.Search.Commits(ctx, query, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to search commits with query '%s'", query),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalResult := MinimalSearchCommitsResult{
+				TotalCount:        result.GetTotal(),
+				IncompleteResults: result.GetIncompleteResults(),
+				Items:             make([]MinimalCommitResult, 0, len(result.Commits)),
+				NextPage:          resp.NextPage,
+			}
+			for _, commit := range result.Commits {
+				item := MinimalCommitResult{
+					SHA:        commit.GetSHA(),
+					Repository: commit.GetRepository().GetFullName(),
+					Message:    commit.GetCommit().GetMessage(),
+					Author:     commit.GetAuthor().GetLogin(),
+					HTMLURL:    commit.GetHTMLURL(),
+				}
+				// Commits by authors without a GitHub account only have the name of the git author
+				if item.Author == "" {
+					item.Author = commit.GetCommit().GetAuthor().GetName()
+				}
+				if date := commit.GetCommit().GetAuthor().GetDate(); !date.IsZero() {
+					item.AuthorDate = date.UTC().Format(time.RFC3339)
+				}
+				minimalResult.Items = append(minimalResult.Items, item)
+			}
+
+			return MarshalledTextResult(minimalResult), nil
+		}
+}
+
+// MinimalTopic is the output type for topic search results.
+type MinimalTopic struct {
+	Name             string `json:"name"`
+	DisplayName      string `json:"display_name,omitempty"`
+	ShortDescription string `json:"short_description,omitempty"`
+	Featured         bool   `json:"featured,omitempty"`
+	Curated          bool   `json:"curated,omitempty"`
+}
+
+type MinimalSearchTopicsResult struct {
+	TotalCount        int            `json:"total_count"`
+	IncompleteResults bool           `json:"incomplete_results"`
+	Items             []MinimalTopic `json:"items"`
+}
+
+// SearchTopics creates a tool to search for the topics repositories are tagged with.
+func SearchTopics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("search_topics",
+			mcp.WithDescription(t("TOOL_SEARCH_TOPICS_DESCRIPTION", "Find the topics GitHub repositories are tagged with, such as 'machine-learning' or 'rust'. Use the topic names with the topic: qualifier of search_repositories to find the repositories of a topic.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_TOPICS_USER_TITLE", "Search topics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Topic search query, matched against topic names and descriptions. Examples: 'machine learning', 'web framework'"),
+			),
+			mcp.WithBoolean("featured",
+				mcp.Description("Only find topics featured on GitHub"),
+			),
+			mcp.WithBoolean("curated",
+				mcp.Description("Only find topics with curated descriptions"),
+			),
+			mcp.WithString("repositories",
+				mcp.Description("Only find topics that this many repositories are tagged with: a number, a comparison such as '>1000', or a range such as '10..50'"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			query, err := RequiredParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			featured, err := OptionalParam[bool](request, "featured")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			curated, err := OptionalParam[bool](request, "curated")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			qualifiers, err := searchQualifiers(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			searchQuery := query + qualifiers
+			if featured {
+				searchQuery += " is:featured"
+			}
+			if curated {
+				searchQuery += " is:curated"
+			}
+			opts := &github.SearchOptions{
+				ListOptions: github.ListOptions{
+					PerPage: pagination.PerPage,
+					Page:    pagination.Page,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result, resp, err := client.Search.Topics(ctx, searchQuery, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to search topics with query '%s'", searchQuery),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalResult := MinimalSearchTopicsResult{
+				TotalCount:        result.GetTotal(),
+				IncompleteResults: result.GetIncompleteResults(),
+				Items:             make([]MinimalTopic, 0, len(result.Topics)),
+			}
+			for _, topic := range result.Topics {
+				minimalResult.Items = append(minimalResult.Items, MinimalTopic{
+					Name:             topic.GetName(),
+					DisplayName:      topic.GetDisplayName(),
+					ShortDescription: topic.GetShortDescription(),
+					Featured:         topic.GetFeatured(),
+					Curated:          topic.GetCurated(),
+				})
+			}
+
+			return MarshalledTextResult(minimalResult), nil
+		}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -176,6 +177,7 @@ func Test_SearchCode(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "query")
 	assert.Contains(t, tool.InputSchema.Properties, "sort")
 	assert.Contains(t, tool.InputSchema.Properties, "order")
+	assert.Contains(t, tool.InputSchema.Properties, "max_fragments")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"query"})
@@ -191,6 +193,22 @@ func Test_SearchCode(t *testing.T) {
 				SHA:        github.Ptr("abc123def456"),
 				HTMLURL:    github.Ptr("https://github.com/owner/repo/blob/main/path/to/file1.go"),
 				Repository: &github.Repository{Name: github.Ptr("repo"), FullName: github.Ptr("owner/repo")},
+				TextMatches: []*github.TextMatch{
+					{
+						Property: github.Ptr("path"),
+						Fragment: github.Ptr("path/to/file1.go"),
+					},
+					{
+						Property: github.Ptr("content"),
+						Fragment: github.Ptr("func main() {\n\tfmt.Println(\"hello\")\n}"),
+						Matches:  []*github.Match{{Text: github.Ptr("fmt.Println"), Indices: []int{15, 26}}},
+					},
+					{
+						Property: github.Ptr("content"),
+						Fragment: github.Ptr("\tfmt.Println(\"bye\")"),
+						Matches:  []*github.Match{{Text: github.Ptr("fmt.Println"), Indices: []int{1, 12}}},
+					},
+				},
 			},
 			{
 				Name:       github.Ptr("file2.go"),
@@ -202,12 +220,48 @@ func Test_SearchCode(t *testing.T) {
 		},
 	}
 
+	expectedFragments := []CodeFragment{
+		{
+			Fragment: "func main() {\n\tfmt.Println(\"hello\")\n}",
+			Matches:  []CodeMatch{{Text: "fmt.Println", Start: 15, End: 26}},
+		},
+		{
+			Fragment: "\tfmt.Println(\"bye\")",
+			Matches:  []CodeMatch{{Text: "fmt.Println", Start: 1, End: 12}},
+		},
+	}
+	expectedResult := MinimalSearchCodeResult{
+		TotalCount: 2,
+		Items: []MinimalCodeResult{
+			{
+				Name:       "file1.go",
+				Path:       "path/to/file1.go",
+				Repository: "owner/repo",
+				SHA:        "abc123def456",
+				HTMLURL:    "https://github.com/owner/repo/blob/main/path/to/file1.go",
+				Fragments:  expectedFragments,
+			},
+			{
+				Name:       "file2.go",
+				Path:       "path/to/file2.go",
+				Repository: "owner/repo",
+				SHA:        "def456abc123",
+				HTMLURL:    "https://github.com/owner/repo/blob/main/path/to/file2.go",
+			},
+		},
+	}
+	withoutFragments := MinimalSearchCodeResult{
+		TotalCount: 2,
+		Items:      []MinimalCodeResult{expectedResult.Items[0], expectedResult.Items[1]},
+	}
+	withoutFragments.Items[0].Fragments = expectedFragments[:1]
+
 	tests := []struct {
 		name           string
 		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedResult *github.CodeSearchResult
+		expectedResult MinimalSearchCodeResult
 		expectedErrMsg string
 	}{
 		{
@@ -234,7 +288,7 @@ func Test_SearchCode(t *testing.T) {
 				"perPage": float64(30),
 			},
 			expectError:    false,
-			expectedResult: mockSearchResult,
+			expectedResult: expectedResult,
 		},
 		{
 			name: "code search with minimal parameters",
@@ -254,7 +308,32 @@ func Test_SearchCode(t *testing.T) {
 				"query": "fmt.Println language:go",
 			},
 			expectError:    false,
-			expectedResult: mockSearchResult,
+			expectedResult: expectedResult,
+		},
+		{
+			name: "code search with capped fragments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchCode,
+					mockResponse(t, http.StatusOK, mockSearchResult),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query":         "fmt.Println language:go",
+				"max_fragments": float64(1),
+			},
+			expectError:    false,
+			expectedResult: withoutFragments,
+		},
+		{
+			name:         "code search with too many fragments",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"query":         "fmt.Println language:go",
+				"max_fragments": float64(11),
+			},
+			expectError:    true,
+			expectedErrMsg: "max_fragments must be between 0 and 10",
 		},
 		{
 			name: "search code fails",
@@ -303,19 +382,10 @@ func Test_SearchCode(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedResult github.CodeSearchResult
+			var returnedResult MinimalSearchCodeResult
 			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
 			require.NoError(t, err)
-			assert.Equal(t, *tc.expectedResult.Total, *returnedResult.Total)
-			assert.Equal(t, *tc.expectedResult.IncompleteResults, *returnedResult.IncompleteResults)
-			assert.Len(t, returnedResult.CodeResults, len(tc.expectedResult.CodeResults))
-			for i, code := range returnedResult.CodeResults {
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].Name, *code.Name)
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].Path, *code.Path)
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].SHA, *code.SHA)
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].HTMLURL, *code.HTMLURL)
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].Repository.FullName, *code.Repository.FullName)
-			}
+			assert.Equal(t, tc.expectedResult, returnedResult)
 		})
 	}
 }
@@ -329,6 +399,9 @@ func Test_SearchUsers(t *testing.T) {
 	assert.Equal(t, "search_users", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "location")
+	assert.Contains(t, tool.InputSchema.Properties, "language")
+	assert.Contains(t, tool.InputSchema.Properties, "followers")
 	assert.Contains(t, tool.InputSchema.Properties, "sort")
 	assert.Contains(t, tool.InputSchema.Properties, "order")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
@@ -410,6 +483,39 @@ func Test_SearchUsers(t *testing.T) {
 			expectError:    false,
 			expectedResult: mockSearchResult,
 		},
+		{
+			name: "users search with qualifier parameters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchUsers,
+					expectQueryParams(t, map[string]string{
+						"q":        `type:user john location:"san francisco" language:go followers:>=100`,
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSearchResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query":     "john",
+				"location":  "san francisco",
+				"language":  "go",
+				"followers": ">=100",
+			},
+			expectError:    false,
+			expectedResult: mockSearchResult,
+		},
+		{
+			name:         "users search with an invalid followers range",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"query":     "john",
+				"followers": "lots",
+			},
+			expectError:    true,
+			expectedErrMsg: "followers must be a number, a comparison such as >100 or a range such as 10..50",
+		},
 		{
 			name: "search users fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -598,3 +704,244 @@ func Test_SearchOrgs(t *testing.T) {
 		})
 	}
 }
+
+func Test_SearchCommits(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SearchCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "search_commits", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "sort")
+	assert.Contains(t, tool.InputSchema.Properties, "order")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"query"})
+
+	mockSearchResult := &github.CommitsSearchResult{
+		Total:             github.Ptr(2),
+		IncompleteResults: github.Ptr(false),
+		Commits: []*github.CommitResult{
+			{
+				SHA:     github.Ptr("abc123"),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123"),
+				Commit: &github.Commit{
+					Message: github.Ptr("Fix memory leak in parser"),
+					Author: &github.CommitAuthor{
+						Name: github.Ptr("Test User"),
+						Date: &github.Timestamp{Time: time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)},
+					},
+				},
+				Author:     &github.User{Login: github.Ptr("testuser")},
+				Repository: &github.Repository{FullName: github.Ptr("owner/repo")},
+			},
+			{
+				SHA: github.Ptr("def456"),
+				Commit: &github.Commit{
+					Message: github.Ptr("Fix memory leak in lexer"),
+					Author:  &github.CommitAuthor{Name: github.Ptr("No Account")},
+				},
+				Repository: &github.Repository{FullName: github.Ptr("owner/other")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult string
+	}{
+		{
+			name: "successful commit search",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchCommits,
+					expectQueryParams(t, map[string]string{
+						"q":        "memory leak org:owner",
+						"sort":     "author-date",
+						"order":    "desc",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSearchResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query": "memory leak org:owner",
+				"sort":  "author-date",
+				"order": "desc",
+			},
+			expectedResult: `{
+				"total_count": 2,
+				"incomplete_results": false,
+				"items": [
+					{"sha": "abc123", "repository": "owner/repo", "message": "Fix memory leak in parser", "author": "testuser", "author_date": "2024-05-02T08:30:00Z", "html_url": "https://github.com/owner/repo/commit/abc123"},
+					{"sha": "def456", "repository": "owner/other", "message": "Fix memory leak in lexer", "author": "No Account"}
+				]
+			}`,
+		},
+		{
+			name: "search commits fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchCommits,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query": "author-date:yesterday",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to search commits with query 'author-date:yesterday'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SearchCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedResult, textContent.Text)
+		})
+	}
+}
+
+func Test_SearchTopics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SearchTopics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "search_topics", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "featured")
+	assert.Contains(t, tool.InputSchema.Properties, "curated")
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"query"})
+
+	mockSearchResult := &github.TopicsSearchResult{
+		Total:             github.Ptr(1),
+		IncompleteResults: github.Ptr(false),
+		Topics: []*github.TopicResult{
+			{
+				Name:             github.Ptr("machine-learning"),
+				DisplayName:      github.Ptr("Machine learning"),
+				ShortDescription: github.Ptr("Machine learning is the practice of teaching a computer to learn."),
+				Featured:         github.Ptr(true),
+				Curated:          github.Ptr(true),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult MinimalSearchTopicsResult
+		expectedErrMsg string
+	}{
+		{
+			name: "successful topics search with all parameters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchTopics,
+					expectQueryParams(t, map[string]string{
+						"q":        "machine learning repositories:>1000 is:featured is:curated",
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSearchResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query":        "machine learning",
+				"featured":     true,
+				"curated":      true,
+				"repositories": ">1000",
+				"page":         float64(2),
+				"perPage":      float64(10),
+			},
+			expectedResult: MinimalSearchTopicsResult{
+				TotalCount: 1,
+				Items: []MinimalTopic{
+					{
+						Name:             "machine-learning",
+						DisplayName:      "Machine learning",
+						ShortDescription: "Machine learning is the practice of teaching a computer to learn.",
+						Featured:         true,
+						Curated:          true,
+					},
+				},
+			},
+		},
+		{
+			name:         "topics search with an invalid repositories range",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"query":        "rust",
+				"repositories": "many",
+			},
+			expectError:    true,
+			expectedErrMsg: "repositories must be a number, a comparison such as >100 or a range such as 10..50",
+		},
+		{
+			name: "search topics fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchTopics,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusBadRequest)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query": "rust",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to search topics",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SearchTopics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returnedResult MinimalSearchTopicsResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returnedResult))
+			assert.Equal(t, tc.expectedResult, returnedResult)
+		})
+	}
+}
@@ -161,3 +161,174 @@ func ListSecretScanningAlerts(getClient GetClientFn, t translations.TranslationH
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+func ListOrgSecretScanningAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_org_secret_scanning_alerts",
+			mcp.WithDescription(t("TOOL_LIST_ORG_SECRET_SCANNING_ALERTS_DESCRIPTION", "List secret scanning alerts across all repositories of a GitHub organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_SECRET_SCANNING_ALERTS_USER_TITLE", "List organization secret scanning alerts"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name."),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter by state"),
+				mcp.Enum("open", "resolved"),
+			),
+			mcp.WithString("secret_type",
+				mcp.Description("A comma-separated list of secret types to return. All default secret patterns are returned. To return generic patterns, pass the token name(s) in the parameter."),
+			),
+			mcp.WithString("resolution",
+				mcp.Description("Filter by resolution"),
+				mcp.Enum("false_positive", "wont_fix", "revoked", "pattern_edited", "pattern_deleted", "used_in_tests"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			secretType, err := OptionalParam[string](request, "secret_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resolution, err := OptionalParam[string](request, "resolution")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			alerts, resp, err := client.SecretScanning.ListAlertsForOrg(ctx, org, &github.SecretScanningAlertListOptions{
+				State:      state,
+				SecretType: secretType,
+				Resolution: resolution,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list alerts for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(alerts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal alerts: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func ResolveSecretScanningAlert(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"resolve_secret_scanning_alert",
+			mcp.WithDescription(t("TOOL_RESOLVE_SECRET_SCANNING_ALERT_DESCRIPTION", "Resolve a secret scanning alert in a GitHub repository, or reopen a resolved one. Resolving an alert does not revoke the secret.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RESOLVE_SECRET_SCANNING_ALERT_USER_TITLE", "Resolve secret scanning alert"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithNumber("alertNumber",
+				mcp.Required(),
+				mcp.Description("The number of the alert."),
+			),
+			mcp.WithString("resolution",
+				mcp.Description("The reason for resolving the alert. Required unless reopen is true"),
+				mcp.Enum("false_positive", "wont_fix", "revoked", "used_in_tests"),
+			),
+			mcp.WithString("comment",
+				mcp.Description("A comment explaining the resolution."),
+			),
+			mcp.WithBoolean("reopen",
+				mcp.Description("Reopen the alert instead of resolving it."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			alertNumber, err := RequiredInt(request, "alertNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resolution, err := OptionalParam[string](request, "resolution")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comment, err := OptionalParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reopen, err := OptionalParam[bool](request, "reopen")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.SecretScanningAlertUpdateOptions{State: "open"}
+			if !reopen {
+				if resolution == "" {
+					return mcp.NewToolResultError("resolution is required to resolve an alert"), nil
+				}
+				opts = &github.SecretScanningAlertUpdateOptions{
+					State:             "resolved",
+					Resolution:        github.Ptr(resolution),
+					ResolutionComment: ToStringPtr(comment),
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			alert, resp, err := client.SecretScanning.UpdateAlert(ctx, owner, repo, int64(alertNumber), opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update alert with number '%d'", alertNumber),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(alert)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal alert: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
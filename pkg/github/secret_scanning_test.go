@@ -247,3 +247,206 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListOrgSecretScanningAlerts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgSecretScanningAlerts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_secret_scanning_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockAlerts := []*github.SecretScanningAlert{
+		{
+			Number:     github.Ptr(3),
+			State:      github.Ptr("open"),
+			SecretType: github.Ptr("github_personal_access_token"),
+			Repository: &github.Repository{FullName: github.Ptr("org/repo")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful organization alerts listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSecretScanningAlertsByOrg,
+					expectQueryParams(t, map[string]string{
+						"state":    "open",
+						"page":     "2",
+						"per_page": "50",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAlerts),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "org",
+				"state":   "open",
+				"page":    float64(2),
+				"perPage": float64(50),
+			},
+		},
+		{
+			name: "organization alerts listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSecretScanningAlertsByOrg,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list alerts for organization 'org'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgSecretScanningAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returnedAlerts []*github.SecretScanningAlert
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedAlerts))
+			require.Len(t, returnedAlerts, 1)
+			assert.Equal(t, "github_personal_access_token", returnedAlerts[0].GetSecretType())
+		})
+	}
+}
+
+func Test_ResolveSecretScanningAlert(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ResolveSecretScanningAlert(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "resolve_secret_scanning_alert", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "resolution")
+	assert.Contains(t, tool.InputSchema.Properties, "reopen")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "alertNumber"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedState  string
+	}{
+		{
+			name: "successful resolution",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposSecretScanningAlertsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state":              "resolved",
+						"resolution":         "revoked",
+						"resolution_comment": "Token rotated",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.SecretScanningAlert{Number: github.Ptr(3), State: github.Ptr("resolved")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(3),
+				"resolution":  "revoked",
+				"comment":     "Token rotated",
+			},
+			expectedState: "resolved",
+		},
+		{
+			name: "successful reopen",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposSecretScanningAlertsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state": "open",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.SecretScanningAlert{Number: github.Ptr(3), State: github.Ptr("open")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(3),
+				"reopen":      true,
+			},
+			expectedState: "open",
+		},
+		{
+			name:         "resolution missing",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(3),
+			},
+			expectError:    true,
+			expectedErrMsg: "resolution is required to resolve an alert",
+		},
+		{
+			name: "resolution fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposSecretScanningAlertsByOwnerByRepoByAlertNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(9999),
+				"resolution":  "wont_fix",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update alert with number '9999'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ResolveSecretScanningAlert(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returnedAlert github.SecretScanningAlert
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedAlert))
+			assert.Equal(t, tc.expectedState, returnedAlert.GetState())
+		})
+	}
+}
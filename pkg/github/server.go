@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/github/github-mcp-server/pkg/cursor"
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -17,6 +18,7 @@ func NewServer(version string, opts ...server.ServerOption) *server.MCPServer {
 	defaultOpts := []server.ServerOption{
 		server.WithToolCapabilities(true),
 		server.WithResourceCapabilities(true, true),
+		server.WithPromptCapabilities(true),
 		server.WithLogging(),
 	}
 	opts = append(defaultOpts, opts...)
@@ -188,9 +190,18 @@ func WithPagination() mcp.ToolOption {
 			mcp.Min(1),
 			mcp.Max(100),
 		)(tool)
+
+		withCursor()(tool)
 	}
 }
 
+// withCursor adds the cursor that list tools return in next_cursor for their next page.
+func withCursor() mcp.ToolOption {
+	return mcp.WithString(cursor.Argument,
+		mcp.Description(cursor.ArgumentDescription),
+	)
+}
+
 // WithUnifiedPagination adds REST API pagination parameters to a tool.
 // GraphQL tools will use this and convert page/perPage to GraphQL cursor parameters internally.
 func WithUnifiedPagination() mcp.ToolOption {
@@ -209,6 +220,8 @@ func WithUnifiedPagination() mcp.ToolOption {
 		mcp.WithString("after",
 			mcp.Description("Cursor for pagination. Use the endCursor from the previous page's PageInfo for GraphQL APIs."),
 		)(tool)
+
+		withCursor()(tool)
 	}
 }
 
@@ -224,6 +237,8 @@ func WithCursorPagination() mcp.ToolOption {
 		mcp.WithString("after",
 			mcp.Description("Cursor for pagination. Use the endCursor from the previous page's PageInfo for GraphQL APIs."),
 		)(tool)
+
+		withCursor()(tool)
 	}
 }
 
@@ -251,11 +266,36 @@ func OptionalPaginationParams(r mcp.CallToolRequest) (PaginationParams, error) {
 	if err != nil {
 		return PaginationParams{}, err
 	}
-	return PaginationParams{
+	params := PaginationParams{
 		Page:    page,
 		PerPage: perPage,
 		After:   after,
-	}, nil
+	}
+	position, ok, err := cursorPosition(r)
+	if err != nil {
+		return PaginationParams{}, err
+	}
+	if ok {
+		params.Page = max(position.Page, 1)
+		if position.PerPage > 0 {
+			params.PerPage = position.PerPage
+		}
+		params.After = position.After
+	}
+	return params, nil
+}
+
+// cursorPosition returns the position of the "cursor" parameter, if it is set.
+func cursorPosition(r mcp.CallToolRequest) (cursor.Position, bool, error) {
+	c, err := OptionalParam[string](r, cursor.Argument)
+	if err != nil || c == "" {
+		return cursor.Position{}, false, err
+	}
+	position, err := cursor.Decode(c)
+	if err != nil {
+		return cursor.Position{}, false, err
+	}
+	return position, true, nil
 }
 
 // OptionalCursorPaginationParams returns the "perPage" and "after" parameters from the request,
@@ -269,10 +309,21 @@ func OptionalCursorPaginationParams(r mcp.CallToolRequest) (CursorPaginationPara
 	if err != nil {
 		return CursorPaginationParams{}, err
 	}
-	return CursorPaginationParams{
+	params := CursorPaginationParams{
 		PerPage: perPage,
 		After:   after,
-	}, nil
+	}
+	position, ok, err := cursorPosition(r)
+	if err != nil {
+		return CursorPaginationParams{}, err
+	}
+	if ok {
+		if position.PerPage > 0 {
+			params.PerPage = position.PerPage
+		}
+		params.After = position.After
+	}
+	return params, nil
 }
 
 type CursorPaginationParams struct {
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/github/github-mcp-server/pkg/cursor"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/google/go-github/v73/github"
 	"github.com/shurcooL/githubv4"
@@ -544,6 +545,38 @@ func TestOptionalPaginationParams(t *testing.T) {
 			expected:    PaginationParams{},
 			expectError: true,
 		},
+		{
+			name: "cursor of a REST page takes the place of page and perPage",
+			params: map[string]any{
+				"page":   float64(1),
+				"cursor": cursor.Encode(cursor.Position{Page: 3, PerPage: 50}),
+			},
+			expected: PaginationParams{
+				Page:    3,
+				PerPage: 50,
+			},
+			expectError: false,
+		},
+		{
+			name: "cursor of a GraphQL page",
+			params: map[string]any{
+				"cursor": cursor.Encode(cursor.Position{After: "Y3Vyc29yOjI=", PerPage: 10}),
+			},
+			expected: PaginationParams{
+				Page:    1,
+				PerPage: 10,
+				After:   "Y3Vyc29yOjI=",
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid cursor",
+			params: map[string]any{
+				"cursor": "page-2",
+			},
+			expected:    PaginationParams{},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
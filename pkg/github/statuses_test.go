@@ -0,0 +1,231 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetCombinedStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCombinedStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_combined_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful combined status fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/commits/main/status").andThen(
+						mockResponse(t, http.StatusOK, &github.CombinedStatus{
+							State:      github.Ptr("failure"),
+							TotalCount: github.Ptr(2),
+							Statuses: []*github.RepoStatus{
+								{Context: github.Ptr("ci/build"), State: github.Ptr("success")},
+								{Context: github.Ptr("ci/deploy"), State: github.Ptr("failure")},
+							},
+						}),
+					),
+				),
+			),
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get combined status for main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCombinedStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			}))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.CombinedStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "failure", returned.GetState())
+			assert.Len(t, returned.Statuses, 2)
+		})
+	}
+}
+
+func Test_ListCommitStatuses(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCommitStatuses(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_commit_statuses", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsStatusesByOwnerByRepoByRef,
+			expectQueryParams(t, map[string]string{
+				"page":     "2",
+				"per_page": "5",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.RepoStatus{
+					{ID: github.Ptr(int64(2)), Context: github.Ptr("ci/build"), State: github.Ptr("success")},
+					{ID: github.Ptr(int64(1)), Context: github.Ptr("ci/build"), State: github.Ptr("pending")},
+				}),
+			),
+		),
+	))
+	_, handler := ListCommitStatuses(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":   "owner",
+		"repo":    "repo",
+		"ref":     "abc123",
+		"page":    float64(2),
+		"perPage": float64(5),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []*github.RepoStatus
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 2)
+	assert.Equal(t, "pending", returned[1].GetState())
+}
+
+func Test_CreateCommitStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCommitStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_commit_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha", "state"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful status creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					expectRequestBody(t, map[string]any{
+						"state":       "success",
+						"context":     "agent/gate",
+						"target_url":  "https://example.com/runs/1",
+						"description": "All good",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.RepoStatus{
+							ID:      github.Ptr(int64(1)),
+							State:   github.Ptr("success"),
+							Context: github.Ptr("agent/gate"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"state":       "success",
+				"context":     "agent/gate",
+				"target_url":  "https://example.com/runs/1",
+				"description": "All good",
+			},
+		},
+		{
+			name:         "description too long",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"state":       "failure",
+				"description": strings.Repeat("x", 141),
+			},
+			expectError:    true,
+			expectedErrMsg: "description is 141 characters long, at most 140 are allowed",
+		},
+		{
+			name: "commit not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "No commit found for SHA: abc123"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"state": "pending",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create commit status for abc123",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCommitStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.RepoStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "agent/gate", returned.GetContext())
+		})
+	}
+}
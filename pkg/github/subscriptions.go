@@ -0,0 +1,231 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultResourcePollInterval is how often subscribed resources are checked for changes by default.
+const DefaultResourcePollInterval = time.Minute
+
+// The MCP methods of resource subscriptions, which the MCP server library doesn't define.
+const (
+	methodResourcesSubscribe   = "resources/subscribe"
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+)
+
+// subscribableResourcePattern matches the URIs of the issue and pull request resources.
+var subscribableResourcePattern = regexp.MustCompile(`^repo://([^/?#]+)/([^/?#]+)/(issues|pulls)/([0-9]+)$`)
+
+// ResourceNotifier sends a notification to the client of a session.
+type ResourceNotifier func(sessionID, method string, params map[string]any) error
+
+// resourceSubscription is a resource one session subscribed to.
+type resourceSubscription struct {
+	owner  string
+	repo   string
+	kind   string
+	number int
+
+	// ctx carries the values of the subscribe request, such as the token of the client, without its
+	// cancellation, and is used for the requests that check the resource
+	ctx       context.Context
+	updatedAt time.Time
+}
+
+// ResourceSubscriptions implements resources/subscribe and resources/unsubscribe for the issue and pull
+// request resources. The MCP server library declares the capability but doesn't dispatch these methods, so
+// transports pass messages through HandleMessage first. Subscribed resources are polled, and a
+// notifications/resources/updated notification is sent when their updated_at changes.
+type ResourceSubscriptions struct {
+	getClient GetClientFn
+	notify    ResourceNotifier
+	interval  time.Duration
+
+	mu sync.Mutex
+	// subscriptions are keyed by session ID, then by URI
+	subscriptions map[string]map[string]*resourceSubscription
+}
+
+// NewResourceSubscriptions creates the subscriptions of a server, notifying clients with notify. A zero
+// interval uses DefaultResourcePollInterval.
+func NewResourceSubscriptions(getClient GetClientFn, notify ResourceNotifier, interval time.Duration) *ResourceSubscriptions {
+	if interval <= 0 {
+		interval = DefaultResourcePollInterval
+	}
+	return &ResourceSubscriptions{
+		getClient:     getClient,
+		notify:        notify,
+		interval:      interval,
+		subscriptions: make(map[string]map[string]*resourceSubscription),
+	}
+}
+
+// ServerNotifier notifies the clients of an MCP server.
+func ServerNotifier(s *server.MCPServer) ResourceNotifier {
+	return s.SendNotificationToSpecificClient
+}
+
+// subscriptionRequest is a JSON-RPC request of one of the subscription methods.
+type subscriptionRequest struct {
+	ID     *mcp.RequestId `json:"id"`
+	Method string         `json:"method"`
+	Params struct {
+		URI string `json:"uri"`
+	} `json:"params"`
+}
+
+// HandleMessage answers the message if it is a subscription request of the session. It reports false for
+// any other message, which the transport then hands to the MCP server.
+func (s *ResourceSubscriptions) HandleMessage(ctx context.Context, sessionID string, message json.RawMessage) (mcp.JSONRPCMessage, bool) {
+	var request subscriptionRequest
+	if err := json.Unmarshal(message, &request); err != nil || request.ID == nil {
+		return nil, false
+	}
+
+	var err error
+	switch request.Method {
+	case methodResourcesSubscribe:
+		err = s.Subscribe(ctx, sessionID, request.Params.URI)
+	case methodResourcesUnsubscribe:
+		s.Unsubscribe(sessionID, request.Params.URI)
+	default:
+		return nil, false
+	}
+
+	if err != nil {
+		response := mcp.JSONRPCError{JSONRPC: mcp.JSONRPC_VERSION, ID: *request.ID}
+		response.Error.Code = mcp.INVALID_PARAMS
+		response.Error.Message = err.Error()
+		return response, true
+	}
+	return mcp.JSONRPCResponse{JSONRPC: mcp.JSONRPC_VERSION, ID: *request.ID, Result: mcp.EmptyResult{}}, true
+}
+
+// Subscribe subscribes a session to a resource. The resource is read once to check that it exists and to
+// know its current state, so that only later changes are notified.
+func (s *ResourceSubscriptions) Subscribe(ctx context.Context, sessionID, uri string) error {
+	m := subscribableResourcePattern.FindStringSubmatch(uri)
+	if m == nil {
+		return fmt.Errorf("subscriptions are only supported for issues and pull requests, such as repo://owner/repo/issues/1: %s", uri)
+	}
+	number, _ := strconv.Atoi(m[4])
+	subscription := &resourceSubscription{
+		owner:  m[1],
+		repo:   m[2],
+		kind:   m[3],
+		number: number,
+		ctx:    context.WithoutCancel(ctx),
+	}
+	updatedAt, err := s.updatedAt(subscription)
+	if err != nil {
+		return err
+	}
+	subscription.updatedAt = updatedAt
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subscriptions[sessionID] == nil {
+		s.subscriptions[sessionID] = make(map[string]*resourceSubscription)
+	}
+	s.subscriptions[sessionID][uri] = subscription
+	return nil
+}
+
+// Unsubscribe ends the subscription of a session to a resource, if any.
+func (s *ResourceSubscriptions) Unsubscribe(sessionID, uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscriptions[sessionID], uri)
+	if len(s.subscriptions[sessionID]) == 0 {
+		delete(s.subscriptions, sessionID)
+	}
+}
+
+// RemoveSession ends all subscriptions of a session.
+func (s *ResourceSubscriptions) RemoveSession(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscriptions, sessionID)
+}
+
+// Run polls the subscribed resources until ctx is done.
+func (s *ResourceSubscriptions) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Poll()
+		}
+	}
+}
+
+// Poll checks every subscribed resource once and notifies the sessions whose resources changed. Sessions
+// that are gone are dropped; resources that fail to load are tried again at the next poll.
+func (s *ResourceSubscriptions) Poll() {
+	type check struct {
+		sessionID    string
+		uri          string
+		subscription *resourceSubscription
+	}
+	var checks []check
+	s.mu.Lock()
+	for sessionID, subscriptions := range s.subscriptions {
+		for uri, subscription := range subscriptions {
+			checks = append(checks, check{sessionID, uri, subscription})
+		}
+	}
+	s.mu.Unlock()
+
+	for _, c := range checks {
+		updatedAt, err := s.updatedAt(c.subscription)
+		if err != nil || !updatedAt.After(c.subscription.updatedAt) {
+			continue
+		}
+		err = s.notify(c.sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": c.uri})
+		if errors.Is(err, server.ErrSessionNotFound) {
+			s.RemoveSession(c.sessionID)
+			continue
+		}
+		if err == nil {
+			s.mu.Lock()
+			c.subscription.updatedAt = updatedAt
+			s.mu.Unlock()
+		}
+	}
+}
+
+// updatedAt returns when the subscribed issue or pull request was last updated.
+func (s *ResourceSubscriptions) updatedAt(subscription *resourceSubscription) (time.Time, error) {
+	ctx := subscription.ctx
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+	if subscription.kind == "pulls" {
+		pr, resp, err := client.PullRequests.Get(ctx, subscription.owner, subscription.repo, subscription.number)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to get pull request: %w", err)
+		}
+		_ = resp.Body.Close()
+		return pr.GetUpdatedAt().Time, nil
+	}
+	issue, resp, err := client.Issues.Get(ctx, subscription.owner, subscription.repo, subscription.number)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get issue: %w", err)
+	}
+	_ = resp.Body.Close()
+	return issue.GetUpdatedAt().Time, nil
+}
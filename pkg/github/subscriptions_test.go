@@ -0,0 +1,117 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedNotification struct {
+	sessionID string
+	method    string
+	uri       any
+}
+
+func Test_ResourceSubscriptions(t *testing.T) {
+	var mu sync.Mutex
+	issueUpdatedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	prUpdatedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repos/owner/repo/issues/1" {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				_, _ = w.Write(mock.MustMarshal(&github.Issue{Number: github.Ptr(1), UpdatedAt: &github.Timestamp{Time: issueUpdatedAt}}))
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposPullsByOwnerByRepoByPullNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				_, _ = w.Write(mock.MustMarshal(&github.PullRequest{Number: github.Ptr(2), UpdatedAt: &github.Timestamp{Time: prUpdatedAt}}))
+			}),
+		),
+	))
+
+	var notifications []recordedNotification
+	notify := func(sessionID, method string, params map[string]any) error {
+		if sessionID == "gone" {
+			return server.ErrSessionNotFound
+		}
+		notifications = append(notifications, recordedNotification{sessionID, method, params["uri"]})
+		return nil
+	}
+	subscriptions := NewResourceSubscriptions(stubGetClientFn(client), notify, 0)
+	ctx := context.Background()
+
+	// Subscription requests are answered, other messages are left to the MCP server
+	response, ok := subscriptions.HandleMessage(ctx, "a", json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"repo://owner/repo/issues/1"}}`))
+	require.True(t, ok)
+	assert.Equal(t, mcp.JSONRPCResponse{JSONRPC: mcp.JSONRPC_VERSION, ID: mcp.NewRequestId(int64(1)), Result: mcp.EmptyResult{}}, response)
+	_, ok = subscriptions.HandleMessage(ctx, "a", json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"resources/read","params":{"uri":"repo://owner/repo/issues/1"}}`))
+	assert.False(t, ok)
+	_, ok = subscriptions.HandleMessage(ctx, "a", json.RawMessage(`{"jsonrpc":"2.0","method":"resources/subscribe","params":{"uri":"repo://owner/repo/issues/1"}}`))
+	assert.False(t, ok, "notifications are not requests")
+
+	response, ok = subscriptions.HandleMessage(ctx, "a", json.RawMessage(`{"jsonrpc":"2.0","id":3,"method":"resources/subscribe","params":{"uri":"repo://owner/repo/contents/README.md"}}`))
+	require.True(t, ok)
+	require.IsType(t, mcp.JSONRPCError{}, response)
+	assert.Equal(t, mcp.INVALID_PARAMS, response.(mcp.JSONRPCError).Error.Code)
+	assert.Contains(t, response.(mcp.JSONRPCError).Error.Message, "only supported for issues and pull requests")
+
+	err := subscriptions.Subscribe(ctx, "a", "repo://owner/repo/issues/404")
+	assert.ErrorContains(t, err, "failed to get issue")
+	require.NoError(t, subscriptions.Subscribe(ctx, "b", "repo://owner/repo/pulls/2"))
+	require.NoError(t, subscriptions.Subscribe(ctx, "gone", "repo://owner/repo/pulls/2"))
+
+	// Nothing changed since subscribing
+	subscriptions.Poll()
+	assert.Empty(t, notifications)
+
+	mu.Lock()
+	issueUpdatedAt = issueUpdatedAt.Add(time.Hour)
+	mu.Unlock()
+	subscriptions.Poll()
+	assert.Equal(t, []recordedNotification{{"a", mcp.MethodNotificationResourceUpdated, "repo://owner/repo/issues/1"}}, notifications)
+
+	// A change is notified once, and no more after unsubscribing
+	subscriptions.Poll()
+	assert.Len(t, notifications, 1)
+	response, ok = subscriptions.HandleMessage(ctx, "a", json.RawMessage(`{"jsonrpc":"2.0","id":4,"method":"resources/unsubscribe","params":{"uri":"repo://owner/repo/issues/1"}}`))
+	require.True(t, ok)
+	assert.IsType(t, mcp.JSONRPCResponse{}, response)
+	mu.Lock()
+	issueUpdatedAt = issueUpdatedAt.Add(time.Hour)
+	prUpdatedAt = prUpdatedAt.Add(time.Hour)
+	mu.Unlock()
+	subscriptions.Poll()
+	assert.Equal(t, []recordedNotification{
+		{"a", mcp.MethodNotificationResourceUpdated, "repo://owner/repo/issues/1"},
+		{"b", mcp.MethodNotificationResourceUpdated, "repo://owner/repo/pulls/2"},
+	}, notifications)
+
+	// Sessions that are gone were dropped
+	subscriptions.mu.Lock()
+	assert.NotContains(t, subscriptions.subscriptions, "gone")
+	assert.NotContains(t, subscriptions.subscriptions, "a")
+	subscriptions.mu.Unlock()
+	subscriptions.RemoveSession("b")
+	assert.Empty(t, subscriptions.subscriptions)
+}
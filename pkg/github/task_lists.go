@@ -0,0 +1,263 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// taskListItemPattern matches a Markdown task list item such as "- [ ] Write docs" or "1. [x] Ship it".
+var taskListItemPattern = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])(\]\s+)(.*)$`)
+
+// TaskListItem is a task list item of an issue body.
+type TaskListItem struct {
+	// Index numbers the items of the body from 1, in order
+	Index   int    `json:"index"`
+	Checked bool   `json:"checked"`
+	Text    string `json:"text"`
+
+	// Depth is how far the item is indented below other items, 0 for top level items
+	Depth int `json:"depth,omitempty"`
+}
+
+// TaskList is the output of the task list tools.
+type TaskList struct {
+	IssueNumber int            `json:"issue_number"`
+	Total       int            `json:"total"`
+	Completed   int            `json:"completed"`
+	Items       []TaskListItem `json:"items"`
+}
+
+// taskListLines calls fn for every task list item of a Markdown body, with the index of the line it is
+// on. Items in fenced code blocks are skipped, as GitHub doesn't render them as tasks.
+func taskListLines(lines []string, fn func(line int, match []string)) {
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		if match := taskListItemPattern.FindStringSubmatch(strings.TrimRight(line, "\r")); match != nil {
+			fn(i, match)
+		}
+	}
+}
+
+// parseTaskList returns the task list items of a Markdown body.
+func parseTaskList(issueNumber int, body string) TaskList {
+	result := TaskList{IssueNumber: issueNumber, Items: []TaskListItem{}}
+	var indents []int
+	taskListLines(strings.Split(body, "\n"), func(_ int, match []string) {
+		indent := len(match[1]) - len(strings.TrimLeft(match[1], " \t"))
+		for len(indents) > 0 && indents[len(indents)-1] >= indent {
+			indents = indents[:len(indents)-1]
+		}
+		item := TaskListItem{
+			Index:   len(result.Items) + 1,
+			Checked: match[2] != " ",
+			Text:    strings.TrimSpace(match[4]),
+			Depth:   len(indents),
+		}
+		indents = append(indents, indent)
+		result.Items = append(result.Items, item)
+		if item.Checked {
+			result.Completed++
+		}
+	})
+	result.Total = len(result.Items)
+	return result
+}
+
+// GetIssueTaskList creates a tool to list the task list items of an issue.
+func GetIssueTaskList(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_task_list",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_TASK_LIST_DESCRIPTION", "Get the Markdown task list items (- [ ] and - [x]) of an issue body, with their index, whether they are checked and their nesting depth, and how many are completed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ISSUE_TASK_LIST_USER_TITLE", "Get issue task list"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			issue, resp, err := client.Issues.Get(ctx, owner, repo, issueNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get issue", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(parseTaskList(issueNumber, issue.GetBody())), nil
+		}
+}
+
+// UpdateIssueTaskList creates a tool to check off, uncheck and add task list items of an issue.
+func UpdateIssueTaskList(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_issue_task_list",
+			mcp.WithDescription(t("TOOL_UPDATE_ISSUE_TASK_LIST_DESCRIPTION", "Check off or uncheck Markdown task list items of an issue body by their index from get_issue_task_list, and add new items at the end of the body. The rest of the body is left as it is.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_ISSUE_TASK_LIST_USER_TITLE", "Update issue task list"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number"),
+			),
+			mcp.WithArray("check",
+				mcp.Description("Indexes of the items to check off"),
+				mcp.Items(map[string]any{"type": "number"}),
+			),
+			mcp.WithArray("uncheck",
+				mcp.Description("Indexes of the items to uncheck"),
+				mcp.Items(map[string]any{"type": "number"}),
+			),
+			mcp.WithArray("add",
+				mcp.Description("Text of unchecked items to add at the end of the body"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			add, err := OptionalStringArrayParam(request, "add")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			marks := map[int]bool{}
+			for _, param := range []string{"check", "uncheck"} {
+				raw, _ := request.GetArguments()[param].([]any)
+				for _, v := range raw {
+					index, ok := v.(float64)
+					if !ok || index < 1 || index != float64(int(index)) {
+						return mcp.NewToolResultError(fmt.Sprintf("%s must be item indexes from 1, got %v", param, v)), nil
+					}
+					if _, seen := marks[int(index)]; seen {
+						return mcp.NewToolResultError(fmt.Sprintf("item %d is listed more than once", int(index))), nil
+					}
+					marks[int(index)] = param == "check"
+				}
+			}
+			if len(marks) == 0 && len(add) == 0 {
+				return mcp.NewToolResultError("nothing to change, give check, uncheck or add"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			issue, resp, err := client.Issues.Get(ctx, owner, repo, issueNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get issue", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			lines := strings.Split(issue.GetBody(), "\n")
+			index := 0
+			taskListLines(lines, func(line int, match []string) {
+				index++
+				checked, ok := marks[index]
+				if !ok {
+					return
+				}
+				mark := " "
+				if checked {
+					mark = "x"
+				}
+				suffix := ""
+				if strings.HasSuffix(lines[line], "\r") {
+					suffix = "\r"
+				}
+				lines[line] = match[1] + mark + match[3] + match[4] + suffix
+			})
+			for i := range marks {
+				if i > index {
+					return mcp.NewToolResultError(fmt.Sprintf("the issue has %d task list items, there is no item %d", index, i)), nil
+				}
+			}
+			body := strings.Join(lines, "\n")
+			if len(add) > 0 {
+				// New items continue a task list the body ends with, or start one after a blank line
+				newline := "\n"
+				if strings.Contains(body, "\r\n") {
+					newline = "\r\n"
+				}
+				body = strings.TrimRight(body, "\r\n")
+				lastLine := body[strings.LastIndex(body, "\n")+1:]
+				if body != "" && !taskListItemPattern.MatchString(lastLine) {
+					body += newline
+				}
+				for _, text := range add {
+					if body != "" {
+						body += newline
+					}
+					body += "- [ ] " + strings.TrimSpace(text)
+				}
+			}
+
+			updated, resp, err := client.Issues.Edit(ctx, owner, repo, issueNumber, &github.IssueRequest{Body: github.Ptr(body)})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update issue", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(parseTaskList(issueNumber, updated.GetBody())), nil
+		}
+}
@@ -0,0 +1,187 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const taskListBody = "## Plan\n\n- [x] Design the API\n- [ ] Implement it\n  - [X] Client\n  - [ ] Server\n1. [ ] Write docs\n\n```md\n- [ ] Not a task\n```\n\n* [] Not a task either"
+
+func Test_ParseTaskList(t *testing.T) {
+	assert.Equal(t, TaskList{
+		IssueNumber: 5,
+		Total:       5,
+		Completed:   2,
+		Items: []TaskListItem{
+			{Index: 1, Checked: true, Text: "Design the API"},
+			{Index: 2, Checked: false, Text: "Implement it"},
+			{Index: 3, Checked: true, Text: "Client", Depth: 1},
+			{Index: 4, Checked: false, Text: "Server", Depth: 1},
+			{Index: 5, Checked: false, Text: "Write docs"},
+		},
+	}, parseTaskList(5, taskListBody))
+
+	assert.Equal(t, TaskList{IssueNumber: 5, Items: []TaskListItem{}}, parseTaskList(5, "No tasks here"))
+}
+
+func Test_GetIssueTaskList(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIssueTaskList(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_issue_task_list", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			&github.Issue{Number: github.Ptr(5), Body: github.Ptr("- [x] One\n- [ ] Two")},
+		),
+	))
+	_, handler := GetIssueTaskList(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(5),
+	}))
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	assert.JSONEq(t, `{
+		"issue_number": 5,
+		"total": 2,
+		"completed": 1,
+		"items": [
+			{"index": 1, "checked": true, "text": "One"},
+			{"index": 2, "checked": false, "text": "Two"}
+		]
+	}`, textContent.Text)
+}
+
+func Test_UpdateIssueTaskList(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateIssueTaskList(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_issue_task_list", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "check")
+	assert.Contains(t, tool.InputSchema.Properties, "uncheck")
+	assert.Contains(t, tool.InputSchema.Properties, "add")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		body           string
+		requestArgs    map[string]any
+		expectedBody   string
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "checks and unchecks items",
+			body: taskListBody,
+			requestArgs: map[string]any{
+				"check":   []any{float64(2), float64(5)},
+				"uncheck": []any{float64(3)},
+			},
+			expectedBody: "## Plan\n\n- [x] Design the API\n- [x] Implement it\n  - [ ] Client\n  - [ ] Server\n1. [x] Write docs\n\n```md\n- [ ] Not a task\n```\n\n* [] Not a task either",
+		},
+		{
+			name: "adds items to the task list the body ends with",
+			body: "- [x] One\r\n- [ ] Two\r\n",
+			requestArgs: map[string]any{
+				"check": []any{float64(2)},
+				"add":   []any{"Three", " Four "},
+			},
+			expectedBody: "- [x] One\r\n- [x] Two\r\n- [ ] Three\r\n- [ ] Four",
+		},
+		{
+			name: "starts a task list after other text",
+			body: "Steps to take:",
+			requestArgs: map[string]any{
+				"add": []any{"One"},
+			},
+			expectedBody: "Steps to take:\n\n- [ ] One",
+		},
+		{
+			name: "item out of range",
+			body: "- [ ] One",
+			requestArgs: map[string]any{
+				"check": []any{float64(2)},
+			},
+			expectError:    true,
+			expectedErrMsg: "the issue has 1 task list items, there is no item 2",
+		},
+		{
+			name: "item checked and unchecked",
+			requestArgs: map[string]any{
+				"check":   []any{float64(1)},
+				"uncheck": []any{float64(1)},
+			},
+			expectError:    true,
+			expectedErrMsg: "item 1 is listed more than once",
+		},
+		{
+			name:           "nothing to change",
+			requestArgs:    map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "nothing to change, give check, uncheck or add",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					&github.Issue{Number: github.Ptr(5), Body: github.Ptr(tc.body)},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"body": tc.expectedBody}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(5), Body: github.Ptr(tc.expectedBody)}),
+					),
+				),
+			))
+			_, handler := UpdateIssueTaskList(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(5),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var taskList TaskList
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &taskList))
+			assert.Equal(t, parseTaskList(5, tc.expectedBody), taskList)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 
+	"github.com/github/github-mcp-server/pkg/graphql"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -16,7 +17,7 @@ type GetGQLClientFn func(context.Context) (*githubv4.Client, error)
 
 var DefaultTools = []string{"all"}
 
-func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) *toolsets.ToolsetGroup {
+func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, draftRules DraftRules, t translations.TranslationHelperFunc) *toolsets.ToolsetGroup {
 	tsg := toolsets.NewToolsetGroup(readOnly)
 
 	// Define all available features with their default state (disabled)
@@ -24,21 +25,46 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	repos := toolsets.NewToolset("repos", "GitHub Repository related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryOverview(getClient, t)),
 			toolsets.NewServerTool(GetFileContents(getClient, getRawClient, t)),
+			toolsets.NewServerTool(FetchSubtree(getClient, getRawClient, t)),
+			toolsets.NewServerTool(GetRepoTree(getClient, t)),
 			toolsets.NewServerTool(ListCommits(getClient, t)),
+			toolsets.NewServerTool(GetCommitGraph(getClient, t)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
+			toolsets.NewServerTool(SearchCommits(getClient, t)),
+			toolsets.NewServerTool(SearchTopics(getClient, t)),
 			toolsets.NewServerTool(GetCommit(getClient, t)),
+			toolsets.NewServerTool(CompareCommits(getClient, t)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
+			toolsets.NewServerTool(ListTagsWithReleases(getGQLClient, t)),
+			toolsets.NewServerTool(CompareForkWithUpstream(getClient, t)),
+			toolsets.NewServerTool(GetDefaultBranch(getClient, t)),
+			toolsets.NewServerTool(ListRuleSuites(getClient, t)),
+			toolsets.NewServerTool(GetRuleSuite(getClient, t)),
+			toolsets.NewServerTool(ListPushRuleBypassRequests(getClient, t)),
+			toolsets.NewServerTool(GetBypassRequest(getClient, t)),
+			toolsets.NewServerTool(ValidateCodeowners(getClient, t)),
+			toolsets.NewServerTool(GetCodeowners(getClient, t)),
+			toolsets.NewServerTool(ListAutolinks(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryLicense(getClient, t)),
+			toolsets.NewServerTool(CheckLicenseCompatibility(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
 			toolsets.NewServerTool(CreateRepository(getClient, t)),
 			toolsets.NewServerTool(ForkRepository(getClient, t)),
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
+			toolsets.NewServerTool(CreateTag(getClient, t)),
 			toolsets.NewServerTool(PushFiles(getClient, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
+			toolsets.NewServerTool(SyncForkBranch(getClient, t)),
+			toolsets.NewServerTool(SetDefaultBranch(getClient, t)),
+			toolsets.NewServerTool(ReviewBypassRequest(getClient, t)),
+			toolsets.NewServerTool(CreateAutolink(getClient, t)),
+			toolsets.NewServerTool(DeleteAutolink(getClient, t)),
 		).
 		AddResourceTemplates(
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceContent(getClient, getRawClient, t)),
@@ -54,19 +80,53 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(ListIssues(getClient, t)),
 			toolsets.NewServerTool(GetIssueComments(getClient, t)),
 			toolsets.NewServerTool(ListSubIssues(getClient, t)),
+			toolsets.NewServerTool(GetIssueTaskList(getClient, t)),
+			toolsets.NewServerTool(ListReactions(getClient, t)),
+			toolsets.NewServerTool(ValidateIssueDraft(getClient, draftRules, t)),
+			toolsets.NewServerTool(ListLabels(getClient, t)),
+			toolsets.NewServerTool(ListMilestones(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateIssue(getClient, t)),
 			toolsets.NewServerTool(AddIssueComment(getClient, t)),
 			toolsets.NewServerTool(UpdateIssue(getClient, t)),
+			toolsets.NewServerTool(BulkUpdateIssues(getClient, t)),
 			toolsets.NewServerTool(AssignCopilotToIssue(getGQLClient, t)),
 			toolsets.NewServerTool(AddSubIssue(getClient, t)),
 			toolsets.NewServerTool(RemoveSubIssue(getClient, t)),
 			toolsets.NewServerTool(ReprioritizeSubIssue(getClient, t)),
+			toolsets.NewServerTool(UpdateIssueTaskList(getClient, t)),
+			toolsets.NewServerTool(AddReaction(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(RemoveReaction(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(TransferIssue(getGQLClient, t)),
+			toolsets.NewServerTool(CloseIssueAsDuplicate(getClient, t)),
+			toolsets.NewServerTool(CreateLabel(getClient, t)),
+			toolsets.NewServerTool(UpdateLabel(getClient, t)),
+			toolsets.NewServerTool(DeleteLabel(getClient, t)),
+			toolsets.NewServerTool(CreateMilestone(getClient, t)),
+			toolsets.NewServerTool(UpdateMilestone(getClient, t)),
+			toolsets.NewServerTool(DeleteMilestone(getClient, t)),
 		).AddPrompts(
 		toolsets.NewServerPrompt(AssignCodingAgentPrompt(t)),
 		toolsets.NewServerPrompt(IssueToFixWorkflowPrompt(t)),
-	)
+		toolsets.NewServerPrompt(TriageIssuesPrompt(getClient, t)),
+		toolsets.NewServerPrompt(ReleaseNotesPrompt(getClient, t)),
+	).
+		AddResourceTemplates(
+			toolsets.NewServerResourceTemplate(GetIssueResource(getClient, t)),
+		)
+	// In read-only mode close_stale_issues is still offered, as a dry run that only reports the matches
+	if readOnly {
+		issues.AddReadTools(toolsets.NewServerTool(CloseStaleIssues(getClient, true, t)))
+	} else {
+		issues.AddWriteTools(toolsets.NewServerTool(CloseStaleIssues(getClient, false, t)))
+	}
+	// sync_labels works the same way, reporting what it would change
+	if readOnly {
+		issues.AddReadTools(toolsets.NewServerTool(SyncLabels(getClient, true, t)))
+	} else {
+		issues.AddWriteTools(toolsets.NewServerTool(SyncLabels(getClient, false, t)))
+	}
 	users := toolsets.NewToolset("users", "GitHub User related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchUsers(getClient, t)),
@@ -74,6 +134,16 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	orgs := toolsets.NewToolset("orgs", "GitHub Organization related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchOrgs(getClient, t)),
+			toolsets.NewServerTool(ListOrgMembers(getClient, t)),
+			toolsets.NewServerTool(GetOrgMembership(getClient, t)),
+			toolsets.NewServerTool(ListTeams(getClient, t)),
+			toolsets.NewServerTool(ListTeamMembers(getClient, t)),
+			toolsets.NewServerTool(ListTeamRepositories(getClient, t)),
+			toolsets.NewServerTool(ListRepositoryCollaborators(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddTeamMember(getClient, t)),
+			toolsets.NewServerTool(RemoveTeamMember(getClient, t)),
 		)
 	pullRequests := toolsets.NewToolset("pull_requests", "GitHub Pull Request related tools").
 		AddReadTools(
@@ -82,39 +152,75 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetPullRequestFiles(getClient, t)),
 			toolsets.NewServerTool(SearchPullRequests(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestStatus(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestRequiredChecksStatus(getClient, t)),
+			toolsets.NewServerTool(ValidatePullRequestDraft(getClient, draftRules, t)),
 			toolsets.NewServerTool(GetPullRequestComments(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestReviews(getClient, t)),
+			toolsets.NewServerTool(GetPendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(GetPullRequestDiff(getClient, t)),
+			toolsets.NewServerTool(SuggestReviewers(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(MergePullRequest(getClient, t)),
-			toolsets.NewServerTool(UpdatePullRequestBranch(getClient, t)),
+			toolsets.NewServerTool(UpdatePullRequestBranch(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(EnablePullRequestAutoMerge(getGQLClient, t)),
+			toolsets.NewServerTool(DisablePullRequestAutoMerge(getGQLClient, t)),
 			toolsets.NewServerTool(CreatePullRequest(getClient, t)),
 			toolsets.NewServerTool(UpdatePullRequest(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(RequestCopilotReview(getClient, t)),
+			toolsets.NewServerTool(RequestPullRequestReviewers(getClient, t)),
+			toolsets.NewServerTool(RemovePullRequestReviewers(getClient, t)),
 
 			// Reviews
 			toolsets.NewServerTool(CreateAndSubmitPullRequestReview(getGQLClient, t)),
+			toolsets.NewServerTool(SubmitPullRequestReview(getClient, t)),
 			toolsets.NewServerTool(CreatePendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(AddCommentToPendingReview(getGQLClient, t)),
 			toolsets.NewServerTool(SubmitPendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(DeletePendingPullRequestReview(getGQLClient, t)),
+		).
+		AddResourceTemplates(
+			toolsets.NewServerResourceTemplate(GetPullRequestResource(getClient, t)),
+		).
+		AddPrompts(
+			toolsets.NewServerPrompt(ReviewPullRequestPrompt(getClient, t)),
 		)
 	codeSecurity := toolsets.NewToolset("code_security", "Code security related tools, such as GitHub Code Scanning").
 		AddReadTools(
 			toolsets.NewServerTool(GetCodeScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListCodeScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(ListOrgCodeScanningAlerts(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(DismissCodeScanningAlert(getClient, t)),
 		)
 	secretProtection := toolsets.NewToolset("secret_protection", "Secret protection related tools, such as GitHub Secret Scanning").
 		AddReadTools(
 			toolsets.NewServerTool(GetSecretScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListSecretScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(ListOrgSecretScanningAlerts(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(ResolveSecretScanningAlert(getClient, t)),
 		)
 	dependabot := toolsets.NewToolset("dependabot", "Dependabot tools").
 		AddReadTools(
 			toolsets.NewServerTool(GetDependabotAlert(getClient, t)),
 			toolsets.NewServerTool(ListDependabotAlerts(getClient, t)),
+			toolsets.NewServerTool(GetDependabotConfig(getClient, t)),
+			toolsets.NewServerTool(ListOrgDependabotAlerts(getClient, t)),
+			toolsets.NewServerTool(ListDependabotPullRequests(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(DismissDependabotAlert(getClient, t)),
+			toolsets.NewServerTool(UpdateDependabotConfig(getClient, t)),
 		)
+	// In read-only mode bulk_dismiss_dependabot_alerts is still offered, as a dry run that only reports the matches
+	if readOnly {
+		dependabot.AddReadTools(toolsets.NewServerTool(BulkDismissDependabotAlerts(getClient, true, t)))
+	} else {
+		dependabot.AddWriteTools(toolsets.NewServerTool(BulkDismissDependabotAlerts(getClient, false, t)))
+	}
 
 	notifications := toolsets.NewToolset("notifications", "GitHub Notifications related tools").
 		AddReadTools(
@@ -134,6 +240,12 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetDiscussion(getGQLClient, t)),
 			toolsets.NewServerTool(GetDiscussionComments(getGQLClient, t)),
 			toolsets.NewServerTool(ListDiscussionCategories(getGQLClient, t)),
+			toolsets.NewServerTool(SearchDiscussions(getGQLClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateDiscussion(getGQLClient, t)),
+			toolsets.NewServerTool(AddDiscussionComment(getGQLClient, t)),
+			toolsets.NewServerTool(MarkDiscussionAnswer(getGQLClient, t)),
 		)
 
 	actions := toolsets.NewToolset("actions", "GitHub Actions workflows and CI/CD operations").
@@ -143,10 +255,15 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunLogs(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowJobs(getClient, t)),
+			toolsets.NewServerTool(GetWorkflowJob(getClient, t)),
 			toolsets.NewServerTool(GetJobLogs(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowRunArtifacts(getClient, t)),
 			toolsets.NewServerTool(DownloadWorkflowRunArtifact(getClient, t)),
+			toolsets.NewServerTool(GetLatestArtifact(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunUsage(getClient, t)),
+			toolsets.NewServerTool(GetActionsPermissions(getClient, t)),
+			toolsets.NewServerTool(GetDefaultWorkflowPermissions(getClient, t)),
+			toolsets.NewServerTool(ListDeploymentBranchPolicies(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(RunWorkflow(getClient, t)),
@@ -154,6 +271,11 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(RerunFailedJobs(getClient, t)),
 			toolsets.NewServerTool(CancelWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(DeleteWorkflowRunLogs(getClient, t)),
+			toolsets.NewServerTool(SetActionsPermissions(getClient, t)),
+			toolsets.NewServerTool(SetDefaultWorkflowPermissions(getClient, t)),
+			toolsets.NewServerTool(CreateDeploymentBranchPolicy(getClient, t)),
+			toolsets.NewServerTool(DeleteDeploymentBranchPolicy(getClient, t)),
+			toolsets.NewServerTool(SetDeploymentBranchPolicyMode(getClient, t)),
 		)
 
 	// Keep experiments alive so the system doesn't error out when it's always enabled
@@ -162,6 +284,7 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	contextTools := toolsets.NewToolset("context", "Tools that provide context about the current user and GitHub context you are operating in").
 		AddReadTools(
 			toolsets.NewServerTool(GetMe(getClient, t)),
+			toolsets.NewServerTool(GetRateLimit(getClient, t)),
 		)
 
 	gists := toolsets.NewToolset("gists", "GitHub Gist related tools").
@@ -173,6 +296,149 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(UpdateGist(getClient, t)),
 		)
 
+	projects := toolsets.NewToolset("projects", "GitHub Projects related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListProjects(getGQLClient, t)),
+			toolsets.NewServerTool(ListProjectFields(getGQLClient, t)),
+			toolsets.NewServerTool(ListProjectItems(getGQLClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddProjectItem(getGQLClient, t)),
+			toolsets.NewServerTool(UpdateProjectItemField(getGQLClient, t)),
+		)
+
+	releases := toolsets.NewToolset("releases", "GitHub Releases related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListReleases(getClient, t)),
+			toolsets.NewServerTool(GetRelease(getClient, t)),
+			toolsets.NewServerTool(GenerateReleaseNotes(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateRelease(getClient, t)),
+			toolsets.NewServerTool(UpdateRelease(getClient, t)),
+			toolsets.NewServerTool(UploadReleaseAsset(getClient, t)),
+			toolsets.NewServerTool(DeleteReleaseAsset(getClient, t)),
+		)
+
+	branchProtection := toolsets.NewToolset("branch_protection", "Branch protection rules and repository rulesets").
+		AddReadTools(
+			toolsets.NewServerTool(GetBranchProtection(getClient, t)),
+			toolsets.NewServerTool(ListRulesets(getClient, t)),
+			toolsets.NewServerTool(GetRuleset(getClient, t)),
+			toolsets.NewServerTool(ExplainPushBlock(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(UpdateBranchProtection(getClient, t)),
+			toolsets.NewServerTool(UpdateRuleset(getClient, t)),
+		)
+
+	checks := toolsets.NewToolset("checks", "Check runs and check suites reported by GitHub Apps on commits").
+		AddReadTools(
+			toolsets.NewServerTool(ListCheckRuns(getClient, t)),
+			toolsets.NewServerTool(GetCheckRunAnnotations(getClient, t)),
+			toolsets.NewServerTool(ListCheckSuites(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(RerequestCheckSuite(getClient, t)),
+		)
+
+	statuses := toolsets.NewToolset("statuses", "Commit statuses reported by external systems on commits").
+		AddReadTools(
+			toolsets.NewServerTool(GetCombinedStatus(getClient, t)),
+			toolsets.NewServerTool(ListCommitStatuses(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateCommitStatus(getClient, t)),
+		)
+
+	deployments := toolsets.NewToolset("deployments", "Deployments, deployment environments and their review gates").
+		AddReadTools(
+			toolsets.NewServerTool(ListEnvironments(getClient, t)),
+			toolsets.NewServerTool(ListDeployments(getClient, t)),
+			toolsets.NewServerTool(ListDeploymentStatuses(getClient, t)),
+			toolsets.NewServerTool(GetPendingDeployments(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateDeployment(getClient, t)),
+			toolsets.NewServerTool(CreateDeploymentStatus(getClient, t)),
+			toolsets.NewServerTool(ReviewPendingDeployments(getClient, t)),
+		)
+
+	packages := toolsets.NewToolset("packages", "GitHub Packages and container images in the GitHub Container registry").
+		AddReadTools(
+			toolsets.NewServerTool(ListPackages(getClient, t)),
+			toolsets.NewServerTool(ListPackageVersions(getClient, t)),
+			toolsets.NewServerTool(GetContainerManifest(t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(DeletePackageVersions(getClient, t)),
+		)
+
+	repositoryAdmin := toolsets.NewToolset("repository_admin", "Repository creation from templates, settings, archiving and transfer").
+		AddWriteTools(
+			toolsets.NewServerTool(CreateRepositoryFromTemplate(getClient, t)),
+			toolsets.NewServerTool(UpdateRepositorySettings(getClient, t)),
+			toolsets.NewServerTool(ArchiveRepository(getClient, t)),
+			toolsets.NewServerTool(TransferRepository(getClient, t)),
+		)
+
+	webhooks := toolsets.NewToolset("webhooks", "Repository and organization webhooks and their deliveries").
+		AddReadTools(
+			toolsets.NewServerTool(ListWebhooks(getClient, t)),
+			toolsets.NewServerTool(ListWebhookDeliveries(getClient, t)),
+			toolsets.NewServerTool(GetWebhookDelivery(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateWebhook(getClient, t)),
+			toolsets.NewServerTool(UpdateWebhook(getClient, t)),
+			toolsets.NewServerTool(PingWebhook(getClient, t)),
+			toolsets.NewServerTool(DeleteWebhook(getClient, t)),
+			toolsets.NewServerTool(RedeliverWebhookDelivery(getClient, t)),
+		)
+
+	insights := toolsets.NewToolset("insights", "Repository traffic, contributor and commit activity statistics").
+		AddReadTools(
+			toolsets.NewServerTool(GetRepositoryTraffic(getClient, t)),
+			toolsets.NewServerTool(GetContributorStats(getClient, t)),
+			toolsets.NewServerTool(GetCodeFrequency(getClient, t)),
+			toolsets.NewServerTool(GetPunchCard(getClient, t)),
+		)
+
+	codespaces := toolsets.NewToolset("codespaces", "Codespaces of the authenticated user: creation, start, stop and deletion").
+		AddReadTools(
+			toolsets.NewServerTool(ListCodespaces(getClient, t)),
+			toolsets.NewServerTool(ListCodespaceMachineTypes(getClient, t)),
+			toolsets.NewServerTool(GetCodespace(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateCodespace(getClient, t)),
+			toolsets.NewServerTool(StartCodespace(getClient, t)),
+			toolsets.NewServerTool(StopCodespace(getClient, t)),
+			toolsets.NewServerTool(DeleteCodespace(getClient, t)),
+		)
+
+	copilot := toolsets.NewToolset("copilot", "Copilot seat assignments and usage metrics of organizations").
+		AddReadTools(
+			toolsets.NewServerTool(ListCopilotSeats(getClient, t)),
+			toolsets.NewServerTool(GetCopilotMetrics(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddCopilotSeats(getClient, t)),
+			toolsets.NewServerTool(RemoveCopilotSeats(getClient, t)),
+		)
+
+	gitData := toolsets.NewToolset("git", "Low-level Git database: blobs, trees, commits and refs, for history the other tools can't write").
+		AddReadTools(
+			toolsets.NewServerTool(GetGitBlob(getClient, t)),
+			toolsets.NewServerTool(GetGitTree(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateGitBlob(getClient, t)),
+			toolsets.NewServerTool(CreateGitTree(getClient, t)),
+			toolsets.NewServerTool(CreateGitCommit(getClient, t)),
+			toolsets.NewServerTool(UpdateGitRef(getClient, t)),
+		)
+
 	// Add toolsets to the group
 	tsg.AddToolset(contextTools)
 	tsg.AddToolset(repos)
@@ -188,6 +454,22 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	tsg.AddToolset(experiments)
 	tsg.AddToolset(discussions)
 	tsg.AddToolset(gists)
+	tsg.AddToolset(projects)
+	tsg.AddToolset(releases)
+	tsg.AddToolset(branchProtection)
+	tsg.AddToolset(checks)
+	tsg.AddToolset(statuses)
+	tsg.AddToolset(deployments)
+	tsg.AddToolset(packages)
+	tsg.AddToolset(insights)
+	tsg.AddToolset(repositoryAdmin)
+	tsg.AddToolset(webhooks)
+	tsg.AddToolset(codespaces)
+	tsg.AddToolset(copilot)
+	tsg.AddToolset(gitData)
+
+	// Security teams triage alerts of all three kinds, so they can enable them together
+	tsg.AddAlias("security", "Code scanning, secret scanning and Dependabot alerts", codeSecurity.Name, secretProtection.Name, dependabot.Name)
 
 	return tsg
 }
@@ -198,7 +480,7 @@ func InitDynamicToolset(s *server.MCPServer, tsg *toolsets.ToolsetGroup, t trans
 	// Need to add the dynamic toolset last so it can be used to enable other toolsets
 	dynamicToolSelection := toolsets.NewToolset("dynamic", "Discover GitHub MCP tools that can help achieve tasks by enabling additional sets of tools, you can control the enablement of any toolset to access its tools when this toolset is enabled.").
 		AddReadTools(
-			toolsets.NewServerTool(ListAvailableToolsets(tsg, t)),
+			toolsets.NewServerTool(ListAvailableToolsets(tsg, true, t)),
 			toolsets.NewServerTool(GetToolsetsTools(tsg, t)),
 			toolsets.NewServerTool(EnableToolset(s, tsg, t)),
 		)
@@ -207,6 +489,46 @@ func InitDynamicToolset(s *server.MCPServer, tsg *toolsets.ToolsetGroup, t trans
 	return dynamicToolSelection
 }
 
+// AppToolset creates the toolset for inspecting the GitHub App the server is authenticated as.
+// getAppClient must authenticate as the app itself (JWT), so this is only registered when app credentials are configured.
+func AppToolset(getAppClient GetClientFn, t translations.TranslationHelperFunc) *toolsets.Toolset {
+	return toolsets.NewToolset("app", "Tools for inspecting the GitHub App this server is authenticated as, only available when app credentials are configured").
+		AddReadTools(
+			toolsets.NewServerTool(GetAppWebhookConfig(getAppClient, t)),
+			toolsets.NewServerTool(ListAppWebhookDeliveries(getAppClient, t)),
+			toolsets.NewServerTool(GetAppWebhookDelivery(getAppClient, t)),
+		)
+}
+
+// GraphQLToolset creates the toolset with the graphql_query tool, which is only registered when enabled in
+// configuration. The tool only becomes a write tool when mutations are allowed and the server isn't read-only.
+func GraphQLToolset(getGraphQLClient graphql.GetClientFn, readOnly bool, allowMutations bool, maxCost int, t translations.TranslationHelperFunc) *toolsets.Toolset {
+	toolset := toolsets.NewToolset("graphql", "Run arbitrary GraphQL queries validated against the GitHub GraphQL schema, only available when enabled in configuration")
+	if allowMutations && !readOnly {
+		return toolset.AddWriteTools(toolsets.NewServerTool(GraphQLQuery(getGraphQLClient, true, maxCost, t)))
+	}
+	return toolset.AddReadTools(toolsets.NewServerTool(GraphQLQuery(getGraphQLClient, false, maxCost, t)))
+}
+
+// APIToolset creates the toolset with the api_request tool, which is only registered when enabled in
+// configuration. In read-only mode the tool is a read tool that only sends GET requests.
+func APIToolset(getClient GetClientFn, readOnly bool, allowlist []APIAllowRule, t translations.TranslationHelperFunc) *toolsets.Toolset {
+	toolset := toolsets.NewToolset("api", "Send REST requests to the GitHub API for endpoints without a dedicated tool, restricted to an allowlist and only available when enabled in configuration")
+	if readOnly {
+		return toolset.AddReadTools(toolsets.NewServerTool(APIRequest(getClient, allowlist, true, t)))
+	}
+	return toolset.AddWriteTools(toolsets.NewServerTool(APIRequest(getClient, allowlist, false, t)))
+}
+
+// CheckRunWriteTools returns the tools that create and update check runs. Only GitHub Apps can write check runs,
+// so these are only added to the checks toolset when the server authenticates as an app installation.
+func CheckRunWriteTools(getClient GetClientFn, t translations.TranslationHelperFunc) []server.ServerTool {
+	return []server.ServerTool{
+		toolsets.NewServerTool(CreateCheckRun(getClient, t)),
+		toolsets.NewServerTool(UpdateCheckRun(getClient, t)),
+	}
+}
+
 // ToBoolPtr converts a bool to a *bool pointer.
 func ToBoolPtr(b bool) *bool {
 	return &b
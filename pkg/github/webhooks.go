@@ -0,0 +1,607 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// withWebhookScope adds the parameters that select the repository, or with repo omitted the organization,
+// whose webhooks a tool works on.
+func withWebhookScope() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner, or the organization for organization webhooks"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Description("Repository name, omit for the webhooks of the organization"),
+		)(tool)
+	}
+}
+
+// webhookScope reads the parameters of withWebhookScope.
+func webhookScope(request mcp.CallToolRequest) (owner, repo string, err error) {
+	owner, err = RequiredParam[string](request, "owner")
+	if err != nil {
+		return "", "", err
+	}
+	repo, err = OptionalParam[string](request, "repo")
+	if err != nil {
+		return "", "", err
+	}
+	return owner, repo, nil
+}
+
+// webhookScopeName names the repository or organization of a webhook in messages.
+func webhookScopeName(owner, repo string) string {
+	if repo == "" {
+		return "organization " + owner
+	}
+	return owner + "/" + repo
+}
+
+// webhookAPIErrorResponse points at the admin access the webhook endpoints of the scope require.
+func webhookAPIErrorResponse(ctx context.Context, repo, message string, resp *github.Response, err error) *mcp.CallToolResult {
+	if repo == "" {
+		return newOrgAdminAPIErrorResponse(ctx, message, resp, err)
+	}
+	return newAdminAPIErrorResponse(ctx, message, resp, err)
+}
+
+// requiredWebhookID reads the hook_id parameter.
+func requiredWebhookID(request mcp.CallToolRequest) (int64, error) {
+	id, err := RequiredInt(request, "hook_id")
+	if err != nil {
+		return 0, err
+	}
+	return int64(id), nil
+}
+
+// withWebhookConfig adds the parameters that configure a webhook.
+func withWebhookConfig(urlRequired bool) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		urlOptions := []mcp.PropertyOption{mcp.Description("URL the payloads are delivered to")}
+		if urlRequired {
+			urlOptions = append(urlOptions, mcp.Required())
+		}
+		mcp.WithString("url", urlOptions...)(tool)
+		mcp.WithString("content_type",
+			mcp.Description("Media type of the payloads, defaults to form on creation"),
+			mcp.Enum("json", "form"),
+		)(tool)
+		mcp.WithString("secret",
+			mcp.Description("Secret used to sign the payloads in the X-Hub-Signature-256 header"),
+		)(tool)
+		mcp.WithBoolean("insecure_ssl",
+			mcp.Description("Skip verifying the TLS certificate of the URL. Exposes the payloads to interception"),
+		)(tool)
+		mcp.WithArray("events",
+			mcp.Description("Events that trigger the webhook, e.g. push or pull_request, or * for all events. Defaults to push on creation"),
+			mcp.Items(map[string]any{"type": "string"}),
+		)(tool)
+		mcp.WithBoolean("active",
+			mcp.Description("Whether deliveries are sent, defaults to true on creation"),
+		)(tool)
+	}
+}
+
+// webhookConfigFrom reads the configuration parameters of withWebhookConfig. A nil config means none of them
+// was given.
+func webhookConfigFrom(request mcp.CallToolRequest) (*github.HookConfig, error) {
+	var config *github.HookConfig
+	ensure := func() *github.HookConfig {
+		if config == nil {
+			config = &github.HookConfig{}
+		}
+		return config
+	}
+	for _, field := range []string{"url", "content_type", "secret"} {
+		value, ok, err := OptionalParamOK[string](request, field)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || (value == "" && field != "secret") {
+			continue
+		}
+		switch field {
+		case "url":
+			ensure().URL = github.Ptr(value)
+		case "content_type":
+			ensure().ContentType = github.Ptr(value)
+		case "secret":
+			// An empty secret removes it
+			ensure().Secret = github.Ptr(value)
+		}
+	}
+	insecure, ok, err := OptionalParamOK[bool](request, "insecure_ssl")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		ensure().InsecureSSL = github.Ptr("0")
+		if insecure {
+			config.InsecureSSL = github.Ptr("1")
+		}
+	}
+	return config, nil
+}
+
+// ListWebhooks creates a tool to list the webhooks of a repository or organization.
+func ListWebhooks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_webhooks",
+			mcp.WithDescription(t("TOOL_LIST_WEBHOOKS_DESCRIPTION", "List the webhooks of a repository, or of an organization when repo is omitted, with their URL, events, and the status of their last delivery. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WEBHOOKS_USER_TITLE", "List webhooks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withWebhookScope(),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, err := webhookScope(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			}
+			var hooks []*github.Hook
+			var resp *github.Response
+			if repo == "" {
+				hooks, resp, err = client.Organizations.ListHooks(ctx, owner, opts)
+			} else {
+				hooks, resp, err = client.Repositories.ListHooks(ctx, owner, repo, opts)
+			}
+			if err != nil {
+				return webhookAPIErrorResponse(ctx, repo, fmt.Sprintf("failed to list webhooks of %s", webhookScopeName(owner, repo)), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(hooks), nil
+		}
+}
+
+// CreateWebhook creates a tool to create a webhook on a repository or organization.
+func CreateWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_webhook",
+			mcp.WithDescription(t("TOOL_CREATE_WEBHOOK_DESCRIPTION", "Create a webhook on a repository, or on an organization when repo is omitted. GitHub sends a ping event to it right away. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_WEBHOOK_USER_TITLE", "Create webhook"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withWebhookScope(),
+			withWebhookConfig(true),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, err := webhookScope(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, err := RequiredParam[string](request, "url"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			config, err := webhookConfigFrom(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			events, err := OptionalStringArrayParam(request, "events")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			active, activeSet, err := OptionalParamOK[bool](request, "active")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			hook := &github.Hook{
+				Config: config,
+				Events: events,
+			}
+			if activeSet {
+				hook.Active = github.Ptr(active)
+			}
+			var created *github.Hook
+			var resp *github.Response
+			if repo == "" {
+				created, resp, err = client.Organizations.CreateHook(ctx, owner, hook)
+			} else {
+				created, resp, err = client.Repositories.CreateHook(ctx, owner, repo, hook)
+			}
+			if err != nil {
+				return webhookAPIErrorResponse(ctx, repo, fmt.Sprintf("failed to create webhook on %s", webhookScopeName(owner, repo)), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(created), nil
+		}
+}
+
+// UpdateWebhook creates a tool to change the configuration, events or state of a webhook.
+func UpdateWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_webhook",
+			mcp.WithDescription(t("TOOL_UPDATE_WEBHOOK_DESCRIPTION", "Change the URL, content type, secret, events or active state of a repository or organization webhook. Only the given settings change, the secret in particular is kept unless a new one is given. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_WEBHOOK_USER_TITLE", "Update webhook"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withWebhookScope(),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("ID of the webhook"),
+			),
+			withWebhookConfig(false),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, err := webhookScope(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := requiredWebhookID(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			config, err := webhookConfigFrom(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, eventsSet := request.GetArguments()["events"]
+			events, err := OptionalStringArrayParam(request, "events")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			active, activeSet, err := OptionalParamOK[bool](request, "active")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if config == nil && !eventsSet && !activeSet {
+				return mcp.NewToolResultError("no settings to change were given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			scope := webhookScopeName(owner, repo)
+			// The configuration is updated on its own, as replacing it through the webhook drops the keys that
+			// are not given, such as the secret
+			if config != nil {
+				var resp *github.Response
+				if repo == "" {
+					_, resp, err = client.Organizations.EditHookConfiguration(ctx, owner, hookID, config)
+				} else {
+					_, resp, err = client.Repositories.EditHookConfiguration(ctx, owner, repo, hookID, config)
+				}
+				if err != nil {
+					return webhookAPIErrorResponse(ctx, repo, fmt.Sprintf("failed to update configuration of webhook %d of %s", hookID, scope), resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			var hook *github.Hook
+			var resp *github.Response
+			if eventsSet || activeSet {
+				edit := &github.Hook{}
+				if eventsSet {
+					edit.Events = events
+				}
+				if activeSet {
+					edit.Active = github.Ptr(active)
+				}
+				if repo == "" {
+					hook, resp, err = client.Organizations.EditHook(ctx, owner, hookID, edit)
+				} else {
+					hook, resp, err = client.Repositories.EditHook(ctx, owner, repo, hookID, edit)
+				}
+			} else if repo == "" {
+				hook, resp, err = client.Organizations.GetHook(ctx, owner, hookID)
+			} else {
+				hook, resp, err = client.Repositories.GetHook(ctx, owner, repo, hookID)
+			}
+			if err != nil {
+				return webhookAPIErrorResponse(ctx, repo, fmt.Sprintf("failed to update webhook %d of %s", hookID, scope), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(hook), nil
+		}
+}
+
+// PingWebhook creates a tool to send a ping event to a webhook.
+func PingWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("ping_webhook",
+			mcp.WithDescription(t("TOOL_PING_WEBHOOK_DESCRIPTION", "Send a ping event to a repository or organization webhook to check that its URL is reachable. The outcome shows up in list_webhook_deliveries. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PING_WEBHOOK_USER_TITLE", "Ping webhook"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withWebhookScope(),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("ID of the webhook"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, err := webhookScope(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := requiredWebhookID(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			if repo == "" {
+				resp, err = client.Organizations.PingHook(ctx, owner, hookID)
+			} else {
+				resp, err = client.Repositories.PingHook(ctx, owner, repo, hookID)
+			}
+			if err != nil {
+				return webhookAPIErrorResponse(ctx, repo, fmt.Sprintf("failed to ping webhook %d of %s", hookID, webhookScopeName(owner, repo)), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("sent a ping event to webhook %d", hookID)), nil
+		}
+}
+
+// DeleteWebhook creates a tool to delete a webhook.
+func DeleteWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_webhook",
+			mcp.WithDescription(t("TOOL_DELETE_WEBHOOK_DESCRIPTION", "Delete a repository or organization webhook along with its delivery history. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_WEBHOOK_USER_TITLE", "Delete webhook"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			withWebhookScope(),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("ID of the webhook"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, err := webhookScope(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := requiredWebhookID(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			if repo == "" {
+				resp, err = client.Organizations.DeleteHook(ctx, owner, hookID)
+			} else {
+				resp, err = client.Repositories.DeleteHook(ctx, owner, repo, hookID)
+			}
+			if err != nil {
+				return webhookAPIErrorResponse(ctx, repo, fmt.Sprintf("failed to delete webhook %d of %s", hookID, webhookScopeName(owner, repo)), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("deleted webhook %d", hookID)), nil
+		}
+}
+
+// ListWebhookDeliveries creates a tool to list the recent deliveries of a webhook.
+func ListWebhookDeliveries(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_webhook_deliveries",
+			mcp.WithDescription(t("TOOL_LIST_WEBHOOK_DELIVERIES_DESCRIPTION", "List the deliveries of a repository or organization webhook from the last 3 days, newest first, with their event, HTTP status code and duration. Use failed_only to find deliveries that did not get a successful response, and get_webhook_delivery for the request and response of a delivery. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WEBHOOK_DELIVERIES_USER_TITLE", "List webhook deliveries"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withWebhookScope(),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("ID of the webhook"),
+			),
+			mcp.WithBoolean("failed_only",
+				mcp.Description("Only return deliveries that failed or did not get a 2xx response"),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Results per page for pagination (min 1, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+			mcp.WithString("cursor",
+				mcp.Description("Cursor for the next page, as returned in next_cursor"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, err := webhookScope(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := requiredWebhookID(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			perPage, err := OptionalIntParamWithDefault(request, "perPage", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			cursor, err := OptionalParam[string](request, "cursor")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			failedOnly, err := OptionalParam[bool](request, "failed_only")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListCursorOptions{
+				PerPage: perPage,
+				Cursor:  cursor,
+			}
+			var deliveries []*github.HookDelivery
+			var resp *github.Response
+			if repo == "" {
+				deliveries, resp, err = client.Organizations.ListHookDeliveries(ctx, owner, hookID, opts)
+			} else {
+				deliveries, resp, err = client.Repositories.ListHookDeliveries(ctx, owner, repo, hookID, opts)
+			}
+			if err != nil {
+				return webhookAPIErrorResponse(ctx, repo, fmt.Sprintf("failed to list deliveries of webhook %d of %s", hookID, webhookScopeName(owner, repo)), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if failedOnly {
+				deliveries = failedDeliveries(deliveries)
+			}
+			result := map[string]any{
+				"deliveries": deliveries,
+			}
+			if resp.Cursor != "" {
+				result["next_cursor"] = resp.Cursor
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// GetWebhookDelivery creates a tool to get the request and response of a webhook delivery.
+func GetWebhookDelivery(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_webhook_delivery",
+			mcp.WithDescription(t("TOOL_GET_WEBHOOK_DELIVERY_DESCRIPTION", "Get a delivery of a repository or organization webhook with the headers and payload of the request GitHub sent and the headers and body of the response it received. Signature headers are masked and large payloads are truncated. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WEBHOOK_DELIVERY_USER_TITLE", "Get webhook delivery"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withWebhookScope(),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("ID of the webhook"),
+			),
+			mcp.WithNumber("delivery_id",
+				mcp.Required(),
+				mcp.Description("ID of the delivery"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, err := webhookScope(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := requiredWebhookID(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deliveryID, err := RequiredInt(request, "delivery_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var delivery *github.HookDelivery
+			var resp *github.Response
+			if repo == "" {
+				delivery, resp, err = client.Organizations.GetHookDelivery(ctx, owner, hookID, int64(deliveryID))
+			} else {
+				delivery, resp, err = client.Repositories.GetHookDelivery(ctx, owner, repo, hookID, int64(deliveryID))
+			}
+			if err != nil {
+				return webhookAPIErrorResponse(ctx, repo, fmt.Sprintf("failed to get delivery %d of webhook %d", deliveryID, hookID), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(webhookDeliveryDetails(delivery)), nil
+		}
+}
+
+// RedeliverWebhookDelivery creates a tool to send a webhook delivery again.
+func RedeliverWebhookDelivery(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("redeliver_webhook_delivery",
+			mcp.WithDescription(t("TOOL_REDELIVER_WEBHOOK_DELIVERY_DESCRIPTION", "Send the payload of a past delivery of a repository or organization webhook again, for instance once the receiving service is fixed. The new attempt shows up in list_webhook_deliveries as a redelivery. Requires admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REDELIVER_WEBHOOK_DELIVERY_USER_TITLE", "Redeliver webhook delivery"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withWebhookScope(),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("ID of the webhook"),
+			),
+			mcp.WithNumber("delivery_id",
+				mcp.Required(),
+				mcp.Description("ID of the delivery to send again"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, err := webhookScope(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := requiredWebhookID(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deliveryID, err := RequiredInt(request, "delivery_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			if repo == "" {
+				_, resp, err = client.Organizations.RedeliverHookDelivery(ctx, owner, hookID, int64(deliveryID))
+			} else {
+				_, resp, err = client.Repositories.RedeliverHookDelivery(ctx, owner, repo, hookID, int64(deliveryID))
+			}
+			// GitHub answers 202 Accepted as the delivery is queued
+			if err != nil && !isAcceptedError(err) {
+				return webhookAPIErrorResponse(ctx, repo, fmt.Sprintf("failed to redeliver delivery %d of webhook %d", deliveryID, hookID), resp, err), nil
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("queued delivery %d of webhook %d for redelivery", deliveryID, hookID)), nil
+		}
+}
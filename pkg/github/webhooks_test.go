@@ -0,0 +1,558 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListWebhooks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWebhooks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_webhooks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	hooks := []*github.Hook{
+		{ID: github.Ptr(int64(1)), Events: []string{"push"}, Active: github.Ptr(true)},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "repository webhooks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposHooksByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, hooks),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+		},
+		{
+			name: "organization webhooks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsHooksByOrg,
+					expectPath(t, "/orgs/octo-org/hooks").andThen(
+						mockResponse(t, http.StatusOK, hooks),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+			},
+		},
+		{
+			name: "no admin access to the organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsHooksByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "requires admin access to the organization",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWebhooks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned []*github.Hook
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, int64(1), returned[0].GetID())
+		})
+	}
+}
+
+func Test_CreateWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_webhook", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "url"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "repository webhook",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposHooksByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"config": map[string]any{
+							"url":          "https://example.com/hook",
+							"content_type": "json",
+							"secret":       "s3cret",
+							"insecure_ssl": "0",
+						},
+						"name":   "web",
+						"events": []any{"push", "pull_request"},
+						"active": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Hook{ID: github.Ptr(int64(7))}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"repo":         "repo",
+				"content_type": "json",
+				"secret":       "s3cret",
+				"insecure_ssl": false,
+				"events":       []interface{}{"push", "pull_request"},
+				"active":       true,
+			},
+		},
+		{
+			name: "organization webhook",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsHooksByOrg,
+					expectRequestBody(t, map[string]any{
+						"name": "web",
+						"config": map[string]any{
+							"url": "https://example.com/hook",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Hook{ID: github.Ptr(int64(7))}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{},
+		},
+		{
+			name: "validation failure",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposHooksByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"repo": "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create webhook on owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner": "owner",
+				"url":   "https://example.com/hook",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.Hook
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, int64(7), returned.GetID())
+		})
+	}
+}
+
+func Test_UpdateWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_webhook", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id"})
+
+	hook := &github.Hook{ID: github.Ptr(int64(7)), Active: github.Ptr(false)}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "configuration and events",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposHooksConfigByOwnerByRepoByHookId,
+					expectRequestBody(t, map[string]any{
+						"url": "https://example.com/new",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.HookConfig{URL: github.Ptr("https://example.com/new")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposHooksByOwnerByRepoByHookId,
+					expectRequestBody(t, map[string]any{
+						"events": []any{"release"},
+						"active": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, hook),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"repo":   "repo",
+				"url":    "https://example.com/new",
+				"events": []interface{}{"release"},
+				"active": false,
+			},
+		},
+		{
+			name: "organization configuration only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchOrgsHooksConfigByOrgByHookId,
+					expectRequestBody(t, map[string]any{
+						"insecure_ssl": "1",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.HookConfig{}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsHooksByOrgByHookId,
+					expectPath(t, "/orgs/owner/hooks/7").andThen(
+						mockResponse(t, http.StatusOK, hook),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"insecure_ssl": true,
+			},
+		},
+		{
+			name:           "nothing to change",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "no settings to change were given",
+		},
+		{
+			name: "webhook not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposHooksByOwnerByRepoByHookId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"repo":   "repo",
+				"active": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update webhook 7 of owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner":   "owner",
+				"hook_id": float64(7),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.Hook
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, int64(7), returned.GetID())
+		})
+	}
+}
+
+func Test_PingWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := PingWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "ping_webhook", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposHooksPingsByOwnerByRepoByHookId,
+			expectPath(t, "/repos/owner/repo/hooks/7/pings").andThen(
+				mockResponse(t, http.StatusNoContent, ""),
+			),
+		),
+	))
+	_, handler := PingWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":   "owner",
+		"repo":    "repo",
+		"hook_id": float64(7),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "sent a ping event to webhook 7", getTextResult(t, result).Text)
+}
+
+func Test_DeleteWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_webhook", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteOrgsHooksByOrgByHookId,
+			expectPath(t, "/orgs/octo-org/hooks/7").andThen(
+				mockResponse(t, http.StatusNoContent, ""),
+			),
+		),
+	))
+	_, handler := DeleteWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":   "octo-org",
+		"hook_id": float64(7),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "deleted webhook 7", getTextResult(t, result).Text)
+}
+
+func Test_ListWebhookDeliveries(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWebhookDeliveries(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_webhook_deliveries", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposHooksDeliveriesByOwnerByRepoByHookId,
+			expectQueryParams(t, map[string]string{
+				"per_page": "10",
+				"cursor":   "v1_abc",
+			}).andThen(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/hooks/7/deliveries?cursor=v1_def&per_page=10>; rel="next"`)
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write(mock.MustMarshal([]*github.HookDelivery{
+						{ID: github.Ptr(int64(11)), Event: github.Ptr("push"), StatusCode: github.Ptr(500)},
+						{ID: github.Ptr(int64(12)), Event: github.Ptr("push"), StatusCode: github.Ptr(200)},
+					}))
+				}),
+			),
+		),
+	))
+	_, handler := ListWebhookDeliveries(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":       "owner",
+		"repo":        "repo",
+		"hook_id":     float64(7),
+		"perPage":     float64(10),
+		"cursor":      "v1_abc",
+		"failed_only": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned struct {
+		Deliveries []*github.HookDelivery `json:"deliveries"`
+		NextCursor string                 `json:"next_cursor"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned.Deliveries, 1)
+	assert.Equal(t, 500, returned.Deliveries[0].GetStatusCode())
+	assert.Equal(t, "v1_def", returned.NextCursor)
+}
+
+func Test_GetWebhookDelivery(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWebhookDelivery(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_webhook_delivery", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id", "delivery_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsHooksDeliveriesByOrgByHookIdByDeliveryId,
+			expectPath(t, "/orgs/octo-org/hooks/7/deliveries/11").andThen(
+				mockResponse(t, http.StatusOK, &github.HookDelivery{
+					ID: github.Ptr(int64(11)),
+					Request: &github.HookRequest{
+						Headers: map[string]string{"X-Hub-Signature-256": "sha256=abc"},
+					},
+					Response: &github.HookResponse{RawPayload: github.Ptr(json.RawMessage(`"internal error"`))},
+				}),
+			),
+		),
+	))
+	_, handler := GetWebhookDelivery(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":       "octo-org",
+		"hook_id":     float64(7),
+		"delivery_id": float64(11),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned struct {
+		ID       int64            `json:"id"`
+		Request  *webhookDelivery `json:"request"`
+		Response *webhookDelivery `json:"response"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, int64(11), returned.ID)
+	assert.NotContains(t, returned.Request.Headers["X-Hub-Signature-256"], "abc")
+	assert.Equal(t, `"internal error"`, returned.Response.Payload)
+}
+
+func Test_RedeliverWebhookDelivery(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RedeliverWebhookDelivery(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "redeliver_webhook_delivery", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id", "delivery_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "redelivery queued",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposHooksDeliveriesAttemptsByOwnerByRepoByHookIdByDeliveryId,
+					expectPath(t, "/repos/owner/repo/hooks/7/deliveries/11/attempts").andThen(
+						mockResponse(t, http.StatusAccepted, `{}`),
+					),
+				),
+			),
+		},
+		{
+			name: "delivery not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposHooksDeliveriesAttemptsByOwnerByRepoByHookIdByDeliveryId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to redeliver delivery 11 of webhook 7",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RedeliverWebhookDelivery(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"hook_id":     float64(7),
+				"delivery_id": float64(11),
+			}))
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "queued delivery 11 of webhook 7 for redelivery", getTextResult(t, result).Text)
+		})
+	}
+}
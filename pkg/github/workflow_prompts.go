@@ -3,8 +3,11 @@ package github
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -75,3 +78,317 @@ func IssueToFixWorkflowPrompt(t translations.TranslationHelperFunc) (tool mcp.Pr
 			}, nil
 		}
 }
+
+const (
+	// maxPromptFiles and maxPromptIssues bound how much context the workflow prompts fetch, so that they
+	// stay within a client's context window. The tools they point to can fetch the rest.
+	maxPromptFiles  = 100
+	maxPromptIssues = 50
+)
+
+// promptArgument returns a prompt argument, or an error naming it when it is required and missing.
+func promptArgument(request mcp.GetPromptRequest, name string, required bool) (string, error) {
+	value := strings.TrimSpace(request.Params.Arguments[name])
+	if value == "" && required {
+		return "", fmt.Errorf("missing required argument: %s", name)
+	}
+	return value, nil
+}
+
+// ReviewPullRequestPrompt provides a workflow to review a pull request, starting from its description and
+// changed files.
+func ReviewPullRequestPrompt(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("ReviewPullRequest",
+			mcp.WithPromptDescription(t("PROMPT_REVIEW_PULL_REQUEST_DESCRIPTION", "Review a pull request: its description and changed files are fetched up front, and the review is left as a pending review with line comments")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription("Repository owner"), mcp.RequiredArgument()),
+			mcp.WithArgument("repo", mcp.ArgumentDescription("Repository name"), mcp.RequiredArgument()),
+			mcp.WithArgument("pullNumber", mcp.ArgumentDescription("Pull request number"), mcp.RequiredArgument()),
+			mcp.WithArgument("focus", mcp.ArgumentDescription("What to pay most attention to, such as security or performance (optional)")),
+		), func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner, err := promptArgument(request, "owner", true)
+			if err != nil {
+				return nil, err
+			}
+			repo, err := promptArgument(request, "repo", true)
+			if err != nil {
+				return nil, err
+			}
+			number, err := promptArgument(request, "pullNumber", true)
+			if err != nil {
+				return nil, err
+			}
+			pullNumber, err := strconv.Atoi(strings.TrimPrefix(number, "#"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid pull request number: %s", number)
+			}
+			focus, _ := promptArgument(request, "focus", false)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			_ = resp.Body.Close()
+			files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, &github.ListOptions{PerPage: maxPromptFiles})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request files: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			var b strings.Builder
+			fmt.Fprintf(&b, "Please review pull request #%d in %s/%s.\n\n", pullNumber, owner, repo)
+			fmt.Fprintf(&b, "Title: %s\nAuthor: %s\nBranches: %s into %s\nState: %s", pr.GetTitle(), pr.GetUser().GetLogin(), pr.GetHead().GetLabel(), pr.GetBase().GetRef(), pr.GetState())
+			if pr.GetDraft() {
+				b.WriteString(" (draft)")
+			}
+			fmt.Fprintf(&b, "\nChanges: %d files, +%d -%d\n\n", pr.GetChangedFiles(), pr.GetAdditions(), pr.GetDeletions())
+			if body := strings.TrimSpace(pr.GetBody()); body != "" {
+				fmt.Fprintf(&b, "Description:\n%s\n\n", body)
+			}
+			b.WriteString("Changed files:\n")
+			for _, f := range files {
+				fmt.Fprintf(&b, "- %s (%s, +%d -%d)\n", f.GetFilename(), f.GetStatus(), f.GetAdditions(), f.GetDeletions())
+			}
+			if omitted := pr.GetChangedFiles() - len(files); omitted > 0 {
+				fmt.Fprintf(&b, "- and %d more files, list them with get_pull_request_files\n", omitted)
+			}
+			if focus != "" {
+				fmt.Fprintf(&b, "\nPay particular attention to: %s\n", focus)
+			}
+
+			messages := []mcp.PromptMessage{
+				{
+					Role:    "system",
+					Content: mcp.NewTextContent("You are a careful code reviewer. Read the changes with `get_pull_request_diff` and, where you need more context, the files themselves with `get_file_contents` at the head of the pull request. Look for bugs, missing tests, unclear code and changes that don't match the description. Leave your feedback as a review: start one with `create_pending_pull_request_review`, add line comments with `add_comment_to_pending_review` and finish with `submit_pending_pull_request_review`. Only approve or request changes when the user asks you to, comment otherwise."),
+				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent(b.String()),
+				},
+				{
+					Role:    "assistant",
+					Content: mcp.NewTextContent(fmt.Sprintf("I'll start by reading the diff of #%d, then go through the changed files and collect my comments in a pending review.", pullNumber)),
+				},
+			}
+			return &mcp.GetPromptResult{
+				Description: fmt.Sprintf("Review %s/%s#%d", owner, repo, pullNumber),
+				Messages:    messages,
+			}, nil
+		}
+}
+
+// TriageIssuesPrompt provides a workflow to triage the open issues of a repository that have no labels,
+// starting from the issues and the labels the repository has.
+func TriageIssuesPrompt(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("TriageIssues",
+			mcp.WithPromptDescription(t("PROMPT_TRIAGE_ISSUES_DESCRIPTION", "Triage new issues: the open issues without labels and the labels of the repository are fetched up front, and labels are proposed for each issue")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription("Repository owner"), mcp.RequiredArgument()),
+			mcp.WithArgument("repo", mcp.ArgumentDescription("Repository name"), mcp.RequiredArgument()),
+			mcp.WithArgument("since", mcp.ArgumentDescription("Only triage issues created or updated after this date, in YYYY-MM-DD format (optional)")),
+		), func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner, err := promptArgument(request, "owner", true)
+			if err != nil {
+				return nil, err
+			}
+			repo, err := promptArgument(request, "repo", true)
+			if err != nil {
+				return nil, err
+			}
+			since, _ := promptArgument(request, "since", false)
+			opts := &github.IssueListByRepoOptions{
+				State:       "open",
+				Sort:        "created",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			if since != "" {
+				if opts.Since, err = parseISOTimestamp(since); err != nil {
+					return nil, err
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list issues: %w", err)
+			}
+			_ = resp.Body.Close()
+			labels, resp, err := client.Issues.ListLabels(ctx, owner, repo, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list labels: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			var b strings.Builder
+			fmt.Fprintf(&b, "Please triage the new issues of %s/%s.\n\nLabels of the repository:\n", owner, repo)
+			for _, l := range labels {
+				fmt.Fprintf(&b, "- %s", l.GetName())
+				if d := l.GetDescription(); d != "" {
+					fmt.Fprintf(&b, ": %s", d)
+				}
+				b.WriteString("\n")
+			}
+			b.WriteString("\nOpen issues without labels:\n")
+			listed := 0
+			for _, issue := range issues {
+				// The issues API lists pull requests too
+				if issue.IsPullRequest() || len(issue.Labels) > 0 {
+					continue
+				}
+				if listed == maxPromptIssues {
+					b.WriteString("- and more, find them with list_issues\n")
+					break
+				}
+				listed++
+				fmt.Fprintf(&b, "- #%d %s (by %s, %d comments)\n", issue.GetNumber(), issue.GetTitle(), issue.GetUser().GetLogin(), issue.GetComments())
+			}
+			if listed == 0 {
+				b.WriteString("- none\n")
+			}
+
+			messages := []mcp.PromptMessage{
+				{
+					Role:    "system",
+					Content: mcp.NewTextContent("You are triaging GitHub issues. For each issue, read it with `get_issue` and, if needed, `get_issue_comments`, then decide which of the existing labels apply, whether it duplicates another issue (use `search_issues` to check) and whether it needs more information from its author. Don't create new labels. Present your proposals as a table first; only apply them with `update_issue` or `bulk_update_issues`, comment with `add_issue_comment` or close duplicates with `close_issue_as_duplicate` after the user confirms."),
+				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent(b.String()),
+				},
+				{
+					Role:    "assistant",
+					Content: mcp.NewTextContent(fmt.Sprintf("I'll go through the %d unlabeled issues one by one and propose labels and next steps for each before changing anything.", listed)),
+				},
+			}
+			return &mcp.GetPromptResult{
+				Description: fmt.Sprintf("Triage new issues of %s/%s", owner, repo),
+				Messages:    messages,
+			}, nil
+		}
+}
+
+// ReleaseNotesPrompt provides a workflow to write the release notes of a milestone, starting from the
+// issues and pull requests closed in it.
+func ReleaseNotesPrompt(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("ReleaseNotes",
+			mcp.WithPromptDescription(t("PROMPT_RELEASE_NOTES_DESCRIPTION", "Write release notes for a milestone: the issues and pull requests closed in it are fetched up front and grouped into a draft")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription("Repository owner"), mcp.RequiredArgument()),
+			mcp.WithArgument("repo", mcp.ArgumentDescription("Repository name"), mcp.RequiredArgument()),
+			mcp.WithArgument("milestone", mcp.ArgumentDescription("Milestone title or number"), mcp.RequiredArgument()),
+		), func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner, err := promptArgument(request, "owner", true)
+			if err != nil {
+				return nil, err
+			}
+			repo, err := promptArgument(request, "repo", true)
+			if err != nil {
+				return nil, err
+			}
+			milestoneArg, err := promptArgument(request, "milestone", true)
+			if err != nil {
+				return nil, err
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			var milestone *github.Milestone
+			if number, err := strconv.Atoi(milestoneArg); err == nil {
+				m, resp, err := client.Issues.GetMilestone(ctx, owner, repo, number)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get milestone: %w", err)
+				}
+				_ = resp.Body.Close()
+				milestone = m
+			} else {
+				milestones, resp, err := client.Issues.ListMilestones(ctx, owner, repo, &github.MilestoneListOptions{State: "all", ListOptions: github.ListOptions{PerPage: 100}})
+				if err != nil {
+					return nil, fmt.Errorf("failed to list milestones: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, m := range milestones {
+					if strings.EqualFold(m.GetTitle(), milestoneArg) {
+						milestone = m
+						break
+					}
+				}
+				if milestone == nil {
+					return nil, fmt.Errorf("milestone %q not found in %s/%s", milestoneArg, owner, repo)
+				}
+			}
+
+			closed, resp, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+				Milestone:   strconv.Itoa(milestone.GetNumber()),
+				State:       "closed",
+				ListOptions: github.ListOptions{PerPage: 100},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list the issues of the milestone: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			var b strings.Builder
+			fmt.Fprintf(&b, "Please write the release notes for the milestone %q of %s/%s.\n", milestone.GetTitle(), owner, repo)
+			if d := strings.TrimSpace(milestone.GetDescription()); d != "" {
+				fmt.Fprintf(&b, "\nMilestone description:\n%s\n", d)
+			}
+			var pulls, issues []*github.Issue
+			for _, item := range closed {
+				if item.IsPullRequest() {
+					pulls = append(pulls, item)
+				} else {
+					issues = append(issues, item)
+				}
+			}
+			for _, group := range []struct {
+				title string
+				items []*github.Issue
+			}{{"Pull requests", pulls}, {"Issues", issues}} {
+				fmt.Fprintf(&b, "\n%s closed in the milestone:\n", group.title)
+				if len(group.items) == 0 {
+					b.WriteString("- none\n")
+				}
+				for i, item := range group.items {
+					if i == maxPromptIssues {
+						fmt.Fprintf(&b, "- and %d more, find them with search_issues\n", len(group.items)-i)
+						break
+					}
+					labelNames := make([]string, 0, len(item.Labels))
+					for _, l := range item.Labels {
+						labelNames = append(labelNames, l.GetName())
+					}
+					fmt.Fprintf(&b, "- #%d %s (by %s", item.GetNumber(), item.GetTitle(), item.GetUser().GetLogin())
+					if len(labelNames) > 0 {
+						fmt.Fprintf(&b, "; labels: %s", strings.Join(labelNames, ", "))
+					}
+					b.WriteString(")\n")
+				}
+			}
+
+			messages := []mcp.PromptMessage{
+				{
+					Role:    "system",
+					Content: mcp.NewTextContent("You are writing release notes. Group the changes into sections such as Features, Bug fixes and Other changes, using labels and titles as a guide, and write one short line per change that says what it means for users, crediting the author and linking the number. Skip pull requests that were closed without being merged and purely internal changes; read them with `get_pull_request` or `get_issue` when unsure. `list_releases` shows the style of earlier release notes. Only create or update a release with `create_release` or `update_release` after the user approves the draft."),
+				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent(b.String()),
+				},
+				{
+					Role:    "assistant",
+					Content: mcp.NewTextContent(fmt.Sprintf("I'll check how earlier releases were written, then draft the notes for %s from the %d pull requests and %d issues.", milestone.GetTitle(), len(pulls), len(issues))),
+				},
+			}
+			return &mcp.GetPromptResult{
+				Description: fmt.Sprintf("Release notes for %s of %s/%s", milestone.GetTitle(), owner, repo),
+				Messages:    messages,
+			}, nil
+		}
+}
@@ -0,0 +1,156 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getPromptRequest(args map[string]string) mcp.GetPromptRequest {
+	request := mcp.GetPromptRequest{}
+	request.Params.Arguments = args
+	return request
+}
+
+func promptUserMessage(t *testing.T, result *mcp.GetPromptResult) string {
+	t.Helper()
+	require.Len(t, result.Messages, 3)
+	text, ok := result.Messages[1].Content.(mcp.TextContent)
+	require.True(t, ok)
+	return text.Text
+}
+
+func Test_ReviewPullRequestPrompt(t *testing.T) {
+	prompt, _ := ReviewPullRequestPrompt(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	assert.Equal(t, "ReviewPullRequest", prompt.Name)
+	assert.NotEmpty(t, prompt.Description)
+	require.Len(t, prompt.Arguments, 4)
+	assert.True(t, prompt.Arguments[2].Required)
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposPullsByOwnerByRepoByPullNumber,
+			&github.PullRequest{
+				Title:        github.Ptr("Add caching"),
+				Body:         github.Ptr("Caches responses."),
+				User:         &github.User{Login: github.Ptr("octocat")},
+				Head:         &github.PullRequestBranch{Label: github.Ptr("octocat:cache")},
+				Base:         &github.PullRequestBranch{Ref: github.Ptr("main")},
+				State:        github.Ptr("open"),
+				ChangedFiles: github.Ptr(2),
+				Additions:    github.Ptr(30),
+				Deletions:    github.Ptr(4),
+			},
+		),
+		mock.WithRequestMatch(
+			mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+			[]*github.CommitFile{
+				{Filename: github.Ptr("cache.go"), Status: github.Ptr("added"), Additions: github.Ptr(28)},
+				{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Additions: github.Ptr(2), Deletions: github.Ptr(4)},
+			},
+		),
+	))
+	_, handler := ReviewPullRequestPrompt(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), getPromptRequest(map[string]string{"owner": "owner", "repo": "repo", "pullNumber": "42", "focus": "concurrency"}))
+	require.NoError(t, err)
+	text := promptUserMessage(t, result)
+	assert.Contains(t, text, "Title: Add caching\nAuthor: octocat\nBranches: octocat:cache into main")
+	assert.Contains(t, text, "Description:\nCaches responses.")
+	assert.Contains(t, text, "- cache.go (added, +28 -0)\n- main.go (modified, +2 -4)\n")
+	assert.Contains(t, text, "Pay particular attention to: concurrency")
+
+	_, err = handler(context.Background(), getPromptRequest(map[string]string{"owner": "owner", "repo": "repo", "pullNumber": "latest"}))
+	assert.EqualError(t, err, "invalid pull request number: latest")
+}
+
+func Test_TriageIssuesPrompt(t *testing.T) {
+	prompt, _ := TriageIssuesPrompt(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	assert.Equal(t, "TriageIssues", prompt.Name)
+	assert.NotEmpty(t, prompt.Description)
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "open", r.URL.Query().Get("state"))
+				assert.Equal(t, "2026-10-01T00:00:00Z", r.URL.Query().Get("since"))
+				_, _ = w.Write(mock.MustMarshal([]*github.Issue{
+					{Number: github.Ptr(3), Title: github.Ptr("Crash on start"), User: &github.User{Login: github.Ptr("hubot")}, Comments: github.Ptr(1)},
+					{Number: github.Ptr(2), Title: github.Ptr("Labeled"), Labels: []*github.Label{{Name: github.Ptr("bug")}}},
+					{Number: github.Ptr(1), Title: github.Ptr("A pull request"), PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/1")}},
+				}))
+			}),
+		),
+		mock.WithRequestMatch(
+			mock.GetReposLabelsByOwnerByRepo,
+			[]*github.Label{
+				{Name: github.Ptr("bug"), Description: github.Ptr("Something isn't working")},
+				{Name: github.Ptr("question")},
+			},
+		),
+	))
+	_, handler := TriageIssuesPrompt(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), getPromptRequest(map[string]string{"owner": "owner", "repo": "repo", "since": "2026-10-01"}))
+	require.NoError(t, err)
+	text := promptUserMessage(t, result)
+	assert.Contains(t, text, "Labels of the repository:\n- bug: Something isn't working\n- question\n")
+	assert.Contains(t, text, "Open issues without labels:\n- #3 Crash on start (by hubot, 1 comments)\n")
+	assert.NotContains(t, text, "#2")
+	assert.NotContains(t, text, "#1")
+
+	_, err = handler(context.Background(), getPromptRequest(map[string]string{"owner": "owner"}))
+	assert.EqualError(t, err, "missing required argument: repo")
+}
+
+func Test_ReleaseNotesPrompt(t *testing.T) {
+	prompt, _ := ReleaseNotesPrompt(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	assert.Equal(t, "ReleaseNotes", prompt.Name)
+	assert.NotEmpty(t, prompt.Description)
+
+	milestones := []*github.Milestone{
+		{Number: github.Ptr(1), Title: github.Ptr("v1.0")},
+		{Number: github.Ptr(2), Title: github.Ptr("v1.1"), Description: github.Ptr("Performance work")},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposMilestonesByOwnerByRepo, milestones),
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "2", r.URL.Query().Get("milestone"))
+				assert.Equal(t, "closed", r.URL.Query().Get("state"))
+				_, _ = w.Write(mock.MustMarshal([]*github.Issue{
+					{
+						Number:           github.Ptr(12),
+						Title:            github.Ptr("Cache responses"),
+						User:             &github.User{Login: github.Ptr("octocat")},
+						Labels:           []*github.Label{{Name: github.Ptr("enhancement")}},
+						PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/12")},
+					},
+					{Number: github.Ptr(10), Title: github.Ptr("Slow listing"), User: &github.User{Login: github.Ptr("hubot")}},
+				}))
+			}),
+		),
+	))
+	_, handler := ReleaseNotesPrompt(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), getPromptRequest(map[string]string{"owner": "owner", "repo": "repo", "milestone": "V1.1"}))
+	require.NoError(t, err)
+	text := promptUserMessage(t, result)
+	assert.Contains(t, text, "Milestone description:\nPerformance work")
+	assert.Contains(t, text, "Pull requests closed in the milestone:\n- #12 Cache responses (by octocat; labels: enhancement)\n")
+	assert.Contains(t, text, "Issues closed in the milestone:\n- #10 Slow listing (by hubot)\n")
+
+	client = github.NewClient(mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetReposMilestonesByOwnerByRepo, milestones)))
+	_, handler = ReleaseNotesPrompt(stubGetClientFn(client), translations.NullTranslationHelper)
+	_, err = handler(context.Background(), getPromptRequest(map[string]string{"owner": "owner", "repo": "repo", "milestone": "v2.0"}))
+	assert.EqualError(t, err, `milestone "v2.0" not found in owner/repo`)
+}
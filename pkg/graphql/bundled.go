@@ -0,0 +1,57 @@
+package graphql
+
+import (
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// BundledSchemaFile is the file of this package that holds the bundled schema, as written by
+// WriteBundledSchema.
+const BundledSchemaFile = "schema.json.gz"
+
+// bundledSchema is the gzipped data of an introspection query of the GitHub GraphQL API, generated with
+// script/generate-graphql-schema. It is empty in builds that bundle no schema.
+//
+//go:embed schema.json.gz
+var bundledSchema []byte
+
+// ErrNoBundledSchema is returned by BundledSchema in builds that bundle no schema.
+var ErrNoBundledSchema = errors.New("this build bundles no GraphQL schema: generate one with script/generate-graphql-schema, or introspect the schema of the host with --graphql-schema-refresh")
+
+// BundledSchema returns the schema of the GitHub GraphQL API that is bundled with the server. Validating
+// against it rather than introspecting the API at runtime means the server never validates against a
+// schema it can't vouch for, and needs no request before the first query.
+func BundledSchema() (*Schema, error) {
+	if len(bundledSchema) == 0 {
+		return nil, ErrNoBundledSchema
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(bundledSchema))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the bundled GraphQL schema: %w", err)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the bundled GraphQL schema: %w", err)
+	}
+	return ParseIntrospection(data)
+}
+
+// WriteBundledSchema writes the data of an introspection query in the format of the bundled schema, after
+// checking that it parses.
+func WriteBundledSchema(w io.Writer, data []byte) error {
+	if _, err := ParseIntrospection(data); err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(w)
+	if _, err := gz.Write(data); err != nil {
+		return fmt.Errorf("failed to write the GraphQL schema: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write the GraphQL schema: %w", err)
+	}
+	return nil
+}
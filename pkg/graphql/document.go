@@ -0,0 +1,804 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Document is a parsed GraphQL executable document: the operations and fragments a request sends.
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation is a query, mutation or subscription of a document.
+type Operation struct {
+	Type         string // "query", "mutation" or "subscription"
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []Selection
+}
+
+// VariableDefinition declares a variable of an operation.
+type VariableDefinition struct {
+	Name         string
+	Type         *TypeRef
+	DefaultValue *Value
+}
+
+// Fragment is a named fragment of a document.
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	SelectionSet  []Selection
+	line, column  int
+}
+
+// Selection is a *Field, *FragmentSpread or *InlineFragment.
+type Selection interface {
+	position() (line, column int)
+}
+
+// Field selects a field of the parent type.
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	SelectionSet []Selection
+	line, column int
+}
+
+// FragmentSpread selects the fields of a named fragment.
+type FragmentSpread struct {
+	Name         string
+	line, column int
+}
+
+// InlineFragment selects fields, when TypeCondition is set only for values of that type.
+type InlineFragment struct {
+	TypeCondition string
+	SelectionSet  []Selection
+	line, column  int
+}
+
+func (f *Field) position() (int, int)          { return f.line, f.column }
+func (f *FragmentSpread) position() (int, int) { return f.line, f.column }
+func (f *InlineFragment) position() (int, int) { return f.line, f.column }
+
+// Argument is an argument given to a field.
+type Argument struct {
+	Name  string
+	Value *Value
+}
+
+// ValueKind is the kind of a literal or variable in a document.
+type ValueKind int
+
+const (
+	VariableValue ValueKind = iota
+	IntValue
+	FloatValue
+	StringValue
+	BooleanValue
+	NullValue
+	EnumValue
+	ListValue
+	ObjectValue
+)
+
+// Value is a literal or variable in a document. Raw holds the variable name or the literal as
+// written, except for strings, whose Raw is the unescaped content.
+type Value struct {
+	Kind   ValueKind
+	Raw    string
+	List   []*Value
+	Fields []*Argument
+}
+
+// TypeRef is a type as written in a document or returned by introspection: a named type, or a list
+// when Elem is set, and not null when NonNull is set.
+type TypeRef struct {
+	Name    string
+	Elem    *TypeRef
+	NonNull bool
+}
+
+// NamedType is the named type a type reference wraps.
+func (t *TypeRef) NamedType() string {
+	for t.Elem != nil {
+		t = t.Elem
+	}
+	return t.Name
+}
+
+func (t *TypeRef) String() string {
+	var s string
+	if t.Elem != nil {
+		s = "[" + t.Elem.String() + "]"
+	} else {
+		s = t.Name
+	}
+	if t.NonNull {
+		s += "!"
+	}
+	return s
+}
+
+// SyntaxError reports where a document does not follow the GraphQL grammar.
+type SyntaxError struct {
+	Line, Column int
+	Message      string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind         tokenKind
+	value        string
+	line, column int
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of document"
+	case tokenString:
+		return "string"
+	default:
+		return fmt.Sprintf("%q", t.value)
+	}
+}
+
+type lexer struct {
+	src          string
+	pos          int
+	line, column int
+}
+
+func (l *lexer) errorf(line, column int, format string, args ...any) error {
+	return &SyntaxError{Line: line, Column: column, Message: fmt.Sprintf(format, args...)}
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.column = 1
+		} else {
+			l.column++
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\ufeff"):
+			l.pos += len("\ufeff")
+		default:
+			return
+		}
+	}
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	tok := token{line: l.line, column: l.column}
+	if l.pos >= len(l.src) {
+		tok.kind = tokenEOF
+		return tok, nil
+	}
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		tok.kind, tok.value = tokenPunctuator, "..."
+		l.advance(3)
+	case strings.ContainsRune("!$&():=@[]{}|", rune(c)):
+		tok.kind, tok.value = tokenPunctuator, string(c)
+		l.advance(1)
+	case isNameStart(c):
+		start := l.pos
+		for l.pos < len(l.src) && (isNameStart(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		tok.kind, tok.value = tokenName, l.src[start:l.pos]
+	case c == '-' || isDigit(c):
+		return l.number(tok)
+	case c == '"':
+		return l.string(tok)
+	default:
+		return tok, l.errorf(tok.line, tok.column, "unexpected character %q", c)
+	}
+	return tok, nil
+}
+
+func (l *lexer) number(tok token) (token, error) {
+	start := l.pos
+	tok.kind = tokenInt
+	if l.src[l.pos] == '-' {
+		l.advance(1)
+	}
+	digits := func() int {
+		n := 0
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.advance(1)
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return tok, l.errorf(tok.line, tok.column, "invalid number")
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		tok.kind = tokenFloat
+		l.advance(1)
+		if digits() == 0 {
+			return tok, l.errorf(tok.line, tok.column, "invalid number")
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		tok.kind = tokenFloat
+		l.advance(1)
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.advance(1)
+		}
+		if digits() == 0 {
+			return tok, l.errorf(tok.line, tok.column, "invalid number")
+		}
+	}
+	if l.pos < len(l.src) && (isNameStart(l.src[l.pos]) || l.src[l.pos] == '.') {
+		return tok, l.errorf(tok.line, tok.column, "invalid number")
+	}
+	tok.value = l.src[start:l.pos]
+	return tok, nil
+}
+
+func (l *lexer) string(tok token) (token, error) {
+	tok.kind = tokenString
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		l.advance(3)
+		var b strings.Builder
+		for {
+			if l.pos >= len(l.src) {
+				return tok, l.errorf(tok.line, tok.column, "unterminated string")
+			}
+			if strings.HasPrefix(l.src[l.pos:], `\"""`) {
+				b.WriteString(`"""`)
+				l.advance(4)
+				continue
+			}
+			if strings.HasPrefix(l.src[l.pos:], `"""`) {
+				l.advance(3)
+				tok.value = blockStringValue(b.String())
+				return tok, nil
+			}
+			b.WriteByte(l.src[l.pos])
+			l.advance(1)
+		}
+	}
+
+	l.advance(1)
+	var b strings.Builder
+	for {
+		if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+			return tok, l.errorf(tok.line, tok.column, "unterminated string")
+		}
+		c := l.src[l.pos]
+		if c == '"' {
+			l.advance(1)
+			tok.value = b.String()
+			return tok, nil
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			l.advance(1)
+			continue
+		}
+		if l.pos+1 >= len(l.src) {
+			return tok, l.errorf(tok.line, tok.column, "unterminated string")
+		}
+		escape := l.src[l.pos+1]
+		switch escape {
+		case '"', '\\', '/':
+			b.WriteByte(escape)
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			if l.pos+6 > len(l.src) {
+				return tok, l.errorf(l.line, l.column, "invalid unicode escape")
+			}
+			var r rune
+			if _, err := fmt.Sscanf(l.src[l.pos+2:l.pos+6], "%04x", &r); err != nil {
+				return tok, l.errorf(l.line, l.column, "invalid unicode escape")
+			}
+			b.WriteRune(r)
+			l.advance(4)
+		default:
+			return tok, l.errorf(l.line, l.column, "invalid escape \\%c", escape)
+		}
+		l.advance(2)
+	}
+}
+
+// blockStringValue removes the common indentation and the blank first and last lines of a block string.
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(line) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			} else {
+				lines[i] = ""
+			}
+		}
+	}
+	for len(lines) > 0 && strings.TrimLeft(lines[0], " \t") == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimLeft(lines[len(lines)-1], " \t") == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+// Parse parses a GraphQL executable document.
+func Parse(src string) (*Document, error) {
+	p := &parser{lex: &lexer{src: src, line: 1, column: 1}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	doc := &Document{Fragments: map[string]*Fragment{}}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunctuator, "{"):
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", SelectionSet: selections})
+		case p.peek(tokenName, "query"), p.peek(tokenName, "mutation"), p.peek(tokenName, "subscription"):
+			operation, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, operation)
+		case p.peek(tokenName, "fragment"):
+			fragment, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.Fragments[fragment.Name]; ok {
+				return nil, &SyntaxError{Line: fragment.line, Column: fragment.column, Message: fmt.Sprintf("fragment %s is defined more than once", fragment.Name)}
+			}
+			doc.Fragments[fragment.Name] = fragment
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.Operations) == 0 {
+		return nil, &SyntaxError{Line: p.tok.line, Column: p.tok.column, Message: "document contains no operation"}
+	}
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+func (p *parser) unexpected() error {
+	return &SyntaxError{Line: p.tok.line, Column: p.tok.column, Message: "unexpected " + p.tok.describe()}
+}
+
+// skip consumes the punctuator if it is next, reporting whether it was.
+func (p *parser) skip(value string) (bool, error) {
+	if !p.peek(tokenPunctuator, value) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(value string) error {
+	if !p.peek(tokenPunctuator, value) {
+		return &SyntaxError{Line: p.tok.line, Column: p.tok.column, Message: fmt.Sprintf("expected %q, found %s", value, p.tok.describe())}
+	}
+	return p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", &SyntaxError{Line: p.tok.line, Column: p.tok.column, Message: "expected a name, found " + p.tok.describe()}
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+func (p *parser) operation() (*Operation, error) {
+	operation := &Operation{Type: p.tok.value}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName {
+		operation.Name = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek(tokenPunctuator, "(") {
+		variables, err := p.variableDefinitions()
+		if err != nil {
+			return nil, err
+		}
+		operation.Variables = variables
+	}
+	if err := p.directives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	operation.SelectionSet = selections
+	return operation, nil
+}
+
+func (p *parser) variableDefinitions() ([]*VariableDefinition, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var definitions []*VariableDefinition
+	for {
+		if closed, err := p.skip(")"); err != nil || closed {
+			return definitions, err
+		}
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		typ, err := p.typeRef()
+		if err != nil {
+			return nil, err
+		}
+		definition := &VariableDefinition{Name: name, Type: typ}
+		if hasDefault, err := p.skip("="); err != nil {
+			return nil, err
+		} else if hasDefault {
+			value, err := p.value(true)
+			if err != nil {
+				return nil, err
+			}
+			definition.DefaultValue = value
+		}
+		if err := p.directives(); err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+}
+
+func (p *parser) typeRef() (*TypeRef, error) {
+	var typ *TypeRef
+	if isList, err := p.skip("["); err != nil {
+		return nil, err
+	} else if isList {
+		elem, err := p.typeRef()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		typ = &TypeRef{Elem: elem}
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		typ = &TypeRef{Name: name}
+	}
+	nonNull, err := p.skip("!")
+	if err != nil {
+		return nil, err
+	}
+	typ.NonNull = nonNull
+	return typ, nil
+}
+
+func (p *parser) fragment() (*Fragment, error) {
+	fragment := &Fragment{line: p.tok.line, column: p.tok.column}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, &SyntaxError{Line: fragment.line, Column: fragment.column, Message: "a fragment can't be named on"}
+	}
+	fragment.Name = name
+	if !p.peek(tokenName, "on") {
+		return nil, &SyntaxError{Line: p.tok.line, Column: p.tok.column, Message: `expected "on", found ` + p.tok.describe()}
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if fragment.TypeCondition, err = p.name(); err != nil {
+		return nil, err
+	}
+	if err := p.directives(); err != nil {
+		return nil, err
+	}
+	if fragment.SelectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return fragment, nil
+}
+
+func (p *parser) selectionSet() ([]Selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var selections []Selection
+	for {
+		if closed, err := p.skip("}"); err != nil {
+			return nil, err
+		} else if closed {
+			if len(selections) == 0 {
+				return nil, &SyntaxError{Line: p.tok.line, Column: p.tok.column, Message: "empty selection set"}
+			}
+			return selections, nil
+		}
+		selection, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+}
+
+func (p *parser) selection() (Selection, error) {
+	line, column := p.tok.line, p.tok.column
+	if spread, err := p.skip("..."); err != nil {
+		return nil, err
+	} else if spread {
+		if p.tok.kind == tokenName && p.tok.value != "on" {
+			name := p.tok.value
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			return &FragmentSpread{Name: name, line: line, column: column}, p.directives()
+		}
+		fragment := &InlineFragment{line: line, column: column}
+		if p.peek(tokenName, "on") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			fragment.TypeCondition = name
+		}
+		if err := p.directives(); err != nil {
+			return nil, err
+		}
+		selections, err := p.selectionSet()
+		if err != nil {
+			return nil, err
+		}
+		fragment.SelectionSet = selections
+		return fragment, nil
+	}
+
+	field := &Field{line: line, column: column}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if alias, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if alias {
+		field.Alias = name
+		if name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	field.Name = name
+	if p.peek(tokenPunctuator, "(") {
+		if field.Arguments, err = p.arguments(false); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.directives(); err != nil {
+		return nil, err
+	}
+	if p.peek(tokenPunctuator, "{") {
+		if field.SelectionSet, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+func (p *parser) arguments(constant bool) ([]*Argument, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var arguments []*Argument
+	for {
+		if closed, err := p.skip(")"); err != nil {
+			return nil, err
+		} else if closed {
+			return arguments, nil
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.value(constant)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, &Argument{Name: name, Value: value})
+	}
+}
+
+// directives skips the directives of a definition or selection, which only the server interprets.
+func (p *parser) directives() error {
+	for p.peek(tokenPunctuator, "@") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if _, err := p.name(); err != nil {
+			return err
+		}
+		if p.peek(tokenPunctuator, "(") {
+			if _, err := p.arguments(false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *parser) value(constant bool) (*Value, error) {
+	tok := p.tok
+	switch {
+	case tok.kind == tokenPunctuator && tok.value == "$" && !constant:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		return &Value{Kind: VariableValue, Raw: name}, nil
+	case tok.kind == tokenInt:
+		return &Value{Kind: IntValue, Raw: tok.value}, p.advance()
+	case tok.kind == tokenFloat:
+		return &Value{Kind: FloatValue, Raw: tok.value}, p.advance()
+	case tok.kind == tokenString:
+		return &Value{Kind: StringValue, Raw: tok.value}, p.advance()
+	case tok.kind == tokenName:
+		value := &Value{Kind: EnumValue, Raw: tok.value}
+		switch tok.value {
+		case "true", "false":
+			value.Kind = BooleanValue
+		case "null":
+			value.Kind = NullValue
+		}
+		return value, p.advance()
+	case tok.kind == tokenPunctuator && tok.value == "[":
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		list := &Value{Kind: ListValue}
+		for {
+			if closed, err := p.skip("]"); err != nil {
+				return nil, err
+			} else if closed {
+				return list, nil
+			}
+			item, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			list.List = append(list.List, item)
+		}
+	case tok.kind == tokenPunctuator && tok.value == "{":
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		object := &Value{Kind: ObjectValue}
+		for {
+			if closed, err := p.skip("}"); err != nil {
+				return nil, err
+			} else if closed {
+				return object, nil
+			}
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			value, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			object.Fields = append(object.Fields, &Argument{Name: name, Value: value})
+		}
+	default:
+		return nil, p.unexpected()
+	}
+}
@@ -0,0 +1,165 @@
+// Package graphql runs arbitrary GraphQL documents against the GitHub GraphQL API, after validating them
+// against the schema of the API. The typed githubv4 client can only send queries built from Go structs.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// maxErrorBodyBytes caps how much of a non-GraphQL error response is quoted in errors.
+const maxErrorBodyBytes = 1024
+
+// GetClientFn is a function type that returns a Client instance.
+type GetClientFn func(context.Context) (*Client, error)
+
+// Client sends GraphQL documents to an endpoint and caches its schema.
+type Client struct {
+	httpClient *http.Client
+	url        string
+
+	mu            sync.Mutex
+	schema        *Schema
+	refreshSchema bool
+}
+
+// NewClient creates a client for the GraphQL endpoint at url. The httpClient authenticates the requests.
+func NewClient(httpClient *http.Client, url string) *Client {
+	return &Client{httpClient: httpClient, url: url}
+}
+
+// Request is a GraphQL request.
+type Request struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	OperationName string         `json:"operationName,omitempty"`
+}
+
+// ReadOnlyRequest reports whether the body of a request to the GraphQL endpoint runs a query, which only
+// reads, rather than a mutation or subscription. It looks at the operation the operation name selects, so
+// comments and fragments before it make no difference. Bodies that do not parse are not read-only.
+func ReadOnlyRequest(body []byte) bool {
+	var request Request
+	if err := json.Unmarshal(body, &request); err != nil {
+		return false
+	}
+	doc, err := Parse(request.Query)
+	if err != nil {
+		return false
+	}
+	operation, err := selectOperation(doc, request.OperationName)
+	if err != nil {
+		return false
+	}
+	return operation.Type == "query"
+}
+
+// Response is a GraphQL response. Data and Errors can both be set when parts of an operation failed.
+type Response struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []Error         `json:"errors,omitempty"`
+}
+
+// Error is an error of a GraphQL response.
+type Error struct {
+	Message   string           `json:"message"`
+	Type      string           `json:"type,omitempty"`
+	Path      []any            `json:"path,omitempty"`
+	Locations []map[string]int `json:"locations,omitempty"`
+}
+
+// Do sends a request. Errors are only returned when no GraphQL response came back;
+// the errors of the operation itself are in the response.
+func (c *Client) Do(ctx context.Context, request Request) (*Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal GraphQL request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GraphQL request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send GraphQL request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return nil, fmt.Errorf("GraphQL request failed with status %s: %s", resp.Status, bytes.TrimSpace(excerpt))
+	}
+	var response Response
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode GraphQL response: %w", err)
+	}
+	return &response, nil
+}
+
+// Schema returns the schema that documents are validated against: the bundled one, see BundledSchema,
+// or with SetSchemaRefresh the one of the endpoint, introspected on first use. A refresh that fails falls
+// back to the bundled schema.
+func (c *Client) Schema(ctx context.Context) (*Schema, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.schema != nil {
+		return c.schema, nil
+	}
+
+	if c.refreshSchema {
+		data, err := c.Introspect(ctx)
+		if err == nil {
+			var schema *Schema
+			if schema, err = ParseIntrospection(data); err == nil {
+				c.schema = schema
+				return schema, nil
+			}
+		}
+		if bundled, bundledErr := BundledSchema(); bundledErr == nil {
+			c.schema = bundled
+			return bundled, nil
+		}
+		return nil, err
+	}
+
+	schema, err := BundledSchema()
+	if err != nil {
+		return nil, err
+	}
+	c.schema = schema
+	return schema, nil
+}
+
+// Introspect returns the data of an introspection query of the endpoint, which ParseIntrospection reads.
+func (c *Client) Introspect(ctx context.Context) (json.RawMessage, error) {
+	response, err := c.Do(ctx, Request{Query: introspectionQuery})
+	if err != nil {
+		return nil, fmt.Errorf("failed to introspect the GraphQL schema: %w", err)
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("failed to introspect the GraphQL schema: %s", response.Errors[0].Message)
+	}
+	return response.Data, nil
+}
+
+// SetSchemaRefresh makes the client introspect the schema of the endpoint instead of using the bundled
+// one, for GitHub Enterprise Server versions whose schema differs from the one of github.com.
+func (c *Client) SetSchemaRefresh(refresh bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshSchema = refresh
+}
+
+// SetSchema makes the client validate against the given schema instead of introspecting one.
+func (c *Client) SetSchema(schema *Schema) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.schema = schema
+}
@@ -0,0 +1,423 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIntrospection is a small slice of the GitHub schema in introspection form.
+const testIntrospection = `{"data": {"__schema": {
+  "queryType": {"name": "Query"},
+  "mutationType": {"name": "Mutation"},
+  "subscriptionType": null,
+  "types": [
+    {"kind": "OBJECT", "name": "Query", "fields": [
+      {"name": "repository", "args": [
+        {"name": "owner", "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "String", "ofType": null}}},
+        {"name": "name", "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "String", "ofType": null}}}
+      ], "type": {"kind": "OBJECT", "name": "Repository", "ofType": null}},
+      {"name": "search", "args": [
+        {"name": "query", "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "String", "ofType": null}}},
+        {"name": "first", "type": {"kind": "SCALAR", "name": "Int", "ofType": null}}
+      ], "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "OBJECT", "name": "SearchResultItemConnection", "ofType": null}}}
+    ]},
+    {"kind": "OBJECT", "name": "Mutation", "fields": [
+      {"name": "addStar", "args": [
+        {"name": "input", "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "INPUT_OBJECT", "name": "AddStarInput", "ofType": null}}}
+      ], "type": {"kind": "OBJECT", "name": "AddStarPayload", "ofType": null}}
+    ]},
+    {"kind": "OBJECT", "name": "AddStarPayload", "fields": [
+      {"name": "clientMutationId", "args": [], "type": {"kind": "SCALAR", "name": "String", "ofType": null}}
+    ]},
+    {"kind": "INPUT_OBJECT", "name": "AddStarInput", "fields": null},
+    {"kind": "OBJECT", "name": "Repository", "fields": [
+      {"name": "name", "args": [], "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "String", "ofType": null}}},
+      {"name": "issues", "args": [
+        {"name": "first", "type": {"kind": "SCALAR", "name": "Int", "ofType": null}},
+        {"name": "last", "type": {"kind": "SCALAR", "name": "Int", "ofType": null}},
+        {"name": "after", "type": {"kind": "SCALAR", "name": "String", "ofType": null}}
+      ], "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "OBJECT", "name": "IssueConnection", "ofType": null}}}
+    ]},
+    {"kind": "OBJECT", "name": "IssueConnection", "fields": [
+      {"name": "nodes", "args": [], "type": {"kind": "LIST", "name": null, "ofType": {"kind": "OBJECT", "name": "Issue", "ofType": null}}},
+      {"name": "pageInfo", "args": [], "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "OBJECT", "name": "PageInfo", "ofType": null}}}
+    ]},
+    {"kind": "OBJECT", "name": "Issue", "fields": [
+      {"name": "number", "args": [], "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "Int", "ofType": null}}},
+      {"name": "labels", "args": [
+        {"name": "first", "type": {"kind": "SCALAR", "name": "Int", "ofType": null}}
+      ], "type": {"kind": "OBJECT", "name": "LabelConnection", "ofType": null}}
+    ]},
+    {"kind": "OBJECT", "name": "LabelConnection", "fields": [
+      {"name": "nodes", "args": [], "type": {"kind": "LIST", "name": null, "ofType": {"kind": "OBJECT", "name": "Label", "ofType": null}}},
+      {"name": "pageInfo", "args": [], "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "OBJECT", "name": "PageInfo", "ofType": null}}}
+    ]},
+    {"kind": "OBJECT", "name": "Label", "fields": [
+      {"name": "name", "args": [], "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "String", "ofType": null}}}
+    ]},
+    {"kind": "OBJECT", "name": "SearchResultItemConnection", "fields": [
+      {"name": "nodes", "args": [], "type": {"kind": "LIST", "name": null, "ofType": {"kind": "UNION", "name": "SearchResultItem", "ofType": null}}},
+      {"name": "pageInfo", "args": [], "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "OBJECT", "name": "PageInfo", "ofType": null}}}
+    ]},
+    {"kind": "UNION", "name": "SearchResultItem", "fields": null},
+    {"kind": "OBJECT", "name": "PageInfo", "fields": [
+      {"name": "hasNextPage", "args": [], "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "Boolean", "ofType": null}}},
+      {"name": "endCursor", "args": [], "type": {"kind": "SCALAR", "name": "String", "ofType": null}}
+    ]},
+    {"kind": "SCALAR", "name": "String", "fields": null},
+    {"kind": "SCALAR", "name": "Int", "fields": null},
+    {"kind": "SCALAR", "name": "Boolean", "fields": null}
+  ]
+}}}`
+
+func testSchema(t *testing.T) *Schema {
+	t.Helper()
+	schema, err := ParseIntrospection([]byte(testIntrospection))
+	require.NoError(t, err)
+	return schema
+}
+
+func Test_ParseIntrospection(t *testing.T) {
+	schema := testSchema(t)
+
+	assert.Equal(t, "Query", schema.QueryType)
+	assert.Equal(t, "Mutation", schema.MutationType)
+	assert.Empty(t, schema.SubscriptionType)
+
+	nodes := schema.Types["IssueConnection"].Fields["nodes"].Type
+	assert.Equal(t, "[Issue]", nodes.String())
+	owner := schema.Types["Query"].Fields["repository"].Args["owner"]
+	assert.Equal(t, "String!", owner.String())
+
+	assert.True(t, schema.isConnection("IssueConnection"))
+	assert.False(t, schema.isConnection("Issue"))
+}
+
+func Test_Validate(t *testing.T) {
+	schema := testSchema(t)
+
+	tests := []struct {
+		name             string
+		query            string
+		operationName    string
+		variables        map[string]any
+		limits           Limits
+		expectedCost     int
+		expectedProblems []string
+	}{
+		{
+			name:         "nested connections multiply",
+			query:        `query { repository(owner: "o", name: "r") { issues(first: 10) { nodes { number labels(first: 5) { nodes { name } } } } } }`,
+			expectedCost: 10 + 10*5,
+		},
+		{
+			name: "page size from variables and defaults",
+			query: `query Issues($owner: String!, $size: Int = 20, $labels: Int) {
+				repository(owner: $owner, name: "r") {
+					issues(last: $size) { nodes { ...IssueFields } }
+				}
+			}
+			fragment IssueFields on Issue { number labels(first: $labels) { nodes { name } } }`,
+			variables:    map[string]any{"owner": "o", "labels": float64(3)},
+			expectedCost: 20 + 20*3,
+		},
+		{
+			name:             "cost above the limit",
+			query:            `{ repository(owner: "o", name: "r") { issues(first: 100) { nodes { labels(first: 100) { nodes { name } } } } } }`,
+			limits:           Limits{MaxCost: 1000},
+			expectedProblems: []string{"the estimated cost of 10100 nodes exceeds the limit of 1000; request smaller pages with first or last"},
+		},
+		{
+			name:             "mutations not allowed",
+			query:            `mutation { addStar(input: {starrableId: "x"}) { clientMutationId } }`,
+			expectedProblems: []string{"mutations are not allowed, only queries can be run"},
+		},
+		{
+			name:   "mutations allowed",
+			query:  `mutation Star($input: AddStarInput!) { addStar(input: $input) { clientMutationId } }`,
+			limits: Limits{AllowMutations: true},
+			variables: map[string]any{
+				"input": map[string]any{"starrableId": "x"},
+			},
+		},
+		{
+			name:             "subscriptions",
+			query:            `subscription { repository { name } }`,
+			limits:           Limits{AllowMutations: true},
+			expectedProblems: []string{"subscriptions are not supported"},
+		},
+		{
+			name: "unknown fields, arguments and fragments",
+			query: `{
+  repository(owner: "o", name: "r", private: true) {
+    stars
+    issues(first: 1) { nodes { ...Missing } }
+  }
+}`,
+			expectedProblems: []string{
+				"2:3: field Query.repository has no argument private",
+				"3:5: type Repository has no field stars",
+				"4:32: unknown fragment Missing",
+			},
+		},
+		{
+			name:  "selection mistakes",
+			query: `{ repository(owner: "o", name: "r") { name { length } issues(first: 1) } }`,
+			expectedProblems: []string{
+				"1:39: field Repository.name is a String and has no fields to select",
+				"1:55: field Repository.issues of type IssueConnection needs a selection of its fields",
+			},
+		},
+		{
+			name:  "connection without a page size",
+			query: `{ repository(owner: "o", name: "r") { issues { nodes { number } } } }`,
+			expectedProblems: []string{
+				"1:39: connection Repository.issues needs first or last to set the page size",
+			},
+		},
+		{
+			name:  "union members need fragments",
+			query: `{ search(query: "q", first: 5) { nodes { __typename name ... on Repository { name } } } }`,
+			expectedProblems: []string{
+				"1:53: SearchResultItem is a union, select name of one of its members in a fragment",
+			},
+		},
+		{
+			name:  "variables",
+			query: `query($owner: String!, $issue: Issue) { repository(owner: $owner, name: $name) { name } }`,
+			expectedProblems: []string{
+				"variable $owner of type String! is required",
+				"variable $issue can't be of output type Issue",
+				"1:41: variable $name is not declared",
+			},
+		},
+		{
+			name:  "fragment cycles",
+			query: `{ repository(owner: "o", name: "r") { ...A } } fragment A on Repository { ...B } fragment B on Repository { ...A }`,
+			expectedProblems: []string{
+				"1:109: fragment A spreads itself",
+			},
+		},
+		{
+			name:             "several operations need a name",
+			query:            `query A { __typename } query B { __typename }`,
+			expectedProblems: []string{"the document has several operations, an operation name is required"},
+		},
+		{
+			name:          "operation chosen by name",
+			query:         `query A { __typename } query B { __schema { types { name } } }`,
+			operationName: "B",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := Parse(tc.query)
+			require.NoError(t, err)
+
+			analysis, err := Validate(doc, schema, tc.operationName, tc.variables, tc.limits)
+			if len(tc.expectedProblems) > 0 {
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, tc.expectedProblems, validationErr.Problems)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCost, analysis.Cost)
+		})
+	}
+}
+
+func Test_Parse(t *testing.T) {
+	doc, err := Parse(`
+# comment
+query Search($q: String! = "is:open", $n: [Int!]) @cached {
+  hits: search(query: $q, first: 10, filter: {states: [OPEN, CLOSED], note: """
+    multi
+      line
+  """, ratio: -1.5e3, none: null, ok: true}) {
+    ... on Issue @include(if: true) { number }
+    ...F
+  }
+}
+fragment F on Repository { name }`)
+	require.NoError(t, err)
+
+	require.Len(t, doc.Operations, 1)
+	operation := doc.Operations[0]
+	assert.Equal(t, "query", operation.Type)
+	assert.Equal(t, "Search", operation.Name)
+	require.Len(t, operation.Variables, 2)
+	assert.Equal(t, "String!", operation.Variables[0].Type.String())
+	assert.Equal(t, "is:open", operation.Variables[0].DefaultValue.Raw)
+	assert.Equal(t, "[Int!]", operation.Variables[1].Type.String())
+
+	field := operation.SelectionSet[0].(*Field)
+	assert.Equal(t, "hits", field.Alias)
+	assert.Equal(t, "search", field.Name)
+	require.Len(t, field.Arguments, 3)
+	filter := field.Arguments[2].Value
+	assert.Equal(t, ObjectValue, filter.Kind)
+	assert.Equal(t, "multi\n  line", filter.Fields[1].Value.Raw)
+	assert.Equal(t, FloatValue, filter.Fields[2].Value.Kind)
+	assert.Equal(t, NullValue, filter.Fields[3].Value.Kind)
+	assert.Equal(t, BooleanValue, filter.Fields[4].Value.Kind)
+
+	assert.Equal(t, "Issue", field.SelectionSet[0].(*InlineFragment).TypeCondition)
+	assert.Equal(t, "F", field.SelectionSet[1].(*FragmentSpread).Name)
+	assert.Equal(t, "Repository", doc.Fragments["F"].TypeCondition)
+
+	for query, message := range map[string]string{
+		`{ repository { name }`:             "syntax error at 1:22: expected a name, found end of document",
+		`{ repository(owner: ) { name } }`:  `syntax error at 1:21: unexpected ")"`,
+		`{ name(x: "open) }`:                "syntax error at 1:11: unterminated string",
+		`{ }`:                               "syntax error at 1:4: empty selection set",
+		`fragment F on Repository { name }`: "syntax error at 1:34: document contains no operation",
+		`{ a } ; `:                          "syntax error at 1:7: unexpected character ';'",
+	} {
+		_, err := Parse(query)
+		assert.EqualError(t, err, message, query)
+	}
+}
+
+func Test_ReadOnlyRequest(t *testing.T) {
+	tests := map[string]bool{
+		`{"query":"{ viewer { login } }"}`:                                               true,
+		`{"query":"query Q { viewer { login } }"}`:                                       true,
+		`{"query":"# mutation\nquery { viewer { login } }"}`:                             true,
+		`{"query":"mutation { addStar(input: {}) { clientMutationId } }"}`:               false,
+		`{"query":"# read\nmutation { addStar(input: {}) { clientMutationId } }"}`:       false,
+		`{"query":"fragment F on User { login } mutation { addStar(input: {}) { x } }"}`: false,
+		`{"query":"query Q { viewer { login } } mutation M { x }","operationName":"M"}`:  false,
+		`{"query":"query Q { viewer { login } } mutation M { x }","operationName":"Q"}`:  true,
+		`{"query":"query Q { viewer { login } } mutation M { x }"}`:                      false,
+		`{"query":"query Q { viewer { login } }","operationName":"Other"}`:               false,
+		`{"query":"subscription { x }"}`:                                                 false,
+		`{"query":"{ viewer { login }"}`:                                                 false,
+		`not json`:                                                                       false,
+	}
+	for body, readOnly := range tests {
+		assert.Equal(t, readOnly, ReadOnlyRequest([]byte(body)), body)
+	}
+}
+
+// withBundledSchema bundles the given introspection data for the duration of a test, or none if it is
+// empty.
+func withBundledSchema(t *testing.T, data string) {
+	t.Helper()
+	bundled := bundledSchema
+	t.Cleanup(func() { bundledSchema = bundled })
+	bundledSchema = nil
+	if data != "" {
+		var buf bytes.Buffer
+		require.NoError(t, WriteBundledSchema(&buf, []byte(data)))
+		bundledSchema = buf.Bytes()
+	}
+}
+
+func Test_ClientSchema(t *testing.T) {
+	requests := 0
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var request Request
+		require.NoError(t, json.Unmarshal(body, &request))
+		assert.Contains(t, request.Query, "__schema")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(testIntrospection))
+	}))
+	defer server.Close()
+
+	t.Run("bundled", func(t *testing.T) {
+		withBundledSchema(t, testIntrospection)
+		requests = 0
+		client := NewClient(server.Client(), server.URL)
+		schema, err := client.Schema(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "Query", schema.QueryType)
+		assert.Zero(t, requests, "the bundled schema needs no introspection")
+	})
+
+	t.Run("none bundled", func(t *testing.T) {
+		withBundledSchema(t, "")
+		requests = 0
+		_, err := NewClient(server.Client(), server.URL).Schema(context.Background())
+		assert.ErrorIs(t, err, ErrNoBundledSchema)
+		assert.Zero(t, requests, "the schema is only introspected when asked to")
+	})
+
+	t.Run("refreshed", func(t *testing.T) {
+		withBundledSchema(t, "")
+		requests = 0
+		client := NewClient(server.Client(), server.URL)
+		client.SetSchemaRefresh(true)
+		for i := 0; i < 2; i++ {
+			schema, err := client.Schema(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "Query", schema.QueryType)
+		}
+		assert.Equal(t, 1, requests, "the schema is introspected once")
+	})
+
+	t.Run("failed refresh", func(t *testing.T) {
+		status = http.StatusBadGateway
+		t.Cleanup(func() { status = http.StatusOK })
+
+		withBundledSchema(t, "")
+		client := NewClient(server.Client(), server.URL)
+		client.SetSchemaRefresh(true)
+		_, err := client.Schema(context.Background())
+		assert.ErrorContains(t, err, "failed to introspect the GraphQL schema")
+
+		withBundledSchema(t, testIntrospection)
+		client = NewClient(server.Client(), server.URL)
+		client.SetSchemaRefresh(true)
+		schema, err := client.Schema(context.Background())
+		require.NoError(t, err, "a failed refresh falls back to the bundled schema")
+		assert.Equal(t, "Query", schema.QueryType)
+	})
+}
+
+func Test_WriteBundledSchema(t *testing.T) {
+	var buf bytes.Buffer
+	assert.ErrorContains(t, WriteBundledSchema(&buf, []byte(`{"data": {}}`)), "introspection result has no query type")
+	assert.Zero(t, buf.Len(), "schemas that don't parse aren't written")
+}
+
+func Test_ClientDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		switch request.OperationName {
+		case "Partial":
+			assert.Equal(t, map[string]any{"n": float64(1)}, request.Variables)
+			_, _ = w.Write([]byte(`{"data": {"viewer": null}, "errors": [{"message": "not allowed", "type": "FORBIDDEN", "path": ["viewer"]}]}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL)
+
+	response, err := client.Do(context.Background(), Request{Query: "query Partial { viewer { login } }", OperationName: "Partial", Variables: map[string]any{"n": 1}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"viewer": null}`, string(response.Data))
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "FORBIDDEN", response.Errors[0].Type)
+
+	_, err = client.Do(context.Background(), Request{Query: "{ viewer { login } }"})
+	assert.EqualError(t, err, `GraphQL request failed with status 401 Unauthorized: {"message": "Bad credentials"}`)
+}
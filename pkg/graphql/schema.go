@@ -0,0 +1,178 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Schema is the part of a GraphQL schema that documents are validated against.
+type Schema struct {
+	QueryType        string
+	MutationType     string
+	SubscriptionType string
+	Types            map[string]*Type
+}
+
+// Type is a named type of a schema.
+type Type struct {
+	Name   string
+	Kind   string // "OBJECT", "INTERFACE", "UNION", "SCALAR", "ENUM" or "INPUT_OBJECT"
+	Fields map[string]*SchemaField
+}
+
+// SchemaField is a field of an object or interface type.
+type SchemaField struct {
+	Name string
+	Args map[string]*TypeRef
+	Type *TypeRef
+}
+
+// composite reports whether values of the type have fields to select.
+func (t *Type) composite() bool {
+	return t.Kind == "OBJECT" || t.Kind == "INTERFACE" || t.Kind == "UNION"
+}
+
+// introspectionQuery fetches what Schema holds. Four levels of ofType cover types such as [String!]!.
+const introspectionQuery = `query IntrospectSchema {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types {
+      kind
+      name
+      fields(includeDeprecated: true) {
+        name
+        args { name type { ...TypeRef } }
+        type { ...TypeRef }
+      }
+    }
+  }
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } }
+}`
+
+type introspectionTypeRef struct {
+	Kind   string                `json:"kind"`
+	Name   string                `json:"name"`
+	OfType *introspectionTypeRef `json:"ofType"`
+}
+
+func (r *introspectionTypeRef) typeRef() (*TypeRef, error) {
+	if r == nil {
+		return nil, fmt.Errorf("incomplete type reference")
+	}
+	switch r.Kind {
+	case "NON_NULL":
+		inner, err := r.OfType.typeRef()
+		if err != nil {
+			return nil, err
+		}
+		inner.NonNull = true
+		return inner, nil
+	case "LIST":
+		elem, err := r.OfType.typeRef()
+		if err != nil {
+			return nil, err
+		}
+		return &TypeRef{Elem: elem}, nil
+	default:
+		return &TypeRef{Name: r.Name}, nil
+	}
+}
+
+type introspectionResult struct {
+	Schema struct {
+		QueryType        *struct{ Name string } `json:"queryType"`
+		MutationType     *struct{ Name string } `json:"mutationType"`
+		SubscriptionType *struct{ Name string } `json:"subscriptionType"`
+		Types            []struct {
+			Kind   string `json:"kind"`
+			Name   string `json:"name"`
+			Fields []struct {
+				Name string `json:"name"`
+				Args []struct {
+					Name string                `json:"name"`
+					Type *introspectionTypeRef `json:"type"`
+				} `json:"args"`
+				Type *introspectionTypeRef `json:"type"`
+			} `json:"fields"`
+		} `json:"types"`
+	} `json:"__schema"`
+}
+
+// ParseIntrospection reads a schema from the data of an introspection query response, with or without
+// the enclosing "data" key.
+func ParseIntrospection(data []byte) (*Schema, error) {
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &envelope); err == nil && len(envelope.Data) > 0 {
+		data = envelope.Data
+	}
+	var result introspectionResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse introspection result: %w", err)
+	}
+	if result.Schema.QueryType == nil {
+		return nil, fmt.Errorf("introspection result has no query type")
+	}
+
+	schema := &Schema{
+		QueryType: result.Schema.QueryType.Name,
+		Types:     make(map[string]*Type, len(result.Schema.Types)),
+	}
+	if result.Schema.MutationType != nil {
+		schema.MutationType = result.Schema.MutationType.Name
+	}
+	if result.Schema.SubscriptionType != nil {
+		schema.SubscriptionType = result.Schema.SubscriptionType.Name
+	}
+	for _, t := range result.Schema.Types {
+		typ := &Type{Name: t.Name, Kind: t.Kind, Fields: make(map[string]*SchemaField, len(t.Fields))}
+		for _, f := range t.Fields {
+			fieldType, err := f.Type.typeRef()
+			if err != nil {
+				return nil, fmt.Errorf("field %s.%s: %w", t.Name, f.Name, err)
+			}
+			field := &SchemaField{Name: f.Name, Type: fieldType, Args: make(map[string]*TypeRef, len(f.Args))}
+			for _, a := range f.Args {
+				argType, err := a.Type.typeRef()
+				if err != nil {
+					return nil, fmt.Errorf("argument %s of %s.%s: %w", a.Name, t.Name, f.Name, err)
+				}
+				field.Args[a.Name] = argType
+			}
+			typ.Fields[f.Name] = field
+		}
+		schema.Types[t.Name] = typ
+	}
+	return schema, nil
+}
+
+// rootType is the type the selections of an operation of the given kind start from.
+func (s *Schema) rootType(operation string) string {
+	switch operation {
+	case "mutation":
+		return s.MutationType
+	case "subscription":
+		return s.SubscriptionType
+	default:
+		return s.QueryType
+	}
+}
+
+// isConnection reports whether a type is a Relay connection, which GitHub only returns a page of at a time.
+func (s *Schema) isConnection(typeName string) bool {
+	t, ok := s.Types[typeName]
+	if !ok || t.Kind != "OBJECT" || !strings.HasSuffix(typeName, "Connection") {
+		return false
+	}
+	_, hasPageInfo := t.Fields["pageInfo"]
+	return hasPageInfo
+}
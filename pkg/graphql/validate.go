@@ -0,0 +1,310 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxPageSize is the most items GitHub returns for a connection in one request.
+const maxPageSize = 100
+
+// Limits bounds the operations Validate accepts.
+type Limits struct {
+	// AllowMutations lets mutations through, otherwise only queries are accepted
+	AllowMutations bool
+
+	// MaxCost caps the estimated cost of an operation, zero means no cap
+	MaxCost int
+}
+
+// Analysis is what Validate found out about the operation it checked.
+type Analysis struct {
+	Operation *Operation
+
+	// Cost estimates how many nodes the operation requests: each connection counts for its page size
+	// times the page sizes of the connections it is nested in, as GitHub computes its node limit
+	Cost int
+}
+
+// ValidationError lists the problems Validate found in a document.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid document: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks the operation of a document that a request with the given operation name and variables
+// would run against the schema: that its fields and arguments exist, its variables are declared and given,
+// its connections are paginated, and that it stays within the limits.
+func Validate(doc *Document, schema *Schema, operationName string, variables map[string]any, limits Limits) (*Analysis, error) {
+	operation, err := selectOperation(doc, operationName)
+	if err != nil {
+		return nil, err
+	}
+	switch operation.Type {
+	case "subscription":
+		return nil, &ValidationError{Problems: []string{"subscriptions are not supported"}}
+	case "mutation":
+		if !limits.AllowMutations {
+			return nil, &ValidationError{Problems: []string{"mutations are not allowed, only queries can be run"}}
+		}
+	}
+	root := schema.rootType(operation.Type)
+	if _, ok := schema.Types[root]; !ok {
+		return nil, &ValidationError{Problems: []string{fmt.Sprintf("the schema does not support %s operations", operation.Type)}}
+	}
+
+	v := &validator{
+		schema:    schema,
+		doc:       doc,
+		variables: variables,
+		declared:  map[string]*VariableDefinition{},
+		seen:      map[string]bool{},
+		visiting:  map[string]bool{},
+	}
+	for _, definition := range operation.Variables {
+		v.checkVariable(definition)
+	}
+	cost := v.checkSelections(root, operation.SelectionSet, 1)
+	if limits.MaxCost > 0 && cost > limits.MaxCost {
+		v.problem("the estimated cost of %d nodes exceeds the limit of %d; request smaller pages with first or last", cost, limits.MaxCost)
+	}
+	if len(v.problems) > 0 {
+		return nil, &ValidationError{Problems: v.problems}
+	}
+	return &Analysis{Operation: operation, Cost: cost}, nil
+}
+
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, &ValidationError{Problems: []string{"the document has several operations, an operation name is required"}}
+		}
+		return doc.Operations[0], nil
+	}
+	for _, operation := range doc.Operations {
+		if operation.Name == name {
+			return operation, nil
+		}
+	}
+	return nil, &ValidationError{Problems: []string{fmt.Sprintf("the document has no operation named %s", name)}}
+}
+
+type validator struct {
+	schema    *Schema
+	doc       *Document
+	variables map[string]any
+	declared  map[string]*VariableDefinition
+	problems  []string
+	seen      map[string]bool
+	visiting  map[string]bool
+}
+
+// problem records a problem once, as fragments spread in several places would report theirs repeatedly.
+func (v *validator) problem(format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	if v.seen[message] {
+		return
+	}
+	v.seen[message] = true
+	v.problems = append(v.problems, message)
+}
+
+func (v *validator) problemAt(selection Selection, format string, args ...any) {
+	line, column := selection.position()
+	v.problem("%d:%d: %s", line, column, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) checkVariable(definition *VariableDefinition) {
+	if _, ok := v.declared[definition.Name]; ok {
+		v.problem("variable $%s is declared more than once", definition.Name)
+		return
+	}
+	v.declared[definition.Name] = definition
+	typ, ok := v.schema.Types[definition.Type.NamedType()]
+	if !ok {
+		v.problem("variable $%s has unknown type %s", definition.Name, definition.Type.NamedType())
+		return
+	}
+	if typ.composite() {
+		v.problem("variable $%s can't be of output type %s", definition.Name, typ.Name)
+	}
+	if definition.Type.NonNull && definition.DefaultValue == nil {
+		if value, given := v.variables[definition.Name]; !given || value == nil {
+			v.problem("variable $%s of type %s is required", definition.Name, definition.Type)
+		}
+	}
+}
+
+// checkSelections checks selections on a value of the parent type, which is requested multiplier times,
+// and returns their estimated cost.
+func (v *validator) checkSelections(parent string, selections []Selection, multiplier int) int {
+	parentType := v.schema.Types[parent]
+	cost := 0
+	for _, selection := range selections {
+		switch s := selection.(type) {
+		case *Field:
+			cost += v.checkField(parentType, s, multiplier)
+		case *InlineFragment:
+			condition := parent
+			if s.TypeCondition != "" {
+				if !v.checkTypeCondition(s, s.TypeCondition) {
+					continue
+				}
+				condition = s.TypeCondition
+			}
+			cost += v.checkSelections(condition, s.SelectionSet, multiplier)
+		case *FragmentSpread:
+			fragment, ok := v.doc.Fragments[s.Name]
+			if !ok {
+				v.problemAt(s, "unknown fragment %s", s.Name)
+				continue
+			}
+			if v.visiting[s.Name] {
+				v.problemAt(s, "fragment %s spreads itself", s.Name)
+				continue
+			}
+			if !v.checkTypeCondition(s, fragment.TypeCondition) {
+				continue
+			}
+			v.visiting[s.Name] = true
+			cost += v.checkSelections(fragment.TypeCondition, fragment.SelectionSet, multiplier)
+			delete(v.visiting, s.Name)
+		}
+	}
+	return cost
+}
+
+func (v *validator) checkTypeCondition(selection Selection, name string) bool {
+	typ, ok := v.schema.Types[name]
+	if !ok {
+		v.problemAt(selection, "unknown type %s", name)
+		return false
+	}
+	if !typ.composite() {
+		v.problemAt(selection, "fragments can't be on %s type %s", strings.ToLower(typ.Kind), name)
+		return false
+	}
+	return true
+}
+
+func (v *validator) checkField(parent *Type, field *Field, multiplier int) int {
+	for _, argument := range field.Arguments {
+		v.checkVariableUses(field, argument.Value)
+	}
+	if field.Name == "__typename" {
+		if field.SelectionSet != nil {
+			v.problemAt(field, "__typename has no fields to select")
+		}
+		return 0
+	}
+	// Introspection is cheap and GitHub validates it fully, these fields are missing from the introspected types
+	if (field.Name == "__schema" || field.Name == "__type") && parent.Name == v.schema.QueryType {
+		return 0
+	}
+
+	definition, ok := parent.Fields[field.Name]
+	if !ok {
+		if parent.Kind == "UNION" {
+			v.problemAt(field, "%s is a union, select %s of one of its members in a fragment", parent.Name, field.Name)
+		} else {
+			v.problemAt(field, "type %s has no field %s", parent.Name, field.Name)
+		}
+		return 0
+	}
+	given := map[string]*Value{}
+	for _, argument := range field.Arguments {
+		if _, ok := definition.Args[argument.Name]; !ok {
+			v.problemAt(field, "field %s.%s has no argument %s", parent.Name, field.Name, argument.Name)
+		}
+		given[argument.Name] = argument.Value
+	}
+
+	named := definition.Type.NamedType()
+	typ, ok := v.schema.Types[named]
+	if !ok {
+		return 0
+	}
+	if !typ.composite() {
+		if field.SelectionSet != nil {
+			v.problemAt(field, "field %s.%s is a %s and has no fields to select", parent.Name, field.Name, named)
+		}
+		return 0
+	}
+	if field.SelectionSet == nil {
+		v.problemAt(field, "field %s.%s of type %s needs a selection of its fields", parent.Name, field.Name, named)
+		return 0
+	}
+
+	cost := 0
+	if v.schema.isConnection(named) {
+		page, ok := v.pageSize(given)
+		switch {
+		case !ok:
+			v.problemAt(field, "connection %s.%s needs first or last to set the page size", parent.Name, field.Name)
+			page = maxPageSize
+		case page < 0 || page > maxPageSize:
+			v.problemAt(field, "the page size of connection %s.%s must be between 0 and %d", parent.Name, field.Name, maxPageSize)
+			page = maxPageSize
+		}
+		multiplier *= page
+		cost = multiplier
+	}
+	return cost + v.checkSelections(named, field.SelectionSet, multiplier)
+}
+
+// pageSize reads the first or last argument of a connection, resolving variables.
+func (v *validator) pageSize(arguments map[string]*Value) (int, bool) {
+	for _, name := range []string{"first", "last"} {
+		value, ok := arguments[name]
+		if !ok {
+			continue
+		}
+		if size, ok := v.intValue(value); ok {
+			return size, true
+		}
+	}
+	return 0, false
+}
+
+func (v *validator) intValue(value *Value) (int, bool) {
+	switch value.Kind {
+	case IntValue:
+		n, err := strconv.Atoi(value.Raw)
+		return n, err == nil
+	case VariableValue:
+		switch given := v.variables[value.Raw].(type) {
+		case float64:
+			if given == float64(int(given)) {
+				return int(given), true
+			}
+		case int:
+			return given, true
+		case nil:
+			if definition, ok := v.declared[value.Raw]; ok && definition.DefaultValue != nil {
+				return v.intValue(definition.DefaultValue)
+			}
+		}
+	}
+	return 0, false
+}
+
+func (v *validator) checkVariableUses(field *Field, value *Value) {
+	switch value.Kind {
+	case VariableValue:
+		if _, ok := v.declared[value.Raw]; !ok {
+			v.problemAt(field, "variable $%s is not declared", value.Raw)
+		}
+	case ListValue:
+		for _, item := range value.List {
+			v.checkVariableUses(field, item)
+		}
+	case ObjectValue:
+		for _, item := range value.Fields {
+			v.checkVariableUses(field, item.Value)
+		}
+	}
+}
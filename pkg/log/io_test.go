@@ -2,10 +2,10 @@ package log
 
 import (
 	"bytes"
+	"log/slog"
 	"strings"
 	"testing"
 
-	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,11 +17,7 @@ func TestLoggedReadWriter(t *testing.T) {
 
 		// Create logger with buffer to capture output
 		var logBuffer bytes.Buffer
-		logger := log.New()
-		logger.SetOutput(&logBuffer)
-		logger.SetFormatter(&log.TextFormatter{
-			DisableTimestamp: true,
-		})
+		logger := slog.New(slog.NewTextHandler(&logBuffer, nil))
 
 		lrw := NewIOLogger(reader, nil, logger)
 
@@ -33,7 +29,7 @@ func TestLoggedReadWriter(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, len(inputData), n)
 		assert.Equal(t, inputData, string(buf[:n]))
-		assert.Contains(t, logBuffer.String(), "[stdin]")
+		assert.Contains(t, logBuffer.String(), "stream=stdin")
 		assert.Contains(t, logBuffer.String(), inputData)
 	})
 
@@ -44,11 +40,7 @@ func TestLoggedReadWriter(t *testing.T) {
 
 		// Create logger with buffer to capture output
 		var logBuffer bytes.Buffer
-		logger := log.New()
-		logger.SetOutput(&logBuffer)
-		logger.SetFormatter(&log.TextFormatter{
-			DisableTimestamp: true,
-		})
+		logger := slog.New(slog.NewTextHandler(&logBuffer, nil))
 
 		lrw := NewIOLogger(nil, &writeBuffer, logger)
 
@@ -59,7 +51,7 @@ func TestLoggedReadWriter(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, len(outputData), n)
 		assert.Equal(t, outputData, writeBuffer.String())
-		assert.Contains(t, logBuffer.String(), "[stdout]")
+		assert.Contains(t, logBuffer.String(), "stream=stdout")
 		assert.Contains(t, logBuffer.String(), outputData)
 	})
 }
@@ -0,0 +1,181 @@
+// Package log provides the logging of the server: structured loggers that redact secrets, the logging
+// of the messages of the stdio transport, and log files that rotate.
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	stdlog "log"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// The formats of New.
+const (
+	// FormatText writes each record as a line of key=value pairs
+	FormatText = "text"
+	// FormatJSON writes each record as a line of JSON
+	FormatJSON = "json"
+)
+
+// SubsystemKey is the attribute that names the part of the server a record comes from.
+const SubsystemKey = "subsystem"
+
+// New returns a logger that writes records of the given level and above to w in the given format, with
+// secrets redacted. The level is one of debug, info, warn or error.
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q, expected debug, info, warn or error", level)
+	}
+	options := &slog.HandlerOptions{Level: l}
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case FormatText, "":
+		handler = slog.NewTextHandler(w, options)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, options)
+	default:
+		return nil, fmt.Errorf("invalid log format %q, expected %s or %s", format, FormatText, FormatJSON)
+	}
+	return slog.New(redactingHandler{handler}), nil
+}
+
+// Subsystem returns a logger whose records say they come from the named part of the server.
+func Subsystem(logger *slog.Logger, name string) *slog.Logger {
+	return logger.With(SubsystemKey, name)
+}
+
+// NewStdLogger returns a logger of the standard library, such as the error log of an http.Server, that
+// writes to the logger as errors of the named part of the server.
+func NewStdLogger(logger *slog.Logger, subsystem string) *stdlog.Logger {
+	return slog.NewLogLogger(Subsystem(logger, subsystem).Handler(), slog.LevelError)
+}
+
+// redactingHandler redacts the secrets of records before handing them on: the values of secret
+// attributes, and tokens and credentials wherever they appear in messages and values.
+type redactingHandler struct {
+	slog.Handler
+}
+
+func (h redactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, RedactSecrets(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.Handler.Handle(ctx, redacted)
+}
+
+func (h redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redactAttr(a)
+	}
+	return redactingHandler{h.Handler.WithAttrs(redacted)}
+}
+
+func (h redactingHandler) WithGroup(name string) slog.Handler {
+	return redactingHandler{h.Handler.WithGroup(name)}
+}
+
+func redactAttr(a slog.Attr) slog.Attr {
+	if SecretName(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	value := a.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, RedactSecrets(value.String()))
+	case slog.KindGroup:
+		attrs := value.Group()
+		redacted := make([]any, len(attrs))
+		for i, attr := range attrs {
+			redacted[i] = redactAttr(attr)
+		}
+		return slog.Group(a.Key, redacted...)
+	case slog.KindAny:
+		if redacted, ok := redactValue(value.Any()); ok {
+			return slog.Any(a.Key, redacted)
+		}
+		// Errors and the like are written out as text, which may well hold a token
+		if err, ok := value.Any().(error); ok {
+			return slog.String(a.Key, RedactSecrets(err.Error()))
+		}
+		if stringer, ok := value.Any().(fmt.Stringer); ok {
+			return slog.String(a.Key, RedactSecrets(stringer.String()))
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: value}
+}
+
+// redactValue returns a copy of headers, maps and slices with the values of secret keys redacted, and
+// secrets redacted from the other strings they hold. It reports false for values of other types.
+func redactValue(v any) (any, bool) {
+	switch v := v.(type) {
+	case http.Header:
+		return http.Header(redactStringsMap(v)), true
+	case map[string][]string:
+		return redactStringsMap(v), true
+	case map[string]string:
+		redacted := make(map[string]string, len(v))
+		for key, s := range v {
+			redacted[key] = redactField(key, s)
+		}
+		return redacted, true
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, value := range v {
+			redacted[key] = Redacted
+			if !SecretName(key) {
+				redacted[key] = redactAny(value)
+			}
+		}
+		return redacted, true
+	case []any:
+		redacted := make([]any, len(v))
+		for i, value := range v {
+			redacted[i] = redactAny(value)
+		}
+		return redacted, true
+	case []string:
+		redacted := make([]string, len(v))
+		for i, s := range v {
+			redacted[i] = RedactSecrets(s)
+		}
+		return redacted, true
+	}
+	return nil, false
+}
+
+// redactAny redacts a value held by a map or slice.
+func redactAny(v any) any {
+	if s, ok := v.(string); ok {
+		return RedactSecrets(s)
+	}
+	if redacted, ok := redactValue(v); ok {
+		return redacted
+	}
+	return v
+}
+
+func redactStringsMap(m map[string][]string) map[string][]string {
+	redacted := make(map[string][]string, len(m))
+	for key, values := range m {
+		redacted[key] = make([]string, len(values))
+		for i, s := range values {
+			redacted[key][i] = redactField(key, s)
+		}
+	}
+	return redacted
+}
+
+// redactField redacts the value of a field, all of it when the field is secret.
+func redactField(key, s string) string {
+	if SecretName(key) {
+		return Redacted
+	}
+	return RedactSecrets(s)
+}
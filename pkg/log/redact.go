@@ -0,0 +1,39 @@
+package log
+
+import (
+	"regexp"
+)
+
+// Redacted replaces secrets.
+const Redacted = "[REDACTED]"
+
+// secretNamePattern matches the names of values that are secret, such as the secret of a webhook, an
+// Authorization or X-GitHub-Token header, or the github_token of the _meta of a tool call.
+var secretNamePattern = regexp.MustCompile(`(?i)(?:^|[_-])(?:secret|token|password|passphrase|credentials?|private_?key|api_?key|authorization)$`)
+
+// secretValuePattern matches GitHub tokens, JSON web tokens such as those of GitHub Apps, and private keys
+// wherever they appear in values.
+var secretValuePattern = regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,}|eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,})\b|-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?(?:-----END [A-Z ]*PRIVATE KEY-----|$)`)
+
+// credentialsPattern matches the credentials of Authorization headers written out in values, such as in
+// a dump of a request, whatever kind of token they are, as well as classic tokens of 40 hex digits that
+// follow the word token. Other runs of 40 hex digits are left alone, as they are commit SHAs.
+var credentialsPattern = regexp.MustCompile(`(?i)(\bauthorization["']?\s*[:=]\s*["']?(?:(?:bearer|token|basic)\s+)?)[^\s"',]+|(\bBearer\s+)[A-Za-z0-9._~+/=-]+|(\btoken\s+)[0-9a-f]{40}\b`)
+
+// secretFieldPattern matches the values of secret fields written out in values: JSON members such as
+// "github_token":"…" in a logged message, and pairs such as GITHUB_PERSONAL_ACCESS_TOKEN=… or a
+// X-GitHub-Token: … header.
+var secretFieldPattern = regexp.MustCompile(`(?i)("[\w-]*(?:secret|token|password|passphrase|credentials?|private_?key|api_?key)"\s*:\s*")(?:[^"\\]|\\.)*(")|\b([\w-]*(?:secret|token|password|passphrase|private_?key|api_?key)\s*[=:]\s*)[^\s&"',;]+`)
+
+// SecretName reports whether a value of the given name, such as an argument or a header, is secret.
+func SecretName(name string) bool {
+	return secretNamePattern.MatchString(name)
+}
+
+// RedactSecrets replaces the GitHub tokens, private keys, credentials of Authorization headers and
+// values of secret fields in a string.
+func RedactSecrets(s string) string {
+	s = secretValuePattern.ReplaceAllString(s, Redacted)
+	s = credentialsPattern.ReplaceAllString(s, "${1}${2}${3}"+Redacted)
+	return secretFieldPattern.ReplaceAllString(s, "${1}${3}"+Redacted+"${2}")
+}
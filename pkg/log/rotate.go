@@ -0,0 +1,203 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is appended to the log file name to name its backups. It sorts chronologically and
+// contains no characters that are invalid in file names.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotateOptions configures when a RotatingFile is rotated and which backups it keeps.
+type RotateOptions struct {
+	// MaxSize is the size in bytes above which the file is rotated
+	MaxSize int64
+
+	// MaxBackups is the number of rotated files to keep, zero keeps all of them
+	MaxBackups int
+
+	// MaxAge is how long rotated files are kept, zero keeps them regardless of their age
+	MaxAge time.Duration
+}
+
+// RotatingFile is an io.WriteCloser that appends to a file and, once the file would grow beyond
+// RotateOptions.MaxSize, renames it to a timestamped backup and starts a new one. Old backups are
+// pruned on every rotation.
+type RotatingFile struct {
+	path string
+	opts RotateOptions
+	now  func() time.Time
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewRotatingFile opens path for appending, creating it if needed.
+func NewRotatingFile(path string, opts RotateOptions) (*RotatingFile, error) {
+	if opts.MaxSize <= 0 {
+		return nil, fmt.Errorf("max size must be positive, got %d", opts.MaxSize)
+	}
+
+	f := &RotatingFile{
+		path: path,
+		opts: opts,
+		now:  time.Now,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// Write writes p to the file, rotating it first if p would take it past the maximum size. A single
+// write larger than the maximum size still goes to one file, so that log entries are never split.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if f.size > 0 && f.size+int64(len(p)) > f.opts.MaxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	f.file = nil
+
+	if err := os.Rename(f.path, f.backupPath()); err != nil {
+		// Keep writing to the file as it is rather than failing every later write
+		if openErr := f.open(); openErr != nil {
+			return fmt.Errorf("failed to rotate log file: %w", errors.Join(err, openErr))
+		}
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	// Pruning is best effort, a backup that can't be removed now is retried on the next rotation
+	f.prune()
+	return nil
+}
+
+// backupPath returns the name of the next backup. Backups of rotations within the same millisecond are
+// told apart by a counter, so that none overwrites another.
+func (f *RotatingFile) backupPath() string {
+	backup := f.path + "." + f.now().UTC().Format(backupTimeFormat)
+	path := backup
+	for n := 1; ; n++ {
+		if _, err := os.Lstat(path); os.IsNotExist(err) {
+			return path
+		}
+		path = fmt.Sprintf("%s.%d", backup, n)
+	}
+}
+
+// parseBackup returns the time and the counter of a backup name, without the log file name before them.
+func parseBackup(name string) (time.Time, int, bool) {
+	n := 0
+	if i := strings.LastIndexByte(name, '.'); i > strings.IndexByte(name, '.') {
+		counter, err := strconv.Atoi(name[i+1:])
+		if err != nil || counter <= 0 {
+			return time.Time{}, 0, false
+		}
+		name, n = name[:i], counter
+	}
+	t, err := time.Parse(backupTimeFormat, name)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	return t, n, true
+}
+
+// prune removes the backups beyond MaxBackups and those older than MaxAge.
+func (f *RotatingFile) prune() {
+	if f.opts.MaxBackups <= 0 && f.opts.MaxAge <= 0 {
+		return
+	}
+
+	type backup struct {
+		path    string
+		time    time.Time
+		counter int
+	}
+
+	matches, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return
+	}
+	backups := make([]backup, 0, len(matches))
+	for _, match := range matches {
+		t, counter, ok := parseBackup(strings.TrimPrefix(match, f.path+"."))
+		if !ok {
+			// Not one of ours
+			continue
+		}
+		backups = append(backups, backup{path: match, time: t, counter: counter})
+	}
+
+	// Newest first
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].time.Equal(backups[j].time) {
+			return backups[i].counter > backups[j].counter
+		}
+		return backups[i].time.After(backups[j].time)
+	})
+
+	now := f.now().UTC()
+	for i, b := range backups {
+		if (f.opts.MaxBackups > 0 && i >= f.opts.MaxBackups) || (f.opts.MaxAge > 0 && now.Sub(b.time) > f.opts.MaxAge) {
+			_ = os.Remove(b.path)
+		}
+	}
+}
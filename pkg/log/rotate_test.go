@@ -0,0 +1,154 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	t.Run("rotates once the file would exceed the maximum size", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "server.log")
+		f, err := NewRotatingFile(path, RotateOptions{MaxSize: 10})
+		require.NoError(t, err)
+		defer func() { _ = f.Close() }()
+
+		now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		f.now = func() time.Time { return now }
+
+		_, err = f.Write([]byte("12345678"))
+		require.NoError(t, err)
+		_, err = f.Write([]byte("abcd"))
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "abcd", string(content))
+
+		backup, err := os.ReadFile(path + ".2025-06-01T12-00-00.000")
+		require.NoError(t, err)
+		assert.Equal(t, "12345678", string(backup))
+	})
+
+	t.Run("keeps every backup of rotations within the same millisecond", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "server.log")
+		f, err := NewRotatingFile(path, RotateOptions{MaxSize: 4, MaxBackups: 2})
+		require.NoError(t, err)
+		defer func() { _ = f.Close() }()
+
+		now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		f.now = func() time.Time { return now }
+
+		for _, line := range []string{"aaaa", "bbbb", "cccc", "dddd"} {
+			_, err = f.Write([]byte(line))
+			require.NoError(t, err)
+		}
+
+		// The first backup is the oldest, and pruned
+		backup := path + ".2025-06-01T12-00-00.000"
+		assert.NoFileExists(t, backup)
+		for name, content := range map[string]string{backup + ".1": "bbbb", backup + ".2": "cccc", path: "dddd"} {
+			b, err := os.ReadFile(name)
+			require.NoError(t, err)
+			assert.Equal(t, content, string(b))
+		}
+	})
+
+	t.Run("keeps writing when the file can't be rotated", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "server.log")
+		f, err := NewRotatingFile(path, RotateOptions{MaxSize: 4})
+		require.NoError(t, err)
+		defer func() { _ = f.Close() }()
+
+		_, err = f.Write([]byte("full"))
+		require.NoError(t, err)
+		// A file that is gone can't be renamed
+		require.NoError(t, os.Remove(path))
+
+		_, err = f.Write([]byte("lost"))
+		assert.ErrorContains(t, err, "failed to rotate log file")
+		_, err = f.Write([]byte("next"))
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "next", string(content))
+		assert.Empty(t, backups(t, path))
+	})
+
+	t.Run("continues an existing file and keeps oversized writes whole", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "server.log")
+		require.NoError(t, os.WriteFile(path, []byte("existing\n"), 0600))
+
+		f, err := NewRotatingFile(path, RotateOptions{MaxSize: 10})
+		require.NoError(t, err)
+		defer func() { _ = f.Close() }()
+
+		_, err = f.Write([]byte("a line that is longer than the maximum size\n"))
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "a line that is longer than the maximum size\n", string(content))
+		assert.Len(t, backups(t, path), 1)
+	})
+
+	t.Run("prunes backups beyond the maximum count and age", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "server.log")
+		now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+		// Pre-existing backups from earlier rotations, and a file that isn't one
+		for _, age := range []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour, 30 * 24 * time.Hour} {
+			name := path + "." + now.Add(-age).Format(backupTimeFormat)
+			require.NoError(t, os.WriteFile(name, []byte("old"), 0600))
+		}
+		require.NoError(t, os.WriteFile(path+".notes", []byte("keep"), 0600))
+
+		f, err := NewRotatingFile(path, RotateOptions{MaxSize: 4, MaxBackups: 3, MaxAge: 7 * 24 * time.Hour})
+		require.NoError(t, err)
+		defer func() { _ = f.Close() }()
+		f.now = func() time.Time { return now }
+
+		_, err = f.Write([]byte("full"))
+		require.NoError(t, err)
+		_, err = f.Write([]byte("next"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			path + "." + now.Add(-2*time.Hour).Format(backupTimeFormat),
+			path + "." + now.Add(-time.Hour).Format(backupTimeFormat),
+			path + "." + now.Format(backupTimeFormat),
+		}, backups(t, path))
+		assert.FileExists(t, path+".notes")
+	})
+
+	t.Run("rejects a non-positive maximum size", func(t *testing.T) {
+		_, err := NewRotatingFile(filepath.Join(t.TempDir(), "server.log"), RotateOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("write after close fails", func(t *testing.T) {
+		f, err := NewRotatingFile(filepath.Join(t.TempDir(), "server.log"), RotateOptions{MaxSize: 10})
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		_, err = f.Write([]byte("late"))
+		assert.ErrorIs(t, err, os.ErrClosed)
+	})
+}
+
+// backups returns the sorted paths of the rotated backups of path.
+func backups(t *testing.T, path string) []string {
+	t.Helper()
+
+	matches, err := filepath.Glob(path + ".????-??-??T??-??-??.???")
+	require.NoError(t, err)
+	sort.Strings(matches)
+	return matches
+}
@@ -0,0 +1,388 @@
+// Package metrics collects metrics of the server and serves them in the Prometheus text exposition
+// format: the calls of each tool and how long they take, the requests to the GitHub API by endpoint and
+// status, the rate limit that is left, how often responses come from the cache and how many sessions are
+// active. The format is written out here rather than with the Prometheus client library, as the handful
+// of metrics the server has doesn't need it.
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/cache"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Path is where the metrics are served.
+const Path = "/metrics"
+
+// contentType is the content type of the Prometheus text exposition format.
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// durationBuckets are the upper bounds in seconds of the buckets of the tool call durations, from quick
+// lookups to tools that page through large listings.
+var durationBuckets = []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// kind is the type of a metric.
+type kind string
+
+const (
+	counter   kind = "counter"
+	gauge     kind = "gauge"
+	histogram kind = "histogram"
+)
+
+// series is the value of a metric for one set of label values.
+type series struct {
+	labels []string
+	value  float64
+	// buckets, sum and count are those of histograms, buckets holds the observations at most each bound
+	buckets []uint64
+	sum     float64
+	count   uint64
+}
+
+// family is a metric with all of its series.
+type family struct {
+	name   string
+	help   string
+	kind   kind
+	labels []string
+	series map[string]*series
+}
+
+// get returns the series of the given label values, creating it if there is none.
+func (f *family) get(values ...string) *series {
+	key := strings.Join(values, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labels: values}
+		if f.kind == histogram {
+			s.buckets = make([]uint64, len(durationBuckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// Metrics holds the metrics of a server. It is safe for concurrent use.
+type Metrics struct {
+	mu       sync.Mutex
+	families []*family
+
+	toolCalls          *family
+	toolDuration       *family
+	requests           *family
+	rateLimitRemaining *family
+	cacheRequests      *family
+	sessions           *family
+
+	now func() time.Time
+}
+
+// New returns empty metrics.
+func New() *Metrics {
+	m := &Metrics{now: time.Now}
+	m.toolCalls = m.add("github_mcp_tool_calls_total", "Tool calls by tool and outcome.", counter, "tool", "outcome")
+	m.toolDuration = m.add("github_mcp_tool_call_duration_seconds", "How long tool calls take.", histogram, "tool")
+	m.requests = m.add("github_mcp_github_requests_total", "Requests to the GitHub API by method, endpoint and status.", counter, "method", "endpoint", "status")
+	m.rateLimitRemaining = m.add("github_mcp_rate_limit_remaining", "Requests left in the GitHub API rate limit, as last reported for each resource.", gauge, "resource")
+	m.cacheRequests = m.add("github_mcp_cache_requests_total", "GET requests by whether the response was served from the cache.", counter, "result")
+	m.sessions = m.add("github_mcp_active_sessions", "Sessions of MCP clients that are connected.", gauge)
+	m.sessions.get()
+	return m
+}
+
+func (m *Metrics) add(name, help string, k kind, labels ...string) *family {
+	f := &family{name: name, help: help, kind: k, labels: labels, series: map[string]*series{}}
+	m.families = append(m.families, f)
+	return f
+}
+
+// ToolHandlerMiddleware counts the tool calls and measures how long they take. Calls whose result is an
+// error count as "error" and calls that fail as "failure", the others as "success".
+func (m *Metrics) ToolHandlerMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := m.now()
+		result, err := next(ctx, request)
+		elapsed := m.now().Sub(start).Seconds()
+
+		outcome := "success"
+		switch {
+		case err != nil:
+			outcome = "failure"
+		case result != nil && result.IsError:
+			outcome = "error"
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.toolCalls.get(request.Params.Name, outcome).value++
+		s := m.toolDuration.get(request.Params.Name)
+		for i, bound := range durationBuckets {
+			if elapsed <= bound {
+				s.buckets[i]++
+			}
+		}
+		s.sum += elapsed
+		s.count++
+		return result, err
+	}
+}
+
+// OnRegisterSession counts a session that started. It is a hook of the MCP server.
+func (m *Metrics) OnRegisterSession(_ context.Context, _ server.ClientSession) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions.get().value++
+}
+
+// OnUnregisterSession counts a session that ended. It is a hook of the MCP server.
+func (m *Metrics) OnUnregisterSession(_ context.Context, _ server.ClientSession) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions.get().value--
+}
+
+// Transport is an http.RoundTripper that counts the requests to the GitHub API and records the rate limit
+// that their responses report.
+type Transport struct {
+	Transport http.RoundTripper
+	Metrics   *Metrics
+	// Cached tells that the transport wraps the cache, whose hits are then counted
+	Cached bool
+}
+
+// NewTransport wraps the given transport, falling back to http.DefaultTransport when it is nil.
+func NewTransport(transport http.RoundTripper, metrics *Metrics, cached bool) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Transport{Transport: transport, Metrics: metrics, Cached: cached}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Transport.RoundTrip(req)
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	m := t.Metrics
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests.get(req.Method, Endpoint(req.URL.Path), status).value++
+	if err != nil {
+		return resp, err
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		resource := resp.Header.Get("X-RateLimit-Resource")
+		if resource == "" {
+			resource = ratelimit.Resource(req)
+		}
+		m.rateLimitRemaining.get(resource).value = float64(remaining)
+	}
+	if t.Cached && req.Method == http.MethodGet {
+		result := "miss"
+		if resp.Header.Get(cache.FromCacheHeader) != "" {
+			result = "hit"
+		}
+		m.cacheRequests.get(result).value++
+	}
+	return resp, nil
+}
+
+// namedSegments are the collections of the GitHub API whose items are addressed by a name rather than a
+// number, with the placeholder of that name.
+var namedSegments = map[string]string{
+	"assignees":     "{username}",
+	"branches":      "{branch}",
+	"collaborators": "{username}",
+	"commits":       "{ref}",
+	"enterprises":   "{enterprise}",
+	"environments":  "{environment}",
+	"labels":        "{name}",
+	"members":       "{username}",
+	"memberships":   "{username}",
+	"orgs":          "{org}",
+	"secrets":       "{name}",
+	"tags":          "{tag}",
+	"teams":         "{team_slug}",
+	"users":         "{username}",
+	"variables":     "{name}",
+	"workflows":     "{workflow_id}",
+}
+
+// restSegments are the parts of paths of the GitHub API after which the rest of the path is a single
+// parameter, such as the path of a file, with the placeholder of that parameter.
+var restSegments = map[string]string{
+	"compare":  "{basehead}",
+	"contents": "{path}",
+	"ref":      "{ref}",
+	"refs":     "{ref}",
+	"tarball":  "{ref}",
+	"trees":    "{tree_sha}",
+	"zipball":  "{ref}",
+}
+
+// Endpoint returns the endpoint of a path of the GitHub API, whose parameters are replaced by
+// placeholders so that the requests of all repositories, issues and so on count towards the same one.
+func Endpoint(path string) string {
+	path = strings.TrimPrefix(path, "/api/v3")
+	path = strings.TrimPrefix(path, "/api")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) == 1 && segments[0] == "" {
+		return "/"
+	}
+
+	out := make([]string, 0, len(segments))
+	for i := 0; i < len(segments); i++ {
+		segment := segments[i]
+		switch {
+		case i > 0 && restSegments[segments[i-1]] != "":
+			out = append(out, restSegments[segments[i-1]])
+			return "/" + strings.Join(out, "/")
+		case segment == "repos" && i+2 < len(segments):
+			out = append(out, segment, "{owner}", "{repo}")
+			i += 2
+			continue
+		case i > 0 && namedSegments[segments[i-1]] != "":
+			out = append(out, namedSegments[segments[i-1]])
+		case isNumber(segment):
+			out = append(out, "{id}")
+		case isHash(segment):
+			out = append(out, "{sha}")
+		default:
+			out = append(out, segment)
+		}
+	}
+	return "/" + strings.Join(out, "/")
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+// isHash reports whether a segment is a commit SHA or the ID of a gist.
+func isHash(s string) bool {
+	if len(s) < 20 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	buf := bufio.NewWriter(w)
+	counted := &countingWriter{w: buf}
+	m.mu.Lock()
+	for _, f := range m.families {
+		f.write(counted)
+	}
+	m.writeHitRatio(counted)
+	m.mu.Unlock()
+	if err := buf.Flush(); err != nil {
+		return counted.n, err
+	}
+	return counted.n, nil
+}
+
+// writeHitRatio writes the share of the GET requests that were served from the cache, which is only
+// known once the cache has seen requests.
+func (m *Metrics) writeHitRatio(w io.Writer) {
+	var hits, total float64
+	for _, s := range m.cacheRequests.series {
+		total += s.value
+		if s.labels[0] == "hit" {
+			hits = s.value
+		}
+	}
+	if total == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "# HELP github_mcp_cache_hit_ratio Share of the GET requests served from the cache.\n# TYPE github_mcp_cache_hit_ratio gauge\ngithub_mcp_cache_hit_ratio %s\n", formatValue(hits/total))
+}
+
+func (f *family) write(w io.Writer) {
+	if len(f.series) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.kind)
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := f.series[key]
+		if f.kind != histogram {
+			_, _ = fmt.Fprintf(w, "%s%s %s\n", f.name, formatLabels(f.labels, s.labels), formatValue(s.value))
+			continue
+		}
+		names := slices.Concat(f.labels, []string{"le"})
+		for i, bound := range durationBuckets {
+			_, _ = fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(names, slices.Concat(s.labels, []string{formatValue(bound)})), s.buckets[i])
+		}
+		_, _ = fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(names, slices.Concat(s.labels, []string{"+Inf"})), s.count)
+		_, _ = fmt.Fprintf(w, "%s_sum%s %s\n", f.name, formatLabels(f.labels, s.labels), formatValue(s.sum))
+		_, _ = fmt.Fprintf(w, "%s_count%s %d\n", f.name, formatLabels(f.labels, s.labels), s.count)
+	}
+}
+
+// labelEscaper escapes label values as the text exposition format asks.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + labelEscaper.Replace(values[i]) + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Handler serves the metrics.
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = m.WriteTo(w)
+	})
+}
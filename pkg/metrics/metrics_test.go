@@ -0,0 +1,153 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/cache"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	srv := httptest.NewServer(m.Handler())
+	t.Cleanup(srv.Close)
+	resp, err := http.Get(srv.URL + Path)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, contentType, resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"/repos/octo-org/app/issues/42/comments":                                      "/repos/{owner}/{repo}/issues/{id}/comments",
+		"/api/v3/repos/octo-org/app/pulls":                                            "/repos/{owner}/{repo}/pulls",
+		"/repos/octo-org/app/contents/docs/README.md":                                 "/repos/{owner}/{repo}/contents/{path}",
+		"/repos/octo-org/app/git/refs/heads/main":                                     "/repos/{owner}/{repo}/git/refs/{ref}",
+		"/repos/octo-org/app/compare/main...feature":                                  "/repos/{owner}/{repo}/compare/{basehead}",
+		"/repos/octo-org/app/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e/status": "/repos/{owner}/{repo}/commits/{ref}/status",
+		"/repos/octo-org/app/git/blobs/6dcb09b5b57875f334f61aebed695e2e4193db5e":      "/repos/{owner}/{repo}/git/blobs/{sha}",
+		"/repos/octo-org/app/labels/bug":                                              "/repos/{owner}/{repo}/labels/{name}",
+		"/orgs/octo-org/teams/core/members":                                           "/orgs/{org}/teams/{team_slug}/members",
+		"/users/octocat/repos":                                                        "/users/{username}/repos",
+		"/user/repos":                                                                 "/user/repos",
+		"/search/issues":                                                              "/search/issues",
+		"/api/graphql":                                                                "/graphql",
+		"/":                                                                           "/",
+	}
+	for path, endpoint := range tests {
+		assert.Equal(t, endpoint, Endpoint(path), path)
+	}
+}
+
+func TestToolHandlerMiddleware(t *testing.T) {
+	m := New()
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	m.now = func() time.Time {
+		now = now.Add(200 * time.Millisecond)
+		return now
+	}
+	results := map[string]func() (*mcp.CallToolResult, error){
+		"get_issue":    func() (*mcp.CallToolResult, error) { return mcp.NewToolResultText("{}"), nil },
+		"create_issue": func() (*mcp.CallToolResult, error) { return mcp.NewToolResultError("not found"), nil },
+		"list_issues":  func() (*mcp.CallToolResult, error) { return nil, errors.New("canceled") },
+	}
+	handler := m.ToolHandlerMiddleware(func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return results[request.Params.Name]()
+	})
+	for _, name := range []string{"get_issue", "get_issue", "create_issue", "list_issues"} {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		_, _ = handler(context.Background(), request)
+	}
+
+	out := scrape(t, m)
+	assert.Contains(t, out, "# TYPE github_mcp_tool_calls_total counter\n")
+	assert.Contains(t, out, `github_mcp_tool_calls_total{tool="get_issue",outcome="success"} 2`+"\n")
+	assert.Contains(t, out, `github_mcp_tool_calls_total{tool="create_issue",outcome="error"} 1`+"\n")
+	assert.Contains(t, out, `github_mcp_tool_calls_total{tool="list_issues",outcome="failure"} 1`+"\n")
+	assert.Contains(t, out, "# TYPE github_mcp_tool_call_duration_seconds histogram\n")
+	assert.Contains(t, out, `github_mcp_tool_call_duration_seconds_bucket{tool="get_issue",le="0.1"} 0`+"\n")
+	assert.Contains(t, out, `github_mcp_tool_call_duration_seconds_bucket{tool="get_issue",le="0.25"} 2`+"\n")
+	assert.Contains(t, out, `github_mcp_tool_call_duration_seconds_bucket{tool="get_issue",le="+Inf"} 2`+"\n")
+	assert.Contains(t, out, `github_mcp_tool_call_duration_seconds_sum{tool="get_issue"} 0.4`+"\n")
+	assert.Contains(t, out, `github_mcp_tool_call_duration_seconds_count{tool="get_issue"} 2`+"\n")
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		if strings.HasSuffix(r.URL.Path, "/graphql") {
+			w.Header().Set("X-RateLimit-Resource", "graphql")
+			w.Header().Set("X-RateLimit-Remaining", "4000")
+		}
+		if r.URL.Path == "/repos/octo-org/app/issues/1" {
+			w.Header().Set(cache.FromCacheHeader, "1")
+		}
+		if r.URL.Path == "/repos/octo-org/app/issues/2" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	m := New()
+	client := &http.Client{Transport: NewTransport(nil, m, true)}
+	for _, path := range []string{"/repos/octo-org/app/issues/1", "/repos/octo-org/app/issues/1", "/repos/octo-org/app/issues/2", "/repos/octo-org/app/issues/3"} {
+		resp, err := client.Get(srv.URL + path)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+	resp, err := client.Post(srv.URL+"/graphql", "application/json", strings.NewReader(`{"query":"{viewer{login}}"}`))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	_, err = (&http.Client{Transport: NewTransport(nil, m, true)}).Get("http://127.0.0.1:0/user")
+	require.Error(t, err)
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `github_mcp_github_requests_total{method="GET",endpoint="/repos/{owner}/{repo}/issues/{id}",status="200"} 3`+"\n")
+	assert.Contains(t, out, `github_mcp_github_requests_total{method="GET",endpoint="/repos/{owner}/{repo}/issues/{id}",status="404"} 1`+"\n")
+	assert.Contains(t, out, `github_mcp_github_requests_total{method="POST",endpoint="/graphql",status="200"} 1`+"\n")
+	assert.Contains(t, out, `github_mcp_github_requests_total{method="GET",endpoint="/user",status="error"} 1`+"\n")
+	assert.Contains(t, out, `github_mcp_rate_limit_remaining{resource="core"} 4999`+"\n")
+	assert.Contains(t, out, `github_mcp_rate_limit_remaining{resource="graphql"} 4000`+"\n")
+	assert.Contains(t, out, `github_mcp_cache_requests_total{result="hit"} 2`+"\n")
+	assert.Contains(t, out, `github_mcp_cache_requests_total{result="miss"} 2`+"\n")
+	assert.Contains(t, out, "github_mcp_cache_hit_ratio 0.5\n")
+}
+
+func TestTransportWithoutCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	m := New()
+	resp, err := (&http.Client{Transport: NewTransport(nil, m, false)}).Get(srv.URL + "/user")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	out := scrape(t, m)
+	assert.NotContains(t, out, "github_mcp_cache", "without a cache there is no hit ratio")
+	assert.NotContains(t, out, "github_mcp_rate_limit_remaining", "responses without rate limit headers report none")
+}
+
+func TestSessions(t *testing.T) {
+	m := New()
+	assert.Contains(t, scrape(t, m), "github_mcp_active_sessions 0\n")
+	m.OnRegisterSession(context.Background(), nil)
+	m.OnRegisterSession(context.Background(), nil)
+	m.OnUnregisterSession(context.Background(), nil)
+	assert.Contains(t, scrape(t, m), "# TYPE github_mcp_active_sessions gauge\ngithub_mcp_active_sessions 1\n")
+}
+
+func TestLabelsAreEscaped(t *testing.T) {
+	assert.Equal(t, `{tool="a\"b\\c\nd"}`, formatLabels([]string{"tool"}, []string{"a\"b\\c\nd"}))
+}
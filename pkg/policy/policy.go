@@ -0,0 +1,278 @@
+// Package policy decides whether write tools may run, with rules that match the tool, the repository,
+// the branches and the arguments of a call. It is finer than read-only mode, which withholds every write
+// tool.
+package policy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// Action is what a rule does with the calls it matches.
+type Action string
+
+const (
+	Allow Action = "allow"
+	Deny  Action = "deny"
+)
+
+// BranchArguments are the arguments of tools that name the branches a call writes to or targets.
+var BranchArguments = []string{"branch", "ref", "base", "head"}
+
+// Rule matches calls of write tools. A call matches when every condition the rule sets matches it, a rule
+// without conditions matches every call. Patterns use path.Match syntax, where * doesn't cross a slash.
+type Rule struct {
+	// Name identifies the rule in the errors of the calls it denies
+	Name string `yaml:"name"`
+	// Tools are patterns of tool names, such as push_files or *_file
+	Tools []string `yaml:"tools"`
+	// Repos are patterns of owner/repo, such as octo-org/* or */infra, matched ignoring case. Tools that
+	// only take an owner are matched as owner/
+	Repos []string `yaml:"repos"`
+	// Branches are patterns of branch names, matched against the BranchArguments of a call without any
+	// refs/heads/ or heads/ prefix. Calls without such an argument don't match
+	Branches []string `yaml:"branches"`
+	// Arguments are values that the arguments of a call must have. Strings are patterns, other values
+	// must be equal
+	Arguments map[string]any `yaml:"arguments"`
+	// Action is Deny when empty
+	Action Action `yaml:"action"`
+	// Message explains to the caller why the call was denied
+	Message string `yaml:"message"`
+}
+
+// Policy holds the rules that calls of write tools are checked against in order. The first rule that
+// matches a call decides, and calls that no rule matches get the default action.
+type Policy struct {
+	// Default is Allow when empty
+	Default Action `yaml:"default"`
+	Rules   []Rule `yaml:"rules"`
+}
+
+// Load reads a policy from a YAML (or JSON) file.
+func Load(filePath string) (*Policy, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	var p Policy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy %s: %w", filePath, err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", filePath, err)
+	}
+	return &p, nil
+}
+
+// validate fills in the default actions and checks the actions and patterns.
+func (p *Policy) validate() error {
+	if p.Default == "" {
+		p.Default = Allow
+	}
+	if p.Default != Allow && p.Default != Deny {
+		return fmt.Errorf("default must be allow or deny, not %q", p.Default)
+	}
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		if rule.Action == "" {
+			rule.Action = Deny
+		}
+		if rule.Action != Allow && rule.Action != Deny {
+			return fmt.Errorf("%s: action must be allow or deny, not %q", rule.Name, rule.Action)
+		}
+		patterns := append(append(append([]string{}, rule.Tools...), rule.Repos...), rule.Branches...)
+		for _, value := range rule.Arguments {
+			if s, ok := value.(string); ok {
+				patterns = append(patterns, s)
+			}
+		}
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("%s: invalid pattern %q: %w", rule.Name, pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Evaluate decides about a call of a write tool. It returns the rule that decided, or nil when the
+// default action did.
+func (p *Policy) Evaluate(tool string, arguments map[string]any) (Action, *Rule) {
+	t := targetOf(tool, arguments)
+	for i := range p.Rules {
+		if p.Rules[i].matches(tool, arguments, t) {
+			return p.Rules[i].Action, &p.Rules[i]
+		}
+	}
+	return p.Default, nil
+}
+
+// target is the repository and the branches that a call writes to.
+type target struct {
+	owner    string
+	repo     string
+	branches []string
+}
+
+// targetOf returns the target of a call: the owner, repo and BranchArguments of most tools, and for
+// api_request the repository and branches that its path, query and body name.
+func targetOf(tool string, arguments map[string]any) target {
+	if tool == "api_request" {
+		return apiRequestTarget(arguments)
+	}
+	var t target
+	t.owner, _ = arguments["owner"].(string)
+	t.repo, _ = arguments["repo"].(string)
+	for _, name := range BranchArguments {
+		branch, _ := arguments[name].(string)
+		t.addBranch(branch)
+	}
+	return t
+}
+
+// addBranch adds a branch, without any refs/heads/ or heads/ prefix.
+func (t *target) addBranch(branch string) {
+	branch = strings.TrimPrefix(strings.TrimPrefix(branch, "refs/"), "heads/")
+	if branch != "" {
+		t.branches = append(t.branches, branch)
+	}
+}
+
+// apiRequestTarget returns the target of a call of api_request. The repository is the one of a path under
+// /repos/{owner}/{repo}, or the owner of one under /orgs/{org} or /users/{user}. The branches are the ones
+// of git/refs/heads/{branch} and branches/{branch} paths, of the ref and branch query parameters, and of
+// the BranchArguments fields of the body.
+func apiRequestTarget(arguments map[string]any) target {
+	var t target
+	apiPath, _ := arguments["path"].(string)
+	apiPath, rawQuery, _ := strings.Cut(apiPath, "?")
+	segments := strings.Split(strings.Trim(apiPath, "/"), "/")
+	for i, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segments[i] = unescaped
+		}
+	}
+	switch {
+	case len(segments) >= 3 && segments[0] == "repos":
+		t.owner, t.repo = segments[1], segments[2]
+		rest := segments[3:]
+		switch {
+		case len(rest) >= 4 && rest[0] == "git" && rest[1] == "refs" && rest[2] == "heads":
+			t.addBranch(strings.Join(rest[3:], "/"))
+		case len(rest) >= 2 && rest[0] == "branches":
+			t.addBranch(rest[1])
+		}
+	case len(segments) >= 2 && (segments[0] == "orgs" || segments[0] == "users"):
+		t.owner = segments[1]
+	}
+	if query, err := url.ParseQuery(rawQuery); err == nil {
+		for _, name := range []string{"ref", "branch"} {
+			t.addBranch(query.Get(name))
+		}
+	}
+	if body, ok := arguments["body"].(map[string]any); ok {
+		for _, name := range BranchArguments {
+			branch, _ := body[name].(string)
+			t.addBranch(branch)
+		}
+	}
+	return t
+}
+
+func (r *Rule) matches(tool string, arguments map[string]any, t target) bool {
+	if len(r.Tools) > 0 && !matchAny(r.Tools, tool) {
+		return false
+	}
+	if len(r.Repos) > 0 {
+		if t.owner == "" || !matchAny(r.Repos, strings.ToLower(t.owner+"/"+t.repo), strings.ToLower) {
+			return false
+		}
+	}
+	if len(r.Branches) > 0 {
+		matched := false
+		for _, branch := range t.branches {
+			if matchAny(r.Branches, branch) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for name, expected := range r.Arguments {
+		actual, ok := arguments[name]
+		if !ok || !matchValue(expected, actual) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchAny reports whether value matches one of the patterns, after normalizing the patterns.
+func matchAny(patterns []string, value string, normalize ...func(string) string) bool {
+	for _, pattern := range patterns {
+		for _, n := range normalize {
+			pattern = n(pattern)
+		}
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchValue compares the value a rule expects with the value of an argument. YAML and JSON decode
+// numbers differently, so values other than strings are compared as they print.
+func matchValue(expected, actual any) bool {
+	if pattern, ok := expected.(string); ok {
+		s, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		matched, _ := path.Match(pattern, s)
+		return matched
+	}
+	return fmt.Sprint(expected) == fmt.Sprint(actual)
+}
+
+// ToolHandlerMiddleware checks the calls of the tools that isWriteTool reports against the policy, and
+// answers the denied ones with an error result instead of running them. It must run after the middleware
+// that changes the arguments of calls, such as parsing references.
+func ToolHandlerMiddleware(p *Policy, isWriteTool func(tool string) bool) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !isWriteTool(request.Params.Name) {
+				return next(ctx, request)
+			}
+			action, rule := p.Evaluate(request.Params.Name, request.GetArguments())
+			if action == Allow {
+				return next(ctx, request)
+			}
+
+			if rule == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is denied by the policy of this server, which denies write tools unless a rule allows them", request.Params.Name)), nil
+			}
+			message := fmt.Sprintf("%s is denied by the policy rule %q of this server", request.Params.Name, rule.Name)
+			if rule.Message != "" {
+				message += ": " + rule.Message
+			}
+			return mcp.NewToolResultError(message), nil
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `
+rules:
+  - name: no-force-updates
+    tools: [update_git_ref]
+    arguments:
+      force: true
+    message: Force updates rewrite history
+  - name: sandbox
+    repos: ["Octo-Org/sandbox-*"]
+    action: allow
+  - name: protect-main
+    tools: [create_or_update_file, push_files, delete_file, update_git_ref]
+    branches: [main, release/*]
+  - name: protect-api-main
+    tools: [api_request]
+    branches: [main, release/*]
+  - name: squash-only
+    tools: [merge_pull_request]
+    arguments:
+      merge_method: "[^s]*"
+`
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestEvaluate(t *testing.T) {
+	p, err := Load(writePolicy(t, testPolicy))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		tool         string
+		arguments    map[string]any
+		expected     Action
+		expectedRule string
+	}{
+		{
+			name:         "force ref update",
+			tool:         "update_git_ref",
+			arguments:    map[string]any{"owner": "octo-org", "repo": "sandbox-1", "ref": "heads/feature", "force": true},
+			expected:     Deny,
+			expectedRule: "no-force-updates",
+		},
+		{
+			name:      "ref update without force",
+			tool:      "update_git_ref",
+			arguments: map[string]any{"owner": "octo-org", "repo": "app", "ref": "refs/heads/feature", "force": false},
+			expected:  Allow,
+		},
+		{
+			name:         "push to main",
+			tool:         "push_files",
+			arguments:    map[string]any{"owner": "octo-org", "repo": "app", "branch": "main"},
+			expected:     Deny,
+			expectedRule: "protect-main",
+		},
+		{
+			name:         "ref update of a release branch",
+			tool:         "update_git_ref",
+			arguments:    map[string]any{"owner": "octo-org", "repo": "app", "ref": "refs/heads/release/1.0"},
+			expected:     Deny,
+			expectedRule: "protect-main",
+		},
+		{
+			name:         "push to main of a sandbox, repositories ignoring case",
+			tool:         "push_files",
+			arguments:    map[string]any{"owner": "OCTO-ORG", "repo": "sandbox-2", "branch": "main"},
+			expected:     Allow,
+			expectedRule: "sandbox",
+		},
+		{
+			name:      "push to a feature branch",
+			tool:      "push_files",
+			arguments: map[string]any{"owner": "octo-org", "repo": "app", "branch": "feature/main"},
+			expected:  Allow,
+		},
+		{
+			name:         "merge commits",
+			tool:         "merge_pull_request",
+			arguments:    map[string]any{"owner": "octo-org", "repo": "app", "merge_method": "merge"},
+			expected:     Deny,
+			expectedRule: "squash-only",
+		},
+		{
+			name:      "squash merges",
+			tool:      "merge_pull_request",
+			arguments: map[string]any{"owner": "octo-org", "repo": "app", "merge_method": "squash"},
+			expected:  Allow,
+		},
+		{
+			name:         "API request writing a file to main",
+			tool:         "api_request",
+			arguments:    map[string]any{"method": "PUT", "path": "/repos/octo-org/app/contents/README.md", "body": map[string]any{"message": "Update", "content": "", "branch": "main"}},
+			expected:     Deny,
+			expectedRule: "protect-api-main",
+		},
+		{
+			name:         "API request updating the ref of a release branch",
+			tool:         "api_request",
+			arguments:    map[string]any{"method": "PATCH", "path": "/repos/octo-org/app/git/refs/heads/release/1.0", "body": map[string]any{"sha": "abc", "force": true}},
+			expected:     Deny,
+			expectedRule: "protect-api-main",
+		},
+		{
+			name:         "API request renaming main",
+			tool:         "api_request",
+			arguments:    map[string]any{"method": "POST", "path": "/repos/octo-org/app/branches/main/rename", "body": map[string]any{"new_name": "trunk"}},
+			expected:     Deny,
+			expectedRule: "protect-api-main",
+		},
+		{
+			name:         "API request of a sandbox, repositories from the path",
+			tool:         "api_request",
+			arguments:    map[string]any{"method": "DELETE", "path": "/repos/Octo-Org/sandbox-3/contents/a.txt?branch=main"},
+			expected:     Allow,
+			expectedRule: "sandbox",
+		},
+		{
+			name:      "API request to a feature branch",
+			tool:      "api_request",
+			arguments: map[string]any{"method": "PATCH", "path": "/repos/octo-org/app/git/refs/heads/feature", "body": map[string]any{"sha": "abc"}},
+			expected:  Allow,
+		},
+		{
+			name:      "arguments that are missing don't match",
+			tool:      "merge_pull_request",
+			arguments: map[string]any{"owner": "octo-org", "repo": "app"},
+			expected:  Allow,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			action, rule := p.Evaluate(tc.tool, tc.arguments)
+			assert.Equal(t, tc.expected, action)
+			if tc.expectedRule == "" {
+				assert.Nil(t, rule)
+			} else {
+				require.NotNil(t, rule)
+				assert.Equal(t, tc.expectedRule, rule.Name)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	p, err := Load(writePolicy(t, `{"default": "deny", "rules": [{"tools": ["create_issue"], "action": "allow"}, {"repos": ["octo-org/*"]}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, Deny, p.Default)
+	assert.Equal(t, "rule 2", p.Rules[1].Name)
+	assert.Equal(t, Deny, p.Rules[1].Action)
+
+	for name, content := range map[string]string{
+		"unknown field":  "rules:\n  - name: a\n    tool: [push_files]\n",
+		"unknown action": "rules:\n  - action: block\n",
+		"bad default":    "default: maybe\n",
+		"bad pattern":    "rules:\n  - branches: [\"[main\"]\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(writePolicy(t, content))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read policy")
+}
+
+func TestToolHandlerMiddleware(t *testing.T) {
+	p, err := Load(writePolicy(t, "default: deny\nrules:\n  - name: issues\n    tools: [create_issue]\n    action: allow\n  - name: main\n    branches: [main]\n    message: Open a pull request instead\n"))
+	require.NoError(t, err)
+	writeTools := map[string]bool{"create_issue": true, "push_files": true, "delete_repository": true}
+
+	called := false
+	handler := ToolHandlerMiddleware(p, func(tool string) bool { return writeTools[tool] })(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		called = true
+		return mcp.NewToolResultText("done"), nil
+	})
+	call := func(tool string, arguments map[string]any) *mcp.CallToolResult {
+		called = false
+		request := mcp.CallToolRequest{}
+		request.Params.Name = tool
+		request.Params.Arguments = arguments
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call("get_issue", map[string]any{"branch": "main"})
+	assert.True(t, called, "read tools are never checked")
+	assert.False(t, result.IsError)
+
+	result = call("create_issue", nil)
+	assert.True(t, called)
+	assert.False(t, result.IsError)
+
+	result = call("push_files", map[string]any{"owner": "octo-org", "repo": "app", "branch": "main"})
+	assert.False(t, called)
+	require.True(t, result.IsError)
+	assert.Equal(t, `push_files is denied by the policy rule "main" of this server: Open a pull request instead`, result.Content[0].(mcp.TextContent).Text)
+
+	result = call("delete_repository", map[string]any{"owner": "octo-org", "repo": "app"})
+	assert.False(t, called)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "denies write tools unless a rule allows them")
+}
@@ -0,0 +1,143 @@
+// Package provenance records where the data in a tool result came from, so that it can be handed
+// back to the agent alongside the result under `_meta.provenance`.
+package provenance
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MetaKey is the key under the result `_meta` that carries the provenance block.
+const MetaKey = "provenance"
+
+// Call describes a single GitHub API request made while serving a tool call.
+type Call struct {
+	Method    string `json:"method"`
+	Endpoint  string `json:"endpoint"`
+	RequestID string `json:"request_id,omitempty"`
+	Status    int    `json:"status,omitempty"`
+	Cached    bool   `json:"cached"`
+}
+
+// Provenance is the block attached to a tool result.
+type Provenance struct {
+	Timestamp time.Time `json:"timestamp"`
+	Calls     []Call    `json:"calls"`
+	// Cached is true when every call was answered from a cache rather than by GitHub.
+	Cached bool `json:"cached"`
+}
+
+// Recorder collects the calls made during a single tool call. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *Recorder) add(c Call) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, c)
+}
+
+// Provenance returns the provenance block for the calls recorded so far.
+func (r *Recorder) Provenance() Provenance {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	calls := make([]Call, len(r.calls))
+	copy(calls, r.calls)
+
+	cached := len(calls) > 0
+	for _, c := range calls {
+		cached = cached && c.Cached
+	}
+
+	return Provenance{
+		Timestamp: time.Now().UTC(),
+		Calls:     calls,
+		Cached:    cached,
+	}
+}
+
+type recorderKey struct{}
+
+// ContextWithRecorder returns a context carrying a fresh Recorder, along with the Recorder itself.
+func ContextWithRecorder(ctx context.Context) (context.Context, *Recorder) {
+	r := &Recorder{}
+	return context.WithValue(ctx, recorderKey{}, r), r
+}
+
+// RecorderFromContext returns the Recorder stored in the context, or nil if there is none.
+func RecorderFromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+// Transport is an http.RoundTripper that records each request into the Recorder found in the
+// request context. Requests without a Recorder pass through untouched.
+type Transport struct {
+	Transport http.RoundTripper
+}
+
+// NewTransport wraps the given transport, falling back to http.DefaultTransport when it is nil.
+func NewTransport(transport http.RoundTripper) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Transport{Transport: transport}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Transport.RoundTrip(req)
+
+	if r := RecorderFromContext(req.Context()); r != nil {
+		c := Call{
+			Method:   req.Method,
+			Endpoint: req.URL.Path,
+		}
+		if resp != nil {
+			c.RequestID = resp.Header.Get("X-GitHub-Request-Id")
+			c.Status = resp.StatusCode
+			c.Cached = resp.StatusCode == http.StatusNotModified || resp.Header.Get("X-From-Cache") == "1"
+		}
+		r.add(c)
+	}
+
+	return resp, err
+}
+
+// ToolHandlerMiddleware records the GitHub calls made by each tool handler and attaches them to the
+// result as `_meta.provenance`.
+func ToolHandlerMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, recorder := ContextWithRecorder(ctx)
+
+		result, err := next(ctx, request)
+		if err != nil || result == nil || !clientSupportsMeta(ctx) {
+			return result, err
+		}
+
+		if result.Meta == nil {
+			result.Meta = &mcp.Meta{}
+		}
+		if result.Meta.AdditionalFields == nil {
+			result.Meta.AdditionalFields = map[string]any{}
+		}
+		result.Meta.AdditionalFields[MetaKey] = recorder.Provenance()
+
+		return result, nil
+	}
+}
+
+// clientSupportsMeta reports whether the client completed the MCP handshake. `_meta` has been part of
+// the protocol since its first revision, so we only leave it out for callers that never initialized,
+// such as direct invocations of a handler outside of a session.
+func clientSupportsMeta(ctx context.Context) bool {
+	session := server.ClientSessionFromContext(ctx)
+	return session != nil && session.Initialized()
+}
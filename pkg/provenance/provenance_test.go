@@ -0,0 +1,113 @@
+package provenance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSession struct {
+	initialized bool
+}
+
+func (f fakeSession) Initialize()                                         {}
+func (f fakeSession) Initialized() bool                                   { return f.initialized }
+func (f fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (f fakeSession) SessionID() string                                   { return "test" }
+
+func TestTransportRecordsCalls(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-GitHub-Request-Id", "ABCD:1234")
+		if r.URL.Path == "/cached" {
+			w.Header().Set("X-From-Cache", "1")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: NewTransport(nil)}
+	ctx, recorder := ContextWithRecorder(context.Background())
+
+	for _, path := range []string{"/repos/owner/repo", "/cached"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+path, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	p := recorder.Provenance()
+	require.Len(t, p.Calls, 2)
+	assert.Equal(t, Call{Method: http.MethodGet, Endpoint: "/repos/owner/repo", RequestID: "ABCD:1234", Status: http.StatusOK}, p.Calls[0])
+	assert.True(t, p.Calls[1].Cached)
+	assert.False(t, p.Cached, "result is only cached if every call was")
+	assert.False(t, p.Timestamp.IsZero())
+}
+
+func TestTransportWithoutRecorder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: NewTransport(nil)}
+	resp, err := client.Get(ts.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestToolHandlerMiddleware(t *testing.T) {
+	handler := ToolHandlerMiddleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		RecorderFromContext(ctx).add(Call{Method: http.MethodGet, Endpoint: "/user", RequestID: "ID"})
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		expectMeta bool
+	}{
+		{
+			name:       "initialized session gets provenance",
+			ctx:        server.NewMCPServer("test", "0.0.1").WithContext(context.Background(), fakeSession{initialized: true}),
+			expectMeta: true,
+		},
+		{
+			name:       "uninitialized session is stripped",
+			ctx:        server.NewMCPServer("test", "0.0.1").WithContext(context.Background(), fakeSession{}),
+			expectMeta: false,
+		},
+		{
+			name:       "no session is stripped",
+			ctx:        context.Background(),
+			expectMeta: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handler(tc.ctx, mcp.CallToolRequest{})
+			require.NoError(t, err)
+
+			if !tc.expectMeta {
+				assert.Nil(t, result.Meta)
+				return
+			}
+
+			require.NotNil(t, result.Meta)
+			require.Contains(t, result.Meta.AdditionalFields, MetaKey)
+			p, ok := result.Meta.AdditionalFields[MetaKey].(Provenance)
+			require.True(t, ok)
+			require.Len(t, p.Calls, 1)
+			assert.Equal(t, "/user", p.Calls[0].Endpoint)
+			assert.Equal(t, "ID", p.Calls[0].RequestID)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+// Package ratelimit keeps the requests of the server within the rate limits of the GitHub API. The
+// Transport tracks the budget that the X-RateLimit headers of responses report for each token and
+// resource, spaces requests out as the budget runs low and waits for it to reset once it is used up,
+// instead of letting the requests fail. Requests that hit a secondary rate limit are retried after the
+// time their Retry-After header asks for.
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxWait is how long a request waits for the rate limit at most, unless configured otherwise.
+const DefaultMaxWait = time.Minute
+
+// lowBudget is the share of the limit below which requests are spaced out, so that the remaining budget
+// lasts until the reset.
+const lowBudget = 0.1
+
+// maxRetries is how many times a request is retried after hitting a rate limit.
+const maxRetries = 2
+
+// maxTracked is the number of budgets above which the ones that have reset are forgotten, as tokens passed
+// through come and go.
+const maxTracked = 1024
+
+// Error is the error of requests that would have to wait longer than the maximum for the rate limit.
+type Error struct {
+	Resource string
+	Reset    time.Time
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("the GitHub API rate limit of %s is used up until %s, try again then", e.Resource, e.Reset.UTC().Format(time.RFC3339))
+}
+
+// budget is what a rate limit has left.
+type budget struct {
+	limit     int
+	remaining int
+	reset     time.Time
+}
+
+// Transport is an http.RoundTripper that keeps requests within the rate limits.
+type Transport struct {
+	Transport http.RoundTripper
+	// MaxWait is how long a request waits for the rate limit at most, requests that would have to wait
+	// longer fail with an Error right away. Zero never waits
+	MaxWait time.Duration
+
+	mu sync.Mutex
+	// budgets are keyed by the token and the resource of the limit
+	budgets map[string]*budget
+	// blocked holds the time until which the secondary rate limit of a token holds back its requests
+	blocked map[string]time.Time
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewTransport wraps the given transport, falling back to http.DefaultTransport when it is nil.
+func NewTransport(transport http.RoundTripper, maxWait time.Duration) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Transport{
+		Transport: transport,
+		MaxWait:   maxWait,
+		budgets:   make(map[string]*budget),
+		blocked:   make(map[string]time.Time),
+		now:       time.Now,
+		sleep:     sleep,
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Resource returns the rate limit resource that a request counts against, named as in the
+// X-RateLimit-Resource header of the responses of GitHub.
+func Resource(req *http.Request) string {
+	path := req.URL.Path
+	switch {
+	case strings.HasSuffix(path, "/graphql"):
+		return "graphql"
+	case strings.Contains(path, "/search/code"):
+		return "code_search"
+	case strings.Contains(path, "/search/"):
+		return "search"
+	default:
+		return "core"
+	}
+}
+
+// tokenKey identifies the token of a request without keeping the token itself.
+func tokenKey(req *http.Request) string {
+	authorization := req.Header.Get("Authorization")
+	if authorization == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(authorization))
+	return fmt.Sprintf("%x", sum[:8])
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, resource := tokenKey(req), Resource(req)
+	for attempt := 0; ; attempt++ {
+		if err := t.wait(req.Context(), token, resource); err != nil {
+			return nil, err
+		}
+		resp, err := t.Transport.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		retryAt, limited := t.update(token, resource, resp)
+		if !limited || attempt == maxRetries || retryAt.Sub(t.now()) > t.MaxWait {
+			return resp, nil
+		}
+		// Only retry requests whose body can be sent again
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, nil
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		_ = resp.Body.Close()
+	}
+}
+
+// wait holds a request back until its budget allows it, and takes one request off the budget.
+func (t *Transport) wait(ctx context.Context, token, resource string) error {
+	t.mu.Lock()
+	now := t.now()
+	var delay time.Duration
+	if blocked, ok := t.blocked[token]; ok && now.Before(blocked) {
+		delay = blocked.Sub(now)
+	}
+	b := t.budgets[token+" "+resource]
+	if b != nil && now.Before(b.reset) {
+		untilReset := b.reset.Sub(now)
+		switch {
+		case b.remaining <= 0:
+			if untilReset > t.MaxWait {
+				t.mu.Unlock()
+				return &Error{Resource: resource, Reset: b.reset}
+			}
+			delay = max(delay, untilReset)
+		case float64(b.remaining) < lowBudget*float64(b.limit):
+			// Spread what is left of the budget over the time until the reset
+			delay = max(delay, min(untilReset/time.Duration(b.remaining+1), t.MaxWait))
+		}
+		b.remaining--
+	}
+	t.mu.Unlock()
+
+	if delay > t.MaxWait {
+		return &Error{Resource: resource, Reset: now.Add(delay)}
+	}
+	if delay <= 0 {
+		return nil
+	}
+	return t.sleep(ctx, delay)
+}
+
+// update records the budget that a response reports. It reports whether the response hit a rate limit,
+// and when to retry it.
+func (t *Transport) update(token, resource string, resp *http.Response) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+
+	var b *budget
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		limit, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+		remaining, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+		b = &budget{limit: limit, remaining: remaining, reset: time.Unix(reset, 0)}
+		t.budgets[token+" "+resource] = b
+	}
+	if len(t.budgets)+len(t.blocked) > maxTracked {
+		for key, tracked := range t.budgets {
+			if !now.Before(tracked.reset) {
+				delete(t.budgets, key)
+			}
+		}
+		for key, blocked := range t.blocked {
+			if !now.Before(blocked) {
+				delete(t.blocked, key)
+			}
+		}
+	}
+
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return time.Time{}, false
+	}
+	// Secondary rate limits say how long to wait in Retry-After
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		retryAt := now.Add(time.Duration(seconds) * time.Second)
+		t.blocked[token] = retryAt
+		return retryAt, true
+	}
+	if b != nil && b.remaining <= 0 {
+		return b.reset, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,189 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// fakeClock is a clock whose sleeps pass at once.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) sleep(_ context.Context, d time.Duration) error {
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return nil
+}
+
+func newTestTransport(maxWait time.Duration, next roundTripFunc) (*Transport, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	t := NewTransport(next, maxWait)
+	t.now = func() time.Time { return clock.now }
+	t.sleep = clock.sleep
+	return t, clock
+}
+
+func response(status int, headers map[string]string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}"))}
+	for name, value := range headers {
+		resp.Header.Set(name, value)
+	}
+	return resp
+}
+
+func get(t *testing.T, transport http.RoundTripper, path string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, "https://api.github.com"+path, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer token")
+	return transport.RoundTrip(req)
+}
+
+func TestResource(t *testing.T) {
+	for path, resource := range map[string]string{
+		"/repos/octo-org/app/issues": "core",
+		"/search/issues":             "search",
+		"/search/code":               "code_search",
+		"/graphql":                   "graphql",
+		"/api/v3/search/code":        "code_search",
+	} {
+		req, err := http.NewRequest(http.MethodGet, "https://api.github.com"+path, nil)
+		require.NoError(t, err)
+		assert.Equal(t, resource, Resource(req), path)
+	}
+}
+
+func TestTransportWaitsForTheReset(t *testing.T) {
+	var transport *Transport
+	var clock *fakeClock
+	transport, clock = newTestTransport(time.Minute, func(_ *http.Request) (*http.Response, error) {
+		return response(http.StatusOK, map[string]string{
+			"X-RateLimit-Limit":     "5000",
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     strconv.FormatInt(clock.now.Add(30*time.Second).Unix(), 10),
+		}), nil
+	})
+
+	_, err := get(t, transport, "/repos/octo-org/app")
+	require.NoError(t, err)
+	assert.Empty(t, clock.sleeps)
+
+	_, err = get(t, transport, "/repos/octo-org/app")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{30 * time.Second}, clock.sleeps, "the second request waits for the reset")
+
+	_, err = get(t, transport, "/search/issues")
+	require.NoError(t, err)
+	assert.Len(t, clock.sleeps, 1, "other resources have their own budget")
+}
+
+func TestTransportFailsPastTheMaxWait(t *testing.T) {
+	calls := 0
+	var clock *fakeClock
+	var transport *Transport
+	transport, clock = newTestTransport(time.Minute, func(_ *http.Request) (*http.Response, error) {
+		calls++
+		return response(http.StatusOK, map[string]string{
+			"X-RateLimit-Limit":     "5000",
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     strconv.FormatInt(clock.now.Add(time.Hour).Unix(), 10),
+		}), nil
+	})
+
+	_, err := get(t, transport, "/repos/octo-org/app")
+	require.NoError(t, err)
+	_, err = get(t, transport, "/repos/octo-org/app")
+	var rateLimitErr *Error
+	require.ErrorAs(t, err, &rateLimitErr)
+	assert.Equal(t, "core", rateLimitErr.Resource)
+	assert.Contains(t, err.Error(), "rate limit of core is used up until 2023-11-14T23:13:20Z")
+	assert.Equal(t, 1, calls, "the request isn't sent")
+}
+
+func TestTransportSpacesOutALowBudget(t *testing.T) {
+	var clock *fakeClock
+	var transport *Transport
+	transport, clock = newTestTransport(time.Minute, func(_ *http.Request) (*http.Response, error) {
+		return response(http.StatusOK, map[string]string{
+			"X-RateLimit-Limit":     "5000",
+			"X-RateLimit-Remaining": "99",
+			"X-RateLimit-Reset":     strconv.FormatInt(clock.now.Add(100*time.Second).Unix(), 10),
+		}), nil
+	})
+
+	_, err := get(t, transport, "/repos/octo-org/app")
+	require.NoError(t, err)
+	_, err = get(t, transport, "/repos/octo-org/app")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Second}, clock.sleeps)
+}
+
+func TestTransportRetriesSecondaryRateLimits(t *testing.T) {
+	calls := 0
+	transport, clock := newTestTransport(time.Minute, func(req *http.Request) (*http.Response, error) {
+		calls++
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"title":"Bug"}`, string(body), "the body is sent again")
+		if calls == 1 {
+			return response(http.StatusForbidden, map[string]string{"Retry-After": "10"}), nil
+		}
+		return response(http.StatusCreated, nil), nil
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.github.com/repos/octo-org/app/issues", strings.NewReader(`{"title":"Bug"}`))
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []time.Duration{10 * time.Second}, clock.sleeps)
+}
+
+func TestTransportReturnsLongSecondaryRateLimits(t *testing.T) {
+	calls := 0
+	transport, _ := newTestTransport(time.Minute, func(_ *http.Request) (*http.Response, error) {
+		calls++
+		return response(http.StatusTooManyRequests, map[string]string{"Retry-After": "120"}), nil
+	})
+
+	resp, err := get(t, transport, "/repos/octo-org/app")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	_, err = get(t, transport, "/repos/octo-org/app")
+	assert.ErrorAs(t, err, new(*Error), "later requests fail until the secondary rate limit passes")
+	assert.Equal(t, 1, calls)
+}
+
+func TestTransportIgnoresOtherErrors(t *testing.T) {
+	calls := 0
+	transport, clock := newTestTransport(time.Minute, func(_ *http.Request) (*http.Response, error) {
+		calls++
+		return response(http.StatusForbidden, map[string]string{
+			"X-RateLimit-Limit":     "5000",
+			"X-RateLimit-Remaining": "4999",
+			"X-RateLimit-Reset":     "1700003600",
+		}), nil
+	})
+
+	resp, err := get(t, transport, "/repos/octo-org/secret")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, clock.sleeps)
+}
@@ -0,0 +1,281 @@
+// Package reference parses free-form references to GitHub objects, such as URLs or `owner/repo#123`, and
+// provides a middleware that spreads them into the discrete owner, repo and number inputs of a tool.
+package reference
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Kind is the kind of object a reference points at.
+type Kind string
+
+const (
+	KindRepository  Kind = "repository"
+	KindIssue       Kind = "issue"
+	KindPullRequest Kind = "pull_request"
+	KindDiscussion  Kind = "discussion"
+	KindBlob        Kind = "blob"
+	KindTree        Kind = "tree"
+	KindCommit      Kind = "commit"
+	KindCompare     Kind = "compare"
+
+	// KindNumbered is a `owner/repo#123` reference, which can be an issue, pull request or discussion
+	KindNumbered Kind = "numbered"
+)
+
+// Reference is a parsed reference to a GitHub repository or an object in it.
+type Reference struct {
+	Owner  string
+	Repo   string
+	Kind   Kind
+	Number int
+	// Ref is the branch, tag or commit of blob, tree and commit references
+	Ref string
+	// Path is the file or directory of blob and tree references
+	Path string
+	// Base and Head are the two sides of a compare reference
+	Base string
+	Head string
+}
+
+var (
+	namePattern      = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	shorthandPattern = regexp.MustCompile(`^([A-Za-z0-9_.-]+)/([A-Za-z0-9_.-]+)(?:#([0-9]+))?$`)
+)
+
+// Parse parses a GitHub URL, with or without scheme and for any host, or an `owner/repo` or
+// `owner/repo#123` shorthand.
+func Parse(s string) (*Reference, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("empty reference")
+	}
+
+	if m := shorthandPattern.FindStringSubmatch(s); m != nil && !strings.Contains(m[1], ".") {
+		ref := &Reference{Owner: m[1], Repo: m[2], Kind: KindRepository}
+		if m[3] != "" {
+			ref.Kind = KindNumbered
+			ref.Number, _ = strconv.Atoi(m[3])
+		}
+		return ref, nil
+	}
+
+	// Anything else must be a URL, add a scheme so that the host is parsed as such
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("unrecognized reference %q", s)
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	// API URLs such as api.github.com/repos/owner/repo or <host>/api/v3/repos/owner/repo
+	for i, segment := range segments {
+		if segment == "repos" && (i == 0 || (i == 2 && segments[0] == "api")) {
+			segments = segments[i+1:]
+			break
+		}
+	}
+	if len(segments) < 2 || !namePattern.MatchString(segments[0]) || !namePattern.MatchString(segments[1]) {
+		return nil, fmt.Errorf("reference %q does not name a repository", s)
+	}
+
+	ref := &Reference{
+		Owner: segments[0],
+		Repo:  strings.TrimSuffix(segments[1], ".git"),
+		Kind:  KindRepository,
+	}
+	rest := segments[2:]
+	if len(rest) == 0 {
+		return ref, nil
+	}
+
+	switch rest[0] {
+	case "issues", "pull", "pulls", "discussions":
+		if len(rest) < 2 {
+			return ref, nil
+		}
+		number, err := strconv.Atoi(rest[1])
+		if err != nil || number <= 0 {
+			return nil, fmt.Errorf("reference %q has an invalid number %q", s, rest[1])
+		}
+		ref.Number = number
+		switch rest[0] {
+		case "issues":
+			ref.Kind = KindIssue
+		case "discussions":
+			ref.Kind = KindDiscussion
+		default:
+			ref.Kind = KindPullRequest
+		}
+	case "blob", "tree":
+		if len(rest) < 2 {
+			return nil, fmt.Errorf("reference %q is missing a ref", s)
+		}
+		// Branch names can contain slashes, which makes them ambiguous with the path. Like most tools,
+		// assume the first segment is the ref.
+		ref.Kind = Kind(rest[0])
+		ref.Ref = rest[1]
+		ref.Path = strings.Join(rest[2:], "/")
+	case "commit", "commits":
+		if len(rest) < 2 {
+			return ref, nil
+		}
+		ref.Kind = KindCommit
+		ref.Ref = rest[1]
+	case "compare":
+		base, head, ok := strings.Cut(strings.Join(rest[1:], "/"), "...")
+		if !ok || base == "" || head == "" {
+			return nil, fmt.Errorf("reference %q is not of the form compare/base...head", s)
+		}
+		ref.Kind = KindCompare
+		ref.Base = base
+		ref.Head = head
+	}
+
+	return ref, nil
+}
+
+// numberInputs are the names tools use for the number of the object they act on, by kind.
+var numberInputs = map[Kind][]string{
+	KindIssue:       {"issue_number"},
+	KindPullRequest: {"pullNumber", "pull_number", "issue_number"},
+	KindDiscussion:  {"discussionNumber"},
+	KindNumbered:    {"issue_number", "pullNumber", "pull_number", "discussionNumber"},
+}
+
+// referenceInputs are the inputs that can carry a free-form reference, when the tool doesn't declare
+// them itself.
+var referenceInputs = []string{"url", "ref"}
+
+// Apply spreads a reference found in args into the inputs the tool declares in properties. A reference
+// is taken from a `url` or `ref` input the tool doesn't declare, or from an owner or repo input that
+// holds more than a single name. Inputs that are already set are never overwritten, and args is left
+// untouched if no reference is found.
+func Apply(args map[string]any, properties map[string]any) map[string]any {
+	if _, ok := properties["owner"]; !ok {
+		return args
+	}
+
+	var ref *Reference
+	var source string
+	for _, input := range referenceInputs {
+		if _, declared := properties[input]; declared {
+			continue
+		}
+		if s, ok := args[input].(string); ok {
+			if parsed, err := Parse(s); err == nil {
+				ref, source = parsed, input
+				break
+			}
+		}
+	}
+	if ref == nil {
+		for _, input := range []string{"owner", "repo"} {
+			if s, ok := args[input].(string); ok && strings.ContainsAny(s, "/#") {
+				if parsed, err := Parse(s); err == nil {
+					ref, source = parsed, input
+					break
+				}
+			}
+		}
+	}
+	if ref == nil {
+		return args
+	}
+
+	// When the reference came from the owner or repo input, it replaces both, unless the other one was
+	// given explicitly and disagrees, which is left to the tool to report
+	if source == "owner" || source == "repo" {
+		other, expected := "repo", ref.Repo
+		if source == "repo" {
+			other, expected = "owner", ref.Owner
+		}
+		if s, ok := args[other].(string); ok && s != "" && s != expected {
+			return args
+		}
+	}
+
+	normalized := make(map[string]any, len(args)+4)
+	for k, v := range args {
+		switch k {
+		case source, "owner", "repo":
+			// Replaced by the reference below
+		default:
+			normalized[k] = v
+		}
+	}
+	if source == "url" || source == "ref" {
+		// An explicit owner or repo takes precedence over the reference
+		for _, input := range []string{"owner", "repo"} {
+			if v, ok := args[input]; ok {
+				normalized[input] = v
+			}
+		}
+	}
+
+	set := func(input string, value any) {
+		if _, declared := properties[input]; !declared {
+			return
+		}
+		if _, present := normalized[input]; present {
+			return
+		}
+		normalized[input] = value
+	}
+
+	set("owner", ref.Owner)
+	set("repo", ref.Repo)
+
+	if ref.Number != 0 {
+		for _, input := range numberInputs[ref.Kind] {
+			if _, declared := properties[input]; declared {
+				set(input, float64(ref.Number))
+				break
+			}
+		}
+	}
+	switch ref.Kind {
+	case KindBlob, KindTree:
+		set("ref", ref.Ref)
+		set("branch", ref.Ref)
+		if ref.Path != "" {
+			set("path", ref.Path)
+		}
+	case KindCommit:
+		if _, declared := properties["sha"]; declared {
+			set("sha", ref.Ref)
+		} else {
+			set("ref", ref.Ref)
+		}
+	case KindCompare:
+		set("base", ref.Base)
+		set("head", ref.Head)
+	}
+
+	return normalized
+}
+
+// ToolHandlerMiddleware applies references found in the arguments of a tool call before it reaches the
+// tool. properties returns the input schema properties of a tool by name, or nil if it is unknown.
+func ToolHandlerMiddleware(properties func(tool string) map[string]any) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if props := properties(request.Params.Name); props != nil {
+				if args, ok := request.Params.Arguments.(map[string]any); ok {
+					request.Params.Arguments = Apply(args, props)
+				}
+			}
+			return next(ctx, request)
+		}
+	}
+}
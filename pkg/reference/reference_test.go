@@ -0,0 +1,210 @@
+package reference
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *Reference
+	}{
+		{
+			input:    "acme/app",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindRepository},
+		},
+		{
+			input:    "acme/app#123",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindNumbered, Number: 123},
+		},
+		{
+			input:    "https://github.com/acme/app",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindRepository},
+		},
+		{
+			input:    "https://github.com/acme/app.git",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindRepository},
+		},
+		{
+			input:    "github.com/acme/app/issues/5",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindIssue, Number: 5},
+		},
+		{
+			input:    "https://github.com/acme/app/issues/5#issuecomment-42",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindIssue, Number: 5},
+		},
+		{
+			input:    "https://github.com/acme/app/pull/7/files",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindPullRequest, Number: 7},
+		},
+		{
+			input:    "https://github.com/acme/app/discussions/9",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindDiscussion, Number: 9},
+		},
+		{
+			input:    "https://github.com/acme/app/blob/main/docs/README.md",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindBlob, Ref: "main", Path: "docs/README.md"},
+		},
+		{
+			input:    "https://github.com/acme/app/tree/v1.2.0",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindTree, Ref: "v1.2.0"},
+		},
+		{
+			input:    "https://github.com/acme/app/commit/abc123",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindCommit, Ref: "abc123"},
+		},
+		{
+			input:    "https://github.com/acme/app/compare/main...feature/login",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindCompare, Base: "main", Head: "feature/login"},
+		},
+		{
+			input:    "https://ghe.example.com/acme/app/pull/3",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindPullRequest, Number: 3},
+		},
+		{
+			input:    "https://api.github.com/repos/acme/app/issues/5",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindIssue, Number: 5},
+		},
+		{
+			input:    "https://ghe.example.com/api/v3/repos/acme/app/pulls/3",
+			expected: &Reference{Owner: "acme", Repo: "app", Kind: KindPullRequest, Number: 3},
+		},
+		{input: ""},
+		{input: "acme"},
+		{input: "main"},
+		{input: "https://github.com/acme"},
+		{input: "https://github.com/acme/app/issues/abc"},
+		{input: "https://github.com/acme/app/compare/main"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := Parse(tc.input)
+			if tc.expected == nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	issueTool := map[string]any{"owner": nil, "repo": nil, "issue_number": nil}
+	pullRequestTool := map[string]any{"owner": nil, "repo": nil, "pullNumber": nil}
+	fileTool := map[string]any{"owner": nil, "repo": nil, "path": nil, "ref": nil}
+
+	tests := []struct {
+		name       string
+		properties map[string]any
+		args       map[string]any
+		expected   map[string]any
+	}{
+		{
+			name:       "issue URL in an undeclared ref input",
+			properties: issueTool,
+			args:       map[string]any{"ref": "github.com/acme/app/issues/5"},
+			expected:   map[string]any{"owner": "acme", "repo": "app", "issue_number": float64(5)},
+		},
+		{
+			name:       "pull request URL in an undeclared url input",
+			properties: pullRequestTool,
+			args:       map[string]any{"url": "https://github.com/acme/app/pull/7"},
+			expected:   map[string]any{"owner": "acme", "repo": "app", "pullNumber": float64(7)},
+		},
+		{
+			name:       "whole URL in the owner input",
+			properties: issueTool,
+			args:       map[string]any{"owner": "https://github.com/acme/app/issues/5", "repo": ""},
+			expected:   map[string]any{"owner": "acme", "repo": "app", "issue_number": float64(5)},
+		},
+		{
+			name:       "shorthand in the repo input",
+			properties: pullRequestTool,
+			args:       map[string]any{"repo": "acme/app#7"},
+			expected:   map[string]any{"owner": "acme", "repo": "app", "pullNumber": float64(7)},
+		},
+		{
+			name:       "pull request URL for a tool that takes an issue number",
+			properties: issueTool,
+			args:       map[string]any{"url": "https://github.com/acme/app/pull/7"},
+			expected:   map[string]any{"owner": "acme", "repo": "app", "issue_number": float64(7)},
+		},
+		{
+			name:       "blob URL for a tool that declares ref",
+			properties: fileTool,
+			args:       map[string]any{"url": "https://github.com/acme/app/blob/main/docs/README.md"},
+			expected:   map[string]any{"owner": "acme", "repo": "app", "ref": "main", "path": "docs/README.md"},
+		},
+		{
+			name:       "explicit inputs are not overwritten",
+			properties: issueTool,
+			args:       map[string]any{"url": "https://github.com/acme/app/issues/5", "issue_number": float64(6)},
+			expected:   map[string]any{"owner": "acme", "repo": "app", "issue_number": float64(6)},
+		},
+		{
+			name:       "declared ref input is left alone",
+			properties: fileTool,
+			args:       map[string]any{"owner": "acme", "repo": "app", "ref": "feature/login"},
+			expected:   map[string]any{"owner": "acme", "repo": "app", "ref": "feature/login"},
+		},
+		{
+			name:       "conflicting owner is left to the tool",
+			properties: issueTool,
+			args:       map[string]any{"owner": "other", "repo": "acme/app"},
+			expected:   map[string]any{"owner": "other", "repo": "acme/app"},
+		},
+		{
+			name:       "unparseable reference is left to the tool",
+			properties: issueTool,
+			args:       map[string]any{"url": "not a reference"},
+			expected:   map[string]any{"url": "not a reference"},
+		},
+		{
+			name:       "tools without an owner input are left alone",
+			properties: map[string]any{"query": nil},
+			args:       map[string]any{"url": "https://github.com/acme/app"},
+			expected:   map[string]any{"url": "https://github.com/acme/app"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Apply(tc.args, tc.properties))
+		})
+	}
+}
+
+func TestToolHandlerMiddleware(t *testing.T) {
+	properties := func(tool string) map[string]any {
+		if tool == "get_issue" {
+			return map[string]any{"owner": nil, "repo": nil, "issue_number": nil}
+		}
+		return nil
+	}
+
+	var received map[string]any
+	handler := ToolHandlerMiddleware(properties)(func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		received = request.GetArguments()
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "get_issue"
+	request.Params.Arguments = map[string]any{"ref": "acme/app#5"}
+	_, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"owner": "acme", "repo": "app", "issue_number": float64(5)}, received)
+
+	request.Params.Name = "unknown_tool"
+	request.Params.Arguments = map[string]any{"ref": "acme/app#5"}
+	_, err = handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"ref": "acme/app#5"}, received)
+}
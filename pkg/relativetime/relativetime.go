@@ -0,0 +1,134 @@
+// Package relativetime adds human readable companions such as `created_at_relative: "3 days ago"` next
+// to the timestamps in tool results, so that agents can restate them without doing date math.
+package relativetime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Suffix is appended to the name of a timestamp field to form the name of its companion.
+const Suffix = "_relative"
+
+// Describe returns a description of t relative to now, such as "just now", "5 minutes ago" or "in 2 days".
+func Describe(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var n int
+	var unit string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		n, unit = int(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		n, unit = int(d/time.Hour), "hour"
+	case d < 30*24*time.Hour:
+		n, unit = int(d/(24*time.Hour)), "day"
+	case d < 365*24*time.Hour:
+		n, unit = int(d/(30*24*time.Hour)), "month"
+	default:
+		n, unit = int(d/(365*24*time.Hour)), "year"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+
+	if future {
+		return fmt.Sprintf("in %d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
+
+// isTimestampField reports whether a field name is one of the timestamp fields GitHub commonly returns,
+// e.g. created_at, updated_at, merged_at or the date of a commit author.
+func isTimestampField(name string) bool {
+	return strings.HasSuffix(name, "_at") || name == "date"
+}
+
+// Annotate walks a decoded JSON value and adds a companion field next to every timestamp field whose
+// value is an RFC 3339 timestamp. The original fields are left intact.
+func Annotate(v any, now time.Time) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if s, ok := value.(string); ok && isTimestampField(key) {
+				if _, exists := v[key+Suffix]; exists {
+					continue
+				}
+				if t, err := time.Parse(time.RFC3339, s); err == nil {
+					v[key+Suffix] = Describe(t, now)
+				}
+				continue
+			}
+			Annotate(value, now)
+		}
+	case []any:
+		for _, value := range v {
+			Annotate(value, now)
+		}
+	}
+}
+
+// annotateText returns the JSON document in text with relative times added, and false if text is not a
+// JSON object or array.
+func annotateText(text string, now time.Time) (string, bool) {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return "", false
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	// Keep numbers as written, so large IDs don't lose precision on the way through
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return "", false
+	}
+	Annotate(v, now)
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return "", false
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), true
+}
+
+// ToolHandlerMiddleware adds relative times to the JSON text content of every successful tool result.
+// Results that are not JSON, such as file contents, are passed through untouched.
+func ToolHandlerMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+
+		now := time.Now().UTC()
+		for i, content := range result.Content {
+			text, ok := content.(mcp.TextContent)
+			if !ok {
+				continue
+			}
+			if annotated, ok := annotateText(text.Text, now); ok {
+				text.Text = annotated
+				result.Content[i] = text
+			}
+		}
+
+		return result, nil
+	}
+}
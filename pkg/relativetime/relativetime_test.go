@@ -0,0 +1,85 @@
+package relativetime
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribe(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected string
+	}{
+		{name: "seconds ago", t: now.Add(-30 * time.Second), expected: "just now"},
+		{name: "one minute ago", t: now.Add(-time.Minute), expected: "1 minute ago"},
+		{name: "hours ago", t: now.Add(-5 * time.Hour), expected: "5 hours ago"},
+		{name: "days ago", t: now.Add(-3 * 24 * time.Hour), expected: "3 days ago"},
+		{name: "months ago", t: now.Add(-65 * 24 * time.Hour), expected: "2 months ago"},
+		{name: "years ago", t: now.Add(-800 * 24 * time.Hour), expected: "2 years ago"},
+		{name: "in the future", t: now.Add(2 * 24 * time.Hour), expected: "in 2 days"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Describe(tc.t, now))
+		})
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var v any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"created_at": "2025-05-29T12:00:00Z",
+		"closed_at": null,
+		"title": "2025-05-29T12:00:00Z",
+		"commit": {"author": {"date": "2025-06-01T11:00:00Z"}},
+		"comments": [{"updated_at": "2025-06-01T11:58:00Z"}]
+	}`), &v))
+
+	Annotate(v, now)
+
+	m := v.(map[string]any)
+	assert.Equal(t, "2025-05-29T12:00:00Z", m["created_at"])
+	assert.Equal(t, "3 days ago", m["created_at_relative"])
+	assert.NotContains(t, m, "closed_at_relative")
+	assert.NotContains(t, m, "title_relative")
+	assert.Equal(t, "1 hour ago", m["commit"].(map[string]any)["author"].(map[string]any)["date_relative"])
+	assert.Equal(t, "2 minutes ago", m["comments"].([]any)[0].(map[string]any)["updated_at_relative"])
+}
+
+func TestToolHandlerMiddleware(t *testing.T) {
+	handler := ToolHandlerMiddleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(`{"id":12345678901234567,"created_at":"2020-01-01T00:00:00Z"}`),
+				mcp.NewTextContent("plain text created_at"),
+			},
+		}, nil
+	})
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+
+	var returned map[string]any
+	decoder := json.NewDecoder(strings.NewReader(result.Content[0].(mcp.TextContent).Text))
+	decoder.UseNumber()
+	require.NoError(t, decoder.Decode(&returned))
+	assert.Equal(t, json.Number("12345678901234567"), returned["id"])
+	assert.Equal(t, "2020-01-01T00:00:00Z", returned["created_at"])
+	assert.Contains(t, returned["created_at_relative"], "years ago")
+
+	assert.Equal(t, "plain text created_at", result.Content[1].(mcp.TextContent).Text)
+}
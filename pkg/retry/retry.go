@@ -0,0 +1,218 @@
+// Package retry retries the requests to the GitHub API that fail for reasons that pass, such as a 502 or
+// 503 from a busy server, a connection that was reset or a GraphQL query that timed out. The Transport
+// waits a jittered, exponentially growing delay between attempts. Requests are only sent again when
+// that can't repeat their effect: reads, GraphQL queries and requests that never reached the server.
+package retry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/graphql"
+)
+
+// DefaultMaxRetries is how many times a request is retried at most, unless configured otherwise.
+const DefaultMaxRetries = 3
+
+// baseDelay is the delay before the first retry, which doubles with every attempt up to maxDelay.
+const baseDelay = 500 * time.Millisecond
+
+// maxDelay caps the delay between two attempts, including the one a Retry-After header asks for.
+const maxDelay = 10 * time.Second
+
+// maxGraphQLBody is the size up to which the bodies of GraphQL responses are checked for timeouts.
+const maxGraphQLBody = 1 << 20
+
+// Transport is an http.RoundTripper that retries requests which failed for transient reasons.
+type Transport struct {
+	Transport http.RoundTripper
+	// MaxRetries is how many times a request is retried at most. Zero never retries
+	MaxRetries int
+
+	sleep  func(ctx context.Context, d time.Duration) error
+	jitter func(d time.Duration) time.Duration
+}
+
+// NewTransport wraps the given transport, falling back to http.DefaultTransport when it is nil.
+func NewTransport(transport http.RoundTripper, maxRetries int) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Transport{
+		Transport:  transport,
+		MaxRetries: maxRetries,
+		sleep:      sleep,
+		jitter:     fullJitter,
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// fullJitter picks a delay between zero and d, so that clients that failed together don't retry together.
+func fullJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d + 1)
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	if t.MaxRetries <= 0 || !replayable {
+		return t.Transport.RoundTrip(req)
+	}
+	idempotent := Idempotent(req)
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := t.Transport.RoundTrip(req)
+		var delay time.Duration
+		switch {
+		case err != nil:
+			// Requests that never reached the server can be sent again whatever they do
+			if attempt == t.MaxRetries || req.Context().Err() != nil || !transientError(err) || !(idempotent || notSent(err)) {
+				return nil, err
+			}
+		default:
+			var retry bool
+			resp, retry, delay = t.check(req, resp)
+			if !retry || !idempotent || attempt == t.MaxRetries {
+				return resp, nil
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		backoff := t.jitter(min(baseDelay<<attempt, maxDelay))
+		if err := t.sleep(req.Context(), max(backoff, delay)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// check reports whether a response failed for a transient reason, and how long its Retry-After header
+// asks to wait. The body of GraphQL responses is read to look for timeouts, so the response it returns
+// replaces the one given.
+func (t *Transport) check(req *http.Request, resp *http.Response) (*http.Response, bool, time.Duration) {
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		var delay time.Duration
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			delay = min(time.Duration(seconds)*time.Second, maxDelay)
+		}
+		return resp, true, delay
+	case http.StatusOK:
+		if !isGraphQL(req) || resp.ContentLength > maxGraphQLBody {
+			return resp, false, 0
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxGraphQLBody+1))
+		if err != nil || int64(len(body)) > maxGraphQLBody {
+			// Hand on what was read along with the rest, errors included
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+			return resp, false, 0
+		}
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, graphQLTimeout(body), 0
+	default:
+		return resp, false, 0
+	}
+}
+
+// Idempotent reports whether a request can be sent again without repeating an effect: reads, and GraphQL
+// requests that are queries rather than mutations.
+func Idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		if !isGraphQL(req) || req.GetBody == nil {
+			return false
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return false
+		}
+		defer func() { _ = body.Close() }()
+		payload, err := io.ReadAll(body)
+		if err != nil {
+			return false
+		}
+		return graphql.ReadOnlyRequest(payload)
+	default:
+		return false
+	}
+}
+
+func isGraphQL(req *http.Request) bool {
+	return strings.HasSuffix(req.URL.Path, "/graphql")
+}
+
+// graphQLTimeout reports whether a GraphQL response is the error that GitHub answers with when a query
+// takes too long, which comes with a 200 status and no data.
+func graphQLTimeout(body []byte) bool {
+	var payload struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return false
+	}
+	if len(payload.Data) > 0 && string(payload.Data) != "null" {
+		return false
+	}
+	for _, e := range payload.Errors {
+		if strings.Contains(strings.ToLower(e.Message), "timeout") {
+			return true
+		}
+	}
+	return false
+}
+
+// transientError reports whether an error of the connection may not happen again.
+func transientError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// notSent reports whether a request failed before any of it was sent, while connecting.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
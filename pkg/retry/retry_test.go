@@ -0,0 +1,212 @@
+package retry
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newTestTransport returns a transport whose sleeps pass at once and whose jitter is left out.
+func newTestTransport(next roundTripFunc) (*Transport, *[]time.Duration) {
+	var sleeps []time.Duration
+	t := NewTransport(next, DefaultMaxRetries)
+	t.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	t.jitter = func(d time.Duration) time.Duration { return d }
+	return t, &sleeps
+}
+
+func response(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func request(t *testing.T, method, path, body string) *http.Request {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, "https://api.github.com"+path, reader)
+	require.NoError(t, err)
+	return req
+}
+
+func TestTransportRetriesServerErrors(t *testing.T) {
+	calls := 0
+	transport, sleeps := newTestTransport(func(_ *http.Request) (*http.Response, error) {
+		calls++
+		if calls < 3 {
+			return response(http.StatusBadGateway, ""), nil
+		}
+		return response(http.StatusOK, "{}"), nil
+	})
+
+	resp, err := transport.RoundTrip(request(t, http.MethodGet, "/repos/octo-org/app", ""))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{baseDelay, 2 * baseDelay}, *sleeps, "the delay doubles")
+}
+
+func TestTransportGivesUp(t *testing.T) {
+	calls := 0
+	transport, _ := newTestTransport(func(_ *http.Request) (*http.Response, error) {
+		calls++
+		return response(http.StatusServiceUnavailable, "down"), nil
+	})
+
+	resp, err := transport.RoundTrip(request(t, http.MethodGet, "/repos/octo-org/app", ""))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "down", string(body), "the last response is handed on")
+	assert.Equal(t, DefaultMaxRetries+1, calls)
+}
+
+func TestTransportHonoursRetryAfter(t *testing.T) {
+	calls := 0
+	transport, sleeps := newTestTransport(func(_ *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			resp := response(http.StatusServiceUnavailable, "")
+			resp.Header.Set("Retry-After", "3")
+			return resp, nil
+		}
+		return response(http.StatusOK, "{}"), nil
+	})
+
+	_, err := transport.RoundTrip(request(t, http.MethodGet, "/repos/octo-org/app", ""))
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{3 * time.Second}, *sleeps)
+}
+
+func TestTransportRetriesConnectionResets(t *testing.T) {
+	calls := 0
+	transport, _ := newTestTransport(func(_ *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+		}
+		return response(http.StatusOK, "{}"), nil
+	})
+
+	resp, err := transport.RoundTrip(request(t, http.MethodGet, "/repos/octo-org/app", ""))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, calls)
+}
+
+func TestTransportLeavesWritesAlone(t *testing.T) {
+	calls := 0
+	transport, _ := newTestTransport(func(_ *http.Request) (*http.Response, error) {
+		calls++
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	})
+
+	_, err := transport.RoundTrip(request(t, http.MethodPost, "/repos/octo-org/app/issues", `{"title":"Bug"}`))
+	require.Error(t, err)
+	assert.Equal(t, 1, calls, "the issue may have been created")
+
+	calls = 0
+	transport.Transport = roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+		calls++
+		return response(http.StatusBadGateway, ""), nil
+	})
+	resp, err := transport.RoundTrip(request(t, http.MethodPatch, "/repos/octo-org/app/issues/1", `{"state":"closed"}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestTransportRetriesWritesThatWereNotSent(t *testing.T) {
+	var bodies []string
+	transport, _ := newTestTransport(func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+		}
+		return response(http.StatusCreated, "{}"), nil
+	})
+
+	resp, err := transport.RoundTrip(request(t, http.MethodPost, "/repos/octo-org/app/issues", `{"title":"Bug"}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, []string{`{"title":"Bug"}`, `{"title":"Bug"}`}, bodies, "the body is sent again")
+}
+
+func TestTransportRetriesGraphQLTimeouts(t *testing.T) {
+	calls := 0
+	transport, _ := newTestTransport(func(_ *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return response(http.StatusOK, `{"data":null,"errors":[{"message":"Something went wrong while executing your query. This may be the result of a timeout, or it could be a GitHub bug."}]}`), nil
+		}
+		return response(http.StatusOK, `{"data":{"viewer":{"login":"octocat"}}}`), nil
+	})
+
+	resp, err := transport.RoundTrip(request(t, http.MethodPost, "/graphql", `{"query":"query{viewer{login}}"}`))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":{"viewer":{"login":"octocat"}}}`, string(body))
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	resp, err = transport.RoundTrip(request(t, http.MethodPost, "/graphql", `{"query":"mutation($input:CloseIssueInput!){closeIssue(input:$input){clientMutationId}}"}`))
+	require.NoError(t, err)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "timeout", "the body is handed on")
+	assert.Equal(t, 1, calls, "mutations aren't sent again")
+}
+
+func TestTransportStopsWhenCancelled(t *testing.T) {
+	calls := 0
+	transport := NewTransport(roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+		calls++
+		return response(http.StatusBadGateway, ""), nil
+	}), DefaultMaxRetries)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := transport.RoundTrip(request(t, http.MethodGet, "/repos/octo-org/app", "").WithContext(ctx))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotent(t *testing.T) {
+	for _, tc := range []struct {
+		method, path, body string
+		idempotent         bool
+	}{
+		{http.MethodGet, "/repos/octo-org/app", "", true},
+		{http.MethodHead, "/repos/octo-org/app", "", true},
+		{http.MethodPost, "/repos/octo-org/app/issues", `{"title":"Bug"}`, false},
+		{http.MethodPut, "/repos/octo-org/app/pulls/1/merge", `{}`, false},
+		{http.MethodDelete, "/repos/octo-org/app/labels/bug", "", false},
+		{http.MethodPost, "/graphql", `{"query":"{viewer{login}}"}`, true},
+		{http.MethodPost, "/api/graphql", `{"query":" mutation { addStar(input:{}) { clientMutationId } }"}`, false},
+		{http.MethodPost, "/graphql", `{"query":"# query\nmutation { addStar(input:{}) { clientMutationId } }"}`, false},
+		{http.MethodPost, "/graphql", `{"query":"fragment F on Starrable { id } mutation { addStar(input:{}) { starrable { ...F } } }"}`, false},
+	} {
+		assert.Equal(t, tc.idempotent, Idempotent(request(t, tc.method, tc.path, tc.body)), "%s %s %s", tc.method, tc.path, tc.body)
+	}
+}
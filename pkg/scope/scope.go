@@ -0,0 +1,384 @@
+// Package scope confines the server to a set of repositories, whatever else the token can access. Calls
+// of tools, resources, prompts and completions are checked against the repositories they name, and calls
+// that could reach repositories without naming them are turned down.
+package scope
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// UnnamedRepositoryTools are the tools that can reach repositories their arguments don't name, such as
+// notifications and codespaces by ID. Their calls are only in scope when they name a repository that is.
+var UnnamedRepositoryTools = []string{
+	"list_notifications",
+	"get_notification_details",
+	"dismiss_notification",
+	"mark_all_notifications_read",
+	"manage_notification_subscription",
+	"list_codespaces",
+	"get_codespace",
+	"start_codespace",
+	"stop_codespace",
+	"delete_codespace",
+}
+
+// UnscopedTools are the tools that reach repositories by node ID or through GraphQL documents, without
+// any argument naming them. Their calls are never in scope.
+var UnscopedTools = []string{
+	"mark_discussion_answer",
+	"graphql_query",
+}
+
+// ReferenceTools are the tools that look up the issues and pull requests that the title and body of a
+// draft reference as owner/repo#123. Those repositories must be in scope as well.
+var ReferenceTools = []string{
+	"validate_issue_draft",
+	"validate_pull_request_draft",
+}
+
+// SearchTools are the tools whose query can reach any repository. Their calls are only in scope when
+// the query, or the owner and repo of the call, narrow the search down to repositories in scope.
+var SearchTools = []string{
+	"search_code",
+	"search_commits",
+	"search_discussions",
+	"search_issues",
+	"search_pull_requests",
+	"search_repositories",
+}
+
+// Scope holds the patterns of the repositories the server may touch. Patterns are owner/repo in
+// path.Match syntax, such as octo-org/* or */dotfiles, and match ignoring case.
+type Scope struct {
+	allowed []string
+	blocked []string
+}
+
+// New creates a scope of the repositories that match one of the allowed patterns, or any repository when
+// there are none, except the ones that match a blocked pattern.
+func New(allowed, blocked []string) (*Scope, error) {
+	s := &Scope{}
+	for _, patterns := range []struct {
+		from []string
+		to   *[]string
+	}{{allowed, &s.allowed}, {blocked, &s.blocked}} {
+		for _, pattern := range patterns.from {
+			pattern = strings.ToLower(strings.TrimSpace(pattern))
+			if pattern == "" {
+				continue
+			}
+			owner, repo, ok := strings.Cut(pattern, "/")
+			if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+				return nil, fmt.Errorf("invalid repository pattern %q, must be owner/repo such as octo-org/*", pattern)
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid repository pattern %q: %w", pattern, err)
+			}
+			*patterns.to = append(*patterns.to, pattern)
+		}
+	}
+	return s, nil
+}
+
+// AllowsRepository reports whether owner/repo is in scope. An empty owner stands for the account of the
+// authenticated user, which only patterns with the owner * match.
+func (s *Scope) AllowsRepository(owner, repo string) bool {
+	name := strings.ToLower(owner + "/" + repo)
+	if len(s.allowed) > 0 && !matchAny(s.allowed, name) {
+		return false
+	}
+	return !matchAny(s.blocked, name)
+}
+
+// AllowsOwner reports whether all the repositories of owner are in scope, which calls that name an owner
+// but no repository, such as listing the teams or alerts of an organization, need.
+func (s *Scope) AllowsOwner(owner string) bool {
+	owner = strings.ToLower(owner)
+	if len(s.allowed) > 0 {
+		allowed := false
+		for _, pattern := range s.allowed {
+			patternOwner, patternRepo, _ := strings.Cut(pattern, "/")
+			if ok, _ := path.Match(patternOwner, owner); ok && patternRepo == "*" {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	for _, pattern := range s.blocked {
+		patternOwner, _, _ := strings.Cut(pattern, "/")
+		if ok, _ := path.Match(patternOwner, owner); ok {
+			return false
+		}
+	}
+	return true
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// names holds the repositories and owners that the arguments of a call name.
+type names struct {
+	repositories [][2]string
+	owners       []string
+}
+
+// argumentNames collects the repositories and owners that arguments name: the owner and repo, the
+// repositories that issues are transferred to or closed as duplicates of, templates, project items,
+// forks and transfers, and the repositories that are created.
+func argumentNames(arguments map[string]any) *names {
+	str := func(name string) string {
+		s, _ := arguments[name].(string)
+		return s
+	}
+	n := &names{}
+	owner, repo := str("owner"), str("repo")
+	switch {
+	case repo != "":
+		n.repositories = append(n.repositories, [2]string{owner, repo})
+	case str("name") != "" && str("organization") == "":
+		// A repository created in the account of the owner, or of the user without one
+		n.repositories = append(n.repositories, [2]string{owner, str("name")})
+	case owner != "":
+		n.owners = append(n.owners, owner)
+	}
+	if org := str("org"); org != "" {
+		n.owners = append(n.owners, org)
+	}
+	if org := str("organization"); org != "" {
+		name := str("name")
+		if name == "" {
+			name = repo
+		}
+		n.repositories = append(n.repositories, [2]string{org, name})
+	}
+	if target := str("target_repo"); target != "" {
+		n.repositories = append(n.repositories, [2]string{owner, target})
+	}
+	if duplicateOf := str("duplicate_of_repo"); duplicateOf != "" {
+		duplicateOwner, duplicateRepo, _ := strings.Cut(duplicateOf, "/")
+		n.repositories = append(n.repositories, [2]string{duplicateOwner, duplicateRepo})
+	}
+	if newOwner := str("new_owner"); newOwner != "" {
+		name := str("new_name")
+		if name == "" {
+			name = repo
+		}
+		n.repositories = append(n.repositories, [2]string{newOwner, name})
+	}
+	for _, prefix := range []string{"template_", "item_"} {
+		if r := str(prefix + "repo"); r != "" {
+			n.repositories = append(n.repositories, [2]string{str(prefix + "owner"), r})
+		}
+	}
+	return n
+}
+
+// searchQualifierPattern matches the repo:, org: and user: qualifiers of a search query that aren't negated.
+var searchQualifierPattern = regexp.MustCompile(`(?:^|[\s(])(repo|org|user):("[^"]*"|[^\s)]+)`)
+
+// searchOrPattern matches the OR operator of a search query, which lets the terms on either side of it
+// widen a search that the qualifiers on the other side narrow down.
+var searchOrPattern = regexp.MustCompile(`(?:^|[\s()])OR(?:$|[\s()])`)
+
+// referencePattern matches the references to issues and pull requests of other repositories, such as
+// octo-org/app#123.
+var referencePattern = regexp.MustCompile(`(?:^|[^\w/#&])([\w.-]+)/([\w.-]+)#[0-9]+\b`)
+
+// addQuery adds the repositories and owners that the qualifiers of a search query narrow it down to.
+func (n *names) addQuery(query string) {
+	for _, m := range searchQualifierPattern.FindAllStringSubmatch(query, -1) {
+		value := strings.Trim(m[2], `"`)
+		if m[1] == "repo" {
+			owner, repo, _ := strings.Cut(value, "/")
+			n.repositories = append(n.repositories, [2]string{owner, repo})
+		} else {
+			n.owners = append(n.owners, value)
+		}
+	}
+}
+
+// addReferences adds the repositories of the issues and pull requests that text references.
+func (n *names) addReferences(text string) {
+	for _, m := range referencePattern.FindAllStringSubmatch(text, -1) {
+		n.repositories = append(n.repositories, [2]string{m[1], m[2]})
+	}
+}
+
+// addAPIPath adds the repository or owner that a REST API path names, and reports whether it names one.
+func (n *names) addAPIPath(apiPath string) bool {
+	apiPath, _, _ = strings.Cut(apiPath, "?")
+	segments := strings.Split(strings.Trim(apiPath, "/"), "/")
+	switch {
+	case len(segments) >= 3 && segments[0] == "repos":
+		n.repositories = append(n.repositories, [2]string{segments[1], segments[2]})
+	case len(segments) >= 2 && (segments[0] == "orgs" || segments[0] == "users"):
+		n.owners = append(n.owners, segments[1])
+	default:
+		return false
+	}
+	return true
+}
+
+// check returns an error when the names aren't all in scope.
+func (s *Scope) check(n *names) error {
+	for _, r := range n.repositories {
+		if !s.AllowsRepository(r[0], r[1]) {
+			if r[0] == "" {
+				return fmt.Errorf("repositories of the authenticated user named %s are out of the repositories this server is scoped to", r[1])
+			}
+			return fmt.Errorf("%s/%s is out of the repositories this server is scoped to", r[0], r[1])
+		}
+	}
+	for _, owner := range n.owners {
+		if !s.AllowsOwner(owner) {
+			return fmt.Errorf("calls that name the owner %s but no repository need all of its repositories in the scope of this server", owner)
+		}
+	}
+	return nil
+}
+
+// CheckToolCall returns an error when a call of a tool is out of scope.
+func (s *Scope) CheckToolCall(tool string, arguments map[string]any) error {
+	n := argumentNames(arguments)
+	switch {
+	case slices.Contains(SearchTools, tool):
+		// The owner of a search only narrows it down together with the repo
+		n = &names{}
+		owner, _ := arguments["owner"].(string)
+		repo, _ := arguments["repo"].(string)
+		if owner != "" && repo != "" {
+			n.repositories = append(n.repositories, [2]string{owner, repo})
+		}
+		query, _ := arguments["query"].(string)
+		if searchOrPattern.MatchString(query) {
+			return errors.New("searches with OR can't be narrowed down to the repositories this server is scoped to, run one search for each instead")
+		}
+		n.addQuery(query)
+		if len(n.repositories) == 0 && len(n.owners) == 0 {
+			return errors.New("searches must be narrowed down to the repositories this server is scoped to, with repo:, org: or user: qualifiers or the owner and repo arguments")
+		}
+	case tool == "api_request":
+		apiPath, _ := arguments["path"].(string)
+		if !n.addAPIPath(apiPath) {
+			return errors.New("only paths under /repos/{owner}/{repo}, /orgs/{org} or /users/{user} can be checked against the repositories this server is scoped to")
+		}
+	case slices.Contains(UnscopedTools, tool):
+		return fmt.Errorf("%s can reach any repository without naming it, so it is not available when this server is scoped to repositories", tool)
+	case slices.Contains(ReferenceTools, tool):
+		for _, name := range []string{"title", "body"} {
+			text, _ := arguments[name].(string)
+			n.addReferences(text)
+		}
+	case slices.Contains(UnnamedRepositoryTools, tool) && len(n.repositories) == 0:
+		return fmt.Errorf("%s can reach repositories that its arguments don't name, so it needs an owner and repo in the scope of this server", tool)
+	}
+	return s.check(n)
+}
+
+// ToolHandlerMiddleware answers the calls of tools that are out of scope with an error result instead of
+// running them. It must run after the middleware that changes the arguments of calls, such as parsing
+// references.
+func ToolHandlerMiddleware(s *Scope) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if err := s.CheckToolCall(request.Params.Name, request.GetArguments()); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is denied: %s", request.Params.Name, err)), nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// resourceURIPattern matches the owner and repository of the URIs of resources, such as
+// repo://owner/repo/issues/1.
+var resourceURIPattern = regexp.MustCompile(`^repo://([^/]+)/([^/]+)`)
+
+// scopedRequest holds the parts of the requests of resources, prompts and completions that name
+// repositories.
+type scopedRequest struct {
+	ID     *mcp.RequestId `json:"id"`
+	Method string         `json:"method"`
+	Params struct {
+		URI       string            `json:"uri"`
+		Arguments map[string]string `json:"arguments"`
+		Context   struct {
+			Arguments map[string]string `json:"arguments"`
+		} `json:"context"`
+	} `json:"params"`
+}
+
+// HandleMessage turns down the requests to read or subscribe to resources, get prompts and complete
+// arguments that name repositories out of scope. It reports false for any other message, so that the
+// requests in scope are served as usual.
+func (s *Scope) HandleMessage(_ context.Context, _ string, message json.RawMessage) (mcp.JSONRPCMessage, bool) {
+	var request scopedRequest
+	if err := json.Unmarshal(message, &request); err != nil || request.ID == nil {
+		return nil, false
+	}
+	if err := s.checkRequest(request); err != nil {
+		response := mcp.JSONRPCError{JSONRPC: mcp.JSONRPC_VERSION, ID: *request.ID}
+		response.Error.Code = mcp.INVALID_PARAMS
+		response.Error.Message = err.Error()
+		return response, true
+	}
+	return nil, false
+}
+
+// OnRequestInitialization is a server.OnRequestInitializationFunc that turns down the same requests as
+// HandleMessage for the transports that hand every request to the MCP server library, such as SSE.
+func (s *Scope) OnRequestInitialization(_ context.Context, _ any, message any) error {
+	raw, ok := message.(json.RawMessage)
+	if !ok {
+		return nil
+	}
+	var request scopedRequest
+	if err := json.Unmarshal(raw, &request); err != nil {
+		return nil
+	}
+	return s.checkRequest(request)
+}
+
+// checkRequest returns an error when a request of a resource, prompt or completion names a repository
+// out of scope.
+func (s *Scope) checkRequest(request scopedRequest) error {
+	var arguments map[string]string
+	switch request.Method {
+	case string(mcp.MethodResourcesRead), "resources/subscribe":
+		m := resourceURIPattern.FindStringSubmatch(request.Params.URI)
+		if m == nil {
+			return nil
+		}
+		arguments = map[string]string{"owner": m[1], "repo": m[2]}
+	case string(mcp.MethodPromptsGet):
+		arguments = request.Params.Arguments
+	case "completion/complete":
+		// Only the branches, tags and labels of the repository filled in before are listed
+		arguments = request.Params.Context.Arguments
+	default:
+		return nil
+	}
+
+	if arguments["owner"] == "" || arguments["repo"] == "" {
+		return nil
+	}
+	return s.check(&names{repositories: [][2]string{{arguments["owner"], arguments["repo"]}}})
+}
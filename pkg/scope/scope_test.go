@@ -0,0 +1,267 @@
+package scope
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	for _, pattern := range []string{"octo-org", "octo-org/", "/app", "octo-org/app/issues", "octo-org/[app"} {
+		_, err := New([]string{pattern}, nil)
+		assert.Error(t, err, pattern)
+	}
+	_, err := New(nil, []string{"*/*"})
+	assert.NoError(t, err)
+}
+
+func TestCheckToolCall(t *testing.T) {
+	s, err := New([]string{"Octo-Org/*", "octocat/dotfiles", "*/sandbox"}, []string{"octo-org/secret"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]any
+		allowed   bool
+	}{
+		{
+			name:      "repository in scope, ignoring case",
+			tool:      "get_issue",
+			arguments: map[string]any{"owner": "OCTO-ORG", "repo": "app", "issue_number": float64(1)},
+			allowed:   true,
+		},
+		{
+			name:      "repository out of scope",
+			tool:      "get_issue",
+			arguments: map[string]any{"owner": "octocat", "repo": "hello-world", "issue_number": float64(1)},
+		},
+		{
+			name:      "blocked repository",
+			tool:      "push_files",
+			arguments: map[string]any{"owner": "octo-org", "repo": "secret", "branch": "main"},
+		},
+		{
+			name:      "owner with a blocked repository",
+			tool:      "list_teams",
+			arguments: map[string]any{"org": "octo-org"},
+		},
+		{
+			name:      "owner without all repositories in scope",
+			tool:      "list_webhooks",
+			arguments: map[string]any{"owner": "octocat"},
+		},
+		{
+			name:      "issue transferred out of scope",
+			tool:      "transfer_issue",
+			arguments: map[string]any{"owner": "octo-org", "repo": "app", "issue_number": float64(1), "target_repo": "secret"},
+		},
+		{
+			name:      "fork into an organization out of scope",
+			tool:      "fork_repository",
+			arguments: map[string]any{"owner": "octo-org", "repo": "app", "organization": "evil-org"},
+		},
+		{
+			name:      "repository created in the account of the user",
+			tool:      "create_repository",
+			arguments: map[string]any{"name": "sandbox"},
+			allowed:   true,
+		},
+		{
+			name:      "repository created from a template out of scope",
+			tool:      "create_repository_from_template",
+			arguments: map[string]any{"template_owner": "octocat", "template_repo": "template", "owner": "octo-org", "name": "app-2"},
+		},
+		{
+			name:      "search narrowed down to repositories in scope",
+			tool:      "search_code",
+			arguments: map[string]any{"query": `func main repo:octo-org/app repo:"octocat/dotfiles"`},
+			allowed:   true,
+		},
+		{
+			name:      "search narrowed down by the owner and repo arguments",
+			tool:      "search_issues",
+			arguments: map[string]any{"query": "is:open", "owner": "octo-org", "repo": "app"},
+			allowed:   true,
+		},
+		{
+			name:      "search of any repository",
+			tool:      "search_code",
+			arguments: map[string]any{"query": "func main -repo:octo-org/secret"},
+		},
+		{
+			name:      "discussion search of any repository",
+			tool:      "search_discussions",
+			arguments: map[string]any{"query": "is:unanswered"},
+		},
+		{
+			name:      "discussion search of a repository in scope",
+			tool:      "search_discussions",
+			arguments: map[string]any{"query": "is:unanswered", "owner": "octo-org", "repo": "app"},
+			allowed:   true,
+		},
+		{
+			name:      "search widened with OR",
+			tool:      "search_issues",
+			arguments: map[string]any{"query": "repo:octo-org/app OR is:public"},
+		},
+		{
+			name:      "search of a repository in parentheses",
+			tool:      "search_issues",
+			arguments: map[string]any{"query": "repo:octo-org/app (repo:octocat/hello-world)"},
+		},
+		{
+			name:      "search with or as a word",
+			tool:      "search_code",
+			arguments: map[string]any{"query": "this or that repo:octo-org/app"},
+			allowed:   true,
+		},
+		{
+			name:      "search of an owner with a blocked repository",
+			tool:      "search_repositories",
+			arguments: map[string]any{"query": "org:octo-org"},
+		},
+		{
+			name:      "API request of a repository in scope",
+			tool:      "api_request",
+			arguments: map[string]any{"method": "GET", "path": "/repos/octo-org/app/issues?state=open"},
+			allowed:   true,
+		},
+		{
+			name:      "API request that names no repository",
+			tool:      "api_request",
+			arguments: map[string]any{"method": "GET", "path": "/search/code?q=main"},
+		},
+		{
+			name:      "notifications of a repository in scope",
+			tool:      "list_notifications",
+			arguments: map[string]any{"owner": "octocat", "repo": "dotfiles"},
+			allowed:   true,
+		},
+		{
+			name:      "notifications of all repositories",
+			tool:      "list_notifications",
+			arguments: map[string]any{},
+		},
+		{
+			name:      "codespaces by name",
+			tool:      "start_codespace",
+			arguments: map[string]any{"codespace_name": "octocat-dotfiles-1234"},
+		},
+		{
+			name:      "GraphQL query naming a repository in scope",
+			tool:      "graphql_query",
+			arguments: map[string]any{"owner": "octo-org", "repo": "app", "query": `{ repository(owner: "octocat", name: "hello-world") { id } }`},
+		},
+		{
+			name:      "discussion answer by node ID",
+			tool:      "mark_discussion_answer",
+			arguments: map[string]any{"owner": "octo-org", "repo": "app", "commentId": "DC_kwDOA"},
+		},
+		{
+			name:      "draft referencing issues in scope",
+			tool:      "validate_issue_draft",
+			arguments: map[string]any{"owner": "octo-org", "repo": "app", "title": "Fix #1", "body": "Follows octocat/dotfiles#2"},
+			allowed:   true,
+		},
+		{
+			name:      "draft referencing an issue out of scope",
+			tool:      "validate_pull_request_draft",
+			arguments: map[string]any{"owner": "octo-org", "repo": "app", "title": "Fix", "body": "Closes octocat/hello-world#2"},
+		},
+		{
+			name:      "tools that don't touch repositories",
+			tool:      "get_me",
+			arguments: map[string]any{},
+			allowed:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := s.CheckToolCall(tc.tool, tc.arguments)
+			if tc.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestAllowsOwner(t *testing.T) {
+	s, err := New(nil, []string{"octo-org/secret"})
+	require.NoError(t, err)
+	assert.True(t, s.AllowsOwner("octocat"), "without allowed patterns, every owner without blocked repositories is in scope")
+	assert.False(t, s.AllowsOwner("Octo-Org"))
+	assert.True(t, s.AllowsRepository("octo-org", "app"))
+}
+
+func TestToolHandlerMiddleware(t *testing.T) {
+	s, err := New([]string{"octo-org/*"}, nil)
+	require.NoError(t, err)
+
+	called := false
+	handler := ToolHandlerMiddleware(s)(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		called = true
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "get_issue"
+	request.Params.Arguments = map[string]any{"owner": "octo-org", "repo": "app"}
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, called)
+	assert.False(t, result.IsError)
+
+	called = false
+	request.Params.Arguments = map[string]any{"owner": "octocat", "repo": "app"}
+	result, err = handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.False(t, called)
+	require.True(t, result.IsError)
+	assert.Equal(t, "get_issue is denied: octocat/app is out of the repositories this server is scoped to", result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestHandleMessage(t *testing.T) {
+	s, err := New([]string{"octo-org/*"}, nil)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name    string
+		message string
+		denied  bool
+	}{
+		{"resource in scope", `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"repo://octo-org/app/issues/1"}}`, false},
+		{"resource out of scope", `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"repo://octocat/app/contents/README.md"}}`, true},
+		{"subscription out of scope", `{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"repo://octocat/app/issues/1"}}`, true},
+		{"prompt out of scope", `{"jsonrpc":"2.0","id":1,"method":"prompts/get","params":{"name":"triage_issues","arguments":{"owner":"octocat","repo":"app"}}}`, true},
+		{"prompt without a repository", `{"jsonrpc":"2.0","id":1,"method":"prompts/get","params":{"name":"triage_issues","arguments":{}}}`, false},
+		{"completion out of scope", `{"jsonrpc":"2.0","id":1,"method":"completion/complete","params":{"ref":{"type":"ref/prompt","name":"triage_issues"},"argument":{"name":"labels","value":""},"context":{"arguments":{"owner":"octocat","repo":"app"}}}}`, true},
+		{"other requests", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			response, ok := s.HandleMessage(context.Background(), "session", json.RawMessage(tc.message))
+			require.Equal(t, tc.denied, ok)
+			if tc.denied {
+				require.IsType(t, mcp.JSONRPCError{}, response)
+				assert.Equal(t, mcp.INVALID_PARAMS, response.(mcp.JSONRPCError).Error.Code)
+				assert.Contains(t, response.(mcp.JSONRPCError).Error.Message, "octocat/app is out of the repositories")
+			}
+		})
+	}
+}
+
+func TestOnRequestInitialization(t *testing.T) {
+	s, err := New([]string{"octo-org/*"}, nil)
+	require.NoError(t, err)
+
+	err = s.OnRequestInitialization(context.Background(), 1, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"repo://octocat/app/issues/1"}}`))
+	assert.ErrorContains(t, err, "octocat/app is out of the repositories")
+	assert.NoError(t, s.OnRequestInitialization(context.Background(), 1, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"repo://octo-org/app/issues/1"}}`)))
+}
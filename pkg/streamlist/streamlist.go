@@ -0,0 +1,207 @@
+// Package streamlist streams the pages of list tools to clients that follow the progress of their calls.
+// A call that gives a progress token fetches page after page by the cursor of the previous one, and each
+// page but the last is sent to the client in a progress notification as soon as it is fetched, so that the
+// client can start on the first items while the next pages are fetched. The result of the call holds the
+// items of every page, so that clients that drop progress notifications still get them all. At most
+// MaxPages pages are fetched, after which the result says where the next page starts.
+package streamlist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"maps"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/cursor"
+	"github.com/github/github-mcp-server/pkg/structured"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MaxPages is the most pages a single call streams.
+const MaxPages = 10
+
+// methodNotificationProgress is the MCP method of progress notifications.
+const methodNotificationProgress = "notifications/progress"
+
+// allPagesArgument is the argument of the list tools that fetch every page at once, which are left to it.
+const allPagesArgument = "allPages"
+
+// nextCursor returns the cursor of the page after the one of a result, or "" if it is the last page. The
+// cursor is in the structured content of the result, in its first part if that is a JSON object, or in a
+// last part of its own if the first is a JSON list.
+func nextCursor(result *mcp.CallToolResult) string {
+	if content, ok := result.StructuredContent.(map[string]any); ok {
+		if c, _ := content[cursor.Field].(string); c != "" {
+			return c
+		}
+	}
+	if len(result.Content) == 0 {
+		return ""
+	}
+	for _, i := range []int{0, len(result.Content) - 1} {
+		text, ok := result.Content[i].(mcp.TextContent)
+		if !ok || !strings.HasPrefix(strings.TrimSpace(text.Text), "{") {
+			continue
+		}
+		var object struct {
+			NextCursor string `json:"next_cursor"`
+		}
+		if json.Unmarshal([]byte(text.Text), &object) == nil && object.NextCursor != "" {
+			return object.NextCursor
+		}
+	}
+	return ""
+}
+
+// listing is the list of a page, on its own or in a field of an object, such as the issues of a search.
+type listing struct {
+	// object holds the other fields of the object, or is nil for a list on its own
+	object map[string]json.RawMessage
+	field  string
+	items  []json.RawMessage
+}
+
+// parseListing parses the first part of a page as a JSON list, or as an object whose largest field is a
+// list.
+func parseListing(page *mcp.CallToolResult) (*listing, bool) {
+	if len(page.Content) == 0 {
+		return nil, false
+	}
+	text, ok := page.Content[0].(mcp.TextContent)
+	if !ok {
+		return nil, false
+	}
+	trimmed := strings.TrimSpace(text.Text)
+	var items []json.RawMessage
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &items); err != nil {
+			return nil, false
+		}
+		return &listing{items: items}, true
+	}
+	var object map[string]json.RawMessage
+	if !strings.HasPrefix(trimmed, "{") || json.Unmarshal([]byte(trimmed), &object) != nil {
+		return nil, false
+	}
+	field, size := "", 0
+	for name, value := range object {
+		if len(value) > size && value[0] == '[' {
+			field, size = name, len(value)
+		}
+	}
+	if field == "" || json.Unmarshal(object[field], &items) != nil {
+		return nil, false
+	}
+	return &listing{object: object, field: field, items: items}, true
+}
+
+// withItems returns the last page of a listing with its items replaced by the items of every page, in its
+// text and in its structured content. HTML isn't escaped, so that the items read as the tool returned them.
+func (l *listing) withItems(page *mcp.CallToolResult, items []json.RawMessage) *mcp.CallToolResult {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.Write(item)
+	}
+	b.WriteByte(']')
+	list := json.RawMessage(b.String())
+
+	text := page.Content[0].(mcp.TextContent)
+	text.Text = string(list)
+	if l.object != nil {
+		object := maps.Clone(l.object)
+		object[l.field] = list
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(object); err != nil {
+			return page
+		}
+		text.Text = strings.TrimSuffix(buf.String(), "\n")
+	}
+	page.Content[0] = text
+	if content, ok := page.StructuredContent.(map[string]any); ok {
+		var decoded []any
+		if json.Unmarshal(list, &decoded) == nil {
+			field := structured.ItemsField
+			if l.object != nil {
+				field = l.field
+			}
+			content[field] = decoded
+		}
+	}
+	return page
+}
+
+// send notifies the client of a page that was fetched.
+func send(ctx context.Context, srv *server.MCPServer, token mcp.ProgressToken, number int, page *mcp.CallToolResult) {
+	var message string
+	if len(page.Content) > 0 {
+		if text, ok := page.Content[0].(mcp.TextContent); ok {
+			message = text.Text
+		}
+	}
+	// Like all progress, pages are best effort, a client that can't be notified still gets them in the result
+	_ = srv.SendNotificationToClient(ctx, methodNotificationProgress, map[string]any{
+		"progressToken": token,
+		"progress":      float64(number),
+		"message":       message,
+	})
+}
+
+// ToolHandlerMiddleware streams the pages of the calls of the list tools that lookup finds
+// cursor.Paginated, when the call gives a progress token. Calls without one, and calls that fetch every
+// page at once with allPages, get their page as usual. It must wrap the cursor middleware, whose cursors
+// lead it from page to page.
+func ToolHandlerMiddleware(lookup func(name string) (mcp.Tool, bool)) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			srv := server.ServerFromContext(ctx)
+			if srv == nil || request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+				return next(ctx, request)
+			}
+			tool, ok := lookup(request.Params.Name)
+			if allPages, _ := request.GetArguments()[allPagesArgument].(bool); !ok || !cursor.Paginated(tool) || allPages {
+				return next(ctx, request)
+			}
+			token := request.Params.Meta.ProgressToken
+
+			// The items of the pages so far, as long as every page is a listing
+			var items []json.RawMessage
+			listings := true
+			for number := 1; ; number++ {
+				result, err := next(ctx, request)
+				if err != nil || result == nil || result.IsError {
+					return result, err
+				}
+				page, ok := parseListing(result)
+				if listings = listings && ok; listings {
+					items = append(items, page.items...)
+				}
+				c := nextCursor(result)
+				if c == "" || number == MaxPages {
+					if listings && number > 1 {
+						return page.withItems(result, items), nil
+					}
+					return result, nil
+				}
+				send(ctx, srv, token, number, result)
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+
+				arguments := maps.Clone(request.GetArguments())
+				if arguments == nil {
+					arguments = map[string]any{}
+				}
+				arguments[cursor.Argument] = c
+				request.Params.Arguments = arguments
+			}
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package streamlist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/cursor"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSession is a client session that keeps the notifications it is sent.
+type fakeSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *fakeSession) Initialize()       {}
+func (s *fakeSession) Initialized() bool { return true }
+func (s *fakeSession) SessionID() string { return "stream" }
+func (s *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+// listTool is a list tool over pages items, one per page, that pages like the GraphQL tools do.
+var listTool = mcp.NewTool("list_items",
+	mcp.WithNumber("perPage"),
+	mcp.WithString("after"),
+	mcp.WithString(cursor.Argument),
+)
+
+// listItems returns the handler of listTool, which fails on the page failOn, if it isn't zero, and counts
+// the pages it was asked for in calls.
+func listItems(pages, failOn int, calls *int) server.ToolHandlerFunc {
+	return func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		*calls++
+		at, err := cursor.FromArguments(request.GetArguments())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		number := 1
+		if at.After != "" {
+			number, _ = strconv.Atoi(at.After)
+			number++
+		}
+		if number == failOn {
+			return mcp.NewToolResultError("failed to list items"), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf(`{"items":[%d],"pageInfo":{"hasNextPage":%t,"endCursor":"%d"}}`, number, number < pages, number)), nil
+	}
+}
+
+// call calls a tool through an MCP server that streams lists, with a progress token if withToken is set,
+// and returns its result and the progress notifications it sent.
+func call(t *testing.T, tool mcp.Tool, handler server.ToolHandlerFunc, withToken bool) (mcp.CallToolResult, []map[string]any) {
+	t.Helper()
+	lookup := func(name string) (mcp.Tool, bool) {
+		return tool, name == tool.Name
+	}
+	srv := server.NewMCPServer("test", "1",
+		server.WithToolHandlerMiddleware(ToolHandlerMiddleware(lookup)),
+		server.WithToolHandlerMiddleware(cursor.ToolHandlerMiddleware(lookup)),
+	)
+	srv.AddTool(tool, handler)
+	session := &fakeSession{notifications: make(chan mcp.JSONRPCNotification, MaxPages)}
+	ctx := srv.WithContext(context.Background(), session)
+
+	params := map[string]any{"name": tool.Name, "arguments": map[string]any{}}
+	if withToken {
+		params["_meta"] = map[string]any{"progressToken": "token"}
+	}
+	message, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": params})
+	require.NoError(t, err)
+	response, ok := srv.HandleMessage(ctx, message).(mcp.JSONRPCResponse)
+	require.True(t, ok)
+	result, ok := response.Result.(mcp.CallToolResult)
+	require.True(t, ok)
+
+	var notifications []map[string]any
+	for len(session.notifications) > 0 {
+		notification := <-session.notifications
+		require.Equal(t, methodNotificationProgress, notification.Method)
+		assert.Equal(t, "token", notification.Params.AdditionalFields["progressToken"])
+		notifications = append(notifications, notification.Params.AdditionalFields)
+	}
+	return result, notifications
+}
+
+// items returns the items of a page of listTool, and its next cursor.
+func items(t *testing.T, text string) ([]int, string) {
+	t.Helper()
+	var page struct {
+		Items      []int  `json:"items"`
+		NextCursor string `json:"next_cursor"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text), &page))
+	return page.Items, page.NextCursor
+}
+
+func resultText(t *testing.T, result mcp.CallToolResult) string {
+	t.Helper()
+	require.NotEmpty(t, result.Content)
+	text, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok)
+	return text.Text
+}
+
+func TestToolHandlerMiddleware(t *testing.T) {
+	t.Run("streams every page but the last, and returns the items of all of them", func(t *testing.T) {
+		var calls int
+		result, notifications := call(t, listTool, listItems(3, 0, &calls), true)
+		require.False(t, result.IsError)
+		assert.Equal(t, 3, calls)
+
+		require.Len(t, notifications, 2)
+		for i, notification := range notifications {
+			assert.Equal(t, float64(i+1), notification["progress"])
+			page, nextCursor := items(t, notification["message"].(string))
+			assert.Equal(t, []int{i + 1}, page)
+			assert.NotEmpty(t, nextCursor)
+		}
+		page, nextCursor := items(t, resultText(t, result))
+		assert.Equal(t, []int{1, 2, 3}, page, "clients that drop the notifications still get every page")
+		assert.Empty(t, nextCursor, "there is no page after the last one")
+	})
+
+	t.Run("stops at the page cap, with the cursor of the next page", func(t *testing.T) {
+		var calls int
+		result, notifications := call(t, listTool, listItems(MaxPages+5, 0, &calls), true)
+		require.False(t, result.IsError)
+		assert.Equal(t, MaxPages, calls)
+		assert.Len(t, notifications, MaxPages-1)
+
+		page, nextCursor := items(t, resultText(t, result))
+		assert.Len(t, page, MaxPages)
+		position, err := cursor.Decode(nextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, strconv.Itoa(MaxPages), position.After, "the listing goes on after the last page streamed")
+	})
+
+	t.Run("pages as usual without a progress token", func(t *testing.T) {
+		var calls int
+		result, notifications := call(t, listTool, listItems(3, 0, &calls), false)
+		require.False(t, result.IsError)
+		assert.Equal(t, 1, calls)
+		assert.Empty(t, notifications)
+		page, nextCursor := items(t, resultText(t, result))
+		assert.Equal(t, []int{1}, page)
+		assert.NotEmpty(t, nextCursor)
+	})
+
+	t.Run("ends with the error of a page", func(t *testing.T) {
+		var calls int
+		result, notifications := call(t, listTool, listItems(5, 3, &calls), true)
+		assert.True(t, result.IsError)
+		assert.Equal(t, "failed to list items", resultText(t, result))
+		assert.Equal(t, 3, calls)
+		assert.Len(t, notifications, 2, "the pages before it were streamed")
+	})
+
+	t.Run("leaves other tools alone", func(t *testing.T) {
+		var calls int
+		tool := mcp.NewTool("get_item", mcp.WithString("after"))
+		result, notifications := call(t, tool, listItems(3, 0, &calls), true)
+		require.False(t, result.IsError)
+		assert.Equal(t, 1, calls)
+		assert.Empty(t, notifications)
+	})
+}
+
+func TestListingWithItems(t *testing.T) {
+	t.Run("a JSON list", func(t *testing.T) {
+		page := &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(`[{"title":"<c>"}]`),
+				mcp.NewTextContent(`{"next_cursor":"abc"}`),
+			},
+			StructuredContent: map[string]any{"items": []any{map[string]any{"title": "<c>"}}, cursor.Field: "abc"},
+		}
+		l, ok := parseListing(page)
+		require.True(t, ok)
+		result := l.withItems(page, []json.RawMessage{json.RawMessage(`{"title":"a"}`), json.RawMessage(`{"title":"b"}`), l.items[0]})
+
+		assert.Equal(t, `[{"title":"a"},{"title":"b"},{"title":"<c>"}]`, resultText(t, *result))
+		assert.Equal(t, `{"next_cursor":"abc"}`, result.Content[1].(mcp.TextContent).Text, "the cursor part is kept")
+		assert.Equal(t, map[string]any{
+			"items":      []any{map[string]any{"title": "a"}, map[string]any{"title": "b"}, map[string]any{"title": "<c>"}},
+			cursor.Field: "abc",
+		}, result.StructuredContent)
+	})
+
+	t.Run("an object with a list", func(t *testing.T) {
+		page := mcp.NewToolResultText(`{"total_count":3,"issues":[3],"next_cursor":"abc"}`)
+		l, ok := parseListing(page)
+		require.True(t, ok)
+		result := l.withItems(page, []json.RawMessage{json.RawMessage(`1`), json.RawMessage(`2`), json.RawMessage(`3`)})
+		assert.JSONEq(t, `{"total_count":3,"issues":[1,2,3],"next_cursor":"abc"}`, resultText(t, *result))
+	})
+
+	t.Run("anything else", func(t *testing.T) {
+		_, ok := parseListing(mcp.NewToolResultText(`{"name":"repo"}`))
+		assert.False(t, ok)
+		_, ok = parseListing(mcp.NewToolResultText(`not JSON`))
+		assert.False(t, ok)
+	})
+}
+
+func TestNextCursor(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   *mcp.CallToolResult
+		expected string
+	}{
+		{
+			name:     "in a JSON object",
+			result:   mcp.NewToolResultText(`{"items":[],"next_cursor":"abc"}`),
+			expected: "abc",
+		},
+		{
+			name: "after a JSON list",
+			result: &mcp.CallToolResult{Content: []mcp.Content{
+				mcp.NewTextContent(`[1,2]`),
+				mcp.NewTextContent(`{"next_cursor":"abc"}`),
+			}},
+			expected: "abc",
+		},
+		{
+			name:     "in the structured content",
+			result:   &mcp.CallToolResult{StructuredContent: map[string]any{cursor.Field: "abc"}},
+			expected: "abc",
+		},
+		{
+			name:   "the last page",
+			result: mcp.NewToolResultText(`[1,2]`),
+		},
+		{
+			name:   "no content",
+			result: &mcp.CallToolResult{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, nextCursor(tc.result))
+		})
+	}
+}
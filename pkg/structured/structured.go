@@ -0,0 +1,86 @@
+// Package structured returns tool results as MCP structured content next to their text, and gives every
+// tool an output schema, so that clients can read results without parsing the text.
+package structured
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// ItemsField holds the results that are JSON arrays, as structured content is always an object.
+	ItemsField = "items"
+	// ValueField holds the results that are JSON strings, numbers or booleans.
+	ValueField = "value"
+	// TextField holds the results that aren't JSON, such as plain messages.
+	TextField = "text"
+)
+
+// DefaultOutputSchema is the output schema of the tools that don't declare their own. JSON objects are
+// returned as they are, everything else in one of the fields below.
+var DefaultOutputSchema = json.RawMessage(`{"type":"object","properties":{` +
+	`"items":{"type":"array","description":"The result, when it is a list"},` +
+	`"value":{"description":"The result, when it is a single JSON value other than an object or a list"},` +
+	`"text":{"type":"string","description":"The result, when it isn't JSON"}}}`)
+
+// Content returns the structured content of the text of a tool result.
+func Content(text string) map[string]any {
+	trimmed := bytes.TrimSpace([]byte(text))
+	var v any
+	if len(trimmed) == 0 || json.Unmarshal(trimmed, &v) != nil {
+		return map[string]any{TextField: text}
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		return v
+	case []any:
+		return map[string]any{ItemsField: v}
+	default:
+		return map[string]any{ValueField: v}
+	}
+}
+
+// ToolHandlerMiddleware sets the structured content of successful tool results, unless the tool already
+// did. A result that is a single text is structured with Content; the texts of results with several parts,
+// such as a file and its resource, are kept together in TextField. It must run after the middleware that
+// changes the text of results.
+func ToolHandlerMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError || result.StructuredContent != nil {
+			return result, err
+		}
+
+		var texts []string
+		for _, content := range result.Content {
+			if text, ok := content.(mcp.TextContent); ok {
+				texts = append(texts, text.Text)
+			}
+		}
+		switch {
+		case len(texts) == 1 && len(result.Content) == 1:
+			result.StructuredContent = Content(texts[0])
+		case len(texts) > 0:
+			result.StructuredContent = map[string]any{TextField: strings.Join(texts, "\n")}
+		default:
+			result.StructuredContent = map[string]any{}
+		}
+		return result, nil
+	}
+}
+
+// AddDefaultOutputSchemas gives the listed tools that don't declare an output schema the default one.
+// It is meant for the hook that runs after tools/list, so that it covers the tools of every toolset,
+// including the ones enabled at runtime.
+func AddDefaultOutputSchemas(_ context.Context, _ any, _ *mcp.ListToolsRequest, result *mcp.ListToolsResult) {
+	for i := range result.Tools {
+		if result.Tools[i].RawOutputSchema == nil {
+			result.Tools[i].RawOutputSchema = DefaultOutputSchema
+		}
+	}
+}
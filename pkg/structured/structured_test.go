@@ -0,0 +1,133 @@
+package structured
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected map[string]any
+	}{
+		{
+			name:     "object",
+			text:     `{"number": 1, "title": "Bug"}`,
+			expected: map[string]any{"number": float64(1), "title": "Bug"},
+		},
+		{
+			name:     "list",
+			text:     `[{"number": 1}, {"number": 2}]`,
+			expected: map[string]any{"items": []any{map[string]any{"number": float64(1)}, map[string]any{"number": float64(2)}}},
+		},
+		{
+			name:     "empty list",
+			text:     `[]`,
+			expected: map[string]any{"items": []any{}},
+		},
+		{
+			name:     "single value",
+			text:     `42`,
+			expected: map[string]any{"value": float64(42)},
+		},
+		{
+			name:     "plain text",
+			text:     "Deleted the label bug",
+			expected: map[string]any{"text": "Deleted the label bug"},
+		},
+		{
+			name:     "empty text",
+			text:     "",
+			expected: map[string]any{"text": ""},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Content(tc.text))
+		})
+	}
+}
+
+func TestToolHandlerMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   *mcp.CallToolResult
+		expected any
+	}{
+		{
+			name:     "structures a single text",
+			result:   mcp.NewToolResultText(`[1, 2]`),
+			expected: map[string]any{"items": []any{float64(1), float64(2)}},
+		},
+		{
+			name: "keeps the texts of results with several parts together",
+			result: &mcp.CallToolResult{Content: []mcp.Content{
+				mcp.NewTextContent("first"),
+				mcp.NewImageContent("aW1hZ2U=", "image/png"),
+				mcp.NewTextContent("second"),
+			}},
+			expected: map[string]any{"text": "first\nsecond"},
+		},
+		{
+			name:     "gives results without text an empty object",
+			result:   &mcp.CallToolResult{Content: []mcp.Content{mcp.NewImageContent("aW1hZ2U=", "image/png")}},
+			expected: map[string]any{},
+		},
+		{
+			name:     "keeps the structured content of the tool",
+			result:   mcp.NewToolResultStructured(map[string]any{"own": true}, `{"own": true}`),
+			expected: map[string]any{"own": true},
+		},
+		{
+			name:   "leaves errors alone",
+			result: mcp.NewToolResultError(`{"message": "Not Found"}`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := ToolHandlerMiddleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tc.result, nil
+			})
+			result, err := handler(context.Background(), mcp.CallToolRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.StructuredContent)
+		})
+	}
+}
+
+func TestToolHandlerMiddleware_SendsStructuredContent(t *testing.T) {
+	handler := ToolHandlerMiddleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(`{"login": "octocat"}`), nil
+	})
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+
+	// The structured content must survive the encoding of the result that clients receive
+	b, err := json.Marshal(result)
+	require.NoError(t, err)
+	var sent map[string]any
+	require.NoError(t, json.Unmarshal(b, &sent))
+	assert.Equal(t, map[string]any{"login": "octocat"}, sent["structuredContent"])
+}
+
+func TestAddDefaultOutputSchemas(t *testing.T) {
+	own := json.RawMessage(`{"type":"object","properties":{"sha":{"type":"string"}}}`)
+	result := &mcp.ListToolsResult{Tools: []mcp.Tool{
+		mcp.NewTool("list_issues"),
+		mcp.NewTool("get_git_blob", mcp.WithRawOutputSchema(own)),
+	}}
+
+	AddDefaultOutputSchemas(context.Background(), nil, nil, result)
+
+	assert.Equal(t, DefaultOutputSchema, result.Tools[0].RawOutputSchema)
+	assert.Equal(t, own, result.Tools[1].RawOutputSchema)
+	assert.True(t, json.Valid(DefaultOutputSchema))
+}
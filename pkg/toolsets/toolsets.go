@@ -29,6 +29,18 @@ func NewToolsetDoesNotExistError(name string) *ToolsetDoesNotExistError {
 	return &ToolsetDoesNotExistError{Name: name}
 }
 
+type ToolDoesNotExistError struct {
+	Name string
+}
+
+func (e *ToolDoesNotExistError) Error() string {
+	return fmt.Sprintf("tool %s does not exist", e.Name)
+}
+
+func NewToolDoesNotExistError(name string) *ToolDoesNotExistError {
+	return &ToolDoesNotExistError{Name: name}
+}
+
 func NewServerTool(tool mcp.Tool, handler server.ToolHandlerFunc) server.ServerTool {
 	return server.ServerTool{Tool: tool, Handler: handler}
 }
@@ -72,37 +84,58 @@ type Toolset struct {
 	resourceTemplates []ServerResourceTemplate
 	// prompts are also not tools but are namespaced similarly
 	prompts []ServerPrompt
+	// excludedTools are the names of the tools withheld by configuration, whether or not the toolset is enabled
+	excludedTools map[string]bool
 }
 
 func (t *Toolset) GetActiveTools() []server.ServerTool {
 	if t.Enabled {
-		if t.readOnly {
-			return t.readTools
-		}
-		return append(t.readTools, t.writeTools...)
+		return t.GetAvailableTools()
 	}
 	return nil
 }
 
 func (t *Toolset) GetAvailableTools() []server.ServerTool {
+	tools := t.withoutExcluded(t.readTools)
 	if t.readOnly {
-		return t.readTools
+		return tools
 	}
-	return append(t.readTools, t.writeTools...)
+	return append(tools, t.withoutExcluded(t.writeTools)...)
 }
 
-func (t *Toolset) RegisterTools(s *server.MCPServer) {
-	if !t.Enabled {
-		return
+// withoutExcluded returns the tools that aren't excluded.
+func (t *Toolset) withoutExcluded(tools []server.ServerTool) []server.ServerTool {
+	kept := make([]server.ServerTool, 0, len(tools))
+	for _, tool := range tools {
+		if !t.excludedTools[tool.Tool.Name] {
+			kept = append(kept, tool)
+		}
 	}
-	for _, tool := range t.readTools {
+	return kept
+}
+
+// IsToolExcluded reports whether configuration withholds the tool, see ToolsetGroup.FilterTools.
+func (t *Toolset) IsToolExcluded(name string) bool {
+	return t.excludedTools[name]
+}
+
+// GetAllTools returns every tool in the toolset, whether or not it is enabled and including write tools
+// that are hidden in read-only mode.
+func (t *Toolset) GetAllTools() []server.ServerTool {
+	tools := make([]server.ServerTool, 0, len(t.readTools)+len(t.writeTools))
+	tools = append(tools, t.readTools...)
+	return append(tools, t.writeTools...)
+}
+
+// IsReadOnly reports whether the write tools of the toolset are withheld.
+func (t *Toolset) IsReadOnly() bool {
+	return t.readOnly
+}
+
+func (t *Toolset) RegisterTools(s *server.MCPServer) {
+	for _, tool := range t.GetActiveTools() {
 		s.AddTool(tool.Tool, tool.Handler)
 	}
-	if !t.readOnly {
-		for _, tool := range t.writeTools {
-			s.AddTool(tool.Tool, tool.Handler)
-		}
-	}
 }
 
 func (t *Toolset) AddResourceTemplates(templates ...ServerResourceTemplate) *Toolset {
@@ -173,7 +206,9 @@ func (t *Toolset) AddReadTools(tools ...server.ServerTool) *Toolset {
 }
 
 type ToolsetGroup struct {
-	Toolsets     map[string]*Toolset
+	Toolsets map[string]*Toolset
+	// Aliases are names that enable several toolsets at once
+	Aliases      map[string]ToolsetAlias
 	everythingOn bool
 	readOnly     bool
 }
@@ -181,6 +216,7 @@ type ToolsetGroup struct {
 func NewToolsetGroup(readOnly bool) *ToolsetGroup {
 	return &ToolsetGroup{
 		Toolsets:     make(map[string]*Toolset),
+		Aliases:      make(map[string]ToolsetAlias),
 		everythingOn: false,
 		readOnly:     readOnly,
 	}
@@ -193,6 +229,22 @@ func (tg *ToolsetGroup) AddToolset(ts *Toolset) {
 	tg.Toolsets[ts.Name] = ts
 }
 
+// ToolsetAlias is a name for a group of toolsets, enabling it enables all of them.
+type ToolsetAlias struct {
+	Name        string
+	Description string
+	Toolsets    []string
+}
+
+// AddAlias adds a name that enables all the given toolsets.
+func (tg *ToolsetGroup) AddAlias(name string, description string, toolsets ...string) {
+	tg.Aliases[name] = ToolsetAlias{
+		Name:        name,
+		Description: description,
+		Toolsets:    toolsets,
+	}
+}
+
 func NewToolset(name string, description string) *Toolset {
 	return &Toolset{
 		Name:        name,
@@ -241,6 +293,14 @@ func (tg *ToolsetGroup) EnableToolsets(names []string) error {
 }
 
 func (tg *ToolsetGroup) EnableToolset(name string) error {
+	if alias, ok := tg.Aliases[name]; ok {
+		for _, toolset := range alias.Toolsets {
+			if err := tg.EnableToolset(toolset); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	toolset, exists := tg.Toolsets[name]
 	if !exists {
 		return NewToolsetDoesNotExistError(name)
@@ -258,6 +318,46 @@ func (tg *ToolsetGroup) RegisterAll(s *server.MCPServer) {
 	}
 }
 
+// FilterTools narrows the tools of all toolsets down to the tools named in include, unless it is empty,
+// and withholds the tools named in exclude, which wins over include. Withheld tools are never registered,
+// not even when their toolset is enabled later. Names that none of the toolsets has are an error, so
+// FilterTools must be called once all toolsets are added.
+func (tg *ToolsetGroup) FilterTools(include, exclude []string) error {
+	known := map[string]bool{}
+	for _, toolset := range tg.Toolsets {
+		for _, tool := range toolset.GetAllTools() {
+			known[tool.Tool.Name] = true
+		}
+	}
+	included := map[string]bool{}
+	for _, name := range include {
+		if !known[name] {
+			return NewToolDoesNotExistError(name)
+		}
+		included[name] = true
+	}
+	excluded := map[string]bool{}
+	for _, name := range exclude {
+		if !known[name] {
+			return NewToolDoesNotExistError(name)
+		}
+		excluded[name] = true
+	}
+
+	for _, toolset := range tg.Toolsets {
+		for _, tool := range toolset.GetAllTools() {
+			name := tool.Tool.Name
+			if excluded[name] || (len(included) > 0 && !included[name]) {
+				if toolset.excludedTools == nil {
+					toolset.excludedTools = map[string]bool{}
+				}
+				toolset.excludedTools[name] = true
+			}
+		}
+	}
+	return nil
+}
+
 func (tg *ToolsetGroup) GetToolset(name string) (*Toolset, error) {
 	toolset, exists := tg.Toolsets[name]
 	if !exists {
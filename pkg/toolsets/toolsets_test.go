@@ -2,7 +2,11 @@ package toolsets
 
 import (
 	"errors"
+	"slices"
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 func TestNewToolsetGroupIsEmptyWithoutEverythingOn(t *testing.T) {
@@ -174,6 +178,33 @@ func TestEnableToolsets(t *testing.T) {
 	}
 }
 
+func TestEnableAlias(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("toolset1", "Feature 1"))
+	tsg.AddToolset(NewToolset("toolset2", "Feature 2"))
+	tsg.AddToolset(NewToolset("toolset3", "Feature 3"))
+	tsg.AddAlias("both", "Features 1 and 2", "toolset1", "toolset2")
+	tsg.AddAlias("broken", "Points at a missing toolset", "toolset1", "non-existent")
+
+	err := tsg.EnableToolsets([]string{"both"})
+	if err != nil {
+		t.Errorf("Expected no error when enabling alias, got: %v", err)
+	}
+
+	if !tsg.IsEnabled("toolset1") || !tsg.IsEnabled("toolset2") {
+		t.Error("Expected the toolsets of the alias to be enabled")
+	}
+
+	if tsg.IsEnabled("toolset3") {
+		t.Error("Expected toolset3 to remain disabled")
+	}
+
+	err = tsg.EnableToolset("broken")
+	if !errors.Is(err, NewToolsetDoesNotExistError("non-existent")) {
+		t.Errorf("Expected ToolsetDoesNotExistError for alias of a missing toolset, got: %v", err)
+	}
+}
+
 func TestEnableEverything(t *testing.T) {
 	tsg := NewToolsetGroup(false)
 
@@ -250,3 +281,113 @@ func TestToolsetGroup_GetToolset(t *testing.T) {
 		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
 	}
 }
+
+func TestGetAllToolsIncludesWriteToolsInReadOnlyMode(t *testing.T) {
+	readOnly := true
+	readWrite := false
+	readTool := NewServerTool(mcp.Tool{Name: "read", Annotations: mcp.ToolAnnotation{ReadOnlyHint: &readOnly}}, nil)
+	writeTool := NewServerTool(mcp.Tool{Name: "write", Annotations: mcp.ToolAnnotation{ReadOnlyHint: &readWrite}}, nil)
+
+	tsg := NewToolsetGroup(true)
+	toolset := NewToolset("test-toolset", "A test toolset").AddReadTools(readTool).AddWriteTools(writeTool)
+	tsg.AddToolset(toolset)
+
+	if !toolset.IsReadOnly() {
+		t.Fatal("Expected toolset to be read-only")
+	}
+	if len(toolset.GetAvailableTools()) != 1 {
+		t.Errorf("Expected 1 available tool, got %d", len(toolset.GetAvailableTools()))
+	}
+
+	all := toolset.GetAllTools()
+	if len(all) != 2 || all[0].Tool.Name != "read" || all[1].Tool.Name != "write" {
+		t.Errorf("Expected read and write tools, got %v", all)
+	}
+}
+
+func TestFilterTools(t *testing.T) {
+	readOnly := true
+	readWrite := false
+	newToolsetGroup := func() *ToolsetGroup {
+		tsg := NewToolsetGroup(false)
+		tsg.AddToolset(NewToolset("issues", "Issues").
+			AddReadTools(
+				NewServerTool(mcp.Tool{Name: "get_issue", Annotations: mcp.ToolAnnotation{ReadOnlyHint: &readOnly}}, nil),
+				NewServerTool(mcp.Tool{Name: "list_issues", Annotations: mcp.ToolAnnotation{ReadOnlyHint: &readOnly}}, nil),
+			).
+			AddWriteTools(NewServerTool(mcp.Tool{Name: "create_issue", Annotations: mcp.ToolAnnotation{ReadOnlyHint: &readWrite}}, nil)))
+		tsg.AddToolset(NewToolset("gists", "Gists").
+			AddReadTools(NewServerTool(mcp.Tool{Name: "list_gists", Annotations: mcp.ToolAnnotation{ReadOnlyHint: &readOnly}}, nil)))
+		return tsg
+	}
+	names := func(tools []server.ServerTool) []string {
+		names := []string{}
+		for _, tool := range tools {
+			names = append(names, tool.Tool.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name           string
+		include        []string
+		exclude        []string
+		expectedIssues []string
+		expectedGists  []string
+	}{
+		{
+			name:           "no filter",
+			expectedIssues: []string{"get_issue", "list_issues", "create_issue"},
+			expectedGists:  []string{"list_gists"},
+		},
+		{
+			name:           "include",
+			include:        []string{"get_issue", "list_issues"},
+			expectedIssues: []string{"get_issue", "list_issues"},
+			expectedGists:  []string{},
+		},
+		{
+			name:           "exclude",
+			exclude:        []string{"create_issue"},
+			expectedIssues: []string{"get_issue", "list_issues"},
+			expectedGists:  []string{"list_gists"},
+		},
+		{
+			name:           "exclude wins over include",
+			include:        []string{"get_issue", "create_issue"},
+			exclude:        []string{"create_issue"},
+			expectedIssues: []string{"get_issue"},
+			expectedGists:  []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsg := newToolsetGroup()
+			if err := tsg.FilterTools(tc.include, tc.exclude); err != nil {
+				t.Fatalf("Expected no error filtering tools, got: %v", err)
+			}
+			if err := tsg.EnableToolsets([]string{"issues", "gists"}); err != nil {
+				t.Fatalf("Expected no error enabling toolsets, got: %v", err)
+			}
+
+			if got := names(tsg.Toolsets["issues"].GetActiveTools()); !slices.Equal(got, tc.expectedIssues) {
+				t.Errorf("Expected issues tools %v, got %v", tc.expectedIssues, got)
+			}
+			if got := names(tsg.Toolsets["gists"].GetActiveTools()); !slices.Equal(got, tc.expectedGists) {
+				t.Errorf("Expected gists tools %v, got %v", tc.expectedGists, got)
+			}
+			// The catalog still lists withheld tools
+			if len(tsg.Toolsets["issues"].GetAllTools()) != 3 {
+				t.Errorf("Expected all 3 issues tools, got %d", len(tsg.Toolsets["issues"].GetAllTools()))
+			}
+		})
+	}
+
+	tsg := newToolsetGroup()
+	err := tsg.FilterTools(nil, []string{"delete_everything"})
+	var notExist *ToolDoesNotExistError
+	if !errors.As(err, &notExist) || notExist.Name != "delete_everything" {
+		t.Errorf("Expected ToolDoesNotExistError for an unknown tool, got: %v", err)
+	}
+}
@@ -0,0 +1,452 @@
+// Package tracing traces tool calls and the GitHub API requests they make with OpenTelemetry spans, and
+// exports them to a collector with OTLP over HTTP. A tool call continues the trace of the client when the
+// traceparent of its _meta says which, and passes the trace on to GitHub in the traceparent header, so
+// that a task of an agent can be followed from the client through the server into the GitHub API. Spans
+// are encoded in the JSON encoding of OTLP here rather than with the OpenTelemetry SDK, as the two kinds
+// of spans the server has don't need it.
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/metrics"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// EndpointEnv is the environment variable of OpenTelemetry that configures the collector, if the server
+// isn't given one.
+const EndpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+// HeadersEnv is the environment variable of OpenTelemetry that holds the headers of the requests to the
+// collector, such as its API key, as comma separated key=value pairs.
+const HeadersEnv = "OTEL_EXPORTER_OTLP_HEADERS"
+
+// tracesPath is where collectors take traces.
+const tracesPath = "/v1/traces"
+
+// exportInterval is how often the ended spans are exported.
+const exportInterval = 5 * time.Second
+
+// maxBatch is the number of ended spans at which they are exported right away.
+const maxBatch = 512
+
+// maxQueued is the number of ended spans beyond which spans are dropped, when the collector can't keep
+// up.
+const maxQueued = 4096
+
+// maxStatusMessage is the length above which the messages of error results are cut.
+const maxStatusMessage = 256
+
+// traceparentKey is the key of the W3C trace context in the _meta of requests and in the headers of
+// requests to GitHub.
+const traceparentKey = "traceparent"
+
+// Kind is the kind of a span, as OTLP numbers it.
+type Kind int
+
+const (
+	KindServer Kind = 2
+	KindClient Kind = 3
+)
+
+// statusError is the status code of spans of failed operations, as OTLP numbers it.
+const statusError = 2
+
+// Attribute is a key and a value of a span, which is a string, an int or a bool.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+func (a Attribute) MarshalJSON() ([]byte, error) {
+	value := map[string]any{}
+	switch v := a.Value.(type) {
+	case bool:
+		value["boolValue"] = v
+	case int:
+		// Integers are strings in the JSON encoding of OTLP
+		value["intValue"] = strconv.Itoa(v)
+	default:
+		value["stringValue"] = fmt.Sprint(v)
+	}
+	return json.Marshal(map[string]any{"key": a.Key, "value": value})
+}
+
+// Span is an operation of a trace.
+type Span struct {
+	tracer   *Tracer
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte
+	name     string
+	kind     Kind
+
+	mu         sync.Mutex
+	start, end time.Time
+	attributes []Attribute
+	failed     bool
+	message    string
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes = append(s.attributes, attributes...)
+}
+
+// SetError marks the span as failed with the given message.
+func (s *Span) SetError(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed = true
+	if len(message) > maxStatusMessage {
+		message = message[:maxStatusMessage] + "..."
+	}
+	s.message = message
+}
+
+// End ends the span, which is then exported.
+func (s *Span) End() {
+	s.mu.Lock()
+	s.end = s.tracer.now()
+	s.mu.Unlock()
+	s.tracer.queue(s)
+}
+
+// traceparent returns the W3C trace context that makes the span the parent of another.
+func (s *Span) traceparent() string {
+	return "00-" + hex.EncodeToString(s.traceID[:]) + "-" + hex.EncodeToString(s.spanID[:]) + "-01"
+}
+
+func (s *Span) MarshalJSON() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	span := map[string]any{
+		"traceId":           hex.EncodeToString(s.traceID[:]),
+		"spanId":            hex.EncodeToString(s.spanID[:]),
+		"name":              s.name,
+		"kind":              s.kind,
+		"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+		"attributes":        s.attributes,
+	}
+	if s.parentID != [8]byte{} {
+		span["parentSpanId"] = hex.EncodeToString(s.parentID[:])
+	}
+	if s.failed {
+		span["status"] = map[string]any{"code": statusError, "message": s.message}
+	}
+	return json.Marshal(span)
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a context in which the given span is the parent of new spans.
+func ContextWithSpan(ctx context.Context, s *Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, s)
+}
+
+// SpanFromContext returns the span of the context, or nil if there is none.
+func SpanFromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// parseTraceparent returns the trace and span IDs of a W3C trace context, and whether it is valid.
+func parseTraceparent(traceparent string) ([16]byte, [8]byte, bool) {
+	var traceID [16]byte
+	var spanID [8]byte
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return traceID, spanID, false
+	}
+	if _, err := hex.Decode(traceID[:], []byte(parts[1])); err != nil || traceID == [16]byte{} {
+		return traceID, spanID, false
+	}
+	if _, err := hex.Decode(spanID[:], []byte(parts[2])); err != nil || spanID == [8]byte{} {
+		return traceID, spanID, false
+	}
+	return traceID, spanID, true
+}
+
+// Tracer creates spans and exports them to a collector in batches. It is safe for concurrent use.
+type Tracer struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+	resource []Attribute
+	onError  func(error)
+	now      func() time.Time
+
+	mu      sync.Mutex
+	pending []*Span
+	dropped int
+
+	flush chan struct{}
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// New returns a tracer that exports to the collector at the given endpoint, such as
+// http://localhost:4318, in the name of the given version of the server. Failed exports are reported to
+// onError if set. Shutdown must be called to export the last spans.
+func New(endpoint, version string, onError func(error)) (*Tracer, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q, expected a URL such as http://localhost:4318", endpoint)
+	}
+	if !strings.HasSuffix(u.Path, tracesPath) {
+		u.Path = strings.TrimSuffix(u.Path, "/") + tracesPath
+	}
+	t := &Tracer{
+		endpoint: u.String(),
+		headers:  parseHeaders(os.Getenv(HeadersEnv)),
+		client:   &http.Client{Timeout: 10 * time.Second},
+		resource: []Attribute{
+			{Key: "service.name", Value: "github-mcp-server"},
+			{Key: "service.version", Value: version},
+		},
+		onError: onError,
+		now:     time.Now,
+		flush:   make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go t.run()
+	return t, nil
+}
+
+// parseHeaders parses headers given as comma separated key=value pairs.
+func parseHeaders(s string) map[string]string {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			continue
+		}
+		if unescaped, err := url.QueryUnescape(strings.TrimSpace(value)); err == nil {
+			value = unescaped
+		}
+		headers[strings.TrimSpace(key)] = value
+	}
+	return headers
+}
+
+// Start starts a span, which is a child of the span of the context if there is one.
+func (t *Tracer) Start(ctx context.Context, name string, kind Kind) (context.Context, *Span) {
+	s := &Span{tracer: t, name: name, kind: kind, start: t.now()}
+	if parent := SpanFromContext(ctx); parent != nil {
+		s.traceID, s.parentID = parent.traceID, parent.spanID
+	} else {
+		_, _ = rand.Read(s.traceID[:])
+	}
+	_, _ = rand.Read(s.spanID[:])
+	return ContextWithSpan(ctx, s), s
+}
+
+// startFrom starts a span that continues the trace of a W3C trace context, or a new trace if it isn't
+// valid.
+func (t *Tracer) startFrom(ctx context.Context, traceparent, name string, kind Kind) (context.Context, *Span) {
+	traceID, parentID, ok := parseTraceparent(traceparent)
+	if !ok || SpanFromContext(ctx) != nil {
+		return t.Start(ctx, name, kind)
+	}
+	s := &Span{tracer: t, traceID: traceID, parentID: parentID, name: name, kind: kind, start: t.now()}
+	_, _ = rand.Read(s.spanID[:])
+	return ContextWithSpan(ctx, s), s
+}
+
+func (t *Tracer) queue(s *Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.pending) >= maxQueued {
+		t.dropped++
+		return
+	}
+	t.pending = append(t.pending, s)
+	if len(t.pending) >= maxBatch {
+		select {
+		case t.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (t *Tracer) run() {
+	defer close(t.done)
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+		case <-t.flush:
+		}
+		t.report(t.export(context.Background()))
+	}
+}
+
+func (t *Tracer) report(err error) {
+	if err != nil && t.onError != nil {
+		t.onError(err)
+	}
+}
+
+// export sends the ended spans to the collector.
+func (t *Tracer) export(ctx context.Context) error {
+	t.mu.Lock()
+	spans, dropped := t.pending, t.dropped
+	t.pending, t.dropped = nil, 0
+	t.mu.Unlock()
+	if dropped > 0 && t.onError != nil {
+		t.onError(fmt.Errorf("dropped %d spans that the OTLP collector couldn't take in time", dropped))
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"resourceSpans": []any{map[string]any{
+			"resource": map[string]any{"attributes": t.resource},
+			"scopeSpans": []any{map[string]any{
+				"scope": map[string]any{"name": "github.com/github/github-mcp-server"},
+				"spans": spans,
+			}},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to export spans: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export spans: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to export spans: the OTLP collector answered %s", resp.Status)
+	}
+	return nil
+}
+
+// Shutdown stops exporting in the background and exports the spans that are left.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	close(t.stop)
+	<-t.done
+	return t.export(ctx)
+}
+
+// ToolHandlerMiddleware traces the tool calls. A call continues the trace that the traceparent of its
+// _meta names, and the spans of the GitHub API requests it makes are its children.
+func (t *Tracer) ToolHandlerMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var traceparent string
+		if meta := request.Params.Meta; meta != nil {
+			traceparent, _ = meta.AdditionalFields[traceparentKey].(string)
+		}
+		ctx, span := t.startFrom(ctx, traceparent, "tools/call "+request.Params.Name, KindServer)
+		defer span.End()
+		span.SetAttributes(
+			Attribute{Key: "mcp.method.name", Value: string(mcp.MethodToolsCall)},
+			Attribute{Key: "gen_ai.operation.name", Value: "execute_tool"},
+			Attribute{Key: "gen_ai.tool.name", Value: request.Params.Name},
+		)
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			span.SetAttributes(Attribute{Key: "mcp.session.id", Value: session.SessionID()})
+		}
+		arguments := request.GetArguments()
+		owner, _ := arguments["owner"].(string)
+		repo, _ := arguments["repo"].(string)
+		switch {
+		case owner != "" && repo != "":
+			span.SetAttributes(Attribute{Key: "github.repository", Value: owner + "/" + repo})
+		case owner != "":
+			span.SetAttributes(Attribute{Key: "github.owner", Value: owner})
+		}
+
+		result, err := next(ctx, request)
+		switch {
+		case err != nil:
+			span.SetError(err.Error())
+		case result != nil && result.IsError:
+			message := "error result"
+			if len(result.Content) > 0 {
+				if text, ok := result.Content[0].(mcp.TextContent); ok {
+					message = text.Text
+				}
+			}
+			span.SetError(message)
+		}
+		return result, err
+	}
+}
+
+// Transport is an http.RoundTripper that traces the requests to the GitHub API, and passes their trace on
+// to GitHub in the traceparent header.
+type Transport struct {
+	Transport http.RoundTripper
+	Tracer    *Tracer
+}
+
+// NewTransport wraps the given transport, falling back to http.DefaultTransport when it is nil.
+func NewTransport(transport http.RoundTripper, tracer *Tracer) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Transport{Transport: transport, Tracer: tracer}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := metrics.Endpoint(req.URL.Path)
+	_, span := t.Tracer.Start(req.Context(), req.Method+" "+endpoint, KindClient)
+	defer span.End()
+	full := *req.URL
+	// The query may carry the search terms of the user, it is left out like the credentials
+	full.RawQuery, full.User = "", nil
+	span.SetAttributes(
+		Attribute{Key: "http.request.method", Value: req.Method},
+		Attribute{Key: "http.route", Value: endpoint},
+		Attribute{Key: "url.full", Value: full.String()},
+		Attribute{Key: "server.address", Value: req.URL.Hostname()},
+	)
+
+	req = req.Clone(req.Context())
+	req.Header.Set(traceparentKey, span.traceparent())
+	resp, err := t.Transport.RoundTrip(req)
+	if err != nil {
+		span.SetError(err.Error())
+		return nil, err
+	}
+	span.SetAttributes(Attribute{Key: "http.response.status_code", Value: resp.StatusCode})
+	if id := resp.Header.Get("X-GitHub-Request-Id"); id != "" {
+		span.SetAttributes(Attribute{Key: "github.request_id", Value: id})
+	}
+	if resp.StatusCode >= 400 {
+		span.SetError(resp.Status)
+	}
+	return resp, nil
+}
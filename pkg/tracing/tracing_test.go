@@ -0,0 +1,191 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exported is a span as a collector receives it.
+type exported struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Kind         int    `json:"kind"`
+	Attributes   []struct {
+		Key   string         `json:"key"`
+		Value map[string]any `json:"value"`
+	} `json:"attributes"`
+	Status *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"status"`
+}
+
+func (s exported) attribute(key string) any {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			for _, v := range a.Value {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+// newCollector returns a tracer that exports to a collector, along with the spans the collector received.
+func newCollector(t *testing.T) (*Tracer, func() map[string]exported) {
+	var mu sync.Mutex
+	var spans []exported
+	var headers []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, tracesPath, r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var body struct {
+			ResourceSpans []struct {
+				Resource struct {
+					Attributes []json.RawMessage `json:"attributes"`
+				} `json:"resource"`
+				ScopeSpans []struct {
+					Spans []exported `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		mu.Lock()
+		defer mu.Unlock()
+		headers = append(headers, r.Header.Get("Api-Key"))
+		for _, rs := range body.ResourceSpans {
+			assert.Contains(t, string(rs.Resource.Attributes[0]), `"github-mcp-server"`)
+			for _, ss := range rs.ScopeSpans {
+				spans = append(spans, ss.Spans...)
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv(HeadersEnv, "api-key=secret%3D")
+
+	tracer, err := New(srv.URL, "1.2.3", func(err error) { t.Error(err) })
+	require.NoError(t, err)
+	return tracer, func() map[string]exported {
+		require.NoError(t, tracer.Shutdown(context.Background()))
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []string{"secret="}, headers)
+		byName := map[string]exported{}
+		for _, s := range spans {
+			byName[s.Name] = s
+		}
+		return byName
+	}
+}
+
+func TestToolCallsAndRequestsAreTraced(t *testing.T) {
+	var traceparents []string
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get(traceparentKey))
+		w.Header().Set("X-GitHub-Request-Id", "C0DE:1A2B")
+		if r.URL.Path == "/repos/octo-org/app/issues/2" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(github.Close)
+
+	tracer, collected := newCollector(t)
+	client := &http.Client{Transport: NewTransport(nil, tracer)}
+	handler := tracer.ToolHandlerMiddleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		for _, path := range []string{"/repos/octo-org/app/issues/1?state=open", "/repos/octo-org/app/issues/2"} {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, github.URL+path, nil)
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+		}
+		return mcp.NewToolResultError("issue 2 not found"), nil
+	})
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "get_issue"
+	request.Params.Arguments = map[string]any{"owner": "octo-org", "repo": "app"}
+	request.Params.Meta = &mcp.Meta{AdditionalFields: map[string]any{traceparentKey: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}}
+	_, err := handler(context.Background(), request)
+	require.NoError(t, err)
+
+	spans := collected()
+	require.Len(t, spans, 2, "both requests share a name")
+	call := spans["tools/call get_issue"]
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", call.TraceID, "the trace of the client goes on")
+	assert.Equal(t, "00f067aa0ba902b7", call.ParentSpanID)
+	assert.Equal(t, int(KindServer), call.Kind)
+	assert.Equal(t, "get_issue", call.attribute("gen_ai.tool.name"))
+	assert.Equal(t, "octo-org/app", call.attribute("github.repository"))
+	require.NotNil(t, call.Status)
+	assert.Equal(t, "issue 2 not found", call.Status.Message)
+
+	get := spans["GET /repos/{owner}/{repo}/issues/{id}"]
+	assert.Equal(t, call.TraceID, get.TraceID)
+	assert.Equal(t, call.SpanID, get.ParentSpanID)
+	assert.Equal(t, int(KindClient), get.Kind)
+	assert.Equal(t, github.URL+"/repos/octo-org/app/issues/2", get.attribute("url.full"), "the query is left out")
+	assert.Equal(t, "404", get.attribute("http.response.status_code"))
+	assert.Equal(t, "C0DE:1A2B", get.attribute("github.request_id"))
+	require.NotNil(t, get.Status, "client errors fail the request")
+
+	require.Len(t, traceparents, 2)
+	assert.Equal(t, "00-"+get.TraceID+"-"+get.SpanID+"-01", traceparents[1], "the trace is passed on to GitHub")
+}
+
+func TestCallsWithoutTraceparentStartTraces(t *testing.T) {
+	tracer, collected := newCollector(t)
+	handler := tracer.ToolHandlerMiddleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("{}"), nil
+	})
+	for _, traceparent := range []any{nil, "not a traceparent"} {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "get_me"
+		if traceparent != nil {
+			request.Params.Meta = &mcp.Meta{AdditionalFields: map[string]any{traceparentKey: traceparent}}
+		}
+		_, err := handler(context.Background(), request)
+		require.NoError(t, err)
+	}
+
+	call := collected()["tools/call get_me"]
+	assert.Len(t, call.TraceID, 32)
+	assert.Empty(t, call.ParentSpanID)
+	assert.Nil(t, call.Status)
+}
+
+func TestParseTraceparent(t *testing.T) {
+	_, _, ok := parseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.True(t, ok)
+	for _, traceparent := range []string{
+		"",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+	} {
+		_, _, ok := parseTraceparent(traceparent)
+		assert.False(t, ok, traceparent)
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, endpoint := range []string{"", "localhost:4318", "ftp://collector"} {
+		_, err := New(endpoint, "1.2.3", nil)
+		assert.Error(t, err, endpoint)
+	}
+	tracer, err := New("http://localhost:4318/", "1.2.3", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:4318/v1/traces", tracer.endpoint)
+	require.NoError(t, tracer.Shutdown(context.Background()))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"strings"
 
@@ -28,7 +29,7 @@ func TranslationHelper() (TranslationHelperFunc, func()) {
 	if err := v.ReadInConfig(); err != nil {
 		// ignore error if file not found as it is not required
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			log.Printf("Could not read JSON config: %v", err)
+			slog.Warn("could not read JSON config", "error", err)
 		}
 	}
 